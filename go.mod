@@ -17,11 +17,13 @@ require (
 	github.com/inconshreveable/log15 v0.0.0-20161013181240-944cbfb97b44
 	github.com/inconshreveable/mousetrap v1.0.0 // indirect
 	github.com/kormat/fmt15 v0.0.0-20181112140556-ee69fecb2656
+	github.com/lib/pq v1.10.9
 	github.com/lucas-clemente/quic-go v0.7.1-0.20190212114006-fd7246d7ed6e
 	github.com/marten-seemann/qtls v0.0.0-20190207043627-591c71538704 // indirect
 	github.com/mattn/go-colorable v0.1.4 // indirect
 	github.com/mattn/go-isatty v0.0.8
 	github.com/mattn/go-sqlite3 v1.9.1-0.20180719091609-b3511bfdd742
+	github.com/miekg/pkcs11 v1.1.1
 	github.com/onsi/ginkgo v1.10.3 // indirect
 	github.com/onsi/gomega v1.7.1 // indirect
 	github.com/opentracing/opentracing-go v1.1.0
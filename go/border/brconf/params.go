@@ -16,12 +16,25 @@ package brconf
 
 import (
 	"io"
+	"time"
 
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/config"
 	"github.com/scionproto/scion/go/lib/env"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/spath"
+	"github.com/scionproto/scion/go/lib/util"
 )
 
+// DefaultMasterKeyOverlap is the default time during which hop fields created
+// with the previous master key are still accepted after a key rollover. It
+// matches the maximum hop field lifetime.
+const DefaultMasterKeyOverlap = spath.MaxTTL * time.Second
+
+// DefaultDRKeyEpochDuration is the default duration of a DRKey epoch. It
+// matches the default of the control service.
+const DefaultDRKeyEpochDuration = 24 * time.Hour
+
 var _ config.Config = (*Config)(nil)
 
 // Config is the border router configuration that is loaded from file.
@@ -76,15 +89,40 @@ type BR struct {
 	// RollbackFailAction indicates the action that should be taken
 	// if the rollback fails.
 	RollbackFailAction FailAction
+	// MasterKeyOverlap is the time after a master key rollover during which
+	// hop fields created with the previous master key (Key1) are still
+	// accepted. A rollover is detected when the active master key (Key0)
+	// changes on reload. (default 24h)
+	MasterKeyOverlap util.DurWrap
+	// SCMPAuth enables the authentication of SCMP errors originated by the
+	// router with a DRKey MAC. (default false)
+	SCMPAuth bool
+	// DRKeyEpochDuration is the duration of a DRKey epoch. It must match the
+	// epoch duration of the control service. (default 24h)
+	DRKeyEpochDuration util.DurWrap
 }
 
 func (cfg *BR) InitDefaults() {
 	if cfg.RollbackFailAction != FailActionContinue {
 		cfg.RollbackFailAction = FailActionFatal
 	}
+	if cfg.MasterKeyOverlap.Duration == 0 {
+		cfg.MasterKeyOverlap.Duration = DefaultMasterKeyOverlap
+	}
+	if cfg.DRKeyEpochDuration.Duration == 0 {
+		cfg.DRKeyEpochDuration.Duration = DefaultDRKeyEpochDuration
+	}
 }
 
 func (cfg *BR) Validate() error {
+	if cfg.MasterKeyOverlap.Duration < 0 {
+		return serrors.New("MasterKeyOverlap must not be negative",
+			"value", cfg.MasterKeyOverlap)
+	}
+	if cfg.SCMPAuth && cfg.DRKeyEpochDuration.Duration < time.Second {
+		return serrors.New("DRKeyEpochDuration must be at least 1s",
+			"value", cfg.DRKeyEpochDuration)
+	}
 	return cfg.RollbackFailAction.Validate()
 }
 
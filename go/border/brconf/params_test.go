@@ -17,6 +17,7 @@ package brconf
 import (
 	"bytes"
 	"testing"
+	"time"
 
 	"github.com/BurntSushi/toml"
 	"github.com/stretchr/testify/assert"
@@ -43,6 +44,9 @@ func InitTestConfig(cfg *Config) {
 
 func InitTestBRConfig(cfg *BR) {
 	cfg.Profile = true
+	cfg.MasterKeyOverlap.Duration = time.Minute
+	cfg.SCMPAuth = true
+	cfg.DRKeyEpochDuration.Duration = time.Minute
 }
 
 func CheckTestConfig(t *testing.T, cfg *Config, id string) {
@@ -53,4 +57,7 @@ func CheckTestConfig(t *testing.T, cfg *Config, id string) {
 func CheckTestBRConfig(t *testing.T, cfg *BR) {
 	assert.False(t, cfg.Profile)
 	assert.Equal(t, FailActionFatal, cfg.RollbackFailAction)
+	assert.Equal(t, DefaultMasterKeyOverlap, cfg.MasterKeyOverlap.Duration)
+	assert.False(t, cfg.SCMPAuth)
+	assert.Equal(t, DefaultDRKeyEpochDuration, cfg.DRKeyEpochDuration.Duration)
 }
@@ -23,4 +23,18 @@ Profile = false
 # Action that should be taken when an error occurs during a context rollback.
 # (Fatal | Continue) (default Fatal)
 RollbackFailAction = "Fatal"
+
+# Time after a master key rollover during which hop fields created with the
+# previous master key (master1.key) are still accepted. A rollover is detected
+# when the active master key (master0.key) changes on reload. (default 24h)
+MasterKeyOverlap = "24h"
+
+# Authenticate SCMP errors originated by the router with a DRKey MAC. The
+# master key (master0.key) must be the same as the one of the control service.
+# (default false)
+SCMPAuth = false
+
+# Duration of a DRKey epoch. It must match the epoch duration of the control
+# service. (default 24h)
+DRKeyEpochDuration = "24h"
 `
@@ -22,11 +22,14 @@ import (
 	"errors"
 
 	"github.com/scionproto/scion/go/border/rcmn"
+	"github.com/scionproto/scion/go/border/rctx"
 	"github.com/scionproto/scion/go/border/rpkt"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/layers"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/scmp"
+	"github.com/scionproto/scion/go/lib/spkt"
+	"github.com/scionproto/scion/go/lib/spse/scmp_auth"
 )
 
 type pktErrorArgs struct {
@@ -139,5 +142,46 @@ func (r *Router) createSCMPErrorReply(rp *rpkt.RtrPkt, ct scmp.ClassType,
 	}
 	sp.Pld = scmp.PldFromQuotes(ct, info, rp.L4Type, rp.GetRaw)
 	sp.L4 = scmp.NewHdr(ct, sp.Pld.Len())
+	if rp.Ctx.SCMPAuth != nil {
+		if err := addSCMPAuth(rp.Ctx, sp); err != nil {
+			// Still send the reply, hosts decide whether unauthenticated SCMP
+			// errors are accepted.
+			rp.Error("Unable to authenticate SCMP error", "err", err)
+		}
+	}
 	return rp.CreateReply(sp)
 }
+
+// addSCMPAuth attaches a DRKey MAC to the SCMP error reply. The MAC is
+// computed with the AS to host key between the local AS and the destination
+// host. SCMPAuthDRKey extensions of the original packet are dropped.
+func addSCMPAuth(ctx *rctx.Ctx, sp *spkt.ScnPkt) error {
+	hdr := sp.L4.(*scmp.Hdr)
+	key, err := ctx.SCMPAuth.Lvl2Key(sp.SrcIA, sp.DstIA, sp.DstHost, hdr.Time())
+	if err != nil {
+		return err
+	}
+	input, err := scmp_auth.MACInput(sp.SrcIA, sp.DstIA, sp.SrcHost, sp.DstHost, hdr, sp.Pld)
+	if err != nil {
+		return err
+	}
+	mac, err := scmp_auth.ComputeMAC(common.RawBytes(key.Key), input)
+	if err != nil {
+		return err
+	}
+	extn := scmp_auth.NewDRKeyExtn()
+	if err := extn.SetDirection(scmp_auth.AsToHost); err != nil {
+		return err
+	}
+	if err := extn.SetMAC(mac); err != nil {
+		return err
+	}
+	e2e := make([]common.Extension, 0, len(sp.E2EExt)+1)
+	for _, e := range sp.E2EExt {
+		if _, ok := e.(*scmp_auth.DRKeyExtn); !ok {
+			e2e = append(e2e, e)
+		}
+	}
+	sp.E2EExt = append(e2e, extn)
+	return nil
+}
@@ -0,0 +1,57 @@
+// Copyright 2019 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/scionproto/scion/go/lib/prom"
+)
+
+type masterKey struct {
+	rollovers     prometheus.Counter
+	prevKeyHits   prometheus.Counter
+	overlapExpiry prometheus.Gauge
+}
+
+func newMasterKey() masterKey {
+	sub := "masterkey"
+	return masterKey{
+		rollovers: prom.NewCounter(Namespace, sub,
+			"rollovers_total", "Total number of master key rollovers."),
+		prevKeyHits: prom.NewCounter(Namespace, sub,
+			"prev_key_verifications_total",
+			"Total number of hop fields verified with the previous master key."),
+		overlapExpiry: prom.NewGauge(Namespace, sub,
+			"overlap_expiry_seconds",
+			"Unix time after which the previous master key is no longer accepted."),
+	}
+}
+
+// Rollovers returns the counter for master key rollovers.
+func (m *masterKey) Rollovers() prometheus.Counter {
+	return m.rollovers
+}
+
+// PrevKeyHits returns the counter for hop fields that were verified with the
+// previous master key.
+func (m *masterKey) PrevKeyHits() prometheus.Counter {
+	return m.prevKeyHits
+}
+
+// OverlapExpiry returns the gauge for the end of the master key overlap window.
+func (m *masterKey) OverlapExpiry() prometheus.Gauge {
+	return m.overlapExpiry
+}
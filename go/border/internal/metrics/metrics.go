@@ -45,10 +45,11 @@ const (
 
 // Metrics initialization.
 var (
-	Input   = newInput()
-	Output  = newOutput()
-	Process = newProcess()
-	Control = newControl()
+	Input     = newInput()
+	Output    = newOutput()
+	Process   = newProcess()
+	Control   = newControl()
+	MasterKey = newMasterKey()
 )
 
 type IntfLabels struct {
@@ -0,0 +1,86 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rctx
+
+import (
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/spse/scmp_auth"
+)
+
+// SCMPAuth derives the level 2 DRKeys that are used to authenticate SCMP
+// errors originated by the router. The router is part of the source AS, thus
+// it derives the keys directly from the AS master key. The secret value of the
+// current epoch is cached, because its derivation is expensive.
+type SCMPAuth struct {
+	factory drkey.SecretValueFactory
+	mtx     sync.Mutex
+	sv      drkey.SV
+}
+
+// NewSCMPAuth creates a key deriver for the given master key and epoch
+// duration. The epoch duration must match the one of the control service.
+func NewSCMPAuth(masterKey []byte, epochDuration time.Duration) *SCMPAuth {
+	return &SCMPAuth{
+		factory: drkey.SecretValueFactory{
+			MasterKey:     masterKey,
+			EpochDuration: epochDuration,
+		},
+	}
+}
+
+// Lvl2Key derives the AS to host key between the local AS and the destination
+// host that is valid at the provided time.
+func (a *SCMPAuth) Lvl2Key(srcIA, dstIA addr.IA, dstHost addr.HostAddr,
+	valTime time.Time) (drkey.Lvl2Key, error) {
+
+	sv, err := a.secretValue(valTime)
+	if err != nil {
+		return drkey.Lvl2Key{}, err
+	}
+	lvl1, err := drkey.DeriveLvl1(drkey.Lvl1Meta{
+		Epoch: sv.Epoch,
+		SrcIA: srcIA,
+		DstIA: dstIA,
+	}, sv)
+	if err != nil {
+		return drkey.Lvl2Key{}, err
+	}
+	return drkey.DeriveLvl2(drkey.Lvl2Meta{
+		KeyType:  drkey.AS2Host,
+		Protocol: scmp_auth.DRKeyProtocol,
+		Epoch:    sv.Epoch,
+		SrcIA:    srcIA,
+		DstIA:    dstIA,
+		DstHost:  dstHost,
+	}, lvl1)
+}
+
+func (a *SCMPAuth) secretValue(valTime time.Time) (drkey.SV, error) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	if a.sv.Key != nil && a.sv.Epoch.Equal(drkey.EpochAt(valTime, a.factory.EpochDuration)) {
+		return a.sv, nil
+	}
+	sv, err := a.factory.GetSecretValue(valTime)
+	if err != nil {
+		return drkey.SV{}, err
+	}
+	a.sv = sv
+	return sv, nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rctx
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/spse/scmp_auth"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestSCMPAuthLvl2Key(t *testing.T) {
+	srcIA := xtest.MustParseIA("1-ff00:0:110")
+	dstIA := xtest.MustParseIA("1-ff00:0:111")
+	dstHost := addr.HostFromIP(net.IP{192, 0, 2, 1})
+	now := time.Now()
+
+	auth := NewSCMPAuth(keyA, time.Hour)
+	for _, valTime := range []time.Time{now, now, now.Add(time.Hour)} {
+		key, err := auth.Lvl2Key(srcIA, dstIA, dstHost, valTime)
+		require.NoError(t, err)
+		// The key must match the key the control service of the source AS
+		// derives.
+		sv, err := drkey.SecretValueFactory{
+			MasterKey:     keyA,
+			EpochDuration: time.Hour,
+		}.GetSecretValue(valTime)
+		require.NoError(t, err)
+		lvl1, err := drkey.DeriveLvl1(drkey.Lvl1Meta{
+			Epoch: sv.Epoch,
+			SrcIA: srcIA,
+			DstIA: dstIA,
+		}, sv)
+		require.NoError(t, err)
+		expected, err := drkey.DeriveLvl2(drkey.Lvl2Meta{
+			KeyType:  drkey.AS2Host,
+			Protocol: scmp_auth.DRKeyProtocol,
+			Epoch:    sv.Epoch,
+			SrcIA:    srcIA,
+			DstIA:    dstIA,
+			DstHost:  dstHost,
+		}, lvl1)
+		require.NoError(t, err)
+		assert.True(t, expected.Equal(key), "valTime %s", valTime)
+	}
+	t.Run("invalid epoch duration", func(t *testing.T) {
+		_, err := NewSCMPAuth(keyA, 0).Lvl2Key(srcIA, dstIA, dstHost, now)
+		assert.Error(t, err)
+	})
+}
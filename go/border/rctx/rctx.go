@@ -18,22 +18,40 @@
 package rctx
 
 import (
+	"bytes"
+	"errors"
+	"hash"
 	"net"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/scionproto/scion/go/border/brconf"
+	"github.com/scionproto/scion/go/border/internal/metrics"
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/spath"
 )
 
 // Ctx is the main router context structure.
 type Ctx struct {
 	// Conf contains the router state for this context.
 	Conf *brconf.BRConf
-	// HFMacPool is the pool of Hop Field MAC generation instances.
+	// HFMacPool is the pool of Hop Field MAC generation instances. The
+	// instances are keyed with the active master key (Key0).
 	HFMacPool *sync.Pool
+	// PrevHFMacPool is the pool of Hop Field MAC verification instances keyed
+	// with the previous master key (Key1). It is nil if the previous key is not
+	// accepted.
+	PrevHFMacPool *sync.Pool
+	// PrevKeyExpiry is the time after which hop fields created with the
+	// previous master key are no longer accepted.
+	PrevKeyExpiry time.Time
+	// SCMPAuth derives the keys to authenticate SCMP errors originated by the
+	// router. It is nil if SCMP authentication is disabled.
+	SCMPAuth *SCMPAuth
 	// LockSockIn is a Sock for receiving packets from the local AS,
 	LocSockIn *Sock
 	// LocSockOut is a Sock for sending packets to the local AS,
@@ -59,19 +77,73 @@ func New(conf *brconf.BRConf) *Ctx {
 	return ctx
 }
 
-// initMacPool initializes the hop field mac pool.
-func (ctx *Ctx) InitMacPool() error {
-	hfMacFactory, err := scrypto.HFMacFactory(ctx.Conf.MasterKeys.Key0)
+// InitMacPool initializes the hop field mac pools. The active master key
+// (Key0) is used to create and verify hop fields. The previous master key
+// (Key1) is accepted for verification during the overlap window. The window
+// starts when the active master key differs from the one in oldCtx, or on
+// startup if oldCtx is nil. Otherwise, the window of oldCtx is kept.
+func (ctx *Ctx) InitMacPool(oldCtx *Ctx, overlap time.Duration) error {
+	pool, err := newMacPool(ctx.Conf.MasterKeys.Key0)
 	if err != nil {
 		return err
 	}
-	// Create a pool of MAC instances.
-	ctx.HFMacPool = &sync.Pool{
+	ctx.HFMacPool = pool
+	switch {
+	case oldCtx == nil:
+		ctx.PrevKeyExpiry = time.Now().Add(overlap)
+	case !bytes.Equal(oldCtx.Conf.MasterKeys.Key0, ctx.Conf.MasterKeys.Key0):
+		ctx.PrevKeyExpiry = time.Now().Add(overlap)
+		metrics.MasterKey.Rollovers().Inc()
+		log.Info("Master key rollover", "overlap", overlap)
+	default:
+		ctx.PrevKeyExpiry = oldCtx.PrevKeyExpiry
+	}
+	if len(ctx.Conf.MasterKeys.Key1) == 0 || !time.Now().Before(ctx.PrevKeyExpiry) {
+		ctx.PrevKeyExpiry = time.Time{}
+		metrics.MasterKey.OverlapExpiry().Set(0)
+		return nil
+	}
+	if ctx.PrevHFMacPool, err = newMacPool(ctx.Conf.MasterKeys.Key1); err != nil {
+		return err
+	}
+	metrics.MasterKey.OverlapExpiry().Set(float64(ctx.PrevKeyExpiry.Unix()))
+	return nil
+}
+
+// VerifyHopF verifies the MAC of the hop field with the active master key. If
+// that fails and the overlap window is still open, the previous master key is
+// tried as well.
+func (ctx *Ctx) VerifyHopF(hopF *spath.HopField, tsInt uint32, prev common.RawBytes) error {
+	hfmac := ctx.HFMacPool.Get().(hash.Hash)
+	err := hopF.Verify(hfmac, tsInt, prev)
+	ctx.HFMacPool.Put(hfmac)
+	if err == nil || !errors.Is(err, spath.ErrorHopFBadMac) {
+		return err
+	}
+	if ctx.PrevHFMacPool == nil || !time.Now().Before(ctx.PrevKeyExpiry) {
+		return err
+	}
+	prevMac := ctx.PrevHFMacPool.Get().(hash.Hash)
+	prevErr := hopF.Verify(prevMac, tsInt, prev)
+	ctx.PrevHFMacPool.Put(prevMac)
+	if prevErr != nil {
+		return err
+	}
+	metrics.MasterKey.PrevKeyHits().Inc()
+	return nil
+}
+
+// newMacPool creates a pool of MAC instances for the given key.
+func newMacPool(key []byte) (*sync.Pool, error) {
+	hfMacFactory, err := scrypto.HFMacFactory(key)
+	if err != nil {
+		return nil, err
+	}
+	return &sync.Pool{
 		New: func() interface{} {
 			return hfMacFactory()
 		},
-	}
-	return nil
+	}, nil
 }
 
 func (ctx *Ctx) ResolveSVC(svc addr.HostSVC) ([]*net.UDPAddr, error) {
@@ -0,0 +1,78 @@
+// Copyright 2019 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rctx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/border/brconf"
+	"github.com/scionproto/scion/go/lib/keyconf"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/spath"
+)
+
+var (
+	keyA = []byte("aaaaaaaaaaaaaaaa")
+	keyB = []byte("bbbbbbbbbbbbbbbb")
+	keyC = []byte("cccccccccccccccc")
+)
+
+func TestVerifyHopFRollover(t *testing.T) {
+	ts := uint32(time.Now().Unix())
+	signedA := mkHopF(t, keyA, ts)
+	signedB := mkHopF(t, keyB, ts)
+
+	// Initial context: A is active, C is the previous key.
+	oldCtx := newTestCtx(keyA, keyC)
+	require.NoError(t, oldCtx.InitMacPool(nil, time.Hour))
+	assert.NoError(t, oldCtx.VerifyHopF(signedA, ts, nil))
+	assert.Error(t, oldCtx.VerifyHopF(signedB, ts, nil))
+
+	// Rollover: B is active, A is the previous key.
+	ctx := newTestCtx(keyB, keyA)
+	require.NoError(t, ctx.InitMacPool(oldCtx, time.Hour))
+	assert.NoError(t, ctx.VerifyHopF(signedA, ts, nil))
+	assert.NoError(t, ctx.VerifyHopF(signedB, ts, nil))
+
+	t.Run("overlap window is kept without rollover", func(t *testing.T) {
+		newCtx := newTestCtx(keyB, keyA)
+		require.NoError(t, newCtx.InitMacPool(ctx, 0))
+		assert.Equal(t, ctx.PrevKeyExpiry, newCtx.PrevKeyExpiry)
+		assert.NoError(t, newCtx.VerifyHopF(signedA, ts, nil))
+	})
+	t.Run("previous key rejected after overlap", func(t *testing.T) {
+		newCtx := newTestCtx(keyB, keyA)
+		require.NoError(t, newCtx.InitMacPool(oldCtx, 0))
+		assert.Nil(t, newCtx.PrevHFMacPool)
+		assert.Error(t, newCtx.VerifyHopF(signedA, ts, nil))
+		assert.NoError(t, newCtx.VerifyHopF(signedB, ts, nil))
+	})
+}
+
+func newTestCtx(key0, key1 []byte) *Ctx {
+	return New(&brconf.BRConf{MasterKeys: keyconf.Master{Key0: key0, Key1: key1}})
+}
+
+func mkHopF(t *testing.T, key []byte, ts uint32) *spath.HopField {
+	f, err := scrypto.HFMacFactory(key)
+	require.NoError(t, err)
+	hopF := &spath.HopField{ConsIngress: 1, ConsEgress: 2, ExpTime: spath.DefaultHopFExpiry}
+	hopF.Mac = hopF.CalcMac(f(), ts, nil)
+	return hopF
+}
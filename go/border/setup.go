@@ -168,9 +168,13 @@ func (r *Router) setupCtxFromTopoUpdate(tx itopo.Transaction, err error) (bool,
 func (r *Router) setupNewContext(ctx *rctx.Ctx, tx *itopo.Transaction) error {
 	oldCtx := rctx.Get()
 	// Initialize Hop Field Mac Pool
-	if err := ctx.InitMacPool(); err != nil {
+	if err := ctx.InitMacPool(oldCtx, cfg.BR.MasterKeyOverlap.Duration); err != nil {
 		return err
 	}
+	if cfg.BR.SCMPAuth {
+		ctx.SCMPAuth = rctx.NewSCMPAuth(ctx.Conf.MasterKeys.Key0,
+			cfg.BR.DRKeyEpochDuration.Duration)
+	}
 	// TODO(roosd): Eventually, this will be configurable through brconfig.toml.
 	sockConf := brconf.SockConf{Default: PosixSock}
 	if err := r.setupNetAndTopo(ctx, oldCtx, sockConf, tx); err != nil {
@@ -15,6 +15,8 @@
 package beacon
 
 import (
+	"time"
+
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
@@ -55,6 +57,37 @@ func (b Beacon) Diversity(other Beacon) int {
 	return diff
 }
 
+// Latency returns the total latency of the beacon up to and including the
+// link it was received on, based on the static info extensions of the AS
+// entries. If the latency of any link or AS on the way is unknown, false is
+// returned.
+func (b Beacon) Latency() (time.Duration, bool) {
+	if b.Segment == nil {
+		return 0, false
+	}
+	var total time.Duration
+	for _, asEntry := range b.Segment.ASEntries {
+		ext := asEntry.Exts.StaticInfo
+		if ext == nil || ext.Latency.Inter == 0 {
+			return 0, false
+		}
+		total += time.Duration(ext.Latency.Inter) * time.Microsecond
+		hopF, err := asEntry.HopEntries[0].HopField()
+		if err != nil {
+			return 0, false
+		}
+		if hopF.ConsIngress == 0 {
+			continue
+		}
+		intra, ok := ext.Latency.IntraLatency(hopF.ConsIngress)
+		if !ok {
+			return 0, false
+		}
+		total += time.Duration(intra) * time.Microsecond
+	}
+	return total, true
+}
+
 func link(entry *seg.ASEntry) (addr.IA, common.IFIDType) {
 	return entry.IA(), entry.HopEntries[0].RemoteOutIF
 }
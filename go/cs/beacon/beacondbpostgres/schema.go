@@ -0,0 +1,62 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacondbpostgres
+
+import "github.com/scionproto/scion/go/lib/infra/modules/db"
+
+// Name identifies the beacon DB schema in the schema versions table.
+const Name = "beacondb"
+
+// Migrations are the schema migrations of the PostgreSQL backend. Existing
+// migrations must never be changed, schema changes are appended as new
+// migrations.
+var Migrations = db.Migrations{
+	{
+		Version:     1,
+		Description: "Initial schema",
+		Up: `CREATE TABLE Beacons(
+			RowID BIGSERIAL PRIMARY KEY,
+			SegID BYTEA UNIQUE NOT NULL,
+			FullID BYTEA UNIQUE NOT NULL,
+			StartIsd BIGINT NOT NULL,
+			StartAs BIGINT NOT NULL,
+			InIntfID BIGINT NOT NULL,
+			HopsLength INTEGER NOT NULL,
+			InfoTime BIGINT NOT NULL,
+			ExpirationTime BIGINT NOT NULL,
+			LastUpdated BIGINT NOT NULL,
+			Usage INTEGER NOT NULL,
+			Beacon BYTEA NOT NULL
+		);
+		CREATE TABLE IntfToBeacon(
+			IsdID BIGINT NOT NULL,
+			AsID BIGINT NOT NULL,
+			IntfID BIGINT NOT NULL,
+			BeaconRowID BIGINT NOT NULL REFERENCES Beacons(RowID) ON DELETE CASCADE,
+			PRIMARY KEY (BeaconRowID, IsdID, AsID, IntfID)
+		);
+		CREATE INDEX IntfToBeaconIntfIndex ON IntfToBeacon(IsdID, AsID, IntfID);
+		CREATE TABLE Revocations(
+			IsdID BIGINT NOT NULL,
+			AsID BIGINT NOT NULL,
+			IntfID BIGINT NOT NULL,
+			LinkType INTEGER NOT NULL,
+			IssuingTime BIGINT NOT NULL,
+			ExpirationTime BIGINT NOT NULL,
+			RawSignedRev BYTEA NOT NULL,
+			PRIMARY KEY (IsdID, AsID, IntfID)
+		);`,
+	},
+}
@@ -41,10 +41,11 @@ type Backend struct {
 }
 
 // New returns a new SQLite backend opening a database at the given path. If
-// no database exists a new database is be created. If the schema version of the
-// stored database is different from the one in schema.go, an error is returned.
+// no database exists a new database is be created. Pending migrations in
+// schema.go are applied. If the schema version of the stored database is newer
+// than the latest migration, an error is returned.
 func New(path string, ia addr.IA) (*Backend, error) {
-	db, err := db.NewSqlite(path, Schema, SchemaVersion)
+	db, err := db.NewSqlite(path, Migrations)
 	if err != nil {
 		return nil, err
 	}
@@ -78,7 +78,7 @@ func TestOpenNewer(t *testing.T) {
 	b, tmpF := setupDB(t)
 	defer cleanup(tmpF)
 	// Write a newer version
-	_, err := b.db.Exec(fmt.Sprintf("PRAGMA user_version = %d", SchemaVersion+1))
+	_, err := b.db.Exec(fmt.Sprintf("PRAGMA user_version = %d", Migrations.Latest()+1))
 	require.NoError(t, err)
 	b.db.Close()
 	b, err = New(tmpF, testIA)
@@ -14,45 +14,53 @@
 
 package beacondbsqlite
 
+import "github.com/scionproto/scion/go/lib/infra/modules/db"
+
+// Migrations are the schema migrations of the SQLite backend. The first
+// migration is the schema at the time migrations were introduced. Existing
+// migrations must never be changed, schema changes are appended as new
+// migrations.
+var Migrations = db.Migrations{
+	{
+		Version:     1,
+		Description: "Initial schema",
+		Up: `CREATE TABLE Beacons(
+			RowID INTEGER PRIMARY KEY,
+			SegID DATA UNIQUE NOT NULL,
+			FullID DATA UNIQUE NOT NULL,
+			StartIsd INTEGER NOT NULL,
+			StartAs INTEGER NOT NULL,
+			InIntfID INTEGER NOT NULL,
+			HopsLength INTEGER NOT NULL,
+			InfoTime INTEGER NOT NULL,
+			ExpirationTime INTEGER NOT NULL,
+			LastUpdated INTEGER NOT NULL,
+			Usage INTEGER NOT NULL,
+			Beacon BLOB NOT NULL
+		);
+		CREATE TABLE IntfToBeacon(
+			IsdID INTEGER NOT NULL,
+			AsID INTEGER NOT NULL,
+			IntfID INTEGER NOT NULL,
+			BeaconRowID INTEGER NOT NULL,
+			FOREIGN KEY (BeaconRowID) REFERENCES Beacons(RowID) ON DELETE CASCADE,
+			PRIMARY KEY (BeaconRowID, IsdID, AsID, IntfID)
+		);
+		CREATE TABLE Revocations(
+			IsdID INTEGER NOT NULL,
+			AsID INTEGER NOT NULL,
+			IntfID INTEGER NOT NULL,
+			LinkType INTEGER NOT NULL,
+			IssuingTime INTEGER NOT NULL,
+			ExpirationTime INTEGER NOT NULL,
+			RawSignedRev BLOB NOT NULL,
+			PRIMARY KEY (IsdID, AsID, IntfID)
+		);
+		`,
+	},
+}
+
 const (
-	// SchemaVersion is the version of the SQLite schema understood by this backend.
-	// Whenever changes to the schema are made, this version number should be increased
-	// to prevent data corruption between incompatible database schemas.
-	SchemaVersion = 1
-	// Schema is the SQLite database layout.
-	Schema = `CREATE TABLE Beacons(
-		RowID INTEGER PRIMARY KEY,
-		SegID DATA UNIQUE NOT NULL,
-		FullID DATA UNIQUE NOT NULL,
-		StartIsd INTEGER NOT NULL,
-		StartAs INTEGER NOT NULL,
-		InIntfID INTEGER NOT NULL,
-		HopsLength INTEGER NOT NULL,
-		InfoTime INTEGER NOT NULL,
-		ExpirationTime INTEGER NOT NULL,
-		LastUpdated INTEGER NOT NULL,
-		Usage INTEGER NOT NULL,
-		Beacon BLOB NOT NULL
-	);
-	CREATE TABLE IntfToBeacon(
-		IsdID INTEGER NOT NULL,
-		AsID INTEGER NOT NULL,
-		IntfID INTEGER NOT NULL,
-		BeaconRowID INTEGER NOT NULL,
-		FOREIGN KEY (BeaconRowID) REFERENCES Beacons(RowID) ON DELETE CASCADE,
-		PRIMARY KEY (BeaconRowID, IsdID, AsID, IntfID)
-	);
-	CREATE TABLE Revocations(
-		IsdID INTEGER NOT NULL,
-		AsID INTEGER NOT NULL,
-		IntfID INTEGER NOT NULL,
-		LinkType INTEGER NOT NULL,
-		IssuingTime INTEGER NOT NULL,
-		ExpirationTime INTEGER NOT NULL,
-		RawSignedRev BLOB NOT NULL,
-		PRIMARY KEY (IsdID, AsID, IntfID)
-	);
-	`
 	BeaconsTable      = "Beacons"
 	IntfToBeaconTable = "IntfToBeacon"
 	RevocationsTable  = "Revocations"
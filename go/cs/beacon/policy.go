@@ -188,6 +188,9 @@ type Policy struct {
 	Filter Filter `yaml:"Filter"`
 	// Type is the policy type.
 	Type PolicyType `yaml:"Type"`
+	// SelectionAlgorithm is the name of the algorithm that selects the best
+	// segments from the candidates.
+	SelectionAlgorithm string `yaml:"SelectionAlgorithm"`
 }
 
 // InitDefaults initializes the default values for unset fields.
@@ -202,6 +205,9 @@ func (p *Policy) InitDefaults() {
 		m := DefaultMaxExpTime
 		p.MaxExpTime = &m
 	}
+	if p.SelectionAlgorithm == "" {
+		p.SelectionAlgorithm = DefaultSelection
+	}
 	p.Filter.InitDefaults()
 }
 
@@ -224,6 +230,9 @@ func ParsePolicyYaml(b common.RawBytes, t PolicyType) (*Policy, error) {
 	if err := p.initDefaults(t); err != nil {
 		return nil, err
 	}
+	if _, err := NewSelectionAlgorithm(p.SelectionAlgorithm); err != nil {
+		return nil, err
+	}
 	return p, nil
 }
 
@@ -48,7 +48,8 @@ func TestLoadPolicyFromYaml(t *testing.T) {
 		SoMsg("AsBlackList", p.Filter.AsBlackList, ShouldResemble, []addr.AS{ia110.A, ia111.A})
 		SoMsg("IsdBlackList", p.Filter.IsdBlackList, ShouldResemble, []addr.ISD{1, 2, 3})
 		SoMsg("AllowIsdLoop", *p.Filter.AllowIsdLoop, ShouldBeTrue)
-
+		SoMsg("SelectionAlgorithm", p.SelectionAlgorithm, ShouldEqual,
+			beacon.KDisjointSelection)
 	}
 	Convey("Given a policy file with policy type set", t, func() {
 		fn := "testdata/typedPolicy.yml"
@@ -78,6 +79,19 @@ func TestLoadPolicyFromYaml(t *testing.T) {
 	})
 }
 
+func TestParsePolicyYamlSelectionAlgorithm(t *testing.T) {
+	Convey("The default selection algorithm is set if unspecified", t, func() {
+		p, err := beacon.ParsePolicyYaml([]byte("BestSetSize: 6"), beacon.PropPolicy)
+		SoMsg("err", err, ShouldBeNil)
+		SoMsg("SelectionAlgorithm", p.SelectionAlgorithm, ShouldEqual, beacon.DefaultSelection)
+	})
+	Convey("An unknown selection algorithm results in an error", t, func() {
+		_, err := beacon.ParsePolicyYaml([]byte("SelectionAlgorithm: unknown"),
+			beacon.PropPolicy)
+		SoMsg("err", err, ShouldNotBeNil)
+	})
+}
+
 func TestFilterApply(t *testing.T) {
 	Convey("Given a filter", t, func() {
 		f := beacon.Filter{
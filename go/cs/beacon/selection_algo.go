@@ -14,14 +14,106 @@
 
 package beacon
 
-import "math"
+import (
+	"math"
+	"sort"
+	"sync"
 
-type selectionAlgorithm interface {
+	"github.com/scionproto/scion/go/lib/common"
+)
+
+// Names of the built-in selection algorithms.
+const (
+	// DefaultSelection selects the shortest beacons and one most diverse
+	// beacon.
+	DefaultSelection = "default"
+	// KDisjointSelection selects the beacons that maximize link disjointness.
+	KDisjointSelection = "k-disjoint"
+	// LatencySelection selects the beacons with the lowest latency.
+	LatencySelection = "latency"
+	// StableSelection prefers the beacons selected in the previous round.
+	StableSelection = "stable"
+)
+
+// SelectionAlgorithm selects the best beacons from a set of candidates.
+// Custom algorithms can be registered with RegisterSelectionAlgorithm.
+type SelectionAlgorithm interface {
 	// SelectAndServe selects the n best beacons from the beacons channel and
-	// serves them on the results channel.
+	// serves them on the results channel. The candidates are ordered by
+	// length, shortest first. Errors read from the beacons channel should be
+	// forwarded on the results channel. The beacons are read-only. The
+	// algorithm might be called concurrently, e.g., for different origin ASes.
 	SelectAndServe(beacons <-chan BeaconOrErr, results chan<- BeaconOrErr, resultSize int)
 }
 
+// SelectionAlgorithmFactory creates a new instance of a selection algorithm.
+// Each policy of a beacon store uses its own instance.
+type SelectionAlgorithmFactory func() SelectionAlgorithm
+
+var (
+	selectionAlgorithmsMtx sync.RWMutex
+	selectionAlgorithms    = map[string]SelectionAlgorithmFactory{
+		DefaultSelection:   func() SelectionAlgorithm { return baseAlgo{} },
+		KDisjointSelection: func() SelectionAlgorithm { return disjointAlgo{} },
+		LatencySelection:   func() SelectionAlgorithm { return latencyAlgo{} },
+		StableSelection:    func() SelectionAlgorithm { return newStableAlgo() },
+	}
+)
+
+// RegisterSelectionAlgorithm registers a selection algorithm under the given
+// name, such that it can be referenced in the beacon policies. It must be
+// called before the policies are loaded. An error is returned if an algorithm
+// with the same name is already registered.
+func RegisterSelectionAlgorithm(name string, factory SelectionAlgorithmFactory) error {
+	selectionAlgorithmsMtx.Lock()
+	defer selectionAlgorithmsMtx.Unlock()
+	if _, ok := selectionAlgorithms[name]; ok {
+		return common.NewBasicError("Selection algorithm already registered", nil,
+			"name", name)
+	}
+	selectionAlgorithms[name] = factory
+	return nil
+}
+
+// NewSelectionAlgorithm creates a new instance of the selection algorithm
+// registered under the given name.
+func NewSelectionAlgorithm(name string) (SelectionAlgorithm, error) {
+	selectionAlgorithmsMtx.RLock()
+	defer selectionAlgorithmsMtx.RUnlock()
+	factory, ok := selectionAlgorithms[name]
+	if !ok {
+		return nil, common.NewBasicError("Unknown selection algorithm", nil, "name", name)
+	}
+	return factory(), nil
+}
+
+// SelectionAlgorithms returns the names of all registered selection
+// algorithms in ascending order.
+func SelectionAlgorithms() []string {
+	selectionAlgorithmsMtx.RLock()
+	defer selectionAlgorithmsMtx.RUnlock()
+	names := make([]string, 0, len(selectionAlgorithms))
+	for name := range selectionAlgorithms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// collectBeacons reads all beacons from the channel. Errors are forwarded to
+// the results channel.
+func collectBeacons(beacons <-chan BeaconOrErr, results chan<- BeaconOrErr) []Beacon {
+	var candidates []Beacon
+	for res := range beacons {
+		if res.Err != nil {
+			results <- res
+			continue
+		}
+		candidates = append(candidates, res.Beacon)
+	}
+	return candidates
+}
+
 // baseAlgo implements a very simple selection algorithm that optimizes for
 // short paths, but also tries to achieve some path diversity.
 type baseAlgo struct{}
@@ -0,0 +1,228 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacon_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/cs/beacon"
+	"github.com/scionproto/scion/go/cs/beacon/mock_beacon"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/xtest/graph"
+)
+
+func TestSelectionAlgorithmRegistry(t *testing.T) {
+	for _, name := range []string{beacon.DefaultSelection, beacon.KDisjointSelection,
+		beacon.LatencySelection, beacon.StableSelection} {
+
+		algo, err := beacon.NewSelectionAlgorithm(name)
+		assert.NoError(t, err, name)
+		assert.NotNil(t, algo, name)
+	}
+	_, err := beacon.NewSelectionAlgorithm("unknown")
+	assert.Error(t, err)
+
+	err = beacon.RegisterSelectionAlgorithm(beacon.DefaultSelection,
+		func() beacon.SelectionAlgorithm { return firstAlgo{} })
+	assert.Error(t, err)
+	err = beacon.RegisterSelectionAlgorithm("first",
+		func() beacon.SelectionAlgorithm { return firstAlgo{} })
+	require.NoError(t, err)
+	defer beacon.UnregisterSelectionAlgorithm("first")
+	assert.Contains(t, beacon.SelectionAlgorithms(), "first")
+
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+	g := graph.NewDefaultGraph(mctrl)
+	candidates := []beacon.BeaconOrErr{
+		testBeaconOrErr(g, graph.If_120_X_111_B, graph.If_111_A_112_X),
+		testBeaconOrErr(g, graph.If_130_B_120_A, graph.If_120_X_111_B, graph.If_111_A_112_X),
+	}
+	selected := selectWith(t, "first", 2, candidates)
+	assert.Equal(t, candidates[:1], selected)
+}
+
+func TestNewBeaconStoreUnknownAlgorithm(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+	policies := beacon.Policies{
+		Prop: beacon.Policy{SelectionAlgorithm: "unknown"},
+	}
+	_, err := beacon.NewBeaconStore(policies, mock_beacon.NewMockDB(mctrl))
+	assert.Error(t, err)
+	corePolicies := beacon.CorePolicies{
+		CoreReg: beacon.Policy{SelectionAlgorithm: "unknown"},
+	}
+	_, err = beacon.NewCoreBeaconStore(corePolicies, mock_beacon.NewMockDB(mctrl))
+	assert.Error(t, err)
+}
+
+func TestKDisjointSelection(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+	g := graph.NewDefaultGraph(mctrl)
+
+	stub := graph.If_210_X_220_X
+	candidates := []beacon.BeaconOrErr{
+		testBeaconOrErr(g, graph.If_130_A_110_X, graph.If_110_X_210_X, stub),
+		// Same beacon as the first beacon.
+		testBeaconOrErr(g, graph.If_130_A_110_X, graph.If_110_X_210_X, stub),
+		// Share the last link between 110 and 210.
+		testBeaconOrErr(g, graph.If_130_B_120_A, graph.If_120_A_110_X, graph.If_110_X_210_X, stub),
+		// Share no link.
+		testBeaconOrErr(g, graph.If_130_B_120_A, graph.If_120_B_220_X, graph.If_220_X_210_X, stub),
+		// Share no link with the first beacon, but longer than the previous
+		// beacon.
+		testBeaconOrErr(g, graph.If_130_B_111_A, graph.If_111_B_120_X, graph.If_120_B_220_X,
+			graph.If_220_X_210_X, stub),
+	}
+	selected := selectWith(t, beacon.KDisjointSelection, 3, candidates)
+	assert.Equal(t, []beacon.BeaconOrErr{candidates[0], candidates[3], candidates[2]}, selected)
+}
+
+func TestLatencySelection(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+	g := graph.NewDefaultGraph(mctrl)
+
+	stub := graph.If_111_A_112_X
+	candidates := []beacon.BeaconOrErr{
+		// Unknown latency.
+		testBeaconOrErr(g, graph.If_120_X_111_B, stub),
+		withLatency(t, testBeaconOrErr(g, graph.If_120_X_111_B, stub), 50, 0),
+		// Total latency: 3*10 inter + 2*5 intra.
+		withLatency(t, testBeaconOrErr(g, graph.If_130_B_120_A, graph.If_120_X_111_B, stub),
+			10, 5),
+	}
+	_, ok := candidates[0].Beacon.Latency()
+	assert.False(t, ok)
+	latency, ok := candidates[2].Beacon.Latency()
+	assert.True(t, ok)
+	assert.Equal(t, 40*time.Microsecond, latency)
+
+	selected := selectWith(t, beacon.LatencySelection, 2, candidates)
+	assert.Equal(t, []beacon.BeaconOrErr{candidates[2], candidates[1]}, selected)
+	selected = selectWith(t, beacon.LatencySelection, 3, candidates)
+	assert.Equal(t, []beacon.BeaconOrErr{candidates[2], candidates[1], candidates[0]}, selected)
+}
+
+func TestStableSelection(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+	g := graph.NewDefaultGraph(mctrl)
+
+	stub := graph.If_111_A_112_X
+	long := []common.IFIDType{graph.If_130_B_120_A, graph.If_120_X_111_B, stub}
+	short := []common.IFIDType{graph.If_130_B_111_A, stub}
+
+	db := mock_beacon.NewMockDB(mctrl)
+	policies := beacon.Policies{
+		Prop: beacon.Policy{BestSetSize: 1, SelectionAlgorithm: beacon.StableSelection},
+	}
+	store, err := beacon.NewBeaconStore(policies, db)
+	require.NoError(t, err)
+
+	first := testBeaconOrErr(g, long...)
+	expectCandidates(db, first)
+	assert.Equal(t, []beacon.BeaconOrErr{first}, propagate(t, store))
+
+	// The beacon with the same links is preferred over the shorter beacon,
+	// even if it was originated anew.
+	second := testBeaconOrErr(g, long...)
+	expectCandidates(db, testBeaconOrErr(g, short...), second)
+	assert.Equal(t, []beacon.BeaconOrErr{second}, propagate(t, store))
+}
+
+// firstAlgo selects the first candidates.
+type firstAlgo struct{}
+
+func (firstAlgo) SelectAndServe(beacons <-chan beacon.BeaconOrErr,
+	results chan<- beacon.BeaconOrErr, resultSize int) {
+
+	res, ok := <-beacons
+	if ok {
+		results <- res
+	}
+	for range beacons {
+	}
+}
+
+// selectWith runs the selection algorithm with the given name on the
+// candidates and returns the selected beacons.
+func selectWith(t *testing.T, algo string, bestSize int,
+	candidates []beacon.BeaconOrErr) []beacon.BeaconOrErr {
+
+	t.Helper()
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+	db := mock_beacon.NewMockDB(mctrl)
+	policies := beacon.Policies{
+		Prop: beacon.Policy{BestSetSize: bestSize, SelectionAlgorithm: algo},
+	}
+	store, err := beacon.NewBeaconStore(policies, db)
+	require.NoError(t, err)
+	expectCandidates(db, candidates...)
+	return propagate(t, store)
+}
+
+func expectCandidates(db *mock_beacon.MockDB, candidates ...beacon.BeaconOrErr) {
+	db.EXPECT().CandidateBeacons(gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any()).DoAndReturn(
+		func(_ ...interface{}) (<-chan beacon.BeaconOrErr, error) {
+			results := make(chan beacon.BeaconOrErr, len(candidates))
+			defer close(results)
+			for _, res := range candidates {
+				results <- res
+			}
+			return results, nil
+		},
+	)
+}
+
+func propagate(t *testing.T, store *beacon.Store) []beacon.BeaconOrErr {
+	t.Helper()
+	res, err := store.BeaconsToPropagate(context.Background())
+	require.NoError(t, err)
+	var selected []beacon.BeaconOrErr
+	for bOrErr := range res {
+		require.NoError(t, bOrErr.Err)
+		selected = append(selected, bOrErr)
+	}
+	return selected
+}
+
+// withLatency attaches a static info extension with the given inter and
+// intra latencies in microseconds to every AS entry of the beacon.
+func withLatency(t *testing.T, b beacon.BeaconOrErr, inter, intra uint32) beacon.BeaconOrErr {
+	t.Helper()
+	for _, asEntry := range b.Beacon.Segment.ASEntries {
+		hopF, err := asEntry.HopEntries[0].HopField()
+		require.NoError(t, err)
+		asEntry.Exts.StaticInfo = &seg.StaticInfoExtn{
+			Latency: seg.LatencyInfo{
+				Inter: inter,
+				Intra: []seg.InterfaceValue{{IfID: hopF.ConsIngress, Value: uint64(intra)}},
+			},
+		}
+	}
+	return b
+}
@@ -0,0 +1,96 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacon
+
+import (
+	"math"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+)
+
+// disjointAlgo implements a selection algorithm that maximizes the link
+// disjointness of the selected beacons. This is useful for ASes with many
+// parallel links to the same neighbor.
+type disjointAlgo struct{}
+
+// SelectAndServe selects the shortest beacon first. Every subsequent beacon
+// is the one whose links are used the fewest times by the already selected
+// beacons. Ties are broken in favor of the shorter beacon. Beacons that only
+// consist of links used by the selected beacons are only selected if there is
+// no other candidate left.
+func (disjointAlgo) SelectAndServe(beacons <-chan BeaconOrErr, results chan<- BeaconOrErr,
+	resultSize int) {
+
+	candidates := collectBeacons(beacons, results)
+	used := make(map[linkKey]int)
+	for i := 0; i < resultSize && len(candidates) > 0; i++ {
+		bestIdx, best := 0, disjointScore{shared: math.MaxInt32}
+		for j, candidate := range candidates {
+			if score := newDisjointScore(candidate, used); score.better(best) {
+				bestIdx, best = j, score
+			}
+		}
+		selected := candidates[bestIdx]
+		// Record the links before serving the beacon to avoid a data race.
+		for _, asEntry := range selected.Segment.ASEntries {
+			ia, ifid := link(asEntry)
+			used[linkKey{ia: ia, ifid: ifid}]++
+		}
+		candidates = append(candidates[:bestIdx], candidates[bestIdx+1:]...)
+		results <- BeaconOrErr{Beacon: selected}
+	}
+}
+
+type linkKey struct {
+	ia   addr.IA
+	ifid common.IFIDType
+}
+
+// disjointScore describes how disjoint a beacon is from the already selected
+// beacons.
+type disjointScore struct {
+	// shared is the number of times the links of the beacon are used by the
+	// selected beacons.
+	shared int
+	// unused is the number of links of the beacon that are not used by any
+	// selected beacon.
+	unused int
+	// length is the number of AS entries of the beacon.
+	length int
+}
+
+func newDisjointScore(b Beacon, used map[linkKey]int) disjointScore {
+	score := disjointScore{length: len(b.Segment.ASEntries)}
+	for _, asEntry := range b.Segment.ASEntries {
+		ia, ifid := link(asEntry)
+		n := used[linkKey{ia: ia, ifid: ifid}]
+		score.shared += n
+		if n == 0 {
+			score.unused++
+		}
+	}
+	return score
+}
+
+func (s disjointScore) better(other disjointScore) bool {
+	if (s.unused == 0) != (other.unused == 0) {
+		return s.unused != 0
+	}
+	if s.shared != other.shared {
+		return s.shared < other.shared
+	}
+	return s.length < other.length
+}
@@ -0,0 +1,54 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacon
+
+import (
+	"sort"
+	"time"
+)
+
+// latencyAlgo implements a selection algorithm that optimizes for low
+// latency, based on the static info extensions of the beacons.
+type latencyAlgo struct{}
+
+// SelectAndServe selects the beacons with the lowest latency. Beacons with
+// unknown latency are only selected if there are not enough beacons with
+// known latency. Ties are broken in favor of the shorter beacon.
+func (latencyAlgo) SelectAndServe(beacons <-chan BeaconOrErr, results chan<- BeaconOrErr,
+	resultSize int) {
+
+	candidates := collectBeacons(beacons, results)
+	type latencyBeacon struct {
+		beacon  Beacon
+		latency time.Duration
+		known   bool
+	}
+	lbs := make([]latencyBeacon, 0, len(candidates))
+	for _, b := range candidates {
+		latency, known := b.Latency()
+		lbs = append(lbs, latencyBeacon{beacon: b, latency: latency, known: known})
+	}
+	// The candidates are ordered by length, a stable sort keeps that order
+	// for ties.
+	sort.SliceStable(lbs, func(i, j int) bool {
+		if lbs[i].known != lbs[j].known {
+			return lbs[i].known
+		}
+		return lbs[i].latency < lbs[j].latency
+	})
+	for i := 0; i < resultSize && i < len(lbs); i++ {
+		results <- BeaconOrErr{Beacon: lbs[i].beacon}
+	}
+}
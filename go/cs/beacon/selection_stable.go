@@ -0,0 +1,99 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacon
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/scionproto/scion/go/lib/addr"
+)
+
+// stableAlgo implements a selection algorithm that avoids churn by preferring
+// the beacons that it selected in the previous round. The selection is
+// tracked per origin AS.
+type stableAlgo struct {
+	mtx sync.Mutex
+	// previous contains the keys of the previously selected beacons per
+	// origin AS.
+	previous map[addr.IA]map[string]struct{}
+}
+
+func newStableAlgo() *stableAlgo {
+	return &stableAlgo{previous: make(map[addr.IA]map[string]struct{})}
+}
+
+// SelectAndServe selects the candidates that were selected in the previous
+// round first. The remaining slots are filled with the shortest beacons.
+func (a *stableAlgo) SelectAndServe(beacons <-chan BeaconOrErr, results chan<- BeaconOrErr,
+	resultSize int) {
+
+	candidates := collectBeacons(beacons, results)
+	keys := make([]string, len(candidates))
+	for i, b := range candidates {
+		keys[i] = stableKey(b)
+	}
+
+	a.mtx.Lock()
+	selected := make([]bool, len(candidates))
+	var n int
+	for i, b := range candidates {
+		if n == resultSize {
+			break
+		}
+		if _, ok := a.previous[b.Segment.FirstIA()][keys[i]]; ok {
+			selected[i] = true
+			n++
+		}
+	}
+	for i := range candidates {
+		if n == resultSize {
+			break
+		}
+		if !selected[i] {
+			selected[i] = true
+			n++
+		}
+	}
+	// Replace the previous selection of all origins in the candidate set.
+	for _, b := range candidates {
+		a.previous[b.Segment.FirstIA()] = make(map[string]struct{})
+	}
+	for i, b := range candidates {
+		if selected[i] {
+			a.previous[b.Segment.FirstIA()][keys[i]] = struct{}{}
+		}
+	}
+	a.mtx.Unlock()
+
+	for i, b := range candidates {
+		if selected[i] {
+			results <- BeaconOrErr{Beacon: b}
+		}
+	}
+}
+
+// stableKey identifies the beacon by the links it traverses. In contrast to
+// the segment ID, it does not change when the beacon is originated anew.
+func stableKey(b Beacon) string {
+	parts := make([]string, 0, len(b.Segment.ASEntries)+1)
+	for _, asEntry := range b.Segment.ASEntries {
+		ia, ifid := link(asEntry)
+		parts = append(parts, fmt.Sprintf("%s#%d", ia, ifid))
+	}
+	parts = append(parts, fmt.Sprintf("%d", b.InIfId))
+	return strings.Join(parts, " ")
+}
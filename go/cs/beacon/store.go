@@ -50,10 +50,14 @@ func NewBeaconStore(policies Policies, db DB) (*Store, error) {
 	if err := policies.Validate(); err != nil {
 		return nil, err
 	}
+	algos, err := newSelectionAlgorithms(&policies.Prop, &policies.UpReg, &policies.DownReg)
+	if err != nil {
+		return nil, err
+	}
 	s := &Store{
 		baseStore: baseStore{
-			db:   db,
-			algo: baseAlgo{},
+			db:    db,
+			algos: algos,
 		},
 		policies: policies,
 	}
@@ -96,7 +100,7 @@ func (s *Store) getBeacons(ctx context.Context, policy *Policy) (<-chan BeaconOr
 	go func() {
 		defer log.LogPanicAndExit()
 		defer close(results)
-		s.algo.SelectAndServe(beacons, results, policy.BestSetSize)
+		s.algos[policy.Type].SelectAndServe(beacons, results, policy.BestSetSize)
 	}()
 	return results, nil
 }
@@ -129,10 +133,14 @@ func NewCoreBeaconStore(policies CorePolicies, db DB) (*CoreStore, error) {
 	if err := policies.Validate(); err != nil {
 		return nil, err
 	}
+	algos, err := newSelectionAlgorithms(&policies.Prop, &policies.CoreReg)
+	if err != nil {
+		return nil, err
+	}
 	s := &CoreStore{
 		baseStore: baseStore{
-			db:   db,
-			algo: baseAlgo{},
+			db:    db,
+			algos: algos,
 		},
 		policies: policies,
 	}
@@ -183,7 +191,7 @@ func (s *CoreStore) getBeacons(ctx context.Context, policy *Policy) (<-chan Beac
 		go func() {
 			defer log.LogPanicAndExit()
 			defer wg.Done()
-			s.algo.SelectAndServe(beacons, results, policy.BestSetSize)
+			s.algos[policy.Type].SelectAndServe(beacons, results, policy.BestSetSize)
 		}()
 	}
 	go func() {
@@ -214,7 +222,23 @@ func (s *CoreStore) MaxExpTime(policyType PolicyType) spath.ExpTimeType {
 type baseStore struct {
 	db     DB
 	usager usager
-	algo   selectionAlgorithm
+	// algos contains the selection algorithm of each policy.
+	algos map[PolicyType]SelectionAlgorithm
+}
+
+// newSelectionAlgorithms creates an instance of the configured selection
+// algorithm for each policy.
+func newSelectionAlgorithms(policies ...*Policy) (map[PolicyType]SelectionAlgorithm, error) {
+	algos := make(map[PolicyType]SelectionAlgorithm, len(policies))
+	for _, policy := range policies {
+		algo, err := NewSelectionAlgorithm(policy.SelectionAlgorithm)
+		if err != nil {
+			return nil, common.NewBasicError("Unable to create selection algorithm", err,
+				"policy", policy.Type)
+		}
+		algos[policy.Type] = algo
+	}
+	return algos, nil
 }
 
 // PreFilter indicates whether the beacon will be filtered on insert by
@@ -74,6 +74,9 @@ func (s *segExtender) extend(pseg *seg.PathSegment, inIfid, egIfid common.IFIDTy
 		MTU:        s.cfg.MTU,
 		HopEntries: hopEntries,
 	}
+	if s.cfg.StaticInfo != nil {
+		asEntry.Exts.StaticInfo = s.cfg.StaticInfo.Generate(egIfid)
+	}
 	if err := pseg.AddASEntry(asEntry, s.cfg.Signer); err != nil {
 		return err
 	}
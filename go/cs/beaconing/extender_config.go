@@ -42,6 +42,9 @@ type ExtenderConf struct {
 	IfidSize uint8
 	// GetMaxExpTime returns the maximum relative expiration time.
 	GetMaxExpTime func() spath.ExpTimeType
+	// StaticInfo is the static info configuration of the AS. If set, a static
+	// info extension is attached to the AS entries.
+	StaticInfo *StaticInfoCfg
 	// task contains an identifier specific to the task that uses the extender.
 	task string
 }
@@ -123,6 +123,7 @@ func TestExtenderExtend(t *testing.T) {
 				Mac:           mac,
 				Intfs:         intfs,
 				GetMaxExpTime: maxExpTimeFactory(beacon.DefaultMaxExpTime),
+				StaticInfo:    &StaticInfoCfg{Note: "static"},
 			}.new()
 			SoMsg("err", err, ShouldBeNil)
 			// Create path segment from description, if available.
@@ -159,6 +160,8 @@ func TestExtenderExtend(t *testing.T) {
 				SoMsg("IA", entry.IA(), ShouldResemble, topoProvider.Get().IA())
 				// Checks that inactive peers are ignored, even when provided.
 				SoMsg("HopEntries length", len(entry.HopEntries), ShouldEqual, 2)
+				SoMsg("StaticInfo", entry.Exts.StaticInfo, ShouldResemble,
+					&seg.StaticInfoExtn{Note: "static"})
 			})
 			infoF, err := pseg.InfoF()
 			SoMsg("infoF err", err, ShouldBeNil)
@@ -0,0 +1,264 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beaconing
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/proto"
+)
+
+// StaticInfoCfg is the operator-provided static information about the AS. It
+// is attached to every AS entry created by the extender in form of a static
+// info extension. All maps are keyed by interface ID. Intra-AS values between
+// two interfaces only need to be configured in one direction.
+type StaticInfoCfg struct {
+	Latency      map[common.IFIDType]InterfaceLatencies  `json:"Latency"`
+	Bandwidth    map[common.IFIDType]InterfaceBandwidths `json:"Bandwidth"`
+	LinkType     map[common.IFIDType]LinkType            `json:"LinkType"`
+	Geo          map[common.IFIDType]InterfaceGeodata    `json:"Geo"`
+	InternalHops map[common.IFIDType]InterfaceHops       `json:"InternalHops"`
+	Note         string                                  `json:"Note"`
+}
+
+// InterfaceLatencies contains the latencies of an interface.
+type InterfaceLatencies struct {
+	// Inter is the latency of the link attached to the interface.
+	Inter util.DurWrap `json:"Inter"`
+	// Intra contains the latencies to other interfaces in the AS.
+	Intra map[common.IFIDType]util.DurWrap `json:"Intra"`
+}
+
+// InterfaceBandwidths contains the bandwidths of an interface in Kbit/s.
+type InterfaceBandwidths struct {
+	// Inter is the bandwidth of the link attached to the interface.
+	Inter uint64 `json:"Inter"`
+	// Intra contains the bandwidths to other interfaces in the AS.
+	Intra map[common.IFIDType]uint64 `json:"Intra"`
+}
+
+// InterfaceGeodata is the location of an interface.
+type InterfaceGeodata struct {
+	Latitude  float32 `json:"Latitude"`
+	Longitude float32 `json:"Longitude"`
+	Address   string  `json:"Address"`
+}
+
+// InterfaceHops contains the number of AS internal hops from an interface.
+type InterfaceHops struct {
+	// Intra contains the number of internal hops to other interfaces in the AS.
+	Intra map[common.IFIDType]uint32 `json:"Intra"`
+}
+
+// LinkType is the type of an inter-AS link. It is (un)marshalled from and to
+// its lower case name, e.g., "direct", "multihop" or "opennet".
+type LinkType proto.StaticInfoExtn_LinkType
+
+func (lt *LinkType) UnmarshalText(text []byte) error {
+	s := strings.ToLower(string(text))
+	for _, t := range []proto.StaticInfoExtn_LinkType{
+		proto.StaticInfoExtn_LinkType_direct,
+		proto.StaticInfoExtn_LinkType_multiHop,
+		proto.StaticInfoExtn_LinkType_openNet,
+	} {
+		if strings.ToLower(t.String()) == s {
+			*lt = LinkType(t)
+			return nil
+		}
+	}
+	return common.NewBasicError("Unknown link type", nil, "type", string(text))
+}
+
+func (lt LinkType) MarshalText() ([]byte, error) {
+	return []byte(strings.ToLower(proto.StaticInfoExtn_LinkType(lt).String())), nil
+}
+
+// ParseStaticInfoCfg parses the static info configuration from raw json.
+func ParseStaticInfoCfg(b common.RawBytes) (*StaticInfoCfg, error) {
+	cfg := &StaticInfoCfg{}
+	if err := json.Unmarshal(b, cfg); err != nil {
+		return nil, common.NewBasicError("Unable to parse static info config", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// LoadStaticInfoCfg loads the static info configuration from a json file.
+func LoadStaticInfoCfg(path string) (*StaticInfoCfg, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, common.NewBasicError("Unable to read static info config", err, "path", path)
+	}
+	return ParseStaticInfoCfg(b)
+}
+
+// Validate checks that the configured values are in range.
+func (cfg *StaticInfoCfg) Validate() error {
+	for ifid, l := range cfg.Latency {
+		if err := validateLatency(l.Inter.Duration); err != nil {
+			return common.NewBasicError("Invalid inter latency", err, "ifid", ifid)
+		}
+		for other, d := range l.Intra {
+			if err := validateLatency(d.Duration); err != nil {
+				return common.NewBasicError("Invalid intra latency", err,
+					"ifid", ifid, "other", other)
+			}
+		}
+	}
+	for ifid, g := range cfg.Geo {
+		if g.Latitude < -90 || g.Latitude > 90 {
+			return common.NewBasicError("Latitude out of range", nil,
+				"ifid", ifid, "latitude", g.Latitude)
+		}
+		if g.Longitude < -180 || g.Longitude > 180 {
+			return common.NewBasicError("Longitude out of range", nil,
+				"ifid", ifid, "longitude", g.Longitude)
+		}
+	}
+	return nil
+}
+
+func validateLatency(d time.Duration) error {
+	if d < 0 {
+		return serrors.New("latency must not be negative")
+	}
+	if d/time.Microsecond > time.Duration(^uint32(0)) {
+		return serrors.New("latency too large")
+	}
+	return nil
+}
+
+// Generate creates the static info extension for an AS entry with the given
+// egress interface. A zero egress interface indicates a terminating AS entry,
+// in which case only the location of the interfaces and the note are included.
+func (cfg *StaticInfoCfg) Generate(egIfid common.IFIDType) *seg.StaticInfoExtn {
+	ext := &seg.StaticInfoExtn{
+		Geo:  cfg.generateGeo(),
+		Note: cfg.Note,
+	}
+	if egIfid == 0 {
+		return ext
+	}
+	ext.Latency.Inter = uint32(cfg.Latency[egIfid].Inter.Duration / time.Microsecond)
+	ext.Bandwidth.Inter = cfg.Bandwidth[egIfid].Inter
+	ext.LinkType = proto.StaticInfoExtn_LinkType(cfg.LinkType[egIfid])
+	for _, ifid := range cfg.interfaces() {
+		if d, ok := cfg.intraLatency(egIfid, ifid); ok {
+			ext.Latency.Intra = append(ext.Latency.Intra, seg.InterfaceValue{
+				IfID:  ifid,
+				Value: uint64(d / time.Microsecond),
+			})
+		}
+		if bw, ok := cfg.intraBandwidth(egIfid, ifid); ok {
+			ext.Bandwidth.Intra = append(ext.Bandwidth.Intra,
+				seg.InterfaceValue{IfID: ifid, Value: bw})
+		}
+		if hops, ok := cfg.internalHops(egIfid, ifid); ok {
+			ext.InternalHops = append(ext.InternalHops,
+				seg.InterfaceValue{IfID: ifid, Value: uint64(hops)})
+		}
+	}
+	return ext
+}
+
+func (cfg *StaticInfoCfg) generateGeo() []seg.GeoInfo {
+	var geo []seg.GeoInfo
+	for ifid, g := range cfg.Geo {
+		geo = append(geo, seg.GeoInfo{
+			IfID:      ifid,
+			Latitude:  g.Latitude,
+			Longitude: g.Longitude,
+			Address:   g.Address,
+		})
+	}
+	sort.Slice(geo, func(i, j int) bool { return geo[i].IfID < geo[j].IfID })
+	return geo
+}
+
+// The intra-AS lookups below are symmetric, i.e., a value configured for a->b
+// is also used for b->a. If both directions are configured, a->b is used.
+
+func (cfg *StaticInfoCfg) intraLatency(a, b common.IFIDType) (time.Duration, bool) {
+	if a == b {
+		return 0, false
+	}
+	if d, ok := cfg.Latency[a].Intra[b]; ok {
+		return d.Duration, true
+	}
+	d, ok := cfg.Latency[b].Intra[a]
+	return d.Duration, ok
+}
+
+func (cfg *StaticInfoCfg) intraBandwidth(a, b common.IFIDType) (uint64, bool) {
+	if a == b {
+		return 0, false
+	}
+	if bw, ok := cfg.Bandwidth[a].Intra[b]; ok {
+		return bw, true
+	}
+	bw, ok := cfg.Bandwidth[b].Intra[a]
+	return bw, ok
+}
+
+func (cfg *StaticInfoCfg) internalHops(a, b common.IFIDType) (uint32, bool) {
+	if a == b {
+		return 0, false
+	}
+	if hops, ok := cfg.InternalHops[a].Intra[b]; ok {
+		return hops, true
+	}
+	hops, ok := cfg.InternalHops[b].Intra[a]
+	return hops, ok
+}
+
+// interfaces returns all interfaces that are mentioned in the intra-AS
+// sections of the configuration in ascending order.
+func (cfg *StaticInfoCfg) interfaces() []common.IFIDType {
+	set := make(map[common.IFIDType]struct{})
+	for ifid, l := range cfg.Latency {
+		set[ifid] = struct{}{}
+		for other := range l.Intra {
+			set[other] = struct{}{}
+		}
+	}
+	for ifid, bw := range cfg.Bandwidth {
+		set[ifid] = struct{}{}
+		for other := range bw.Intra {
+			set[other] = struct{}{}
+		}
+	}
+	for ifid, hops := range cfg.InternalHops {
+		set[ifid] = struct{}{}
+		for other := range hops.Intra {
+			set[other] = struct{}{}
+		}
+	}
+	ifids := make([]common.IFIDType, 0, len(set))
+	for ifid := range set {
+		ifids = append(ifids, ifid)
+	}
+	sort.Slice(ifids, func(i, j int) bool { return ifids[i] < ifids[j] })
+	return ifids
+}
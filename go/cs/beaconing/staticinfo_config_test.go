@@ -0,0 +1,83 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beaconing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/proto"
+)
+
+func TestLoadStaticInfoCfg(t *testing.T) {
+	cfg, err := LoadStaticInfoCfg("testdata/staticinfo.json")
+	require.NoError(t, err)
+	assert.Equal(t, LinkType(proto.StaticInfoExtn_LinkType_multiHop), cfg.LinkType[2])
+	assert.Equal(t, "Test AS", cfg.Note)
+
+	_, err = LoadStaticInfoCfg("testdata/nonexistent.json")
+	assert.Error(t, err)
+}
+
+func TestParseStaticInfoCfgInvalid(t *testing.T) {
+	tests := map[string]string{
+		"unknown link type":  `{"LinkType": {"1": "wormhole"}}`,
+		"negative latency":   `{"Latency": {"1": {"Inter": "-1ms"}}}`,
+		"latitude too large": `{"Geo": {"1": {"Latitude": 91}}}`,
+		"longitude too low":  `{"Geo": {"1": {"Longitude": -181}}}`,
+	}
+	for name, raw := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseStaticInfoCfg([]byte(raw))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestStaticInfoCfgGenerate(t *testing.T) {
+	cfg, err := LoadStaticInfoCfg("testdata/staticinfo.json")
+	require.NoError(t, err)
+	geo := []seg.GeoInfo{
+		{IfID: 1, Latitude: 47.3769, Longitude: 8.5417, Address: "Zurich"},
+		{IfID: 2, Latitude: 46.948, Longitude: 7.4474, Address: "Bern"},
+	}
+
+	t.Run("egress interface set", func(t *testing.T) {
+		expected := &seg.StaticInfoExtn{
+			Latency: seg.LatencyInfo{
+				Intra: []seg.InterfaceValue{{IfID: 1, Value: 1000}, {IfID: 3, Value: 3000}},
+				Inter: 5000,
+			},
+			Bandwidth: seg.BandwidthInfo{
+				Intra: []seg.InterfaceValue{{IfID: 1, Value: 1000000}},
+			},
+			Geo:          geo,
+			LinkType:     proto.StaticInfoExtn_LinkType_multiHop,
+			InternalHops: []seg.InterfaceValue{{IfID: 1, Value: 2}, {IfID: 3, Value: 4}},
+			Note:         "Test AS",
+		}
+		assert.Equal(t, expected, cfg.Generate(2))
+	})
+	t.Run("terminating entry", func(t *testing.T) {
+		expected := &seg.StaticInfoExtn{
+			Geo:  geo,
+			Note: "Test AS",
+		}
+		assert.Equal(t, expected, cfg.Generate(0))
+	})
+}
@@ -22,6 +22,7 @@ import (
 	"io"
 
 	"github.com/scionproto/scion/go/cs/beacon"
+	"github.com/scionproto/scion/go/cs/beacon/beacondbpostgres"
 	"github.com/scionproto/scion/go/cs/beacon/beacondbsqlite"
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
@@ -40,6 +41,8 @@ const (
 	backendNone Backend = ""
 	// BackendSqlite indicates an sqlite backend.
 	BackendSqlite Backend = "sqlite"
+	// BackendPostgres indicates a PostgreSQL backend.
+	BackendPostgres Backend = "postgres"
 )
 
 const (
@@ -100,7 +103,7 @@ func (cfg *BeaconDBConf) Validate() error {
 
 func (cfg *BeaconDBConf) validateBackend() error {
 	switch cfg.Backend() {
-	case BackendSqlite:
+	case BackendSqlite, BackendPostgres:
 		return nil
 	case backendNone:
 		return serrors.New("No backend set")
@@ -134,6 +137,8 @@ func (cfg *BeaconDBConf) New(ia addr.IA) (beacon.DB, error) {
 	switch cfg.Backend() {
 	case BackendSqlite:
 		bdb, err = beacondbsqlite.New(cfg.Connection(), ia)
+	case BackendPostgres:
+		bdb, err = beacondbpostgres.New(cfg.Connection(), ia)
 	default:
 		return nil, common.NewBasicError("Unsupported backend", nil, "backend", cfg.Backend())
 	}
@@ -40,6 +40,12 @@ RevTTL = "10s"
 # The amount of time before the expiry of an existing revocation where the revoker can reissue a
 # new revocation. (default 5s)
 RevOverlap = "5s"
+
+# The file path for the static info configuration. It contains the latency,
+# bandwidth, geographic location, link type and internal hops of the
+# interfaces, and a note about the AS. In case of the empty string, no static
+# info extension is added to the beacons. (default "")
+StaticInfoConfig = ""
 `
 
 const PoliciesSample = `
@@ -16,6 +16,7 @@
 package config
 
 import (
+	"fmt"
 	"io"
 	"time"
 
@@ -63,6 +64,30 @@ const (
 	ReissReqRate = 10 * time.Second
 	// ReissueReqTimeout is the default timeout of a reissue request.
 	ReissueReqTimeout = 5 * time.Second
+	// LeafValidity is the default maximum validity period of AS certificates
+	// issued by an issuer AS.
+	LeafValidity = 3 * 24 * time.Hour
+	// TRCCheckInterval is the default interval between two consecutive checks
+	// for newer TRC versions.
+	TRCCheckInterval = 10 * time.Minute
+	// KeyBackendTimeout is the default timeout for a single request to the
+	// external signing process.
+	KeyBackendTimeout = time.Second
+	// DRKeyEpochDuration is the default duration of a DRKey epoch.
+	DRKeyEpochDuration = 24 * time.Hour
+	// DRKeyTimeout is the default timeout for handling a single DRKey request.
+	DRKeyTimeout = 5 * time.Second
+)
+
+// Key backend types.
+const (
+	// KeyBackendFile loads the private keys from the keys directory in the
+	// config directory.
+	KeyBackendFile = "file"
+	// KeyBackendProcess delegates signing to an external signing process.
+	KeyBackendProcess = "process"
+	// KeyBackendPKCS11 signs with private keys held by a PKCS#11 token.
+	KeyBackendPKCS11 = "pkcs11"
 )
 
 var (
@@ -174,6 +199,9 @@ type BSConfig struct {
 	// RevOverlap specifies for how long before the expiry of an existing revocation the revoker
 	// can reissue a new revocation. (default 5s)
 	RevOverlap util.DurWrap
+	// StaticInfoConfig is the file path of the static info configuration. If
+	// set, the static info extension is added to the created AS entries.
+	StaticInfoConfig string
 	// Policies contains the policy files.
 	Policies Policies
 }
@@ -256,10 +284,20 @@ type CSConfig struct {
 	ReissueRate util.DurWrap
 	// ReissueTimeout is the timeout for resissue request.
 	ReissueTimeout util.DurWrap
+	// LeafValidity is the maximum validity period of AS certificates issued
+	// in response to reissue requests.
+	LeafValidity util.DurWrap
+	// TRCCheckInterval is the interval between two consecutive checks for
+	// newer TRC versions of the known ISDs.
+	TRCCheckInterval util.DurWrap
 	// AutomaticRenewal whether automatic reissuing is enabled.
 	AutomaticRenewal bool
 	// DisableCorePush disables the core pusher task.
 	DisableCorePush bool
+	// KeyBackend configures the backend that holds the private keys.
+	KeyBackend KeyBackendConf `toml:"key_backend"`
+	// DRKey configures the DRKey service.
+	DRKey DRKeyConf `toml:"drkey"`
 }
 
 func (cfg *CSConfig) InitDefaults() {
@@ -275,6 +313,13 @@ func (cfg *CSConfig) InitDefaults() {
 	if cfg.ReissueTimeout.Duration == 0 {
 		cfg.ReissueTimeout.Duration = ReissueReqTimeout
 	}
+	if cfg.LeafValidity.Duration == 0 {
+		cfg.LeafValidity.Duration = LeafValidity
+	}
+	if cfg.TRCCheckInterval.Duration == 0 {
+		cfg.TRCCheckInterval.Duration = TRCCheckInterval
+	}
+	config.InitAll(&cfg.KeyBackend, &cfg.DRKey)
 }
 
 func (cfg *CSConfig) Validate() error {
@@ -290,17 +335,137 @@ func (cfg *CSConfig) Validate() error {
 	if cfg.ReissueTimeout.Duration == 0 {
 		return serrors.New("ReissueTimeout must not be zero")
 	}
-	return nil
+	if cfg.LeafValidity.Duration == 0 {
+		return serrors.New("LeafValidity must not be zero")
+	}
+	if cfg.TRCCheckInterval.Duration == 0 {
+		return serrors.New("TRCCheckInterval must not be zero")
+	}
+	if cfg.AutomaticRenewal && cfg.KeyBackend.Type != KeyBackendFile {
+		return serrors.New("AutomaticRenewal requires the file key backend",
+			"type", cfg.KeyBackend.Type)
+	}
+	if cfg.DRKey.Enabled && cfg.KeyBackend.Type != KeyBackendFile {
+		return serrors.New("DRKey requires the file key backend",
+			"type", cfg.KeyBackend.Type)
+	}
+	return config.ValidateAll(&cfg.KeyBackend, &cfg.DRKey)
 }
 
-func (cfg *CSConfig) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
+func (cfg *CSConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
 	config.WriteString(dst, CSSample)
+	config.WriteSample(dst, path, ctx, &cfg.KeyBackend, &cfg.DRKey)
 }
 
 func (cfg *CSConfig) ConfigName() string {
 	return "cs"
 }
 
+var _ config.Config = (*KeyBackendConf)(nil)
+
+// KeyBackendConf configures the backend that holds the private keys used for
+// signing control plane messages and certificates.
+type KeyBackendConf struct {
+	// Type is the key backend type. (file|process|pkcs11)
+	Type string
+	// Socket is the unix socket of the external signing process. It is only
+	// used by the process backend.
+	Socket string
+	// Timeout is the timeout for a single request to the external signing
+	// process.
+	Timeout util.DurWrap
+	// Module is the path to the PKCS#11 module. It is only used by the pkcs11
+	// backend.
+	Module string
+	// TokenLabel is the label of the PKCS#11 token that holds the private
+	// keys. It is only used by the pkcs11 backend.
+	TokenLabel string
+	// PIN is the user PIN of the PKCS#11 token. It is only used by the pkcs11
+	// backend.
+	PIN string
+}
+
+func (cfg *KeyBackendConf) InitDefaults() {
+	if cfg.Type == "" {
+		cfg.Type = KeyBackendFile
+	}
+	initDurWrap(&cfg.Timeout, KeyBackendTimeout)
+}
+
+func (cfg *KeyBackendConf) Validate() error {
+	switch cfg.Type {
+	case KeyBackendFile:
+		return nil
+	case KeyBackendProcess:
+		if cfg.Socket == "" {
+			return serrors.New("Socket must be set for the process key backend")
+		}
+		return nil
+	case KeyBackendPKCS11:
+		if cfg.Module == "" {
+			return serrors.New("Module must be set for the pkcs11 key backend")
+		}
+		if cfg.TokenLabel == "" {
+			return serrors.New("TokenLabel must be set for the pkcs11 key backend")
+		}
+		return nil
+	default:
+		return serrors.New("unsupported key backend", "type", cfg.Type)
+	}
+}
+
+func (cfg *KeyBackendConf) Sample(dst io.Writer, _ config.Path, _ config.CtxMap) {
+	config.WriteString(dst, KeyBackendSample)
+}
+
+func (cfg *KeyBackendConf) ConfigName() string {
+	return "key_backend"
+}
+
+var _ config.Config = (*DRKeyConf)(nil)
+
+// DRKeyConf configures the DRKey service.
+type DRKeyConf struct {
+	// Enabled enables the DRKey service.
+	Enabled bool
+	// EpochDuration is the duration of a DRKey epoch.
+	EpochDuration util.DurWrap
+	// Lvl1DB is the file path of the level 1 key database.
+	Lvl1DB string
+	// Timeout is the timeout for handling a single DRKey request.
+	Timeout util.DurWrap
+}
+
+func (cfg *DRKeyConf) InitDefaults() {
+	initDurWrap(&cfg.EpochDuration, DRKeyEpochDuration)
+	initDurWrap(&cfg.Timeout, DRKeyTimeout)
+}
+
+func (cfg *DRKeyConf) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.EpochDuration.Duration < time.Second {
+		return serrors.New("EpochDuration must be at least 1s",
+			"duration", cfg.EpochDuration)
+	}
+	if cfg.Lvl1DB == "" {
+		return serrors.New("Lvl1DB must be set")
+	}
+	if cfg.Timeout.Duration == 0 {
+		return serrors.New("Timeout must not be zero")
+	}
+	return nil
+}
+
+func (cfg *DRKeyConf) Sample(dst io.Writer, _ config.Path, ctx config.CtxMap) {
+	config.WriteString(dst, fmt.Sprintf(DRKeySample, ctx[config.ID]))
+}
+
+func (cfg *DRKeyConf) ConfigName() string {
+	return "drkey"
+}
+
 var _ config.Config = (*PSConfig)(nil)
 
 type PSConfig struct {
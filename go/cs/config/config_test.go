@@ -16,6 +16,7 @@ package config
 
 import (
 	"bytes"
+	"fmt"
 	"testing"
 	"time"
 
@@ -56,6 +57,97 @@ func TestInvalidTTL(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestKeyBackendValidate(t *testing.T) {
+	tests := map[string]struct {
+		Modify       func(cfg *CSConfig)
+		ErrAssertion assert.ErrorAssertionFunc
+	}{
+		"default": {
+			Modify:       func(*CSConfig) {},
+			ErrAssertion: assert.NoError,
+		},
+		"process": {
+			Modify: func(cfg *CSConfig) {
+				cfg.KeyBackend.Type = KeyBackendProcess
+				cfg.KeyBackend.Socket = "/run/signer.sock"
+			},
+			ErrAssertion: assert.NoError,
+		},
+		"process without socket": {
+			Modify: func(cfg *CSConfig) {
+				cfg.KeyBackend.Type = KeyBackendProcess
+			},
+			ErrAssertion: assert.Error,
+		},
+		"process with automatic renewal": {
+			Modify: func(cfg *CSConfig) {
+				cfg.KeyBackend.Type = KeyBackendProcess
+				cfg.KeyBackend.Socket = "/run/signer.sock"
+				cfg.AutomaticRenewal = true
+			},
+			ErrAssertion: assert.Error,
+		},
+		"pkcs11": {
+			Modify: func(cfg *CSConfig) {
+				cfg.KeyBackend.Type = KeyBackendPKCS11
+				cfg.KeyBackend.Module = "/usr/lib/softhsm/libsofthsm2.so"
+				cfg.KeyBackend.TokenLabel = "scion"
+			},
+			ErrAssertion: assert.NoError,
+		},
+		"pkcs11 without module": {
+			Modify: func(cfg *CSConfig) {
+				cfg.KeyBackend.Type = KeyBackendPKCS11
+				cfg.KeyBackend.TokenLabel = "scion"
+			},
+			ErrAssertion: assert.Error,
+		},
+		"pkcs11 without token label": {
+			Modify: func(cfg *CSConfig) {
+				cfg.KeyBackend.Type = KeyBackendPKCS11
+				cfg.KeyBackend.Module = "/usr/lib/softhsm/libsofthsm2.so"
+			},
+			ErrAssertion: assert.Error,
+		},
+		"unknown type": {
+			Modify: func(cfg *CSConfig) {
+				cfg.KeyBackend.Type = "unknown"
+			},
+			ErrAssertion: assert.Error,
+		},
+		"drkey": {
+			Modify: func(cfg *CSConfig) {
+				cfg.DRKey.Enabled = true
+				cfg.DRKey.Lvl1DB = "/var/lib/scion/drkey/cs-1.lvl1.db"
+			},
+			ErrAssertion: assert.NoError,
+		},
+		"drkey without database": {
+			Modify: func(cfg *CSConfig) {
+				cfg.DRKey.Enabled = true
+			},
+			ErrAssertion: assert.Error,
+		},
+		"drkey with process backend": {
+			Modify: func(cfg *CSConfig) {
+				cfg.KeyBackend.Type = KeyBackendProcess
+				cfg.KeyBackend.Socket = "/run/signer.sock"
+				cfg.DRKey.Enabled = true
+				cfg.DRKey.Lvl1DB = "/var/lib/scion/drkey/cs-1.lvl1.db"
+			},
+			ErrAssertion: assert.Error,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var cfg CSConfig
+			cfg.InitDefaults()
+			test.Modify(&cfg)
+			test.ErrAssertion(t, cfg.Validate())
+		})
+	}
+}
+
 func InitTestConfig(cfg *Config) {
 	envtest.InitTest(&cfg.General, &cfg.Logging, &cfg.Metrics, &cfg.Tracing, nil)
 	truststoragetest.InitTestConfig(&cfg.TrustDB)
@@ -64,6 +156,7 @@ func InitTestConfig(cfg *Config) {
 }
 
 func InitTestBSConfig(cfg *BSConfig) {
+	cfg.StaticInfoConfig = "test"
 	InitTestPolicies(&cfg.Policies)
 }
 
@@ -79,7 +172,7 @@ func CheckTestConfig(t *testing.T, cfg *Config, id string) {
 	truststoragetest.CheckTestConfig(t, &cfg.TrustDB, id)
 	beaconstoragetest.CheckTestBeaconDBConf(t, &cfg.BeaconDB, id)
 	CheckTestBSConfig(t, &cfg.BS)
-	CheckTestCSConfig(t, &cfg.CS)
+	CheckTestCSConfig(t, &cfg.CS, id)
 	CheckTestPSConfig(t, &cfg.PS, id)
 }
 
@@ -92,6 +185,7 @@ func CheckTestBSConfig(t *testing.T, cfg *BSConfig) {
 	assert.Equal(t, DefaultExpiredCheckInterval, cfg.ExpiredCheckInterval.Duration)
 	assert.Equal(t, DefaultRevTTL, cfg.RevTTL.Duration)
 	assert.Equal(t, DefaultRevOverlap, cfg.RevOverlap.Duration)
+	assert.Empty(t, cfg.StaticInfoConfig)
 	CheckTestPolicies(t, &cfg.Policies)
 }
 
@@ -105,15 +199,34 @@ func CheckTestPolicies(t *testing.T, cfg *Policies) {
 func InitTestCSConfig(cfg *CSConfig) {
 	cfg.AutomaticRenewal = true
 	cfg.DisableCorePush = true
+	cfg.KeyBackend.Type = KeyBackendProcess
+	cfg.KeyBackend.Socket = "test"
+	cfg.KeyBackend.Module = "test"
+	cfg.KeyBackend.TokenLabel = "test"
+	cfg.KeyBackend.PIN = "test"
+	cfg.DRKey.Enabled = true
+	cfg.DRKey.Lvl1DB = "test"
 }
 
-func CheckTestCSConfig(t *testing.T, cfg *CSConfig) {
+func CheckTestCSConfig(t *testing.T, cfg *CSConfig, id string) {
 	assert.Equal(t, ReissReqRate, cfg.ReissueRate.Duration)
 	assert.Equal(t, ReissueReqTimeout, cfg.ReissueTimeout.Duration)
 	assert.False(t, cfg.AutomaticRenewal)
 	assert.Equal(t, LeafReissTime, cfg.LeafReissueLeadTime.Duration)
 	assert.Equal(t, IssuerReissTime, cfg.IssuerReissueLeadTime.Duration)
+	assert.Equal(t, LeafValidity, cfg.LeafValidity.Duration)
+	assert.Equal(t, TRCCheckInterval, cfg.TRCCheckInterval.Duration)
 	assert.False(t, cfg.DisableCorePush)
+	assert.Equal(t, KeyBackendFile, cfg.KeyBackend.Type)
+	assert.Empty(t, cfg.KeyBackend.Socket)
+	assert.Empty(t, cfg.KeyBackend.Module)
+	assert.Empty(t, cfg.KeyBackend.TokenLabel)
+	assert.Empty(t, cfg.KeyBackend.PIN)
+	assert.Equal(t, KeyBackendTimeout, cfg.KeyBackend.Timeout.Duration)
+	assert.False(t, cfg.DRKey.Enabled)
+	assert.Equal(t, DRKeyEpochDuration, cfg.DRKey.EpochDuration.Duration)
+	assert.Equal(t, fmt.Sprintf("/var/lib/scion/drkey/%s.lvl1.db", id), cfg.DRKey.Lvl1DB)
+	assert.Equal(t, DRKeyTimeout, cfg.DRKey.Timeout.Duration)
 }
 
 func InitTestPSConfig(cfg *PSConfig) {
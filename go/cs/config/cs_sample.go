@@ -32,9 +32,63 @@ ReissueRate = "10s"
 # Timeout for resissue request. (default 5s)
 ReissueTimeout = "5s"
 
+# Maximum validity period of AS certificates issued in response to reissue
+# requests. Only relevant for issuer ASes. (default 72h)
+LeafValidity = "72h"
+
+# Interval between two consecutive checks for newer TRC versions of the known
+# ISDs. (default 10m)
+TRCCheckInterval = "10m"
+
 # Whether automatic reissuing is enabled. (default false)
 AutomaticRenewal = false
 
 # Disable the core pushing. (default false)
 DisableCorePush = false
 `
+
+const KeyBackendSample = `
+# The backend that holds the private keys. With the file backend, the keys are
+# loaded from the keys directory in the config directory. With the process
+# backend, signing is delegated to an external signing process. With the pkcs11
+# backend, the private keys are held by a PKCS#11 token, e.g., a hardware
+# security module, and the public key files are loaded from the keys directory.
+# Automatic renewal requires the file backend. (file|process|pkcs11)
+# (default file)
+Type = "file"
+
+# The unix socket of the external signing process. Only used by the process
+# backend. (default "")
+Socket = ""
+
+# The timeout for a single request to the external signing process. (default 1s)
+Timeout = "1s"
+
+# The path to the PKCS#11 module. Only used by the pkcs11 backend. (default "")
+Module = ""
+
+# The label of the PKCS#11 token. On the token, a private key is identified by
+# its file name without extension, e.g., "as-signing-v1". Only used by the
+# pkcs11 backend. (default "")
+TokenLabel = ""
+
+# The user PIN of the PKCS#11 token. Only used by the pkcs11 backend.
+# (default "")
+PIN = ""
+`
+
+const DRKeySample = `
+# Whether the DRKey service is enabled. It requires the file key backend.
+# (default false)
+Enabled = false
+
+# The duration of a DRKey epoch. (default 24h)
+EpochDuration = "24h"
+
+# The file path of the level 1 key database. Required if the DRKey service is
+# enabled. (default "")
+Lvl1DB = "/var/lib/scion/drkey/%s.lvl1.db"
+
+# The timeout for handling a single DRKey request. (default 5s)
+Timeout = "5s"
+`
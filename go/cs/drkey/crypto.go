@@ -0,0 +1,171 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drkey
+
+import (
+	"context"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/ctrl/drkey_mgmt"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/infra/modules/trust"
+	"github.com/scionproto/scion/go/lib/keyconf"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/scrypto/cert"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+// ChainProvider provides certificate chains.
+type ChainProvider interface {
+	// GetRawChain returns the raw certificate chain.
+	GetRawChain(ctx context.Context, id trust.ChainID, opts infra.ChainOpts) ([]byte, error)
+}
+
+// KeyRing provides private keys.
+type KeyRing interface {
+	// PrivateKey returns the private key with the given usage and version.
+	PrivateKey(usage keyconf.Usage, version scrypto.KeyVersion) (keyconf.Key, error)
+}
+
+// Crypto encrypts and decrypts level 1 keys with the encryption keys that are
+// authenticated by the AS certificates.
+type Crypto struct {
+	// IA is the local AS.
+	IA addr.IA
+	// Chains provides the certificate chains of the local and remote ASes.
+	Chains ChainProvider
+	// KeyRing provides the private decryption key of the local AS.
+	KeyRing KeyRing
+}
+
+// Encrypt encrypts the level 1 key for the destination AS, using the
+// encryption key in the destination AS certificate with the given version. The
+// local AS must be the source of the key.
+func (c Crypto) Encrypt(ctx context.Context, key drkey.Lvl1Key, dstVersion scrypto.Version,
+	now time.Time) (*drkey_mgmt.Lvl1Rep, error) {
+
+	if !key.SrcIA.Equal(c.IA) {
+		return nil, serrors.New("local AS is not the source", "src_ia", key.SrcIA)
+	}
+	local, err := c.encryptionKey(ctx, c.IA, scrypto.LatestVer, localOnly())
+	if err != nil {
+		return nil, err
+	}
+	remote, err := c.encryptionKey(ctx, key.DstIA, dstVersion, infra.ChainOpts{})
+	if err != nil {
+		return nil, err
+	}
+	priv, err := c.privateKey(local.meta, remote.meta)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := scrypto.Nonce(scrypto.NaClBoxNonceSize)
+	if err != nil {
+		return nil, serrors.WrapStr("unable to generate nonce", err)
+	}
+	cipher, err := drkey.EncryptLvl1(key, nonce, remote.meta.Key, priv, local.meta.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	return &drkey_mgmt.Lvl1Rep{
+		RawSrcIA:     key.SrcIA.IAInt(),
+		TimestampRaw: util.TimeToSecs(now),
+		EpochBegin:   key.Epoch.Begin(),
+		EpochEnd:     key.Epoch.End(),
+		Cipher:       cipher,
+		Nonce:        nonce,
+		CertVerSrc:   uint32(local.version),
+		CertVerDst:   uint32(remote.version),
+	}, nil
+}
+
+// Decrypt decrypts the level 1 key in the reply and checks that it matches the
+// expected metadata. The local AS must be the destination of the key.
+func (c Crypto) Decrypt(ctx context.Context, rep *drkey_mgmt.Lvl1Rep,
+	meta drkey.Lvl1Meta) (drkey.Lvl1Key, error) {
+
+	if !meta.DstIA.Equal(c.IA) {
+		return drkey.Lvl1Key{}, serrors.New("local AS is not the destination",
+			"dst_ia", meta.DstIA)
+	}
+	local, err := c.encryptionKey(ctx, c.IA, rep.DstVersion(), localOnly())
+	if err != nil {
+		return drkey.Lvl1Key{}, err
+	}
+	remote, err := c.encryptionKey(ctx, meta.SrcIA, rep.SrcVersion(), infra.ChainOpts{})
+	if err != nil {
+		return drkey.Lvl1Key{}, err
+	}
+	priv, err := c.privateKey(local.meta, remote.meta)
+	if err != nil {
+		return drkey.Lvl1Key{}, err
+	}
+	return drkey.DecryptLvl1(rep.Cipher, rep.Nonce, remote.meta.Key, priv,
+		local.meta.Algorithm, meta)
+}
+
+// privateKey loads the private decryption key that corresponds to the local
+// encryption key, and checks that the remote key uses the same algorithm.
+func (c Crypto) privateKey(local, remote scrypto.KeyMeta) ([]byte, error) {
+	if local.Algorithm != remote.Algorithm {
+		return nil, serrors.New("encryption algorithm mismatch", "local", local.Algorithm,
+			"remote", remote.Algorithm)
+	}
+	key, err := c.KeyRing.PrivateKey(keyconf.ASDecryptionKey, local.KeyVersion)
+	if err != nil {
+		return nil, serrors.WrapStr("unable to load decryption key", err,
+			"key_version", local.KeyVersion)
+	}
+	return key.Bytes, nil
+}
+
+// encKey is the encryption key authenticated by an AS certificate.
+type encKey struct {
+	meta    scrypto.KeyMeta
+	version scrypto.Version
+}
+
+func (c Crypto) encryptionKey(ctx context.Context, ia addr.IA, version scrypto.Version,
+	opts infra.ChainOpts) (encKey, error) {
+
+	raw, err := c.Chains.GetRawChain(ctx, trust.ChainID{IA: ia, Version: version}, opts)
+	if err != nil {
+		return encKey{}, serrors.WrapStr("unable to get certificate chain", err,
+			"ia", ia, "version", version)
+	}
+	chain, err := cert.ParseChain(raw)
+	if err != nil {
+		return encKey{}, serrors.WrapStr("unable to parse certificate chain", err,
+			"ia", ia, "version", version)
+	}
+	as, err := chain.AS.Encoded.Decode()
+	if err != nil {
+		return encKey{}, serrors.WrapStr("unable to decode AS certificate", err,
+			"ia", ia, "version", version)
+	}
+	meta, ok := as.Keys[cert.EncryptionKey]
+	if !ok {
+		return encKey{}, serrors.New("AS certificate without encryption key",
+			"ia", ia, "version", as.Version)
+	}
+	return encKey{meta: meta, version: as.Version}, nil
+}
+
+func localOnly() infra.ChainOpts {
+	return infra.ChainOpts{TrustStoreOpts: infra.TrustStoreOpts{LocalOnly: true}}
+}
@@ -0,0 +1,38 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package drkey implements the DRKey service of the control service.
+//
+// Service
+//
+// The service derives the level 1 keys for which the local AS is the source
+// from the secret value of the current epoch. Level 1 keys for which the
+// local AS is the destination are fetched from the control service of the
+// source AS and cached in the level 1 database. Level 2 keys are derived on
+// demand from the corresponding level 1 key.
+//
+// Level 1 exchange
+//
+// The level 1 request is signed by the requesting control service. The
+// handler verifies the signature, derives the level 1 key for the requesting
+// AS and encrypts it with the encryption key in the certificate chain of the
+// requesting AS. The reply is authenticated with the encryption key in the
+// certificate chain of the responding AS.
+//
+// Level 2 handler
+//
+// The level 2 handler serves level 2 key requests from the local SCIOND.
+// Only keys for which the local AS is either the source or the destination
+// are served.
+package drkey
@@ -0,0 +1,431 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drkey_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/cs/drkey"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/ctrl"
+	"github.com/scionproto/scion/go/lib/ctrl/ack"
+	"github.com/scionproto/scion/go/lib/ctrl/drkey_mgmt"
+	libdrkey "github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/drkey/sqlite"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/infra/mock_infra"
+	"github.com/scionproto/scion/go/lib/infra/modules/trust"
+	"github.com/scionproto/scion/go/lib/keyconf"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/scrypto/cert"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/snet/mock_snet"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/proto"
+)
+
+var (
+	srcIA = xtest.MustParseIA("1-ff00:0:110")
+	dstIA = xtest.MustParseIA("1-ff00:0:111")
+)
+
+func TestLvl1Exchange(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+
+	chains := chainStore{}
+	srcRing := chains.add(t, srcIA)
+	dstRing := chains.add(t, dstIA)
+
+	verifier := mock_infra.NewMockVerifier(mctrl)
+	verifier.EXPECT().WithIA(dstIA).Return(verifier)
+	verifier.EXPECT().Verify(gomock.Any(), gomock.Any(), gomock.Any())
+	srcService := &drkey.Service{
+		IA:           srcIA,
+		SecretValues: secretValues("source"),
+	}
+	handler := &drkey.Lvl1Handler{
+		Service:  srcService,
+		Crypto:   drkey.Crypto{IA: srcIA, Chains: chains, KeyRing: srcRing},
+		Verifier: verifier,
+		Timeout:  time.Second,
+	}
+
+	path := mock_snet.NewMockPath(mctrl)
+	path.EXPECT().Destination().Return(srcIA).AnyTimes()
+	path.EXPECT().Path().AnyTimes()
+	path.EXPECT().OverlayNextHop().AnyTimes()
+	router := mock_snet.NewMockRouter(mctrl)
+	router.EXPECT().Route(gomock.Any(), srcIA).Return(path, nil)
+	fetcher := &drkey.Fetcher{
+		IA:     dstIA,
+		Msgr:   handlerMsgr{t: t, handler: handler, peer: dstIA},
+		Router: router,
+		Crypto: drkey.Crypto{IA: dstIA, Chains: chains, KeyRing: dstRing},
+	}
+
+	now := time.Now()
+	key, err := fetcher.FetchLvl1(context.Background(), srcIA, now)
+	require.NoError(t, err)
+	expected, err := srcService.DeriveLvl1(dstIA, now)
+	require.NoError(t, err)
+	assert.True(t, expected.Equal(key))
+	assert.True(t, key.Epoch.Contains(now))
+}
+
+func TestLvl1HandlerReply(t *testing.T) {
+	now := time.Now()
+	tests := map[string]struct {
+		Req       *drkey_mgmt.Lvl1Req
+		Sign      *proto.SignS
+		VerifyErr error
+	}{
+		"wrong source": {
+			Req:  drkey_mgmt.NewLvl1Req(dstIA, now),
+			Sign: newSign(dstIA),
+		},
+		"unsigned": {
+			Req:  drkey_mgmt.NewLvl1Req(srcIA, now),
+			Sign: proto.NewSignS(proto.SignType_none, nil),
+		},
+		"invalid signature": {
+			Req:       drkey_mgmt.NewLvl1Req(srcIA, now),
+			Sign:      newSign(dstIA),
+			VerifyErr: errors.New("invalid signature"),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mctrl := gomock.NewController(t)
+			defer mctrl.Finish()
+			verifier := mock_infra.NewMockVerifier(mctrl)
+			verifier.EXPECT().WithIA(dstIA).Return(verifier).AnyTimes()
+			verifier.EXPECT().Verify(gomock.Any(), gomock.Any(),
+				gomock.Any()).Return(test.VerifyErr).AnyTimes()
+			handler := &drkey.Lvl1Handler{
+				Service:  &drkey.Service{IA: srcIA, SecretValues: secretValues("source")},
+				Verifier: verifier,
+			}
+			signed := &ctrl.SignedPld{Blob: []byte("request"), Sign: test.Sign}
+			_, err := handler.Reply(context.Background(), test.Req, signed, dstIA)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestLvl2HandlerAuthorize(t *testing.T) {
+	hostIP := net.IP{10, 0, 0, 1}
+	otherIP := net.IP{10, 0, 0, 2}
+	localPeer := &net.TCPAddr{IP: hostIP, Port: 40000}
+	tests := map[string]struct {
+		Meta         libdrkey.Lvl2Meta
+		Peer         net.Addr
+		ErrAssertion assert.ErrorAssertionFunc
+	}{
+		"destination host": {
+			Meta: libdrkey.Lvl2Meta{KeyType: libdrkey.AS2Host, SrcIA: srcIA, DstIA: dstIA,
+				DstHost: addr.HostFromIP(hostIP)},
+			Peer:         localPeer,
+			ErrAssertion: assert.NoError,
+		},
+		"destination host over SCION": {
+			Meta: libdrkey.Lvl2Meta{KeyType: libdrkey.AS2Host, SrcIA: srcIA, DstIA: dstIA,
+				DstHost: addr.HostFromIP(hostIP)},
+			Peer:         &snet.UDPAddr{IA: dstIA, Host: &net.UDPAddr{IP: hostIP}},
+			ErrAssertion: assert.NoError,
+		},
+		"source host of host-to-host key": {
+			Meta: libdrkey.Lvl2Meta{KeyType: libdrkey.Host2Host, SrcIA: dstIA, DstIA: srcIA,
+				SrcHost: addr.HostFromIP(hostIP), DstHost: addr.HostFromIP(otherIP)},
+			Peer:         localPeer,
+			ErrAssertion: assert.NoError,
+		},
+		"other destination host": {
+			Meta: libdrkey.Lvl2Meta{KeyType: libdrkey.AS2Host, SrcIA: srcIA, DstIA: dstIA,
+				DstHost: addr.HostFromIP(otherIP)},
+			Peer:         localPeer,
+			ErrAssertion: assert.Error,
+		},
+		"other source host of host-to-host key": {
+			Meta: libdrkey.Lvl2Meta{KeyType: libdrkey.Host2Host, SrcIA: dstIA, DstIA: srcIA,
+				SrcHost: addr.HostFromIP(otherIP), DstHost: addr.HostFromIP(hostIP)},
+			Peer:         localPeer,
+			ErrAssertion: assert.Error,
+		},
+		"source host of AS-to-host key": {
+			Meta: libdrkey.Lvl2Meta{KeyType: libdrkey.AS2Host, SrcIA: dstIA, DstIA: srcIA,
+				SrcHost: addr.HostFromIP(hostIP), DstHost: addr.HostFromIP(otherIP)},
+			Peer:         localPeer,
+			ErrAssertion: assert.Error,
+		},
+		"AS-to-AS key": {
+			Meta:         libdrkey.Lvl2Meta{KeyType: libdrkey.AS2AS, SrcIA: srcIA, DstIA: dstIA},
+			Peer:         localPeer,
+			ErrAssertion: assert.Error,
+		},
+		"AS-to-AS key with destination host": {
+			Meta: libdrkey.Lvl2Meta{KeyType: libdrkey.AS2AS, SrcIA: srcIA, DstIA: dstIA,
+				DstHost: addr.HostFromIP(hostIP)},
+			Peer: localPeer,
+			ErrAssertion: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.True(t, errors.Is(err, drkey.ErrASKey))
+			},
+		},
+		"AS-to-AS key with source host": {
+			Meta: libdrkey.Lvl2Meta{KeyType: libdrkey.AS2AS, SrcIA: dstIA, DstIA: srcIA,
+				SrcHost: addr.HostFromIP(hostIP)},
+			Peer: localPeer,
+			ErrAssertion: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.True(t, errors.Is(err, drkey.ErrASKey))
+			},
+		},
+		"destination host of host-to-host key": {
+			Meta: libdrkey.Lvl2Meta{KeyType: libdrkey.Host2Host, SrcIA: srcIA, DstIA: dstIA,
+				SrcHost: addr.HostFromIP(otherIP), DstHost: addr.HostFromIP(hostIP)},
+			Peer:         localPeer,
+			ErrAssertion: assert.NoError,
+		},
+		"destination host in remote AS": {
+			Meta: libdrkey.Lvl2Meta{KeyType: libdrkey.AS2Host, SrcIA: dstIA, DstIA: srcIA,
+				DstHost: addr.HostFromIP(hostIP)},
+			Peer:         localPeer,
+			ErrAssertion: assert.Error,
+		},
+		"peer in remote AS": {
+			Meta: libdrkey.Lvl2Meta{KeyType: libdrkey.AS2Host, SrcIA: srcIA, DstIA: dstIA,
+				DstHost: addr.HostFromIP(hostIP)},
+			Peer:         &snet.UDPAddr{IA: srcIA, Host: &net.UDPAddr{IP: hostIP}},
+			ErrAssertion: assert.Error,
+		},
+		"unsupported peer type": {
+			Meta: libdrkey.Lvl2Meta{KeyType: libdrkey.AS2Host, SrcIA: srcIA, DstIA: dstIA,
+				DstHost: addr.HostFromIP(hostIP)},
+			Peer:         &net.UDPAddr{IP: hostIP},
+			ErrAssertion: assert.Error,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			handler := &drkey.Lvl2Handler{Service: &drkey.Service{IA: dstIA}}
+			test.ErrAssertion(t, handler.Authorize(test.Meta, test.Peer))
+		})
+	}
+}
+
+func TestLvl2HandlerRejectsOtherHost(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+	meta := libdrkey.Lvl2Meta{
+		KeyType: libdrkey.AS2Host,
+		SrcIA:   srcIA,
+		DstIA:   dstIA,
+		DstHost: addr.HostFromIP(net.IP{10, 0, 0, 1}),
+	}
+	rw := mock_infra.NewMockResponseWriter(mctrl)
+	rw.EXPECT().SendAckReply(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, msg *ack.Ack) error {
+			assert.Equal(t, proto.Ack_ErrCode_reject, msg.Err)
+			return nil
+		},
+	)
+	ctx := infra.NewContextWithResponseWriter(context.Background(), rw)
+	req := infra.NewRequest(ctx, drkey_mgmt.NewLvl2ReqFromMeta(meta, time.Now()), nil,
+		&net.TCPAddr{IP: net.IP{10, 0, 0, 2}, Port: 40000}, 0)
+	// The service is not used, since the request is rejected before the key is
+	// derived.
+	handler := &drkey.Lvl2Handler{Service: &drkey.Service{IA: dstIA}, Timeout: time.Second}
+	assert.Equal(t, infra.MetricsErrInvalid, handler.Handle(req))
+}
+
+func TestServiceGetLvl2Key(t *testing.T) {
+	otherIA := xtest.MustParseIA("1-ff00:0:112")
+	now := time.Now()
+	remote := &drkey.Service{IA: otherIA, SecretValues: secretValues("remote")}
+	fetcher := &countingFetcher{service: remote, dstIA: srcIA}
+	db, err := sqlite.New(":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	service := &drkey.Service{
+		IA:           srcIA,
+		SecretValues: secretValues("local"),
+		DB:           db,
+		Fetcher:      fetcher,
+	}
+
+	t.Run("local source", func(t *testing.T) {
+		meta := libdrkey.Lvl2Meta{
+			KeyType:  libdrkey.AS2AS,
+			Protocol: "scmp",
+			SrcIA:    srcIA,
+			DstIA:    otherIA,
+		}
+		key, err := service.GetLvl2Key(context.Background(), meta, now)
+		require.NoError(t, err)
+		lvl1, err := service.DeriveLvl1(otherIA, now)
+		require.NoError(t, err)
+		meta.Epoch = lvl1.Epoch
+		expected, err := libdrkey.DeriveLvl2(meta, lvl1)
+		require.NoError(t, err)
+		assert.True(t, expected.Key.Equal(key.Key))
+		assert.Equal(t, 0, fetcher.count)
+	})
+	t.Run("local destination", func(t *testing.T) {
+		meta := libdrkey.Lvl2Meta{
+			KeyType:  libdrkey.AS2Host,
+			Protocol: "scmp",
+			SrcIA:    otherIA,
+			DstIA:    srcIA,
+			DstHost:  addr.HostFromIPStr("127.0.0.1"),
+		}
+		key, err := service.GetLvl2Key(context.Background(), meta, now)
+		require.NoError(t, err)
+		lvl1, err := remote.DeriveLvl1(srcIA, now)
+		require.NoError(t, err)
+		meta.Epoch = lvl1.Epoch
+		expected, err := libdrkey.DeriveLvl2(meta, lvl1)
+		require.NoError(t, err)
+		assert.True(t, expected.Key.Equal(key.Key))
+		// The level 1 key is cached after the first fetch.
+		_, err = service.GetLvl2Key(context.Background(), meta, now)
+		require.NoError(t, err)
+		assert.Equal(t, 1, fetcher.count)
+	})
+	t.Run("not local", func(t *testing.T) {
+		meta := libdrkey.Lvl2Meta{
+			KeyType:  libdrkey.AS2AS,
+			Protocol: "scmp",
+			SrcIA:    otherIA,
+			DstIA:    dstIA,
+		}
+		_, err := service.GetLvl2Key(context.Background(), meta, now)
+		xtest.AssertErrorsIs(t, err, drkey.ErrNotLocal)
+	})
+}
+
+func secretValues(master string) libdrkey.SecretValueFactory {
+	return libdrkey.SecretValueFactory{
+		MasterKey:     []byte(master),
+		EpochDuration: time.Hour,
+	}
+}
+
+func newSign(ia addr.IA) *proto.SignS {
+	src := ctrl.SignSrcDef{IA: ia, ChainVer: 1, TRCVer: 1}
+	sign := proto.NewSignS(proto.SignType_ed25519, src.Pack())
+	sign.SetTimestamp(time.Now())
+	return sign
+}
+
+// handlerMsgr passes the requests directly to the handler.
+type handlerMsgr struct {
+	t       *testing.T
+	handler *drkey.Lvl1Handler
+	peer    addr.IA
+}
+
+func (m handlerMsgr) RequestDRKeyLvl1(ctx context.Context, msg *drkey_mgmt.Lvl1Req,
+	_ net.Addr, _ uint64) (*drkey_mgmt.Lvl1Rep, error) {
+
+	signed := &ctrl.SignedPld{Blob: []byte("request"), Sign: newSign(m.peer)}
+	return m.handler.Reply(ctx, msg, signed, m.peer)
+}
+
+// countingFetcher derives the level 1 keys with the remote service and counts
+// the number of fetches.
+type countingFetcher struct {
+	service *drkey.Service
+	dstIA   addr.IA
+	count   int
+}
+
+func (f *countingFetcher) FetchLvl1(_ context.Context, srcIA addr.IA,
+	valTime time.Time) (libdrkey.Lvl1Key, error) {
+
+	f.count++
+	return f.service.DeriveLvl1(f.dstIA, valTime)
+}
+
+// chainStore keeps a single certificate chain per AS without verification.
+type chainStore map[addr.IA][]byte
+
+// add creates a certificate chain with a fresh encryption key for the AS, and
+// returns the key ring that holds the private decryption key.
+func (s chainStore) add(t *testing.T, ia addr.IA) keyRing {
+	t.Helper()
+	pub, priv, err := scrypto.GenKeyPair(scrypto.Curve25519xSalsa20Poly1305)
+	require.NoError(t, err)
+	enc, err := cert.EncodeAS(&cert.AS{
+		Base: cert.Base{
+			Subject:                    ia,
+			Version:                    1,
+			FormatVersion:              1,
+			Description:                "AS certificate",
+			OptionalDistributionPoints: []addr.IA{},
+			Validity: &scrypto.Validity{
+				NotBefore: util.UnixTime{Time: time.Now().Add(-time.Hour)},
+				NotAfter:  util.UnixTime{Time: time.Now().Add(time.Hour)},
+			},
+			Keys: map[cert.KeyType]scrypto.KeyMeta{
+				cert.EncryptionKey: {
+					KeyVersion: 1,
+					Algorithm:  scrypto.Curve25519xSalsa20Poly1305,
+					Key:        pub,
+				},
+			},
+		},
+		Issuer: cert.IssuerCertID{IA: ia, CertificateVersion: 1},
+	})
+	require.NoError(t, err)
+	raw, err := json.Marshal(cert.Chain{AS: cert.SignedAS{Encoded: enc}})
+	require.NoError(t, err)
+	s[ia] = raw
+	return keyRing{
+		ID:        keyconf.ID{Usage: keyconf.ASDecryptionKey, IA: ia, Version: 1},
+		Type:      keyconf.PrivateKey,
+		Algorithm: scrypto.Curve25519xSalsa20Poly1305,
+		Bytes:     priv,
+	}
+}
+
+func (s chainStore) GetRawChain(_ context.Context, id trust.ChainID,
+	_ infra.ChainOpts) ([]byte, error) {
+
+	raw, ok := s[id.IA]
+	if !ok {
+		return nil, trust.ErrNotFound
+	}
+	return raw, nil
+}
+
+type keyRing keyconf.Key
+
+func (r keyRing) PrivateKey(usage keyconf.Usage,
+	version scrypto.KeyVersion) (keyconf.Key, error) {
+
+	if usage != r.Usage || version != r.Version {
+		return keyconf.Key{}, errors.New("key not found")
+	}
+	return keyconf.Key(r), nil
+}
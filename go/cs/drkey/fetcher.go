@@ -0,0 +1,82 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drkey
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/ctrl/drkey_mgmt"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/infra/messenger"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+// Lvl1Requester is used to send level 1 requests.
+type Lvl1Requester interface {
+	RequestDRKeyLvl1(ctx context.Context, msg *drkey_mgmt.Lvl1Req, a net.Addr,
+		id uint64) (*drkey_mgmt.Lvl1Rep, error)
+}
+
+var _ Lvl1Fetcher = (*Fetcher)(nil)
+
+// Fetcher fetches level 1 keys from the control service of the source AS.
+type Fetcher struct {
+	// IA is the local AS.
+	IA addr.IA
+	// Msgr is used to send the requests.
+	Msgr Lvl1Requester
+	// Router is used to find a path to the source AS.
+	Router snet.Router
+	// Crypto decrypts the level 1 keys.
+	Crypto Crypto
+}
+
+// FetchLvl1 fetches the level 1 key from the source AS to the local AS that is
+// valid at valTime.
+func (f *Fetcher) FetchLvl1(ctx context.Context, srcIA addr.IA,
+	valTime time.Time) (drkey.Lvl1Key, error) {
+
+	path, err := f.Router.Route(ctx, srcIA)
+	if err != nil {
+		return drkey.Lvl1Key{}, serrors.WrapStr("unable to find path to source AS", err,
+			"src_ia", srcIA)
+	}
+	a := &snet.SVCAddr{
+		IA:      path.Destination(),
+		Path:    path.Path(),
+		NextHop: path.OverlayNextHop(),
+		SVC:     addr.SvcCS,
+	}
+	rep, err := f.Msgr.RequestDRKeyLvl1(ctx, drkey_mgmt.NewLvl1Req(srcIA, valTime), a,
+		messenger.NextId())
+	if err != nil {
+		return drkey.Lvl1Key{}, serrors.WrapStr("level 1 request failed", err,
+			"src_ia", srcIA)
+	}
+	if !rep.SrcIA().Equal(srcIA) {
+		return drkey.Lvl1Key{}, serrors.New("reply for wrong source AS",
+			"expected", srcIA, "actual", rep.SrcIA())
+	}
+	epoch := rep.Epoch()
+	if !epoch.Contains(valTime) {
+		return drkey.Lvl1Key{}, serrors.New("epoch does not cover requested time",
+			"epoch", epoch, "val_time", valTime)
+	}
+	return f.Crypto.Decrypt(ctx, rep, drkey.Lvl1Meta{Epoch: epoch, SrcIA: srcIA, DstIA: f.IA})
+}
@@ -0,0 +1,233 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drkey
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl"
+	"github.com/scionproto/scion/go/lib/ctrl/drkey_mgmt"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/infra/messenger"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/proto"
+)
+
+// Lvl1Handler handles level 1 requests from the control services of the
+// destination ASes.
+type Lvl1Handler struct {
+	// Service derives the level 1 keys.
+	Service *Service
+	// Crypto encrypts the level 1 keys.
+	Crypto Crypto
+	// Verifier verifies the signature of the requests.
+	Verifier infra.Verifier
+	// Timeout is the timeout for handling a single request.
+	Timeout time.Duration
+}
+
+// Handle handles level 1 requests.
+func (h *Lvl1Handler) Handle(r *infra.Request) *infra.HandlerResult {
+	logger := log.FromCtx(r.Context())
+	req, ok := r.Message.(*drkey_mgmt.Lvl1Req)
+	if !ok {
+		logger.Error("[drkey.Lvl1Handler] Wrong message type, expected drkey_mgmt.Lvl1Req",
+			"msg", r.Message, "type", common.TypeOf(r.Message))
+		return infra.MetricsErrInternal
+	}
+	signed, ok := r.FullMessage.(*ctrl.SignedPld)
+	if !ok {
+		logger.Error("[drkey.Lvl1Handler] Wrong message type, expected ctrl.SignedPld",
+			"msg", r.FullMessage, "type", common.TypeOf(r.FullMessage))
+		return infra.MetricsErrInternal
+	}
+	peer, ok := r.Peer.(*snet.UDPAddr)
+	if !ok {
+		logger.Error("[drkey.Lvl1Handler] Invalid peer address type, expected *snet.UDPAddr",
+			"peer", r.Peer, "type", common.TypeOf(r.Peer))
+		return infra.MetricsErrInvalid
+	}
+	rw, ok := infra.ResponseWriterFromContext(r.Context())
+	if !ok {
+		logger.Error("[drkey.Lvl1Handler] Unable to service request, no ResponseWriter found")
+		return infra.MetricsErrInternal
+	}
+	ctx, cancelF := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancelF()
+	sendAck := messenger.SendAckHelper(ctx, rw)
+
+	logger.Debug("[drkey.Lvl1Handler] Received level 1 request", "req", req, "peer", r.Peer)
+	rep, err := h.Reply(ctx, req, signed, peer.IA)
+	if err != nil {
+		logger.Error("[drkey.Lvl1Handler] Unable to serve level 1 request", "req", req,
+			"peer", r.Peer, "err", err)
+		sendAck(proto.Ack_ErrCode_reject, err.Error())
+		return infra.MetricsErrInvalid
+	}
+	if err := rw.SendDRKeyLvl1Reply(ctx, rep); err != nil {
+		logger.Error("[drkey.Lvl1Handler] Unable to send reply", "err", err)
+		return infra.MetricsErrMsger(err)
+	}
+	return infra.MetricsResultOk
+}
+
+// Reply verifies the signed request from the peer AS and returns the reply
+// that contains the encrypted level 1 key from the local AS to the peer AS.
+func (h *Lvl1Handler) Reply(ctx context.Context, req *drkey_mgmt.Lvl1Req,
+	signed *ctrl.SignedPld, peer addr.IA) (*drkey_mgmt.Lvl1Rep, error) {
+
+	if !req.SrcIA().Equal(h.Service.IA) {
+		return nil, serrors.New("local AS is not the source", "src_ia", req.SrcIA())
+	}
+	if signed.Sign == nil || signed.Sign.Type == proto.SignType_none {
+		return nil, serrors.New("request not signed")
+	}
+	src, err := ctrl.NewSignSrcDefFromRaw(signed.Sign.Src)
+	if err != nil {
+		return nil, serrors.WrapStr("unable to parse signature source", err)
+	}
+	if err := h.Verifier.WithIA(peer).Verify(ctx, signed.Blob, signed.Sign); err != nil {
+		return nil, serrors.WrapStr("unable to verify request", err)
+	}
+	key, err := h.Service.DeriveLvl1(peer, req.ValTime())
+	if err != nil {
+		return nil, err
+	}
+	return h.Crypto.Encrypt(ctx, key, src.ChainVer, time.Now())
+}
+
+// Level 2 authorization errors.
+var (
+	// ErrNotLocalHost indicates that a level 2 key was requested by a host
+	// outside of the local AS.
+	ErrNotLocalHost = serrors.New("requester is not a host in the local AS")
+	// ErrNotKeyHost indicates that a level 2 key was requested by a host that
+	// is not the host in the local AS that the key is derived for.
+	ErrNotKeyHost = serrors.New("requester is not the host of the key")
+	// ErrASKey indicates that an AS-to-AS level 2 key was requested by a host.
+	// These keys do not depend on any host and are not served to hosts.
+	ErrASKey = serrors.New("AS-to-AS keys are not served to hosts")
+)
+
+// Lvl2Handler handles level 2 requests from the local SCIOND.
+type Lvl2Handler struct {
+	// Service provides the level 2 keys.
+	Service *Service
+	// Timeout is the timeout for handling a single request.
+	Timeout time.Duration
+}
+
+// Handle handles level 2 requests.
+func (h *Lvl2Handler) Handle(r *infra.Request) *infra.HandlerResult {
+	logger := log.FromCtx(r.Context())
+	req, ok := r.Message.(*drkey_mgmt.Lvl2Req)
+	if !ok {
+		logger.Error("[drkey.Lvl2Handler] Wrong message type, expected drkey_mgmt.Lvl2Req",
+			"msg", r.Message, "type", common.TypeOf(r.Message))
+		return infra.MetricsErrInternal
+	}
+	rw, ok := infra.ResponseWriterFromContext(r.Context())
+	if !ok {
+		logger.Error("[drkey.Lvl2Handler] Unable to service request, no ResponseWriter found")
+		return infra.MetricsErrInternal
+	}
+	ctx, cancelF := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancelF()
+	sendAck := messenger.SendAckHelper(ctx, rw)
+
+	logger.Debug("[drkey.Lvl2Handler] Received level 2 request", "req", req, "peer", r.Peer)
+	meta, err := req.ToMeta()
+	if err != nil {
+		logger.Error("[drkey.Lvl2Handler] Invalid level 2 request", "req", req, "err", err)
+		sendAck(proto.Ack_ErrCode_reject, err.Error())
+		return infra.MetricsErrInvalid
+	}
+	if err := h.Authorize(meta, r.Peer); err != nil {
+		logger.Info("[drkey.Lvl2Handler] Unauthorized level 2 request", "req", req,
+			"peer", r.Peer, "err", err)
+		sendAck(proto.Ack_ErrCode_reject, err.Error())
+		return infra.MetricsErrInvalid
+	}
+	key, err := h.Service.GetLvl2Key(ctx, meta, req.ValTime())
+	if err != nil {
+		logger.Error("[drkey.Lvl2Handler] Unable to get level 2 key", "req", req, "err", err)
+		sendAck(proto.Ack_ErrCode_reject, err.Error())
+		return infra.MetricsErrInvalid
+	}
+	rep := drkey_mgmt.NewLvl2RepFromKey(key, time.Now())
+	if err := rw.SendDRKeyLvl2Reply(ctx, rep); err != nil {
+		logger.Error("[drkey.Lvl2Handler] Unable to send reply", "err", err)
+		return infra.MetricsErrMsger(err)
+	}
+	return infra.MetricsResultOk
+}
+
+// Authorize checks that the peer is allowed to obtain the level 2 key. Only
+// hosts in the local AS are served, and only the keys that are derived for
+// them, i.e., the peer must be the destination host of an AS-to-host key, or
+// the source or destination host of a host-to-host key, in the local AS.
+// AS-to-AS keys are never served to hosts.
+func (h *Lvl2Handler) Authorize(meta drkey.Lvl2Meta, peer net.Addr) error {
+	var ip net.IP
+	switch p := peer.(type) {
+	case *net.TCPAddr:
+		// The TCP messenger only serves AS local clients.
+		ip = p.IP
+	case *snet.UDPAddr:
+		if !p.IA.Equal(h.Service.IA) {
+			return serrors.WithCtx(ErrNotLocalHost, "peer_ia", p.IA)
+		}
+		if p.Host != nil {
+			ip = p.Host.IP
+		}
+	default:
+		return serrors.WithCtx(ErrNotLocalHost, "type", common.TypeOf(peer))
+	}
+	if ip == nil {
+		return serrors.WithCtx(ErrNotLocalHost, "peer", peer)
+	}
+	isDst := meta.DstIA.Equal(h.Service.IA) && hostIs(meta.DstHost, ip)
+	isSrc := meta.SrcIA.Equal(h.Service.IA) && hostIs(meta.SrcHost, ip)
+	switch meta.KeyType {
+	case drkey.AS2Host:
+		if isDst {
+			return nil
+		}
+	case drkey.Host2Host:
+		if isDst || isSrc {
+			return nil
+		}
+	default:
+		return serrors.WithCtx(ErrASKey, "type", meta.KeyType, "peer", peer)
+	}
+	return serrors.WithCtx(ErrNotKeyHost, "type", meta.KeyType, "src_ia", meta.SrcIA,
+		"dst_ia", meta.DstIA, "src_host", meta.SrcHost, "dst_host", meta.DstHost,
+		"peer", peer)
+}
+
+// hostIs returns whether the host has the given IP.
+func hostIs(host addr.HostAddr, ip net.IP) bool {
+	if host == nil || host.IP() == nil {
+		return false
+	}
+	return host.IP().Equal(ip)
+}
@@ -0,0 +1,113 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drkey
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+// ErrNotLocal indicates that the local AS is neither the source nor the
+// destination of the requested key.
+var ErrNotLocal = serrors.New("local AS is neither source nor destination")
+
+// Lvl1Fetcher fetches level 1 keys from the source AS.
+type Lvl1Fetcher interface {
+	// FetchLvl1 fetches the level 1 key from the source AS to the local AS
+	// that is valid at valTime.
+	FetchLvl1(ctx context.Context, srcIA addr.IA, valTime time.Time) (drkey.Lvl1Key, error)
+}
+
+// Service provides the DRKeys of the local AS.
+type Service struct {
+	// IA is the local AS.
+	IA addr.IA
+	// SecretValues derives the secret values of the local AS.
+	SecretValues drkey.SecretValueFactory
+	// DB caches the level 1 keys fetched from the source ASes.
+	DB drkey.Lvl1DB
+	// Fetcher fetches level 1 keys that are not cached.
+	Fetcher Lvl1Fetcher
+}
+
+// DeriveLvl1 derives the level 1 key from the local AS to the destination AS
+// that is valid at valTime.
+func (s *Service) DeriveLvl1(dstIA addr.IA, valTime time.Time) (drkey.Lvl1Key, error) {
+	sv, err := s.SecretValues.GetSecretValue(valTime)
+	if err != nil {
+		return drkey.Lvl1Key{}, serrors.WrapStr("unable to get secret value", err)
+	}
+	meta := drkey.Lvl1Meta{Epoch: sv.Epoch, SrcIA: s.IA, DstIA: dstIA}
+	return drkey.DeriveLvl1(meta, sv)
+}
+
+// GetLvl1Key returns the level 1 key from the source AS to the local AS that is
+// valid at valTime. If the key is not cached, it is fetched from the source AS.
+func (s *Service) GetLvl1Key(ctx context.Context, srcIA addr.IA,
+	valTime time.Time) (drkey.Lvl1Key, error) {
+
+	if srcIA.Equal(s.IA) {
+		return s.DeriveLvl1(s.IA, valTime)
+	}
+	meta := drkey.Lvl1Meta{SrcIA: srcIA, DstIA: s.IA}
+	key, err := s.DB.GetLvl1Key(ctx, meta, util.TimeToSecs(valTime))
+	switch {
+	case err == nil:
+		return key, nil
+	case !errors.Is(err, drkey.ErrKeyNotFound):
+		return drkey.Lvl1Key{}, serrors.WrapStr("unable to get level 1 key from DB", err,
+			"src_ia", srcIA)
+	}
+	key, err = s.Fetcher.FetchLvl1(ctx, srcIA, valTime)
+	if err != nil {
+		return drkey.Lvl1Key{}, serrors.WrapStr("unable to fetch level 1 key", err,
+			"src_ia", srcIA)
+	}
+	if err := s.DB.InsertLvl1Key(ctx, key); err != nil {
+		log.FromCtx(ctx).Error("[drkey.Service] Unable to store level 1 key", "err", err)
+	}
+	return key, nil
+}
+
+// GetLvl2Key returns the level 2 key described by meta that is valid at
+// valTime. The epoch in meta is ignored. The local AS must be either the
+// source or the destination of the key.
+func (s *Service) GetLvl2Key(ctx context.Context, meta drkey.Lvl2Meta,
+	valTime time.Time) (drkey.Lvl2Key, error) {
+
+	var lvl1 drkey.Lvl1Key
+	var err error
+	switch {
+	case meta.SrcIA.Equal(s.IA):
+		lvl1, err = s.DeriveLvl1(meta.DstIA, valTime)
+	case meta.DstIA.Equal(s.IA):
+		lvl1, err = s.GetLvl1Key(ctx, meta.SrcIA, valTime)
+	default:
+		return drkey.Lvl2Key{}, serrors.WithCtx(ErrNotLocal, "src_ia", meta.SrcIA,
+			"dst_ia", meta.DstIA)
+	}
+	if err != nil {
+		return drkey.Lvl2Key{}, err
+	}
+	meta.Epoch = lvl1.Epoch
+	return drkey.DeriveLvl2(meta, lvl1)
+}
@@ -37,17 +37,21 @@ import (
 	"github.com/scionproto/scion/go/cs/beaconing"
 	"github.com/scionproto/scion/go/cs/beaconstorage"
 	"github.com/scionproto/scion/go/cs/config"
+	"github.com/scionproto/scion/go/cs/drkey"
 	"github.com/scionproto/scion/go/cs/handlers"
 	"github.com/scionproto/scion/go/cs/ifstate"
 	"github.com/scionproto/scion/go/cs/keepalive"
 	"github.com/scionproto/scion/go/cs/metrics"
 	"github.com/scionproto/scion/go/cs/onehop"
+	"github.com/scionproto/scion/go/cs/reiss"
 	"github.com/scionproto/scion/go/cs/revocation"
 	"github.com/scionproto/scion/go/cs/segreq"
 	"github.com/scionproto/scion/go/cs/segsyncer"
 	"github.com/scionproto/scion/go/cs/segutil"
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
+	libdrkey "github.com/scionproto/scion/go/lib/drkey"
+	drkeysqlite "github.com/scionproto/scion/go/lib/drkey/sqlite"
 	"github.com/scionproto/scion/go/lib/env"
 	"github.com/scionproto/scion/go/lib/fatal"
 	"github.com/scionproto/scion/go/lib/infra"
@@ -80,9 +84,19 @@ var (
 	cfg config.Config
 
 	intfs *ifstate.Interfaces
-	tasks *periodicTasks
+	// tasks is read by the SIGHUP handler, and must only be accessed while
+	// holding tasksMtx.
+	tasks    *periodicTasks
+	tasksMtx sync.Mutex
+	// pkcs11Ring is the key ring of the pkcs11 key backend. It holds an open
+	// session to the token and is shared by all users of the key ring.
+	pkcs11Ring *keyconf.PKCS11Ring
 
 	helpPolicy bool
+
+	// signedTypes are the message types that are signed by the messenger.
+	signedTypes = []infra.MessageType{infra.Seg, infra.ChainIssueRequest,
+		infra.DRKeyLvl1Request}
 )
 
 func init() {
@@ -130,6 +144,19 @@ func realMain() int {
 		log.Crit("Unable to find topo address for CS module")
 		return 1
 	}
+	if cfg.CS.KeyBackend.Type == config.KeyBackendPKCS11 {
+		pkcs11Ring, err = keyconf.NewPKCS11Ring(filepath.Join(cfg.General.ConfigDir, "keys"),
+			topo.IA(), keyconf.PKCS11Conf{
+				Module:     cfg.CS.KeyBackend.Module,
+				TokenLabel: cfg.CS.KeyBackend.TokenLabel,
+				PIN:        cfg.CS.KeyBackend.PIN,
+			})
+		if err != nil {
+			log.Crit("Unable to open PKCS#11 key ring", "err", err)
+			return 1
+		}
+		defer pkcs11Ring.Close()
+	}
 
 	tracer, trCloser, err := cfg.Tracing.NewTracer(cfg.General.ID)
 	if err != nil {
@@ -200,6 +227,13 @@ func realMain() int {
 		},
 	}
 	inspector := trust.DefaultInspector{Provider: provider}
+	trcChecker := &trust.TRCChecker{
+		DB:       trustDB,
+		Recurser: provider.Recurser,
+		Resolver: provider.Resolver,
+		Router:   provider.Router,
+		Timeout:  5 * time.Second,
+	}
 
 	args := handlers.HandlerArgs{
 		PathDB:          pathDB,
@@ -239,11 +273,8 @@ func realMain() int {
 		return 1
 	}
 	gen := trust.SignerGen{
-		IA: topo.IA(),
-		KeyRing: keyconf.LoadingRing{
-			Dir: filepath.Join(cfg.General.ConfigDir, "keys"),
-			IA:  topo.IA(),
-		},
+		IA:       topo.IA(),
+		KeyRing:  keyRing(topo.IA()),
 		Provider: trustStore,
 	}
 	signer, err := gen.Signer(context.Background())
@@ -294,6 +325,44 @@ func realMain() int {
 		},
 	})
 
+	if topo.Core() {
+		msgr.AddHandler(infra.ChainIssueRequest, &reiss.Handler{
+			IA:          topo.IA(),
+			Store:       trustStore,
+			KeyRing:     keyRing(topo.IA()),
+			MaxValidity: cfg.CS.LeafValidity.Duration,
+			Timeout:     cfg.CS.ReissueTimeout.Duration,
+		})
+	}
+
+	if cfg.CS.DRKey.Enabled {
+		drkeyDB, err := drkeysqlite.New(cfg.CS.DRKey.Lvl1DB)
+		if err != nil {
+			log.Crit("Unable to initialize DRKey database", "err", err)
+			return 1
+		}
+		defer drkeyDB.Close()
+		service, crypto, err := newDRKeyService(topo.IA(), trustStore, trustRouter, msgr,
+			drkeyDB)
+		if err != nil {
+			log.Crit("Unable to initialize DRKey service", "err", err)
+			return 1
+		}
+		msgr.AddHandler(infra.DRKeyLvl1Request, &drkey.Lvl1Handler{
+			Service:  service,
+			Crypto:   crypto,
+			Verifier: trust.NewVerifier(trustStore),
+			Timeout:  cfg.CS.DRKey.Timeout.Duration,
+		})
+		tcpMsgr.AddHandler(infra.DRKeyLvl2Request, &drkey.Lvl2Handler{
+			Service: service,
+			Timeout: cfg.CS.DRKey.Timeout.Duration,
+		})
+		drkeyCleaner := periodic.Start(libdrkey.NewLvl1Cleaner(drkeyDB, "cs_drkey_lvl1"),
+			time.Hour, time.Minute)
+		defer drkeyCleaner.Stop()
+	}
+
 	tcpMsgr.AddHandler(infra.ChainRequest, chainReqHandler)
 	tcpMsgr.AddHandler(infra.TRCRequest, trcReqHandler)
 	tcpMsgr.AddHandler(infra.SegRequest, segReqHandler)
@@ -302,6 +371,7 @@ func realMain() int {
 	http.HandleFunc("/config", configHandler)
 	http.HandleFunc("/info", env.InfoHandler)
 	http.HandleFunc("/topology", itopo.TopologyHandler)
+	http.HandleFunc("/trust", trcChecker.StatusHandler)
 	cfg.Metrics.StartPrometheus()
 	go func() {
 		defer log.LogPanicAndExit()
@@ -330,16 +400,31 @@ func realMain() int {
 		log.Crit("Unable to create SCION packet conn", "err", err)
 		return 1
 	}
-	tasks = &periodicTasks{
+	masterKey, genMac, err := macGenFactory()
+	if err != nil {
+		log.Crit("Unable to initialize MAC generator", "err", err)
+		return 1
+	}
+	staticInfo, err := loadStaticInfo(cfg.BS.StaticInfoConfig)
+	if err != nil {
+		log.Crit("Unable to initialize static info", "err", err)
+		return 1
+	}
+	t := &periodicTasks{
 		args:         args,
 		intfs:        intfs,
 		conn:         conn.(*snet.SCIONPacketConn),
 		trustStore:   trustStore,
 		trustDB:      trustDB,
+		trustRouter:  trustRouter,
+		trcChecker:   trcChecker,
 		store:        beaconStore,
 		pathDB:       pathDB,
 		msgr:         msgr,
 		topoProvider: itopo.Provider(),
+		masterKey:    masterKey,
+		genMac:       genMac,
+		staticInfo:   staticInfo,
 		addressRewriter: nc.AddressRewriter(
 			&onehop.OHPPacketDispatcherService{
 				PacketDispatcherService: &snet.DefaultPacketDispatcherService{
@@ -348,20 +433,20 @@ func realMain() int {
 			},
 		),
 	}
-	msgr.UpdateSigner(signer, []infra.MessageType{infra.Seg, infra.ChainIssueRequest})
+	msgr.UpdateSigner(signer, signedTypes)
 	// TODO(scrye): this breaks Interface Keepalives if it is enabled
 	// msgr.UpdateVerifier(trust.NewVerifier(trustStore))
 
-	if tasks.genMac, err = macGenFactory(); err != nil {
-		log.Crit("Unable to initialize MAC generator", "err", err)
-		return 1
-	}
-
-	if err := tasks.Start(); err != nil {
+	if err := t.Start(); err != nil {
 		log.Crit("Unable to start tasks", "err", err)
 		return 1
 	}
-	defer tasks.Kill()
+	defer t.Kill()
+	// Only publish the tasks to the SIGHUP handler once they are fully
+	// initialized.
+	tasksMtx.Lock()
+	tasks = t
+	tasksMtx.Unlock()
 
 	select {
 	case <-fatal.ShutdownChan():
@@ -389,9 +474,13 @@ type periodicTasks struct {
 	args            handlers.HandlerArgs
 	intfs           *ifstate.Interfaces
 	conn            *snet.SCIONPacketConn
+	masterKey       []byte
 	genMac          func() hash.Hash
+	staticInfo      *beaconing.StaticInfoCfg
 	trustStore      trust.Store
 	trustDB         trust.DB
+	trustRouter     snet.Router
+	trcChecker      *trust.TRCChecker
 	store           beaconstorage.Store
 	pathDB          pathdb.PathDB
 	msgr            infra.Messenger
@@ -407,6 +496,7 @@ type periodicTasks struct {
 
 	corePusher *periodic.Runner
 	reissuance *periodic.Runner
+	trcCheck   *periodic.Runner
 
 	beaconCleaner *periodic.Runner
 	revCleaner    *periodic.Runner
@@ -418,6 +508,9 @@ type periodicTasks struct {
 
 	mtx     sync.Mutex
 	running bool
+	// restartMtx serializes restarts of the tasks, e.g., after a master key
+	// rollover and after a certificate chain renewal.
+	restartMtx sync.Mutex
 }
 
 func (t *periodicTasks) Start() error {
@@ -458,7 +551,11 @@ func (t *periodicTasks) Start() error {
 		beaconstorage.NewRevocationCleaner(t.store), 5*time.Second, 5*time.Second)
 
 	// t.corePusher = t.startCorePusher()
-	// t.reissuance = t.startReissuance(t.corePusher)
+	if cfg.CS.AutomaticRenewal {
+		t.reissuance = t.startReissuance()
+	}
+	t.trcCheck = periodic.Start(t.trcChecker, cfg.CS.TRCCheckInterval.Duration,
+		cfg.CS.TRCCheckInterval.Duration)
 
 	if cfg.PS.SegSync && itopo.Get().Core() {
 		t.segSyncers, err = segsyncer.StartAll(t.args, t.msgr)
@@ -543,6 +640,7 @@ func (t *periodicTasks) startOriginator(a *net.UDPAddr) (*periodic.Runner, error
 			MTU:           topo.MTU(),
 			Signer:        signer,
 			GetMaxExpTime: maxExpTimeFactory(t.store, beacon.PropPolicy),
+			StaticInfo:    t.staticInfo,
 		},
 		Period: cfg.BS.OriginationInterval.Duration,
 	}.New()
@@ -579,6 +677,7 @@ func (t *periodicTasks) startPropagator(a *net.UDPAddr) (*periodic.Runner, error
 			MTU:           topo.MTU(),
 			Signer:        signer,
 			GetMaxExpTime: maxExpTimeFactory(t.store, beacon.PropPolicy),
+			StaticInfo:    t.staticInfo,
 		},
 		Period: cfg.BS.PropagationInterval.Duration,
 	}.New()
@@ -631,6 +730,7 @@ func (t *periodicTasks) startRegistrar(topo topology.Topology, segType proto.Pat
 			MTU:           topo.MTU(),
 			Signer:        signer,
 			GetMaxExpTime: maxExpTimeFactory(t.store, policyType),
+			StaticInfo:    t.staticInfo,
 		},
 	}.New()
 	if err != nil {
@@ -640,16 +740,54 @@ func (t *periodicTasks) startRegistrar(topo topology.Topology, segType proto.Pat
 		cfg.BS.RegistrationInterval.Duration), nil
 }
 
+func (t *periodicTasks) startReissuance() *periodic.Runner {
+	topo := t.topoProvider.Get()
+	keyDir := filepath.Join(cfg.General.ConfigDir, "keys")
+	r := &reiss.Requester{
+		IA:        topo.IA(),
+		Store:     t.trustStore,
+		KeyRing:   keyRing(topo.IA()),
+		KeyDir:    keyDir,
+		Msgr:      t.msgr,
+		Router:    t.trustRouter,
+		LeadTime:  cfg.CS.LeafReissueLeadTime.Duration,
+		Timeout:   cfg.CS.ReissueTimeout.Duration,
+		OnRenewal: t.reloadSigner,
+	}
+	return periodic.Start(r, cfg.CS.ReissueRate.Duration, cfg.CS.ReissueRate.Duration)
+}
+
+// reloadSigner is called after the certificate chain has been renewed. The
+// messenger signer is updated and the periodic tasks are restarted, such that
+// they sign with the renewed certificate chain and key.
+func (t *periodicTasks) reloadSigner(ctx context.Context) {
+	signer, err := t.createSigner(t.topoProvider.Get().IA())
+	if err != nil {
+		log.FromCtx(ctx).Error("Unable to create signer for renewed chain", "err", err)
+		return
+	}
+	t.msgr.UpdateSigner(signer, signedTypes)
+	// Killing the tasks waits for the reissuance task that calls this method,
+	// thus the tasks are restarted asynchronously.
+	go func() {
+		defer log.LogPanicAndExit()
+		t.restartMtx.Lock()
+		defer t.restartMtx.Unlock()
+		t.Kill()
+		log.Info("Certificate chain renewed, restarting periodic tasks")
+		if err := t.Start(); err != nil {
+			log.Error("Unable to restart periodic tasks", "err", err)
+		}
+	}()
+}
+
 func (t *periodicTasks) createSigner(ia addr.IA) (infra.Signer, error) {
 	ctx, cancelF := context.WithTimeout(context.Background(), time.Second)
 	defer cancelF()
 	gen := trust.SignerGen{
 		IA:       itopo.Get().IA(),
 		Provider: t.trustStore,
-		KeyRing: keyconf.LoadingRing{
-			Dir: filepath.Join(cfg.General.ConfigDir, "keys"),
-			IA:  ia,
-		},
+		KeyRing:  keyRing(ia),
 	}
 	return gen.Signer(ctx)
 }
@@ -669,6 +807,7 @@ func (t *periodicTasks) Kill() {
 	t.beaconCleaner.Kill()
 	t.revCleaner.Kill()
 	t.reissuance.Kill()
+	t.trcCheck.Kill()
 	t.corePusher.Kill()
 	for i := range t.segSyncers {
 		syncer := t.segSyncers[i]
@@ -681,16 +820,90 @@ func (t *periodicTasks) Kill() {
 	log.Info("Stopped periodic tasks.")
 }
 
-func macGenFactory() (func() hash.Hash, error) {
+// ReloadMasterKey reloads the master keys from the config directory. If the
+// active master key (Key0) changed, the tasks are restarted such that new hop
+// fields are created with the new key. The border routers must have been
+// reloaded before, such that they accept hop fields created with either key.
+func (t *periodicTasks) ReloadMasterKey() error {
+	key, genMac, err := macGenFactory()
+	if err != nil {
+		return err
+	}
+	t.restartMtx.Lock()
+	defer t.restartMtx.Unlock()
+	t.mtx.Lock()
+	changed := !bytes.Equal(t.masterKey, key)
+	t.mtx.Unlock()
+	if !changed {
+		return nil
+	}
+	t.Kill()
+	t.mtx.Lock()
+	t.masterKey, t.genMac = key, genMac
+	t.mtx.Unlock()
+	log.Info("Master key rollover, restarting periodic tasks")
+	return t.Start()
+}
+
+// keyRing returns the key ring of the configured key backend.
+func keyRing(ia addr.IA) trust.KeyRing {
+	switch cfg.CS.KeyBackend.Type {
+	case config.KeyBackendProcess:
+		return keyconf.ProcessRing{
+			Socket:  cfg.CS.KeyBackend.Socket,
+			IA:      ia,
+			Timeout: cfg.CS.KeyBackend.Timeout.Duration,
+		}
+	case config.KeyBackendPKCS11:
+		return pkcs11Ring
+	}
+	return keyconf.LoadingRing{Dir: filepath.Join(cfg.General.ConfigDir, "keys"), IA: ia}
+}
+
+// newDRKeyService creates the DRKey service and the crypto used for the level 1
+// key exchange. The secret values are derived from the active master key.
+func newDRKeyService(ia addr.IA, store trust.Store, router snet.Router,
+	msgr drkey.Lvl1Requester, db libdrkey.Lvl1DB) (*drkey.Service, drkey.Crypto, error) {
+
+	keyDir := filepath.Join(cfg.General.ConfigDir, "keys")
+	mk, err := keyconf.LoadMaster(keyDir)
+	if err != nil {
+		return nil, drkey.Crypto{}, err
+	}
+	crypto := drkey.Crypto{
+		IA:      ia,
+		Chains:  store,
+		KeyRing: keyconf.LoadingRing{Dir: keyDir, IA: ia},
+	}
+	service := &drkey.Service{
+		IA: ia,
+		SecretValues: libdrkey.SecretValueFactory{
+			MasterKey:     mk.Key0,
+			EpochDuration: cfg.CS.DRKey.EpochDuration.Duration,
+		},
+		DB: db,
+		Fetcher: &drkey.Fetcher{
+			IA:     ia,
+			Msgr:   msgr,
+			Router: router,
+			Crypto: crypto,
+		},
+	}
+	return service, crypto, nil
+}
+
+// macGenFactory loads the active master key (Key0) and returns it together
+// with the factory for hop field MAC instances.
+func macGenFactory() ([]byte, func() hash.Hash, error) {
 	mk, err := keyconf.LoadMaster(filepath.Join(cfg.General.ConfigDir, "keys"))
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	hfMacFactory, err := scrypto.HFMacFactory(mk.Key0)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return hfMacFactory, nil
+	return mk.Key0, hfMacFactory, nil
 }
 
 func maxExpTimeFactory(store beaconstorage.Store, p beacon.PolicyType) func() spath.ExpTimeType {
@@ -741,10 +954,24 @@ func initTopo(topo topology.Topology) error {
 	if err := itopo.Update(topo); err != nil {
 		return serrors.WrapStr("Unable to set initial static topology", err)
 	}
-	infraenv.InitInfraEnvironment(cfg.General.Topology)
+	infraenv.InitInfraEnvironmentFunc(cfg.General.Topology, reloadMasterKey)
 	return nil
 }
 
+// reloadMasterKey is called on SIGHUP to pick up a rotated master key.
+func reloadMasterKey() {
+	tasksMtx.Lock()
+	t := tasks
+	tasksMtx.Unlock()
+	if t == nil {
+		log.Info("Periodic tasks not started yet, ignoring master key reload")
+		return
+	}
+	if err := t.ReloadMasterKey(); err != nil {
+		log.Error("Unable to reload master key", "err", err)
+	}
+}
+
 func loadStore(core bool, ia addr.IA, cfg config.Config) (beaconstorage.Store, error) {
 	if core {
 		policies, err := loadCorePolicies(cfg.BS.Policies)
@@ -800,6 +1027,20 @@ func loadPolicy(fn string, t beacon.PolicyType) (beacon.Policy, error) {
 	return policy, nil
 }
 
+// loadStaticInfo loads the static info configuration. If no file is
+// configured, nil is returned and no static info extension is added to the
+// beacons.
+func loadStaticInfo(fn string) (*beaconing.StaticInfoCfg, error) {
+	if fn == "" {
+		return nil, nil
+	}
+	staticInfo, err := beaconing.LoadStaticInfoCfg(fn)
+	if err != nil {
+		return nil, common.NewBasicError("Unable to load static info config", err, "fn", fn)
+	}
+	return staticInfo, nil
+}
+
 func checkFlags(cfg *config.Config) (int, bool) {
 	if helpPolicy {
 		var sample beacon.Policy
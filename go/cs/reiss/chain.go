@@ -0,0 +1,67 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reiss
+
+import (
+	"context"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/infra/modules/trust"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/scrypto/cert"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// Store provides and inserts certificate chains.
+type Store interface {
+	// GetRawChain returns the raw certificate chain.
+	GetRawChain(ctx context.Context, id trust.ChainID, opts infra.ChainOpts) ([]byte, error)
+	// InsertRawChain verifies the raw certificate chain and inserts it.
+	InsertRawChain(ctx context.Context, raw []byte) error
+}
+
+// chain is a decoded certificate chain.
+type chain struct {
+	cert.Chain
+	as     *cert.AS
+	issuer *cert.Issuer
+}
+
+func parseChain(raw []byte) (chain, error) {
+	c, err := cert.ParseChain(raw)
+	if err != nil {
+		return chain{}, serrors.WrapStr("unable to parse chain", err)
+	}
+	as, err := c.AS.Encoded.Decode()
+	if err != nil {
+		return chain{}, serrors.WrapStr("unable to decode AS certificate", err)
+	}
+	issuer, err := c.Issuer.Encoded.Decode()
+	if err != nil {
+		return chain{}, serrors.WrapStr("unable to decode issuer certificate", err)
+	}
+	return chain{Chain: c, as: as, issuer: issuer}, nil
+}
+
+func latestChain(ctx context.Context, store Store, ia addr.IA,
+	opts infra.ChainOpts) (chain, error) {
+
+	raw, err := store.GetRawChain(ctx, trust.ChainID{IA: ia, Version: scrypto.LatestVer}, opts)
+	if err != nil {
+		return chain{}, serrors.WrapStr("unable to get latest chain", err, "ia", ia)
+	}
+	return parseChain(raw)
+}
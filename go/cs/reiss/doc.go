@@ -0,0 +1,32 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package reiss implements the certificate chain reissuance.
+//
+// Handler
+//
+// The handler serves chain issuance requests in issuer ASes. The renewal
+// request must be signed with the signing key authenticated by the currently
+// active certificate chain of the subject, and contain proofs of possession for
+// all new keys. If the request complies with the issuance policy, a new AS
+// certificate is issued with the issuer certificate of the local AS.
+//
+// Requester
+//
+// The requester is a periodic task that runs in non-issuer ASes. Before the
+// currently active certificate chain expires, it generates new keys, requests a
+// new certificate chain from the issuer AS and inserts it into the trust store.
+// The new private keys are written to the key directory such that signers that
+// are created after the renewal use the new certificate chain.
+package reiss
@@ -0,0 +1,254 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reiss
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/cert_mgmt"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/infra/messenger"
+	"github.com/scionproto/scion/go/lib/infra/modules/trust"
+	"github.com/scionproto/scion/go/lib/keyconf"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/scrypto/cert"
+	"github.com/scionproto/scion/go/lib/scrypto/cert/renewal"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/proto"
+)
+
+var (
+	// ErrNotIssuer indicates that the local AS is not an issuer AS.
+	ErrNotIssuer = serrors.New("local AS is not an issuer")
+	// ErrPolicyViolation indicates that the request does not comply with the
+	// issuance policy.
+	ErrPolicyViolation = serrors.New("policy violation")
+)
+
+// Handler handles chain issuance requests in issuer ASes.
+type Handler struct {
+	// IA is the local issuer AS.
+	IA addr.IA
+	// Store provides the certificate chains and stores the issued ones.
+	Store Store
+	// KeyRing provides the private issuer certificate signing key.
+	KeyRing trust.KeyRing
+	// MaxValidity is the maximum validity period of issued AS certificates.
+	MaxValidity time.Duration
+	// Timeout is the timeout for handling a single request.
+	Timeout time.Duration
+}
+
+// Handle handles chain issuance requests.
+func (h *Handler) Handle(r *infra.Request) *infra.HandlerResult {
+	logger := log.FromCtx(r.Context())
+	req, ok := r.Message.(*cert_mgmt.ChainIssReq)
+	if !ok {
+		logger.Error("[reiss.Handler] Wrong message type, expected cert_mgmt.ChainIssReq",
+			"msg", r.Message, "type", common.TypeOf(r.Message))
+		return infra.MetricsErrInternal
+	}
+	rw, ok := infra.ResponseWriterFromContext(r.Context())
+	if !ok {
+		logger.Error("[reiss.Handler] Unable to service request, no ResponseWriter found")
+		return infra.MetricsErrInternal
+	}
+	ctx, cancelF := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancelF()
+	sendAck := messenger.SendAckHelper(ctx, rw)
+
+	logger.Debug("[reiss.Handler] Received chain issuance request", "req", req,
+		"peer", r.Peer)
+	raw, err := h.Issue(ctx, req.Raw)
+	if err != nil {
+		logger.Error("[reiss.Handler] Unable to issue certificate chain", "req", req,
+			"peer", r.Peer, "err", err)
+		sendAck(proto.Ack_ErrCode_reject, err.Error())
+		return infra.MetricsErrInvalid
+	}
+	if err := rw.SendChainIssueReply(ctx, &cert_mgmt.ChainIssRep{RawChain: raw}); err != nil {
+		logger.Error("[reiss.Handler] Unable to send reply", "err", err)
+		return infra.MetricsErrMsger(err)
+	}
+	logger.Info("[reiss.Handler] Issued certificate chain", "req", req, "peer", r.Peer)
+	return infra.MetricsResultOk
+}
+
+// Issue verifies the raw signed renewal request, checks that it complies with
+// the issuance policy and issues a new certificate chain. The issued chain is
+// inserted into the store, and returned in its raw format.
+func (h *Handler) Issue(ctx context.Context, raw []byte) ([]byte, error) {
+	signed, err := renewal.ParseSignedRequest(raw)
+	if err != nil {
+		return nil, serrors.WrapStr("unable to parse request", err)
+	}
+	request, err := signed.Encoded.Decode()
+	if err != nil {
+		return nil, serrors.WrapStr("unable to decode request", err)
+	}
+	unverified, err := request.Encoded.Decode()
+	if err != nil {
+		return nil, serrors.WrapStr("unable to decode request info", err)
+	}
+	current, err := latestChain(ctx, h.Store, unverified.Subject, infra.ChainOpts{})
+	if err != nil {
+		return nil, err
+	}
+	info, err := signed.Verify(current.as.Keys[cert.SigningKey])
+	if err != nil {
+		return nil, serrors.WrapStr("unable to verify request", err,
+			"subject", unverified.Subject)
+	}
+	local, err := latestChain(ctx, h.Store, h.IA, infra.ChainOpts{
+		TrustStoreOpts: infra.TrustStoreOpts{LocalOnly: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !local.issuer.Subject.Equal(h.IA) {
+		return nil, serrors.WithCtx(ErrNotIssuer, "ia", h.IA, "issuer", local.issuer.Subject)
+	}
+	validity, err := h.checkPolicy(info, current, local.issuer, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	keys := map[cert.KeyType]scrypto.KeyMeta{
+		cert.SigningKey:    keyMeta(info.Keys.Signing),
+		cert.RevocationKey: keyMeta(info.Keys.Revocation),
+		cert.EncryptionKey: current.as.Keys[cert.EncryptionKey],
+	}
+	as := &cert.AS{
+		Base: cert.Base{
+			Subject:                    info.Subject,
+			Version:                    info.Version,
+			FormatVersion:              info.FormatVersion,
+			Description:                info.Description,
+			OptionalDistributionPoints: info.OptionalDistributionPoints,
+			Validity:                   &validity,
+			Keys:                       keys,
+		},
+		Issuer: cert.IssuerCertID{
+			IA:                 h.IA,
+			CertificateVersion: local.issuer.Version,
+		},
+	}
+	signedAS, err := h.sign(as, local.issuer)
+	if err != nil {
+		return nil, err
+	}
+	rawChain, err := json.Marshal(cert.Chain{Issuer: local.Issuer, AS: signedAS})
+	if err != nil {
+		return nil, serrors.WrapStr("unable to marshal chain", err)
+	}
+	if err := h.Store.InsertRawChain(ctx, rawChain); err != nil {
+		return nil, serrors.WrapStr("unable to insert issued chain", err)
+	}
+	return rawChain, nil
+}
+
+// checkPolicy checks that the request complies with the issuance policy and
+// returns the validity period of the certificate to issue. The requested
+// validity period is capped to the maximum validity and the validity of the
+// issuer certificate.
+func (h *Handler) checkPolicy(info renewal.RequestInfo, current chain,
+	issuer *cert.Issuer, now time.Time) (scrypto.Validity, error) {
+
+	switch {
+	case !info.Issuer.Equal(h.IA):
+		return scrypto.Validity{}, serrors.WithCtx(ErrPolicyViolation,
+			"reason", "request addressed to other issuer", "issuer", info.Issuer)
+	case !current.as.Issuer.IA.Equal(h.IA):
+		return scrypto.Validity{}, serrors.WithCtx(ErrPolicyViolation,
+			"reason", "subject not issued by local AS", "issuer", current.as.Issuer.IA)
+	case info.Subject.I != h.IA.I:
+		return scrypto.Validity{}, serrors.WithCtx(ErrPolicyViolation,
+			"reason", "subject in different ISD", "subject", info.Subject)
+	case info.Version <= current.as.Version:
+		return scrypto.Validity{}, serrors.WithCtx(ErrPolicyViolation,
+			"reason", "version not increased", "requested", info.Version,
+			"current", current.as.Version)
+	case info.Keys.Signing.KeyVersion <= current.as.Keys[cert.SigningKey].KeyVersion:
+		return scrypto.Validity{}, serrors.WithCtx(ErrPolicyViolation,
+			"reason", "signing key version not increased",
+			"requested", info.Keys.Signing.KeyVersion,
+			"current", current.as.Keys[cert.SigningKey].KeyVersion)
+	case info.Validity == nil:
+		return scrypto.Validity{}, serrors.WithCtx(ErrPolicyViolation,
+			"reason", "validity not set")
+	}
+	validity := *info.Validity
+	if validity.NotBefore.Before(now.Add(-h.MaxValidity)) {
+		return scrypto.Validity{}, serrors.WithCtx(ErrPolicyViolation,
+			"reason", "validity starts too far in the past", "validity", validity)
+	}
+	if max := validity.NotBefore.Add(h.MaxValidity); validity.NotAfter.After(max) {
+		validity.NotAfter = util.UnixTime{Time: max}
+	}
+	if validity.NotAfter.After(issuer.Validity.NotAfter.Time) {
+		validity.NotAfter = issuer.Validity.NotAfter
+	}
+	if !issuer.Validity.Covers(validity) || !validity.NotAfter.After(validity.NotBefore.Time) {
+		return scrypto.Validity{}, serrors.WithCtx(ErrPolicyViolation,
+			"reason", "validity not covered by issuer certificate",
+			"validity", validity, "issuer", issuer.Validity)
+	}
+	return validity, nil
+}
+
+func (h *Handler) sign(as *cert.AS, issuer *cert.Issuer) (cert.SignedAS, error) {
+	if err := as.Validate(); err != nil {
+		return cert.SignedAS{}, serrors.WrapStr("invalid AS certificate", err)
+	}
+	issuingKey := issuer.Keys[cert.IssuingKey]
+	signer, err := h.KeyRing.Signer(keyconf.IssCertSigningKey, issuingKey.KeyVersion)
+	if err != nil {
+		return cert.SignedAS{}, serrors.WrapStr("unable to load issuing key", err,
+			"key_version", issuingKey.KeyVersion)
+	}
+	encoded, err := cert.EncodeAS(as)
+	if err != nil {
+		return cert.SignedAS{}, serrors.WrapStr("unable to encode AS certificate", err)
+	}
+	protected, err := cert.EncodeProtectedAS(cert.ProtectedAS{
+		Algorithm:          issuingKey.Algorithm,
+		IA:                 issuer.Subject,
+		CertificateVersion: issuer.Version,
+	})
+	if err != nil {
+		return cert.SignedAS{}, serrors.WrapStr("unable to encode protected", err)
+	}
+	signed := cert.SignedAS{
+		Encoded:          encoded,
+		EncodedProtected: protected,
+	}
+	if signed.Signature, err = signer.Sign(signed.SigInput()); err != nil {
+		return cert.SignedAS{}, serrors.WrapStr("unable to sign AS certificate", err)
+	}
+	return signed, nil
+}
+
+func keyMeta(m renewal.KeyMeta) scrypto.KeyMeta {
+	return scrypto.KeyMeta{
+		KeyVersion: m.KeyVersion,
+		Algorithm:  m.Algorithm,
+		Key:        m.Key,
+	}
+}
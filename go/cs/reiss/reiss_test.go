@@ -0,0 +1,497 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reiss_test
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/cs/reiss"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/ctrl/cert_mgmt"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/infra/modules/trust"
+	"github.com/scionproto/scion/go/lib/keyconf"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/scrypto/cert"
+	"github.com/scionproto/scion/go/lib/scrypto/cert/renewal"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet/mock_snet"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+var (
+	issuerIA  = xtest.MustParseIA("1-ff00:0:110")
+	subjectIA = xtest.MustParseIA("1-ff00:0:111")
+)
+
+func TestRenewal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	dir, cleanF := tempDir(t)
+	defer cleanF()
+
+	now := time.Now()
+	m := newMaterial(t, now.Add(-71*time.Hour), now.Add(time.Hour))
+	writeKey(t, dir, m.subjectKey)
+
+	path := mock_snet.NewMockPath(ctrl)
+	path.EXPECT().Destination().Return(issuerIA).AnyTimes()
+	path.EXPECT().Path().AnyTimes()
+	path.EXPECT().OverlayNextHop().AnyTimes()
+	router := mock_snet.NewMockRouter(ctrl)
+	router.EXPECT().Route(gomock.Any(), issuerIA).Return(path, nil)
+
+	handler := &reiss.Handler{
+		IA:          issuerIA,
+		Store:       m.store,
+		KeyRing:     m.issuerRing,
+		MaxValidity: 72 * time.Hour,
+		Timeout:     time.Second,
+	}
+	renewed := false
+	requester := &reiss.Requester{
+		IA:        subjectIA,
+		Store:     m.store,
+		KeyRing:   keyconf.LoadingRing{Dir: dir, IA: subjectIA},
+		KeyDir:    dir,
+		Msgr:      handlerMsgr{handler: handler},
+		Router:    router,
+		LeadTime:  6 * time.Hour,
+		Timeout:   time.Second,
+		OnRenewal: func(context.Context) { renewed = true },
+	}
+	requester.Run(context.Background())
+	require.True(t, renewed)
+
+	c, as := m.store.latest(t, subjectIA)
+	assert.Equal(t, scrypto.Version(2), as.Version)
+	assert.Equal(t, issuerIA, as.Issuer.IA)
+	assert.True(t, as.Validity.NotAfter.After(now.Add(71*time.Hour)))
+	assert.Equal(t, m.encryption, as.Keys[cert.EncryptionKey])
+	err := cert.ASVerifier{Issuer: m.issuer, AS: as, SignedAS: &c.AS}.Verify()
+	require.NoError(t, err)
+
+	// The new private key is available to signers created after the renewal.
+	ring := keyconf.LoadingRing{Dir: dir, IA: subjectIA}
+	key, err := ring.PrivateKey(keyconf.ASSigningKey, as.Keys[cert.SigningKey].KeyVersion)
+	require.NoError(t, err)
+	pub, err := scrypto.GetPubKey(key.Bytes, key.Algorithm)
+	require.NoError(t, err)
+	assert.Equal(t, []byte(as.Keys[cert.SigningKey].Key), pub)
+
+	// The renewed chain is not about to expire.
+	requester.Run(context.Background())
+}
+
+func TestRequesterNotExpiring(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	now := time.Now()
+	m := newMaterial(t, now.Add(-time.Hour), now.Add(71*time.Hour))
+	requester := &reiss.Requester{
+		IA:        subjectIA,
+		Store:     m.store,
+		Router:    mock_snet.NewMockRouter(ctrl),
+		LeadTime:  6 * time.Hour,
+		Timeout:   time.Second,
+		OnRenewal: func(context.Context) { t.Fatal("unexpected renewal") },
+	}
+	requester.Run(context.Background())
+}
+
+func TestRequesterInsertFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	dir, cleanF := tempDir(t)
+	defer cleanF()
+
+	now := time.Now()
+	m := newMaterial(t, now.Add(-71*time.Hour), now.Add(time.Hour))
+	writeKey(t, dir, m.subjectKey)
+
+	path := mock_snet.NewMockPath(ctrl)
+	path.EXPECT().Destination().Return(issuerIA).AnyTimes()
+	path.EXPECT().Path().AnyTimes()
+	path.EXPECT().OverlayNextHop().AnyTimes()
+	router := mock_snet.NewMockRouter(ctrl)
+	router.EXPECT().Route(gomock.Any(), issuerIA).Return(path, nil)
+
+	handler := &reiss.Handler{
+		IA:          issuerIA,
+		Store:       m.store,
+		KeyRing:     m.issuerRing,
+		MaxValidity: 72 * time.Hour,
+		Timeout:     time.Second,
+	}
+	requester := &reiss.Requester{
+		IA:        subjectIA,
+		Store:     failingStore{chainStore: m.store},
+		KeyRing:   keyconf.LoadingRing{Dir: dir, IA: subjectIA},
+		KeyDir:    dir,
+		Msgr:      handlerMsgr{handler: handler},
+		Router:    router,
+		LeadTime:  6 * time.Hour,
+		Timeout:   time.Second,
+		OnRenewal: func(context.Context) { t.Fatal("unexpected renewal") },
+	}
+	requester.Run(context.Background())
+
+	// No keys are written for a chain that was not inserted.
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, m.subjectKey.File(), files[0].Name())
+}
+
+func TestHandlerIssue(t *testing.T) {
+	now := time.Now()
+	tests := map[string]struct {
+		Modify      func(info *renewal.RequestInfo, current *renewal.PrivateKey)
+		ExpectedErr error
+	}{
+		"valid": {
+			Modify: func(*renewal.RequestInfo, *renewal.PrivateKey) {},
+		},
+		"wrong issuer": {
+			Modify: func(info *renewal.RequestInfo, _ *renewal.PrivateKey) {
+				info.Issuer = xtest.MustParseIA("1-ff00:0:112")
+			},
+			ExpectedErr: reiss.ErrPolicyViolation,
+		},
+		"version not increased": {
+			Modify: func(info *renewal.RequestInfo, _ *renewal.PrivateKey) {
+				info.Version = 1
+			},
+			ExpectedErr: reiss.ErrPolicyViolation,
+		},
+		"validity in the past": {
+			Modify: func(info *renewal.RequestInfo, _ *renewal.PrivateKey) {
+				info.Validity.NotBefore.Time = now.Add(-100 * time.Hour)
+			},
+			ExpectedErr: reiss.ErrPolicyViolation,
+		},
+		"not signed with current key": {
+			Modify: func(_ *renewal.RequestInfo, current *renewal.PrivateKey) {
+				_, current.Key, _ = scrypto.GenKeyPair(scrypto.Ed25519)
+			},
+			ExpectedErr: renewal.ErrInvalidSignature,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := newMaterial(t, now.Add(-71*time.Hour), now.Add(time.Hour))
+			handler := &reiss.Handler{
+				IA:          issuerIA,
+				Store:       m.store,
+				KeyRing:     m.issuerRing,
+				MaxValidity: 72 * time.Hour,
+			}
+			signing, signingMeta := newKey(t, keyconf.ASSigningKey, subjectIA, 2)
+			revocation, revocationMeta := newKey(t, keyconf.ASRevocationKey, subjectIA, 2)
+			info := &renewal.RequestInfo{
+				Subject:                    subjectIA,
+				Version:                    2,
+				FormatVersion:              1,
+				Description:                "AS certificate",
+				OptionalDistributionPoints: []addr.IA{},
+				Validity: &scrypto.Validity{
+					NotBefore: util.UnixTime{Time: now},
+					NotAfter:  util.UnixTime{Time: now.Add(100 * time.Hour)},
+				},
+				Keys: renewal.Keys{
+					Signing:    renewalMeta(signingMeta),
+					Revocation: renewalMeta(revocationMeta),
+				},
+				Issuer:      issuerIA,
+				RequestTime: util.UnixTime{Time: now},
+			}
+			current := privateKey(m.subjectKey)
+			test.Modify(info, &current)
+			signed, err := renewal.NewSignedRequest(info, privateKey(signing),
+				privateKey(revocation), current)
+			require.NoError(t, err)
+			raw, err := json.Marshal(signed)
+			require.NoError(t, err)
+
+			rawChain, err := handler.Issue(context.Background(), raw)
+			if test.ExpectedErr != nil {
+				assert.Truef(t, errors.Is(err, test.ExpectedErr),
+					"expected: %v, actual: %v", test.ExpectedErr, err)
+				return
+			}
+			require.NoError(t, err)
+			c, as := m.store.latest(t, subjectIA)
+			stored, err := json.Marshal(c)
+			require.NoError(t, err)
+			assert.Equal(t, stored, rawChain)
+			// The validity is capped to the maximum validity.
+			assert.Equal(t, 72*time.Hour, as.Validity.NotAfter.Sub(as.Validity.NotBefore.Time))
+		})
+	}
+}
+
+type material struct {
+	store      *chainStore
+	issuer     *cert.Issuer
+	issuerRing keyRing
+	subjectKey keyconf.Key
+	encryption scrypto.KeyMeta
+}
+
+// newMaterial creates an issuer certificate for the issuer AS, and a
+// certificate chain for the subject AS with the given validity.
+func newMaterial(t *testing.T, notBefore, notAfter time.Time) material {
+	t.Helper()
+	issuingKey, issuingMeta := newKey(t, keyconf.IssCertSigningKey, issuerIA, 1)
+	issuer := &cert.Issuer{
+		Base: cert.Base{
+			Subject:                    issuerIA,
+			Version:                    1,
+			FormatVersion:              1,
+			Description:                "issuer certificate",
+			OptionalDistributionPoints: []addr.IA{},
+			Validity: &scrypto.Validity{
+				NotBefore: util.UnixTime{Time: time.Now().Add(-7 * 24 * time.Hour)},
+				NotAfter:  util.UnixTime{Time: time.Now().Add(7 * 24 * time.Hour)},
+			},
+			Keys: map[cert.KeyType]scrypto.KeyMeta{cert.IssuingKey: issuingMeta},
+		},
+		Issuer: cert.IssuerTRC{TRCVersion: 1},
+	}
+	encIssuer, err := cert.EncodeIssuer(issuer)
+	require.NoError(t, err)
+	protIssuer, err := cert.EncodeProtectedIssuer(cert.ProtectedIssuer{
+		Algorithm:  scrypto.Ed25519,
+		TRCVersion: 1,
+	})
+	require.NoError(t, err)
+	signedIssuer := cert.SignedIssuer{
+		Encoded:          encIssuer,
+		EncodedProtected: protIssuer,
+		Signature:        []byte("not verified"),
+	}
+
+	subjectKey, signingMeta := newKey(t, keyconf.ASSigningKey, subjectIA, 1)
+	encPub, _, err := scrypto.GenKeyPair(scrypto.Curve25519xSalsa20Poly1305)
+	require.NoError(t, err)
+	encryption := scrypto.KeyMeta{
+		KeyVersion: 1,
+		Algorithm:  scrypto.Curve25519xSalsa20Poly1305,
+		Key:        encPub,
+	}
+	as := &cert.AS{
+		Base: cert.Base{
+			Subject:                    subjectIA,
+			Version:                    1,
+			FormatVersion:              1,
+			Description:                "AS certificate",
+			OptionalDistributionPoints: []addr.IA{},
+			Validity: &scrypto.Validity{
+				NotBefore: util.UnixTime{Time: notBefore},
+				NotAfter:  util.UnixTime{Time: notAfter},
+			},
+			Keys: map[cert.KeyType]scrypto.KeyMeta{
+				cert.SigningKey:    signingMeta,
+				cert.EncryptionKey: encryption,
+			},
+		},
+		Issuer: cert.IssuerCertID{IA: issuerIA, CertificateVersion: 1},
+	}
+	encAS, err := cert.EncodeAS(as)
+	require.NoError(t, err)
+	protAS, err := cert.EncodeProtectedAS(cert.ProtectedAS{
+		Algorithm:          scrypto.Ed25519,
+		IA:                 issuerIA,
+		CertificateVersion: 1,
+	})
+	require.NoError(t, err)
+	signedAS := cert.SignedAS{Encoded: encAS, EncodedProtected: protAS}
+	signedAS.Signature, err = scrypto.Sign(signedAS.SigInput(), issuingKey.Bytes,
+		issuingKey.Algorithm)
+	require.NoError(t, err)
+
+	store := &chainStore{chains: make(map[addr.IA][]byte)}
+	store.insert(t, cert.Chain{Issuer: signedIssuer, AS: signedAS})
+	// The issuer chain only needs to carry the issuer certificate.
+	store.insert(t, cert.Chain{Issuer: signedIssuer, AS: cert.SignedAS{
+		Encoded:          mustEncodeAS(t, issuerIA),
+		EncodedProtected: protAS,
+	}})
+	return material{
+		store:      store,
+		issuer:     issuer,
+		issuerRing: keyRing{issuingKey},
+		subjectKey: subjectKey,
+		encryption: encryption,
+	}
+}
+
+func mustEncodeAS(t *testing.T, ia addr.IA) cert.EncodedAS {
+	t.Helper()
+	enc, err := cert.EncodeAS(&cert.AS{
+		Base: cert.Base{
+			Subject:                    ia,
+			Version:                    1,
+			FormatVersion:              1,
+			Description:                "AS certificate",
+			OptionalDistributionPoints: []addr.IA{},
+			Validity: &scrypto.Validity{
+				NotBefore: util.UnixTime{Time: time.Now()},
+				NotAfter:  util.UnixTime{Time: time.Now().Add(time.Hour)},
+			},
+			Keys: map[cert.KeyType]scrypto.KeyMeta{},
+		},
+		Issuer: cert.IssuerCertID{IA: ia, CertificateVersion: 1},
+	})
+	require.NoError(t, err)
+	return enc
+}
+
+func newKey(t *testing.T, usage keyconf.Usage, ia addr.IA,
+	version scrypto.KeyVersion) (keyconf.Key, scrypto.KeyMeta) {
+
+	t.Helper()
+	pub, priv, err := scrypto.GenKeyPair(scrypto.Ed25519)
+	require.NoError(t, err)
+	key := keyconf.Key{
+		ID:        keyconf.ID{Usage: usage, IA: ia, Version: version},
+		Type:      keyconf.PrivateKey,
+		Algorithm: scrypto.Ed25519,
+		Bytes:     priv,
+	}
+	meta := scrypto.KeyMeta{KeyVersion: version, Algorithm: scrypto.Ed25519, Key: pub}
+	return key, meta
+}
+
+func privateKey(key keyconf.Key) renewal.PrivateKey {
+	return renewal.PrivateKey{
+		KeyVersion: key.Version,
+		Algorithm:  key.Algorithm,
+		Key:        key.Bytes,
+	}
+}
+
+func renewalMeta(m scrypto.KeyMeta) renewal.KeyMeta {
+	return renewal.KeyMeta{KeyVersion: m.KeyVersion, Algorithm: m.Algorithm, Key: m.Key}
+}
+
+func writeKey(t *testing.T, dir string, key keyconf.Key) {
+	t.Helper()
+	block := key.PEM()
+	err := ioutil.WriteFile(filepath.Join(dir, key.File()), pem.EncodeToMemory(&block), 0600)
+	require.NoError(t, err)
+}
+
+func tempDir(t *testing.T) (string, func()) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "reiss")
+	require.NoError(t, err)
+	return dir, func() { os.RemoveAll(dir) }
+}
+
+// chainStore keeps the latest certificate chain per AS without verification.
+type chainStore struct {
+	chains map[addr.IA][]byte
+}
+
+func (s *chainStore) GetRawChain(_ context.Context, id trust.ChainID,
+	_ infra.ChainOpts) ([]byte, error) {
+
+	raw, ok := s.chains[id.IA]
+	if !ok {
+		return nil, trust.ErrNotFound
+	}
+	return raw, nil
+}
+
+func (s *chainStore) InsertRawChain(_ context.Context, raw []byte) error {
+	c, err := cert.ParseChain(raw)
+	if err != nil {
+		return err
+	}
+	as, err := c.AS.Encoded.Decode()
+	if err != nil {
+		return err
+	}
+	s.chains[as.Subject] = raw
+	return nil
+}
+
+func (s *chainStore) insert(t *testing.T, c cert.Chain) {
+	t.Helper()
+	raw, err := json.Marshal(c)
+	require.NoError(t, err)
+	require.NoError(t, s.InsertRawChain(context.Background(), raw))
+}
+
+func (s *chainStore) latest(t *testing.T, ia addr.IA) (cert.Chain, *cert.AS) {
+	t.Helper()
+	c, err := cert.ParseChain(s.chains[ia])
+	require.NoError(t, err)
+	as, err := c.AS.Encoded.Decode()
+	require.NoError(t, err)
+	return c, as
+}
+
+// failingStore fails to insert any certificate chain.
+type failingStore struct {
+	*chainStore
+}
+
+func (failingStore) InsertRawChain(context.Context, []byte) error {
+	return serrors.New("internal error")
+}
+
+type keyRing struct {
+	key keyconf.Key
+}
+
+func (r keyRing) Signer(usage keyconf.Usage,
+	version scrypto.KeyVersion) (keyconf.Signer, error) {
+
+	if usage != r.key.Usage || version != r.key.Version {
+		return nil, trust.ErrNotFound
+	}
+	return keyconf.KeySigner{Key: r.key}, nil
+}
+
+// handlerMsgr forwards the chain issuance requests to the handler.
+type handlerMsgr struct {
+	handler *reiss.Handler
+}
+
+func (m handlerMsgr) RequestChainIssue(ctx context.Context, msg *cert_mgmt.ChainIssReq,
+	_ net.Addr, _ uint64) (*cert_mgmt.ChainIssRep, error) {
+
+	raw, err := m.handler.Issue(ctx, msg.Raw)
+	if err != nil {
+		return nil, err
+	}
+	return &cert_mgmt.ChainIssRep{RawChain: raw}, nil
+}
@@ -0,0 +1,288 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reiss
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"net"
+	"path/filepath"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/ctrl/cert_mgmt"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/infra/messenger"
+	"github.com/scionproto/scion/go/lib/infra/modules/trust"
+	"github.com/scionproto/scion/go/lib/keyconf"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/periodic"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/scrypto/cert"
+	"github.com/scionproto/scion/go/lib/scrypto/cert/renewal"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+// RequestAPI is used to send chain issuance requests.
+type RequestAPI interface {
+	RequestChainIssue(ctx context.Context, msg *cert_mgmt.ChainIssReq, a net.Addr,
+		id uint64) (*cert_mgmt.ChainIssRep, error)
+}
+
+var _ periodic.Task = (*Requester)(nil)
+
+// Requester requests a new certificate chain from the issuer AS before the
+// currently active one expires.
+type Requester struct {
+	// IA is the local AS.
+	IA addr.IA
+	// Store provides the active certificate chain and stores the renewed one.
+	Store Store
+	// KeyRing provides the currently active private signing key.
+	KeyRing trust.KeyRing
+	// KeyDir is the directory the new private keys are written to.
+	KeyDir string
+	// Msgr is used to send the request to the issuer AS.
+	Msgr RequestAPI
+	// Router is used to find a path to the issuer AS.
+	Router snet.Router
+	// LeadTime indicates how long before the expiration of the active
+	// certificate chain the renewal starts.
+	LeadTime time.Duration
+	// Timeout is the timeout for a single request.
+	Timeout time.Duration
+	// OnRenewal is called after the renewed certificate chain has been
+	// inserted into the store. It can be nil.
+	OnRenewal func(ctx context.Context)
+}
+
+// Name returns the task name.
+func (r *Requester) Name() string {
+	return "cs_reiss_requester"
+}
+
+// Run requests a new certificate chain, if the currently active one is about
+// to expire.
+func (r *Requester) Run(ctx context.Context) {
+	logger := log.FromCtx(ctx)
+	renewed, err := r.run(ctx, time.Now())
+	if err != nil {
+		logger.Error("[reiss.Requester] Unable to renew certificate chain", "err", err)
+		return
+	}
+	if renewed && r.OnRenewal != nil {
+		r.OnRenewal(ctx)
+	}
+}
+
+func (r *Requester) run(ctx context.Context, now time.Time) (bool, error) {
+	logger := log.FromCtx(ctx)
+	current, err := latestChain(ctx, r.Store, r.IA, infra.ChainOpts{
+		TrustStoreOpts: infra.TrustStoreOpts{LocalOnly: true},
+	})
+	if err != nil {
+		return false, err
+	}
+	if current.as.Issuer.IA.Equal(r.IA) {
+		logger.Trace("[reiss.Requester] Local AS is issuer, skip renewal")
+		return false, nil
+	}
+	if now.Add(r.LeadTime).Before(current.as.Validity.NotAfter.Time) {
+		return false, nil
+	}
+	logger.Info("[reiss.Requester] Certificate chain expires soon, requesting renewal",
+		"version", current.as.Version, "expiration", current.as.Validity.NotAfter,
+		"issuer", current.as.Issuer.IA)
+	keys, signed, err := r.createRequest(current.as, now)
+	if err != nil {
+		return false, err
+	}
+	rawReq, err := json.Marshal(signed)
+	if err != nil {
+		return false, serrors.WrapStr("unable to marshal request", err)
+	}
+	rawChain, err := r.sendRequest(ctx, current.as.Issuer.IA, rawReq)
+	if err != nil {
+		return false, err
+	}
+	renewed, err := parseChain(rawChain)
+	if err != nil {
+		return false, err
+	}
+	if err := checkRenewed(renewed.as, r.IA, current.as.Version, keys); err != nil {
+		return false, err
+	}
+	// Only persist the keys once the renewed chain is known to the store.
+	// Otherwise, a failed insert leaves keys on disk that no chain
+	// authenticates.
+	if err := r.Store.InsertRawChain(ctx, rawChain); err != nil {
+		return false, serrors.WrapStr("unable to insert renewed chain", err)
+	}
+	for _, key := range keys {
+		if err := writeKey(r.KeyDir, key); err != nil {
+			return false, err
+		}
+	}
+	logger.Info("[reiss.Requester] Certificate chain renewed", "version", renewed.as.Version,
+		"validity", renewed.as.Validity)
+	return true, nil
+}
+
+// createRequest generates the new keys and the signed renewal request.
+func (r *Requester) createRequest(as *cert.AS,
+	now time.Time) (map[cert.KeyType]keyconf.Key, renewal.SignedRequest, error) {
+
+	currentMeta := as.Keys[cert.SigningKey]
+	current, err := r.KeyRing.Signer(keyconf.ASSigningKey, currentMeta.KeyVersion)
+	if err != nil {
+		return nil, renewal.SignedRequest{}, serrors.WrapStr("unable to load signing key", err,
+			"key_version", currentMeta.KeyVersion)
+	}
+	now = now.Truncate(time.Second)
+	period := as.Validity.NotAfter.Sub(as.Validity.NotBefore.Time)
+	validity := scrypto.Validity{
+		NotBefore: util.UnixTime{Time: now},
+		NotAfter:  util.UnixTime{Time: now.Add(period)},
+	}
+	usages := map[cert.KeyType]keyconf.Usage{
+		cert.SigningKey:    keyconf.ASSigningKey,
+		cert.RevocationKey: keyconf.ASRevocationKey,
+	}
+	keys := make(map[cert.KeyType]keyconf.Key, len(usages))
+	metas := make(map[cert.KeyType]renewal.KeyMeta, len(usages))
+	for keyType, usage := range usages {
+		meta, ok := as.Keys[keyType]
+		if !ok {
+			meta = scrypto.KeyMeta{Algorithm: currentMeta.Algorithm}
+		}
+		pub, priv, err := scrypto.GenKeyPair(meta.Algorithm)
+		if err != nil {
+			return nil, renewal.SignedRequest{}, serrors.WrapStr("unable to generate key", err,
+				"usage", usage)
+		}
+		keys[keyType] = keyconf.Key{
+			ID: keyconf.ID{
+				Usage:   usage,
+				IA:      r.IA,
+				Version: meta.KeyVersion + 1,
+			},
+			Type:      keyconf.PrivateKey,
+			Algorithm: meta.Algorithm,
+			Validity:  validity,
+			Bytes:     priv,
+		}
+		metas[keyType] = renewal.KeyMeta{
+			KeyVersion: meta.KeyVersion + 1,
+			Algorithm:  meta.Algorithm,
+			Key:        pub,
+		}
+	}
+	info := &renewal.RequestInfo{
+		Subject:                    r.IA,
+		Version:                    as.Version + 1,
+		FormatVersion:              as.FormatVersion,
+		Description:                as.Description,
+		OptionalDistributionPoints: as.OptionalDistributionPoints,
+		Validity:                   &validity,
+		Keys: renewal.Keys{
+			Signing:    metas[cert.SigningKey],
+			Revocation: metas[cert.RevocationKey],
+		},
+		Issuer:      as.Issuer.IA,
+		RequestTime: util.UnixTime{Time: now},
+	}
+	signed, err := renewal.NewSignedRequest(info,
+		privateKey(keys[cert.SigningKey]),
+		privateKey(keys[cert.RevocationKey]),
+		renewal.PrivateKey{
+			KeyVersion: current.Meta().Version,
+			Algorithm:  current.Meta().Algorithm,
+			Signer:     current,
+		},
+	)
+	if err != nil {
+		return nil, renewal.SignedRequest{}, err
+	}
+	return keys, signed, nil
+}
+
+func (r *Requester) sendRequest(ctx context.Context, issuer addr.IA,
+	raw []byte) ([]byte, error) {
+
+	ctx, cancelF := context.WithTimeout(ctx, r.Timeout)
+	defer cancelF()
+	path, err := r.Router.Route(ctx, issuer)
+	if err != nil {
+		return nil, serrors.WrapStr("unable to find path to issuer", err, "issuer", issuer)
+	}
+	a := &snet.SVCAddr{
+		IA:      path.Destination(),
+		Path:    path.Path(),
+		NextHop: path.OverlayNextHop(),
+		SVC:     addr.SvcCS,
+	}
+	rep, err := r.Msgr.RequestChainIssue(ctx, &cert_mgmt.ChainIssReq{Raw: raw}, a,
+		messenger.NextId())
+	if err != nil {
+		return nil, serrors.WrapStr("chain issuance request failed", err, "issuer", issuer)
+	}
+	return rep.RawChain, nil
+}
+
+// checkRenewed checks that the renewed certificate authenticates the generated
+// keys.
+func checkRenewed(as *cert.AS, ia addr.IA, version scrypto.Version,
+	keys map[cert.KeyType]keyconf.Key) error {
+
+	if !as.Subject.Equal(ia) || as.Version <= version {
+		return serrors.New("unexpected renewed certificate", "subject", as.Subject,
+			"version", as.Version)
+	}
+	for keyType, key := range keys {
+		pub, err := scrypto.GetPubKey(key.Bytes, key.Algorithm)
+		if err != nil {
+			return serrors.WrapStr("unable to compute public key", err, "type", keyType)
+		}
+		meta := as.Keys[keyType]
+		if meta.KeyVersion != key.Version || !bytes.Equal(meta.Key, pub) {
+			return serrors.New("renewed certificate does not contain generated key",
+				"type", keyType)
+		}
+	}
+	return nil
+}
+
+// writeKey atomically writes the private key to the directory, such that
+// loading key rings never observe a partially written key file.
+func writeKey(dir string, key keyconf.Key) error {
+	block := key.PEM()
+	file := filepath.Join(dir, key.File())
+	if err := util.WriteFile(file, pem.EncodeToMemory(&block), 0600); err != nil {
+		return serrors.WrapStr("unable to write private key", err, "file", file)
+	}
+	return nil
+}
+
+func privateKey(key keyconf.Key) renewal.PrivateKey {
+	return renewal.PrivateKey{
+		KeyVersion: key.Version,
+		Algorithm:  key.Algorithm,
+		Key:        key.Bytes,
+	}
+}
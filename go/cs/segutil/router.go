@@ -93,6 +93,7 @@ func (r *Router) translate(comb *combinator.Path, dst addr.IA) (path, error) {
 		spath:      sp,
 		mtu:        comb.Mtu,
 		expiry:     comb.ComputeExpTime(),
+		metadata:   comb.Metadata.ToSnet(),
 	}
 	for _, intf := range comb.Interfaces {
 		p.interfaces = append(p.interfaces, pathInterface{ia: intf.IA(), ifid: intf.ID()})
@@ -106,6 +107,7 @@ type path struct {
 	spath      *spath.Path
 	mtu        uint16
 	expiry     time.Time
+	metadata   *snet.PathMetadata
 	dst        addr.IA
 }
 
@@ -165,6 +167,10 @@ func (p path) Expiry() time.Time {
 	return p.expiry
 }
 
+func (p path) Metadata() *snet.PathMetadata {
+	return p.metadata.Copy()
+}
+
 func (p path) Copy() snet.Path {
 	return path{
 		interfaces: append(p.interfaces[:0:0], p.interfaces...),
@@ -172,6 +178,7 @@ func (p path) Copy() snet.Path {
 		spath:      p.Path(),           // creates copy
 		mtu:        p.mtu,
 		expiry:     p.expiry,
+		metadata:   p.Metadata(), // creates copy
 	}
 }
 
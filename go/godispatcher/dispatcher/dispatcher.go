@@ -3,6 +3,7 @@ package dispatcher
 import (
 	"context"
 	"net"
+	"strconv"
 	"sync"
 	"time"
 
@@ -27,17 +28,63 @@ const OverflowLoggingInterval = 10 * time.Second
 // ReceiveBufferSize is the size of receive buffers used by the dispatcher.
 const ReceiveBufferSize = 1 << 20
 
+// DefaultQueueSize is the default size of the application ingress queues.
+const DefaultQueueSize = 128
+
+// DefaultMaxQueueSize is the default maximum size of the application ingress
+// queues.
+const DefaultMaxQueueSize = 4096
+
+// QueueConfig configures the sizes of the application ingress queues.
+type QueueConfig struct {
+	// DefaultSize is the size of the queue of applications that do not
+	// request a specific size. If it is 0, DefaultQueueSize is used.
+	DefaultSize int
+	// MaxSize is the maximum size an application can request. Larger requests
+	// are capped. If it is 0, DefaultMaxQueueSize is used.
+	MaxSize int
+}
+
+// size returns the queue size for the requested size.
+func (c QueueConfig) size(requested uint32) int {
+	def, max := c.DefaultSize, c.MaxSize
+	if def == 0 {
+		def = DefaultQueueSize
+	}
+	if max == 0 {
+		max = DefaultMaxQueueSize
+	}
+	if requested == 0 {
+		requested = uint32(def)
+	}
+	if requested > uint32(max) {
+		return max
+	}
+	return int(requested)
+}
+
+// RegOptions contains the optional parameters of a registration.
+type RegOptions struct {
+	// QueueSize is the requested size of the application ingress queue. If it
+	// is 0, the default size is used.
+	QueueSize uint32
+	// Backpressure enables signaling of dropped packets to the application.
+	Backpressure bool
+}
+
 // Server is the main object allowing to create new SCION connections.
 type Server struct {
 	// routingTable is used to register new connections.
 	routingTable *IATable
 	ipv4Conn     net.PacketConn
 	ipv6Conn     net.PacketConn
+	queues       QueueConfig
 }
 
 // NewServer creates new instance of Server. Internally, it opens the dispatcher ports
-// for both IPv4 and IPv6. Returns error if the ports can't be opened.
-func NewServer(address string) (*Server, error) {
+// for both IPv4 and IPv6. Returns error if the ports can't be opened. The
+// queue configuration determines the sizes of the application ingress queues.
+func NewServer(address string, queues QueueConfig) (*Server, error) {
 	metaLogger := &throttledMetaLogger{
 		Logger:      log.Root(),
 		MinInterval: OverflowLoggingInterval,
@@ -56,6 +103,7 @@ func NewServer(address string) (*Server, error) {
 		routingTable: NewIATable(1024, 65535),
 		ipv4Conn:     ipv4Conn,
 		ipv6Conn:     ipv6Conn,
+		queues:       queues,
 	}, nil
 }
 
@@ -82,15 +130,28 @@ func (as *Server) Serve() error {
 	return <-errChan
 }
 
-// Register creates a new connection.
+// Register creates a new connection with the default registration options.
 func (as *Server) Register(ctx context.Context, ia addr.IA, address *net.UDPAddr,
 	svc addr.HostSVC) (net.PacketConn, uint16, error) {
 
-	tableEntry := newTableEntry()
+	return as.RegisterWithOptions(ctx, ia, address, svc, RegOptions{})
+}
+
+// RegisterWithOptions creates a new connection. The requested queue size is
+// capped to the maximum queue size of the server.
+func (as *Server) RegisterWithOptions(ctx context.Context, ia addr.IA, address *net.UDPAddr,
+	svc addr.HostSVC, opts RegOptions) (net.PacketConn, uint16, error) {
+
+	tableEntry := newTableEntry(as.queues.size(opts.QueueSize), opts.Backpressure)
 	ref, err := as.routingTable.Register(ia, address, nil, svc, tableEntry)
 	if err != nil {
 		return nil, 0, err
 	}
+	dropLabels := metrics.AppQueue{
+		Port: strconv.Itoa(ref.UDPAddr().Port),
+		SVC:  svc.BaseString(),
+	}
+	tableEntry.setDropCounter(metrics.M.AppQueueDrops(dropLabels))
 	var ovConn net.PacketConn
 	if address.IP.To4() == nil {
 		ovConn = as.ipv6Conn
@@ -99,12 +160,19 @@ func (as *Server) Register(ctx context.Context, ia addr.IA, address *net.UDPAddr
 	}
 	conn := &Conn{
 		conn:         ovConn,
+		entry:        tableEntry,
 		ring:         tableEntry.appIngressRing,
 		regReference: ref,
+		dropLabels:   dropLabels,
 	}
 	return conn, uint16(ref.UDPAddr().Port), nil
 }
 
+// Registrations returns a snapshot of the live application registrations.
+func (as *Server) Registrations() []RegistrationInfo {
+	return as.routingTable.Registrations()
+}
+
 func (as *Server) Close() {
 	as.ipv4Conn.Close()
 	as.ipv6Conn.Close()
@@ -114,10 +182,14 @@ func (as *Server) Close() {
 type Conn struct {
 	// conn is used to send packets.
 	conn net.PacketConn
+	// entry is the routing table entry of the connection.
+	entry *TableEntry
 	// ring is used to retrieve incoming packets.
 	ring *ringbuf.Ring
 	// regReference is the reference to the registration in the routing table.
 	regReference registration.RegReference
+	// dropLabels are the labels of the queue drop metric.
+	dropLabels metrics.AppQueue
 }
 
 func (ac *Conn) WriteTo(p []byte, addr net.Addr) (int, error) {
@@ -160,9 +232,30 @@ func (ac *Conn) Read() *respool.Packet {
 	return pkt
 }
 
+// TakeDrops returns the number of packets that were dropped since the last
+// call, if backpressure is enabled for the connection. Otherwise, it returns
+// 0.
+func (ac *Conn) TakeDrops() uint64 {
+	return ac.entry.takePendingDrops()
+}
+
+// DropSignal returns a channel that is notified when packets are dropped
+// because the application ingress queue is full. Multiple drops may be
+// coalesced into a single notification; TakeDrops returns the actual count.
+// If backpressure is disabled for the connection, the channel is nil.
+func (ac *Conn) DropSignal() <-chan struct{} {
+	return ac.entry.dropSignal
+}
+
+// QueueSize returns the size of the application ingress queue.
+func (ac *Conn) QueueSize() int {
+	return ac.ring.Cap()
+}
+
 func (ac *Conn) Close() error {
 	ac.regReference.Free()
 	ac.ring.Close()
+	metrics.M.DeleteAppQueueDrops(ac.dropLabels)
 	return nil
 }
 
@@ -217,13 +217,15 @@ func (d *SCMPAppDestination) Send(dp *NetToRingDataplane, pkt *respool.Packet) {
 }
 
 // sendPacket puts pkt on the routing entry's ring buffer, and releases the
-// reference to pkt.
+// reference to pkt. If the ring buffer is full, the packet is dropped and the
+// drop is accounted on the routing entry.
 func sendPacket(routingEntry *TableEntry, pkt *respool.Packet) {
 	// Move packet reference to other goroutine.
 	count, _ := routingEntry.appIngressRing.Write(ringbuf.EntryList{pkt}, false)
 	if count <= 0 {
 		// Release buffer if we couldn't transmit it to the other goroutine.
 		pkt.Free()
+		routingEntry.dropped()
 	}
 }
 
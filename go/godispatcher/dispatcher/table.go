@@ -16,6 +16,10 @@ package dispatcher
 
 import (
 	"net"
+	"sync"
+	"sync/atomic"
+
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/scionproto/scion/go/godispatcher/internal/registration"
 	"github.com/scionproto/scion/go/lib/addr"
@@ -23,14 +27,77 @@ import (
 )
 
 type TableEntry struct {
+	// pendingDrops is the number of dropped packets that were not yet
+	// signaled to the application. It must only be accessed atomically.
+	pendingDrops uint64
+	// totalDrops is the total number of dropped packets. It must only be
+	// accessed atomically.
+	totalDrops     uint64
 	appIngressRing *ringbuf.Ring
+	// backpressure indicates whether drops are signaled to the application.
+	backpressure bool
+	// dropSignal is notified whenever a packet is dropped, if backpressure is
+	// enabled. Otherwise, it is nil.
+	dropSignal chan struct{}
+
+	mtx   sync.Mutex
+	drops prometheus.Counter
 }
 
-func newTableEntry() *TableEntry {
+func newTableEntry(queueSize int, backpressure bool) *TableEntry {
 	// Construct application ingress ring buffer
-	appIngressRing := ringbuf.New(128, nil, "net_to_app_ring")
-	return &TableEntry{
+	appIngressRing := ringbuf.New(queueSize, nil, "net_to_app_ring")
+	e := &TableEntry{
 		appIngressRing: appIngressRing,
+		backpressure:   backpressure,
+	}
+	if backpressure {
+		e.dropSignal = make(chan struct{}, 1)
+	}
+	return e
+}
+
+// setDropCounter sets the metric that counts packets dropped because the
+// application ingress ring was full. The counter is only known once the
+// registration is complete.
+func (e *TableEntry) setDropCounter(c prometheus.Counter) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	e.drops = c
+}
+
+// dropped accounts for a packet that could not be written to the application
+// ingress ring.
+func (e *TableEntry) dropped() {
+	atomic.AddUint64(&e.totalDrops, 1)
+	if e.backpressure {
+		atomic.AddUint64(&e.pendingDrops, 1)
+		// A pending notification already covers this drop.
+		select {
+		case e.dropSignal <- struct{}{}:
+		default:
+		}
+	}
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	if e.drops != nil {
+		e.drops.Inc()
+	}
+}
+
+// takePendingDrops returns the number of drops since the last call and resets
+// the count.
+func (e *TableEntry) takePendingDrops() uint64 {
+	return atomic.SwapUint64(&e.pendingDrops, 0)
+}
+
+// info returns the queue state and drop counters of the entry.
+func (e *TableEntry) info() QueueInfo {
+	return QueueInfo{
+		QueueSize:    e.appIngressRing.Cap(),
+		QueueLen:     e.appIngressRing.Len(),
+		Drops:        atomic.LoadUint64(&e.totalDrops),
+		Backpressure: e.backpressure,
 	}
 }
 
@@ -69,3 +136,45 @@ func (t *IATable) LookupID(ia addr.IA, id uint64) (*TableEntry, bool) {
 	}
 	return e.(*TableEntry), true
 }
+
+// Registrations returns a snapshot of all live registrations.
+func (t *IATable) Registrations() []RegistrationInfo {
+	regs := t.IATable.Registrations()
+	infos := make([]RegistrationInfo, 0, len(regs))
+	for _, reg := range regs {
+		info := RegistrationInfo{
+			IA:        reg.IA,
+			Public:    reg.Public.String(),
+			QueueInfo: reg.Value.(*TableEntry).info(),
+		}
+		if reg.Bind != nil {
+			info.Bind = reg.Bind.String()
+		}
+		if reg.SVC != addr.SvcNone {
+			info.SVC = reg.SVC.String()
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// RegistrationInfo describes a live application registration.
+type RegistrationInfo struct {
+	IA     addr.IA
+	Public string
+	Bind   string `json:",omitempty"`
+	SVC    string `json:",omitempty"`
+	QueueInfo
+}
+
+// QueueInfo describes the state of an application ingress queue.
+type QueueInfo struct {
+	// QueueSize is the capacity of the queue, in packets.
+	QueueSize int
+	// QueueLen is the number of packets currently in the queue.
+	QueueLen int
+	// Drops is the number of packets dropped because the queue was full.
+	Drops uint64
+	// Backpressure indicates whether drops are signaled to the application.
+	Backpressure bool
+}
@@ -0,0 +1,87 @@
+// Copyright 2019 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatcher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/go/godispatcher/internal/respool"
+)
+
+func TestQueueConfigSize(t *testing.T) {
+	testCases := map[string]struct {
+		Config    QueueConfig
+		Requested uint32
+		Expected  int
+	}{
+		"defaults":             {Expected: DefaultQueueSize},
+		"default max":          {Requested: 1 << 20, Expected: DefaultMaxQueueSize},
+		"configured default":   {Config: QueueConfig{DefaultSize: 64}, Expected: 64},
+		"requested":            {Config: QueueConfig{MaxSize: 512}, Requested: 256, Expected: 256},
+		"requested is capped":  {Config: QueueConfig{MaxSize: 512}, Requested: 513, Expected: 512},
+		"requested is smaller": {Config: QueueConfig{DefaultSize: 64}, Requested: 8, Expected: 8},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, tc.Config.size(tc.Requested))
+		})
+	}
+}
+
+func TestSendPacketDrops(t *testing.T) {
+	t.Run("without backpressure", func(t *testing.T) {
+		entry := newTableEntry(2, false)
+		for i := 0; i < 5; i++ {
+			sendPacket(entry, respool.GetPacket())
+		}
+		assert.Zero(t, entry.takePendingDrops())
+		assert.Nil(t, entry.dropSignal)
+	})
+	t.Run("with backpressure", func(t *testing.T) {
+		entry := newTableEntry(2, true)
+		for i := 0; i < 2; i++ {
+			sendPacket(entry, respool.GetPacket())
+		}
+		assertNoDropSignal(t, entry)
+		for i := 0; i < 3; i++ {
+			sendPacket(entry, respool.GetPacket())
+		}
+		// The drops are signaled immediately, without further deliveries.
+		assertDropSignal(t, entry)
+		assertNoDropSignal(t, entry)
+		assert.Equal(t, uint64(3), entry.takePendingDrops())
+		assert.Zero(t, entry.takePendingDrops())
+	})
+}
+
+func assertDropSignal(t *testing.T, entry *TableEntry) {
+	t.Helper()
+	select {
+	case <-entry.dropSignal:
+	default:
+		t.Fatal("drop not signaled")
+	}
+}
+
+func assertNoDropSignal(t *testing.T, entry *TableEntry) {
+	t.Helper()
+	select {
+	case <-entry.dropSignal:
+		t.Fatal("unexpected drop signal")
+	default:
+	}
+}
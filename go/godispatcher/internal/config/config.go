@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/scionproto/scion/go/godispatcher/dispatcher"
 	"github.com/scionproto/scion/go/lib/config"
 	"github.com/scionproto/scion/go/lib/env"
 	"github.com/scionproto/scion/go/lib/serrors"
@@ -48,6 +49,12 @@ type Config struct {
 		// DeleteSocket specifies whether the dispatcher should delete the
 		// socket file prior to attempting to create a new one.
 		DeleteSocket bool
+		// QueueSize is the size of the ingress queue of applications that do
+		// not request a specific size, in packets. (default 128)
+		QueueSize int
+		// MaxQueueSize is the maximum ingress queue size an application can
+		// request, in packets. Larger requests are capped. (default 4096)
+		MaxQueueSize int
 	}
 }
 
@@ -61,6 +68,12 @@ func (cfg *Config) InitDefaults() {
 	if cfg.Dispatcher.OverlayPort == 0 {
 		cfg.Dispatcher.OverlayPort = topology.EndhostPort
 	}
+	if cfg.Dispatcher.QueueSize == 0 {
+		cfg.Dispatcher.QueueSize = dispatcher.DefaultQueueSize
+	}
+	if cfg.Dispatcher.MaxQueueSize == 0 {
+		cfg.Dispatcher.MaxQueueSize = dispatcher.DefaultMaxQueueSize
+	}
 }
 
 func (cfg *Config) Validate() error {
@@ -76,6 +89,13 @@ func (cfg *Config) Validate() error {
 	if cfg.Dispatcher.ID == "" {
 		return serrors.New("ID must be set")
 	}
+	if cfg.Dispatcher.QueueSize <= 0 {
+		return serrors.New("QueueSize must be positive", "size", cfg.Dispatcher.QueueSize)
+	}
+	if cfg.Dispatcher.MaxQueueSize < cfg.Dispatcher.QueueSize {
+		return serrors.New("MaxQueueSize must not be smaller than QueueSize",
+			"max", cfg.Dispatcher.MaxQueueSize, "default", cfg.Dispatcher.QueueSize)
+	}
 	return config.ValidateAll(&cfg.Logging, &cfg.Metrics)
 }
 
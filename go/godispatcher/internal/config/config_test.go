@@ -22,6 +22,7 @@ import (
 	"github.com/BurntSushi/toml"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/scionproto/scion/go/godispatcher/dispatcher"
 	"github.com/scionproto/scion/go/lib/env/envtest"
 	"github.com/scionproto/scion/go/lib/sock/reliable"
 	"github.com/scionproto/scion/go/lib/topology"
@@ -53,4 +54,6 @@ func CheckTestConfig(t *testing.T, cfg *Config, id string) {
 	assert.Equal(t, topology.EndhostPort, cfg.Dispatcher.OverlayPort)
 	assert.Empty(t, cfg.Dispatcher.PerfData)
 	assert.False(t, cfg.Dispatcher.DeleteSocket)
+	assert.Equal(t, dispatcher.DefaultQueueSize, cfg.Dispatcher.QueueSize)
+	assert.Equal(t, dispatcher.DefaultMaxQueueSize, cfg.Dispatcher.MaxQueueSize)
 }
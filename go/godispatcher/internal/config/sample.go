@@ -36,4 +36,12 @@ PerfData = ""
 # Set DeleteSock to true to have the Dispatcher remove the socket file (if it
 # exists) on start. (default false)
 DeleteSocket = false
+
+# QueueSize is the size of the ingress queue of applications that do not
+# request a specific size, in packets. (default 128)
+QueueSize = 128
+
+# MaxQueueSize is the maximum ingress queue size an application can request,
+# in packets. Larger requests are capped. (default 4096)
+MaxQueueSize = 4096
 `
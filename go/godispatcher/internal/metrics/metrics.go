@@ -81,6 +81,22 @@ func (l SCMP) Values() []string {
 	return []string{"class", "type"}
 }
 
+// AppQueue contains the labels for application ingress queue metrics.
+type AppQueue struct {
+	Port string
+	SVC  string
+}
+
+// Labels returns the list of labels.
+func (l AppQueue) Labels() []string {
+	return []string{"port", "svc"}
+}
+
+// Values returns the label values in the order defined by Labels.
+func (l AppQueue) Values() []string {
+	return []string{l.Port, l.SVC}
+}
+
 type metrics struct {
 	netWriteBytes      prometheus.Counter
 	netWritePkts       prometheus.Counter
@@ -101,6 +117,7 @@ type metrics struct {
 	appNotFoundErrors  prometheus.Counter
 	appWriteSVCPkts    *prometheus.CounterVec
 	netReadOverflows   prometheus.Counter
+	appQueueDrops      *prometheus.CounterVec
 }
 
 func newMetrics() metrics {
@@ -143,6 +160,9 @@ func newMetrics() metrics {
 			"Total SVC packets delivered to applications", SVC{}),
 		netReadOverflows: prom.NewCounter(Namespace, "", "net_read_overflow_pkts_total",
 			"Total ingress packets that were dropped on the OS socket"),
+		appQueueDrops: prom.NewCounterVecWithLabels(Namespace, "", "app_queue_drops_total",
+			"Total packets dropped because the application ingress queue was full.",
+			AppQueue{}),
 	}
 }
 
@@ -223,3 +243,12 @@ func (m metrics) AppWriteSVCPkts(labels SVC) prometheus.Counter {
 func (m metrics) NetReadOverflows() prometheus.Counter {
 	return m.netReadOverflows
 }
+
+func (m metrics) AppQueueDrops(labels AppQueue) prometheus.Counter {
+	return m.appQueueDrops.WithLabelValues(labels.Values()...)
+}
+
+// DeleteAppQueueDrops removes the drop counter of a closed application queue.
+func (m metrics) DeleteAppQueueDrops(labels AppQueue) {
+	m.appQueueDrops.DeleteLabelValues(labels.Values()...)
+}
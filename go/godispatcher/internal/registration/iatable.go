@@ -15,7 +15,9 @@
 package registration
 
 import (
+	"bytes"
 	"net"
+	"sort"
 	"sync"
 
 	"github.com/scionproto/scion/go/lib/addr"
@@ -93,6 +95,22 @@ type IATable interface {
 	// If an entry is found, the returned boolean is set to true. Otherwise, it
 	// is set to false.
 	LookupID(ia addr.IA, id uint64) (interface{}, bool)
+	// Registrations returns a snapshot of all live registrations, sorted by
+	// IA and public address.
+	Registrations() []Registration
+}
+
+// Registration describes a live registration in an IATable.
+type Registration struct {
+	IA addr.IA
+	// Public is the public address, including the allocated port.
+	Public *net.UDPAddr
+	// Bind is the bind address. It is nil if no bind address was registered.
+	Bind net.IP
+	// SVC is the service address. It is SvcNone if no service was registered.
+	SVC addr.HostSVC
+	// Value is the value associated with the registration.
+	Value interface{}
 }
 
 // NewIATable creates a new UDP/IP port registration table.
@@ -110,6 +128,7 @@ var _ IATable = (*iaTable)(nil)
 type iaTable struct {
 	mtx     sync.RWMutex
 	ia      map[addr.IA]*Table
+	refs    map[*iaTableReference]struct{}
 	minPort int
 	maxPort int
 }
@@ -117,6 +136,7 @@ type iaTable struct {
 func newIATable(minPort, maxPort int) *iaTable {
 	return &iaTable{
 		ia:      make(map[addr.IA]*Table),
+		refs:    make(map[*iaTableReference]struct{}),
 		minPort: minPort,
 		maxPort: maxPort,
 	}
@@ -142,13 +162,16 @@ func (t *iaTable) Register(ia addr.IA, public *net.UDPAddr, bind net.IP, svc add
 	if err != nil {
 		return nil, err
 	}
-	return &iaTableReference{
+	ref := &iaTableReference{
 		table:    t,
 		ia:       ia,
 		entryRef: reference,
+		bind:     bind,
 		svc:      svc,
 		value:    value,
-	}, nil
+	}
+	t.refs[ref] = struct{}{}
+	return ref, nil
 }
 
 func (t *iaTable) LookupPublic(ia addr.IA, public *net.UDPAddr) (interface{}, bool) {
@@ -178,12 +201,42 @@ func (t *iaTable) LookupID(ia addr.IA, id uint64) (interface{}, bool) {
 	return nil, false
 }
 
+func (t *iaTable) Registrations() []Registration {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+	regs := make([]Registration, 0, len(t.refs))
+	for ref := range t.refs {
+		var bind net.IP
+		if ref.bind != nil {
+			bind = copyIPAddr(ref.bind)
+		}
+		regs = append(regs, Registration{
+			IA:     ref.ia,
+			Public: copyUDPAddr(ref.entryRef.UDPAddr()),
+			Bind:   bind,
+			SVC:    ref.svc,
+			Value:  ref.value,
+		})
+	}
+	sort.Slice(regs, func(i, j int) bool {
+		if regs[i].IA != regs[j].IA {
+			return regs[i].IA.IAInt() < regs[j].IA.IAInt()
+		}
+		if c := bytes.Compare(regs[i].Public.IP, regs[j].Public.IP); c != 0 {
+			return c < 0
+		}
+		return regs[i].Public.Port < regs[j].Public.Port
+	})
+	return regs
+}
+
 var _ RegReference = (*iaTableReference)(nil)
 
 type iaTableReference struct {
 	table    *iaTable
 	ia       addr.IA
 	entryRef *TableReference
+	bind     net.IP
 	svc      addr.HostSVC
 	// value is the main table information associated with this reference
 	value interface{}
@@ -193,6 +246,7 @@ func (r *iaTableReference) Free() {
 	r.table.mtx.Lock()
 	defer r.table.mtx.Unlock()
 	r.entryRef.Free()
+	delete(r.table.refs, r)
 	if r.table.ia[r.ia].Size() == 0 {
 		delete(r.table.ia, r.ia)
 	}
@@ -170,3 +170,33 @@ func TestIATableSCMPExistingRegistration(t *testing.T) {
 		assert.Nil(t, retValue)
 	})
 }
+
+func TestIATableRegistrations(t *testing.T) {
+	table := NewIATable(minPort, maxPort)
+	otherIA := xtest.MustParseIA("1-ff00:0:2")
+	bind := net.IP{192, 0, 2, 2}
+	refA, err := table.Register(otherIA, public, nil, addr.SvcNone, "a")
+	require.NoError(t, err)
+	refB, err := table.Register(ia, public, bind, addr.SvcCS, "b")
+	require.NoError(t, err)
+	refC, err := table.Register(ia, &net.UDPAddr{IP: net.IP{192, 0, 2, 1}}, nil,
+		addr.SvcNone, "c")
+	require.NoError(t, err)
+
+	regs := table.Registrations()
+	require.Len(t, regs, 3)
+	assert.Equal(t, Registration{IA: ia, Public: public, Bind: bind, SVC: addr.SvcCS,
+		Value: "b"}, regs[0])
+	assert.Equal(t, refC.UDPAddr(), regs[1].Public)
+	assert.Nil(t, regs[1].Bind)
+	assert.Equal(t, "c", regs[1].Value)
+	assert.Equal(t, otherIA, regs[2].IA)
+
+	refA.Free()
+	refB.Free()
+	regs = table.Registrations()
+	require.Len(t, regs, 1)
+	assert.Equal(t, "c", regs[0].Value)
+	refC.Free()
+	assert.Empty(t, table.Registrations())
+}
@@ -26,6 +26,7 @@ import (
 
 	"github.com/BurntSushi/toml"
 
+	"github.com/scionproto/scion/go/godispatcher/dispatcher"
 	"github.com/scionproto/scion/go/godispatcher/internal/config"
 	"github.com/scionproto/scion/go/godispatcher/network"
 	"github.com/scionproto/scion/go/lib/common"
@@ -74,14 +75,18 @@ func realMain() int {
 		return 1
 	}
 
+	disp := &network.Dispatcher{
+		OverlaySocket:     fmt.Sprintf(":%d", cfg.Dispatcher.OverlayPort),
+		ApplicationSocket: cfg.Dispatcher.ApplicationSocket,
+		SocketFileMode:    os.FileMode(cfg.Dispatcher.SocketFileMode),
+		Queues: dispatcher.QueueConfig{
+			DefaultSize: cfg.Dispatcher.QueueSize,
+			MaxSize:     cfg.Dispatcher.MaxQueueSize,
+		},
+	}
 	go func() {
 		defer log.LogPanicAndExit()
-		err := RunDispatcher(
-			cfg.Dispatcher.DeleteSocket,
-			cfg.Dispatcher.ApplicationSocket,
-			os.FileMode(cfg.Dispatcher.SocketFileMode),
-			cfg.Dispatcher.OverlayPort,
-		)
+		err := RunDispatcher(cfg.Dispatcher.DeleteSocket, disp)
 		if err != nil {
 			fatal.Fatal(err)
 		}
@@ -99,6 +104,7 @@ func realMain() int {
 	env.SetupEnv(nil)
 	http.HandleFunc("/config", configHandler)
 	http.HandleFunc("/info", env.InfoHandler)
+	http.HandleFunc("/registrations", disp.RegistrationsHandler)
 	cfg.Metrics.StartPrometheus()
 
 	returnCode := waitForTeardown()
@@ -132,21 +138,15 @@ func setupBasic() error {
 	return env.LogAppStarted("Dispatcher", cfg.Dispatcher.ID)
 }
 
-func RunDispatcher(deleteSocketFlag bool, applicationSocket string, socketFileMode os.FileMode,
-	overlayPort int) error {
-
+func RunDispatcher(deleteSocketFlag bool, disp *network.Dispatcher) error {
 	if deleteSocketFlag {
-		if err := deleteSocket(cfg.Dispatcher.ApplicationSocket); err != nil {
+		if err := deleteSocket(disp.ApplicationSocket); err != nil {
 			return err
 		}
 	}
-	dispatcher := &network.Dispatcher{
-		OverlaySocket:     fmt.Sprintf(":%d", overlayPort),
-		ApplicationSocket: applicationSocket,
-		SocketFileMode:    socketFileMode,
-	}
-	log.Debug("Dispatcher starting", "appSocket", applicationSocket, "overlayPort", overlayPort)
-	return dispatcher.ListenAndServe()
+	log.Debug("Dispatcher starting", "appSocket", disp.ApplicationSocket,
+		"overlaySocket", disp.OverlaySocket)
+	return disp.ListenAndServe()
 }
 
 func deleteSocket(socket string) error {
@@ -17,6 +17,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"io/ioutil"
 	"net"
 	"os"
@@ -25,6 +26,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/scionproto/scion/go/godispatcher/dispatcher"
+	"github.com/scionproto/scion/go/godispatcher/network"
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/hpkt"
@@ -419,12 +422,19 @@ var testCases = []*TestCase{
 func TestDataplaneIntegration(t *testing.T) {
 	settings := InitTestSettings(t)
 
+	disp := &network.Dispatcher{
+		OverlaySocket:     fmt.Sprintf(":%d", settings.OverlayPort),
+		ApplicationSocket: settings.ApplicationSocket,
+		SocketFileMode:    reliable.DefaultDispSocketFileMode,
+	}
 	go func() {
-		err := RunDispatcher(false, settings.ApplicationSocket, reliable.DefaultDispSocketFileMode,
-			settings.OverlayPort)
+		err := RunDispatcher(false, disp)
 		xtest.FailOnErr(t, err, "dispatcher error")
 	}()
 	time.Sleep(defaultWaitDuration)
+	if regs, ok := disp.Registrations(); !ok || len(regs) != 0 {
+		t.Fatalf("bad registrations, have %v (serving %v), expect none", regs, ok)
+	}
 
 	for _, tc := range testCases {
 		t.Run(tc.Name, func(t *testing.T) {
@@ -432,6 +442,25 @@ func TestDataplaneIntegration(t *testing.T) {
 		})
 		time.Sleep(defaultWaitDuration)
 	}
+
+	t.Run("registrations", func(t *testing.T) {
+		ctx, cancelF := context.WithTimeout(context.Background(), defaultTimeout)
+		defer cancelF()
+		public := &net.UDPAddr{IP: commonPublicL3Address.IP(), Port: 8090}
+		conn, _, err := reliable.NewDispatcher(settings.ApplicationSocket).Register(
+			ctx, commonIA, public, addr.SvcNone)
+		xtest.FailOnErr(t, err, "unable to open socket")
+		defer conn.Close()
+
+		regs, _ := disp.Registrations()
+		if len(regs) != 1 || regs[0].IA != commonIA || regs[0].Public != public.String() {
+			t.Fatalf("bad registrations, have %v, expect %v", regs, public)
+		}
+		if regs[0].QueueSize != dispatcher.DefaultQueueSize {
+			t.Errorf("bad queue size, have %d, expect %d", regs[0].QueueSize,
+				dispatcher.DefaultQueueSize)
+		}
+	})
 }
 
 func RunTestCase(t *testing.T, tc *TestCase, settings *TestSettings) {
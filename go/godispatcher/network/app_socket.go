@@ -17,6 +17,7 @@ package network
 import (
 	"fmt"
 	"io"
+	"math"
 	"net"
 
 	"github.com/scionproto/scion/go/godispatcher/dispatcher"
@@ -87,6 +88,14 @@ func (h *AppConnHandler) Handle(appServer *dispatcher.Server) {
 		defer log.LogPanicAndExit()
 		h.RunRingToAppDataplane()
 	}()
+	if dropSignal := h.DispConn.DropSignal(); dropSignal != nil {
+		done := make(chan struct{})
+		defer close(done)
+		go func() {
+			defer log.LogPanicAndExit()
+			h.RunDropSignaler(dropSignal, done)
+		}()
+	}
 
 	h.RunAppToNetDataplane()
 }
@@ -103,32 +112,40 @@ func (h *AppConnHandler) doRegExchange(appServer *dispatcher.Server) (net.Packet
 	if err != nil {
 		return nil, common.NewBasicError("registration message error", nil, "err", err)
 	}
-	appConn, _, err := appServer.Register(nil,
-		regInfo.IA, regInfo.PublicAddress, regInfo.SVCAddress)
+	opts := dispatcher.RegOptions{
+		QueueSize:    regInfo.QueueSize,
+		Backpressure: regInfo.Backpressure,
+	}
+	appConn, _, err := appServer.RegisterWithOptions(nil,
+		regInfo.IA, regInfo.PublicAddress, regInfo.SVCAddress, opts)
 	if err != nil {
 		return nil, common.NewBasicError("registration table error", nil, "err", err)
 	}
-	udpAddr := appConn.(*dispatcher.Conn).LocalAddr().(*net.UDPAddr)
+	dispConn := appConn.(*dispatcher.Conn)
+	udpAddr := dispConn.LocalAddr().(*net.UDPAddr)
 	port := uint16(udpAddr.Port)
 	if err := h.sendConfirmation(b, &reliable.Confirmation{Port: port}); err != nil {
 		appConn.Close()
 		return nil, common.NewBasicError("confirmation message error", nil, "err", err)
 	}
 	h.logRegistration(regInfo.IA, udpAddr, getBindIP(regInfo.BindAddress),
-		regInfo.SVCAddress)
+		regInfo.SVCAddress, dispConn.QueueSize(), regInfo.Backpressure)
 	return appConn, nil
 }
 
 func (h *AppConnHandler) logRegistration(ia addr.IA, public *net.UDPAddr, bind net.IP,
-	svc addr.HostSVC) {
+	svc addr.HostSVC, queueSize int, backpressure bool) {
 
-	items := []interface{}{"ia", ia, "public", public}
+	items := []interface{}{"ia", ia, "public", public, "queue_size", queueSize}
 	if bind != nil {
 		items = append(items, "extra_bind", bind)
 	}
 	if svc != addr.SvcNone {
 		items = append(items, "svc", svc)
 	}
+	if backpressure {
+		items = append(items, "backpressure", backpressure)
+	}
 	h.Logger.Info("Client registered address", items...)
 }
 
@@ -215,6 +232,45 @@ func (h *AppConnHandler) RunRingToAppDataplane() {
 	}
 }
 
+// RunDropSignaler sends a backpressure signal to the application as soon as
+// packets are dropped from its ingress ring, independent of whether further
+// packets are delivered. It returns when done is closed.
+func (h *AppConnHandler) RunDropSignaler(dropSignal <-chan struct{}, done <-chan struct{}) {
+	b := make([]byte, 4)
+	for {
+		select {
+		case <-done:
+			return
+		case <-dropSignal:
+			if err := h.signalDrops(b); err != nil {
+				metrics.M.AppWriteErrors().Inc()
+				h.Logger.Error("[network->app] App connection error.", "err", err)
+				h.Conn.Close()
+				return
+			}
+		}
+	}
+}
+
+// signalDrops sends a backpressure signal to the application if packets were
+// dropped since the last signal.
+func (h *AppConnHandler) signalDrops(b common.RawBytes) error {
+	drops := h.DispConn.TakeDrops()
+	if drops == 0 {
+		return nil
+	}
+	if drops > math.MaxUint32 {
+		drops = math.MaxUint32
+	}
+	signal := reliable.BackpressureSignal{Dropped: uint32(drops)}
+	n, err := signal.SerializeTo(b)
+	if err != nil {
+		return err
+	}
+	_, err = h.Conn.WriteTo(b[:n], nil)
+	return err
+}
+
 func getBindIP(address *net.UDPAddr) net.IP {
 	if address == nil {
 		return nil
@@ -15,7 +15,10 @@
 package network
 
 import (
+	"encoding/json"
+	"net/http"
 	"os"
+	"sync"
 
 	"github.com/scionproto/scion/go/godispatcher/dispatcher"
 	"github.com/scionproto/scion/go/lib/common"
@@ -27,14 +30,21 @@ type Dispatcher struct {
 	OverlaySocket     string
 	ApplicationSocket string
 	SocketFileMode    os.FileMode
+	// Queues configures the sizes of the application ingress queues.
+	Queues dispatcher.QueueConfig
+
+	mtx    sync.Mutex
+	server *dispatcher.Server
 }
 
 func (d *Dispatcher) ListenAndServe() error {
-	dispServer, err := dispatcher.NewServer(d.OverlaySocket)
+	dispServer, err := dispatcher.NewServer(d.OverlaySocket, d.Queues)
 	if err != nil {
 		return err
 	}
 	defer dispServer.Close()
+	d.setServer(dispServer)
+	defer d.setServer(nil)
 
 	dispServerConn, err := reliable.Listen(d.ApplicationSocket)
 	if err != nil {
@@ -62,3 +72,36 @@ func (d *Dispatcher) ListenAndServe() error {
 
 	return <-errChan
 }
+
+// Registrations returns a snapshot of the live application registrations. The
+// second return value is false if the dispatcher is not serving.
+func (d *Dispatcher) Registrations() ([]dispatcher.RegistrationInfo, bool) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if d.server == nil {
+		return nil, false
+	}
+	return d.server.Registrations(), true
+}
+
+// RegistrationsHandler is an HTTP handler that lists the live application
+// registrations as JSON.
+func (d *Dispatcher) RegistrationsHandler(w http.ResponseWriter, r *http.Request) {
+	regs, ok := d.Registrations()
+	if !ok {
+		http.Error(w, "dispatcher not serving", http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(regs); err != nil {
+		log.Error("Unable to write registrations", "err", err)
+	}
+}
+
+func (d *Dispatcher) setServer(server *dispatcher.Server) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	d.server = server
+}
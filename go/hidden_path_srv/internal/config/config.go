@@ -0,0 +1,128 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package config contains the configuration of the hidden path server.
+package config
+
+import (
+	"io"
+
+	"github.com/scionproto/scion/go/lib/config"
+	"github.com/scionproto/scion/go/lib/env"
+	"github.com/scionproto/scion/go/lib/pathstorage"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/truststorage"
+)
+
+var _ config.Config = (*Config)(nil)
+
+// Config is the hidden path server configuration.
+type Config struct {
+	General  env.General
+	Features env.Features
+	Logging  env.Logging
+	Metrics  env.Metrics
+	Tracing  env.Tracing
+	QUIC     env.QUIC `toml:"quic"`
+	TrustDB  truststorage.TrustDBConf
+	HPS      HPSConfig
+}
+
+// InitDefaults initializes the default values for all parts of the config.
+func (cfg *Config) InitDefaults() {
+	config.InitAll(
+		&cfg.General,
+		&cfg.Features,
+		&cfg.Logging,
+		&cfg.Metrics,
+		&cfg.Tracing,
+		&cfg.TrustDB,
+		&cfg.HPS,
+	)
+}
+
+// Validate validates all parts of the config.
+func (cfg *Config) Validate() error {
+	return config.ValidateAll(
+		&cfg.General,
+		&cfg.Features,
+		&cfg.Logging,
+		&cfg.Metrics,
+		&cfg.TrustDB,
+		&cfg.HPS,
+	)
+}
+
+// Sample generates a sample config file for the hidden path server.
+func (cfg *Config) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
+	config.WriteSample(dst, path, config.CtxMap{config.ID: idSample},
+		&cfg.General,
+		&cfg.Features,
+		&cfg.Logging,
+		&cfg.Metrics,
+		&cfg.Tracing,
+		&cfg.QUIC,
+		&cfg.TrustDB,
+		&cfg.HPS,
+	)
+}
+
+// ConfigName returns the name this config should have in a config file.
+func (cfg *Config) ConfigName() string {
+	return "hps_config"
+}
+
+var _ config.Config = (*HPSConfig)(nil)
+
+// HPSConfig holds the configuration specific to the hidden path server.
+type HPSConfig struct {
+	// Address is the address the server listens on. Requests of other hidden
+	// path servers are received over SCION, requests of AS local clients, e.g.,
+	// sciond, are received over TCP on the same address.
+	Address string
+	// GroupFiles is a list of files that contain the hidden path groups that
+	// are known to the server.
+	GroupFiles []string
+	// PathDB contains the configuration for the PathDB connection.
+	PathDB pathstorage.PathDBConf
+	// RevCache contains the configuration for the RevCache connection.
+	RevCache pathstorage.RevCacheConf
+}
+
+// InitDefaults initializes the default values for the path storage.
+func (cfg *HPSConfig) InitDefaults() {
+	config.InitAll(&cfg.PathDB, &cfg.RevCache)
+}
+
+// Validate validates that all values are parsable.
+func (cfg *HPSConfig) Validate() error {
+	if cfg.Address == "" {
+		return serrors.New("Address must be set")
+	}
+	if len(cfg.GroupFiles) == 0 {
+		return serrors.New("GroupFiles must not be empty")
+	}
+	return config.ValidateAll(&cfg.PathDB, &cfg.RevCache)
+}
+
+// Sample generates a sample config file for the hidden path server.
+func (cfg *HPSConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
+	config.WriteString(dst, hpsSample)
+	config.WriteSample(dst, path, ctx, &cfg.PathDB, &cfg.RevCache)
+}
+
+// ConfigName returns the name this config should have in a config file.
+func (cfg *HPSConfig) ConfigName() string {
+	return "hps"
+}
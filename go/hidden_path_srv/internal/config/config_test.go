@@ -0,0 +1,99 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/go/lib/env/envtest"
+	"github.com/scionproto/scion/go/lib/pathstorage/pathstoragetest"
+	"github.com/scionproto/scion/go/lib/truststorage/truststoragetest"
+)
+
+func TestConfigSample(t *testing.T) {
+	var sample bytes.Buffer
+	var cfg Config
+	cfg.Sample(&sample, nil, nil)
+
+	InitTestConfig(&cfg)
+	meta, err := toml.Decode(sample.String(), &cfg)
+	assert.NoError(t, err)
+	assert.Empty(t, meta.Undecoded())
+	CheckTestConfig(t, &cfg, idSample)
+}
+
+func TestHPSConfigValidate(t *testing.T) {
+	tests := map[string]struct {
+		Modify       func(cfg *HPSConfig)
+		ErrAssertion assert.ErrorAssertionFunc
+	}{
+		"sample": {
+			Modify:       func(cfg *HPSConfig) {},
+			ErrAssertion: assert.NoError,
+		},
+		"no address": {
+			Modify: func(cfg *HPSConfig) {
+				cfg.Address = ""
+			},
+			ErrAssertion: assert.Error,
+		},
+		"no group files": {
+			Modify: func(cfg *HPSConfig) {
+				cfg.GroupFiles = nil
+			},
+			ErrAssertion: assert.Error,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var sample bytes.Buffer
+			var cfg Config
+			cfg.Sample(&sample, nil, nil)
+			_, err := toml.Decode(sample.String(), &cfg)
+			assert.NoError(t, err)
+			cfg.InitDefaults()
+			test.Modify(&cfg.HPS)
+			test.ErrAssertion(t, cfg.HPS.Validate())
+		})
+	}
+}
+
+func InitTestConfig(cfg *Config) {
+	envtest.InitTest(&cfg.General, &cfg.Logging, &cfg.Metrics, &cfg.Tracing, nil)
+	truststoragetest.InitTestConfig(&cfg.TrustDB)
+	InitTestHPSConfig(&cfg.HPS)
+}
+
+func InitTestHPSConfig(cfg *HPSConfig) {
+	pathstoragetest.InitTestPathDBConf(&cfg.PathDB)
+	pathstoragetest.InitTestRevCacheConf(&cfg.RevCache)
+}
+
+func CheckTestConfig(t *testing.T, cfg *Config, id string) {
+	envtest.CheckTest(t, &cfg.General, &cfg.Logging, &cfg.Metrics, &cfg.Tracing, nil, id)
+	truststoragetest.CheckTestConfig(t, &cfg.TrustDB, id)
+	CheckTestHPSConfig(t, &cfg.HPS, id)
+}
+
+func CheckTestHPSConfig(t *testing.T, cfg *HPSConfig, id string) {
+	pathstoragetest.CheckTestPathDBConf(t, &cfg.PathDB, id)
+	pathstoragetest.CheckTestRevCacheConf(t, &cfg.RevCache)
+	assert.Equal(t, "127.0.0.1:30258", cfg.Address)
+	assert.Equal(t, []string{"/etc/scion/hp_groups/ff00_0_110-69b5.json"}, cfg.GroupFiles)
+}
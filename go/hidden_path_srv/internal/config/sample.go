@@ -0,0 +1,27 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+const idSample = "hps"
+
+const hpsSample = `
+# The address to listen on. Requests of other hidden path servers are received
+# over SCION, requests of AS local clients, e.g., sciond, over TCP on the same
+# address. (required)
+Address = "127.0.0.1:30258"
+
+# The files containing the hidden path groups known to the server. (required)
+GroupFiles = ["/etc/scion/hp_groups/ff00_0_110-69b5.json"]
+`
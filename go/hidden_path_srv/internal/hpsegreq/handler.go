@@ -15,6 +15,9 @@
 package hpsegreq
 
 import (
+	"net"
+
+	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
 	"github.com/scionproto/scion/go/lib/infra"
@@ -27,14 +30,18 @@ import (
 type hpSegReqHandler struct {
 	request *infra.Request
 	fetcher Fetcher
+	localIA addr.IA
 }
 
-// NewSegReqHandler returns a hidden path segment request handler
-func NewSegReqHandler(fetcher Fetcher) infra.Handler {
+// NewSegReqHandler returns a hidden path segment request handler. Requests
+// received over TCP are sent by AS local clients, e.g., sciond, and are
+// treated as if they originated in localIA.
+func NewSegReqHandler(fetcher Fetcher, localIA addr.IA) infra.Handler {
 	f := func(r *infra.Request) *infra.HandlerResult {
 		handler := &hpSegReqHandler{
 			request: r,
 			fetcher: fetcher,
+			localIA: localIA,
 		}
 		return handler.Handle()
 	}
@@ -67,10 +74,18 @@ func (h *hpSegReqHandler) handle(logger log.Logger) (*infra.HandlerResult, error
 	sendAck := messenger.SendAckHelper(ctx, rw)
 	logger.Debug("[hpSegReqHandler] Received HPSegReq", "src", h.request.Peer, "req", hpSegReq)
 
-	snetPeer, ok := h.request.Peer.(*snet.UDPAddr)
-	if !ok {
-		logger.Error("[hpSegReqHandler] Invalid peer address type, expected *snet.UDPAddr", nil,
-			"peer", h.request.Peer, "type", common.TypeOf(h.request.Peer))
+	var snetPeer *snet.UDPAddr
+	switch peer := h.request.Peer.(type) {
+	case *snet.UDPAddr:
+		snetPeer = peer
+	case *net.TCPAddr:
+		snetPeer = &snet.UDPAddr{
+			IA:   h.localIA,
+			Host: &net.UDPAddr{IP: peer.IP, Port: peer.Port, Zone: peer.Zone},
+		}
+	default:
+		logger.Error("[hpSegReqHandler] Invalid peer address type, expected *snet.UDPAddr "+
+			"or *net.TCPAddr", "peer", h.request.Peer, "type", common.TypeOf(h.request.Peer))
 		sendAck(proto.Ack_ErrCode_reject, messenger.AckRejectFailedToParse)
 		return infra.MetricsErrInvalid, nil
 	}
@@ -19,21 +19,27 @@ import (
 	"errors"
 	"net"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/scionproto/scion/go/hidden_path_srv/internal/hiddenpathdb/adapter"
 	"github.com/scionproto/scion/go/hidden_path_srv/internal/hpsegreq"
 	"github.com/scionproto/scion/go/hidden_path_srv/internal/hpsegreq/mock_hpsegreq"
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/ctrl/ack"
 	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
 	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/hiddenpath"
 	"github.com/scionproto/scion/go/lib/infra"
 	"github.com/scionproto/scion/go/lib/infra/messenger"
+	"github.com/scionproto/scion/go/lib/infra/messenger/tcp"
 	"github.com/scionproto/scion/go/lib/infra/mock_infra"
 	"github.com/scionproto/scion/go/lib/infra/modules/seghandler/mock_seghandler"
 	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/pathdb/sqlite"
 	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/xtest/matchers"
 	"github.com/scionproto/scion/go/proto"
@@ -63,6 +69,24 @@ func TestSegReq(t *testing.T) {
 			res := handler.Handle(req)
 			assert.Equal(t, infra.MetricsResultOk, res)
 		},
+		"AS local TCP peer": func(t *testing.T, ctx context.Context,
+			handler infra.Handler, m *mocks) {
+
+			msg := &path_mgmt.HPSegReq{
+				RawDstIA: ia111.IAInt(),
+				GroupIds: []*path_mgmt.HPGroupId{group1.Id.ToMsg()},
+			}
+			peer := &net.TCPAddr{IP: net.IP{127, 0, 0, 1}, Port: 40000}
+			req := infra.NewRequest(ctx, msg, nil, peer, 0)
+			snetPeer := &snet.UDPAddr{
+				IA:   ia110,
+				Host: &net.UDPAddr{IP: net.IP{127, 0, 0, 1}, Port: 40000},
+			}
+			m.fetcher.EXPECT().Fetch(gomock.Any(), msg, snetPeer).Return(nil, nil)
+			m.rw.EXPECT().SendHPSegReply(gomock.Any(), &path_mgmt.HPSegReply{})
+			res := handler.Handle(req)
+			assert.Equal(t, infra.MetricsResultOk, res)
+		},
 		"wrong message type": func(t *testing.T, ctx context.Context,
 			handler infra.Handler, m *mocks) {
 
@@ -121,12 +145,75 @@ func TestSegReq(t *testing.T) {
 				context.Background(), mocks.rw)
 			handler := hpsegreq.NewSegReqHandler(
 				mocks.fetcher,
+				ia110,
 			)
 			test(t, ctx, handler, mocks)
 		})
 	}
 }
 
+// TestSegReqTCP sends a hidden path segment request over the TCP messenger,
+// the same way sciond does, to a server that serves the handler.
+func TestSegReqTCP(t *testing.T) {
+	log.Root().SetHandler(log.DiscardHandler())
+	newTestGraph(t, gomock.NewController(t))
+	ctx, cancelF := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancelF()
+
+	db, err := sqlite.New(":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	hpDB := adapter.New(db)
+	_, err = hpDB.Insert(ctx, seg130_112, hiddenpath.GroupIdsToSet(group1.Id))
+	require.NoError(t, err)
+	groupInfo := &hpsegreq.GroupInfo{
+		LocalIA: ia110,
+		Groups:  map[hiddenpath.GroupId]*hiddenpath.Group{group1.Id: group1},
+	}
+	fetcher := hpsegreq.NewDefaultFetcher(groupInfo, nil, hpDB)
+
+	// Reserve a free port for the server.
+	l, err := net.ListenTCP("tcp", &net.TCPAddr{IP: net.IP{127, 0, 0, 1}})
+	require.NoError(t, err)
+	serverAddr := l.Addr().(*net.TCPAddr)
+	require.NoError(t, l.Close())
+	server := tcp.NewServerMessenger(serverAddr)
+	server.AddHandler(infra.HPSegRequest, hpsegreq.NewSegReqHandler(fetcher, ia110))
+	go func() {
+		defer log.LogPanicAndExit()
+		server.ListenAndServe()
+	}()
+
+	client := tcp.NewClientMessenger(tcp.Client{})
+	req := &path_mgmt.HPSegReq{
+		RawDstIA: ia112.IAInt(),
+		GroupIds: []*path_mgmt.HPGroupId{group1.Id.ToMsg()},
+	}
+	dst := &snet.UDPAddr{
+		IA:   ia110,
+		Host: &net.UDPAddr{IP: serverAddr.IP, Port: serverAddr.Port},
+	}
+	var reply *path_mgmt.HPSegReply
+	// The server might not be listening yet.
+	for {
+		reply, err = client.GetHPSegs(ctx, req, dst, messenger.NextId())
+		if err == nil || ctx.Err() != nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.NoError(t, err)
+	require.Len(t, reply.Recs, 1)
+	assert.Equal(t, group1.Id.ToMsg(), reply.Recs[0].GroupId)
+	assert.Empty(t, reply.Recs[0].Err)
+	require.Len(t, reply.Recs[0].Recs, 1)
+	expectedID, err := seg130_112.Segment.ID()
+	require.NoError(t, err)
+	id, err := reply.Recs[0].Recs[0].Segment.ID()
+	require.NoError(t, err)
+	assert.Equal(t, expectedID, id)
+}
+
 type mocks struct {
 	fetcher *mock_hpsegreq.MockFetcher
 	storage *mock_seghandler.MockStorage
@@ -0,0 +1,280 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	_ "net/http/pprof"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/scionproto/scion/go/hidden_path_srv/internal/config"
+	"github.com/scionproto/scion/go/hidden_path_srv/internal/hiddenpathdb/adapter"
+	"github.com/scionproto/scion/go/hidden_path_srv/internal/hpcfgreq"
+	"github.com/scionproto/scion/go/hidden_path_srv/internal/hpsegreq"
+	"github.com/scionproto/scion/go/hidden_path_srv/internal/registration"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/env"
+	"github.com/scionproto/scion/go/lib/fatal"
+	"github.com/scionproto/scion/go/lib/hiddenpath"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/infra/infraenv"
+	"github.com/scionproto/scion/go/lib/infra/messenger"
+	"github.com/scionproto/scion/go/lib/infra/messenger/tcp"
+	"github.com/scionproto/scion/go/lib/infra/modules/itopo"
+	"github.com/scionproto/scion/go/lib/infra/modules/seghandler"
+	"github.com/scionproto/scion/go/lib/infra/modules/trust"
+	"github.com/scionproto/scion/go/lib/infra/modules/trust/trustdbmetrics"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/pathdb"
+	"github.com/scionproto/scion/go/lib/pathstorage"
+	"github.com/scionproto/scion/go/lib/periodic"
+	"github.com/scionproto/scion/go/lib/prom"
+	"github.com/scionproto/scion/go/lib/revcache"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/topology"
+)
+
+var (
+	cfg config.Config
+)
+
+func init() {
+	flag.Usage = env.Usage
+}
+
+func main() {
+	os.Exit(realMain())
+}
+
+func realMain() int {
+	fatal.Init()
+	env.AddFlags()
+	flag.Parse()
+	if v, ok := env.CheckFlags(&cfg); !ok {
+		return v
+	}
+	if err := setupBasic(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer log.Flush()
+	defer env.LogAppStopped("HPS", cfg.General.ID)
+	defer log.LogPanicAndExit()
+	if err := setup(); err != nil {
+		log.Crit("Setup failed", "err", err)
+		return 1
+	}
+	groups, err := loadGroups(cfg.HPS.GroupFiles)
+	if err != nil {
+		log.Crit("Unable to load hidden path groups", "err", err)
+		return 1
+	}
+	pathDB, revCache, err := pathstorage.NewPathStorage(cfg.HPS.PathDB, cfg.HPS.RevCache)
+	if err != nil {
+		log.Crit("Unable to initialize path storage", "err", err)
+		return 1
+	}
+	defer pathDB.Close()
+	defer revCache.Close()
+	tracer, trCloser, err := cfg.Tracing.NewTracer(cfg.General.ID)
+	if err != nil {
+		log.Crit("Unable to create tracer", "err", err)
+		return 1
+	}
+	defer trCloser.Close()
+	opentracing.SetGlobalTracer(tracer)
+
+	public, err := net.ResolveUDPAddr("udp", cfg.HPS.Address)
+	if err != nil {
+		log.Crit("Unable to resolve listening address", "err", err, "addr", cfg.HPS.Address)
+		return 1
+	}
+	topo := itopo.Get()
+	nc := infraenv.NetworkConfig{
+		IA:                    topo.IA(),
+		Public:                public,
+		SVC:                   addr.SvcHPS,
+		ReconnectToDispatcher: cfg.General.ReconnectToDispatcher,
+		QUIC: infraenv.QUIC{
+			Address:  cfg.QUIC.Address,
+			CertFile: cfg.QUIC.CertFile,
+			KeyFile:  cfg.QUIC.KeyFile,
+		},
+		SVCResolutionFraction: cfg.QUIC.ResolutionFraction,
+		SVCRouter:             messenger.NewSVCRouter(itopo.Provider()),
+	}
+	msgr, err := nc.Messenger()
+	if err != nil {
+		log.Crit(infraenv.ErrAppUnableToInitMessenger.Error(), "err", err)
+		return 1
+	}
+	defer msgr.CloseServer()
+	// AS local clients, e.g., sciond, send their requests over TCP.
+	tcpMsgr := tcp.NewServerMessenger(&net.TCPAddr{
+		IP:   public.IP,
+		Port: public.Port,
+		Zone: public.Zone,
+	})
+	defer tcpMsgr.CloseServer()
+
+	trustDB, err := cfg.TrustDB.New()
+	if err != nil {
+		log.Crit("Error initializing trust database", "err", err)
+		return 1
+	}
+	trustDB = trustdbmetrics.WithMetrics(string(cfg.TrustDB.Backend()), trustDB)
+	defer trustDB.Close()
+	inserter := trust.DefaultInserter{
+		BaseInserter: trust.BaseInserter{DB: trustDB},
+	}
+	provider := trust.Provider{
+		DB:       trustDB,
+		Recurser: trust.LocalOnlyRecurser{},
+		Resolver: trust.DefaultResolver{
+			DB:       trustDB,
+			Inserter: inserter,
+			RPC:      trust.DefaultRPC{Msgr: msgr},
+			IA:       topo.IA(),
+		},
+		Router: trust.LocalRouter{IA: topo.IA()},
+	}
+	trustStore := trust.Store{
+		Inspector:      trust.DefaultInspector{Provider: provider},
+		CryptoProvider: provider,
+		Inserter:       inserter,
+		DB:             trustDB,
+	}
+	certsDir := filepath.Join(cfg.General.ConfigDir, "certs")
+	err = trustStore.LoadCryptoMaterial(context.Background(), certsDir)
+	if err != nil {
+		log.Crit("Error loading crypto material", "err", err)
+		return 1
+	}
+
+	hpDB := adapter.New(pathDB)
+	groupInfo := &hpsegreq.GroupInfo{LocalIA: topo.IA(), Groups: groups}
+	segReqHandler := hpsegreq.NewSegReqHandler(
+		hpsegreq.NewDefaultFetcher(groupInfo, msgr, hpDB),
+		topo.IA(),
+	)
+	groupList := make([]*hiddenpath.Group, 0, len(groups))
+	for _, group := range groups {
+		groupList = append(groupList, group)
+	}
+	msgr.AddHandler(infra.HPSegRequest, segReqHandler)
+	msgr.AddHandler(infra.HPCfgRequest, hpcfgreq.NewHandler(groupList, topo.IA()))
+	msgr.AddHandler(infra.HPSegReg, registration.NewSegRegHandler(
+		registration.NewDefaultValidator(topo.IA(), groups),
+		seghandler.Handler{
+			Verifier: &seghandler.DefaultVerifier{Verifier: trust.NewVerifier(provider)},
+			Storage:  &seghandler.DefaultStorage{PathDB: pathDB, RevCache: revCache},
+		},
+	))
+	tcpMsgr.AddHandler(infra.HPSegRequest, segReqHandler)
+
+	cleaner := periodic.Start(pathdb.NewCleaner(pathDB, "hps_segments"),
+		300*time.Second, 295*time.Second)
+	defer cleaner.Stop()
+	rcCleaner := periodic.Start(revcache.NewCleaner(revCache, "hps_revocation"),
+		10*time.Second, 10*time.Second)
+	defer rcCleaner.Stop()
+	http.HandleFunc("/config", configHandler)
+	http.HandleFunc("/info", env.InfoHandler)
+	http.HandleFunc("/topology", itopo.TopologyHandler)
+	cfg.Metrics.StartPrometheus()
+	go func() {
+		defer log.LogPanicAndExit()
+		msgr.ListenAndServe()
+	}()
+	go func() {
+		defer log.LogPanicAndExit()
+		tcpMsgr.ListenAndServe()
+	}()
+	select {
+	case <-fatal.ShutdownChan():
+		// Whenever we receive a SIGINT or SIGTERM we exit without an error.
+		// Deferred shutdowns for all running servers run now.
+		return 0
+	case <-fatal.FatalChan():
+		return 1
+	}
+}
+
+// loadGroups loads the hidden path groups from the given files.
+func loadGroups(files []string) (map[hiddenpath.GroupId]*hiddenpath.Group, error) {
+	groups := make(map[hiddenpath.GroupId]*hiddenpath.Group, len(files))
+	for _, file := range files {
+		b, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, serrors.WrapStr("unable to read hidden path group file", err,
+				"file", file)
+		}
+		group := &hiddenpath.Group{}
+		if err := json.Unmarshal(b, group); err != nil {
+			return nil, serrors.WrapStr("unable to parse hidden path group file", err,
+				"file", file)
+		}
+		groups[group.Id] = group
+	}
+	return groups, nil
+}
+
+func setupBasic() error {
+	if _, err := toml.DecodeFile(env.ConfigFile(), &cfg); err != nil {
+		return serrors.New("Failed to load config", "err", err, "file", env.ConfigFile())
+	}
+	cfg.InitDefaults()
+	if err := env.InitLogging(&cfg.Logging); err != nil {
+		return serrors.New("Failed to initialize logging", "err", err)
+	}
+	prom.ExportElementID(cfg.General.ID)
+	return env.LogAppStarted("HPS", cfg.General.ID)
+}
+
+func setup() error {
+	if err := cfg.Validate(); err != nil {
+		return common.NewBasicError("unable to validate config", err)
+	}
+	topo, err := topology.FromJSONFile(cfg.General.Topology)
+	if err != nil {
+		return common.NewBasicError("unable to load topology", err)
+	}
+	itopo.Init(&itopo.Config{})
+	if err := itopo.Update(topo); err != nil {
+		return common.NewBasicError("unable to set initial static topology", err)
+	}
+	infraenv.InitInfraEnvironment(cfg.General.Topology)
+	return nil
+}
+
+func configHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain")
+	var buf bytes.Buffer
+	toml.NewEncoder(&buf).Encode(cfg)
+	fmt.Fprint(w, buf.String())
+}
@@ -38,12 +38,20 @@ const (
 	DefaultIOTimeout = 1 * time.Second
 )
 
+// SCMP authentication modes, see the scmp_auth flag.
+const (
+	SCMPAuthDisabled = "disabled"
+	SCMPAuthOptional = "optional"
+	SCMPAuthStrict   = "strict"
+)
+
 var (
 	Local        snet.UDPAddr
 	Mode         string
 	sciondAddr   string
 	networksFile string
 	Attempts     int
+	scmpAuth     string
 )
 
 func Setup() {
@@ -58,6 +66,8 @@ func addFlags() {
 	flag.StringVar(&networksFile, "networks", integration.SCIONDAddressesFile,
 		"File containing network definitions")
 	flag.IntVar(&Attempts, "attempts", 1, "Number of attempts before giving up")
+	flag.StringVar(&scmpAuth, "scmp_auth", SCMPAuthDisabled, "Authentication of SCMP errors ("+
+		SCMPAuthDisabled+"|"+SCMPAuthOptional+"|"+SCMPAuthStrict+")")
 	log.AddLogConsFlags()
 }
 
@@ -94,6 +104,11 @@ func validateFlags() {
 	if Local.Host == nil {
 		LogFatal("Missing local address")
 	}
+	switch scmpAuth {
+	case SCMPAuthDisabled, SCMPAuthOptional, SCMPAuthStrict:
+	default:
+		LogFatal("Unknown SCMP authentication mode", "mode", scmpAuth)
+	}
 }
 
 func InitNetwork() *snet.SCIONNetwork {
@@ -102,10 +117,15 @@ func InitNetwork() *snet.SCIONNetwork {
 	if err != nil {
 		LogFatal("Unable to initialize SCION network", "err", err)
 	}
+	var opts []snet.NetworkOption
+	if scmpAuth != SCMPAuthDisabled {
+		opts = append(opts, snet.WithSCMPAuth(&snet.DRKeySCMPVerifier{Keys: sciondConn},
+			scmpAuth == SCMPAuthStrict))
+	}
 	n := snet.NewNetworkWithPR(Local.IA, ds, sciond.Querier{
 		Connector: sciondConn,
 		IA:        Local.IA,
-	}, sciond.RevHandler{Connector: sciondConn})
+	}, sciond.RevHandler{Connector: sciondConn}, opts...)
 	log.Debug("SCION network successfully initialized")
 	return n
 }
@@ -0,0 +1,96 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drkey_mgmt_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/ctrl"
+	"github.com/scionproto/scion/go/lib/ctrl/drkey_mgmt"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/proto"
+)
+
+func TestRoundTrip(t *testing.T) {
+	now := time.Unix(1500, 0)
+	meta := drkey.Lvl2Meta{
+		KeyType:  drkey.Host2Host,
+		Protocol: "scmp",
+		SrcIA:    xtest.MustParseIA("1-ff00:0:110"),
+		DstIA:    xtest.MustParseIA("1-ff00:0:111"),
+		SrcHost:  addr.HostFromIPStr("127.0.0.1"),
+		DstHost:  addr.HostFromIPStr("::1"),
+	}
+	tests := map[string]proto.Cerealizable{
+		"lvl1 req": drkey_mgmt.NewLvl1Req(xtest.MustParseIA("1-ff00:0:110"), now),
+		"lvl1 rep": &drkey_mgmt.Lvl1Rep{
+			RawSrcIA:     xtest.MustParseIA("1-ff00:0:110").IAInt(),
+			TimestampRaw: 1500,
+			EpochBegin:   1000,
+			EpochEnd:     2000,
+			Cipher:       []byte{1, 2, 3},
+			Nonce:        []byte{4, 5, 6},
+			CertVerSrc:   2,
+			CertVerDst:   3,
+		},
+		"lvl2 req": drkey_mgmt.NewLvl2ReqFromMeta(meta, now),
+		"lvl2 req no hosts": drkey_mgmt.NewLvl2ReqFromMeta(drkey.Lvl2Meta{
+			KeyType: drkey.AS2AS, Protocol: "scmp", SrcIA: meta.SrcIA, DstIA: meta.DstIA,
+		}, now),
+		"lvl2 rep": drkey_mgmt.NewLvl2RepFromKey(drkey.Lvl2Key{
+			Lvl2Meta: drkey.Lvl2Meta{Epoch: drkey.NewEpoch(1000, 2000)},
+			Key:      xtest.MustParseHexString("0123456789abcdef0123456789abcdef"),
+		}, now),
+	}
+	for name, msg := range tests {
+		t.Run(name, func(t *testing.T) {
+			pld, err := ctrl.NewDRKeyMgmtPld(msg, nil, &ctrl.Data{ReqId: 42})
+			require.NoError(t, err)
+			raw, err := proto.PackRoot(pld)
+			require.NoError(t, err)
+			parsed, err := ctrl.NewPldFromRaw(raw)
+			require.NoError(t, err)
+			dpld, _, err := parsed.GetDRKeyMgmt()
+			require.NoError(t, err)
+			u, err := dpld.Union()
+			require.NoError(t, err)
+			assert.Equal(t, msg, u)
+		})
+	}
+}
+
+func TestLvl2ReqToMeta(t *testing.T) {
+	meta := drkey.Lvl2Meta{
+		KeyType:  drkey.AS2Host,
+		Protocol: "scmp",
+		SrcIA:    xtest.MustParseIA("1-ff00:0:110"),
+		DstIA:    xtest.MustParseIA("1-ff00:0:111"),
+		DstHost:  addr.HostFromIPStr("127.0.0.1"),
+	}
+	req := drkey_mgmt.NewLvl2ReqFromMeta(meta, time.Now())
+	parsed, err := req.ToMeta()
+	require.NoError(t, err)
+	assert.True(t, meta.Equal(parsed))
+
+	req.DstHost.Host = []byte{1}
+	_, err = req.ToMeta()
+	assert.Error(t, err)
+}
@@ -0,0 +1,87 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drkey_mgmt
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/proto"
+)
+
+var _ proto.Cerealizable = (*Lvl1Rep)(nil)
+
+// Lvl1Rep is the reply to a level 1 key request. The key is encrypted with
+// the encryption key of the destination AS, and authenticated with the
+// encryption key of the source AS. The certificate versions identify the key
+// pairs that were used.
+type Lvl1Rep struct {
+	// RawSrcIA is the source AS of the key.
+	RawSrcIA addr.IAInt `capnp:"isdas"`
+	// TimestampRaw is the creation time of the reply, in seconds since Unix
+	// epoch.
+	TimestampRaw uint32 `capnp:"timestamp"`
+	// EpochBegin is the begin of the key validity, in seconds since Unix epoch.
+	EpochBegin uint32
+	// EpochEnd is the end of the key validity, in seconds since Unix epoch.
+	EpochEnd uint32 `capnp:"expTime"`
+	// Cipher is the encrypted key.
+	Cipher []byte
+	// Nonce is the nonce used for encryption.
+	Nonce []byte
+	// CertVerSrc is the version of the source AS certificate.
+	CertVerSrc uint32
+	// CertVerDst is the version of the destination AS certificate.
+	CertVerDst uint32
+}
+
+// SrcIA returns the source AS of the key.
+func (c *Lvl1Rep) SrcIA() addr.IA {
+	return c.RawSrcIA.IA()
+}
+
+// Timestamp returns the creation time of the reply.
+func (c *Lvl1Rep) Timestamp() time.Time {
+	return util.SecsToTime(c.TimestampRaw)
+}
+
+// Epoch returns the validity period of the key.
+func (c *Lvl1Rep) Epoch() drkey.Epoch {
+	return drkey.NewEpoch(c.EpochBegin, c.EpochEnd)
+}
+
+// SrcVersion returns the version of the source AS certificate.
+func (c *Lvl1Rep) SrcVersion() scrypto.Version {
+	return scrypto.Version(c.CertVerSrc)
+}
+
+// DstVersion returns the version of the destination AS certificate.
+func (c *Lvl1Rep) DstVersion() scrypto.Version {
+	return scrypto.Version(c.CertVerDst)
+}
+
+func (c *Lvl1Rep) ProtoId() proto.ProtoIdType {
+	return proto.DRKeyRep_TypeID
+}
+
+func (c *Lvl1Rep) String() string {
+	return fmt.Sprintf("SrcIA: %s Timestamp: %s Epoch: [%d, %d) CertVerSrc: %d CertVerDst: %d",
+		c.SrcIA(), util.TimeToCompact(c.Timestamp()), c.EpochBegin, c.EpochEnd,
+		c.CertVerSrc, c.CertVerDst)
+}
@@ -0,0 +1,72 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drkey_mgmt
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/proto"
+)
+
+var _ proto.Cerealizable = (*Lvl1Req)(nil)
+
+// Lvl1Req is the request for a level 1 key. It is sent by the control service
+// of the destination AS to the control service of the source AS. The request
+// is authenticated by the signature of the control payload.
+type Lvl1Req struct {
+	// RawSrcIA is the source AS of the requested key.
+	RawSrcIA addr.IAInt `capnp:"isdas"`
+	// ValTimeRaw is the point in time at which the requested key must be
+	// valid, in seconds since Unix epoch.
+	ValTimeRaw uint32 `capnp:"timestamp"`
+	Flags      Lvl1ReqFlags
+}
+
+// Lvl1ReqFlags holds the flags of a level 1 key request.
+type Lvl1ReqFlags struct {
+	// Prefetch indicates that the key is requested ahead of time.
+	Prefetch bool
+}
+
+// NewLvl1Req creates a request for the level 1 key of the source AS that is
+// valid at valTime.
+func NewLvl1Req(srcIA addr.IA, valTime time.Time) *Lvl1Req {
+	return &Lvl1Req{
+		RawSrcIA:   srcIA.IAInt(),
+		ValTimeRaw: util.TimeToSecs(valTime),
+	}
+}
+
+// SrcIA returns the source AS of the requested key.
+func (c *Lvl1Req) SrcIA() addr.IA {
+	return c.RawSrcIA.IA()
+}
+
+// ValTime returns the point in time at which the requested key must be valid.
+func (c *Lvl1Req) ValTime() time.Time {
+	return util.SecsToTime(c.ValTimeRaw)
+}
+
+func (c *Lvl1Req) ProtoId() proto.ProtoIdType {
+	return proto.DRKeyReq_TypeID
+}
+
+func (c *Lvl1Req) String() string {
+	return fmt.Sprintf("SrcIA: %s ValTime: %s Prefetch: %v", c.SrcIA(),
+		util.TimeToCompact(c.ValTime()), c.Flags.Prefetch)
+}
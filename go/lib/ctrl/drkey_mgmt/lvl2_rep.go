@@ -0,0 +1,75 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drkey_mgmt
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/proto"
+)
+
+var _ proto.Cerealizable = (*Lvl2Rep)(nil)
+
+// Lvl2Rep is the reply to a level 2 key request.
+type Lvl2Rep struct {
+	// TimestampRaw is the creation time of the reply, in seconds since Unix
+	// epoch.
+	TimestampRaw uint32 `capnp:"timestamp"`
+	// Key is the level 2 key.
+	Key []byte `capnp:"drkey"`
+	// EpochBegin is the begin of the key validity, in seconds since Unix epoch.
+	EpochBegin uint32
+	// EpochEnd is the end of the key validity, in seconds since Unix epoch.
+	EpochEnd uint32
+}
+
+// NewLvl2RepFromKey creates a reply containing the level 2 key.
+func NewLvl2RepFromKey(key drkey.Lvl2Key, now time.Time) *Lvl2Rep {
+	return &Lvl2Rep{
+		TimestampRaw: util.TimeToSecs(now),
+		Key:          key.Key,
+		EpochBegin:   key.Epoch.Begin(),
+		EpochEnd:     key.Epoch.End(),
+	}
+}
+
+// Timestamp returns the creation time of the reply.
+func (c *Lvl2Rep) Timestamp() time.Time {
+	return util.SecsToTime(c.TimestampRaw)
+}
+
+// Epoch returns the validity period of the key.
+func (c *Lvl2Rep) Epoch() drkey.Epoch {
+	return drkey.NewEpoch(c.EpochBegin, c.EpochEnd)
+}
+
+// ToKey returns the level 2 key with the provided metadata. The epoch in meta
+// is replaced with the epoch of the reply.
+func (c *Lvl2Rep) ToKey(meta drkey.Lvl2Meta) drkey.Lvl2Key {
+	meta.Epoch = c.Epoch()
+	return drkey.Lvl2Key{Lvl2Meta: meta, Key: drkey.DRKey(c.Key)}
+}
+
+func (c *Lvl2Rep) ProtoId() proto.ProtoIdType {
+	return proto.DRKeyLvl2Rep_TypeID
+}
+
+func (c *Lvl2Rep) String() string {
+	return fmt.Sprintf("Timestamp: %s Epoch: [%d, %d)", util.TimeToCompact(c.Timestamp()),
+		c.EpochBegin, c.EpochEnd)
+}
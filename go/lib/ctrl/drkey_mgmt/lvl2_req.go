@@ -0,0 +1,142 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drkey_mgmt
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/proto"
+)
+
+var _ proto.Cerealizable = (*Lvl2Req)(nil)
+
+// Lvl2Req is the request for a level 2 key. It is sent by the endhost, via
+// SCIOND, to the control service of the local AS.
+type Lvl2Req struct {
+	Protocol string
+	ReqType  uint8
+	// ValTimeRaw is the point in time at which the requested key must be
+	// valid, in seconds since Unix epoch.
+	ValTimeRaw uint32     `capnp:"valTime"`
+	RawSrcIA   addr.IAInt `capnp:"srcIA"`
+	RawDstIA   addr.IAInt `capnp:"dstIA"`
+	SrcHost    *Host
+	DstHost    *Host
+}
+
+// NewLvl2ReqFromMeta creates a request for the level 2 key described by meta
+// that is valid at valTime. The epoch in meta is ignored.
+func NewLvl2ReqFromMeta(meta drkey.Lvl2Meta, valTime time.Time) *Lvl2Req {
+	return &Lvl2Req{
+		Protocol:   meta.Protocol,
+		ReqType:    uint8(meta.KeyType),
+		ValTimeRaw: util.TimeToSecs(valTime),
+		RawSrcIA:   meta.SrcIA.IAInt(),
+		RawDstIA:   meta.DstIA.IAInt(),
+		SrcHost:    NewHost(meta.SrcHost),
+		DstHost:    NewHost(meta.DstHost),
+	}
+}
+
+// SrcIA returns the source AS of the requested key.
+func (c *Lvl2Req) SrcIA() addr.IA {
+	return c.RawSrcIA.IA()
+}
+
+// DstIA returns the destination AS of the requested key.
+func (c *Lvl2Req) DstIA() addr.IA {
+	return c.RawDstIA.IA()
+}
+
+// ValTime returns the point in time at which the requested key must be valid.
+func (c *Lvl2Req) ValTime() time.Time {
+	return util.SecsToTime(c.ValTimeRaw)
+}
+
+// ToMeta returns the metadata of the requested key. The epoch is not set.
+func (c *Lvl2Req) ToMeta() (drkey.Lvl2Meta, error) {
+	srcHost, err := c.SrcHost.ToHostAddr()
+	if err != nil {
+		return drkey.Lvl2Meta{}, serrors.WrapStr("invalid source host", err)
+	}
+	dstHost, err := c.DstHost.ToHostAddr()
+	if err != nil {
+		return drkey.Lvl2Meta{}, serrors.WrapStr("invalid destination host", err)
+	}
+	return drkey.Lvl2Meta{
+		KeyType:  drkey.Lvl2KeyType(c.ReqType),
+		Protocol: c.Protocol,
+		SrcIA:    c.SrcIA(),
+		DstIA:    c.DstIA(),
+		SrcHost:  srcHost,
+		DstHost:  dstHost,
+	}, nil
+}
+
+func (c *Lvl2Req) ProtoId() proto.ProtoIdType {
+	return proto.DRKeyLvl2Req_TypeID
+}
+
+func (c *Lvl2Req) String() string {
+	return fmt.Sprintf("Protocol: %s Type: %s ValTime: %s SrcIA: %s DstIA: %s "+
+		"SrcHost: %s DstHost: %s", c.Protocol, drkey.Lvl2KeyType(c.ReqType),
+		util.TimeToCompact(c.ValTime()), c.SrcIA(), c.DstIA(), c.SrcHost, c.DstHost)
+}
+
+var _ proto.Cerealizable = (*Host)(nil)
+
+// Host is a host address in a level 2 key request.
+type Host struct {
+	Type addr.HostAddrType
+	Host []byte
+}
+
+// NewHost creates the representation of the host address. If the address is
+// nil, nil is returned.
+func NewHost(host addr.HostAddr) *Host {
+	if host == nil {
+		return nil
+	}
+	return &Host{Type: host.Type(), Host: host.Pack()}
+}
+
+// ToHostAddr returns the host address. If the host is not set, nil is
+// returned.
+func (h *Host) ToHostAddr() (addr.HostAddr, error) {
+	if h == nil || h.Type == addr.HostTypeNone {
+		return nil, nil
+	}
+	return addr.HostFromRaw(h.Host, h.Type)
+}
+
+func (h *Host) ProtoId() proto.ProtoIdType {
+	return proto.DRKeyHost_TypeID
+}
+
+func (h *Host) String() string {
+	host, err := h.ToHostAddr()
+	switch {
+	case err != nil:
+		return fmt.Sprintf("Invalid host: %v", err)
+	case host == nil:
+		return "<nil>"
+	}
+	return host.String()
+}
@@ -0,0 +1,142 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file contains the Go representation of the static info extension. It
+// carries operator-configured metadata (latency, bandwidth, geographic
+// location, link type, internal hops and a free-form note) about an AS. All
+// values are relative to the egress interface of the AS entry that carries the
+// extension.
+
+package seg
+
+import (
+	"fmt"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/proto"
+)
+
+var _ proto.Cerealizable = (*StaticInfoExtn)(nil)
+
+type StaticInfoExtn struct {
+	Latency   LatencyInfo
+	Bandwidth BandwidthInfo
+	// Geo contains the locations of the interfaces of the AS.
+	Geo []GeoInfo
+	// LinkType is the type of the egress link.
+	LinkType proto.StaticInfoExtn_LinkType
+	// InternalHops contains the number of AS internal hops between the egress
+	// interface and the listed interfaces.
+	InternalHops []InterfaceValue
+	Note         string
+}
+
+func (ext *StaticInfoExtn) ProtoId() proto.ProtoIdType {
+	return proto.StaticInfoExtn_TypeID
+}
+
+func (ext *StaticInfoExtn) String() string {
+	if ext == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("Latency: %v Bandwidth: %v Geo: %v LinkType: %v InternalHops: %v "+
+		"Note: %q", ext.Latency, ext.Bandwidth, ext.Geo, ext.LinkType, ext.InternalHops, ext.Note)
+}
+
+// InternalHopsTo returns the number of AS internal hops between the egress
+// interface and the given interface, and whether it is known.
+func (ext *StaticInfoExtn) InternalHopsTo(ifid common.IFIDType) (uint32, bool) {
+	v, ok := lookupInterfaceValue(ext.InternalHops, ifid)
+	return uint32(v), ok
+}
+
+// GeoOf returns the location of the given interface, and whether it is known.
+func (ext *StaticInfoExtn) GeoOf(ifid common.IFIDType) (GeoInfo, bool) {
+	for _, g := range ext.Geo {
+		if g.IfID == ifid {
+			return g, true
+		}
+	}
+	return GeoInfo{}, false
+}
+
+// LatencyInfo contains latencies in microseconds.
+type LatencyInfo struct {
+	// Intra contains the latencies between the egress interface and the listed
+	// interfaces.
+	Intra []InterfaceValue
+	// Inter is the latency of the egress link.
+	Inter uint32
+}
+
+// IntraLatency returns the latency between the egress interface and the
+// given interface, and whether it is known.
+func (l LatencyInfo) IntraLatency(ifid common.IFIDType) (uint32, bool) {
+	v, ok := lookupInterfaceValue(l.Intra, ifid)
+	return uint32(v), ok
+}
+
+func (l LatencyInfo) String() string {
+	return fmt.Sprintf("Intra: %v Inter: %d", l.Intra, l.Inter)
+}
+
+// BandwidthInfo contains bandwidths in Kbit/s.
+type BandwidthInfo struct {
+	// Intra contains the bandwidths between the egress interface and the
+	// listed interfaces.
+	Intra []InterfaceValue
+	// Inter is the bandwidth of the egress link.
+	Inter uint64
+}
+
+// IntraBandwidth returns the bandwidth between the egress interface and the
+// given interface, and whether it is known.
+func (b BandwidthInfo) IntraBandwidth(ifid common.IFIDType) (uint64, bool) {
+	return lookupInterfaceValue(b.Intra, ifid)
+}
+
+func (b BandwidthInfo) String() string {
+	return fmt.Sprintf("Intra: %v Inter: %d", b.Intra, b.Inter)
+}
+
+// InterfaceValue associates a value with an interface.
+type InterfaceValue struct {
+	IfID  common.IFIDType
+	Value uint64
+}
+
+func (v InterfaceValue) String() string {
+	return fmt.Sprintf("%d: %d", v.IfID, v.Value)
+}
+
+// GeoInfo is the location of an interface.
+type GeoInfo struct {
+	IfID      common.IFIDType
+	Latitude  float32
+	Longitude float32
+	Address   string
+}
+
+func (g GeoInfo) String() string {
+	return fmt.Sprintf("%d: (%f, %f) %q", g.IfID, g.Latitude, g.Longitude, g.Address)
+}
+
+func lookupInterfaceValue(values []InterfaceValue, ifid common.IFIDType) (uint64, bool) {
+	for _, v := range values {
+		if v.IfID == ifid {
+			return v.Value, true
+		}
+	}
+	return 0, false
+}
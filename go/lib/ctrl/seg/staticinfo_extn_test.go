@@ -0,0 +1,62 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package seg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/proto"
+)
+
+func TestASEntryStaticInfoRoundTrip(t *testing.T) {
+	ase := &ASEntry{
+		RawIA: xtest.MustParseIA("1-ff00:0:110").IAInt(),
+		MTU:   1472,
+	}
+	ase.Exts.StaticInfo = &StaticInfoExtn{
+		Latency: LatencyInfo{
+			Intra: []InterfaceValue{{IfID: 1, Value: 300}, {IfID: 3, Value: 1200}},
+			Inter: 5000,
+		},
+		Bandwidth: BandwidthInfo{
+			Intra: []InterfaceValue{{IfID: 1, Value: 1000000}},
+			Inter: 400000,
+		},
+		Geo: []GeoInfo{
+			{IfID: 1, Latitude: 47.3769, Longitude: 8.5417, Address: "Zurich"},
+		},
+		LinkType:     proto.StaticInfoExtn_LinkType_direct,
+		InternalHops: []InterfaceValue{{IfID: 3, Value: 2}},
+		Note:         "test",
+	}
+	raw, err := ase.Pack()
+	require.NoError(t, err)
+	parsed, err := NewASEntryFromRaw(raw)
+	require.NoError(t, err)
+	assert.Equal(t, ase.Exts.StaticInfo, parsed.Exts.StaticInfo)
+
+	lat, ok := parsed.Exts.StaticInfo.Latency.IntraLatency(3)
+	assert.True(t, ok)
+	assert.Equal(t, uint32(1200), lat)
+	_, ok = parsed.Exts.StaticInfo.Latency.IntraLatency(2)
+	assert.False(t, ok)
+	hops, ok := parsed.Exts.StaticInfo.InternalHopsTo(3)
+	assert.True(t, ok)
+	assert.Equal(t, uint32(2), hops)
+}
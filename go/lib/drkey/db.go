@@ -0,0 +1,73 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drkey
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/infra/modules/cleaner"
+	"github.com/scionproto/scion/go/lib/infra/modules/db"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+// ErrKeyNotFound indicates that the requested key is not in the database.
+var ErrKeyNotFound = serrors.New("key not found")
+
+// Lvl1DB is the database for level 1 DRKeys. It is used by the destination
+// AS to store the level 1 keys fetched from the source ASes.
+type Lvl1DB interface {
+	// GetLvl1Key returns the level 1 key between the source and destination
+	// AS in meta that is valid at valTime, expressed in seconds since Unix
+	// epoch. The epoch in meta is ignored. ErrKeyNotFound is returned if no
+	// matching key is stored.
+	GetLvl1Key(ctx context.Context, meta Lvl1Meta, valTime uint32) (Lvl1Key, error)
+	// InsertLvl1Key inserts the level 1 key. Inserting an existing key is a
+	// no-op.
+	InsertLvl1Key(ctx context.Context, key Lvl1Key) error
+	// RemoveOutdatedLvl1Keys removes all level 1 keys that expired before
+	// cutoff, expressed in seconds since Unix epoch. It returns the number of
+	// removed keys.
+	RemoveOutdatedLvl1Keys(ctx context.Context, cutoff uint32) (int64, error)
+	db.LimitSetter
+	io.Closer
+}
+
+// Lvl2DB is the database for level 2 DRKeys.
+type Lvl2DB interface {
+	// GetLvl2Key returns the level 2 key described by meta that is valid at
+	// valTime, expressed in seconds since Unix epoch. The epoch in meta is
+	// ignored. ErrKeyNotFound is returned if no matching key is stored.
+	GetLvl2Key(ctx context.Context, meta Lvl2Meta, valTime uint32) (Lvl2Key, error)
+	// InsertLvl2Key inserts the level 2 key. Inserting an existing key is a
+	// no-op.
+	InsertLvl2Key(ctx context.Context, key Lvl2Key) error
+	// RemoveOutdatedLvl2Keys removes all level 2 keys that expired before
+	// cutoff, expressed in seconds since Unix epoch. It returns the number of
+	// removed keys.
+	RemoveOutdatedLvl2Keys(ctx context.Context, cutoff uint32) (int64, error)
+	db.LimitSetter
+	io.Closer
+}
+
+// NewLvl1Cleaner creates a cleaner task that deletes expired level 1 keys.
+func NewLvl1Cleaner(db Lvl1DB, namespace string) *cleaner.Cleaner {
+	return cleaner.New(func(ctx context.Context) (int, error) {
+		count, err := db.RemoveOutdatedLvl1Keys(ctx, util.TimeToSecs(time.Now()))
+		return int(count), err
+	}, namespace)
+}
@@ -0,0 +1,92 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package drkey implements the dynamically recreatable keys (DRKey)
+// infrastructure.
+//
+// Every AS derives a secret value (SV) per epoch from its master key. The
+// level 1 key between a source AS and a destination AS is derived by the
+// source AS from its secret value, and fetched by the destination AS from the
+// control service of the source AS. Level 2 keys are derived from level 1
+// keys and are bound to a protocol and, depending on the key type, to the
+// source and destination hosts. Since the source AS can always re-derive the
+// keys from its secret value, it does not need to store any keys.
+package drkey
+
+import (
+	"crypto/subtle"
+	"encoding/hex"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+// DRKey represents a raw binary key.
+type DRKey []byte
+
+// String does not print the key to avoid leaking it into logs.
+func (k DRKey) String() string {
+	return "[redacted key]"
+}
+
+// Hex returns the hex encoded key. It must only be used for debugging.
+func (k DRKey) Hex() string {
+	return hex.EncodeToString(k)
+}
+
+// Equal returns whether the two keys are equal. The comparison is done in
+// constant time.
+func (k DRKey) Equal(other DRKey) bool {
+	return subtle.ConstantTimeCompare(k, other) == 1
+}
+
+// Epoch represents a validity period of a DRKey.
+type Epoch struct {
+	scrypto.Validity
+}
+
+// NewEpoch constructs an epoch from the begin and end timestamps, both
+// expressed in seconds since Unix epoch.
+func NewEpoch(begin, end uint32) Epoch {
+	return Epoch{
+		scrypto.Validity{
+			NotBefore: util.UnixTime{Time: util.SecsToTime(begin)},
+			NotAfter:  util.UnixTime{Time: util.SecsToTime(end)},
+		},
+	}
+}
+
+// EpochAt returns the epoch of the given duration that contains the provided
+// point in time. Epochs are aligned to the Unix epoch.
+func EpochAt(t time.Time, duration time.Duration) Epoch {
+	secs := uint32(duration / time.Second)
+	begin := util.TimeToSecs(t) / secs * secs
+	return NewEpoch(begin, begin+secs)
+}
+
+// Begin returns the begin of the epoch in seconds since Unix epoch.
+func (e Epoch) Begin() uint32 {
+	return util.TimeToSecs(e.NotBefore.Time)
+}
+
+// End returns the end of the epoch in seconds since Unix epoch.
+func (e Epoch) End() uint32 {
+	return util.TimeToSecs(e.NotAfter.Time)
+}
+
+// Equal returns whether both epochs cover the same period.
+func (e Epoch) Equal(other Epoch) bool {
+	return e.Begin() == other.Begin() && e.End() == other.End()
+}
@@ -0,0 +1,195 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drkey_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+var (
+	ia110 = xtest.MustParseIA("1-ff00:0:110")
+	ia111 = xtest.MustParseIA("1-ff00:0:111")
+)
+
+func TestEpochAt(t *testing.T) {
+	e := drkey.EpochAt(time.Unix(1000, 0), 24*time.Hour)
+	assert.Equal(t, uint32(0), e.Begin())
+	assert.Equal(t, uint32(86400), e.End())
+	e = drkey.EpochAt(time.Unix(86400, 0), 24*time.Hour)
+	assert.Equal(t, uint32(86400), e.Begin())
+	assert.True(t, e.Equal(drkey.NewEpoch(86400, 2*86400)))
+}
+
+func TestSecretValueFactory(t *testing.T) {
+	f := drkey.SecretValueFactory{
+		MasterKey:     []byte("0123456789012345"),
+		EpochDuration: time.Hour,
+	}
+	now := time.Unix(10*3600+5, 0)
+	sv, err := f.GetSecretValue(now)
+	require.NoError(t, err)
+	assert.Len(t, sv.Key, 16)
+	assert.True(t, sv.Epoch.Equal(drkey.NewEpoch(10*3600, 11*3600)))
+
+	same, err := f.GetSecretValue(now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.True(t, sv.Equal(same))
+
+	next, err := f.GetSecretValue(now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.False(t, sv.Key.Equal(next.Key))
+
+	other := f
+	other.MasterKey = []byte("1123456789012345")
+	otherSV, err := other.GetSecretValue(now)
+	require.NoError(t, err)
+	assert.False(t, sv.Key.Equal(otherSV.Key))
+
+	_, err = drkey.SecretValueFactory{EpochDuration: time.Hour}.GetSecretValue(now)
+	assert.Error(t, err)
+}
+
+func TestDeriveLvl1(t *testing.T) {
+	sv := mustSV(t)
+	meta := drkey.Lvl1Meta{Epoch: sv.Epoch, SrcIA: ia110, DstIA: ia111}
+	key, err := drkey.DeriveLvl1(meta, sv)
+	require.NoError(t, err)
+	assert.Len(t, key.Key, 16)
+	again, err := drkey.DeriveLvl1(meta, sv)
+	require.NoError(t, err)
+	assert.True(t, key.Equal(again))
+
+	meta.DstIA = xtest.MustParseIA("1-ff00:0:112")
+	other, err := drkey.DeriveLvl1(meta, sv)
+	require.NoError(t, err)
+	assert.False(t, key.Key.Equal(other.Key))
+
+	meta.Epoch = drkey.NewEpoch(0, 1)
+	_, err = drkey.DeriveLvl1(meta, sv)
+	assert.Error(t, err)
+}
+
+func TestDeriveLvl2(t *testing.T) {
+	sv := mustSV(t)
+	lvl1, err := drkey.DeriveLvl1(drkey.Lvl1Meta{Epoch: sv.Epoch, SrcIA: ia110, DstIA: ia111}, sv)
+	require.NoError(t, err)
+	srcHost := addr.HostFromIPStr("127.0.0.1")
+	dstHost := addr.HostFromIPStr("127.0.0.2")
+
+	tests := map[string]struct {
+		Meta      drkey.Lvl2Meta
+		Assertion assert.ErrorAssertionFunc
+	}{
+		"as2as": {
+			Meta:      drkey.Lvl2Meta{KeyType: drkey.AS2AS, Protocol: "scmp"},
+			Assertion: assert.NoError,
+		},
+		"as2host": {
+			Meta:      drkey.Lvl2Meta{KeyType: drkey.AS2Host, Protocol: "scmp", DstHost: dstHost},
+			Assertion: assert.NoError,
+		},
+		"as2host without host": {
+			Meta:      drkey.Lvl2Meta{KeyType: drkey.AS2Host, Protocol: "scmp"},
+			Assertion: assert.Error,
+		},
+		"host2host": {
+			Meta: drkey.Lvl2Meta{KeyType: drkey.Host2Host, Protocol: "scmp",
+				SrcHost: srcHost, DstHost: dstHost},
+			Assertion: assert.NoError,
+		},
+		"host2host without src host": {
+			Meta:      drkey.Lvl2Meta{KeyType: drkey.Host2Host, Protocol: "scmp", DstHost: dstHost},
+			Assertion: assert.Error,
+		},
+		"unknown type": {
+			Meta:      drkey.Lvl2Meta{KeyType: 7, Protocol: "scmp"},
+			Assertion: assert.Error,
+		},
+	}
+	keys := make(map[string]drkey.DRKey)
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			meta := test.Meta
+			meta.Epoch, meta.SrcIA, meta.DstIA = lvl1.Epoch, lvl1.SrcIA, lvl1.DstIA
+			key, err := drkey.DeriveLvl2(meta, lvl1)
+			test.Assertion(t, err)
+			if err != nil {
+				return
+			}
+			assert.Len(t, key.Key, 16)
+			for other, k := range keys {
+				assert.False(t, key.Key.Equal(k), "same key as %s", other)
+			}
+			keys[name] = key.Key
+		})
+	}
+	t.Run("mismatching level 1 key", func(t *testing.T) {
+		meta := drkey.Lvl2Meta{KeyType: drkey.AS2AS, Protocol: "scmp", Epoch: lvl1.Epoch,
+			SrcIA: ia111, DstIA: ia110}
+		_, err := drkey.DeriveLvl2(meta, lvl1)
+		assert.Error(t, err)
+	})
+}
+
+func TestLvl1Encryption(t *testing.T) {
+	srcPub, srcPriv, err := scrypto.GenKeyPair(scrypto.Curve25519xSalsa20Poly1305)
+	require.NoError(t, err)
+	dstPub, dstPriv, err := scrypto.GenKeyPair(scrypto.Curve25519xSalsa20Poly1305)
+	require.NoError(t, err)
+	nonce, err := scrypto.Nonce(scrypto.NaClBoxNonceSize)
+	require.NoError(t, err)
+	sv := mustSV(t)
+	key, err := drkey.DeriveLvl1(drkey.Lvl1Meta{Epoch: sv.Epoch, SrcIA: ia110, DstIA: ia111}, sv)
+	require.NoError(t, err)
+
+	cipher, err := drkey.EncryptLvl1(key, nonce, dstPub, srcPriv,
+		scrypto.Curve25519xSalsa20Poly1305)
+	require.NoError(t, err)
+
+	t.Run("valid", func(t *testing.T) {
+		dec, err := drkey.DecryptLvl1(cipher, nonce, srcPub, dstPriv,
+			scrypto.Curve25519xSalsa20Poly1305, key.Lvl1Meta)
+		require.NoError(t, err)
+		assert.True(t, key.Equal(dec))
+	})
+	t.Run("metadata mismatch", func(t *testing.T) {
+		meta := key.Lvl1Meta
+		meta.DstIA = ia110
+		_, err := drkey.DecryptLvl1(cipher, nonce, srcPub, dstPriv,
+			scrypto.Curve25519xSalsa20Poly1305, meta)
+		assert.Error(t, err)
+	})
+	t.Run("wrong key", func(t *testing.T) {
+		_, err := drkey.DecryptLvl1(cipher, nonce, dstPub, dstPriv,
+			scrypto.Curve25519xSalsa20Poly1305, key.Lvl1Meta)
+		assert.Error(t, err)
+	})
+}
+
+func mustSV(t *testing.T) drkey.SV {
+	sv, err := drkey.DeriveSV(drkey.SVMeta{Epoch: drkey.NewEpoch(0, 3600)},
+		[]byte("0123456789012345"))
+	require.NoError(t, err)
+	return sv
+}
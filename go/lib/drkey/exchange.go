@@ -0,0 +1,80 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drkey
+
+import (
+	"encoding/binary"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// lvl1PlainLen is the length of the encrypted level 1 key and its metadata.
+const lvl1PlainLen = 16 + 2*addr.IABytes + 8
+
+// EncryptLvl1 encrypts the level 1 key for the destination AS. The metadata
+// is bound to the ciphertext, such that the destination AS can detect
+// mismatches on decryption. The public key is the encryption key of the
+// destination AS and the private key is the decryption key of the source AS.
+func EncryptLvl1(key Lvl1Key, nonce, pubKey, privKey []byte, algo string) ([]byte, error) {
+	if len(key.Key) != 16 {
+		return nil, serrors.New("invalid key length", "len", len(key.Key))
+	}
+	cipher, err := scrypto.Encrypt(packLvl1(key), nonce, pubKey, privKey, algo)
+	if err != nil {
+		return nil, serrors.WrapStr("unable to encrypt level 1 key", err)
+	}
+	return cipher, nil
+}
+
+// DecryptLvl1 decrypts the level 1 key and checks that it matches the
+// expected metadata. The public key is the encryption key of the source AS
+// and the private key is the decryption key of the destination AS.
+func DecryptLvl1(cipher, nonce, pubKey, privKey []byte, algo string,
+	meta Lvl1Meta) (Lvl1Key, error) {
+
+	raw, err := scrypto.Decrypt(cipher, nonce, pubKey, privKey, algo)
+	if err != nil {
+		return Lvl1Key{}, serrors.WrapStr("unable to decrypt level 1 key", err)
+	}
+	if len(raw) != lvl1PlainLen {
+		return Lvl1Key{}, serrors.New("invalid plaintext length", "len", len(raw))
+	}
+	key := Lvl1Key{
+		Lvl1Meta: Lvl1Meta{
+			SrcIA: addr.IAFromRaw(raw[16 : 16+addr.IABytes]),
+			DstIA: addr.IAFromRaw(raw[16+addr.IABytes : 16+2*addr.IABytes]),
+			Epoch: NewEpoch(binary.BigEndian.Uint32(raw[lvl1PlainLen-8:]),
+				binary.BigEndian.Uint32(raw[lvl1PlainLen-4:])),
+		},
+		Key: DRKey(append([]byte(nil), raw[:16]...)),
+	}
+	if !key.Lvl1Meta.Equal(meta) {
+		return Lvl1Key{}, serrors.New("level 1 key metadata mismatch",
+			"expected", meta, "actual", key.Lvl1Meta)
+	}
+	return key, nil
+}
+
+func packLvl1(key Lvl1Key) []byte {
+	raw := make([]byte, lvl1PlainLen)
+	copy(raw, key.Key)
+	key.SrcIA.Write(raw[16:])
+	key.DstIA.Write(raw[16+addr.IABytes:])
+	binary.BigEndian.PutUint32(raw[lvl1PlainLen-8:], key.Epoch.Begin())
+	binary.BigEndian.PutUint32(raw[lvl1PlainLen-4:], key.Epoch.End())
+	return raw
+}
@@ -0,0 +1,62 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drkey
+
+import (
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// Lvl1Meta represents the information about a level 1 DRKey other than the key itself.
+type Lvl1Meta struct {
+	Epoch Epoch
+	SrcIA addr.IA
+	DstIA addr.IA
+}
+
+// Equal returns whether both metadata describe the same key.
+func (m Lvl1Meta) Equal(other Lvl1Meta) bool {
+	return m.Epoch.Equal(other.Epoch) && m.SrcIA.Equal(other.SrcIA) &&
+		m.DstIA.Equal(other.DstIA)
+}
+
+// Lvl1Key represents a level 1 DRKey.
+type Lvl1Key struct {
+	Lvl1Meta
+	Key DRKey
+}
+
+// Equal returns whether both level 1 keys are equal.
+func (k Lvl1Key) Equal(other Lvl1Key) bool {
+	return k.Lvl1Meta.Equal(other.Lvl1Meta) && k.Key.Equal(other.Key)
+}
+
+// DeriveLvl1 derives the level 1 DRKey described by meta from the secret value
+// of the source AS.
+func DeriveLvl1(meta Lvl1Meta, sv SV) (Lvl1Key, error) {
+	if !meta.Epoch.Equal(sv.Epoch) {
+		return Lvl1Key{}, serrors.New("epoch mismatch", "key_epoch", meta.Epoch,
+			"sv_epoch", sv.Epoch)
+	}
+	mac, err := scrypto.InitMac(sv.Key)
+	if err != nil {
+		return Lvl1Key{}, serrors.WrapStr("unable to initialize mac", err)
+	}
+	all := make([]byte, 16)
+	meta.DstIA.Write(all)
+	mac.Write(all)
+	return Lvl1Key{Lvl1Meta: meta, Key: DRKey(mac.Sum(nil))}, nil
+}
@@ -0,0 +1,136 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drkey
+
+import (
+	"fmt"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// Lvl2KeyType represents the different types of level 2 DRKeys.
+type Lvl2KeyType uint8
+
+// Lvl2KeyType constants.
+const (
+	// AS2AS is a key shared between the source and the destination AS.
+	AS2AS Lvl2KeyType = iota
+	// AS2Host is a key shared between the source AS and a destination host.
+	AS2Host
+	// Host2Host is a key shared between a source host and a destination host.
+	Host2Host
+)
+
+func (t Lvl2KeyType) String() string {
+	switch t {
+	case AS2AS:
+		return "AS2AS"
+	case AS2Host:
+		return "AS2Host"
+	case Host2Host:
+		return "Host2Host"
+	default:
+		return fmt.Sprintf("UNKNOWN(%d)", uint8(t))
+	}
+}
+
+// Lvl2Meta represents the information about a level 2 DRKey other than the key itself.
+type Lvl2Meta struct {
+	KeyType  Lvl2KeyType
+	Protocol string
+	Epoch    Epoch
+	SrcIA    addr.IA
+	DstIA    addr.IA
+	SrcHost  addr.HostAddr
+	DstHost  addr.HostAddr
+}
+
+// Equal returns whether both metadata describe the same key.
+func (m Lvl2Meta) Equal(other Lvl2Meta) bool {
+	return m.KeyType == other.KeyType && m.Protocol == other.Protocol &&
+		m.Epoch.Equal(other.Epoch) && m.SrcIA.Equal(other.SrcIA) &&
+		m.DstIA.Equal(other.DstIA) && hostEqual(m.SrcHost, other.SrcHost) &&
+		hostEqual(m.DstHost, other.DstHost)
+}
+
+// Lvl2Key represents a level 2 DRKey.
+type Lvl2Key struct {
+	Lvl2Meta
+	Key DRKey
+}
+
+// Equal returns whether both level 2 keys are equal.
+func (k Lvl2Key) Equal(other Lvl2Key) bool {
+	return k.Lvl2Meta.Equal(other.Lvl2Meta) && k.Key.Equal(other.Key)
+}
+
+// DeriveLvl2 derives the level 2 DRKey described by meta from the level 1
+// key. The hosts are only part of the derivation if the key type requires
+// them.
+func DeriveLvl2(meta Lvl2Meta, lvl1 Lvl1Key) (Lvl2Key, error) {
+	if !meta.SrcIA.Equal(lvl1.SrcIA) || !meta.DstIA.Equal(lvl1.DstIA) ||
+		!meta.Epoch.Equal(lvl1.Epoch) {
+
+		return Lvl2Key{}, serrors.New("level 1 key does not match", "lvl1", lvl1.Lvl1Meta,
+			"lvl2", meta)
+	}
+	if len(meta.Protocol) > 255 {
+		return Lvl2Key{}, serrors.New("protocol identifier too long",
+			"len", len(meta.Protocol))
+	}
+	all := []byte{byte(meta.KeyType), byte(len(meta.Protocol))}
+	all = append(all, meta.Protocol...)
+	switch meta.KeyType {
+	case AS2AS:
+	case AS2Host:
+		if meta.DstHost == nil {
+			return Lvl2Key{}, serrors.New("destination host not set", "type", meta.KeyType)
+		}
+		all = appendHost(all, meta.DstHost)
+	case Host2Host:
+		if meta.SrcHost == nil || meta.DstHost == nil {
+			return Lvl2Key{}, serrors.New("hosts not set", "type", meta.KeyType)
+		}
+		all = appendHost(all, meta.SrcHost)
+		all = appendHost(all, meta.DstHost)
+	default:
+		return Lvl2Key{}, serrors.New("unknown key type", "type", meta.KeyType)
+	}
+	// Pad to the block size of the MAC.
+	if rem := len(all) % 16; rem != 0 {
+		all = append(all, make([]byte, 16-rem)...)
+	}
+	mac, err := scrypto.InitMac(lvl1.Key)
+	if err != nil {
+		return Lvl2Key{}, serrors.WrapStr("unable to initialize mac", err)
+	}
+	mac.Write(all)
+	return Lvl2Key{Lvl2Meta: meta, Key: DRKey(mac.Sum(nil))}, nil
+}
+
+func appendHost(b []byte, host addr.HostAddr) []byte {
+	raw := host.Pack()
+	b = append(b, byte(host.Type()), byte(len(raw)))
+	return append(b, raw...)
+}
+
+func hostEqual(a, b addr.HostAddr) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Equal(b)
+}
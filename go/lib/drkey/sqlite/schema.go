@@ -0,0 +1,62 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlite
+
+import "github.com/scionproto/scion/go/lib/infra/modules/db"
+
+// Migrations are the schema migrations of the SQLite DRKey database.
+// Existing migrations must never be changed, schema changes are appended as
+// new migrations.
+var Migrations = db.Migrations{
+	{
+		Version:     1,
+		Description: "Initial schema",
+		Up: `CREATE TABLE DRKeyLvl1(
+			SrcIsdID INTEGER NOT NULL,
+			SrcAsID INTEGER NOT NULL,
+			DstIsdID INTEGER NOT NULL,
+			DstAsID INTEGER NOT NULL,
+			EpochBegin INTEGER NOT NULL,
+			EpochEnd INTEGER NOT NULL,
+			Key DATA NOT NULL,
+			PRIMARY KEY (SrcIsdID, SrcAsID, DstIsdID, DstAsID, EpochBegin)
+		);
+		CREATE INDEX DRKeyLvl1EpochEnd ON DRKeyLvl1(EpochEnd);
+		CREATE TABLE DRKeyLvl2(
+			Protocol TEXT NOT NULL,
+			Type INTEGER NOT NULL,
+			SrcIsdID INTEGER NOT NULL,
+			SrcAsID INTEGER NOT NULL,
+			DstIsdID INTEGER NOT NULL,
+			DstAsID INTEGER NOT NULL,
+			SrcHostType INTEGER NOT NULL,
+			SrcHost DATA NOT NULL,
+			DstHostType INTEGER NOT NULL,
+			DstHost DATA NOT NULL,
+			EpochBegin INTEGER NOT NULL,
+			EpochEnd INTEGER NOT NULL,
+			Key DATA NOT NULL,
+			PRIMARY KEY (Protocol, Type, SrcIsdID, SrcAsID, DstIsdID, DstAsID,
+				SrcHostType, SrcHost, DstHostType, DstHost, EpochBegin)
+		);
+		CREATE INDEX DRKeyLvl2EpochEnd ON DRKeyLvl2(EpochEnd);
+		`,
+	},
+}
+
+const (
+	Lvl1Table = "DRKeyLvl1"
+	Lvl2Table = "DRKeyLvl2"
+)
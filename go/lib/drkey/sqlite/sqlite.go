@@ -0,0 +1,181 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sqlite contains an SQLite backend for the DRKey database.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/infra/modules/db"
+)
+
+var (
+	_ drkey.Lvl1DB = (*Backend)(nil)
+	_ drkey.Lvl2DB = (*Backend)(nil)
+)
+
+// Backend is a DRKey database that is backed by an SQLite database. It stores
+// both level 1 and level 2 keys.
+type Backend struct {
+	sync.RWMutex
+	db *sql.DB
+}
+
+// New returns a new SQLite backend opening a database at the given path. If
+// no database exists a new database is be created. Pending migrations in
+// schema.go are applied. If the schema version of the stored database is newer
+// than the latest migration, an error is returned.
+func New(path string) (*Backend, error) {
+	db, err := db.NewSqlite(path, Migrations)
+	if err != nil {
+		return nil, err
+	}
+	return &Backend{db: db}, nil
+}
+
+func (b *Backend) GetLvl1Key(ctx context.Context, meta drkey.Lvl1Meta,
+	valTime uint32) (drkey.Lvl1Key, error) {
+
+	b.RLock()
+	defer b.RUnlock()
+	query := `
+	SELECT EpochBegin, EpochEnd, Key FROM DRKeyLvl1
+	WHERE SrcIsdID = ? AND SrcAsID = ? AND DstIsdID = ? AND DstAsID = ?
+	AND EpochBegin <= ? AND ? < EpochEnd
+	`
+	var begin, end uint32
+	var key []byte
+	err := b.db.QueryRowContext(ctx, query, meta.SrcIA.I, meta.SrcIA.A, meta.DstIA.I,
+		meta.DstIA.A, valTime, valTime).Scan(&begin, &end, &key)
+	switch {
+	case err == sql.ErrNoRows:
+		return drkey.Lvl1Key{}, drkey.ErrKeyNotFound
+	case err != nil:
+		return drkey.Lvl1Key{}, db.NewReadError("lookup level 1 key", err)
+	}
+	meta.Epoch = drkey.NewEpoch(begin, end)
+	return drkey.Lvl1Key{Lvl1Meta: meta, Key: key}, nil
+}
+
+func (b *Backend) InsertLvl1Key(ctx context.Context, key drkey.Lvl1Key) error {
+	b.Lock()
+	defer b.Unlock()
+	query := `
+	INSERT OR IGNORE INTO DRKeyLvl1
+	(SrcIsdID, SrcAsID, DstIsdID, DstAsID, EpochBegin, EpochEnd, Key)
+	VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err := b.db.ExecContext(ctx, query, key.SrcIA.I, key.SrcIA.A, key.DstIA.I,
+		key.DstIA.A, key.Epoch.Begin(), key.Epoch.End(), []byte(key.Key))
+	if err != nil {
+		return db.NewWriteError("insert level 1 key", err)
+	}
+	return nil
+}
+
+func (b *Backend) RemoveOutdatedLvl1Keys(ctx context.Context, cutoff uint32) (int64, error) {
+	b.Lock()
+	defer b.Unlock()
+	cnt, err := db.DeleteInTx(ctx, b.db, func(tx *sql.Tx) (sql.Result, error) {
+		return tx.ExecContext(ctx, "DELETE FROM DRKeyLvl1 WHERE EpochEnd < ?", cutoff)
+	})
+	return int64(cnt), err
+}
+
+func (b *Backend) GetLvl2Key(ctx context.Context, meta drkey.Lvl2Meta,
+	valTime uint32) (drkey.Lvl2Key, error) {
+
+	b.RLock()
+	defer b.RUnlock()
+	srcType, srcHost := packHost(meta.SrcHost)
+	dstType, dstHost := packHost(meta.DstHost)
+	query := `
+	SELECT EpochBegin, EpochEnd, Key FROM DRKeyLvl2
+	WHERE Protocol = ? AND Type = ? AND SrcIsdID = ? AND SrcAsID = ?
+	AND DstIsdID = ? AND DstAsID = ? AND SrcHostType = ? AND SrcHost = ?
+	AND DstHostType = ? AND DstHost = ? AND EpochBegin <= ? AND ? < EpochEnd
+	`
+	var begin, end uint32
+	var key []byte
+	err := b.db.QueryRowContext(ctx, query, meta.Protocol, meta.KeyType, meta.SrcIA.I,
+		meta.SrcIA.A, meta.DstIA.I, meta.DstIA.A, srcType, srcHost, dstType, dstHost,
+		valTime, valTime).Scan(&begin, &end, &key)
+	switch {
+	case err == sql.ErrNoRows:
+		return drkey.Lvl2Key{}, drkey.ErrKeyNotFound
+	case err != nil:
+		return drkey.Lvl2Key{}, db.NewReadError("lookup level 2 key", err)
+	}
+	meta.Epoch = drkey.NewEpoch(begin, end)
+	return drkey.Lvl2Key{Lvl2Meta: meta, Key: key}, nil
+}
+
+func (b *Backend) InsertLvl2Key(ctx context.Context, key drkey.Lvl2Key) error {
+	b.Lock()
+	defer b.Unlock()
+	srcType, srcHost := packHost(key.SrcHost)
+	dstType, dstHost := packHost(key.DstHost)
+	query := `
+	INSERT OR IGNORE INTO DRKeyLvl2
+	(Protocol, Type, SrcIsdID, SrcAsID, DstIsdID, DstAsID, SrcHostType, SrcHost,
+	DstHostType, DstHost, EpochBegin, EpochEnd, Key)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err := b.db.ExecContext(ctx, query, key.Protocol, key.KeyType, key.SrcIA.I,
+		key.SrcIA.A, key.DstIA.I, key.DstIA.A, srcType, srcHost, dstType, dstHost,
+		key.Epoch.Begin(), key.Epoch.End(), []byte(key.Key))
+	if err != nil {
+		return db.NewWriteError("insert level 2 key", err)
+	}
+	return nil
+}
+
+func (b *Backend) RemoveOutdatedLvl2Keys(ctx context.Context, cutoff uint32) (int64, error) {
+	b.Lock()
+	defer b.Unlock()
+	cnt, err := db.DeleteInTx(ctx, b.db, func(tx *sql.Tx) (sql.Result, error) {
+		return tx.ExecContext(ctx, "DELETE FROM DRKeyLvl2 WHERE EpochEnd < ?", cutoff)
+	})
+	return int64(cnt), err
+}
+
+func (b *Backend) Close() error {
+	return b.db.Close()
+}
+
+func (b *Backend) SetMaxOpenConns(maxOpenConns int) {
+	b.db.SetMaxOpenConns(maxOpenConns)
+}
+
+func (b *Backend) SetMaxIdleConns(maxIdleConns int) {
+	b.db.SetMaxIdleConns(maxIdleConns)
+}
+
+// packHost returns the type and the raw bytes of the host. Unset hosts are
+// stored with the none type and an empty address, since NULL values are not
+// comparable in the primary key.
+func packHost(host addr.HostAddr) (addr.HostAddrType, common.RawBytes) {
+	if host == nil {
+		return addr.HostTypeNone, common.RawBytes{}
+	}
+	return host.Type(), host.Pack()
+}
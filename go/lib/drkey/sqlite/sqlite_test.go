@@ -0,0 +1,160 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlite_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/drkey/sqlite"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+const timeout = 3 * time.Second
+
+var (
+	ia110 = xtest.MustParseIA("1-ff00:0:110")
+	ia111 = xtest.MustParseIA("1-ff00:0:111")
+)
+
+func TestLvl1Keys(t *testing.T) {
+	b, cleanF := newBackend(t)
+	defer cleanF()
+	ctx, cancelF := context.WithTimeout(context.Background(), timeout)
+	defer cancelF()
+
+	key := drkey.Lvl1Key{
+		Lvl1Meta: drkey.Lvl1Meta{Epoch: drkey.NewEpoch(100, 200), SrcIA: ia110, DstIA: ia111},
+		Key:      drkey.DRKey(xtest.MustParseHexString("0123456789abcdef0123456789abcdef")),
+	}
+	require.NoError(t, b.InsertLvl1Key(ctx, key))
+	// Inserting the same key again is a no-op.
+	require.NoError(t, b.InsertLvl1Key(ctx, key))
+
+	query := drkey.Lvl1Meta{SrcIA: ia110, DstIA: ia111}
+	t.Run("valid", func(t *testing.T) {
+		for _, valTime := range []uint32{100, 150, 199} {
+			got, err := b.GetLvl1Key(ctx, query, valTime)
+			require.NoError(t, err)
+			assert.True(t, key.Equal(got))
+		}
+	})
+	t.Run("not found", func(t *testing.T) {
+		_, err := b.GetLvl1Key(ctx, query, 200)
+		xtest.AssertErrorsIs(t, err, drkey.ErrKeyNotFound)
+		_, err = b.GetLvl1Key(ctx, drkey.Lvl1Meta{SrcIA: ia111, DstIA: ia110}, 150)
+		xtest.AssertErrorsIs(t, err, drkey.ErrKeyNotFound)
+	})
+	t.Run("remove outdated", func(t *testing.T) {
+		n, err := b.RemoveOutdatedLvl1Keys(ctx, 200)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), n)
+		n, err = b.RemoveOutdatedLvl1Keys(ctx, 201)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), n)
+		_, err = b.GetLvl1Key(ctx, query, 150)
+		xtest.AssertErrorsIs(t, err, drkey.ErrKeyNotFound)
+	})
+}
+
+func TestLvl2Keys(t *testing.T) {
+	b, cleanF := newBackend(t)
+	defer cleanF()
+	ctx, cancelF := context.WithTimeout(context.Background(), timeout)
+	defer cancelF()
+
+	meta := drkey.Lvl2Meta{
+		KeyType:  drkey.AS2Host,
+		Protocol: "scmp",
+		Epoch:    drkey.NewEpoch(100, 200),
+		SrcIA:    ia110,
+		DstIA:    ia111,
+		DstHost:  addr.HostFromIPStr("127.0.0.1"),
+	}
+	key := drkey.Lvl2Key{
+		Lvl2Meta: meta,
+		Key:      drkey.DRKey(xtest.MustParseHexString("0123456789abcdef0123456789abcdef")),
+	}
+	asKey := drkey.Lvl2Key{
+		Lvl2Meta: drkey.Lvl2Meta{KeyType: drkey.AS2AS, Protocol: "scmp",
+			Epoch: meta.Epoch, SrcIA: ia110, DstIA: ia111},
+		Key: drkey.DRKey(xtest.MustParseHexString("fedcba9876543210fedcba9876543210")),
+	}
+	require.NoError(t, b.InsertLvl2Key(ctx, key))
+	require.NoError(t, b.InsertLvl2Key(ctx, asKey))
+
+	t.Run("valid", func(t *testing.T) {
+		got, err := b.GetLvl2Key(ctx, meta, 150)
+		require.NoError(t, err)
+		assert.True(t, key.Equal(got))
+		got, err = b.GetLvl2Key(ctx, asKey.Lvl2Meta, 150)
+		require.NoError(t, err)
+		assert.True(t, asKey.Equal(got))
+	})
+	t.Run("not found", func(t *testing.T) {
+		other := meta
+		other.DstHost = addr.HostFromIPStr("127.0.0.2")
+		_, err := b.GetLvl2Key(ctx, other, 150)
+		xtest.AssertErrorsIs(t, err, drkey.ErrKeyNotFound)
+		other = meta
+		other.Protocol = "other"
+		_, err = b.GetLvl2Key(ctx, other, 150)
+		xtest.AssertErrorsIs(t, err, drkey.ErrKeyNotFound)
+		_, err = b.GetLvl2Key(ctx, meta, 99)
+		xtest.AssertErrorsIs(t, err, drkey.ErrKeyNotFound)
+	})
+	t.Run("remove outdated", func(t *testing.T) {
+		n, err := b.RemoveOutdatedLvl2Keys(ctx, 201)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), n)
+	})
+}
+
+func TestPersistence(t *testing.T) {
+	dir, cleanF := xtest.MustTempDir("", "drkeydb-sqlite")
+	defer cleanF()
+	path := filepath.Join(dir, "drkey.db")
+	ctx, cancelF := context.WithTimeout(context.Background(), timeout)
+	defer cancelF()
+
+	key := drkey.Lvl1Key{
+		Lvl1Meta: drkey.Lvl1Meta{Epoch: drkey.NewEpoch(100, 200), SrcIA: ia110, DstIA: ia111},
+		Key:      drkey.DRKey(xtest.MustParseHexString("0123456789abcdef0123456789abcdef")),
+	}
+	b, err := sqlite.New(path)
+	require.NoError(t, err)
+	require.NoError(t, b.InsertLvl1Key(ctx, key))
+	require.NoError(t, b.Close())
+
+	b, err = sqlite.New(path)
+	require.NoError(t, err)
+	defer b.Close()
+	got, err := b.GetLvl1Key(ctx, key.Lvl1Meta, 150)
+	require.NoError(t, err)
+	assert.True(t, key.Equal(got))
+}
+
+func newBackend(t *testing.T) (*sqlite.Backend, func()) {
+	b, err := sqlite.New(":memory:")
+	require.NoError(t, err)
+	return b, func() { b.Close() }
+}
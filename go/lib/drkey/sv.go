@@ -0,0 +1,79 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drkey
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"time"
+
+	"golang.org/x/crypto/pbkdf2"
+
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+var svSalt = []byte("Derive DRKey Key")
+
+// SVMeta represents the information about a secret value.
+type SVMeta struct {
+	Epoch Epoch
+}
+
+// SV represents a secret value, i.e. the AS local secret from which the level
+// 1 keys of an epoch are derived.
+type SV struct {
+	SVMeta
+	Key DRKey
+}
+
+// Equal returns whether both secret values are equal.
+func (sv SV) Equal(other SV) bool {
+	return sv.Epoch.Equal(other.Epoch) && sv.Key.Equal(other.Key)
+}
+
+// DeriveSV derives the secret value for the epoch in meta from the AS master
+// key.
+func DeriveSV(meta SVMeta, asSecret []byte) (SV, error) {
+	msKey := pbkdf2.Key(asSecret, svSalt, 1000, 16, sha256.New)
+	mac, err := scrypto.InitMac(msKey)
+	if err != nil {
+		return SV{}, serrors.WrapStr("unable to initialize mac", err)
+	}
+	all := make([]byte, 16)
+	binary.BigEndian.PutUint32(all[:4], meta.Epoch.Begin())
+	binary.BigEndian.PutUint32(all[4:8], meta.Epoch.End())
+	mac.Write(all)
+	return SV{SVMeta: meta, Key: DRKey(mac.Sum(nil))}, nil
+}
+
+// SecretValueFactory derives the secret values of the fixed length epochs.
+type SecretValueFactory struct {
+	// MasterKey is the AS master key the secret values are derived from.
+	MasterKey []byte
+	// EpochDuration is the length of a single epoch.
+	EpochDuration time.Duration
+}
+
+// GetSecretValue returns the secret value of the epoch that contains t.
+func (f SecretValueFactory) GetSecretValue(t time.Time) (SV, error) {
+	if len(f.MasterKey) == 0 {
+		return SV{}, serrors.New("master key not set")
+	}
+	if f.EpochDuration < time.Second {
+		return SV{}, serrors.New("invalid epoch duration", "duration", f.EpochDuration)
+	}
+	return DeriveSV(SVMeta{Epoch: EpochAt(t, f.EpochDuration)}, f.MasterKey)
+}
@@ -39,6 +39,10 @@
 //  infra.HPCfgReply          -> ctrl.SignedPld/ctrl.Pld/path_mgmt.HPCfgReply
 //  infra.ChainIssueRequest   -> ctrl.SignedPld/ctrl.Pld/cert_mgmt.ChainIssReq
 //  infra.ChainIssueReply     -> ctrl.SignedPld/ctrl.Pld/cert_mgmt.ChainIssRep
+//  infra.DRKeyLvl1Request    -> ctrl.SignedPld/ctrl.Pld/drkey_mgmt.Lvl1Req
+//  infra.DRKeyLvl1Reply      -> ctrl.SignedPld/ctrl.Pld/drkey_mgmt.Lvl1Rep
+//  infra.DRKeyLvl2Request    -> ctrl.SignedPld/ctrl.Pld/drkey_mgmt.Lvl2Req
+//  infra.DRKeyLvl2Reply      -> ctrl.SignedPld/ctrl.Pld/drkey_mgmt.Lvl2Rep
 //
 // To start processing messages received via the Messenger, call
 // ListenAndServe. The method runs in the current goroutine, and spawns new
@@ -91,6 +95,7 @@ import (
 	"github.com/scionproto/scion/go/lib/ctrl"
 	"github.com/scionproto/scion/go/lib/ctrl/ack"
 	"github.com/scionproto/scion/go/lib/ctrl/cert_mgmt"
+	"github.com/scionproto/scion/go/lib/ctrl/drkey_mgmt"
 	"github.com/scionproto/scion/go/lib/ctrl/ctrl_msg"
 	"github.com/scionproto/scion/go/lib/ctrl/ifid"
 	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
@@ -661,6 +666,94 @@ func (m *Messenger) SendChainIssueReply(ctx context.Context, msg *cert_mgmt.Chai
 	return m.getFallbackRequester(infra.ChainIssueReply).Notify(ctx, pld, a)
 }
 
+func (m *Messenger) RequestDRKeyLvl1(ctx context.Context, msg *drkey_mgmt.Lvl1Req,
+	a net.Addr, id uint64) (*drkey_mgmt.Lvl1Rep, error) {
+
+	logger := log.FromCtx(ctx)
+	data := &ctrl.Data{ReqId: id, TraceId: tracing.IDFromCtx(ctx)}
+	pld, err := ctrl.NewDRKeyMgmtPld(msg, nil, data)
+	if err != nil {
+		return nil, err
+	}
+	logger.Trace("[Messenger] Sending request", "req_type", infra.DRKeyLvl1Request,
+		"msg_id", id, "request", msg, "peer", a)
+	replyCtrlPld, err := m.getFallbackRequester(infra.DRKeyLvl1Request).Request(ctx, pld, a, false)
+	if err != nil {
+		return nil, common.NewBasicError("[Messenger] Request error", err,
+			"req_type", infra.DRKeyLvl1Request)
+	}
+	_, replyMsg, err := Validate(replyCtrlPld)
+	if err != nil {
+		return nil, common.NewBasicError("[Messenger] Reply validation failed", err)
+	}
+	switch reply := replyMsg.(type) {
+	case *drkey_mgmt.Lvl1Rep:
+		logger.Trace("[Messenger] Received reply", "req_id", id)
+		return reply, nil
+	case *ack.Ack:
+		return nil, &infra.Error{Message: reply}
+	default:
+		err := newTypeAssertErr("*drkey_mgmt.Lvl1Rep", replyMsg)
+		return nil, common.NewBasicError("[Messenger] Type assertion failed", err)
+	}
+}
+
+func (m *Messenger) SendDRKeyLvl1Reply(ctx context.Context, msg *drkey_mgmt.Lvl1Rep,
+	a net.Addr, id uint64) error {
+
+	pld, err := ctrl.NewDRKeyMgmtPld(msg, nil, &ctrl.Data{ReqId: id})
+	if err != nil {
+		return err
+	}
+	logger := log.FromCtx(ctx)
+	logger.Trace("[Messenger] Sending Notify", "type", infra.DRKeyLvl1Reply, "to", a, "id", id)
+	return m.getFallbackRequester(infra.DRKeyLvl1Reply).Notify(ctx, pld, a)
+}
+
+func (m *Messenger) RequestDRKeyLvl2(ctx context.Context, msg *drkey_mgmt.Lvl2Req,
+	a net.Addr, id uint64) (*drkey_mgmt.Lvl2Rep, error) {
+
+	logger := log.FromCtx(ctx)
+	data := &ctrl.Data{ReqId: id, TraceId: tracing.IDFromCtx(ctx)}
+	pld, err := ctrl.NewDRKeyMgmtPld(msg, nil, data)
+	if err != nil {
+		return nil, err
+	}
+	logger.Trace("[Messenger] Sending request", "req_type", infra.DRKeyLvl2Request,
+		"msg_id", id, "request", msg, "peer", a)
+	replyCtrlPld, err := m.getFallbackRequester(infra.DRKeyLvl2Request).Request(ctx, pld, a, false)
+	if err != nil {
+		return nil, common.NewBasicError("[Messenger] Request error", err,
+			"req_type", infra.DRKeyLvl2Request)
+	}
+	_, replyMsg, err := Validate(replyCtrlPld)
+	if err != nil {
+		return nil, common.NewBasicError("[Messenger] Reply validation failed", err)
+	}
+	switch reply := replyMsg.(type) {
+	case *drkey_mgmt.Lvl2Rep:
+		logger.Trace("[Messenger] Received reply", "req_id", id)
+		return reply, nil
+	case *ack.Ack:
+		return nil, &infra.Error{Message: reply}
+	default:
+		err := newTypeAssertErr("*drkey_mgmt.Lvl2Rep", replyMsg)
+		return nil, common.NewBasicError("[Messenger] Type assertion failed", err)
+	}
+}
+
+func (m *Messenger) SendDRKeyLvl2Reply(ctx context.Context, msg *drkey_mgmt.Lvl2Rep,
+	a net.Addr, id uint64) error {
+
+	pld, err := ctrl.NewDRKeyMgmtPld(msg, nil, &ctrl.Data{ReqId: id})
+	if err != nil {
+		return err
+	}
+	logger := log.FromCtx(ctx)
+	logger.Trace("[Messenger] Sending Notify", "type", infra.DRKeyLvl2Reply, "to", a, "id", id)
+	return m.getFallbackRequester(infra.DRKeyLvl2Reply).Notify(ctx, pld, a)
+}
+
 func (m *Messenger) SendBeacon(ctx context.Context, msg *seg.Beacon, a net.Addr, id uint64) error {
 	logger := log.FromCtx(ctx)
 	switch a.(type) {
@@ -1126,6 +1219,21 @@ func Validate(pld *ctrl.Pld) (infra.MessageType, proto.Cerealizable, error) {
 				common.NewBasicError("Unsupported SignedPld.CtrlPld.PathMgmt.Xxx message type",
 					nil, "capnp_which", pld.PathMgmt.Which)
 		}
+	case proto.CtrlPld_Which_drkeyMgmt:
+		switch pld.DRKeyMgmt.Which {
+		case proto.DRKeyMgmt_Which_drkeyReq:
+			return infra.DRKeyLvl1Request, pld.DRKeyMgmt.Lvl1Req, nil
+		case proto.DRKeyMgmt_Which_drkeyRep:
+			return infra.DRKeyLvl1Reply, pld.DRKeyMgmt.Lvl1Rep, nil
+		case proto.DRKeyMgmt_Which_drkeyLvl2Req:
+			return infra.DRKeyLvl2Request, pld.DRKeyMgmt.Lvl2Req, nil
+		case proto.DRKeyMgmt_Which_drkeyLvl2Rep:
+			return infra.DRKeyLvl2Reply, pld.DRKeyMgmt.Lvl2Rep, nil
+		default:
+			return infra.None, nil,
+				common.NewBasicError("Unsupported SignedPld.CtrlPld.DRKeyMgmt.Xxx message type",
+					nil, "capnp_which", pld.DRKeyMgmt.Which)
+		}
 	case proto.CtrlPld_Which_ack:
 		return infra.Ack, pld.Ack, nil
 	default:
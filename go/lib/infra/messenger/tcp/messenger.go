@@ -25,6 +25,7 @@ import (
 	"github.com/scionproto/scion/go/lib/ctrl"
 	"github.com/scionproto/scion/go/lib/ctrl/ack"
 	"github.com/scionproto/scion/go/lib/ctrl/cert_mgmt"
+	"github.com/scionproto/scion/go/lib/ctrl/drkey_mgmt"
 	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
 	"github.com/scionproto/scion/go/lib/infra"
 	"github.com/scionproto/scion/go/lib/infra/messenger"
@@ -185,6 +186,77 @@ func (m *Messenger) GetSegs(ctx context.Context, msg *path_mgmt.SegReq, a net.Ad
 	}
 }
 
+// GetHPSegs asks the server at the remote address for the hidden path segments
+// that satisfy msg, and returns the parsed reply.
+func (m *Messenger) GetHPSegs(ctx context.Context, msg *path_mgmt.HPSegReq, a net.Addr,
+	id uint64) (*path_mgmt.HPSegReply, error) {
+
+	logger := log.FromCtx(ctx)
+	data := &ctrl.Data{ReqId: id, TraceId: tracing.IDFromCtx(ctx)}
+	pld, err := ctrl.NewPathMgmtPld(msg, nil, data)
+	if err != nil {
+		return nil, err
+	}
+	logger.Trace("[tcp-msger] Sending request", "req_type", infra.HPSegRequest,
+		"msg_id", id, "request", msg, "peer", a)
+	replyCtrlPld, err := m.Client.Request(ctx, pld, a)
+	if err != nil {
+		return nil, serrors.WrapStr("[tcp-msger] request error", err,
+			"req_type", infra.HPSegRequest)
+	}
+	_, replyMsg, err := messenger.Validate(replyCtrlPld)
+	if err != nil {
+		return nil, serrors.WrapStr("[tcp-msger] reply validation failed", err)
+	}
+	switch reply := replyMsg.(type) {
+	case *path_mgmt.HPSegReply:
+		if err := reply.ParseRaw(); err != nil {
+			return nil, serrors.WrapStr("[tcp-msger] failed to parse reply", err)
+		}
+		logger.Trace("[tcp-msger] Received reply", "req_id", id)
+		return reply, nil
+	case *ack.Ack:
+		return nil, &infra.Error{Message: reply}
+	default:
+		return nil, serrors.New("[tcp-msger] Type assertion failed",
+			"msg", replyMsg, "type", "*path_mgmt.HPSegReply")
+	}
+}
+
+// RequestDRKeyLvl2 sends a drkey_mgmt.Lvl2Req to address a, blocks until it
+// receives a reply and returns the reply.
+func (m *Messenger) RequestDRKeyLvl2(ctx context.Context, msg *drkey_mgmt.Lvl2Req, a net.Addr,
+	id uint64) (*drkey_mgmt.Lvl2Rep, error) {
+
+	logger := log.FromCtx(ctx)
+	data := &ctrl.Data{ReqId: id, TraceId: tracing.IDFromCtx(ctx)}
+	pld, err := ctrl.NewDRKeyMgmtPld(msg, nil, data)
+	if err != nil {
+		return nil, err
+	}
+	logger.Trace("[tcp-msger] Sending request", "req_type", infra.DRKeyLvl2Request,
+		"msg_id", id, "request", msg, "peer", a)
+	replyCtrlPld, err := m.Client.Request(ctx, pld, a)
+	if err != nil {
+		return nil, serrors.WrapStr("[tcp-msger] request error", err,
+			"req_type", infra.DRKeyLvl2Request)
+	}
+	_, replyMsg, err := messenger.Validate(replyCtrlPld)
+	if err != nil {
+		return nil, serrors.WrapStr("[tcp-msger] reply validation failed", err)
+	}
+	switch reply := replyMsg.(type) {
+	case *drkey_mgmt.Lvl2Rep:
+		logger.Trace("[tcp-msger] Received reply", "req_id", id, "reply", reply)
+		return reply, nil
+	case *ack.Ack:
+		return nil, &infra.Error{Message: reply}
+	default:
+		return nil, serrors.New("[tcp-msger] Type assertion failed",
+			"msg", replyMsg, "type", "*drkey_mgmt.Lvl2Rep")
+	}
+}
+
 func (m *Messenger) AddHandler(msgType infra.MessageType, h infra.Handler) {
 	m.Handler.Handle(msgType, h)
 }
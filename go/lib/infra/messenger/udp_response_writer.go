@@ -20,6 +20,7 @@ import (
 
 	"github.com/scionproto/scion/go/lib/ctrl/ack"
 	"github.com/scionproto/scion/go/lib/ctrl/cert_mgmt"
+	"github.com/scionproto/scion/go/lib/ctrl/drkey_mgmt"
 	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
 	"github.com/scionproto/scion/go/lib/infra"
 )
@@ -67,3 +68,15 @@ func (rw *UDPResponseWriter) SendHPSegReply(ctx context.Context, msg *path_mgmt.
 func (rw *UDPResponseWriter) SendHPCfgReply(ctx context.Context, msg *path_mgmt.HPCfgReply) error {
 	return rw.Messenger.SendHPCfgReply(ctx, msg, rw.Remote, rw.ID)
 }
+
+func (rw *UDPResponseWriter) SendDRKeyLvl1Reply(ctx context.Context,
+	msg *drkey_mgmt.Lvl1Rep) error {
+
+	return rw.Messenger.SendDRKeyLvl1Reply(ctx, msg, rw.Remote, rw.ID)
+}
+
+func (rw *UDPResponseWriter) SendDRKeyLvl2Reply(ctx context.Context,
+	msg *drkey_mgmt.Lvl2Rep) error {
+
+	return rw.Messenger.SendDRKeyLvl2Reply(ctx, msg, rw.Remote, rw.ID)
+}
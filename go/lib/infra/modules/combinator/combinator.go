@@ -71,6 +71,9 @@ type Path struct {
 	Weight     int
 	Mtu        uint16
 	Interfaces []sciond.PathInterface
+	// Metadata is the static metadata aggregated from the static info
+	// extensions of the AS entries. It is nil if no AS announced any.
+	Metadata *sciond.PathMetadata
 }
 
 func (p *Path) writeTestString(w io.Writer) {
@@ -361,9 +361,19 @@ func (solution *PathSolution) GetFwdPathMetadata() *Path {
 	}
 	path.reverseDownSegment()
 	path.aggregateInterfaces()
+	path.Metadata = collectMetadata(path.Interfaces, solution.asEntries())
 	return path
 }
 
+// asEntries returns the AS entries of all segments in the solution.
+func (solution *PathSolution) asEntries() []*seg.ASEntry {
+	var asEntries []*seg.ASEntry
+	for _, solEdge := range solution.edges {
+		asEntries = append(asEntries, solEdge.segment.ASEntries...)
+	}
+	return asEntries
+}
+
 // PathSolutionList is a sort.Interface implementation for a slice of solutions.
 type PathSolutionList []*PathSolution
 
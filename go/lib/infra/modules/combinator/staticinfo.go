@@ -0,0 +1,159 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package combinator
+
+import (
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/proto"
+)
+
+// staticInfo is a static info extension together with the egress interface
+// it is relative to.
+type staticInfo struct {
+	egress common.IFIDType
+	ext    *seg.StaticInfoExtn
+}
+
+// staticInfoMap contains the static info extensions of AS entries, indexed by
+// AS.
+type staticInfoMap map[addr.IA][]staticInfo
+
+// newStaticInfoMap creates the map from the AS entries. The segments are
+// received from the network, AS entries without a valid hop entry are
+// skipped.
+func newStaticInfoMap(asEntries []*seg.ASEntry) staticInfoMap {
+	m := make(staticInfoMap)
+	for _, asEntry := range asEntries {
+		if asEntry.Exts.StaticInfo == nil || len(asEntry.HopEntries) == 0 {
+			continue
+		}
+		hopF, err := asEntry.HopEntries[0].HopField()
+		if err != nil {
+			continue
+		}
+		m[asEntry.IA()] = append(m[asEntry.IA()],
+			staticInfo{egress: hopF.ConsEgress, ext: asEntry.Exts.StaticInfo})
+	}
+	return m
+}
+
+// collectMetadata aggregates the static info extensions of the AS entries
+// into the metadata of the path with the given interfaces. If none of the AS
+// entries carries a static info extension, nil is returned.
+func collectMetadata(interfaces []sciond.PathInterface,
+	asEntries []*seg.ASEntry) *sciond.PathMetadata {
+
+	infos := newStaticInfoMap(asEntries)
+	if len(infos) == 0 || len(interfaces) == 0 {
+		return nil
+	}
+	meta := &sciond.PathMetadata{}
+	for i := 0; i < len(interfaces)-1; i++ {
+		a, b := interfaces[i], interfaces[i+1]
+		if i%2 == 0 {
+			latency, bw, linkType := infos.inter(a, b)
+			meta.Latency = append(meta.Latency, latency)
+			meta.Bandwidth = append(meta.Bandwidth, bw)
+			meta.LinkType = append(meta.LinkType, linkType)
+			continue
+		}
+		latency, bw, hops := infos.intra(a, b)
+		meta.Latency = append(meta.Latency, latency)
+		meta.Bandwidth = append(meta.Bandwidth, bw)
+		meta.InternalHops = append(meta.InternalHops, hops)
+	}
+	for _, intf := range interfaces {
+		meta.Geo = append(meta.Geo, infos.geo(intf))
+	}
+	meta.Notes = append(meta.Notes, infos.note(interfaces[0].IA()))
+	for i := 1; i < len(interfaces); i += 2 {
+		meta.Notes = append(meta.Notes, infos.note(interfaces[i].IA()))
+	}
+	return meta
+}
+
+// inter returns the latency, bandwidth and link type of the inter-AS link
+// between interface a and b. The information is taken from the AS that uses
+// the link as egress. A zero inter latency in the extension means that the
+// latency is unknown.
+func (m staticInfoMap) inter(a, b sciond.PathInterface) (int32, uint64,
+	proto.StaticInfoExtn_LinkType) {
+
+	for _, intf := range []sciond.PathInterface{a, b} {
+		for _, info := range m[intf.IA()] {
+			if info.egress == intf.IfID {
+				ext := info.ext
+				latency := int32(-1)
+				if ext.Latency.Inter != 0 {
+					latency = int32(ext.Latency.Inter)
+				}
+				return latency, ext.Bandwidth.Inter, ext.LinkType
+			}
+		}
+	}
+	return -1, 0, 0
+}
+
+// intra returns the latency, bandwidth and number of internal hops between the
+// interfaces a and b in the same AS.
+func (m staticInfoMap) intra(a, b sciond.PathInterface) (int32, uint64, uint32) {
+	latency, bw, hops := int32(-1), uint64(0), uint32(0)
+	var latencyFound, bwFound, hopsFound bool
+	for _, pair := range [][2]common.IFIDType{{a.IfID, b.IfID}, {b.IfID, a.IfID}} {
+		egress, other := pair[0], pair[1]
+		for _, info := range m[a.IA()] {
+			if info.egress != egress {
+				continue
+			}
+			if v, ok := info.ext.Latency.IntraLatency(other); ok && !latencyFound {
+				latency, latencyFound = int32(v), true
+			}
+			if v, ok := info.ext.Bandwidth.IntraBandwidth(other); ok && !bwFound {
+				bw, bwFound = v, true
+			}
+			if v, ok := info.ext.InternalHopsTo(other); ok && !hopsFound {
+				hops, hopsFound = v, true
+			}
+		}
+	}
+	return latency, bw, hops
+}
+
+// geo returns the location of the interface.
+func (m staticInfoMap) geo(intf sciond.PathInterface) sciond.GeoCoordinates {
+	for _, info := range m[intf.IA()] {
+		if g, ok := info.ext.GeoOf(intf.IfID); ok {
+			return sciond.GeoCoordinates{
+				Latitude:  g.Latitude,
+				Longitude: g.Longitude,
+				Address:   g.Address,
+			}
+		}
+	}
+	return sciond.GeoCoordinates{}
+}
+
+// note returns the note of the AS.
+func (m staticInfoMap) note(ia addr.IA) string {
+	for _, info := range m[ia] {
+		if info.ext.Note != "" {
+			return info.ext.Note
+		}
+	}
+	return ""
+}
@@ -0,0 +1,120 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package combinator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/lib/spath"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/proto"
+)
+
+func TestCollectMetadata(t *testing.T) {
+	ia110 := xtest.MustParseIA("1-ff00:0:110")
+	ia111 := xtest.MustParseIA("1-ff00:0:111")
+	ia112 := xtest.MustParseIA("1-ff00:0:112")
+	asEntries := []*seg.ASEntry{
+		testASEntry(ia110, 0, 1, &seg.StaticInfoExtn{
+			Latency:   seg.LatencyInfo{Inter: 1000},
+			Bandwidth: seg.BandwidthInfo{Inter: 100},
+			Geo:       []seg.GeoInfo{{IfID: 1, Latitude: 1, Longitude: 2, Address: "A1"}},
+			LinkType:  proto.StaticInfoExtn_LinkType_direct,
+			Note:      "A",
+		}),
+		testASEntry(ia111, 2, 3, &seg.StaticInfoExtn{
+			Latency: seg.LatencyInfo{
+				Intra: []seg.InterfaceValue{{IfID: 2, Value: 500}},
+				Inter: 2000,
+			},
+			Bandwidth: seg.BandwidthInfo{
+				Intra: []seg.InterfaceValue{{IfID: 2, Value: 50}},
+				Inter: 200,
+			},
+			Geo: []seg.GeoInfo{
+				{IfID: 2, Latitude: 3, Longitude: 4, Address: "B2"},
+				{IfID: 3, Latitude: 5, Longitude: 6, Address: "B3"},
+			},
+			LinkType:     proto.StaticInfoExtn_LinkType_multiHop,
+			InternalHops: []seg.InterfaceValue{{IfID: 2, Value: 3}},
+			Note:         "B",
+		}),
+		testASEntry(ia112, 4, 0, nil),
+	}
+	interfaces := []sciond.PathInterface{
+		{RawIsdas: ia110.IAInt(), IfID: 1},
+		{RawIsdas: ia111.IAInt(), IfID: 2},
+		{RawIsdas: ia111.IAInt(), IfID: 3},
+		{RawIsdas: ia112.IAInt(), IfID: 4},
+	}
+
+	t.Run("all extensions", func(t *testing.T) {
+		expected := &sciond.PathMetadata{
+			Latency:   []int32{1000, 500, 2000},
+			Bandwidth: []uint64{100, 50, 200},
+			Geo: []sciond.GeoCoordinates{
+				{Latitude: 1, Longitude: 2, Address: "A1"},
+				{Latitude: 3, Longitude: 4, Address: "B2"},
+				{Latitude: 5, Longitude: 6, Address: "B3"},
+				{},
+			},
+			LinkType: []proto.StaticInfoExtn_LinkType{
+				proto.StaticInfoExtn_LinkType_direct,
+				proto.StaticInfoExtn_LinkType_multiHop,
+			},
+			InternalHops: []uint32{3},
+			Notes:        []string{"A", "B", ""},
+		}
+		assert.Equal(t, expected, collectMetadata(interfaces, asEntries))
+	})
+	t.Run("missing extension", func(t *testing.T) {
+		meta := collectMetadata(interfaces, asEntries[1:])
+		assert.Equal(t, []int32{-1, 500, 2000}, meta.Latency)
+		assert.Equal(t, []string{"", "B", ""}, meta.Notes)
+	})
+	t.Run("AS entry without hop entries", func(t *testing.T) {
+		invalid := *asEntries[0]
+		invalid.HopEntries = nil
+		meta := collectMetadata(interfaces, []*seg.ASEntry{&invalid, asEntries[1]})
+		assert.Equal(t, []int32{-1, 500, 2000}, meta.Latency)
+		assert.Equal(t, []string{"", "B", ""}, meta.Notes)
+	})
+	t.Run("malformed hop entry", func(t *testing.T) {
+		invalid := *asEntries[0]
+		invalid.HopEntries = []*seg.HopEntry{{RawHopField: []byte{0x01}}}
+		meta := collectMetadata(interfaces, []*seg.ASEntry{&invalid, asEntries[1]})
+		assert.Equal(t, []int32{-1, 500, 2000}, meta.Latency)
+		assert.Equal(t, []string{"", "B", ""}, meta.Notes)
+	})
+	t.Run("no extensions", func(t *testing.T) {
+		assert.Nil(t, collectMetadata(interfaces, asEntries[2:]))
+	})
+}
+
+func testASEntry(ia addr.IA, in, eg common.IFIDType, ext *seg.StaticInfoExtn) *seg.ASEntry {
+	hopF := spath.HopField{ConsIngress: in, ConsEgress: eg}
+	ase := &seg.ASEntry{
+		RawIA:      ia.IAInt(),
+		HopEntries: []*seg.HopEntry{{RawHopField: hopF.Pack()}},
+	}
+	ase.Exts.StaticInfo = ext
+	return ase
+}
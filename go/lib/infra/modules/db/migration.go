@@ -0,0 +1,94 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"time"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// Migration is a schema change of a database.
+type Migration struct {
+	// Version is the schema version after the migration has been applied.
+	Version int
+	// Description is a short description of the schema change.
+	Description string
+	// Up contains the statements that apply the schema change.
+	Up string
+}
+
+// MigrationRecord is an entry in the migration history of a database.
+type MigrationRecord struct {
+	Version     int
+	Description string
+	Applied     time.Time
+}
+
+// Migrations is a list of migrations ordered by version. The first migration
+// creates the schema from scratch. It is the baseline, databases with an older
+// schema version cannot be migrated.
+type Migrations []Migration
+
+// Validate checks that there is at least one migration and that the versions
+// are positive and strictly increasing.
+func (m Migrations) Validate() error {
+	if len(m) == 0 {
+		return serrors.New("no migrations")
+	}
+	prev := 0
+	for _, migration := range m {
+		if migration.Version <= prev {
+			return serrors.New("migration versions not strictly increasing",
+				"prev", prev, "version", migration.Version)
+		}
+		if migration.Up == "" {
+			return serrors.New("empty migration", "version", migration.Version)
+		}
+		prev = migration.Version
+	}
+	return nil
+}
+
+// Latest returns the schema version after all migrations have been applied.
+func (m Migrations) Latest() int {
+	if len(m) == 0 {
+		return 0
+	}
+	return m[len(m)-1].Version
+}
+
+// Pending returns the migrations that need to be applied to a database with
+// the given schema version. A version of zero indicates an empty database. An
+// error is returned if the version is newer than the latest migration or if
+// there is no migration path.
+func (m Migrations) Pending(version int) (Migrations, error) {
+	switch {
+	case version > m.Latest():
+		return nil, serrors.New("Database schema version newer than supported",
+			"have", version, "latest", m.Latest())
+	case version == 0:
+		return m, nil
+	case version < m[0].Version:
+		return nil, serrors.New("Database schema version too old to migrate",
+			"have", version, "oldest", m[0].Version)
+	}
+	for i, migration := range m {
+		if migration.Version == version {
+			return m[i+1:], nil
+		}
+	}
+	return nil, serrors.New("Unknown database schema version", "have", version)
+}
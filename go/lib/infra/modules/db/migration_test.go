@@ -0,0 +1,204 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+const timeout = 3 * time.Second
+
+var testMigrations = Migrations{
+	{Version: 1, Description: "Initial schema", Up: `CREATE TABLE a(x INTEGER);`},
+	{Version: 2, Description: "Add b", Up: `CREATE TABLE b(y INTEGER);`},
+	{Version: 3, Description: "Add column", Up: `ALTER TABLE a ADD COLUMN z INTEGER;`},
+}
+
+func TestMigrationsValidate(t *testing.T) {
+	tests := map[string]struct {
+		Migrations Migrations
+		Valid      bool
+	}{
+		"valid": {
+			Migrations: testMigrations,
+			Valid:      true,
+		},
+		"empty": {},
+		"zero version": {
+			Migrations: Migrations{{Version: 0, Up: "x"}},
+		},
+		"not increasing": {
+			Migrations: Migrations{{Version: 2, Up: "x"}, {Version: 2, Up: "y"}},
+		},
+		"empty up": {
+			Migrations: Migrations{{Version: 1}},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := test.Migrations.Validate()
+			if test.Valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestMigrationsPending(t *testing.T) {
+	baseline := testMigrations[1:]
+	tests := map[string]struct {
+		Migrations Migrations
+		Version    int
+		Expected   Migrations
+		Error      bool
+	}{
+		"empty database": {
+			Migrations: testMigrations,
+			Version:    0,
+			Expected:   testMigrations,
+		},
+		"partially migrated": {
+			Migrations: testMigrations,
+			Version:    1,
+			Expected:   testMigrations[1:],
+		},
+		"up to date": {
+			Migrations: testMigrations,
+			Version:    3,
+			Expected:   Migrations{},
+		},
+		"newer": {
+			Migrations: testMigrations,
+			Version:    4,
+			Error:      true,
+		},
+		"older than baseline": {
+			Migrations: baseline,
+			Version:    1,
+			Error:      true,
+		},
+		"unknown": {
+			Migrations: Migrations{{Version: 1, Up: "x"}, {Version: 3, Up: "y"}},
+			Version:    2,
+			Error:      true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pending, err := test.Migrations.Pending(test.Version)
+			if test.Error {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.Expected, pending)
+		})
+	}
+}
+
+func TestMigrateSqlite(t *testing.T) {
+	ctx, cancelF := context.WithTimeout(context.Background(), timeout)
+	defer cancelF()
+	tmpDir, cleanF := xtest.MustTempDir("", "test-db-migrate")
+	defer cleanF()
+
+	t.Run("new database", func(t *testing.T) {
+		db, err := NewSqlite(filepath.Join(tmpDir, "new.db"), testMigrations)
+		require.NoError(t, err)
+		defer db.Close()
+		version, err := SqliteSchemaVersion(ctx, db)
+		require.NoError(t, err)
+		assert.Equal(t, 3, version)
+		history, err := SqliteMigrationHistory(ctx, db)
+		require.NoError(t, err)
+		require.Len(t, history, 3)
+		for i, r := range history {
+			assert.Equal(t, testMigrations[i].Version, r.Version)
+			assert.Equal(t, testMigrations[i].Description, r.Description)
+		}
+		_, err = db.ExecContext(ctx, `INSERT INTO a (x, z) VALUES (1, 2)`)
+		assert.NoError(t, err)
+	})
+	t.Run("upgrade", func(t *testing.T) {
+		path := filepath.Join(tmpDir, "upgrade.db")
+		db, err := NewSqlite(path, testMigrations[:1])
+		require.NoError(t, err)
+		_, err = db.ExecContext(ctx, `INSERT INTO a (x) VALUES (1)`)
+		require.NoError(t, err)
+		db.Close()
+
+		db, err = OpenSqlite(path)
+		require.NoError(t, err)
+		defer db.Close()
+		pending, err := MigrateSqlite(ctx, db, testMigrations, true)
+		require.NoError(t, err)
+		assert.Equal(t, testMigrations[1:], pending)
+		version, err := SqliteSchemaVersion(ctx, db)
+		require.NoError(t, err)
+		assert.Equal(t, 1, version, "dry run must not modify the database")
+
+		applied, err := MigrateSqlite(ctx, db, testMigrations, false)
+		require.NoError(t, err)
+		assert.Equal(t, testMigrations[1:], applied)
+		version, err = SqliteSchemaVersion(ctx, db)
+		require.NoError(t, err)
+		assert.Equal(t, 3, version)
+		history, err := SqliteMigrationHistory(ctx, db)
+		require.NoError(t, err)
+		assert.Len(t, history, 3)
+		var count int
+		err = db.QueryRowContext(ctx, `SELECT COUNT(*) FROM a WHERE z IS NULL`).Scan(&count)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+	t.Run("failed migration is rolled back", func(t *testing.T) {
+		path := filepath.Join(tmpDir, "rollback.db")
+		db, err := NewSqlite(path, testMigrations[:1])
+		require.NoError(t, err)
+		defer db.Close()
+		broken := append(testMigrations[:1:1],
+			Migration{Version: 2, Description: "Add b", Up: `CREATE TABLE b(y INTEGER);`},
+			Migration{Version: 3, Description: "Broken", Up: `ALTER TABLE c ADD COLUMN z;`},
+		)
+		_, err = MigrateSqlite(ctx, db, broken, false)
+		assert.Error(t, err)
+		version, err := SqliteSchemaVersion(ctx, db)
+		require.NoError(t, err)
+		assert.Equal(t, 1, version)
+		history, err := SqliteMigrationHistory(ctx, db)
+		require.NoError(t, err)
+		assert.Len(t, history, 1)
+	})
+	t.Run("newer version", func(t *testing.T) {
+		path := filepath.Join(tmpDir, "newer.db")
+		db, err := NewSqlite(path, testMigrations)
+		require.NoError(t, err)
+		db.Close()
+		db, err = NewSqlite(path, testMigrations[:2])
+		assert.Error(t, err)
+		assert.Nil(t, db)
+	})
+}
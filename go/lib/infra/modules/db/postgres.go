@@ -0,0 +1,134 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"hash/fnv"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// DriverPostgres is the name of the database/sql driver used for PostgreSQL
+// databases. The driver (github.com/lib/pq) is linked by the PostgreSQL
+// backends.
+const DriverPostgres = "postgres"
+
+// SchemaVersionsTable is the table that keeps track of the schema versions in
+// a PostgreSQL database. Multiple backends can share the same database, the
+// schema version is tracked per backend name.
+const SchemaVersionsTable = "schema_versions"
+
+// NewPostgres returns a new PostgreSQL backend connected to the database
+// identified by the connection string. The migrations are the schema changes
+// of the backend with the given name. Pending migrations are applied. If the
+// schema version of the stored database is newer than the latest migration, an
+// error is returned.
+func NewPostgres(connection, name string, migrations Migrations) (*sql.DB, error) {
+	var err error
+	if connection == "" {
+		return nil, serrors.New("Empty connection not allowed for postgres")
+	}
+	if !driverRegistered(DriverPostgres) {
+		return nil, serrors.New("PostgreSQL driver not registered", "driver", DriverPostgres)
+	}
+	db, err := sql.Open(DriverPostgres, connection)
+	if err != nil {
+		return nil, common.NewBasicError("Couldn't open PostgreSQL database", err)
+	}
+	// On future errors, close the sql database before exiting
+	defer func() {
+		if err != nil {
+			db.Close()
+		}
+	}()
+	// Make sure DB is reachable
+	if err = db.Ping(); err != nil {
+		return nil, common.NewBasicError("Initial DB ping failed, connection broken?", err)
+	}
+	if err = migratePostgres(context.Background(), db, name, migrations); err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+// migratePostgres applies the missing migrations in a single transaction. An
+// advisory lock serializes concurrent migrations of instances sharing the
+// database. The lock is shared by all backends, because concurrently creating
+// the schema versions table fails even with IF NOT EXISTS.
+func migratePostgres(ctx context.Context, db *sql.DB, name string,
+	migrations Migrations) error {
+
+	if err := migrations.Validate(); err != nil {
+		return err
+	}
+	return DoInTx(ctx, db, func(ctx context.Context, tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock($1)",
+			advisoryLockKey()); err != nil {
+			return common.NewBasicError("Failed to acquire migration lock", err, "name", name)
+		}
+		_, err := tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS `+SchemaVersionsTable+`(
+			name TEXT PRIMARY KEY,
+			version INTEGER NOT NULL
+		)`)
+		if err != nil {
+			return common.NewBasicError("Failed to set up schema versions", err, "name", name)
+		}
+		var existingVersion int
+		err = tx.QueryRowContext(ctx,
+			`SELECT version FROM `+SchemaVersionsTable+` WHERE name=$1`, name).
+			Scan(&existingVersion)
+		if err != nil && err != sql.ErrNoRows {
+			return common.NewBasicError("Failed to check schema version", err, "name", name)
+		}
+		pending, err := migrations.Pending(existingVersion)
+		if err != nil {
+			return serrors.WithCtx(err, "name", name)
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+		for _, m := range pending {
+			if _, err := tx.ExecContext(ctx, m.Up); err != nil {
+				return common.NewBasicError("Failed to apply migration", err,
+					"name", name, "version", m.Version)
+			}
+		}
+		_, err = tx.ExecContext(ctx, `INSERT INTO `+SchemaVersionsTable+` (name, version)
+			VALUES ($1, $2) ON CONFLICT (name) DO UPDATE SET version=EXCLUDED.version`,
+			name, migrations.Latest())
+		if err != nil {
+			return common.NewBasicError("Failed to write schema version", err, "name", name)
+		}
+		return nil
+	})
+}
+
+func advisoryLockKey() int64 {
+	h := fnv.New64a()
+	h.Write([]byte(SchemaVersionsTable))
+	return int64(h.Sum64())
+}
+
+func driverRegistered(name string) bool {
+	for _, d := range sql.Drivers() {
+		if d == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,37 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPostgres(t *testing.T) {
+	t.Run("empty connection", func(t *testing.T) {
+		db, err := NewPostgres("", "test", nil)
+		assert.Error(t, err)
+		assert.Nil(t, db)
+	})
+	t.Run("no driver", func(t *testing.T) {
+		if driverRegistered(DriverPostgres) {
+			t.Skip("driver registered")
+		}
+		db, err := NewPostgres("host=localhost", "test", nil)
+		assert.Error(t, err)
+		assert.Nil(t, db)
+	})
+}
@@ -15,22 +15,26 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/serrors"
 )
 
+// MigrationsTable is the table that records the migration history of an
+// SQLite database.
+const MigrationsTable = "schema_migrations"
+
 // NewSqlite returns a new SQLite backend opening a database at the given path. If
-// no database exists a new database is be created. If the schema version of the
-// stored database is different from schemaVersion, an error is returned.
-func NewSqlite(path string, schema string, schemaVersion int) (*sql.DB, error) {
+// no database exists a new database is be created. Pending migrations are
+// applied. If the schema version of the stored database is newer than the
+// latest migration, or if there is no migration path, an error is returned.
+func NewSqlite(path string, migrations Migrations) (*sql.DB, error) {
 	var err error
-	if path == "" {
-		return nil, serrors.New("Empty path not allowed for sqlite")
-	}
-	db, err := open(path)
+	db, err := OpenSqlite(path)
 	if err != nil {
 		return nil, err
 	}
@@ -40,28 +44,129 @@ func NewSqlite(path string, schema string, schemaVersion int) (*sql.DB, error) {
 			db.Close()
 		}
 	}()
-	// prevent weird errors. (see https://stackoverflow.com/a/35805826)
-	db.SetMaxOpenConns(1)
 	if _, err = db.Exec("PRAGMA journal_mode=WAL"); err != nil {
 		return nil, common.NewBasicError("Unable to set WAL journal mode", err,
 			"path", path)
 	}
-	// Check the schema version and set up new DB if necessary.
-	var existingVersion int
-	err = db.QueryRow("PRAGMA user_version;").Scan(&existingVersion)
+	if _, err = MigrateSqlite(context.Background(), db, migrations, false); err != nil {
+		return nil, common.NewBasicError("Failed to migrate database", err, "path", path)
+	}
+	return db, nil
+}
+
+// OpenSqlite opens the SQLite database at the given path without applying any
+// migrations. If no database exists a new database is created.
+func OpenSqlite(path string) (*sql.DB, error) {
+	if path == "" {
+		return nil, serrors.New("Empty path not allowed for sqlite")
+	}
+	db, err := open(path)
 	if err != nil {
-		return nil, common.NewBasicError("Failed to check schema version", err,
-			"path", path)
+		return nil, err
+	}
+	// prevent weird errors. (see https://stackoverflow.com/a/35805826)
+	db.SetMaxOpenConns(1)
+	return db, nil
+}
+
+// MigrateSqlite applies the pending migrations to the database in a single
+// transaction, and records them in the migration history. The applied
+// migrations are returned. In a dry run, the database is not modified and the
+// migrations that would be applied are returned.
+func MigrateSqlite(ctx context.Context, db *sql.DB, migrations Migrations,
+	dryRun bool) (Migrations, error) {
+
+	if err := migrations.Validate(); err != nil {
+		return nil, err
+	}
+	var pending Migrations
+	err := DoInTx(ctx, db, func(ctx context.Context, tx *sql.Tx) error {
+		version, err := SqliteSchemaVersion(ctx, tx)
+		if err != nil {
+			return err
+		}
+		if pending, err = migrations.Pending(version); err != nil {
+			return err
+		}
+		if dryRun || len(pending) == 0 {
+			return nil
+		}
+		_, err = tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS `+MigrationsTable+`(
+			Version INTEGER PRIMARY KEY,
+			Description TEXT NOT NULL,
+			Applied INTEGER NOT NULL
+		)`)
+		if err != nil {
+			return common.NewBasicError("Failed to set up migration history", err)
+		}
+		for _, m := range pending {
+			if _, err := tx.ExecContext(ctx, m.Up); err != nil {
+				return common.NewBasicError("Failed to apply migration", err,
+					"version", m.Version)
+			}
+			_, err := tx.ExecContext(ctx, `INSERT OR REPLACE INTO `+MigrationsTable+
+				` (Version, Description, Applied) VALUES (?, ?, ?)`,
+				m.Version, m.Description, time.Now().Unix())
+			if err != nil {
+				return common.NewBasicError("Failed to record migration", err,
+					"version", m.Version)
+			}
+		}
+		// Write schema version to database.
+		_, err = tx.ExecContext(ctx, fmt.Sprintf("PRAGMA user_version = %d",
+			migrations.Latest()))
+		if err != nil {
+			return common.NewBasicError("Failed to write schema version", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	if existingVersion == 0 {
-		if err = setup(db, schema, schemaVersion, path); err != nil {
-			return nil, err
+	return pending, nil
+}
+
+// SqliteSchemaVersion returns the schema version of the database. A version of
+// zero indicates an empty database.
+func SqliteSchemaVersion(ctx context.Context, db Sqler) (int, error) {
+	var version int
+	if err := db.QueryRowContext(ctx, "PRAGMA user_version;").Scan(&version); err != nil {
+		return 0, common.NewBasicError("Failed to check schema version", err)
+	}
+	return version, nil
+}
+
+// SqliteMigrationHistory returns the migrations that have been applied to the
+// database, ordered by version. Databases that have been created before
+// migrations were recorded do not have a history.
+func SqliteMigrationHistory(ctx context.Context, db Sqler) ([]MigrationRecord, error) {
+	var exists int
+	err := db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM sqlite_master WHERE type='table' AND name=?`,
+		MigrationsTable).Scan(&exists)
+	if err != nil {
+		return nil, common.NewBasicError("Failed to check migration history", err)
+	}
+	if exists == 0 {
+		return nil, nil
+	}
+	rows, err := db.QueryContext(ctx, `SELECT Version, Description, Applied FROM `+
+		MigrationsTable+` ORDER BY Version`)
+	if err != nil {
+		return nil, common.NewBasicError("Failed to read migration history", err)
+	}
+	defer rows.Close()
+	var history []MigrationRecord
+	for rows.Next() {
+		var r MigrationRecord
+		var applied int64
+		if err := rows.Scan(&r.Version, &r.Description, &applied); err != nil {
+			return nil, common.NewBasicError("Failed to read migration history", err)
 		}
-	} else if existingVersion != schemaVersion {
-		return nil, common.NewBasicError("Database schema version mismatch", nil,
-			"expected", schemaVersion, "have", existingVersion, "path", path)
+		r.Applied = time.Unix(applied, 0)
+		history = append(history, r)
 	}
-	return db, nil
+	return history, rows.Err()
 }
 
 func open(path string) (*sql.DB, error) {
@@ -101,16 +206,3 @@ func open(path string) (*sql.DB, error) {
 	}
 	return db, nil
 }
-
-func setup(db *sql.DB, schema string, schemaVersion int, path string) error {
-	_, err := db.Exec(schema)
-	if err != nil {
-		return common.NewBasicError("Failed to set up SQLite database", err, "path", path)
-	}
-	// Write schema version to database.
-	_, err = db.Exec(fmt.Sprintf("PRAGMA user_version = %d", schemaVersion))
-	if err != nil {
-		return common.NewBasicError("Failed to write schema version", err, "path", path)
-	}
-	return nil
-}
@@ -67,8 +67,7 @@ func (p *Pather) GetPaths(ctx context.Context, dst addr.IA,
 	if err != nil {
 		return nil, err
 	}
-	paths := p.buildAllPaths(src, dst, segs)
-	paths, err = p.filterRevoked(ctx, paths)
+	paths, err := p.BuildPaths(ctx, src, dst, segs)
 	if err != nil {
 		return nil, err
 	}
@@ -78,6 +77,15 @@ func (p *Pather) GetPaths(ctx context.Context, dst addr.IA,
 	return paths, nil
 }
 
+// BuildPaths combines the segments to all non-revoked and non-expired paths
+// from src to dst. The paths are sorted from best to worst according to the
+// weighting in path combinator.
+func (p *Pather) BuildPaths(ctx context.Context, src, dst addr.IA,
+	segs Segments) ([]*combinator.Path, error) {
+
+	return p.filterRevoked(ctx, p.buildAllPaths(src, dst, segs))
+}
+
 func (p *Pather) buildAllPaths(src, dst addr.IA, segs Segments) []*combinator.Path {
 	destinations := p.findDestinations(dst, segs.Up, segs.Core)
 	var paths []*combinator.Path
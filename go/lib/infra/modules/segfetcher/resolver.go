@@ -30,6 +30,10 @@ import (
 // ErrInvalidRequest indicates an invalid request.
 var ErrInvalidRequest = serrors.New("invalid request")
 
+// publicHPCfgIDs restricts database lookups to publicly registered segments.
+// Hidden path segments are only used if they are explicitly requested.
+var publicHPCfgIDs = []*query.HPCfgID{&query.NullHpCfgID}
+
 // Resolver resolves segments that are locally cached.
 type Resolver interface {
 	// Resolve resolves a request set. It returns the segments that are locally
@@ -114,6 +118,7 @@ func (r *DefaultResolver) Resolve(ctx context.Context, segs Segments,
 			StartsAt: []addr.IA{coreReq.Dst},
 			EndsAt:   []addr.IA{coreReq.Src},
 			SegTypes: []proto.PathSegType{proto.PathSegType_core},
+			HpCfgIDs: publicHPCfgIDs,
 		})
 		if err != nil {
 			return segs, req, err
@@ -181,6 +186,7 @@ func (r *DefaultResolver) resolveSegment(ctx context.Context,
 		StartsAt: []addr.IA{start},
 		EndsAt:   []addr.IA{end},
 		SegTypes: []proto.PathSegType{segType},
+		HpCfgIDs: publicHPCfgIDs,
 	})
 	if err != nil {
 		return nil, req, err
@@ -130,6 +130,7 @@ func TestResolver(t *testing.T) {
 				db.EXPECT().GetNextQuery(gomock.Any(), gomock.Eq(non_core_111),
 					gomock.Eq(isd1), gomock.Any()).Return(futureT, nil)
 				db.EXPECT().Get(gomock.Any(), matchers.EqParams(&query.Params{
+					HpCfgIDs: []*query.HPCfgID{&query.NullHpCfgID},
 					SegTypes: []proto.PathSegType{proto.PathSegType_up},
 					StartsAt: []addr.IA{isd1}, EndsAt: []addr.IA{non_core_111},
 				})).Return(resultsFromSegs(tg.seg120_111, tg.seg130_111), nil)
@@ -147,6 +148,7 @@ func TestResolver(t *testing.T) {
 			},
 			ExpectCalls: func(db *mock_pathdb.MockPathDB) {
 				db.EXPECT().Get(gomock.Any(), matchers.EqParams(&query.Params{
+					HpCfgIDs: []*query.HPCfgID{&query.NullHpCfgID},
 					SegTypes: []proto.PathSegType{proto.PathSegType_up},
 					StartsAt: []addr.IA{isd1}, EndsAt: []addr.IA{non_core_111},
 				})).Return(resultsFromSegs(tg.seg120_111, tg.seg130_111), nil)
@@ -185,6 +187,7 @@ func TestResolver(t *testing.T) {
 				db.EXPECT().GetNextQuery(gomock.Any(), gomock.Eq(non_core_111),
 					gomock.Eq(isd1), gomock.Any()).Return(futureT, nil)
 				db.EXPECT().Get(gomock.Any(), matchers.EqParams(&query.Params{
+					HpCfgIDs: []*query.HPCfgID{&query.NullHpCfgID},
 					SegTypes: []proto.PathSegType{proto.PathSegType_up},
 					StartsAt: []addr.IA{isd1}, EndsAt: []addr.IA{non_core_111},
 				})).Return(resultsFromSegs(tg.seg120_111, tg.seg130_111), nil)
@@ -213,6 +216,7 @@ func TestResolver(t *testing.T) {
 				db.EXPECT().GetNextQuery(gomock.Any(), gomock.Eq(non_core_111),
 					gomock.Eq(isd1), gomock.Any()).Return(futureT, nil)
 				db.EXPECT().Get(gomock.Any(), matchers.EqParams(&query.Params{
+					HpCfgIDs: []*query.HPCfgID{&query.NullHpCfgID},
 					SegTypes: []proto.PathSegType{proto.PathSegType_up},
 					StartsAt: []addr.IA{isd1}, EndsAt: []addr.IA{non_core_111},
 				})).Return(resultsFromSegs(tg.seg120_111, tg.seg130_111), nil)
@@ -222,10 +226,12 @@ func TestResolver(t *testing.T) {
 				db.EXPECT().GetNextQuery(gomock.Any(), gomock.Eq(core_130),
 					gomock.Eq(core_110), gomock.Any()).Return(futureT, nil)
 				db.EXPECT().Get(gomock.Any(), matchers.EqParams(&query.Params{
+					HpCfgIDs: []*query.HPCfgID{&query.NullHpCfgID},
 					SegTypes: []proto.PathSegType{proto.PathSegType_core},
 					StartsAt: []addr.IA{core_110}, EndsAt: []addr.IA{core_120},
 				})).Return(resultsFromSegs(tg.seg110_120), nil)
 				db.EXPECT().Get(gomock.Any(), matchers.EqParams(&query.Params{
+					HpCfgIDs: []*query.HPCfgID{&query.NullHpCfgID},
 					SegTypes: []proto.PathSegType{proto.PathSegType_core},
 					StartsAt: []addr.IA{core_110}, EndsAt: []addr.IA{core_130},
 				})).Return(resultsFromSegs(tg.seg110_130), nil)
@@ -252,14 +258,17 @@ func TestResolver(t *testing.T) {
 			},
 			ExpectCalls: func(db *mock_pathdb.MockPathDB) {
 				db.EXPECT().Get(gomock.Any(), matchers.EqParams(&query.Params{
+					HpCfgIDs: []*query.HPCfgID{&query.NullHpCfgID},
 					SegTypes: []proto.PathSegType{proto.PathSegType_up},
 					StartsAt: []addr.IA{isd1}, EndsAt: []addr.IA{non_core_111},
 				})).Return(resultsFromSegs(tg.seg120_111, tg.seg130_111), nil)
 				db.EXPECT().Get(gomock.Any(), matchers.EqParams(&query.Params{
+					HpCfgIDs: []*query.HPCfgID{&query.NullHpCfgID},
 					SegTypes: []proto.PathSegType{proto.PathSegType_core},
 					StartsAt: []addr.IA{core_110}, EndsAt: []addr.IA{core_120},
 				})).Return(resultsFromSegs(tg.seg110_120), nil)
 				db.EXPECT().Get(gomock.Any(), matchers.EqParams(&query.Params{
+					HpCfgIDs: []*query.HPCfgID{&query.NullHpCfgID},
 					SegTypes: []proto.PathSegType{proto.PathSegType_core},
 					StartsAt: []addr.IA{core_110}, EndsAt: []addr.IA{core_130},
 				})).Return(resultsFromSegs(tg.seg110_130), nil)
@@ -326,10 +335,12 @@ func TestResolver(t *testing.T) {
 				db.EXPECT().GetNextQuery(gomock.Any(), isd2, non_core_212, gomock.Any()).
 					Return(futureT, nil)
 				db.EXPECT().Get(gomock.Any(), matchers.EqParams(&query.Params{
+					HpCfgIDs: []*query.HPCfgID{&query.NullHpCfgID},
 					SegTypes: []proto.PathSegType{proto.PathSegType_up},
 					StartsAt: []addr.IA{isd2}, EndsAt: []addr.IA{non_core_211},
 				})).Return(resultsFromSegs(tg.seg210_211), nil)
 				db.EXPECT().Get(gomock.Any(), matchers.EqParams(&query.Params{
+					HpCfgIDs: []*query.HPCfgID{&query.NullHpCfgID},
 					SegTypes: []proto.PathSegType{proto.PathSegType_down},
 					StartsAt: []addr.IA{isd2}, EndsAt: []addr.IA{non_core_212},
 				})).Return(resultsFromSegs(tg.seg210_212), nil)
@@ -374,6 +385,7 @@ func TestResolver(t *testing.T) {
 				db.EXPECT().GetNextQuery(gomock.Any(), gomock.Eq(non_core_111),
 					gomock.Eq(isd1), gomock.Any()).Return(futureT, nil)
 				db.EXPECT().Get(gomock.Any(), matchers.EqParams(&query.Params{
+					HpCfgIDs: []*query.HPCfgID{&query.NullHpCfgID},
 					SegTypes: []proto.PathSegType{proto.PathSegType_up},
 					StartsAt: []addr.IA{isd1}, EndsAt: []addr.IA{non_core_111},
 				})).Return(resultsFromSegs(tg.seg120_111, tg.seg130_111), nil)
@@ -401,6 +413,7 @@ func TestResolver(t *testing.T) {
 					gomock.Eq(isd2), gomock.Any()).
 					Return(futureT, nil)
 				db.EXPECT().Get(gomock.Any(), matchers.EqParams(&query.Params{
+					HpCfgIDs: []*query.HPCfgID{&query.NullHpCfgID},
 					SegTypes: []proto.PathSegType{proto.PathSegType_up},
 					StartsAt: []addr.IA{isd2}, EndsAt: []addr.IA{non_core_211},
 				})).Return(resultsFromSegs(tg.seg210_211), nil)
@@ -408,6 +421,7 @@ func TestResolver(t *testing.T) {
 					gomock.Eq(non_core_111), gomock.Any()).
 					Return(futureT, nil)
 				db.EXPECT().Get(gomock.Any(), matchers.EqParams(&query.Params{
+					HpCfgIDs: []*query.HPCfgID{&query.NullHpCfgID},
 					SegTypes: []proto.PathSegType{proto.PathSegType_down},
 					StartsAt: []addr.IA{isd1}, EndsAt: []addr.IA{non_core_111},
 				})).Return(resultsFromSegs(tg.seg120_111, tg.seg130_111), nil)
@@ -441,6 +455,7 @@ func TestResolver(t *testing.T) {
 					Return(futureT, nil)
 				// return no up segments
 				db.EXPECT().Get(gomock.Any(), matchers.EqParams(&query.Params{
+					HpCfgIDs: []*query.HPCfgID{&query.NullHpCfgID},
 					SegTypes: []proto.PathSegType{proto.PathSegType_up},
 					StartsAt: []addr.IA{isd2}, EndsAt: []addr.IA{non_core_211},
 				}))
@@ -448,6 +463,7 @@ func TestResolver(t *testing.T) {
 					gomock.Eq(non_core_111), gomock.Any()).
 					Return(futureT, nil)
 				db.EXPECT().Get(gomock.Any(), matchers.EqParams(&query.Params{
+					HpCfgIDs: []*query.HPCfgID{&query.NullHpCfgID},
 					SegTypes: []proto.PathSegType{proto.PathSegType_down},
 					StartsAt: []addr.IA{isd1}, EndsAt: []addr.IA{non_core_111},
 				})).Return(resultsFromSegs(tg.seg120_111, tg.seg130_111), nil)
@@ -506,6 +522,7 @@ func TestResolver(t *testing.T) {
 				db.EXPECT().GetNextQuery(gomock.Any(), gomock.Eq(core_210),
 					gomock.Eq(core_130), gomock.Any()).Return(futureT, nil)
 				db.EXPECT().Get(gomock.Any(), matchers.EqParams(&query.Params{
+					HpCfgIDs: []*query.HPCfgID{&query.NullHpCfgID},
 					SegTypes: []proto.PathSegType{proto.PathSegType_core},
 					StartsAt: []addr.IA{core_130}, EndsAt: []addr.IA{core_210},
 				})).Return(resultsFromSegs(tg.seg210_130), nil)
@@ -537,6 +554,7 @@ func TestResolver(t *testing.T) {
 				db.EXPECT().GetNextQuery(gomock.Any(), gomock.Eq(core_210),
 					gomock.Eq(core_130), gomock.Any()).Return(futureT, nil)
 				db.EXPECT().Get(gomock.Any(), matchers.EqParams(&query.Params{
+					HpCfgIDs: []*query.HPCfgID{&query.NullHpCfgID},
 					SegTypes: []proto.PathSegType{proto.PathSegType_core},
 					StartsAt: []addr.IA{core_130}, EndsAt: []addr.IA{core_210},
 				})).Return(resultsFromSegs(tg.seg210_130), nil)
@@ -577,6 +595,7 @@ func TestResolver(t *testing.T) {
 				db.EXPECT().GetNextQuery(gomock.Any(), gomock.Eq(isd2),
 					gomock.Eq(non_core_211), gomock.Any()).Return(futureT, nil)
 				db.EXPECT().Get(gomock.Any(), matchers.EqParams(&query.Params{
+					HpCfgIDs: []*query.HPCfgID{&query.NullHpCfgID},
 					SegTypes: []proto.PathSegType{proto.PathSegType_down},
 					StartsAt: []addr.IA{isd2}, EndsAt: []addr.IA{non_core_211},
 				})).Return(resultsFromSegs(tg.seg210_211), nil)
@@ -624,6 +643,7 @@ func TestResolver(t *testing.T) {
 				db.EXPECT().GetNextQuery(gomock.Any(), gomock.Eq(isd1),
 					gomock.Eq(non_core_111), gomock.Any()).Return(futureT, nil)
 				db.EXPECT().Get(gomock.Any(), matchers.EqParams(&query.Params{
+					HpCfgIDs: []*query.HPCfgID{&query.NullHpCfgID},
 					SegTypes: []proto.PathSegType{proto.PathSegType_down},
 					StartsAt: []addr.IA{isd1}, EndsAt: []addr.IA{non_core_111},
 				})).Return(resultsFromSegs(tg.seg120_111, tg.seg130_111), nil)
@@ -632,10 +652,12 @@ func TestResolver(t *testing.T) {
 				db.EXPECT().GetNextQuery(gomock.Any(), gomock.Eq(core_210),
 					gomock.Eq(core_130), gomock.Any()).Return(futureT, nil)
 				db.EXPECT().Get(gomock.Any(), matchers.EqParams(&query.Params{
+					HpCfgIDs: []*query.HPCfgID{&query.NullHpCfgID},
 					SegTypes: []proto.PathSegType{proto.PathSegType_core},
 					StartsAt: []addr.IA{core_120}, EndsAt: []addr.IA{core_210},
 				})).Return(resultsFromSegs(tg.seg210_120), nil)
 				db.EXPECT().Get(gomock.Any(), matchers.EqParams(&query.Params{
+					HpCfgIDs: []*query.HPCfgID{&query.NullHpCfgID},
 					SegTypes: []proto.PathSegType{proto.PathSegType_core},
 					StartsAt: []addr.IA{core_130}, EndsAt: []addr.IA{core_210},
 				})).Return(resultsFromSegs(tg.seg210_130), nil)
@@ -675,6 +697,7 @@ func TestResolver(t *testing.T) {
 				db.EXPECT().GetNextQuery(gomock.Any(), gomock.Eq(core_120),
 					gomock.Eq(non_core_111), gomock.Any()).Return(futureT, nil)
 				db.EXPECT().Get(gomock.Any(), matchers.EqParams(&query.Params{
+					HpCfgIDs: []*query.HPCfgID{&query.NullHpCfgID},
 					SegTypes: []proto.PathSegType{proto.PathSegType_down},
 					StartsAt: []addr.IA{core_120}, EndsAt: []addr.IA{non_core_111},
 				})).Return(resultsFromSegs(tg.seg120_111), nil)
@@ -726,10 +749,12 @@ func TestResolverCacheBypass(t *testing.T) {
 			ExpectCalls: func(db *mock_pathdb.MockPathDB) {
 				// cached up segments
 				db.EXPECT().Get(gomock.Any(), matchers.EqParams(&query.Params{
+					HpCfgIDs: []*query.HPCfgID{&query.NullHpCfgID},
 					SegTypes: []proto.PathSegType{proto.PathSegType_up},
 					StartsAt: []addr.IA{isd2}, EndsAt: []addr.IA{non_core_211},
 				})).Return(resultsFromSegs(tg.seg210_211), nil)
 				db.EXPECT().Get(gomock.Any(), matchers.EqParams(&query.Params{
+					HpCfgIDs: []*query.HPCfgID{&query.NullHpCfgID},
 					SegTypes: []proto.PathSegType{proto.PathSegType_down},
 					StartsAt: []addr.IA{isd1}, EndsAt: []addr.IA{non_core_111},
 				})).Return(resultsFromSegs(tg.seg120_111, tg.seg130_111), nil)
@@ -777,6 +802,7 @@ func TestResolverWithRevocations(t *testing.T) {
 				db.EXPECT().GetNextQuery(gomock.Any(), gomock.Eq(non_core_111),
 					gomock.Eq(isd1), gomock.Any()).Return(futureT, nil)
 				db.EXPECT().Get(gomock.Any(), matchers.EqParams(&query.Params{
+					HpCfgIDs: []*query.HPCfgID{&query.NullHpCfgID},
 					SegTypes: []proto.PathSegType{proto.PathSegType_up},
 					StartsAt: []addr.IA{isd1}, EndsAt: []addr.IA{non_core_111},
 				})).Return(resultsFromSegs(tg.seg120_111, tg.seg130_111), nil)
@@ -811,6 +837,7 @@ func TestResolverWithRevocations(t *testing.T) {
 				db.EXPECT().GetNextQuery(gomock.Any(), gomock.Eq(core_210),
 					gomock.Eq(core_130), gomock.Any()).Return(futureT, nil)
 				db.EXPECT().Get(gomock.Any(), matchers.EqParams(&query.Params{
+					HpCfgIDs: []*query.HPCfgID{&query.NullHpCfgID},
 					SegTypes: []proto.PathSegType{proto.PathSegType_core},
 					StartsAt: []addr.IA{core_130}, EndsAt: []addr.IA{core_210},
 				})).Return(resultsFromSegs(tg.seg210_130, tg.seg210_130_2), nil)
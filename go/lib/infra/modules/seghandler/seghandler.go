@@ -165,7 +165,9 @@ func (h *Handler) storeResults(ctx context.Context, verifiedUnits []segverifier.
 	return verifyErrs, nil
 }
 
-func convertHPGroupID(id hiddenpath.GroupId) []*query.HPCfgID {
+// ConvertHPGroupID converts the hidden path group ID to the hidden path config
+// IDs that segments of the group are stored with in the path database.
+func ConvertHPGroupID(id hiddenpath.GroupId) []*query.HPCfgID {
 	return []*query.HPCfgID{
 		{
 			IA: addr.IA{
@@ -86,7 +86,7 @@ func (s *DefaultStorage) StoreSegs(ctx context.Context, segs []*SegWithHP) (SegS
 	})
 	segStats := SegStats{}
 	for _, seg := range segs {
-		stats, err := tx.InsertWithHPCfgIDs(ctx, seg.Seg, convertHPGroupID(seg.HPGroup))
+		stats, err := tx.InsertWithHPCfgIDs(ctx, seg.Seg, ConvertHPGroupID(seg.HPGroup))
 		if err != nil {
 			return SegStats{}, err
 		}
@@ -0,0 +1,215 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trust
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/infra/modules/trust/internal/metrics"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/periodic"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+var _ periodic.Task = (*TRCChecker)(nil)
+
+// TRCState is the state of the latest TRC of an ISD as observed by the
+// TRC checker.
+type TRCState struct {
+	ISD      addr.ISD         `json:"isd"`
+	Version  scrypto.Version  `json:"version"`
+	Validity scrypto.Validity `json:"validity"`
+	// GracePeriodEnd indicates until when the previous TRC is still active. It
+	// is not set for base TRCs.
+	GracePeriodEnd *util.UnixTime `json:"grace_period_end,omitempty"`
+	// LastCheck is the time of the last check.
+	LastCheck util.UnixTime `json:"last_check"`
+	// LastUpdate is the time a new TRC version was last resolved by the
+	// checker. It is not set, if no update has been observed.
+	LastUpdate *util.UnixTime `json:"last_update,omitempty"`
+	// Error is the error of the last check, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// InGracePeriod indicates whether the previous TRC is still in its grace
+// period at the provided time.
+func (s TRCState) InGracePeriod(now time.Time) bool {
+	return s.GracePeriodEnd != nil && now.Before(s.GracePeriodEnd.Time)
+}
+
+// TRCChecker periodically polls the authoritative ASes of every ISD with a TRC
+// in the database for the latest TRC version. Newer TRCs, and all missing
+// links in the TRC update chain, are resolved and inserted into the database.
+// The checker keeps track of the state of the latest TRC of each ISD.
+type TRCChecker struct {
+	DB       DBRead
+	Recurser Recurser
+	Resolver Resolver
+	Router   Router
+	// Timeout is the timeout for checking a single ISD.
+	Timeout time.Duration
+
+	mtx    sync.Mutex
+	states map[addr.ISD]TRCState
+}
+
+// Name returns the task name.
+func (c *TRCChecker) Name() string {
+	return "trust_trc_checker"
+}
+
+// Run checks the latest TRC of all ISDs that are known to the database.
+func (c *TRCChecker) Run(ctx context.Context) {
+	logger := log.FromCtx(ctx)
+	ctx = metrics.CtxWith(ctx, metrics.TRCChecker)
+	isds, err := c.DB.GetISDs(ctx)
+	if err != nil {
+		logger.Error("[TrustStore:TRCChecker] Unable to get ISDs", "err", err)
+		return
+	}
+	for _, isd := range isds {
+		c.update(c.check(ctx, isd, time.Now()))
+	}
+}
+
+// States returns the state of the latest TRC of all checked ISDs, sorted by
+// ISD.
+func (c *TRCChecker) States() []TRCState {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	states := make([]TRCState, 0, len(c.states))
+	for _, state := range c.states {
+		states = append(states, state)
+	}
+	sort.Slice(states, func(i, j int) bool { return states[i].ISD < states[j].ISD })
+	return states
+}
+
+// StatusHandler writes the state of the latest TRC of all checked ISDs as
+// JSON.
+func (c *TRCChecker) StatusHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(c.States()); err != nil {
+		log.Error("[TrustStore:TRCChecker] Unable to write TRC states", "err", err)
+	}
+}
+
+func (c *TRCChecker) check(ctx context.Context, isd addr.ISD, now time.Time) TRCState {
+	logger := log.FromCtx(ctx)
+	l := metrics.CheckerLabels{ISD: isd.String()}
+	state := TRCState{ISD: isd, LastCheck: util.UnixTime{Time: now}}
+	if prev, ok := c.state(isd); ok {
+		state.LastUpdate = prev.LastUpdate
+	}
+	updated, err := c.resolveLatest(ctx, isd)
+	if err != nil {
+		logger.Info("[TrustStore:TRCChecker] Unable to resolve latest TRC", "isd", isd,
+			"err", err)
+		state.Error = err.Error()
+	}
+	if updated {
+		state.LastUpdate = &util.UnixTime{Time: now}
+	}
+	info, dbErr := c.DB.GetTRCInfo(ctx, TRCID{ISD: isd, Version: scrypto.LatestVer})
+	if dbErr != nil {
+		metrics.Checker.Check(l.WithResult(metrics.ErrDB)).Inc()
+		logger.Error("[TrustStore:TRCChecker] Unable to get latest TRC info", "isd", isd,
+			"err", dbErr)
+		state.Error = serrors.WrapStr("unable to get latest TRC info", dbErr).Error()
+		return state
+	}
+	metrics.Checker.Check(l.WithResult(errToLabel(err))).Inc()
+	state.Version = info.Version
+	state.Validity = info.Validity
+	if !info.Base() {
+		state.GracePeriodEnd = &util.UnixTime{Time: info.Validity.NotBefore.Add(info.GracePeriod)}
+	}
+	if !info.Validity.Contains(now) {
+		logger.Info("[TrustStore:TRCChecker] Latest TRC is not valid", "isd", isd,
+			"version", info.Version, "validity", info.Validity)
+	}
+	return state
+}
+
+// resolveLatest resolves the latest TRC from the network. The returned value
+// indicates whether a newer TRC has been resolved.
+func (c *TRCChecker) resolveLatest(ctx context.Context, isd addr.ISD) (bool, error) {
+	logger := log.FromCtx(ctx)
+	if err := c.Recurser.AllowRecursion(nil); err != nil {
+		return false, err
+	}
+	ctx, cancelF := context.WithTimeout(ctx, c.Timeout)
+	defer cancelF()
+	server, err := c.Router.ChooseServer(ctx, isd)
+	if err != nil {
+		return false, serrors.WrapStr("unable to route TRC request", err)
+	}
+	decTRC, err := c.Resolver.TRC(ctx, TRCReq{ISD: isd, Version: scrypto.LatestVer}, server)
+	switch {
+	case errors.Is(err, ErrResolveSuperseded):
+		logger.Trace("[TrustStore:TRCChecker] Latest TRC is available locally", "isd", isd)
+		return false, nil
+	case err != nil:
+		return false, serrors.WrapStr("unable to resolve latest TRC", err, "addr", server)
+	}
+	logger.Info("[TrustStore:TRCChecker] Resolved new TRC", "isd", isd,
+		"version", decTRC.TRC.Version)
+	return true, nil
+}
+
+func (c *TRCChecker) state(isd addr.ISD) (TRCState, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	state, ok := c.states[isd]
+	return state, ok
+}
+
+func (c *TRCChecker) update(state TRCState) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.states == nil {
+		c.states = make(map[addr.ISD]TRCState)
+	}
+	if state.Version == 0 {
+		// Keep the last known TRC, if the database lookup failed.
+		prev := c.states[state.ISD]
+		state.Version = prev.Version
+		state.Validity = prev.Validity
+		state.GracePeriodEnd = prev.GracePeriodEnd
+	}
+	c.states[state.ISD] = state
+	if state.Version == 0 {
+		return
+	}
+	l := metrics.TRCLabels{ISD: state.ISD.String()}
+	metrics.Checker.Version(l).Set(float64(state.Version))
+	metrics.Checker.Expiration(l).Set(float64(state.Validity.NotAfter.Unix()))
+	var graceEnd float64
+	if state.GracePeriodEnd != nil {
+		graceEnd = float64(state.GracePeriodEnd.Unix())
+	}
+	metrics.Checker.GracePeriodEnd(l).Set(graceEnd)
+}
@@ -0,0 +1,140 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trust_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/infra/modules/trust"
+	"github.com/scionproto/scion/go/lib/infra/modules/trust/internal/decoded"
+	"github.com/scionproto/scion/go/lib/infra/modules/trust/mock_trust"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/scrypto/trc"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+func TestTRCCheckerRun(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	validity := scrypto.Validity{
+		NotBefore: util.UnixTime{Time: now.Add(-time.Hour)},
+		NotAfter:  util.UnixTime{Time: now.Add(time.Hour)},
+	}
+	internal := serrors.New("internal")
+	server := &snet.SVCAddr{IA: ia110, SVC: addr.SvcCS}
+
+	tests := map[string]struct {
+		Expect func(*mock_trust.MockDB, *mock_trust.MockResolver)
+		Check  func(*testing.T, trust.TRCState)
+	}{
+		"up to date": {
+			Expect: func(db *mock_trust.MockDB, resolver *mock_trust.MockResolver) {
+				resolver.EXPECT().TRC(gomock.Any(),
+					trust.TRCReq{ISD: 1, Version: scrypto.LatestVer}, server).Return(
+					decoded.TRC{}, serrors.WithCtx(trust.ErrResolveSuperseded, "latest", 1))
+				db.EXPECT().GetTRCInfo(gomock.Any(),
+					trust.TRCID{ISD: 1, Version: scrypto.LatestVer}).Return(
+					trust.TRCInfo{Version: 1, Validity: validity}, nil)
+			},
+			Check: func(t *testing.T, state trust.TRCState) {
+				assert.Equal(t, scrypto.Version(1), state.Version)
+				assert.Equal(t, validity, state.Validity)
+				assert.Nil(t, state.GracePeriodEnd)
+				assert.Nil(t, state.LastUpdate)
+				assert.Empty(t, state.Error)
+			},
+		},
+		"update in grace period": {
+			Expect: func(db *mock_trust.MockDB, resolver *mock_trust.MockResolver) {
+				resolver.EXPECT().TRC(gomock.Any(),
+					trust.TRCReq{ISD: 1, Version: scrypto.LatestVer}, server).Return(
+					decoded.TRC{TRC: &trc.TRC{Version: 2}}, nil)
+				db.EXPECT().GetTRCInfo(gomock.Any(),
+					trust.TRCID{ISD: 1, Version: scrypto.LatestVer}).Return(
+					trust.TRCInfo{Version: 2, Validity: validity, GracePeriod: 2 * time.Hour},
+					nil)
+			},
+			Check: func(t *testing.T, state trust.TRCState) {
+				assert.Equal(t, scrypto.Version(2), state.Version)
+				require.NotNil(t, state.GracePeriodEnd)
+				assert.Equal(t, now.Add(time.Hour), state.GracePeriodEnd.Time)
+				assert.True(t, state.InGracePeriod(now))
+				assert.False(t, state.InGracePeriod(now.Add(time.Hour)))
+				assert.NotNil(t, state.LastUpdate)
+				assert.Empty(t, state.Error)
+			},
+		},
+		"resolver error": {
+			Expect: func(db *mock_trust.MockDB, resolver *mock_trust.MockResolver) {
+				resolver.EXPECT().TRC(gomock.Any(),
+					trust.TRCReq{ISD: 1, Version: scrypto.LatestVer}, server).Return(
+					decoded.TRC{}, internal)
+				db.EXPECT().GetTRCInfo(gomock.Any(),
+					trust.TRCID{ISD: 1, Version: scrypto.LatestVer}).Return(
+					trust.TRCInfo{Version: 1, Validity: validity}, nil)
+			},
+			Check: func(t *testing.T, state trust.TRCState) {
+				assert.Equal(t, scrypto.Version(1), state.Version)
+				assert.Nil(t, state.LastUpdate)
+				assert.NotEmpty(t, state.Error)
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mctrl := gomock.NewController(t)
+			defer mctrl.Finish()
+
+			db := mock_trust.NewMockDB(mctrl)
+			recurser := mock_trust.NewMockRecurser(mctrl)
+			resolver := mock_trust.NewMockResolver(mctrl)
+			router := mock_trust.NewMockRouter(mctrl)
+			db.EXPECT().GetISDs(gomock.Any()).Return([]addr.ISD{1}, nil)
+			recurser.EXPECT().AllowRecursion(nil).Return(nil)
+			router.EXPECT().ChooseServer(gomock.Any(), addr.ISD(1)).Return(server, nil)
+			test.Expect(db, resolver)
+
+			checker := &trust.TRCChecker{
+				DB:       db,
+				Recurser: recurser,
+				Resolver: resolver,
+				Router:   router,
+				Timeout:  time.Second,
+			}
+			checker.Run(context.Background())
+			states := checker.States()
+			require.Len(t, states, 1)
+			assert.Equal(t, addr.ISD(1), states[0].ISD)
+			test.Check(t, states[0])
+
+			rec := httptest.NewRecorder()
+			checker.StatusHandler(rec, httptest.NewRequest("GET", "/trust", nil))
+			var served []trust.TRCState
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &served))
+			require.Len(t, served, 1)
+			assert.Equal(t, states[0].Version, served[0].Version)
+		})
+	}
+}
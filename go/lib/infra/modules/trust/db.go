@@ -91,6 +91,9 @@ type TRCRead interface {
 	// not found, ErrNotFound is returned.
 	GetIssuingGrantKeyInfo(ctx context.Context, ia addr.IA,
 		version scrypto.Version) (KeyInfo, error)
+	// GetISDs returns the ISDs for which at least one TRC is in the database,
+	// in ascending order.
+	GetISDs(ctx context.Context) ([]addr.ISD, error)
 }
 
 // TRCWrite defines the TRC write operations.
@@ -0,0 +1,97 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/scionproto/scion/go/lib/prom"
+)
+
+// CheckerLabels defines the TRC checker labels.
+type CheckerLabels struct {
+	ISD    string
+	Result string
+}
+
+// Labels returns the list of labels.
+func (l CheckerLabels) Labels() []string {
+	return []string{"isd", prom.LabelResult}
+}
+
+// Values returns the label values in the order defined by Labels.
+func (l CheckerLabels) Values() []string {
+	return []string{l.ISD, l.Result}
+}
+
+// WithResult returns the checker labels with the modified result.
+func (l CheckerLabels) WithResult(result string) CheckerLabels {
+	l.Result = result
+	return l
+}
+
+// TRCLabels defines the labels for the latest TRC of an ISD.
+type TRCLabels struct {
+	ISD string
+}
+
+// Labels returns the list of labels.
+func (l TRCLabels) Labels() []string {
+	return []string{"isd"}
+}
+
+// Values returns the label values in the order defined by Labels.
+func (l TRCLabels) Values() []string {
+	return []string{l.ISD}
+}
+
+type checker struct {
+	checks         *prometheus.CounterVec
+	version        *prometheus.GaugeVec
+	expiration     *prometheus.GaugeVec
+	gracePeriodEnd *prometheus.GaugeVec
+}
+
+func newChecker() checker {
+	return checker{
+		checks: prom.NewCounterVecWithLabels(Namespace, "", "trc_checks_total",
+			"Number of TRC freshness checks done by the trust store", CheckerLabels{}),
+		version: prom.NewGaugeVecWithLabels(Namespace, "", "trc_version",
+			"Version of the latest TRC", TRCLabels{}),
+		expiration: prom.NewGaugeVecWithLabels(Namespace, "",
+			"trc_expiration_timestamp_seconds",
+			"Expiration time of the latest TRC as unix timestamp", TRCLabels{}),
+		gracePeriodEnd: prom.NewGaugeVecWithLabels(Namespace, "",
+			"trc_grace_period_end_timestamp_seconds",
+			"End of the grace period of the previous TRC as unix timestamp. "+
+				"Zero for base TRCs", TRCLabels{}),
+	}
+}
+
+func (c *checker) Check(l CheckerLabels) prometheus.Counter {
+	return c.checks.WithLabelValues(l.Values()...)
+}
+
+func (c *checker) Version(l TRCLabels) prometheus.Gauge {
+	return c.version.WithLabelValues(l.Values()...)
+}
+
+func (c *checker) Expiration(l TRCLabels) prometheus.Gauge {
+	return c.expiration.WithLabelValues(l.Values()...)
+}
+
+func (c *checker) GracePeriodEnd(l TRCLabels) prometheus.Gauge {
+	return c.gracePeriodEnd.WithLabelValues(l.Values()...)
+}
@@ -28,6 +28,7 @@ const (
 	GetRawTRC              = "get_raw_trc"
 	GetTRCInfo             = "get_trc_info"
 	GetIssuingGrantKeyInfo = "get_issuing_grant_key_info"
+	GetISDs                = "get_isds"
 	InsertTRC              = "insert_trc"
 	TRCExists              = "trc_exists"
 
@@ -24,6 +24,8 @@ import (
 func TestQueryLabels(t *testing.T) {
 	tests := map[string]interface{}{
 		"QueryLabels":     metrics.QueryLabels{},
+		"CheckerLabels":   metrics.CheckerLabels{},
+		"TRCLabels":       metrics.TRCLabels{},
 		"HandlerLabels":   metrics.HandlerLabels{},
 		"InserterLabels":  metrics.InserterLabels{},
 		"InspectorLabels": metrics.InspectorLabels{},
@@ -42,6 +42,7 @@ const (
 	SigVerification = "signature_verification"
 	ASInspector     = "trc_inspection"
 	App             = "application"
+	TRCChecker      = "trc_checker"
 )
 
 // Result types
@@ -63,6 +64,8 @@ const (
 )
 
 var (
+	// Checker exposes the TRC checker metrics.
+	Checker = newChecker()
 	// DB exposes the database metrics.
 	DB = newDB()
 	// Handler exposes the handler metrics.
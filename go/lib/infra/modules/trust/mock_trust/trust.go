@@ -183,6 +183,21 @@ func (mr *MockDBMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockDB)(nil).Close))
 }
 
+// GetISDs mocks base method
+func (m *MockDB) GetISDs(arg0 context.Context) ([]addr.ISD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetISDs", arg0)
+	ret0, _ := ret[0].([]addr.ISD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetISDs indicates an expected call of GetISDs
+func (mr *MockDBMockRecorder) GetISDs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetISDs", reflect.TypeOf((*MockDB)(nil).GetISDs), arg0)
+}
+
 // GetIssuingGrantKeyInfo mocks base method
 func (m *MockDB) GetIssuingGrantKeyInfo(arg0 context.Context, arg1 addr.IA, arg2 scrypto.Version) (trust.KeyInfo, error) {
 	m.ctrl.T.Helper()
@@ -351,19 +366,19 @@ func (m *MockKeyRing) EXPECT() *MockKeyRingMockRecorder {
 	return m.recorder
 }
 
-// PrivateKey mocks base method
-func (m *MockKeyRing) PrivateKey(arg0 keyconf.Usage, arg1 scrypto.KeyVersion) (keyconf.Key, error) {
+// Signer mocks base method
+func (m *MockKeyRing) Signer(arg0 keyconf.Usage, arg1 scrypto.KeyVersion) (keyconf.Signer, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PrivateKey", arg0, arg1)
-	ret0, _ := ret[0].(keyconf.Key)
+	ret := m.ctrl.Call(m, "Signer", arg0, arg1)
+	ret0, _ := ret[0].(keyconf.Signer)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// PrivateKey indicates an expected call of PrivateKey
-func (mr *MockKeyRingMockRecorder) PrivateKey(arg0, arg1 interface{}) *gomock.Call {
+// Signer indicates an expected call of Signer
+func (mr *MockKeyRingMockRecorder) Signer(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrivateKey", reflect.TypeOf((*MockKeyRing)(nil).PrivateKey), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Signer", reflect.TypeOf((*MockKeyRing)(nil).Signer), arg0, arg1)
 }
 
 // MockInserter is a mock of Inserter interface
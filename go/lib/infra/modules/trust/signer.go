@@ -30,11 +30,11 @@ import (
 	"github.com/scionproto/scion/go/proto"
 )
 
-// KeyRing provides different private keys.
+// KeyRing provides signers for different private keys.
 type KeyRing interface {
-	// PrivateKey returns the private key for the given usage and version. If it
-	// is not in the key ring, an error is returned.
-	PrivateKey(usage keyconf.Usage, version scrypto.KeyVersion) (keyconf.Key, error)
+	// Signer returns the signer for the private key with the given usage and
+	// version. If the key is not in the key ring, an error is returned.
+	Signer(usage keyconf.Usage, version scrypto.KeyVersion) (keyconf.Signer, error)
 }
 
 // SignerConf holds the configuration of a signer.
@@ -42,7 +42,11 @@ type SignerConf struct {
 	ChainVer scrypto.Version
 	TRCVer   scrypto.Version
 	Validity scrypto.Validity
-	Key      keyconf.Key
+	// Key is the private key metadata.
+	Key keyconf.Key
+	// KeySigner creates the signatures. If it is not set, the signatures are
+	// created with the key material in Key.
+	KeySigner keyconf.Signer
 }
 
 // Validate validates that the signer config is valid.
@@ -66,6 +70,7 @@ func (cfg SignerConf) Validate() error {
 // Signer is used to sign control plane data authenticated by certificate chains.
 type Signer struct {
 	cfg      SignerConf
+	signer   keyconf.Signer
 	signType proto.SignType
 	src      []byte
 }
@@ -81,7 +86,12 @@ func NewSigner(cfg SignerConf) (*Signer, error) {
 		ChainVer: cfg.ChainVer,
 		TRCVer:   cfg.TRCVer,
 	}
+	signer := cfg.KeySigner
+	if signer == nil {
+		signer = keyconf.KeySigner{Key: cfg.Key}
+	}
 	s := &Signer{
+		signer:   signer,
 		signType: signType,
 		src:      src.Pack(),
 		cfg:      cfg,
@@ -94,8 +104,7 @@ func (s *Signer) Sign(msg []byte) (*proto.SignS, error) {
 	var err error
 	l := metrics.SignerLabels{}
 	sign := proto.NewSignS(s.signType, append(s.src[:0:0], s.src...))
-	sign.Signature, err = scrypto.Sign(sign.SigInput(msg, true),
-		s.cfg.Key.Bytes, s.cfg.Key.Algorithm)
+	sign.Signature, err = s.signer.Sign(sign.SigInput(msg, true))
 	if err != nil {
 		metrics.Signer.Sign(l.WithResult(metrics.ErrInternal)).Inc()
 		return nil, err
@@ -139,13 +148,13 @@ func (g *SignerGen) Signer(ctx context.Context) (*Signer, error) {
 		metrics.Signer.Generate(l.WithResult(errToLabel(err))).Inc()
 		return nil, err
 	}
-	priv, err := g.KeyRing.PrivateKey(keyconf.ASSigningKey, dec.AS.Keys[cert.SigningKey].KeyVersion)
+	signer, err := g.KeyRing.Signer(keyconf.ASSigningKey, dec.AS.Keys[cert.SigningKey].KeyVersion)
 	if err != nil {
 		metrics.Signer.Generate(l.WithResult(metrics.ErrKey)).Inc()
 		return nil, serrors.WrapStr("private key not found", err, "chain", dec,
 			"key_version", dec.AS.Keys[cert.SigningKey].KeyVersion)
 	}
-	pub, err := scrypto.GetPubKey(priv.Bytes, priv.Algorithm)
+	pub, err := signer.PublicKey()
 	if err != nil {
 		metrics.Signer.Generate(l.WithResult(metrics.ErrKey)).Inc()
 		return nil, serrors.WrapStr("unable to compute public key", err, "chain", dec,
@@ -164,10 +173,11 @@ func (g *SignerGen) Signer(ctx context.Context) (*Signer, error) {
 	}
 	metrics.Signer.Generate(l.WithResult(metrics.Success)).Inc()
 	return NewSigner(SignerConf{
-		ChainVer: dec.AS.Version,
-		TRCVer:   trc.Version,
-		Validity: *dec.AS.Validity,
-		Key:      priv,
+		ChainVer:  dec.AS.Version,
+		TRCVer:    trc.Version,
+		Validity:  *dec.AS.Validity,
+		Key:       signer.Meta(),
+		KeySigner: signer,
 	})
 }
 
@@ -175,6 +185,10 @@ func signTypeFromAlgo(algo string) (proto.SignType, error) {
 	switch algo {
 	case scrypto.Ed25519:
 		return proto.SignType_ed25519, nil
+	case scrypto.ECDSAP256:
+		return proto.SignType_ecdsaP256, nil
+	case scrypto.ECDSAP384:
+		return proto.SignType_ecdsaP384, nil
 	default:
 		return proto.SignType_none, serrors.New("unsupported signing algorithm", "algo", algo)
 	}
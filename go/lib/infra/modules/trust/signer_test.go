@@ -34,6 +34,7 @@ import (
 	"github.com/scionproto/scion/go/lib/scrypto/cert"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/proto"
 )
 
 func TestNewSigner(t *testing.T) {
@@ -141,6 +142,33 @@ func TestSignerSign(t *testing.T) {
 		input := sign.SigInput([]byte("wasn't me"), false)
 		assert.NoError(t, scrypto.Verify(input, sign.Signature, pub, scrypto.Ed25519))
 	})
+	t.Run("valid ecdsa", func(t *testing.T) {
+		pub, priv, err := scrypto.GenKeyPair(scrypto.ECDSAP256)
+		require.NoError(t, err)
+		mcfg := cfg
+		mcfg.Key.Algorithm = scrypto.ECDSAP256
+		mcfg.Key.Bytes = priv
+		signer, err := trust.NewSigner(mcfg)
+		require.NoError(t, err)
+		sign, err := signer.Sign([]byte("wasn't me"))
+		require.NoError(t, err)
+		assert.Equal(t, proto.SignType_ecdsaP256, sign.Type)
+
+		input := sign.SigInput([]byte("wasn't me"), false)
+		assert.NoError(t, scrypto.Verify(input, sign.Signature, pub, scrypto.ECDSAP256))
+	})
+	t.Run("valid key signer", func(t *testing.T) {
+		mcfg := cfg
+		mcfg.KeySigner = keyconf.KeySigner{Key: cfg.Key}
+		mcfg.Key.Bytes = nil
+		signer, err := trust.NewSigner(mcfg)
+		require.NoError(t, err)
+		sign, err := signer.Sign([]byte("wasn't me"))
+		require.NoError(t, err)
+
+		input := sign.SigInput([]byte("wasn't me"), false)
+		assert.NoError(t, scrypto.Verify(input, sign.Signature, pub, scrypto.Ed25519))
+	})
 	t.Run("fail", func(t *testing.T) {
 		mcfg := cfg
 		mcfg.Key.Bytes = []byte("garbage key")
@@ -229,7 +257,7 @@ func TestSignerGenSigner(t *testing.T) {
 			},
 			KeyRing: func(t *testing.T, ctrl *gomock.Controller) trust.KeyRing {
 				r := mock_trust.NewMockKeyRing(ctrl)
-				r.EXPECT().PrivateKey(gomock.Any(), gomock.Any()).Return(keyconf.Key{}, internal)
+				r.EXPECT().Signer(gomock.Any(), gomock.Any()).Return(nil, internal)
 				return r
 			},
 			ErrAssertion: assert.Error,
@@ -247,7 +275,8 @@ func TestSignerGenSigner(t *testing.T) {
 				id := keyconf.ID{IA: ia110, Usage: keyconf.ASSigningKey, Version: 1}
 				key := loadPrivateKey(t, id)
 				key.Bytes = []byte("garbage key")
-				r.EXPECT().PrivateKey(gomock.Any(), gomock.Any()).Return(key, nil)
+				r.EXPECT().Signer(gomock.Any(), gomock.Any()).Return(
+					keyconf.KeySigner{Key: key}, nil)
 				return r
 			},
 			ErrAssertion: assert.Error,
@@ -265,7 +294,8 @@ func TestSignerGenSigner(t *testing.T) {
 				id := keyconf.ID{IA: ia110, Usage: keyconf.ASSigningKey, Version: 1}
 				key := loadPrivateKey(t, id)
 				key.Bytes[0] ^= 0xFF
-				r.EXPECT().PrivateKey(gomock.Any(), gomock.Any()).Return(key, nil)
+				r.EXPECT().Signer(gomock.Any(), gomock.Any()).Return(
+					keyconf.KeySigner{Key: key}, nil)
 				return r
 			},
 			ErrAssertion: assert.Error,
@@ -285,7 +315,8 @@ func TestSignerGenSigner(t *testing.T) {
 			KeyRing: func(t *testing.T, ctrl *gomock.Controller) trust.KeyRing {
 				r := mock_trust.NewMockKeyRing(ctrl)
 				id := keyconf.ID{IA: ia110, Usage: keyconf.ASSigningKey, Version: 1}
-				r.EXPECT().PrivateKey(gomock.Any(), gomock.Any()).Return(loadPrivateKey(t, id), nil)
+				r.EXPECT().Signer(gomock.Any(), gomock.Any()).Return(
+					keyconf.KeySigner{Key: loadPrivateKey(t, id)}, nil)
 				return r
 			},
 			ErrAssertion: assert.Error,
@@ -307,7 +338,8 @@ func TestSignerGenSigner(t *testing.T) {
 				id := keyconf.ID{IA: ia110, Usage: keyconf.ASSigningKey, Version: 1}
 				key := loadPrivateKey(t, id)
 				key.IA = addr.IA{}
-				r.EXPECT().PrivateKey(gomock.Any(), gomock.Any()).Return(key, nil)
+				r.EXPECT().Signer(gomock.Any(), gomock.Any()).Return(
+					keyconf.KeySigner{Key: key}, nil)
 				return r
 			},
 			ErrAssertion: assert.Error,
@@ -327,7 +359,8 @@ func TestSignerGenSigner(t *testing.T) {
 			KeyRing: func(t *testing.T, ctrl *gomock.Controller) trust.KeyRing {
 				r := mock_trust.NewMockKeyRing(ctrl)
 				id := keyconf.ID{IA: ia110, Usage: keyconf.ASSigningKey, Version: 1}
-				r.EXPECT().PrivateKey(gomock.Any(), gomock.Any()).Return(loadPrivateKey(t, id), nil)
+				r.EXPECT().Signer(gomock.Any(), gomock.Any()).Return(
+					keyconf.KeySigner{Key: loadPrivateKey(t, id)}, nil)
 				return r
 			},
 			ErrAssertion: assert.NoError,
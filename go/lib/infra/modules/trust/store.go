@@ -101,6 +101,17 @@ func (s Store) NewChainPushHandler(ia addr.IA) infra.Handler {
 	return infra.HandlerFunc(f)
 }
 
+// InsertRawChain decodes the raw certificate chain, verifies it and inserts it
+// into the database. The issuing TRC is queried through the crypto provider,
+// when necessary.
+func (s Store) InsertRawChain(ctx context.Context, raw []byte) error {
+	dec, err := decoded.DecodeChain(raw)
+	if err != nil {
+		return err
+	}
+	return s.Inserter.InsertChain(ctx, dec, newTRCGetter(s.CryptoProvider, nil))
+}
+
 // LoadCryptoMaterial loads the crypto material from the file system and
 // populates the trust database.
 func (s Store) LoadCryptoMaterial(ctx context.Context, dir string) error {
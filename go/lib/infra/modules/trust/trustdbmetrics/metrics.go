@@ -196,6 +196,16 @@ func (e *executor) GetIssuingGrantKeyInfo(ctx context.Context, ia addr.IA,
 	return info, err
 }
 
+func (e *executor) GetISDs(ctx context.Context) ([]addr.ISD, error) {
+	var isds []addr.ISD
+	var err error
+	e.metrics.Observe(ctx, metrics.GetISDs, func(ctx context.Context) error {
+		isds, err = e.rw.GetISDs(ctx)
+		return err
+	})
+	return isds, err
+}
+
 func (e *executor) InsertTRC(ctx context.Context, d decoded.TRC) (bool, error) {
 	var inserted bool
 	var err error
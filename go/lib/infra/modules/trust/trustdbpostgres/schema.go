@@ -0,0 +1,62 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file contains the PostgreSQL schema of the trust database.
+
+package trustdbpostgres
+
+import "github.com/scionproto/scion/go/lib/infra/modules/db"
+
+// Name identifies the trust DB schema in the schema versions table.
+const Name = "trustdb"
+
+// Migrations are the schema migrations of the PostgreSQL backend. Existing
+// migrations must never be changed, schema changes are appended as new
+// migrations.
+var Migrations = db.Migrations{
+	{
+		Version:     1,
+		Description: "Initial schema",
+		Up: `CREATE TABLE trcs(
+			isd_id BIGINT NOT NULL,
+			version BIGINT NOT NULL,
+			raw BYTEA NOT NULL,
+			pld BYTEA NOT NULL,
+			pld_hash BYTEA NOT NULL,
+			not_before BIGINT NOT NULL,
+			not_after BIGINT NOT NULL,
+			grace_period BIGINT NOT NULL,
+			PRIMARY KEY (isd_id, version)
+		);
+		CREATE TABLE chains(
+			isd_id BIGINT NOT NULL,
+			as_id BIGINT NOT NULL,
+			version BIGINT NOT NULL,
+			raw BYTEA NOT NULL,
+			as_hash BYTEA NOT NULL,
+			issuer_hash BYTEA NOT NULL,
+			PRIMARY KEY (isd_id, as_id, version)
+		);
+		CREATE TABLE issuer_certs(
+			isd_id BIGINT NOT NULL,
+			as_id BIGINT NOT NULL,
+			version BIGINT NOT NULL,
+			pld BYTEA NOT NULL,
+			pld_hash BYTEA NOT NULL,
+			protected BYTEA NOT NULL,
+			signature BYTEA NOT NULL,
+			PRIMARY KEY (isd_id, as_id, version)
+		);`,
+	},
+}
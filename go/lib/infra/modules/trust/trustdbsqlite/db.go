@@ -42,10 +42,11 @@ type Backend struct {
 }
 
 // New returns a new SQLite backend opening a database at the given path. If
-// no database exists a new database is be created. If the schema version of the
-// stored database is different from the one in schema.go, an error is returned.
+// no database exists a new database is be created. Pending migrations in
+// schema.go are applied. If the schema version of the stored database is newer
+// than the latest migration, an error is returned.
 func New(path string) (*Backend, error) {
-	db, err := db.NewSqlite(path, Schema, SchemaVersion)
+	db, err := db.NewSqlite(path, Migrations)
 	if err != nil {
 		return nil, err
 	}
@@ -215,6 +216,26 @@ func (e *executor) GetIssuingGrantKeyInfo(ctx context.Context, ia addr.IA,
 	}, nil
 }
 
+func (e *executor) GetISDs(ctx context.Context) ([]addr.ISD, error) {
+	e.RLock()
+	defer e.RUnlock()
+	query := `SELECT DISTINCT isd_id FROM trcs ORDER BY isd_id`
+	rows, err := e.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, serrors.WrapStr("unable to query ISDs", err)
+	}
+	defer rows.Close()
+	var isds []addr.ISD
+	for rows.Next() {
+		var isd addr.ISD
+		if err := rows.Scan(&isd); err != nil {
+			return nil, serrors.WrapStr("unable to scan ISD", err)
+		}
+		isds = append(isds, isd)
+	}
+	return isds, rows.Err()
+}
+
 func (e *executor) InsertTRC(ctx context.Context, d decoded.TRC) (bool, error) {
 	e.Lock()
 	defer e.Unlock()
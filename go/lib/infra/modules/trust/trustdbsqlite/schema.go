@@ -16,42 +16,47 @@
 
 package trustdbsqlite
 
-const (
-	// SchemaVersion is the version of the SQLite schema understood by this backend.
-	// Whenever changes to the schema are made, this version number should be increased
-	// to prevent data corruption between incompatible database schemas.
-	SchemaVersion = 1
-	// Schema is the SQLite database layout.
-	Schema = `
-	CREATE TABLE trcs(
-		isd_id INTEGER NOT NULL,
-		version INTEGER NOT NULL,
-		raw DATA NOT NULL,
-		pld DATA NOT NULL,
-		pld_hash DATA NOT NULL,
-		not_before INTEGER NOT NULL,
-		not_after INTEGER NOT NULL,
-		grace_period INTEGER NOT NULL,
-		PRIMARY KEY (isd_id, version)
-	);
-	CREATE TABLE chains(
-		isd_id INTEGER NOT NULL,
-		as_id INTEGER NOT NULL,
-		version INTEGER NOT NULL,
-		raw DATA NOT NULL,
-		as_hash DATA NOT NULL,
-		issuer_hash DATA NOT NULL,
-		PRIMARY KEY (isd_id, as_id, version)
-	);
-	CREATE TABLE issuer_certs(
-		isd_id INTEGER NOT NULL,
-		as_id INTEGER NOT NULL,
-		version INTEGER NOT NULL,
-		pld DATA NOT NULL,
-		pld_hash DATA NOT NULL,
-		protected DATA NOT NULL,
-		signature DATA NOT NULL,
-		PRIMARY KEY (isd_id, as_id, version)
-	);
-	`
-)
+import "github.com/scionproto/scion/go/lib/infra/modules/db"
+
+// Migrations are the schema migrations of the SQLite backend. The first
+// migration is the schema at the time migrations were introduced. Existing
+// migrations must never be changed, schema changes are appended as new
+// migrations.
+var Migrations = db.Migrations{
+	{
+		Version:     1,
+		Description: "Initial schema",
+		Up: `
+		CREATE TABLE trcs(
+			isd_id INTEGER NOT NULL,
+			version INTEGER NOT NULL,
+			raw DATA NOT NULL,
+			pld DATA NOT NULL,
+			pld_hash DATA NOT NULL,
+			not_before INTEGER NOT NULL,
+			not_after INTEGER NOT NULL,
+			grace_period INTEGER NOT NULL,
+			PRIMARY KEY (isd_id, version)
+		);
+		CREATE TABLE chains(
+			isd_id INTEGER NOT NULL,
+			as_id INTEGER NOT NULL,
+			version INTEGER NOT NULL,
+			raw DATA NOT NULL,
+			as_hash DATA NOT NULL,
+			issuer_hash DATA NOT NULL,
+			PRIMARY KEY (isd_id, as_id, version)
+		);
+		CREATE TABLE issuer_certs(
+			isd_id INTEGER NOT NULL,
+			as_id INTEGER NOT NULL,
+			version INTEGER NOT NULL,
+			pld DATA NOT NULL,
+			pld_hash DATA NOT NULL,
+			protected DATA NOT NULL,
+			signature DATA NOT NULL,
+			PRIMARY KEY (isd_id, as_id, version)
+		);
+		`,
+	},
+}
@@ -186,6 +186,11 @@ func testTRC(t *testing.T, db trust.ReadWrite, cfg Config) {
 		_, err = db.GetTRCInfo(ctx, trust.TRCID{ISD: 42, Version: scrypto.LatestVer})
 		xtest.AssertErrorsIs(t, err, trust.ErrNotFound)
 	})
+	t.Run("GetISDs", func(t *testing.T) {
+		isds, err := db.GetISDs(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []addr.ISD{v1.TRC.ISD}, isds)
+	})
 	t.Run("GetIssuingGrantKeyInfo", func(t *testing.T) {
 		ia110 := xtest.MustParseIA("1-ff00:0:110")
 		ia120 := xtest.MustParseIA("1-ff00:0:120")
@@ -127,6 +127,11 @@ func (v *verifier) Verify(ctx context.Context, msg []byte, sign *proto.SignS) er
 		return err
 	}
 
+	if signType, err := signTypeFromAlgo(key.Algorithm); err != nil || signType != sign.Type {
+		metrics.Verifier.Verify(l.WithResult(metrics.ErrValidate)).Inc()
+		return serrors.WithCtx(ErrValidation, "msg", "signature type does not match key",
+			"sign_type", sign.Type, "algo", key.Algorithm)
+	}
 	m, s := sign.SigInput(msg, false), sign.Signature
 	if err := scrypto.Verify(m, s, key.Key, key.Algorithm); err != nil {
 		metrics.Verifier.Verify(l.WithResult(metrics.ErrVerify)).Inc()
@@ -0,0 +1,28 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyconf
+
+import (
+	"github.com/scionproto/scion/go/lib/addr"
+)
+
+// PKCS11Token exposes the PKCS#11 API used by the PKCS11Ring for testing.
+type PKCS11Token = pkcs11Token
+
+// NewTestPKCS11Ring creates a PKCS#11 ring that uses the token and session
+// zero.
+func NewTestPKCS11Ring(dir string, ia addr.IA, token PKCS11Token) *PKCS11Ring {
+	return &PKCS11Ring{Dir: dir, IA: ia, token: token}
+}
@@ -67,7 +67,7 @@ func loadKey(file string, algo string) (common.RawBytes, error) {
 	}
 	dbuf = dbuf[:n]
 	switch strings.ToLower(algo) {
-	case RawKey, scrypto.Curve25519xSalsa20Poly1305:
+	case RawKey, scrypto.Curve25519xSalsa20Poly1305, scrypto.ECDSAP256, scrypto.ECDSAP384:
 		return dbuf, nil
 	case scrypto.Ed25519:
 		return common.RawBytes(ed25519.NewKeyFromSeed(dbuf)), nil
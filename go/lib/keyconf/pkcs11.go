@@ -0,0 +1,281 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyconf
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/miekg/pkcs11"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// The PKCS#11 module is loaded with dlopen through cgo. Note that this makes
+// cgo a build requirement for all importers of this package.
+
+// ckmEdDSA is the PKCS#11 v3.0 EdDSA mechanism. It is not defined by the
+// PKCS#11 library, which implements v2.40.
+const ckmEdDSA = 0x00001057
+
+// PKCS11Conf configures the access to a PKCS#11 token.
+type PKCS11Conf struct {
+	// Module is the path to the PKCS#11 module (shared library) of the token.
+	Module string
+	// TokenLabel is the label of the token that holds the private keys.
+	TokenLabel string
+	// PIN is the user PIN of the token.
+	PIN string
+}
+
+// pkcs11Token is the subset of the PKCS#11 API used by the PKCS11Ring.
+type pkcs11Token interface {
+	FindObjectsInit(sh pkcs11.SessionHandle, temp []*pkcs11.Attribute) error
+	FindObjects(sh pkcs11.SessionHandle, max int) ([]pkcs11.ObjectHandle, bool, error)
+	FindObjectsFinal(sh pkcs11.SessionHandle) error
+	SignInit(sh pkcs11.SessionHandle, m []*pkcs11.Mechanism, o pkcs11.ObjectHandle) error
+	Sign(sh pkcs11.SessionHandle, message []byte) ([]byte, error)
+}
+
+var _ SignerRing = (*PKCS11Ring)(nil)
+
+// PKCS11Ring provides signers for private keys that are held by a PKCS#11
+// token, e.g., a hardware security module. The private key material never
+// leaves the token.
+//
+// On the token, a private key is identified by its label, which is the private
+// key file name without the extension (e.g., "as-signing-v1"). The metadata and
+// the public key are loaded from the public key file in the key directory.
+//
+// All operations share a single session and are serialized.
+type PKCS11Ring struct {
+	// Dir is the directory that contains the public key files.
+	Dir string
+	IA  addr.IA
+
+	ctx     *pkcs11.Ctx
+	token   pkcs11Token
+	session pkcs11.SessionHandle
+	mtx     sync.Mutex
+}
+
+// NewPKCS11Ring loads the PKCS#11 module, opens a session to the configured
+// token and logs in. The ring must be closed to release the session.
+func NewPKCS11Ring(dir string, ia addr.IA, cfg PKCS11Conf) (*PKCS11Ring, error) {
+	ctx := pkcs11.New(cfg.Module)
+	if ctx == nil {
+		return nil, serrors.New("unable to load PKCS#11 module", "module", cfg.Module)
+	}
+	if err := ctx.Initialize(); err != nil {
+		ctx.Destroy()
+		return nil, serrors.WrapStr("unable to initialize PKCS#11 module", err,
+			"module", cfg.Module)
+	}
+	session, err := openSession(ctx, cfg)
+	if err != nil {
+		ctx.Finalize()
+		ctx.Destroy()
+		return nil, err
+	}
+	r := &PKCS11Ring{
+		Dir:     dir,
+		IA:      ia,
+		ctx:     ctx,
+		token:   ctx,
+		session: session,
+	}
+	return r, nil
+}
+
+func openSession(ctx *pkcs11.Ctx, cfg PKCS11Conf) (pkcs11.SessionHandle, error) {
+	slots, err := ctx.GetSlotList(true)
+	if err != nil {
+		return 0, serrors.WrapStr("unable to list PKCS#11 slots", err)
+	}
+	for _, slot := range slots {
+		info, err := ctx.GetTokenInfo(slot)
+		if err != nil {
+			return 0, serrors.WrapStr("unable to get PKCS#11 token info", err, "slot", slot)
+		}
+		if info.Label != cfg.TokenLabel {
+			continue
+		}
+		session, err := ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION)
+		if err != nil {
+			return 0, serrors.WrapStr("unable to open PKCS#11 session", err,
+				"token", cfg.TokenLabel)
+		}
+		err = ctx.Login(session, pkcs11.CKU_USER, cfg.PIN)
+		if err != nil && err != pkcs11.Error(pkcs11.CKR_USER_ALREADY_LOGGED_IN) {
+			ctx.CloseSession(session)
+			return 0, serrors.WrapStr("unable to log in to PKCS#11 token", err,
+				"token", cfg.TokenLabel)
+		}
+		return session, nil
+	}
+	return 0, serrors.New("PKCS#11 token not found", "token", cfg.TokenLabel)
+}
+
+// Close closes the session and unloads the PKCS#11 module.
+func (r *PKCS11Ring) Close() error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if r.ctx == nil {
+		return nil
+	}
+	r.ctx.Logout(r.session)
+	err := r.ctx.CloseSession(r.session)
+	r.ctx.Finalize()
+	r.ctx.Destroy()
+	r.ctx, r.token = nil, nil
+	return err
+}
+
+// Signer returns a signer for the private key with the given usage and
+// version. The metadata is loaded from the public key file, and the private
+// key must be present on the token.
+func (r *PKCS11Ring) Signer(usage Usage, version scrypto.KeyVersion) (Signer, error) {
+	id := ID{
+		IA:      r.IA,
+		Usage:   usage,
+		Version: version,
+	}
+	pub, err := LoadKeyFromFile(filepath.Join(r.Dir, PublicKeyFile(usage, r.IA, version)),
+		PublicKey, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := pkcs11Mechanism(pub.Algorithm); err != nil {
+		return nil, err
+	}
+	label := pkcs11Label(usage, version)
+	if _, err := r.findKey(label); err != nil {
+		return nil, err
+	}
+	meta := pub
+	meta.Type = PrivateKey
+	meta.Bytes = nil
+	return pkcs11Signer{ring: r, meta: meta, label: label, pub: pub.Bytes}, nil
+}
+
+// sign signs the input with the private key with the given label.
+func (r *PKCS11Ring) sign(label, algo string, input []byte) ([]byte, error) {
+	mech, err := pkcs11Mechanism(algo)
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(algo) {
+	case scrypto.ECDSAP256:
+		digest := sha256.Sum256(input)
+		input = digest[:]
+	case scrypto.ECDSAP384:
+		digest := sha512.Sum384(input)
+		input = digest[:]
+	}
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if r.token == nil {
+		return nil, serrors.New("PKCS#11 ring closed")
+	}
+	key, err := r.findKeyLocked(label)
+	if err != nil {
+		return nil, err
+	}
+	mechs := []*pkcs11.Mechanism{pkcs11.NewMechanism(mech, nil)}
+	if err := r.token.SignInit(r.session, mechs, key); err != nil {
+		return nil, serrors.WrapStr("unable to initialize PKCS#11 signing", err,
+			"label", label)
+	}
+	sig, err := r.token.Sign(r.session, input)
+	if err != nil {
+		return nil, serrors.WrapStr("unable to sign with PKCS#11 token", err, "label", label)
+	}
+	return sig, nil
+}
+
+func (r *PKCS11Ring) findKey(label string) (pkcs11.ObjectHandle, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if r.token == nil {
+		return 0, serrors.New("PKCS#11 ring closed")
+	}
+	return r.findKeyLocked(label)
+}
+
+func (r *PKCS11Ring) findKeyLocked(label string) (pkcs11.ObjectHandle, error) {
+	template := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PRIVATE_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+	}
+	if err := r.token.FindObjectsInit(r.session, template); err != nil {
+		return 0, serrors.WrapStr("unable to search PKCS#11 token", err, "label", label)
+	}
+	objs, _, err := r.token.FindObjects(r.session, 2)
+	if finalErr := r.token.FindObjectsFinal(r.session); err == nil {
+		err = finalErr
+	}
+	if err != nil {
+		return 0, serrors.WrapStr("unable to search PKCS#11 token", err, "label", label)
+	}
+	switch len(objs) {
+	case 0:
+		return 0, serrors.New("private key not found on PKCS#11 token", "label", label)
+	case 1:
+		return objs[0], nil
+	default:
+		return 0, serrors.New("private key label not unique on PKCS#11 token",
+			"label", label)
+	}
+}
+
+// pkcs11Label returns the label of the private key on the PKCS#11 token.
+func pkcs11Label(usage Usage, version scrypto.KeyVersion) string {
+	return strings.TrimSuffix(PrivateKeyFile(usage, version), ".key")
+}
+
+func pkcs11Mechanism(algo string) (uint, error) {
+	switch strings.ToLower(algo) {
+	case scrypto.Ed25519:
+		return ckmEdDSA, nil
+	case scrypto.ECDSAP256, scrypto.ECDSAP384:
+		return pkcs11.CKM_ECDSA, nil
+	default:
+		return 0, serrors.WithCtx(scrypto.ErrUnsupportedSignAlgo, "algo", algo)
+	}
+}
+
+type pkcs11Signer struct {
+	ring  *PKCS11Ring
+	meta  Key
+	label string
+	pub   []byte
+}
+
+func (s pkcs11Signer) Meta() Key {
+	return s.meta
+}
+
+func (s pkcs11Signer) PublicKey() ([]byte, error) {
+	return append([]byte(nil), s.pub...), nil
+}
+
+func (s pkcs11Signer) Sign(input []byte) ([]byte, error) {
+	return s.ring.sign(s.label, s.meta.Algorithm, input)
+}
@@ -0,0 +1,357 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyconf_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/asn1"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/miekg/pkcs11"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/keyconf"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+// softHSMEnv is the environment variable that contains the path to the SoftHSM
+// PKCS#11 module. If it is not set, the module is looked up in the default
+// location. The SoftHSM test is skipped if the module does not exist.
+const softHSMEnv = "SCION_TEST_SOFTHSM_MODULE"
+
+const defaultSoftHSMModule = "/usr/lib/softhsm/libsofthsm2.so"
+
+func TestPKCS11RingSigner(t *testing.T) {
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	tmpDir, cleanF := xtest.MustTempDir("", "test-keyconf-pkcs11")
+	defer cleanF()
+
+	token := &fakeToken{keys: make(map[string]interface{})}
+	for _, algo := range []string{scrypto.Ed25519, scrypto.ECDSAP256, scrypto.ECDSAP384} {
+		t.Run(algo, func(t *testing.T) {
+			pub, priv, err := scrypto.GenKeyPair(algo)
+			require.NoError(t, err)
+			version := scrypto.KeyVersion(len(token.keys) + 1)
+			key := writePublicKey(t, tmpDir, ia, algo, version, pub)
+			label := keyLabel(version)
+			token.keys[label] = softwareKey(t, algo, priv)
+
+			ring := keyconf.NewTestPKCS11Ring(tmpDir, ia, token)
+			signer, err := ring.Signer(keyconf.ASSigningKey, version)
+			require.NoError(t, err)
+			meta := signer.Meta()
+			assert.Equal(t, key.ID, meta.ID)
+			assert.Equal(t, keyconf.PrivateKey, meta.Type)
+			assert.Equal(t, key.Algorithm, meta.Algorithm)
+			assert.Equal(t, key.Validity, meta.Validity)
+			assert.Empty(t, meta.Bytes)
+			rawPub, err := signer.PublicKey()
+			require.NoError(t, err)
+			assert.Equal(t, []byte(pub), rawPub)
+			sig, err := signer.Sign([]byte("message"))
+			require.NoError(t, err)
+			assert.NoError(t, scrypto.Verify([]byte("message"), sig, rawPub, algo))
+		})
+	}
+	t.Run("key not on token", func(t *testing.T) {
+		pub, _, err := scrypto.GenKeyPair(scrypto.Ed25519)
+		require.NoError(t, err)
+		writePublicKey(t, tmpDir, ia, scrypto.Ed25519, 42, pub)
+		ring := keyconf.NewTestPKCS11Ring(tmpDir, ia, token)
+		_, err = ring.Signer(keyconf.ASSigningKey, 42)
+		assert.Error(t, err)
+	})
+	t.Run("public key missing", func(t *testing.T) {
+		ring := keyconf.NewTestPKCS11Ring(tmpDir, ia, token)
+		_, err := ring.Signer(keyconf.ASSigningKey, 43)
+		assert.Error(t, err)
+	})
+}
+
+func TestPKCS11RingSoftHSM(t *testing.T) {
+	module := os.Getenv(softHSMEnv)
+	if module == "" {
+		module = defaultSoftHSMModule
+	}
+	if _, err := os.Stat(module); err != nil {
+		t.Skipf("SoftHSM module not available: %s", err)
+	}
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	tmpDir, cleanF := xtest.MustTempDir("", "test-keyconf-softhsm")
+	defer cleanF()
+	tokenDir := filepath.Join(tmpDir, "tokens")
+	require.NoError(t, os.Mkdir(tokenDir, 0700))
+	conf := filepath.Join(tmpDir, "softhsm2.conf")
+	require.NoError(t, ioutil.WriteFile(conf,
+		[]byte("directories.tokendir = "+tokenDir+"\nobjectstore.backend = file\n"), 0600))
+	prevConf, confSet := os.LookupEnv("SOFTHSM2_CONF")
+	require.NoError(t, os.Setenv("SOFTHSM2_CONF", conf))
+	defer func() {
+		if confSet {
+			os.Setenv("SOFTHSM2_CONF", prevConf)
+		} else {
+			os.Unsetenv("SOFTHSM2_CONF")
+		}
+	}()
+
+	cfg := keyconf.PKCS11Conf{Module: module, TokenLabel: "scion", PIN: "1234"}
+	keys := map[scrypto.KeyVersion]string{
+		1: scrypto.Ed25519,
+		2: scrypto.ECDSAP256,
+	}
+	pubs := initSoftHSM(t, cfg, keys)
+	for version, algo := range keys {
+		writePublicKey(t, tmpDir, ia, algo, version, pubs[version])
+	}
+
+	ring, err := keyconf.NewPKCS11Ring(tmpDir, ia, cfg)
+	require.NoError(t, err)
+	defer ring.Close()
+	for version, algo := range keys {
+		t.Run(algo, func(t *testing.T) {
+			signer, err := ring.Signer(keyconf.ASSigningKey, version)
+			require.NoError(t, err)
+			sig, err := signer.Sign([]byte("message"))
+			require.NoError(t, err)
+			assert.NoError(t, scrypto.Verify([]byte("message"), sig, pubs[version], algo))
+		})
+	}
+	_, err = keyconf.NewPKCS11Ring(tmpDir, ia,
+		keyconf.PKCS11Conf{Module: module, TokenLabel: "unknown", PIN: "1234"})
+	assert.Error(t, err)
+}
+
+// initSoftHSM initializes the token in the first SoftHSM slot and generates
+// the key pairs on it. It returns the public keys in the SCION format.
+func initSoftHSM(t *testing.T, cfg keyconf.PKCS11Conf,
+	keys map[scrypto.KeyVersion]string) map[scrypto.KeyVersion][]byte {
+
+	const (
+		ckmECEdwardsKeyPairGen = 0x00001055
+		ckkECEdwards           = 0x00000040
+	)
+	ctx := pkcs11.New(cfg.Module)
+	require.NotNil(t, ctx)
+	defer ctx.Destroy()
+	require.NoError(t, ctx.Initialize())
+	defer ctx.Finalize()
+	slots, err := ctx.GetSlotList(true)
+	require.NoError(t, err)
+	require.NotEmpty(t, slots)
+	require.NoError(t, ctx.InitToken(slots[0], "so-pin", cfg.TokenLabel))
+	// Initializing the token reassigns the slots.
+	slots, err = ctx.GetSlotList(true)
+	require.NoError(t, err)
+	var slot uint
+	for _, s := range slots {
+		info, err := ctx.GetTokenInfo(s)
+		require.NoError(t, err)
+		if info.Label == cfg.TokenLabel {
+			slot = s
+		}
+	}
+	session, err := ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION|pkcs11.CKF_RW_SESSION)
+	require.NoError(t, err)
+	defer ctx.CloseSession(session)
+	require.NoError(t, ctx.Login(session, pkcs11.CKU_SO, "so-pin"))
+	require.NoError(t, ctx.InitPIN(session, cfg.PIN))
+	require.NoError(t, ctx.Logout(session))
+	require.NoError(t, ctx.Login(session, pkcs11.CKU_USER, cfg.PIN))
+	defer ctx.Logout(session)
+
+	pubs := make(map[scrypto.KeyVersion][]byte)
+	for version, algo := range keys {
+		label := keyLabel(version)
+		var mech uint
+		var keyType uint
+		var params asn1.ObjectIdentifier
+		switch algo {
+		case scrypto.Ed25519:
+			mech, keyType, params = ckmECEdwardsKeyPairGen, ckkECEdwards,
+				asn1.ObjectIdentifier{1, 3, 101, 112}
+		case scrypto.ECDSAP256:
+			mech, keyType, params = pkcs11.CKM_EC_KEY_PAIR_GEN, pkcs11.CKK_EC,
+				asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7}
+		}
+		rawParams, err := asn1.Marshal(params)
+		require.NoError(t, err)
+		pubHandle, _, err := ctx.GenerateKeyPair(session,
+			[]*pkcs11.Mechanism{pkcs11.NewMechanism(mech, nil)},
+			[]*pkcs11.Attribute{
+				pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+				pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, keyType),
+				pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, rawParams),
+				pkcs11.NewAttribute(pkcs11.CKA_VERIFY, true),
+				pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+			},
+			[]*pkcs11.Attribute{
+				pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+				pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, keyType),
+				pkcs11.NewAttribute(pkcs11.CKA_PRIVATE, true),
+				pkcs11.NewAttribute(pkcs11.CKA_SENSITIVE, true),
+				pkcs11.NewAttribute(pkcs11.CKA_SIGN, true),
+				pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+			},
+		)
+		require.NoError(t, err)
+		attrs, err := ctx.GetAttributeValue(session, pubHandle,
+			[]*pkcs11.Attribute{pkcs11.NewAttribute(pkcs11.CKA_EC_POINT, nil)})
+		require.NoError(t, err)
+		// The point is DER encoded as an octet string.
+		var point []byte
+		_, err = asn1.Unmarshal(attrs[0].Value, &point)
+		require.NoError(t, err)
+		pubs[version] = point
+	}
+	return pubs
+}
+
+func writePublicKey(t *testing.T, dir string, ia addr.IA, algo string,
+	version scrypto.KeyVersion, pub []byte) keyconf.Key {
+
+	now := time.Now().Truncate(time.Second)
+	key := keyconf.Key{
+		ID: keyconf.ID{
+			IA:      ia,
+			Usage:   keyconf.ASSigningKey,
+			Version: version,
+		},
+		Type:      keyconf.PublicKey,
+		Algorithm: algo,
+		Validity: scrypto.Validity{
+			NotBefore: util.UnixTime{Time: now},
+			NotAfter:  util.UnixTime{Time: now.Add(time.Hour)},
+		},
+		Bytes: pub,
+	}
+	block := key.PEM()
+	err := ioutil.WriteFile(filepath.Join(dir, key.File()), pem.EncodeToMemory(&block), 0644)
+	require.NoError(t, err)
+	return key
+}
+
+// keyLabel returns the label of the AS signing key on the token.
+func keyLabel(version scrypto.KeyVersion) string {
+	return strings.TrimSuffix(keyconf.PrivateKeyFile(keyconf.ASSigningKey, version), ".key")
+}
+
+// softwareKey converts the private key to the key type used by the fake token.
+func softwareKey(t *testing.T, algo string, priv []byte) interface{} {
+	switch algo {
+	case scrypto.Ed25519:
+		return ed25519.PrivateKey(priv)
+	case scrypto.ECDSAP256, scrypto.ECDSAP384:
+		curve := elliptic.P256()
+		if algo == scrypto.ECDSAP384 {
+			curve = elliptic.P384()
+		}
+		key := &ecdsa.PrivateKey{D: new(big.Int).SetBytes(priv)}
+		key.Curve = curve
+		key.X, key.Y = curve.ScalarBaseMult(priv)
+		return key
+	}
+	t.Fatalf("unsupported algorithm %s", algo)
+	return nil
+}
+
+// fakeToken is a PKCS#11 token that holds software keys indexed by their
+// label. Object handles are indices into the sorted labels.
+type fakeToken struct {
+	keys    map[string]interface{}
+	found   []string
+	signing string
+	mech    uint
+}
+
+func (t *fakeToken) FindObjectsInit(_ pkcs11.SessionHandle, temp []*pkcs11.Attribute) error {
+	t.found = nil
+	for _, attr := range temp {
+		if attr.Type != pkcs11.CKA_LABEL {
+			continue
+		}
+		if _, ok := t.keys[string(attr.Value)]; ok {
+			t.found = append(t.found, string(attr.Value))
+		}
+	}
+	return nil
+}
+
+func (t *fakeToken) FindObjects(_ pkcs11.SessionHandle,
+	max int) ([]pkcs11.ObjectHandle, bool, error) {
+
+	var objs []pkcs11.ObjectHandle
+	for i := range t.found {
+		if i < max {
+			objs = append(objs, pkcs11.ObjectHandle(i))
+		}
+	}
+	return objs, false, nil
+}
+
+func (t *fakeToken) FindObjectsFinal(_ pkcs11.SessionHandle) error {
+	return nil
+}
+
+func (t *fakeToken) SignInit(_ pkcs11.SessionHandle, m []*pkcs11.Mechanism,
+	o pkcs11.ObjectHandle) error {
+
+	if int(o) >= len(t.found) || len(m) != 1 {
+		return pkcs11.Error(pkcs11.CKR_ARGUMENTS_BAD)
+	}
+	t.signing, t.mech = t.found[o], m[0].Mechanism
+	return nil
+}
+
+func (t *fakeToken) Sign(_ pkcs11.SessionHandle, message []byte) ([]byte, error) {
+	switch key := t.keys[t.signing].(type) {
+	case ed25519.PrivateKey:
+		if t.mech != 0x00001057 {
+			return nil, pkcs11.Error(pkcs11.CKR_MECHANISM_INVALID)
+		}
+		return ed25519.Sign(key, message), nil
+	case *ecdsa.PrivateKey:
+		if t.mech != pkcs11.CKM_ECDSA {
+			return nil, pkcs11.Error(pkcs11.CKR_MECHANISM_INVALID)
+		}
+		// The input is the digest, the signature is the concatenation of r
+		// and s.
+		r, s, err := ecdsa.Sign(rand.Reader, key, message)
+		if err != nil {
+			return nil, err
+		}
+		size := (key.Curve.Params().BitSize + 7) / 8
+		sig := make([]byte, 2*size)
+		copy(sig[size-len(r.Bytes()):size], r.Bytes())
+		copy(sig[2*size-len(s.Bytes()):], s.Bytes())
+		return sig, nil
+	default:
+		return nil, serrors.New("unknown key", "label", t.signing)
+	}
+}
@@ -0,0 +1,248 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyconf
+
+import (
+	"encoding/json"
+	"net"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// DefaultProcessTimeout is the default timeout for a single request to the
+// external signing process.
+const DefaultProcessTimeout = time.Second
+
+// Operations supported by the external signing process.
+const (
+	// OpKey requests the metadata and the public key of a private key.
+	OpKey = "key"
+	// OpSign requests a signature.
+	OpSign = "sign"
+)
+
+// ErrProcess indicates that the external signing process returned an error.
+var ErrProcess = serrors.New("signing process error")
+
+// ProcessRequest is a request to the external signing process.
+//
+// The external signing process listens on a unix socket. For every request, a
+// new connection is opened, the JSON encoded request is written, and the JSON
+// encoded response is read.
+type ProcessRequest struct {
+	Op      string             `json:"op"`
+	IA      addr.IA            `json:"ia"`
+	Usage   Usage              `json:"usage"`
+	Version scrypto.KeyVersion `json:"version"`
+	// Input is the input to sign. It is only set for OpSign.
+	Input []byte `json:"input,omitempty"`
+}
+
+// ProcessResponse is the response of the external signing process.
+type ProcessResponse struct {
+	// Algorithm is the key algorithm. It is set for OpKey.
+	Algorithm string `json:"algorithm,omitempty"`
+	// Validity is the key validity period. It is set for OpKey.
+	Validity *scrypto.Validity `json:"validity,omitempty"`
+	// PublicKey is the public key. It is set for OpKey.
+	PublicKey []byte `json:"public_key,omitempty"`
+	// Signature is the created signature. It is set for OpSign.
+	Signature []byte `json:"signature,omitempty"`
+	// Error describes the error, if the request failed.
+	Error string `json:"error,omitempty"`
+}
+
+var _ SignerRing = ProcessRing{}
+
+// ProcessRing provides signers that delegate signing to an external signing
+// process over a unix socket. The private key material never enters the
+// calling process. Keys held by a hardware security module are made available
+// by a signing process that talks PKCS#11 to the token.
+type ProcessRing struct {
+	// Socket is the unix socket the signing process listens on.
+	Socket string
+	IA     addr.IA
+	// Timeout is the timeout for a single request. If zero,
+	// DefaultProcessTimeout is used.
+	Timeout time.Duration
+}
+
+// Signer returns a signer for the private key with the given usage and
+// version. The key metadata and the public key are requested from the signing
+// process.
+func (r ProcessRing) Signer(usage Usage, version scrypto.KeyVersion) (Signer, error) {
+	req := ProcessRequest{
+		Op:      OpKey,
+		IA:      r.IA,
+		Usage:   usage,
+		Version: version,
+	}
+	rep, err := r.request(req)
+	if err != nil {
+		return nil, err
+	}
+	if rep.Algorithm == "" || rep.Validity == nil || len(rep.PublicKey) == 0 {
+		return nil, serrors.New("incomplete key response", "usage", usage, "version", version)
+	}
+	s := processSigner{
+		ring: r,
+		meta: Key{
+			ID: ID{
+				IA:      r.IA,
+				Usage:   usage,
+				Version: version,
+			},
+			Type:      PrivateKey,
+			Algorithm: rep.Algorithm,
+			Validity:  *rep.Validity,
+		},
+		pub: rep.PublicKey,
+	}
+	return s, nil
+}
+
+func (r ProcessRing) request(req ProcessRequest) (ProcessResponse, error) {
+	timeout := r.Timeout
+	if timeout == 0 {
+		timeout = DefaultProcessTimeout
+	}
+	conn, err := net.DialTimeout("unix", r.Socket, timeout)
+	if err != nil {
+		return ProcessResponse{}, serrors.WrapStr("unable to connect to signing process", err,
+			"socket", r.Socket)
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return ProcessResponse{}, err
+	}
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return ProcessResponse{}, serrors.WrapStr("unable to write request", err)
+	}
+	var rep ProcessResponse
+	if err := json.NewDecoder(conn).Decode(&rep); err != nil {
+		return ProcessResponse{}, serrors.WrapStr("unable to read response", err)
+	}
+	if rep.Error != "" {
+		return ProcessResponse{}, serrors.WithCtx(ErrProcess, "op", req.Op,
+			"usage", req.Usage, "version", req.Version, "msg", rep.Error)
+	}
+	return rep, nil
+}
+
+type processSigner struct {
+	ring ProcessRing
+	meta Key
+	pub  []byte
+}
+
+func (s processSigner) Meta() Key {
+	return s.meta
+}
+
+func (s processSigner) PublicKey() ([]byte, error) {
+	return append([]byte(nil), s.pub...), nil
+}
+
+func (s processSigner) Sign(input []byte) ([]byte, error) {
+	req := ProcessRequest{
+		Op:      OpSign,
+		IA:      s.meta.IA,
+		Usage:   s.meta.Usage,
+		Version: s.meta.Version,
+		Input:   input,
+	}
+	rep, err := s.ring.request(req)
+	if err != nil {
+		return nil, err
+	}
+	if len(rep.Signature) == 0 {
+		return nil, serrors.New("empty signature", "usage", s.meta.Usage,
+			"version", s.meta.Version)
+	}
+	return rep.Signature, nil
+}
+
+// ProcessServer serves the signers of a key ring to ProcessRing clients. It
+// implements the server side of the external signing process protocol.
+type ProcessServer struct {
+	IA   addr.IA
+	Ring SignerRing
+}
+
+// Serve accepts connections on the listener and serves the requests until
+// the listener is closed.
+func (s ProcessServer) Serve(l net.Listener) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		go func() {
+			defer log.LogPanicAndExit()
+			s.serve(conn)
+		}()
+	}
+}
+
+func (s ProcessServer) serve(conn net.Conn) {
+	defer conn.Close()
+	var req ProcessRequest
+	if err := json.NewDecoder(conn).Decode(&req); err != nil {
+		log.Info("[keyconf.ProcessServer] Unable to read request", "err", err)
+		return
+	}
+	rep, err := s.handle(req)
+	if err != nil {
+		rep = ProcessResponse{Error: err.Error()}
+	}
+	if err := json.NewEncoder(conn).Encode(rep); err != nil {
+		log.Info("[keyconf.ProcessServer] Unable to write response", "err", err)
+	}
+}
+
+func (s ProcessServer) handle(req ProcessRequest) (ProcessResponse, error) {
+	if !req.IA.Equal(s.IA) {
+		return ProcessResponse{}, serrors.New("unknown IA", "ia", req.IA)
+	}
+	signer, err := s.Ring.Signer(req.Usage, req.Version)
+	if err != nil {
+		return ProcessResponse{}, err
+	}
+	switch req.Op {
+	case OpKey:
+		pub, err := signer.PublicKey()
+		if err != nil {
+			return ProcessResponse{}, err
+		}
+		meta := signer.Meta()
+		return ProcessResponse{
+			Algorithm: meta.Algorithm,
+			Validity:  &meta.Validity,
+			PublicKey: pub,
+		}, nil
+	case OpSign:
+		sig, err := signer.Sign(req.Input)
+		if err != nil {
+			return ProcessResponse{}, err
+		}
+		return ProcessResponse{Signature: sig}, nil
+	default:
+		return ProcessResponse{}, serrors.New("unsupported operation", "op", req.Op)
+	}
+}
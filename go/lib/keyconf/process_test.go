@@ -0,0 +1,102 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyconf_test
+
+import (
+	"encoding/pem"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/keyconf"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestProcessRingSigner(t *testing.T) {
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	tmpDir, cleanF := xtest.MustTempDir("", "test-keyconf-process")
+	defer cleanF()
+
+	now := time.Now().Truncate(time.Second)
+	pub, priv, err := scrypto.GenKeyPair(scrypto.ECDSAP256)
+	require.NoError(t, err)
+	key := keyconf.Key{
+		ID: keyconf.ID{
+			IA:      ia,
+			Usage:   keyconf.ASSigningKey,
+			Version: 2,
+		},
+		Type:      keyconf.PrivateKey,
+		Algorithm: scrypto.ECDSAP256,
+		Validity: scrypto.Validity{
+			NotBefore: util.UnixTime{Time: now},
+			NotAfter:  util.UnixTime{Time: now.Add(time.Hour)},
+		},
+		Bytes: priv,
+	}
+	block := key.PEM()
+	err = ioutil.WriteFile(filepath.Join(tmpDir, key.File()), pem.EncodeToMemory(&block), 0600)
+	require.NoError(t, err)
+
+	socket := filepath.Join(tmpDir, "signer.sock")
+	l, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	defer l.Close()
+	server := keyconf.ProcessServer{
+		IA:   ia,
+		Ring: keyconf.LoadingRing{Dir: tmpDir, IA: ia},
+	}
+	go server.Serve(l)
+
+	t.Run("sign", func(t *testing.T) {
+		ring := keyconf.ProcessRing{Socket: socket, IA: ia}
+		signer, err := ring.Signer(keyconf.ASSigningKey, 2)
+		require.NoError(t, err)
+		meta := signer.Meta()
+		assert.Equal(t, key.ID, meta.ID)
+		assert.Equal(t, key.Algorithm, meta.Algorithm)
+		assert.Equal(t, key.Validity, meta.Validity)
+		assert.Empty(t, meta.Bytes)
+		rawPub, err := signer.PublicKey()
+		require.NoError(t, err)
+		assert.Equal(t, []byte(pub), rawPub)
+
+		sig, err := signer.Sign([]byte("message"))
+		require.NoError(t, err)
+		assert.NoError(t, scrypto.Verify([]byte("message"), sig, pub, scrypto.ECDSAP256))
+	})
+	t.Run("unknown key", func(t *testing.T) {
+		ring := keyconf.ProcessRing{Socket: socket, IA: ia}
+		_, err := ring.Signer(keyconf.ASSigningKey, 3)
+		assert.Error(t, err)
+	})
+	t.Run("wrong IA", func(t *testing.T) {
+		ring := keyconf.ProcessRing{Socket: socket, IA: xtest.MustParseIA("1-ff00:0:111")}
+		_, err := ring.Signer(keyconf.ASSigningKey, 2)
+		assert.Error(t, err)
+	})
+	t.Run("no process", func(t *testing.T) {
+		ring := keyconf.ProcessRing{Socket: filepath.Join(tmpDir, "none.sock"), IA: ia}
+		_, err := ring.Signer(keyconf.ASSigningKey, 2)
+		assert.Error(t, err)
+	})
+}
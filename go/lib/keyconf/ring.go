@@ -21,6 +21,8 @@ import (
 	"github.com/scionproto/scion/go/lib/scrypto"
 )
 
+var _ SignerRing = LoadingRing{}
+
 // LoadingRing loads the private keys on-demand from the file system.
 type LoadingRing struct {
 	Dir string
@@ -38,3 +40,13 @@ func (r LoadingRing) PrivateKey(usage Usage, version scrypto.KeyVersion) (Key, e
 	file := filepath.Join(r.Dir, PrivateKeyFile(usage, version))
 	return LoadKeyFromFile(file, PrivateKey, id)
 }
+
+// Signer returns a signer for the private key with the given usage and
+// version. The key is loaded from the file system.
+func (r LoadingRing) Signer(usage Usage, version scrypto.KeyVersion) (Signer, error) {
+	key, err := r.PrivateKey(usage, version)
+	if err != nil {
+		return nil, err
+	}
+	return KeySigner{Key: key}, nil
+}
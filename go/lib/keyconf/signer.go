@@ -0,0 +1,68 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyconf
+
+import (
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// Signer creates signatures with a private key. Depending on the backend, the
+// private key material is not accessible to the caller.
+type Signer interface {
+	// Meta returns the private key metadata. The key material is not set.
+	Meta() Key
+	// PublicKey returns the public key that corresponds to the private key.
+	PublicKey() ([]byte, error)
+	// Sign signs the input with the private key.
+	Sign(input []byte) ([]byte, error)
+}
+
+// SignerRing provides signers for different private keys.
+type SignerRing interface {
+	// Signer returns the signer for the private key with the given usage and
+	// version. If the key is not in the key ring, an error is returned.
+	Signer(usage Usage, version scrypto.KeyVersion) (Signer, error)
+}
+
+var _ Signer = KeySigner{}
+
+// KeySigner is a signer that holds the private key material in memory.
+type KeySigner struct {
+	Key Key
+}
+
+// Meta returns the private key metadata.
+func (s KeySigner) Meta() Key {
+	meta := s.Key
+	meta.Bytes = nil
+	return meta
+}
+
+// PublicKey computes the public key from the private key.
+func (s KeySigner) PublicKey() ([]byte, error) {
+	if s.Key.Type != PrivateKey {
+		return nil, serrors.WithCtx(ErrUnsupportedType, "type", s.Key.Type)
+	}
+	return scrypto.GetPubKey(s.Key.Bytes, s.Key.Algorithm)
+}
+
+// Sign signs the input with the private key.
+func (s KeySigner) Sign(input []byte) ([]byte, error) {
+	if s.Key.Type != PrivateKey {
+		return nil, serrors.WithCtx(ErrUnsupportedType, "type", s.Key.Type)
+	}
+	return scrypto.Sign(input, s.Key.Bytes, s.Key.Algorithm)
+}
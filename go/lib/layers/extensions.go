@@ -18,6 +18,8 @@ import (
 	"fmt"
 
 	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/spse"
+	"github.com/scionproto/scion/go/lib/spse/scmp_auth"
 )
 
 func ExtensionFactory(class common.L4ProtocolType, extension *Extension) (common.Extension, error) {
@@ -35,6 +37,8 @@ func ExtensionFactory(class common.L4ProtocolType, extension *Extension) (common
 		switch extension.Type {
 		case common.ExtnE2EDebugType.Type:
 			return NewExtnE2EDebugFromLayer(extension)
+		case common.ExtnSCIONPacketSecurityType.Type:
+			return newSPSEFromLayer(extension)
 		default:
 			return NewExtnUnknownFromLayer(common.End2EndClass, extension)
 		}
@@ -43,6 +47,16 @@ func ExtensionFactory(class common.L4ProtocolType, extension *Extension) (common
 	}
 }
 
+// newSPSEFromLayer parses the SCIONPacketSecurity extension. Only the
+// SCMPAuthDRKey security mode is decoded, all other modes are returned as
+// unknown extensions.
+func newSPSEFromLayer(extension *Extension) (common.Extension, error) {
+	if len(extension.Data) > 0 && spse.SecMode(extension.Data[0]) == spse.ScmpAuthDRKey {
+		return scmp_auth.DRKeyExtnFromRaw(extension.Data)
+	}
+	return NewExtnUnknownFromLayer(common.End2EndClass, extension)
+}
+
 var _ common.Extension = (*ExtnOHP)(nil)
 
 type ExtnOHP struct{}
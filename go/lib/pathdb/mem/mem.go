@@ -0,0 +1,638 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mem contains an in-memory backend for the PathDB. The content of the
+// database is lost when the process terminates.
+package mem
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/pathdb"
+	"github.com/scionproto/scion/go/lib/pathdb/query"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/proto"
+)
+
+var noInsertion = pathdb.InsertStats{}
+
+var errNoDB = serrors.New("No database open")
+
+var _ pathdb.PathDB = (*Backend)(nil)
+
+// Backend is an in-memory path database.
+type Backend struct {
+	*executor
+}
+
+// New returns a new, empty in-memory backend.
+func New() *Backend {
+	return &Backend{
+		executor: &executor{
+			state:     newState(),
+			closedErr: errNoDB,
+		},
+	}
+}
+
+// Close drops the content of the database. All subsequent operations fail.
+func (b *Backend) Close() error {
+	b.Lock()
+	defer b.Unlock()
+	b.state = nil
+	return nil
+}
+
+// SetMaxOpenConns is a no-op for the in-memory backend.
+func (b *Backend) SetMaxOpenConns(_ int) {}
+
+// SetMaxIdleConns is a no-op for the in-memory backend.
+func (b *Backend) SetMaxIdleConns(_ int) {}
+
+// BeginTransaction starts a transaction. The transaction operates on a
+// snapshot of the database. The write operations of the transaction are
+// applied to the database on commit, in the order they were executed in the
+// transaction.
+func (b *Backend) BeginTransaction(ctx context.Context,
+	_ *sql.TxOptions) (pathdb.Transaction, error) {
+
+	b.RLock()
+	defer b.RUnlock()
+	if err := b.check(ctx); err != nil {
+		return nil, err
+	}
+	return &transaction{
+		executor: &executor{
+			state:     b.state.clone(),
+			closedErr: sql.ErrTxDone,
+			logOps:    true,
+		},
+		backend: b,
+	}, nil
+}
+
+var _ pathdb.Transaction = (*transaction)(nil)
+
+type transaction struct {
+	*executor
+	backend *Backend
+}
+
+func (tx *transaction) Commit() error {
+	tx.Lock()
+	defer tx.Unlock()
+	if tx.state == nil {
+		return sql.ErrTxDone
+	}
+	tx.state = nil
+	tx.backend.Lock()
+	defer tx.backend.Unlock()
+	if tx.backend.state == nil {
+		return errNoDB
+	}
+	for _, op := range tx.ops {
+		op(tx.backend.state)
+	}
+	tx.ops = nil
+	return nil
+}
+
+func (tx *transaction) Rollback() error {
+	tx.Lock()
+	defer tx.Unlock()
+	if tx.state == nil {
+		return sql.ErrTxDone
+	}
+	tx.state, tx.ops = nil, nil
+	return nil
+}
+
+// segEntry is a stored path segment. Entries are never modified after they
+// have been added to the state, updates replace the entry. This allows
+// transactions to share entries with the database.
+type segEntry struct {
+	segID      common.RawBytes
+	fullID     common.RawBytes
+	packed     common.RawBytes
+	infoTS     time.Time
+	maxExpiry  time.Time
+	lastUpdate time.Time
+	// seq orders entries with the same lastUpdate by modification order.
+	seq      uint64
+	start    addr.IA
+	end      addr.IA
+	types    []proto.PathSegType
+	hpCfgIDs []query.HPCfgID
+	intfs    map[query.IntfSpec]struct{}
+}
+
+type nqKey struct {
+	src    addr.IA
+	dst    addr.IA
+	policy string
+}
+
+type state struct {
+	segs      map[string]*segEntry
+	nextQuery map[nqKey]time.Time
+	seq       uint64
+}
+
+func newState() *state {
+	return &state{
+		segs:      make(map[string]*segEntry),
+		nextQuery: make(map[nqKey]time.Time),
+	}
+}
+
+func (s *state) clone() *state {
+	c := &state{
+		segs:      make(map[string]*segEntry, len(s.segs)),
+		nextQuery: make(map[nqKey]time.Time, len(s.nextQuery)),
+		seq:       s.seq,
+	}
+	for k, v := range s.segs {
+		c.segs[k] = v
+	}
+	for k, v := range s.nextQuery {
+		c.nextQuery[k] = v
+	}
+	return c
+}
+
+func (s *state) nextSeq() uint64 {
+	s.seq++
+	return s.seq
+}
+
+var _ pathdb.ReadWrite = (*executor)(nil)
+
+type executor struct {
+	sync.RWMutex
+	state *state
+	// closedErr is returned when the state is no longer available.
+	closedErr error
+	// logOps indicates that write operations are recorded in ops.
+	logOps bool
+	ops    []func(*state)
+}
+
+func (e *executor) check(ctx context.Context) error {
+	if e.state == nil {
+		return e.closedErr
+	}
+	return ctx.Err()
+}
+
+// write applies the operation to the state. The operation must not fail and
+// must not depend on anything but the state, such that it can be replayed on
+// commit.
+func (e *executor) write(ctx context.Context, op func(*state)) error {
+	e.Lock()
+	defer e.Unlock()
+	if err := e.check(ctx); err != nil {
+		return err
+	}
+	op(e.state)
+	if e.logOps {
+		e.ops = append(e.ops, op)
+	}
+	return nil
+}
+
+func (e *executor) Insert(ctx context.Context, segMeta *seg.Meta) (pathdb.InsertStats, error) {
+	return e.InsertWithHPCfgIDs(ctx, segMeta, []*query.HPCfgID{&query.NullHpCfgID})
+}
+
+func (e *executor) InsertWithHPCfgIDs(ctx context.Context, segMeta *seg.Meta,
+	hpCfgIDs []*query.HPCfgID) (pathdb.InsertStats, error) {
+
+	newEntry, err := newSegEntry(segMeta, hpCfgIDs)
+	if err != nil {
+		return noInsertion, err
+	}
+	now := time.Now()
+	var stats pathdb.InsertStats
+	err = e.write(ctx, func(s *state) {
+		stats = noInsertion
+		cur, ok := s.segs[string(newEntry.segID)]
+		if !ok {
+			entry := *newEntry
+			entry.lastUpdate, entry.seq = now, s.nextSeq()
+			s.segs[string(entry.segID)] = &entry
+			stats.Inserted = 1
+			return
+		}
+		// Only update if the new segment is more recent.
+		if !newEntry.infoTS.After(cur.infoTS) {
+			return
+		}
+		entry := *newEntry
+		entry.lastUpdate, entry.seq = now, s.nextSeq()
+		entry.types = addTypes(cur.types, newEntry.types...)
+		entry.hpCfgIDs = addHPCfgIDs(cur.hpCfgIDs, newEntry.hpCfgIDs...)
+		s.segs[string(entry.segID)] = &entry
+		stats.Updated = 1
+	})
+	if err != nil {
+		return noInsertion, err
+	}
+	return stats, nil
+}
+
+func newSegEntry(segMeta *seg.Meta, hpCfgIDs []*query.HPCfgID) (*segEntry, error) {
+	pseg := segMeta.Segment
+	segID, err := pseg.ID()
+	if err != nil {
+		return nil, err
+	}
+	fullID, err := pseg.FullId()
+	if err != nil {
+		return nil, err
+	}
+	packed, err := pseg.Pack()
+	if err != nil {
+		return nil, err
+	}
+	info, err := pseg.InfoF()
+	if err != nil {
+		return nil, err
+	}
+	intfs, err := interfaces(pseg.ASEntries)
+	if err != nil {
+		return nil, err
+	}
+	entry := &segEntry{
+		segID:     segID,
+		fullID:    fullID,
+		packed:    packed,
+		infoTS:    info.Timestamp(),
+		maxExpiry: pseg.MaxExpiry(),
+		start:     pseg.FirstIA(),
+		end:       pseg.LastIA(),
+		types:     []proto.PathSegType{segMeta.Type},
+		intfs:     intfs,
+	}
+	for _, hpCfgID := range hpCfgIDs {
+		entry.hpCfgIDs = addHPCfgIDs(entry.hpCfgIDs, *hpCfgID)
+	}
+	return entry, nil
+}
+
+// interfaces returns the interfaces of the segment that are indexed. As in the
+// SQL backends, the ingress interface of every hop entry is indexed, and the
+// egress interface only for the first hop entry in an AS entry.
+func interfaces(ases []*seg.ASEntry) (map[query.IntfSpec]struct{}, error) {
+	intfs := make(map[query.IntfSpec]struct{})
+	for _, as := range ases {
+		ia := as.IA()
+		for idx, hop := range as.HopEntries {
+			hof, err := hop.HopField()
+			if err != nil {
+				return nil, common.NewBasicError("Failed to extract hop field", err)
+			}
+			if hof.ConsIngress != 0 {
+				intfs[query.IntfSpec{IA: ia, IfID: hof.ConsIngress}] = struct{}{}
+			}
+			if idx == 0 && hof.ConsEgress != 0 {
+				intfs[query.IntfSpec{IA: ia, IfID: hof.ConsEgress}] = struct{}{}
+			}
+		}
+	}
+	return intfs, nil
+}
+
+// addTypes returns a new slice with the types added that are not yet
+// contained in cur.
+func addTypes(cur []proto.PathSegType, types ...proto.PathSegType) []proto.PathSegType {
+	res := append([]proto.PathSegType(nil), cur...)
+	for _, t := range types {
+		if !containsType(res, t) {
+			res = append(res, t)
+		}
+	}
+	return res
+}
+
+func containsType(types []proto.PathSegType, t proto.PathSegType) bool {
+	for _, o := range types {
+		if o == t {
+			return true
+		}
+	}
+	return false
+}
+
+// addHPCfgIDs returns a new slice with the hidden path config IDs added that
+// are not yet contained in cur.
+func addHPCfgIDs(cur []query.HPCfgID, ids ...query.HPCfgID) []query.HPCfgID {
+	res := append([]query.HPCfgID(nil), cur...)
+	for _, id := range ids {
+		if !containsHPCfgID(res, &id) {
+			res = append(res, id)
+		}
+	}
+	return res
+}
+
+func containsHPCfgID(ids []query.HPCfgID, id *query.HPCfgID) bool {
+	for i := range ids {
+		if ids[i].Equal(id) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *executor) Delete(ctx context.Context, params *query.Params) (int, error) {
+	var deleted int
+	err := e.write(ctx, func(s *state) {
+		deleted = 0
+		for k, entry := range s.segs {
+			if matches(entry, params) {
+				delete(s.segs, k)
+				deleted++
+			}
+		}
+	})
+	return deleted, err
+}
+
+func (e *executor) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	var deleted int
+	err := e.write(ctx, func(s *state) {
+		deleted = 0
+		for k, entry := range s.segs {
+			if entry.maxExpiry.Unix() < now.Unix() {
+				delete(s.segs, k)
+				deleted++
+			}
+		}
+	})
+	return deleted, err
+}
+
+func (e *executor) Get(ctx context.Context, params *query.Params) (query.Results, error) {
+	e.RLock()
+	defer e.RUnlock()
+	if err := e.check(ctx); err != nil {
+		return nil, err
+	}
+	var res query.Results
+	for _, entry := range e.sorted() {
+		if !matches(entry, params) {
+			continue
+		}
+		r, err := result(entry, params)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, r)
+	}
+	return res, nil
+}
+
+func (e *executor) GetAll(ctx context.Context) (<-chan query.ResultOrErr, error) {
+	// Since we have everything in memory anyway we just fill the channel at the start.
+	e.RLock()
+	defer e.RUnlock()
+	if err := e.check(ctx); err != nil {
+		return nil, err
+	}
+	entries := e.sorted()
+	resCh := make(chan query.ResultOrErr, len(entries))
+	defer close(resCh)
+	for _, entry := range entries {
+		r, err := result(entry, nil)
+		if err != nil {
+			resCh <- query.ResultOrErr{Err: err}
+			break
+		}
+		resCh <- query.ResultOrErr{Result: r}
+	}
+	return resCh, nil
+}
+
+// sorted returns the stored entries sorted by modification order.
+func (e *executor) sorted() []*segEntry {
+	entries := make([]*segEntry, 0, len(e.state.segs))
+	for _, entry := range e.state.segs {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].lastUpdate.Equal(entries[j].lastUpdate) {
+			return entries[i].lastUpdate.Before(entries[j].lastUpdate)
+		}
+		return entries[i].seq < entries[j].seq
+	})
+	return entries
+}
+
+// result creates the query result for the entry. If the params restrict the
+// segment types or hidden path config IDs, only the matching ones are
+// considered.
+func result(entry *segEntry, params *query.Params) (*query.Result, error) {
+	pseg, err := seg.NewSegFromRaw(entry.packed)
+	if err != nil {
+		return nil, common.NewBasicError("Error unmarshalling segment", err)
+	}
+	r := &query.Result{
+		Seg:        pseg,
+		LastUpdate: entry.lastUpdate,
+		Type:       entry.types[0],
+	}
+	if params != nil && len(params.SegTypes) > 0 {
+		for _, t := range entry.types {
+			if containsType(params.SegTypes, t) {
+				r.Type = t
+				break
+			}
+		}
+	}
+	filterHPCfgIDs := params != nil && len(params.HpCfgIDs) > 0
+	for i := range entry.hpCfgIDs {
+		hpCfgID := entry.hpCfgIDs[i]
+		if filterHPCfgIDs && !matchesHPCfgID(params.HpCfgIDs, &hpCfgID) {
+			continue
+		}
+		r.HpCfgIDs = append(r.HpCfgIDs, &hpCfgID)
+	}
+	return r, nil
+}
+
+// matches checks whether the entry matches the params. A nil params matches
+// all entries.
+func matches(entry *segEntry, params *query.Params) bool {
+	if params == nil {
+		return true
+	}
+	if len(params.SegIDs) > 0 && !matchesSegID(params.SegIDs, entry.segID) {
+		return false
+	}
+	if len(params.SegTypes) > 0 && !matchesAnyType(params.SegTypes, entry.types) {
+		return false
+	}
+	if len(params.HpCfgIDs) > 0 && !matchesAnyHPCfgID(params.HpCfgIDs, entry.hpCfgIDs) {
+		return false
+	}
+	if len(params.Intfs) > 0 && !matchesIntf(params.Intfs, entry.intfs) {
+		return false
+	}
+	if len(params.StartsAt) > 0 && !matchesIA(params.StartsAt, entry.start) {
+		return false
+	}
+	if len(params.EndsAt) > 0 && !matchesIA(params.EndsAt, entry.end) {
+		return false
+	}
+	if params.MinLastUpdate != nil && !entry.lastUpdate.After(*params.MinLastUpdate) {
+		return false
+	}
+	return true
+}
+
+func matchesSegID(segIDs []common.RawBytes, segID common.RawBytes) bool {
+	for _, id := range segIDs {
+		if bytes.Equal(id, segID) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesAnyType(filter, types []proto.PathSegType) bool {
+	for _, t := range types {
+		if containsType(filter, t) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesHPCfgID(filter []*query.HPCfgID, id *query.HPCfgID) bool {
+	for _, f := range filter {
+		if f.Equal(id) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesAnyHPCfgID(filter []*query.HPCfgID, ids []query.HPCfgID) bool {
+	for i := range ids {
+		if matchesHPCfgID(filter, &ids[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesIntf(filter []*query.IntfSpec, intfs map[query.IntfSpec]struct{}) bool {
+	for _, spec := range filter {
+		if _, ok := intfs[*spec]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesIA checks whether ia matches any of the filter entries. A filter
+// entry with a wildcard AS matches all IAs in the ISD.
+func matchesIA(filter []addr.IA, ia addr.IA) bool {
+	for _, f := range filter {
+		if f.I == ia.I && (f.A == 0 || f.A == ia.A) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *executor) InsertNextQuery(ctx context.Context, src, dst addr.IA, policy pathdb.PolicyHash,
+	nextQuery time.Time) (bool, error) {
+
+	if policy == nil {
+		policy = pathdb.NoPolicy
+	}
+	k := nqKey{src: src, dst: dst, policy: string(policy)}
+	var updated bool
+	err := e.write(ctx, func(s *state) {
+		cur, ok := s.nextQuery[k]
+		updated = !ok || nextQuery.After(cur)
+		if updated {
+			s.nextQuery[k] = nextQuery
+		}
+	})
+	if err != nil {
+		return false, err
+	}
+	return updated, nil
+}
+
+func (e *executor) GetNextQuery(ctx context.Context, src, dst addr.IA,
+	policy pathdb.PolicyHash) (time.Time, error) {
+
+	e.RLock()
+	defer e.RUnlock()
+	if err := e.check(ctx); err != nil {
+		return time.Time{}, err
+	}
+	if policy == nil {
+		policy = pathdb.NoPolicy
+	}
+	return e.state.nextQuery[nqKey{src: src, dst: dst, policy: string(policy)}], nil
+}
+
+func (e *executor) DeleteExpiredNQ(ctx context.Context, now time.Time) (int, error) {
+	var deleted int
+	err := e.write(ctx, func(s *state) {
+		deleted = 0
+		for k, nextQuery := range s.nextQuery {
+			if nextQuery.Before(now) {
+				delete(s.nextQuery, k)
+				deleted++
+			}
+		}
+	})
+	return deleted, err
+}
+
+func (e *executor) DeleteNQ(ctx context.Context, src, dst addr.IA,
+	policy pathdb.PolicyHash) (int, error) {
+
+	var deleted int
+	err := e.write(ctx, func(s *state) {
+		deleted = 0
+		for k := range s.nextQuery {
+			if !src.IsZero() && !k.src.Equal(src) {
+				continue
+			}
+			if !dst.IsZero() && !k.dst.Equal(dst) {
+				continue
+			}
+			if policy != nil && k.policy != string(policy) {
+				continue
+			}
+			delete(s.nextQuery, k)
+			deleted++
+		}
+	})
+	return deleted, err
+}
@@ -0,0 +1,79 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mem
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/pathdb/pathdbtest"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+var _ pathdbtest.TestablePathDB = (*TestPathDB)(nil)
+
+type TestPathDB struct {
+	*Backend
+}
+
+func (b *TestPathDB) Prepare(_ *testing.T, _ context.Context) {
+	b.Backend = New()
+}
+
+func TestPathDBSuite(t *testing.T) {
+	tdb := &TestPathDB{}
+	pathdbtest.TestPathDB(t, tdb)
+}
+
+func TestTransactionCommit(t *testing.T) {
+	ctx, cancelF := context.WithTimeout(context.Background(), time.Second)
+	defer cancelF()
+	ia110 := xtest.MustParseIA("1-ff00:0:110")
+	ia120 := xtest.MustParseIA("1-ff00:0:120")
+	now := time.Now()
+
+	db := New()
+	tx, err := db.BeginTransaction(ctx, nil)
+	require.NoError(t, err)
+	_, err = tx.InsertNextQuery(ctx, ia110, ia110, nil, now)
+	require.NoError(t, err)
+	// Writes to the database during the transaction are not visible in the
+	// transaction, and are not lost on commit.
+	_, err = db.InsertNextQuery(ctx, ia110, ia120, nil, now)
+	require.NoError(t, err)
+	nq, err := tx.GetNextQuery(ctx, ia110, ia120, nil)
+	require.NoError(t, err)
+	assert.Zero(t, nq)
+	nq, err = db.GetNextQuery(ctx, ia110, ia110, nil)
+	require.NoError(t, err)
+	assert.Zero(t, nq)
+
+	require.NoError(t, tx.Commit())
+	nq, err = db.GetNextQuery(ctx, ia110, ia110, nil)
+	require.NoError(t, err)
+	assert.Equal(t, now, nq)
+	nq, err = db.GetNextQuery(ctx, ia110, ia120, nil)
+	require.NoError(t, err)
+	assert.Equal(t, now, nq)
+	_, err = tx.GetNextQuery(ctx, ia110, ia110, nil)
+	assert.Equal(t, sql.ErrTxDone, err)
+	assert.Equal(t, sql.ErrTxDone, tx.Commit())
+	assert.Equal(t, sql.ErrTxDone, tx.Rollback())
+}
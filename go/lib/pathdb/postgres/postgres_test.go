@@ -0,0 +1,64 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/infra/modules/db"
+	"github.com/scionproto/scion/go/lib/pathdb/pathdbtest"
+)
+
+// connEnv is the environment variable that contains the connection string of
+// the PostgreSQL database used for testing. The tests are skipped if it is not
+// set. The content of the database is dropped.
+const connEnv = "SCION_TEST_POSTGRES"
+
+var _ pathdbtest.TestablePathDB = (*TestPathDB)(nil)
+
+type TestPathDB struct {
+	*Backend
+	conn string
+}
+
+func (b *TestPathDB) Prepare(t *testing.T, _ context.Context) {
+	var err error
+	if b.Backend == nil {
+		// Make sure the schema versions table exists.
+		b.Backend, err = New(b.conn)
+		require.NoError(t, err)
+	}
+	_, err = b.db.Exec(`DROP TABLE IF EXISTS Segments, IntfToSeg, SegTypes, HpCfgIds,
+		NextQuery CASCADE`)
+	require.NoError(t, err)
+	_, err = b.db.Exec(`DELETE FROM `+db.SchemaVersionsTable+` WHERE name=$1`, Name)
+	require.NoError(t, err)
+	require.NoError(t, b.Close())
+	b.Backend, err = New(b.conn)
+	require.NoError(t, err)
+}
+
+func TestPathDBSuite(t *testing.T) {
+	conn := os.Getenv(connEnv)
+	if conn == "" {
+		t.Skipf("%s not set", connEnv)
+	}
+	tdb := &TestPathDB{conn: conn}
+	pathdbtest.TestPathDB(t, tdb)
+}
@@ -0,0 +1,74 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file contains the PostgreSQL schema of the PathDB.
+
+package postgres
+
+import "github.com/scionproto/scion/go/lib/infra/modules/db"
+
+// Name identifies the PathDB schema in the schema versions table.
+const Name = "pathdb"
+
+// Migrations are the schema migrations of the PostgreSQL backend. Existing
+// migrations must never be changed, schema changes are appended as new
+// migrations.
+var Migrations = db.Migrations{
+	{
+		Version:     1,
+		Description: "Initial schema",
+		Up: `CREATE TABLE Segments(
+			RowID BIGSERIAL PRIMARY KEY,
+			SegID BYTEA UNIQUE NOT NULL,
+			FullID BYTEA UNIQUE NOT NULL,
+			LastUpdated BIGINT NOT NULL,
+			InfoTs BIGINT NOT NULL,
+			Segment BYTEA NOT NULL,
+			MaxExpiry BIGINT NOT NULL,
+			StartIsdID BIGINT NOT NULL,
+			StartAsID BIGINT NOT NULL,
+			EndIsdID BIGINT NOT NULL,
+			EndAsID BIGINT NOT NULL
+		);
+		CREATE TABLE IntfToSeg(
+			IsdID BIGINT NOT NULL,
+			AsID BIGINT NOT NULL,
+			IntfID BIGINT NOT NULL,
+			SegRowID BIGINT NOT NULL REFERENCES Segments(RowID) ON DELETE CASCADE
+		);
+		CREATE INDEX IntfToSegRowIDIndex ON IntfToSeg(SegRowID);
+		CREATE INDEX IntfToSegIntfIndex ON IntfToSeg(IsdID, AsID, IntfID);
+		CREATE TABLE SegTypes(
+			SegRowID BIGINT NOT NULL REFERENCES Segments(RowID) ON DELETE CASCADE,
+			Type INTEGER NOT NULL,
+			PRIMARY KEY (SegRowID, Type)
+		);
+		CREATE TABLE HpCfgIds(
+			SegRowID BIGINT NOT NULL REFERENCES Segments(RowID) ON DELETE CASCADE,
+			IsdID BIGINT NOT NULL,
+			AsID BIGINT NOT NULL,
+			CfgID BIGINT NOT NULL,
+			PRIMARY KEY (SegRowID, IsdID, AsID, CfgID)
+		);
+		CREATE TABLE NextQuery(
+			SrcIsdID BIGINT NOT NULL,
+			SrcAsID BIGINT NOT NULL,
+			DstIsdID BIGINT NOT NULL,
+			DstAsID BIGINT NOT NULL,
+			Policy BYTEA NOT NULL,
+			NextQuery BIGINT NOT NULL,
+			PRIMARY KEY (SrcIsdID, SrcAsID, DstIsdID, DstAsID, Policy)
+		);`,
+	},
+}
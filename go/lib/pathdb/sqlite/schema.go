@@ -17,62 +17,87 @@
 
 package sqlite
 
+import "github.com/scionproto/scion/go/lib/infra/modules/db"
+
+// Migrations are the schema migrations of the SQLite backend. The first
+// migration is the schema at the time migrations were introduced. Existing
+// migrations must never be changed, schema changes are appended as new
+// migrations.
+var Migrations = db.Migrations{
+	{
+		Version:     8,
+		Description: "Initial schema",
+		Up: `CREATE TABLE Segments(
+			RowID INTEGER PRIMARY KEY,
+			SegID DATA UNIQUE NOT NULL,
+			FullID DATA UNIQUE NOT NULL,
+			LastUpdated INTEGER NOT NULL,
+			InfoTs INTEGER NOT NULL,
+			Segment DATA NOT NULL,
+			MaxExpiry INTEGER NOT NULL,
+			StartIsdID INTEGER NOT NULL,
+			StartAsID INTEGER NOT NULL,
+			EndIsdID INTEGER NOT NULL,
+			EndAsID INTEGER NOT NULL
+		);
+		CREATE TABLE IntfToSeg(
+			IsdID INTEGER NOT NULL,
+			AsID INTEGER NOT NULL,
+			IntfID INTEGER NOT NULL,
+			SegRowID INTEGER NOT NULL,
+			FOREIGN KEY (SegRowID) REFERENCES Segments(RowID) ON DELETE CASCADE
+		);
+		CREATE INDEX RowIdIndex ON IntfToSeg(SegRowID);
+		CREATE TABLE SegTypes(
+			SegRowID INTEGER NOT NULL,
+			Type INTEGER NOT NULL,
+			PRIMARY KEY (SegRowID, Type) ON CONFLICT IGNORE,
+			FOREIGN KEY (SegRowID) REFERENCES Segments(RowID) ON DELETE CASCADE
+		);
+		CREATE TABLE HpCfgIds(
+			SegRowID INTEGER NOT NULL,
+			IsdID INTEGER NOT NULL,
+			AsID INTEGER NOT NULL,
+			CfgID INTEGER NOT NULL,
+			PRIMARY KEY (SegRowID, IsdID, AsID, CfgID) ON CONFLICT IGNORE,
+			FOREIGN KEY (SegRowID) REFERENCES Segments(RowID) ON DELETE CASCADE
+		);
+		CREATE TABLE NextQuery(
+			RowID INTEGER PRIMARY KEY,
+			SrcIsdID INTEGER NOT NULL,
+			SrcAsID INTEGER NOT NULL,
+			DstIsdID INTEGER NOT NULL,
+			DstAsID INTEGER NOT NULL,
+			Policy DATA NOT NULL,
+			NextQuery INTEGER NOT NULL,
+			UNIQUE(SrcIsdID, SrcAsID, DstIsdID, DstAsID, Policy) ON CONFLICT REPLACE
+		);`,
+	},
+	{
+		// The revocations table is used by the SQLite revocation cache if it
+		// shares the database with the path database.
+		Version:     9,
+		Description: "Add revocations table",
+		Up: `CREATE TABLE Revocations(
+			IsdID INTEGER NOT NULL,
+			AsID INTEGER NOT NULL,
+			IfID INTEGER NOT NULL,
+			IssuingTime INTEGER NOT NULL,
+			Expiration INTEGER NOT NULL,
+			RawSignedRev DATA NOT NULL,
+			PRIMARY KEY (IsdID, AsID, IfID)
+		);
+		CREATE INDEX RevocationsExpiration ON Revocations(Expiration);`,
+	},
+}
+
 const (
-	// SchemaVersion is the version of the SQLite schema understood by this backend.
-	// Whenever changes to the schema are made, this version number should be increased
-	// to prevent data corruption between incompatible database schemas.
-	SchemaVersion = 8
-	// Schema is the SQLite database layout.
-	Schema = `CREATE TABLE Segments(
-		RowID INTEGER PRIMARY KEY,
-		SegID DATA UNIQUE NOT NULL,
-		FullID DATA UNIQUE NOT NULL,
-		LastUpdated INTEGER NOT NULL,
-		InfoTs INTEGER NOT NULL,
-		Segment DATA NOT NULL,
-		MaxExpiry INTEGER NOT NULL,
-		StartIsdID INTEGER NOT NULL,
-		StartAsID INTEGER NOT NULL,
-		EndIsdID INTEGER NOT NULL,
-		EndAsID INTEGER NOT NULL
-	);
-	CREATE TABLE IntfToSeg(
-		IsdID INTEGER NOT NULL,
-		AsID INTEGER NOT NULL,
-		IntfID INTEGER NOT NULL,
-		SegRowID INTEGER NOT NULL,
-		FOREIGN KEY (SegRowID) REFERENCES Segments(RowID) ON DELETE CASCADE
-	);
-	CREATE INDEX RowIdIndex ON IntfToSeg(SegRowID);
-	CREATE TABLE SegTypes(
-		SegRowID INTEGER NOT NULL,
-		Type INTEGER NOT NULL,
-		PRIMARY KEY (SegRowID, Type) ON CONFLICT IGNORE,
-		FOREIGN KEY (SegRowID) REFERENCES Segments(RowID) ON DELETE CASCADE
-	);
-	CREATE TABLE HpCfgIds(
-		SegRowID INTEGER NOT NULL,
-		IsdID INTEGER NOT NULL,
-		AsID INTEGER NOT NULL,
-		CfgID INTEGER NOT NULL,
-		PRIMARY KEY (SegRowID, IsdID, AsID, CfgID) ON CONFLICT IGNORE,
-		FOREIGN KEY (SegRowID) REFERENCES Segments(RowID) ON DELETE CASCADE
-	);
-	CREATE TABLE NextQuery(
-		RowID INTEGER PRIMARY KEY,
-		SrcIsdID INTEGER NOT NULL,
-		SrcAsID INTEGER NOT NULL,
-		DstIsdID INTEGER NOT NULL,
-		DstAsID INTEGER NOT NULL,
-		Policy DATA NOT NULL,
-		NextQuery INTEGER NOT NULL,
-		UNIQUE(SrcIsdID, SrcAsID, DstIsdID, DstAsID, Policy) ON CONFLICT REPLACE
-	);`
-	SegmentsTable  = "Segments"
-	IntfToSegTable = "IntfToSeg"
-	StartsAtTable  = "StartsAt"
-	EndsAtTable    = "EndsAt"
-	SegTypesTable  = "SegTypes"
-	HpCfgIdsTable  = "HpCfgIds"
-	NextQueryTable = "NextQuery"
+	SegmentsTable    = "Segments"
+	IntfToSegTable   = "IntfToSeg"
+	StartsAtTable    = "StartsAt"
+	EndsAtTable      = "EndsAt"
+	SegTypesTable    = "SegTypes"
+	HpCfgIdsTable    = "HpCfgIds"
+	NextQueryTable   = "NextQuery"
+	RevocationsTable = "Revocations"
 )
@@ -0,0 +1,333 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathpol
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+var (
+	// ErrCircularExtends indicates that a policy (indirectly) extends itself.
+	ErrCircularExtends = serrors.New("circular policy extension")
+	// ErrExtendedNotFound indicates that an extended policy does not exist.
+	ErrExtendedNotFound = serrors.New("extended policy could not be found")
+)
+
+// MergeMode defines how an attribute of an extended policy is combined with
+// the same attribute of the extending policy.
+type MergeMode string
+
+const (
+	// MergeReplace uses the attribute of the extended policy only if the
+	// extending policy does not set it. This is the default.
+	MergeReplace MergeMode = "replace"
+	// MergeAppend appends the ACL entries of the extended policy to the ACL of
+	// the extending policy. Only the default entry of the extending policy is
+	// kept.
+	MergeAppend MergeMode = "append"
+	// MergeIntersect intersects the sequence of the extended policy with the
+	// sequence of the extending policy.
+	MergeIntersect MergeMode = "intersect"
+)
+
+// Merge defines the merge modes of the attributes of an extending policy.
+// Attributes without a merge mode are replaced.
+type Merge struct {
+	// ACL is the merge mode of the ACL, either replace or append.
+	ACL MergeMode `json:"acl,omitempty"`
+	// Sequence is the merge mode of the sequence, either replace or
+	// intersect.
+	Sequence MergeMode `json:"sequence,omitempty"`
+}
+
+func (m *Merge) validate() error {
+	if m == nil {
+		return nil
+	}
+	switch m.ACL {
+	case "", MergeReplace, MergeAppend:
+	default:
+		return common.NewBasicError("Invalid ACL merge mode", nil, "mode", m.ACL)
+	}
+	switch m.Sequence {
+	case "", MergeReplace, MergeIntersect:
+	default:
+		return common.NewBasicError("Invalid sequence merge mode", nil, "mode", m.Sequence)
+	}
+	return nil
+}
+
+func (m *Merge) acl() MergeMode {
+	if m == nil || m.ACL == "" {
+		return MergeReplace
+	}
+	return m.ACL
+}
+
+func (m *Merge) sequence() MergeMode {
+	if m == nil || m.Sequence == "" {
+		return MergeReplace
+	}
+	return m.Sequence
+}
+
+// Sources records from which policy the attributes of a compiled policy
+// originate.
+type Sources struct {
+	// ACL contains the source policy of each ACL entry.
+	ACL []string
+	// Sequence contains the source policies of the intersected sequences.
+	Sequence []string
+	Filters  string
+	Order    string
+	Options  string
+}
+
+// CompiledPolicy is a policy with all extended policies merged into it,
+// together with the sources of its attributes.
+type CompiledPolicy struct {
+	*Policy
+	Sources Sources
+}
+
+// Explain writes a human readable description of the compiled policy to w,
+// that lists for each rule the policy it originates from.
+func (cp *CompiledPolicy) Explain(w io.Writer) {
+	fmt.Fprintf(w, "Policy: %s\n", cp.Name)
+	if cp.ACL != nil {
+		fmt.Fprintln(w, "ACL:")
+		for i, entry := range cp.ACL.Entries {
+			fmt.Fprintf(w, "  %-40s (from %s)\n", entry, cp.Sources.ACL[i])
+		}
+	}
+	if cp.Sequence != nil && cp.Sequence.String() != "" {
+		fmt.Fprintf(w, "Sequence: %s (from %s)\n", cp.Sequence,
+			strings.Join(cp.Sources.Sequence, ", "))
+	}
+	if cp.Filters != nil {
+		fmt.Fprintf(w, "Filters: %s (from %s)\n", formatFilters(cp.Filters), cp.Sources.Filters)
+	}
+	if len(cp.Order) > 0 {
+		fmt.Fprintf(w, "Order: %v (from %s)\n", cp.Order, cp.Sources.Order)
+	}
+	if len(cp.Options) > 0 {
+		fmt.Fprintf(w, "Options: %d (from %s)\n", len(cp.Options), cp.Sources.Options)
+	}
+}
+
+func formatFilters(f *Filters) string {
+	var parts []string
+	if f.MaxHops != nil {
+		parts = append(parts, fmt.Sprintf("max_hops=%d", *f.MaxHops))
+	}
+	if f.MinMTU != nil {
+		parts = append(parts, fmt.Sprintf("min_mtu=%d", *f.MinMTU))
+	}
+	if f.MinExpiry != nil {
+		parts = append(parts, fmt.Sprintf("min_expiry=%s", f.MinExpiry))
+	}
+	if f.MaxLatency != nil {
+		parts = append(parts, fmt.Sprintf("max_latency=%s", f.MaxLatency))
+	}
+	if f.MinBandwidth != nil {
+		parts = append(parts, fmt.Sprintf("min_bandwidth=%d", *f.MinBandwidth))
+	}
+	return strings.Join(parts, " ")
+}
+
+// Compiler compiles extending policies into self-contained policies. The
+// extended policies are looked up by name in the policy map of the compiler.
+type Compiler struct {
+	policies PolicyMap
+}
+
+// NewCompiler creates a compiler for the policies in the map. The keys of the
+// map are used as the names of the policies.
+func NewCompiler(policies PolicyMap) *Compiler {
+	return &Compiler{policies: policies}
+}
+
+// Compile compiles the policy with the given name.
+func (c *Compiler) Compile(name string) (*CompiledPolicy, error) {
+	extPolicy, ok := c.policies[name]
+	if !ok {
+		return nil, serrors.WithCtx(ErrExtendedNotFound, "policy", name)
+	}
+	return c.compile(name, extPolicy, nil)
+}
+
+// CompileExt compiles the given policy, which does not need to be part of the
+// policy map of the compiler.
+func (c *Compiler) CompileExt(extPolicy *ExtPolicy) (*CompiledPolicy, error) {
+	var name string
+	if extPolicy.Policy != nil {
+		name = extPolicy.Name
+	}
+	return c.compile(name, extPolicy, nil)
+}
+
+// Lint compiles all policies of the policy map and returns the problems it
+// finds, ordered by policy name.
+func (c *Compiler) Lint() []error {
+	names := make([]string, 0, len(c.policies))
+	for name := range c.policies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var errs []error
+	for _, name := range names {
+		compiled, err := c.Compile(name)
+		if err != nil {
+			errs = append(errs, serrors.WrapStr("invalid policy", err, "policy", name))
+			continue
+		}
+		if compiled.ACL != nil && (len(compiled.ACL.Entries) == 0 ||
+			!compiled.ACL.Entries[len(compiled.ACL.Entries)-1].Rule.matchesAll()) {
+			errs = append(errs, serrors.WithCtx(ErrNoDefault, "policy", name))
+		}
+	}
+	return errs
+}
+
+// compile compiles the policy. The stack contains the names of the policies
+// that are currently being compiled and is used to detect cycles.
+func (c *Compiler) compile(name string, extPolicy *ExtPolicy,
+	stack []string) (*CompiledPolicy, error) {
+
+	for _, n := range stack {
+		if n == name {
+			return nil, serrors.WithCtx(ErrCircularExtends,
+				"cycle", strings.Join(append(stack, name), " -> "))
+		}
+	}
+	if err := extPolicy.Merge.validate(); err != nil {
+		return nil, common.NewBasicError("Invalid merge", err, "policy", name)
+	}
+	compiled := &CompiledPolicy{Policy: &Policy{Name: name}}
+	if extPolicy.Policy != nil {
+		compiled.apply(extPolicy.Policy, name)
+	}
+	stack = append(stack, name)
+	// Traverse in reverse, s.t. the last entry of the list has precedence.
+	for i := len(extPolicy.Extends) - 1; i >= 0; i-- {
+		extName := extPolicy.Extends[i]
+		extended, ok := c.policies[extName]
+		if !ok {
+			return nil, serrors.WithCtx(ErrExtendedNotFound,
+				"policy", extName, "extended_by", name)
+		}
+		parent, err := c.compile(extName, extended, stack)
+		if err != nil {
+			return nil, err
+		}
+		if err := compiled.merge(parent, extPolicy.Merge); err != nil {
+			return nil, common.NewBasicError("Unable to merge policy", err,
+				"policy", name, "extended", extName)
+		}
+	}
+	return compiled, nil
+}
+
+// apply sets the attributes of the policy as the attributes of the compiled
+// policy.
+func (cp *CompiledPolicy) apply(p *Policy, name string) {
+	if p.ACL != nil {
+		cp.ACL = p.ACL
+		cp.Sources.ACL = make([]string, len(p.ACL.Entries))
+		for i := range cp.Sources.ACL {
+			cp.Sources.ACL[i] = name
+		}
+	}
+	if p.Sequence != nil {
+		cp.Sequence = p.Sequence
+		cp.Sources.Sequence = []string{name}
+	}
+	if p.Filters != nil {
+		cp.Filters, cp.Sources.Filters = p.Filters, name
+	}
+	if len(p.Order) > 0 {
+		cp.Order, cp.Sources.Order = p.Order, name
+	}
+	if len(p.Options) > 0 {
+		cp.Options, cp.Sources.Options = p.Options, name
+	}
+}
+
+// merge merges the compiled extended policy into the compiled policy
+// according to the merge modes.
+func (cp *CompiledPolicy) merge(parent *CompiledPolicy, merge *Merge) error {
+	switch {
+	case cp.ACL == nil && parent.ACL != nil:
+		cp.ACL, cp.Sources.ACL = parent.ACL, parent.Sources.ACL
+	case cp.ACL != nil && parent.ACL != nil && merge.acl() == MergeAppend:
+		cp.appendACL(parent)
+	}
+	switch {
+	case cp.Sequence == nil:
+		cp.Sequence, cp.Sources.Sequence = parent.Sequence, parent.Sources.Sequence
+	case parent.Sequence != nil && merge.sequence() == MergeIntersect:
+		cp.Sequence = cp.Sequence.Intersect(parent.Sequence)
+		cp.Sources.Sequence = append(append([]string(nil), cp.Sources.Sequence...),
+			parent.Sources.Sequence...)
+	}
+	if cp.Filters == nil {
+		cp.Filters, cp.Sources.Filters = parent.Filters, parent.Sources.Filters
+	}
+	if len(cp.Order) == 0 {
+		cp.Order, cp.Sources.Order = parent.Order, parent.Sources.Order
+	}
+	if len(cp.Options) == 0 {
+		cp.Options, cp.Sources.Options = parent.Options, parent.Sources.Options
+	}
+	return nil
+}
+
+// appendACL appends the entries of the ACL of the parent to the ACL of the
+// compiled policy. The default entry of the compiled policy is moved to the
+// end, the default entry of the parent is dropped.
+func (cp *CompiledPolicy) appendACL(parent *CompiledPolicy) {
+	own, ownSrcs, def, defSrc := splitDefault(cp.ACL.Entries, cp.Sources.ACL)
+	other, otherSrcs, parentDef, parentDefSrc := splitDefault(parent.ACL.Entries,
+		parent.Sources.ACL)
+	if def == nil {
+		def, defSrc = parentDef, parentDefSrc
+	}
+	entries := append(append([]*ACLEntry(nil), own...), other...)
+	srcs := append(append([]string(nil), ownSrcs...), otherSrcs...)
+	if def != nil {
+		entries = append(entries, def)
+		srcs = append(srcs, defSrc)
+	}
+	cp.ACL = &ACL{Entries: entries}
+	cp.Sources.ACL = srcs
+}
+
+// splitDefault splits off the trailing default entry of the ACL entries, if
+// there is one.
+func splitDefault(entries []*ACLEntry, srcs []string) ([]*ACLEntry, []string,
+	*ACLEntry, string) {
+
+	if len(entries) == 0 || !entries[len(entries)-1].Rule.matchesAll() {
+		return entries, srcs, nil, ""
+	}
+	last := len(entries) - 1
+	return entries[:last], srcs[:last], entries[last], srcs[last]
+}
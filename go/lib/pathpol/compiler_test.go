@@ -0,0 +1,246 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathpol
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestCompilerCycles(t *testing.T) {
+	tests := map[string]struct {
+		Policies PolicyMap
+		Compile  string
+		ErrIs    error
+		Cycle    string
+	}{
+		"self reference": {
+			Policies: PolicyMap{
+				"a": {Extends: []string{"a"}},
+			},
+			Compile: "a",
+			ErrIs:   ErrCircularExtends,
+			Cycle:   "a -> a",
+		},
+		"indirect cycle": {
+			Policies: PolicyMap{
+				"a": {Extends: []string{"b"}},
+				"b": {Extends: []string{"c"}},
+				"c": {Extends: []string{"a"}},
+			},
+			Compile: "a",
+			ErrIs:   ErrCircularExtends,
+			Cycle:   "a -> b -> c -> a",
+		},
+		"cycle below entry point": {
+			Policies: PolicyMap{
+				"a": {Extends: []string{"b"}},
+				"b": {Extends: []string{"c"}},
+				"c": {Extends: []string{"b"}},
+			},
+			Compile: "a",
+			ErrIs:   ErrCircularExtends,
+			Cycle:   "a -> b -> c -> b",
+		},
+		"diamond is not a cycle": {
+			Policies: PolicyMap{
+				"a": {Extends: []string{"b", "c"}},
+				"b": {Extends: []string{"d"}},
+				"c": {Extends: []string{"d"}},
+				"d": {},
+			},
+			Compile: "a",
+		},
+		"missing extended policy": {
+			Policies: PolicyMap{
+				"a": {Extends: []string{"b"}},
+			},
+			Compile: "a",
+			ErrIs:   ErrExtendedNotFound,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewCompiler(test.Policies).Compile(test.Compile)
+			if test.ErrIs == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, errors.Is(err, test.ErrIs), "%v", err)
+			assert.Contains(t, err.Error(), test.Cycle)
+		})
+	}
+}
+
+func TestPolicyFromExtPolicyCycle(t *testing.T) {
+	extended := []*ExtPolicy{
+		{Policy: &Policy{Name: "policy1"}, Extends: []string{"policy2"}},
+		{Policy: &Policy{Name: "policy2"}, Extends: []string{"policy1"}},
+	}
+	_, err := PolicyFromExtPolicy(&ExtPolicy{Extends: []string{"policy1"}}, extended)
+	assert.True(t, errors.Is(err, ErrCircularExtends), "%v", err)
+}
+
+func TestCompilerMerge(t *testing.T) {
+	t.Run("append ACL", func(t *testing.T) {
+		policies := PolicyMap{
+			"top": {
+				Extends: []string{"base", "extra"},
+				Merge:   &Merge{ACL: MergeAppend},
+				Policy: &Policy{ACL: &ACL{Entries: []*ACLEntry{
+					{Action: Deny, Rule: mustHopPredicate(t, "1-ff00:0:133#0")},
+					{Action: Allow},
+				}}},
+			},
+			"base": {Policy: &Policy{ACL: &ACL{Entries: []*ACLEntry{
+				{Action: Deny, Rule: mustHopPredicate(t, "1-ff00:0:131#0")},
+				{Action: Deny},
+			}}}},
+			"extra": {Policy: &Policy{ACL: &ACL{Entries: []*ACLEntry{
+				{Action: Deny, Rule: mustHopPredicate(t, "1-ff00:0:132#0")},
+				{Action: Deny, Rule: mustHopPredicate(t, "0-0#0")},
+			}}}},
+		}
+		compiled, err := NewCompiler(policies).Compile("top")
+		require.NoError(t, err)
+		expected := &ACL{Entries: []*ACLEntry{
+			{Action: Deny, Rule: mustHopPredicate(t, "1-ff00:0:133#0")},
+			{Action: Deny, Rule: mustHopPredicate(t, "1-ff00:0:132#0")},
+			{Action: Deny, Rule: mustHopPredicate(t, "1-ff00:0:131#0")},
+			{Action: Allow},
+		}}
+		assert.Equal(t, expected, compiled.ACL)
+		assert.Equal(t, []string{"top", "extra", "base", "top"}, compiled.Sources.ACL)
+		// The inputs are not modified.
+		assert.Len(t, policies["top"].ACL.Entries, 2)
+	})
+	t.Run("replace ACL", func(t *testing.T) {
+		policies := PolicyMap{
+			"top": {
+				Extends: []string{"base"},
+				Policy:  &Policy{ACL: &ACL{Entries: []*ACLEntry{{Action: Allow}}}},
+			},
+			"base": {Policy: &Policy{ACL: &ACL{Entries: []*ACLEntry{{Action: Deny}}}}},
+		}
+		compiled, err := NewCompiler(policies).Compile("top")
+		require.NoError(t, err)
+		assert.Equal(t, &ACL{Entries: []*ACLEntry{{Action: Allow}}}, compiled.ACL)
+		assert.Equal(t, []string{"top"}, compiled.Sources.ACL)
+	})
+	t.Run("intersect sequence", func(t *testing.T) {
+		policies := PolicyMap{
+			"top": {
+				Extends: []string{"base"},
+				Merge:   &Merge{Sequence: MergeIntersect},
+				Policy:  &Policy{Sequence: newSequence(t, "0* 1-ff00:0:120 0*")},
+			},
+			"base": {Policy: &Policy{
+				Sequence: newSequence(t, "0* (1-ff00:0:120#2911,3122 | 2-ff00:0:210) 0*"),
+			}},
+		}
+		compiled, err := NewCompiler(policies).Compile("top")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"top", "base"}, compiled.Sources.Sequence)
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		pp := NewPathProvider(ctrl)
+		paths := pp.GetPaths(xtest.MustParseIA("1-ff00:0:110"), xtest.MustParseIA("2-ff00:0:220"))
+		require.Len(t, paths, 3)
+		assert.Len(t, policies["top"].Sequence.Eval(paths), 2)
+		assert.Len(t, policies["base"].Sequence.Eval(paths), 2)
+		assert.Len(t, compiled.Filter(paths), 1)
+	})
+	t.Run("invalid merge mode", func(t *testing.T) {
+		policies := PolicyMap{
+			"top":  {Extends: []string{"base"}, Merge: &Merge{ACL: MergeIntersect}},
+			"base": {},
+		}
+		_, err := NewCompiler(policies).Compile("top")
+		assert.Error(t, err)
+	})
+}
+
+func TestCompilerLint(t *testing.T) {
+	policies := PolicyMap{
+		"ok":      {Extends: []string{"base"}},
+		"base":    {Policy: &Policy{ACL: &ACL{Entries: []*ACLEntry{{Action: Allow}}}}},
+		"cycle":   {Extends: []string{"cycle"}},
+		"missing": {Extends: []string{"unknown"}},
+		"nodefault": {Policy: &Policy{ACL: &ACL{Entries: []*ACLEntry{
+			{Action: Deny, Rule: mustHopPredicate(t, "1-ff00:0:133#0")},
+		}}}},
+	}
+	errs := NewCompiler(policies).Lint()
+	require.Len(t, errs, 3)
+	assert.True(t, errors.Is(errs[0], ErrCircularExtends), "%v", errs[0])
+	assert.True(t, errors.Is(errs[1], ErrExtendedNotFound), "%v", errs[1])
+	assert.True(t, errors.Is(errs[2], ErrNoDefault), "%v", errs[2])
+}
+
+func TestCompiledPolicyExplain(t *testing.T) {
+	policies, err := PolicyMapFromYAML([]byte(`
+- top:
+    extends: [base]
+    merge:
+      acl: append
+    acl:
+      - "- 1-ff00:0:133#0"
+      - "+"
+    order: [latency]
+- base:
+    acl:
+      - "- 1-ff00:0:131#0"
+      - "-"
+    sequence: "0* 1-ff00:0:120 0*"
+`))
+	require.NoError(t, err)
+	compiled, err := NewCompiler(policies).Compile("top")
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	compiled.Explain(&buf)
+	expected := `Policy: top
+ACL:
+  - 1-ff00:0:133#0                         (from top)
+  - 1-ff00:0:131#0                         (from base)
+  +                                        (from top)
+Sequence: 0* 1-ff00:0:120 0* (from base)
+Order: [latency] (from top)
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestPolicyMapFromYAML(t *testing.T) {
+	yamlMap := `
+sub:
+  acl: ["+"]
+top:
+  extends: [sub]
+`
+	policies, err := PolicyMapFromYAML([]byte(yamlMap))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sub"}, policies["top"].Extends)
+	assert.Equal(t, &ACL{Entries: []*ACLEntry{{Action: Allow}}}, policies["sub"].ACL)
+
+	_, err = PolicyMapFromYAML([]byte("- a: {}\n- a: {}\n"))
+	assert.Error(t, err)
+}
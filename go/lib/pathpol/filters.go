@@ -0,0 +1,210 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathpol
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+// Filters restricts paths based on their properties. A path is only accepted
+// if it satisfies all set filters. If a filtered property of a path is
+// unknown, the path is rejected.
+type Filters struct {
+	// MaxHops is the maximum number of AS hops, i.e., inter-AS links, of a
+	// path.
+	MaxHops *int `json:"max_hops,omitempty"`
+	// MinMTU is the minimum MTU of a path.
+	MinMTU *uint16 `json:"min_mtu,omitempty"`
+	// MinExpiry is the minimum time a path must remain valid.
+	MinExpiry *util.DurWrap `json:"min_expiry,omitempty"`
+	// MaxLatency is the maximum total latency of a path.
+	MaxLatency *util.DurWrap `json:"max_latency,omitempty"`
+	// MinBandwidth is the minimum bottleneck bandwidth of a path in Kbit/s.
+	MinBandwidth *uint64 `json:"min_bandwidth,omitempty"`
+}
+
+// Eval returns the set of paths that satisfy all filters.
+func (f *Filters) Eval(inputSet PathSet) PathSet {
+	if f == nil {
+		return inputSet
+	}
+	now := time.Now()
+	resultSet := make(PathSet)
+	for key, path := range inputSet {
+		if f.evalPath(path, now) {
+			resultSet[key] = path
+		}
+	}
+	return resultSet
+}
+
+func (f *Filters) evalPath(path Path, now time.Time) bool {
+	if f.MaxHops != nil && hops(path) > *f.MaxHops {
+		return false
+	}
+	if f.MinMTU != nil && mtu(path) < *f.MinMTU {
+		return false
+	}
+	if f.MinExpiry != nil && expiry(path).Sub(now) < f.MinExpiry.Duration {
+		return false
+	}
+	if f.MaxLatency != nil {
+		lat, ok := latency(path)
+		if !ok || lat > f.MaxLatency.Duration {
+			return false
+		}
+	}
+	if f.MinBandwidth != nil {
+		bw, ok := bandwidth(path)
+		if !ok || bw < *f.MinBandwidth {
+			return false
+		}
+	}
+	return true
+}
+
+// OrderKey is a criterion by which paths are ordered. Paths are always ordered
+// best first, paths for which the criterion is unknown are ordered last.
+type OrderKey string
+
+const (
+	// OrderHops orders paths by the number of AS hops, fewest first.
+	OrderHops OrderKey = "hops"
+	// OrderLatency orders paths by total latency, lowest first.
+	OrderLatency OrderKey = "latency"
+	// OrderBandwidth orders paths by bottleneck bandwidth, highest first.
+	OrderBandwidth OrderKey = "bandwidth"
+	// OrderMTU orders paths by MTU, highest first.
+	OrderMTU OrderKey = "mtu"
+	// OrderExpiry orders paths by expiration time, latest first.
+	OrderExpiry OrderKey = "expiry"
+)
+
+func (k *OrderKey) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	switch key := OrderKey(s); key {
+	case OrderHops, OrderLatency, OrderBandwidth, OrderMTU, OrderExpiry:
+		*k = key
+		return nil
+	default:
+		return common.NewBasicError("Unknown order key", nil, "key", s)
+	}
+}
+
+// Order is a list of criteria by which paths are ordered. The first criterion
+// has the highest priority, subsequent criteria are used to break ties. Paths
+// that are equal in all criteria are ordered by fingerprint.
+type Order []OrderKey
+
+// Sort returns the paths in the set ordered according to the criteria.
+func (o Order) Sort(inputSet PathSet) []Path {
+	paths := make([]Path, 0, len(inputSet))
+	for _, path := range inputSet {
+		paths = append(paths, path)
+	}
+	sort.SliceStable(paths, func(i, j int) bool {
+		for _, key := range o {
+			if c := compare(key, paths[i], paths[j]); c != 0 {
+				return c < 0
+			}
+		}
+		return paths[i].Fingerprint() < paths[j].Fingerprint()
+	})
+	return paths
+}
+
+// compare returns a negative value if a is better than b according to the
+// key, a positive value if b is better than a, and zero otherwise.
+func compare(key OrderKey, a, b Path) int {
+	switch key {
+	case OrderHops:
+		return hops(a) - hops(b)
+	case OrderLatency:
+		la, okA := latency(a)
+		lb, okB := latency(b)
+		return compareKnown(okA, okB, la < lb, la > lb)
+	case OrderBandwidth:
+		bwA, okA := bandwidth(a)
+		bwB, okB := bandwidth(b)
+		return compareKnown(okA, okB, bwA > bwB, bwA < bwB)
+	case OrderMTU:
+		return compareKnown(mtu(a) != 0, mtu(b) != 0, mtu(a) > mtu(b), mtu(a) < mtu(b))
+	case OrderExpiry:
+		ea, eb := expiry(a), expiry(b)
+		return compareKnown(!ea.IsZero(), !eb.IsZero(), ea.After(eb), ea.Before(eb))
+	default:
+		return 0
+	}
+}
+
+// compareKnown orders known values before unknown values. If both values are
+// known, better and worse indicate the result of the comparison.
+func compareKnown(knownA, knownB, better, worse bool) int {
+	switch {
+	case knownA && !knownB:
+		return -1
+	case !knownA && knownB:
+		return 1
+	case !knownA && !knownB:
+		return 0
+	case better:
+		return -1
+	case worse:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// hops returns the number of AS hops of the path.
+func hops(path Path) int {
+	return len(path.Interfaces()) / 2
+}
+
+func mtu(path Path) uint16 {
+	if p, ok := path.(MetaPath); ok {
+		return p.MTU()
+	}
+	return 0
+}
+
+func expiry(path Path) time.Time {
+	if p, ok := path.(MetaPath); ok {
+		return p.Expiry()
+	}
+	return time.Time{}
+}
+
+func latency(path Path) (time.Duration, bool) {
+	if p, ok := path.(MetaPath); ok {
+		return p.Metadata().TotalLatency()
+	}
+	return 0, false
+}
+
+func bandwidth(path Path) (uint64, bool) {
+	if p, ok := path.(MetaPath); ok {
+		return p.Metadata().MinBandwidth()
+	}
+	return 0, false
+}
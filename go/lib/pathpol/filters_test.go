@@ -0,0 +1,264 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathpol
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestFiltersEval(t *testing.T) {
+	paths := newMetaPathSet()
+	tests := map[string]struct {
+		Filters  *Filters
+		Expected []snet.PathFingerprint
+	}{
+		"nil filters": {
+			Expected: []snet.PathFingerprint{"short", "long", "unknown"},
+		},
+		"max hops": {
+			Filters:  &Filters{MaxHops: intPtr(1)},
+			Expected: []snet.PathFingerprint{"short", "unknown"},
+		},
+		"min mtu": {
+			Filters:  &Filters{MinMTU: uint16Ptr(1400)},
+			Expected: []snet.PathFingerprint{"long"},
+		},
+		"min expiry": {
+			Filters:  &Filters{MinExpiry: &util.DurWrap{Duration: 10 * time.Minute}},
+			Expected: []snet.PathFingerprint{"short"},
+		},
+		"max latency rejects unknown": {
+			Filters:  &Filters{MaxLatency: &util.DurWrap{Duration: time.Second}},
+			Expected: []snet.PathFingerprint{"short", "long"},
+		},
+		"max latency": {
+			Filters:  &Filters{MaxLatency: &util.DurWrap{Duration: 20 * time.Millisecond}},
+			Expected: []snet.PathFingerprint{"short"},
+		},
+		"min bandwidth": {
+			Filters:  &Filters{MinBandwidth: uint64Ptr(500)},
+			Expected: []snet.PathFingerprint{"long"},
+		},
+		"combined": {
+			Filters: &Filters{
+				MaxHops:   intPtr(2),
+				MinExpiry: &util.DurWrap{Duration: time.Minute},
+			},
+			Expected: []snet.PathFingerprint{"short", "long"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := test.Filters.Eval(paths)
+			assert.ElementsMatch(t, test.Expected, fingerprints(result))
+		})
+	}
+}
+
+func TestOrderSort(t *testing.T) {
+	paths := newMetaPathSet()
+	tests := map[string]struct {
+		Order    Order
+		Expected []snet.PathFingerprint
+	}{
+		"no order sorts by fingerprint": {
+			Expected: []snet.PathFingerprint{"long", "short", "unknown"},
+		},
+		"hops": {
+			Order:    Order{OrderHops},
+			Expected: []snet.PathFingerprint{"short", "unknown", "long"},
+		},
+		"latency puts unknown last": {
+			Order:    Order{OrderLatency},
+			Expected: []snet.PathFingerprint{"short", "long", "unknown"},
+		},
+		"bandwidth": {
+			Order:    Order{OrderBandwidth},
+			Expected: []snet.PathFingerprint{"long", "short", "unknown"},
+		},
+		"mtu": {
+			Order:    Order{OrderMTU},
+			Expected: []snet.PathFingerprint{"long", "short", "unknown"},
+		},
+		"expiry": {
+			Order:    Order{OrderExpiry},
+			Expected: []snet.PathFingerprint{"short", "long", "unknown"},
+		},
+		"tie break": {
+			Order:    Order{OrderHops, OrderMTU},
+			Expected: []snet.PathFingerprint{"short", "unknown", "long"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var result []snet.PathFingerprint
+			for _, path := range test.Order.Sort(paths) {
+				result = append(result, path.Fingerprint())
+			}
+			assert.Equal(t, test.Expected, result)
+		})
+	}
+}
+
+func TestPolicyFiltersAndOrder(t *testing.T) {
+	paths := newMetaPathSet()
+	extPolicy := &ExtPolicy{
+		Policy: &Policy{
+			Name:    "base",
+			Filters: &Filters{MaxHops: intPtr(2)},
+			Order:   Order{OrderLatency},
+		},
+	}
+	policy, err := PolicyFromExtPolicy(&ExtPolicy{Extends: []string{"base"}},
+		[]*ExtPolicy{extPolicy})
+	require.NoError(t, err)
+	var result []snet.PathFingerprint
+	for _, path := range policy.Sort(policy.Filter(paths)) {
+		result = append(result, path.Fingerprint())
+	}
+	assert.Equal(t, []snet.PathFingerprint{"short", "long", "unknown"}, result)
+}
+
+func TestFiltersJSON(t *testing.T) {
+	policy := &Policy{
+		Filters: &Filters{
+			MaxHops:      intPtr(5),
+			MinMTU:       uint16Ptr(1400),
+			MinExpiry:    &util.DurWrap{Duration: 10 * time.Minute},
+			MaxLatency:   &util.DurWrap{Duration: 100 * time.Millisecond},
+			MinBandwidth: uint64Ptr(1000),
+		},
+		Order: Order{OrderLatency, OrderHops},
+	}
+	raw, err := json.Marshal(policy)
+	require.NoError(t, err)
+	var pol Policy
+	require.NoError(t, json.Unmarshal(raw, &pol))
+	assert.Equal(t, policy, &pol)
+
+	err = json.Unmarshal([]byte(`{"order": ["fastest"]}`), &pol)
+	assert.Error(t, err)
+}
+
+func TestPolicyFromYAML(t *testing.T) {
+	yamlPol := `
+acl:
+  - "- 1-ff00:0:133#0"
+  - "+"
+filters:
+  max_hops: 5
+  min_mtu: 1400
+  min_expiry: 10m
+order: [latency, hops]
+`
+	policy, err := PolicyFromYAML([]byte(yamlPol))
+	require.NoError(t, err)
+	expected := &Policy{
+		ACL: &ACL{Entries: []*ACLEntry{
+			{Action: Deny, Rule: mustHopPredicate(t, "1-ff00:0:133#0")},
+			{Action: Allow},
+		}},
+		Filters: &Filters{
+			MaxHops:   intPtr(5),
+			MinMTU:    uint16Ptr(1400),
+			MinExpiry: &util.DurWrap{Duration: 10 * time.Minute},
+		},
+		Order: Order{OrderLatency, OrderHops},
+	}
+	assert.Equal(t, expected, policy)
+
+	// JSON is valid YAML.
+	raw, err := json.Marshal(expected)
+	require.NoError(t, err)
+	policy, err = PolicyFromYAML(raw)
+	require.NoError(t, err)
+	assert.Equal(t, expected, policy)
+
+	_, err = PolicyFromYAML([]byte("filters: [1, 2]"))
+	assert.Error(t, err)
+}
+
+// metaPath is a test path that implements MetaPath.
+type metaPath struct {
+	testPath
+	mtu      uint16
+	expiry   time.Time
+	metadata *snet.PathMetadata
+}
+
+func (p *metaPath) MTU() uint16                  { return p.mtu }
+func (p *metaPath) Expiry() time.Time            { return p.expiry }
+func (p *metaPath) Metadata() *snet.PathMetadata { return p.metadata }
+
+// newMetaPathSet creates a set of three paths: "short" is a one hop path with
+// low latency, "long" is a two hop path with high bandwidth and MTU, and
+// "unknown" is a one hop path without any metadata.
+func newMetaPathSet() PathSet {
+	now := time.Now()
+	newPath := func(key string, hops int) testPath {
+		var intfs []snet.PathInterface
+		for i := 0; i < hops; i++ {
+			intfs = append(intfs,
+				testPathIntf{ia: xtest.MustParseIA("1-ff00:0:110")},
+				testPathIntf{ia: xtest.MustParseIA("1-ff00:0:111")},
+			)
+		}
+		return testPath{interfaces: intfs, key: snet.PathFingerprint(key)}
+	}
+	return PathSet{
+		"short": &metaPath{
+			testPath: newPath("short", 1),
+			mtu:      1280,
+			expiry:   now.Add(time.Hour),
+			metadata: &snet.PathMetadata{
+				Latency:   []time.Duration{10 * time.Millisecond},
+				Bandwidth: []uint64{100},
+			},
+		},
+		"long": &metaPath{
+			testPath: newPath("long", 2),
+			mtu:      1472,
+			expiry:   now.Add(5 * time.Minute),
+			metadata: &snet.PathMetadata{
+				Latency: []time.Duration{
+					10 * time.Millisecond, 5 * time.Millisecond, 10 * time.Millisecond,
+				},
+				Bandwidth: []uint64{1000, 2000, 1000},
+			},
+		},
+		"unknown": &testPath{interfaces: newPath("unknown", 1).interfaces, key: "unknown"},
+	}
+}
+
+func fingerprints(paths PathSet) []snet.PathFingerprint {
+	var result []snet.PathFingerprint
+	for key := range paths {
+		result = append(result, key)
+	}
+	return result
+}
+
+func intPtr(v int) *int          { return &v }
+func uint16Ptr(v uint16) *uint16 { return &v }
+func uint64Ptr(v uint64) *uint64 { return &v }
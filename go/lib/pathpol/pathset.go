@@ -15,6 +15,8 @@
 package pathpol
 
 import (
+	"time"
+
 	"github.com/scionproto/scion/go/lib/snet"
 )
 
@@ -28,3 +30,17 @@ type Path interface {
 	// Returns a string that uniquely identifies this path.
 	Fingerprint() snet.PathFingerprint
 }
+
+// MetaPath is a path that additionally provides metadata. Filters and orderings
+// that depend on metadata treat paths that do not implement MetaPath as paths
+// with unknown MTU, expiration time and static metadata.
+type MetaPath interface {
+	Path
+	// MTU returns the MTU of the path. Zero means unknown.
+	MTU() uint16
+	// Expiry returns the expiration time of the path. The zero value means
+	// unknown.
+	Expiry() time.Time
+	// Metadata returns the static metadata of the path. Nil means unknown.
+	Metadata() *snet.PathMetadata
+}
@@ -14,20 +14,22 @@
 // limitations under the License.
 
 // Package pathpol implements path policies, documentation in doc/PathPolicy.md
-// Currently implemented: ACL, Sequence, Extends and Options.
+// Currently implemented: ACL, Sequence, Filters, Order, Extends and Options.
 //
 // A policy has an Act() method that takes an AppPathSet and returns a filtered AppPathSet
 package pathpol
 
 import (
 	"sort"
-
-	"github.com/scionproto/scion/go/lib/common"
 )
 
 // ExtPolicy is an extending policy, it may have a list of policies it extends
 type ExtPolicy struct {
 	Extends []string `json:"extends,omitempty"`
+	// Merge defines how the attributes of the extended policies are merged.
+	// By default, attributes of extended policies are only used if they are not
+	// set in the extending policy.
+	Merge *Merge `json:"merge,omitempty"`
 	*Policy
 }
 
@@ -47,6 +49,8 @@ type Policy struct {
 	Name     string    `json:"-"`
 	ACL      *ACL      `json:"acl,omitempty"`
 	Sequence *Sequence `json:"sequence,omitempty"`
+	Filters  *Filters  `json:"filters,omitempty"`
+	Order    Order     `json:"order,omitempty"`
 	Options  []Option  `json:"options,omitempty"`
 }
 
@@ -75,6 +79,7 @@ func (p *Policy) FilterOpt(paths PathSet, opts FilterOptions) PathSet {
 	if p.Sequence != nil && !opts.IgnoreSequence {
 		resultSet = p.Sequence.Eval(resultSet)
 	}
+	resultSet = p.Filters.Eval(resultSet)
 	// Filter on sub policies
 	if len(p.Options) > 0 {
 		resultSet = p.evalOptions(resultSet, opts)
@@ -82,53 +87,28 @@ func (p *Policy) FilterOpt(paths PathSet, opts FilterOptions) PathSet {
 	return resultSet
 }
 
-// PolicyFromExtPolicy creates a Policy from an extending Policy and the extended policies
-func PolicyFromExtPolicy(extPolicy *ExtPolicy, extended []*ExtPolicy) (*Policy, error) {
-	policy := extPolicy.Policy
-	if policy == nil {
-		policy = &Policy{}
-	}
-	// Apply all extended policies
-	if err := policy.applyExtended(extPolicy.Extends, extended); err != nil {
-		return nil, err
+// Sort returns the paths in the set ordered according to the order of the
+// policy. Without an order, the paths are ordered by fingerprint.
+func (p *Policy) Sort(paths PathSet) []Path {
+	if p == nil {
+		return Order(nil).Sort(paths)
 	}
-	return policy, nil
+	return p.Order.Sort(paths)
 }
 
-// applyExtended adds attributes of extended policies to the extending policy if they are not
-// already set
-func (p *Policy) applyExtended(extends []string, exPolicies []*ExtPolicy) error {
-	// TODO(worxli): Prevent circular policies.
-	// traverse in reverse s.t. last entry of the list has precedence
-	for i := len(extends) - 1; i >= 0; i-- {
-		var policy *Policy
-		// Find extended policy
-		for _, exPol := range exPolicies {
-			if exPol.Name == extends[i] {
-				var err error
-				if policy, err = PolicyFromExtPolicy(exPol, exPolicies); err != nil {
-					return err
-				}
-			}
-		}
-		if policy == nil {
-			return common.NewBasicError("Extended policy could not be found", nil,
-				"policy", extends[i])
-		}
-		// Replace ACL
-		if p.ACL == nil && policy.ACL != nil {
-			p.ACL = policy.ACL
-		}
-		// Replace Options
-		if len(p.Options) == 0 {
-			p.Options = policy.Options
-		}
-		// Replace Sequence
-		if p.Sequence == nil {
-			p.Sequence = policy.Sequence
+// PolicyFromExtPolicy creates a Policy from an extending Policy and the extended policies
+func PolicyFromExtPolicy(extPolicy *ExtPolicy, extended []*ExtPolicy) (*Policy, error) {
+	policies := make(PolicyMap, len(extended))
+	for _, exPol := range extended {
+		if exPol.Policy != nil {
+			policies[exPol.Name] = exPol
 		}
 	}
-	return nil
+	compiled, err := NewCompiler(policies).CompileExt(extPolicy)
+	if err != nil {
+		return nil, err
+	}
+	return compiled.Policy, nil
 }
 
 // evalOptions evaluates the options of a policy and returns the pathSet that matches the option
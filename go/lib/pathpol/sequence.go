@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"strings"
 
 	"github.com/antlr/antlr4/runtime/Go/antlr"
 
@@ -39,6 +40,9 @@ type Sequence struct {
 	re     *regexp.Regexp
 	srcstr string
 	restr  string
+	// and contains the sequences that a path must match in addition to re. It
+	// is set if the sequence was created by Intersect.
+	and []*Sequence
 }
 
 // NewSequence creates a new sequence from a string
@@ -72,6 +76,20 @@ func NewSequence(s string) (*Sequence, error) {
 	return &Sequence{re: re, srcstr: s, restr: restr}, nil
 }
 
+// Intersect returns a sequence that matches the paths that match both s and
+// other. If either sequence is empty, the other one is returned. The
+// intersection only exists in memory, it cannot be marshaled.
+func (s *Sequence) Intersect(other *Sequence) *Sequence {
+	if s == nil || s.srcstr == "" {
+		return other
+	}
+	if other == nil || other.srcstr == "" {
+		return s
+	}
+	and := append(append([]*Sequence(nil), s.and...), other)
+	return &Sequence{re: s.re, srcstr: s.srcstr, restr: s.restr, and: and}
+}
+
 // Eval evaluates the interface sequence list and returns the set of paths that match the list
 func (s *Sequence) Eval(inputSet PathSet) PathSet {
 	if s == nil || s.srcstr == "" {
@@ -100,18 +118,41 @@ func (s *Sequence) Eval(inputSet PathSet) PathSet {
 			ifaces[len(ifaces)-1].ID())
 		// Check whether the string matches the sequence regexp.
 		//fmt.Printf("EVAL: %s\n", p)
-		if s.re.MatchString(p) {
+		if s.match(p) {
 			resultSet[key] = path
 		}
 	}
 	return resultSet
 }
 
+func (s *Sequence) match(p string) bool {
+	if !s.re.MatchString(p) {
+		return false
+	}
+	for _, other := range s.and {
+		if !other.match(p) {
+			return false
+		}
+	}
+	return true
+}
+
 func (s *Sequence) String() string {
-	return s.srcstr
+	if len(s.and) == 0 {
+		return s.srcstr
+	}
+	strs := []string{s.srcstr}
+	for _, other := range s.and {
+		strs = append(strs, other.String())
+	}
+	return strings.Join(strs, " & ")
 }
 
 func (s *Sequence) MarshalJSON() ([]byte, error) {
+	if len(s.and) != 0 {
+		return nil, common.NewBasicError("Intersected sequence cannot be marshaled", nil,
+			"sequence", s)
+	}
 	return json.Marshal(s.srcstr)
 }
 
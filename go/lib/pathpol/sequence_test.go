@@ -32,6 +32,7 @@ func TestNewSequence(t *testing.T) {
 		"0":       assert.NoError,
 		"1#0":     assert.Error,
 		"1-0":     assert.NoError,
+		"0 & 1-0": assert.Error,
 	}
 	for seq, assertion := range tests {
 		t.Run(seq, func(t *testing.T) {
@@ -282,3 +283,33 @@ func TestSequenceEval(t *testing.T) {
 		})
 	}
 }
+
+func TestSequenceIntersect(t *testing.T) {
+	a := newSequence(t, "0* 1-ff00:0:120 0*")
+	b := newSequence(t, "0* (1-ff00:0:120#2911,3122 | 2-ff00:0:210) 0*")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	pp := NewPathProvider(ctrl)
+	paths := pp.GetPaths(xtest.MustParseIA("1-ff00:0:110"), xtest.MustParseIA("2-ff00:0:220"))
+
+	t.Run("empty operand", func(t *testing.T) {
+		empty := newSequence(t, "")
+		assert.Equal(t, a, a.Intersect(empty))
+		assert.Equal(t, a, empty.Intersect(a))
+		assert.Equal(t, a, a.Intersect(nil))
+	})
+	t.Run("intersection", func(t *testing.T) {
+		seq := a.Intersect(b)
+		assert.Len(t, seq.Eval(paths), 1)
+		assert.Equal(t, "0* 1-ff00:0:120 0* & "+
+			"0* (1-ff00:0:120#2911,3122 | 2-ff00:0:210) 0*", seq.String())
+		// The operands are not modified.
+		assert.Len(t, a.Eval(paths), 2)
+		assert.Len(t, b.Eval(paths), 2)
+	})
+	t.Run("marshal", func(t *testing.T) {
+		_, err := a.Intersect(b).MarshalJSON()
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,130 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathpol
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/scionproto/scion/go/lib/common"
+)
+
+// PolicyFromYAML parses a policy in YAML format. Since YAML is a superset of
+// JSON, policies in JSON format are accepted as well. The policy must not
+// extend other policies.
+func PolicyFromYAML(b []byte) (*Policy, error) {
+	raw, err := yamlToJSON(b)
+	if err != nil {
+		return nil, common.NewBasicError("Unable to parse policy", err)
+	}
+	extPolicy := &ExtPolicy{}
+	if err := json.Unmarshal(raw, extPolicy); err != nil {
+		return nil, common.NewBasicError("Unable to parse policy", err)
+	}
+	return PolicyFromExtPolicy(extPolicy, nil)
+}
+
+// LoadPolicy loads a policy from a YAML or JSON file.
+func LoadPolicy(path string) (*Policy, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, common.NewBasicError("Unable to read policy file", err, "path", path)
+	}
+	return PolicyFromYAML(b)
+}
+
+// PolicyMapFromYAML parses a policy map in YAML or JSON format. The policies
+// can either be given as a mapping from name to policy, or as a list of such
+// mappings, as used in the examples of doc/PathPolicy.md.
+func PolicyMapFromYAML(b []byte) (PolicyMap, error) {
+	var v interface{}
+	if err := yaml.Unmarshal(b, &v); err != nil {
+		return nil, common.NewBasicError("Unable to parse policy map", err)
+	}
+	v = convertYAML(v)
+	if list, ok := v.([]interface{}); ok {
+		merged := make(map[string]interface{})
+		for _, item := range list {
+			m, ok := item.(map[string]interface{})
+			if !ok {
+				return nil, common.NewBasicError("Policy list entry is not a mapping", nil,
+					"entry", item)
+			}
+			for name, policy := range m {
+				if _, ok := merged[name]; ok {
+					return nil, common.NewBasicError("Duplicate policy", nil, "policy", name)
+				}
+				merged[name] = policy
+			}
+		}
+		v = merged
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, common.NewBasicError("Unable to parse policy map", err)
+	}
+	policies := make(PolicyMap)
+	if err := json.Unmarshal(raw, &policies); err != nil {
+		return nil, common.NewBasicError("Unable to parse policy map", err)
+	}
+	for name, policy := range policies {
+		if policy == nil {
+			policies[name] = &ExtPolicy{}
+		}
+	}
+	return policies, nil
+}
+
+// LoadPolicyMap loads a policy map from a YAML or JSON file.
+func LoadPolicyMap(path string) (PolicyMap, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, common.NewBasicError("Unable to read policy file", err, "path", path)
+	}
+	return PolicyMapFromYAML(b)
+}
+
+// yamlToJSON converts a YAML document to JSON, such that the JSON unmarshalers
+// of the policy types can be reused.
+func yamlToJSON(b []byte) ([]byte, error) {
+	var v interface{}
+	if err := yaml.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(convertYAML(v))
+}
+
+// convertYAML converts the generic maps created by the YAML decoder to maps
+// with string keys, which can be marshalled to JSON.
+func convertYAML(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			m[fmt.Sprint(key)] = convertYAML(val)
+		}
+		return m
+	case []interface{}:
+		for i, val := range v {
+			v[i] = convertYAML(val)
+		}
+		return v
+	default:
+		return v
+	}
+}
@@ -23,9 +23,12 @@ import (
 	"github.com/scionproto/scion/go/lib/infra/modules/db"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/pathdb"
+	mempathdb "github.com/scionproto/scion/go/lib/pathdb/mem"
+	postgrespathdb "github.com/scionproto/scion/go/lib/pathdb/postgres"
 	sqlitepathdb "github.com/scionproto/scion/go/lib/pathdb/sqlite"
 	"github.com/scionproto/scion/go/lib/revcache"
 	"github.com/scionproto/scion/go/lib/revcache/memrevcache"
+	sqliterevcache "github.com/scionproto/scion/go/lib/revcache/sqlite"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/util"
 )
@@ -33,9 +36,10 @@ import (
 type Backend string
 
 const (
-	BackendNone   Backend = ""
-	BackendSqlite Backend = "sqlite"
-	BackendMem    Backend = "mem"
+	BackendNone     Backend = ""
+	BackendSqlite   Backend = "sqlite"
+	BackendMem      Backend = "mem"
+	BackendPostgres Backend = "postgres"
 )
 
 const (
@@ -100,7 +104,7 @@ func (cfg *PathDBConf) Validate() error {
 
 func (cfg *PathDBConf) validateBackend() error {
 	switch cfg.Backend() {
-	case BackendSqlite:
+	case BackendSqlite, BackendMem, BackendPostgres:
 		return nil
 	case BackendNone:
 		return serrors.New("No backend set")
@@ -109,7 +113,7 @@ func (cfg *PathDBConf) validateBackend() error {
 }
 
 func (cfg *PathDBConf) validateConnection() error {
-	if cfg.Connection() == "" {
+	if cfg.Backend() != BackendMem && cfg.Connection() == "" {
 		return serrors.New("Empty connection not allowed")
 	}
 	return nil
@@ -213,14 +217,39 @@ func NewPathStorage(pdbConf PathDBConf,
 	return pdb, rc, nil
 }
 
+// sameBackend indicates whether the path database and the revocation cache
+// share the same backend and connection. In-memory backends never share state.
 func sameBackend(pdbConf PathDBConf, rcConf RevCacheConf) bool {
-	return pdbConf.Backend() == rcConf.Backend() && pdbConf.Backend() != BackendNone
+	return pdbConf.Backend() == rcConf.Backend() && pdbConf.Backend() != BackendNone &&
+		pdbConf.Backend() != BackendMem && pdbConf.Connection() == rcConf.Connection()
 }
 
+// newCombinedBackend creates a path database and a revocation cache that
+// share a single database.
 func newCombinedBackend(pdbConf PathDBConf,
 	rcConf RevCacheConf) (pathdb.PathDB, revcache.RevCache, error) {
 
-	panic("Combined backend not supported")
+	if err := pdbConf.Validate(); err != nil {
+		return nil, nil, common.NewBasicError("Invalid pathdb config", err)
+	}
+	if err := rcConf.Validate(); err != nil {
+		return nil, nil, common.NewBasicError("Invalid revcache config", err)
+	}
+	log.Info("Connecting combined PathDB and RevCache", "backend", pdbConf.Backend(),
+		"connection", pdbConf.Connection())
+	switch pdbConf.Backend() {
+	case BackendSqlite:
+		sqlDB, err := db.NewSqlite(pdbConf.Connection(), sqlitepathdb.Migrations)
+		if err != nil {
+			return nil, nil, err
+		}
+		pdb := sqlitepathdb.NewFromDB(sqlDB)
+		db.SetConnLimits(&pdbConf, pdb)
+		return pdb, sqliterevcache.NewFromDB(sqlDB), nil
+	default:
+		return nil, nil, common.NewBasicError("Unsupported combined backend", nil,
+			"backend", pdbConf.Backend())
+	}
 }
 
 func newPathDB(conf PathDBConf) (pathdb.PathDB, error) {
@@ -231,6 +260,10 @@ func newPathDB(conf PathDBConf) (pathdb.PathDB, error) {
 	switch conf.Backend() {
 	case BackendSqlite:
 		pdb, err = sqlitepathdb.New(conf.Connection())
+	case BackendMem:
+		pdb = mempathdb.New()
+	case BackendPostgres:
+		pdb, err = postgrespathdb.New(conf.Connection())
 	case BackendNone:
 		return nil, nil
 	default:
@@ -249,6 +282,13 @@ func newRevCache(conf RevCacheConf) (revcache.RevCache, error) {
 	switch conf.Backend() {
 	case BackendMem:
 		return memrevcache.New(), nil
+	case BackendSqlite:
+		rc, err := sqliterevcache.New(conf.Connection())
+		if err != nil {
+			return nil, err
+		}
+		db.SetConnLimits(&conf, rc)
+		return rc, nil
 	case BackendNone:
 		return nil, nil
 	default:
@@ -0,0 +1,88 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathstorage
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/revcache"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/proto"
+)
+
+func TestNewPathStorageCombined(t *testing.T) {
+	tmpDir, cleanF := xtest.MustTempDir("", "test-pathstorage")
+	defer cleanF()
+	ctx, cancelF := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancelF()
+
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	rev, err := path_mgmt.NewSignedRevInfo(&path_mgmt.RevInfo{
+		IfID:         15,
+		RawIsdas:     ia.IAInt(),
+		LinkType:     proto.LinkType_core,
+		RawTimestamp: util.TimeToSecs(time.Now()),
+		RawTTL:       10,
+	}, infra.NullSigner)
+	require.NoError(t, err)
+
+	file := filepath.Join(tmpDir, "combined.db")
+	pdbConf := PathDBConf{BackendKey: string(BackendSqlite), ConnectionKey: file}
+	rcConf := RevCacheConf{BackendKey: string(BackendSqlite), ConnectionKey: file}
+	pdb, rc, err := NewPathStorage(pdbConf, rcConf)
+	require.NoError(t, err)
+	inserted, err := rc.Insert(ctx, rev)
+	require.NoError(t, err)
+	assert.True(t, inserted)
+	_, err = pdb.GetNextQuery(ctx, ia, ia, nil)
+	assert.NoError(t, err)
+	require.NoError(t, pdb.Close())
+	require.NoError(t, rc.Close())
+
+	// The revocation survives a restart.
+	pdb, rc, err = NewPathStorage(pdbConf, rcConf)
+	require.NoError(t, err)
+	defer pdb.Close()
+	revs, err := rc.Get(ctx, revcache.SingleKey(ia, 15))
+	require.NoError(t, err)
+	assert.Len(t, revs, 1)
+}
+
+func TestNewPathStorageSeparate(t *testing.T) {
+	tmpDir, cleanF := xtest.MustTempDir("", "test-pathstorage")
+	defer cleanF()
+
+	pdbConf := PathDBConf{
+		BackendKey:    string(BackendSqlite),
+		ConnectionKey: filepath.Join(tmpDir, "path.db"),
+	}
+	rcConf := RevCacheConf{
+		BackendKey:    string(BackendSqlite),
+		ConnectionKey: filepath.Join(tmpDir, "rev.db"),
+	}
+	pdb, rc, err := NewPathStorage(pdbConf, rcConf)
+	require.NoError(t, err)
+	assert.NoError(t, pdb.Close())
+	assert.NoError(t, rc.Close())
+}
@@ -15,10 +15,12 @@
 package pathstorage
 
 const pathDbSample = `
-# The type of pathdb backend.
+# The type of pathdb backend. Supported backends are "sqlite", "mem" and
+# "postgres".
 backend = "sqlite"
 
-# Path to the path database.
+# Path to the path database, or the connection string for the "postgres"
+# backend. Ignored for the "mem" backend.
 connection = "/var/lib/scion/pathdb/%s.path.db"
 
 # The maximum number of open connections to the database. In case of the
@@ -31,9 +33,14 @@ max_idle_conns = ""
 `
 
 const revSample = `
-# The type of RevCache backend.
+# The type of RevCache backend. Supported backends are "mem" and "sqlite". If
+# the "sqlite" backend and connection are the same as for the path database,
+# both share a single database. (default "mem")
 backend = "mem"
 
+# Path to the revocation cache database. Ignored for the "mem" backend.
+connection = ""
+
 # The maximum number of open connections to the database. In case of the
 # empty string, the limit is not set and uses the go default. (default "")
 max_open_conns = ""
@@ -4,6 +4,8 @@ WHITESPACE: [ \r\n\t]+ -> skip;
 DIGITS: '0' | [1-9] [0-9]*;
 HEX_DIGITS: ('a' .. 'f' | 'A' .. 'F' | [0-9])+;
 NET: DIGITS '.' DIGITS '.' DIGITS '.' DIGITS '/' DIGITS;
+NET6: (HEX_DIGITS? ':')+ HEX_DIGITS? '/' DIGITS;
+RANGE: DIGITS '-' DIGITS;
 
 ANY: 'ANY' | 'any';
 ALL: 'ALL' | 'all';
@@ -13,11 +15,23 @@ SRC: 'SRC' | 'src';
 DST: 'DST' | 'dst';
 DSCP: 'DSCP' | 'dscp';
 TOS: 'TOS' | 'tos';
+TC: 'TC' | 'tc';
+FLOWLABEL: 'FLOWLABEL' | 'flowlabel';
+NEXTHDR: 'NEXTHDR' | 'nexthdr';
+PROTO: 'PROTO' | 'proto';
+SPORT: 'SPORT' | 'sport';
+DPORT: 'DPORT' | 'dport';
 
-matchSrc: SRC '=' NET;
-matchDst: DST '=' NET;
+matchSrc: SRC '=' (NET | NET6);
+matchDst: DST '=' (NET | NET6);
 matchDSCP: DSCP '=0x' (HEX_DIGITS | DIGITS);
 matchTOS: TOS '=0x' (HEX_DIGITS | DIGITS);
+matchTC: TC '=0x' (HEX_DIGITS | DIGITS);
+matchFlowLabel: FLOWLABEL '=' DIGITS;
+matchNextHdr: NEXTHDR '=' DIGITS;
+matchProto: PROTO '=' DIGITS;
+matchSrcPort: SPORT '=' (DIGITS | RANGE);
+matchDstPort: DPORT '=' (DIGITS | RANGE);
 
 condCls: 'cls=' DIGITS;
 condAny: ANY '(' cond (',' cond)* ')';
@@ -26,5 +40,7 @@ condNot: NOT '(' cond ')';
 condBool: BOOL '=' ('true' | 'false');
 
 condIPv4: matchSrc | matchDst | matchDSCP | matchTOS;
-cond: condAll | condAny | condNot | condIPv4 | condCls | condBool;
+condIPv6: matchTC | matchFlowLabel | matchNextHdr;
+condL4: matchProto | matchSrcPort | matchDstPort;
+cond: condAll | condAny | condNot | condIPv4 | condIPv6 | condL4 | condCls | condBool;
 trafficClass: cond EOF;
@@ -74,6 +74,44 @@ func TestClassMapMarshalUnMarshal(t *testing.T) {
 				),
 			},
 		},
+		{
+			Name:     "IPv6 and L4",
+			FileName: "class_3",
+			Classes: pktcls.ClassMap{
+				"voice": pktcls.NewClass(
+					"voice",
+					pktcls.NewCondAllOf(
+						pktcls.NewCondIPv6(&pktcls.IPv6MatchTrafficClass{TC: 0xb8}),
+						pktcls.NewCondIPv6(&pktcls.IPv6MatchDestination{
+							Net: &net.IPNet{
+								IP:   net.ParseIP("2001:db8::"),
+								Mask: net.CIDRMask(32, 128),
+							},
+						}),
+						pktcls.NewCondL4(&pktcls.L4MatchProtocol{Protocol: 17}),
+						pktcls.NewCondL4(&pktcls.L4MatchDstPort{
+							Ports: pktcls.PortRange{Min: 5060, Max: 5061},
+						}),
+					),
+				),
+				"labeled": pktcls.NewClass(
+					"labeled",
+					pktcls.NewCondAnyOf(
+						pktcls.NewCondIPv6(&pktcls.IPv6MatchFlowLabel{FlowLabel: 0xfffff}),
+						pktcls.NewCondIPv6(&pktcls.IPv6MatchNextHeader{NextHeader: 58}),
+						pktcls.NewCondIPv6(&pktcls.IPv6MatchSource{
+							Net: &net.IPNet{
+								IP:   net.ParseIP("fd00::"),
+								Mask: net.CIDRMask(8, 128),
+							},
+						}),
+						pktcls.NewCondL4(&pktcls.L4MatchSrcPort{
+							Ports: pktcls.PortRange{Min: 53, Max: 53},
+						}),
+					),
+				),
+			},
+		},
 		{
 			Name:     "nil ClassMap stays nil",
 			FileName: "class_2",
@@ -219,6 +257,51 @@ func TestClassUnmarshalError(t *testing.T) {
 			},
 			"Name": "Unable to parse source operand string"
 		}
+		`, `
+		{
+			"CondIPv6": {
+				"IPv6MatchSource": {
+					"Net": "1.2.3.0/24"
+				}
+			},
+			"Name": "IPv4 network in IPv6 source operand"
+		}
+		`, `
+		{
+			"CondIPv6": {
+				"MatchSource": {
+					"Net": "1.2.3.0/24"
+				}
+			},
+			"Name": "IPv4 predicate in IPv6 condition"
+		}
+		`, `
+		{
+			"CondIPv6": {
+				"IPv6MatchFlowLabel": {
+					"FlowLabel": "1048576"
+				}
+			},
+			"Name": "Flow label out of range"
+		}
+		`, `
+		{
+			"CondL4": {
+				"L4MatchDstPort": {
+					"Ports": "90-80"
+				}
+			},
+			"Name": "Invalid port range"
+		}
+		`, `
+		{
+			"CondL4": {
+				"L4MatchDstPort": {
+					"Ports": 80
+				}
+			},
+			"Name": "Non-string port range"
+		}
 	`}
 	for i, tc := range testCases {
 		var c pktcls.Class
@@ -216,6 +216,97 @@ func (c *CondIPv4) UnmarshalJSON(b []byte) error {
 	return err
 }
 
+var _ Cond = (*CondIPv6)(nil)
+
+// CondIPv6 conditions return true if the embedded IPv6 predicate returns true.
+type CondIPv6 struct {
+	Predicate IPv6Predicate
+}
+
+func NewCondIPv6(p IPv6Predicate) *CondIPv6 {
+	return &CondIPv6{Predicate: p}
+}
+
+func (c *CondIPv6) Eval(v interface{}) bool {
+	if v == nil {
+		return false
+	}
+	pkt := v.(*Packet)
+	// Protect against typed nils
+	if pkt == nil {
+		return false
+	}
+	parsedPkt, ok := pkt.parsedPkt.Layer(layers.LayerTypeIPv6).(*layers.IPv6)
+	if !ok || parsedPkt == nil {
+		return false
+	}
+	return c.Predicate.Eval(parsedPkt)
+}
+
+func (c *CondIPv6) Type() string {
+	return TypeCondIPv6
+}
+
+func (c *CondIPv6) String() string {
+	return c.Predicate.String()
+}
+
+func (c *CondIPv6) MarshalJSON() ([]byte, error) {
+	return marshalInterface(c.Predicate)
+}
+
+func (c *CondIPv6) UnmarshalJSON(b []byte) error {
+	var err error
+	c.Predicate, err = unmarshalIPv6Predicate(b)
+	return err
+}
+
+var _ Cond = (*CondL4)(nil)
+
+// CondL4 conditions return true if the embedded L4 predicate returns true. They
+// apply to both IPv4 and IPv6 packets.
+type CondL4 struct {
+	Predicate L4Predicate
+}
+
+func NewCondL4(p L4Predicate) *CondL4 {
+	return &CondL4{Predicate: p}
+}
+
+func (c *CondL4) Eval(v interface{}) bool {
+	if v == nil {
+		return false
+	}
+	pkt := v.(*Packet)
+	// Protect against typed nils
+	if pkt == nil {
+		return false
+	}
+	hdr, ok := pkt.l4Header()
+	if !ok {
+		return false
+	}
+	return c.Predicate.Eval(hdr)
+}
+
+func (c *CondL4) Type() string {
+	return TypeCondL4
+}
+
+func (c *CondL4) String() string {
+	return c.Predicate.String()
+}
+
+func (c *CondL4) MarshalJSON() ([]byte, error) {
+	return marshalInterface(c.Predicate)
+}
+
+func (c *CondL4) UnmarshalJSON(b []byte) error {
+	var err error
+	c.Predicate, err = unmarshalL4Predicate(b)
+	return err
+}
+
 const typeCondClass = "CondClass"
 
 // CondClass conditions return true if the embedded traffic class returns true
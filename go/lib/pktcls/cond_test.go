@@ -156,7 +156,154 @@ func TestIPCond(t *testing.T) {
 	}
 }
 
+func TestIPv6Cond(t *testing.T) {
+	_, network, _ := net.ParseCIDR("2001:db8::/32")
+	pkt := newTestPacket6(
+		&layers.IPv6{
+			TrafficClass: 0xb8,
+			FlowLabel:    12345,
+			NextHeader:   layers.IPProtocolUDP,
+			SrcIP:        net.ParseIP("2001:db8::1"),
+			DstIP:        net.ParseIP("2001:db9::1"),
+		},
+		&layers.UDP{SrcPort: 1234, DstPort: 53},
+	)
+	testCases := []struct {
+		Name    string
+		Cond    pktcls.Cond
+		Packet  *pktcls.Packet
+		ExpEval bool
+	}{
+		{
+			Name:    "Match IPv6 source",
+			Cond:    pktcls.NewCondIPv6(&pktcls.IPv6MatchSource{Net: network}),
+			Packet:  pkt,
+			ExpEval: true,
+		},
+		{
+			Name:    "Match IPv6 destination",
+			Cond:    pktcls.NewCondIPv6(&pktcls.IPv6MatchDestination{Net: network}),
+			Packet:  pkt,
+			ExpEval: false,
+		},
+		{
+			Name: "Match traffic class, flow label and next header",
+			Cond: pktcls.NewCondAllOf(
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchTrafficClass{TC: 0xb8}),
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchFlowLabel{FlowLabel: 12345}),
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchNextHeader{NextHeader: 17}),
+			),
+			Packet:  pkt,
+			ExpEval: true,
+		},
+		{
+			Name:    "IPv6 predicate on IPv4 packet",
+			Cond:    pktcls.NewCondIPv6(&pktcls.IPv6MatchTrafficClass{TC: 0}),
+			Packet:  newTestPacket(&layers.IPv4{}, []byte{1, 1, 1, 1}),
+			ExpEval: false,
+		},
+		{
+			Name: "IPv4 predicate on IPv6 packet",
+			Cond: pktcls.NewCondIPv4(&pktcls.IPv4MatchSource{
+				Net: &net.IPNet{IP: net.IPv4zero, Mask: net.CIDRMask(0, 32)},
+			}),
+			Packet:  pkt,
+			ExpEval: false,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.Name, func(t *testing.T) {
+			assert.Equal(t, test.ExpEval, test.Cond.Eval(test.Packet))
+		})
+	}
+}
+
+func TestL4Cond(t *testing.T) {
+	udp4 := newTestPacketL4(
+		&layers.IPv4{
+			Version:  4,
+			IHL:      5,
+			Protocol: layers.IPProtocolUDP,
+			SrcIP:    net.IP{192, 0, 2, 1},
+			DstIP:    net.IP{198, 51, 100, 1},
+		},
+		&layers.UDP{SrcPort: 1234, DstPort: 5060},
+	)
+	tcp6 := newTestPacket6(
+		&layers.IPv6{
+			NextHeader: layers.IPProtocolTCP,
+			SrcIP:      net.ParseIP("2001:db8::1"),
+			DstIP:      net.ParseIP("2001:db8::2"),
+		},
+		&layers.TCP{SrcPort: 40000, DstPort: 443},
+	)
+	icmp6 := newTestPacket6(
+		&layers.IPv6{
+			NextHeader: layers.IPProtocolICMPv6,
+			SrcIP:      net.ParseIP("2001:db8::1"),
+			DstIP:      net.ParseIP("2001:db8::2"),
+		},
+		gopacket.Payload([]byte{128, 0, 0, 0}),
+	)
+	all := pktcls.PortRange{Min: 0, Max: 65535}
+	testCases := []struct {
+		Name    string
+		Cond    pktcls.Cond
+		Packet  *pktcls.Packet
+		ExpEval bool
+	}{
+		{
+			Name:    "Match IPv4 protocol",
+			Cond:    pktcls.NewCondL4(&pktcls.L4MatchProtocol{Protocol: 17}),
+			Packet:  udp4,
+			ExpEval: true,
+		},
+		{
+			Name:    "Match IPv6 protocol",
+			Cond:    pktcls.NewCondL4(&pktcls.L4MatchProtocol{Protocol: 6}),
+			Packet:  tcp6,
+			ExpEval: true,
+		},
+		{
+			Name: "Match UDP destination port range",
+			Cond: pktcls.NewCondL4(&pktcls.L4MatchDstPort{
+				Ports: pktcls.PortRange{Min: 5060, Max: 5061},
+			}),
+			Packet:  udp4,
+			ExpEval: true,
+		},
+		{
+			Name: "Match TCP source port",
+			Cond: pktcls.NewCondL4(&pktcls.L4MatchSrcPort{
+				Ports: pktcls.PortRange{Min: 443, Max: 443},
+			}),
+			Packet:  tcp6,
+			ExpEval: false,
+		},
+		{
+			Name:    "Port on protocol without ports",
+			Cond:    pktcls.NewCondL4(&pktcls.L4MatchDstPort{Ports: all}),
+			Packet:  icmp6,
+			ExpEval: false,
+		},
+		{
+			Name:    "Nil packet",
+			Cond:    pktcls.NewCondL4(&pktcls.L4MatchProtocol{Protocol: 0}),
+			Packet:  nil,
+			ExpEval: false,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.Name, func(t *testing.T) {
+			assert.Equal(t, test.ExpEval, test.Cond.Eval(test.Packet))
+		})
+	}
+}
+
 func TestStringer(t *testing.T) {
+	_, net6, _ := net.ParseCIDR("2001:db8::/32")
 	_, net, _ := net.ParseCIDR("12.12.12.0/26")
 	tests := map[string]struct {
 		Cond pktcls.Cond
@@ -183,6 +330,25 @@ func TestStringer(t *testing.T) {
 				},
 			},
 		},
+		"IPv6 L4": {
+			Str: "all(src=2001:db8::/32,tc=0x2e,flowlabel=7,nexthdr=6,any(proto=17,sport=53," +
+				"dport=5000-6000))",
+			Cond: pktcls.CondAllOf{
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchSource{Net: net6}),
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchTrafficClass{TC: 0x2e}),
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchFlowLabel{FlowLabel: 7}),
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchNextHeader{NextHeader: 6}),
+				pktcls.CondAnyOf{
+					pktcls.NewCondL4(&pktcls.L4MatchProtocol{Protocol: 17}),
+					pktcls.NewCondL4(&pktcls.L4MatchSrcPort{
+						Ports: pktcls.PortRange{Min: 53, Max: 53},
+					}),
+					pktcls.NewCondL4(&pktcls.L4MatchDstPort{
+						Ports: pktcls.PortRange{Min: 5000, Max: 6000},
+					}),
+				},
+			},
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -205,3 +371,21 @@ func newTestPacket(ipv4 *layers.IPv4, pld []byte) *pktcls.Packet {
 	)
 	return pktcls.NewPacket(buf.Bytes())
 }
+
+func newTestPacket6(ipv6 *layers.IPv6, l4 gopacket.SerializableLayer) *pktcls.Packet {
+	ipv6.Version = 6
+	return newTestPacketL4(ipv6, l4)
+}
+
+// newTestPacketL4 creates a packet with the given network and upper layer.
+func newTestPacketL4(l3, l4 gopacket.SerializableLayer) *pktcls.Packet {
+	buf := gopacket.NewSerializeBuffer()
+	gopacket.SerializeLayers(
+		buf,
+		gopacket.SerializeOptions{FixLengths: true},
+		l3,
+		l4,
+		gopacket.Payload([]byte{1, 2, 3, 4}),
+	)
+	return pktcls.NewPacket(buf.Bytes())
+}
@@ -20,14 +20,24 @@
 // true for a ClsPkt, that packet is considered to be part of that class.
 //
 // The following conditions are supported:
-// AnyOf, AllOf, Boolean true, Boolean false and IPv4. AnyOf returns true if at
-// least one subcondition returns true. AllOf returns true if all subconditions
-// return true.  AllOf or AnyOf without subconditions return true. Boolean
-// conditions always return their internal value. IPv4 conditions include
-// predicates that compare the analyzed packet to preset values. Supported IPv4
-// conditions currently include destination network match, source network match
-// and ToS/DSCP fields match. Multiple predicates can be checked by enumerating
-// them under AllOf or AnyOf.
+// AnyOf, AllOf, Boolean true, Boolean false, IPv4, IPv6 and L4. AnyOf returns
+// true if at least one subcondition returns true. AllOf returns true if all
+// subconditions return true.  AllOf or AnyOf without subconditions return true.
+// Boolean conditions always return their internal value. IPv4, IPv6 and L4
+// conditions include predicates that compare the analyzed packet to preset
+// values. Supported IPv4 conditions currently include destination network
+// match, source network match and ToS/DSCP fields match. Supported IPv6
+// conditions include destination network match, source network match, and
+// traffic class, flow label and next header fields match. IPv4 conditions never
+// match IPv6 packets and vice versa. L4 conditions apply to both IPv4 and IPv6
+// packets and include upper layer protocol match, and TCP/UDP source and
+// destination port range match. Multiple predicates can be checked by
+// enumerating them under AllOf or AnyOf.
+//
+// In the human readable representation, the network version of src and dst is
+// inferred from the network, e.g., src=10.0.0.0/8 is an IPv4 condition and
+// src=2001:db8::/32 is an IPv6 condition. Port ranges are written as
+// dport=5000-6000.
 //
 // The package contains support for JSON marshaling and unmarshaling of
 // classes. Due to the custom formatting of the JSON output, marshaling must be
@@ -41,15 +41,25 @@ type Typer interface {
 // concrete type is unmarshaled.
 
 const (
-	TypeCondAllOf            = "CondAllOf"
-	TypeCondAnyOf            = "CondAnyOf"
-	TypeCondNot              = "CondNot"
-	TypeCondBool             = "CondBool"
-	TypeCondIPv4             = "CondIPv4"
-	TypeIPv4MatchSource      = "MatchSource"
-	TypeIPv4MatchDestination = "MatchDestination"
-	TypeIPv4MatchToS         = "MatchToS"
-	TypeIPv4MatchDSCP        = "MatchDSCP"
+	TypeCondAllOf             = "CondAllOf"
+	TypeCondAnyOf             = "CondAnyOf"
+	TypeCondNot               = "CondNot"
+	TypeCondBool              = "CondBool"
+	TypeCondIPv4              = "CondIPv4"
+	TypeIPv4MatchSource       = "MatchSource"
+	TypeIPv4MatchDestination  = "MatchDestination"
+	TypeIPv4MatchToS          = "MatchToS"
+	TypeIPv4MatchDSCP         = "MatchDSCP"
+	TypeCondIPv6              = "CondIPv6"
+	TypeIPv6MatchSource       = "IPv6MatchSource"
+	TypeIPv6MatchDestination  = "IPv6MatchDestination"
+	TypeIPv6MatchTrafficClass = "IPv6MatchTrafficClass"
+	TypeIPv6MatchFlowLabel    = "IPv6MatchFlowLabel"
+	TypeIPv6MatchNextHeader   = "IPv6MatchNextHeader"
+	TypeCondL4                = "CondL4"
+	TypeL4MatchProtocol       = "L4MatchProtocol"
+	TypeL4MatchSrcPort        = "L4MatchSrcPort"
+	TypeL4MatchDstPort        = "L4MatchDstPort"
 )
 
 // generic container for marshaling custom data
@@ -112,6 +122,46 @@ func unmarshalInterface(b []byte) (Typer, error) {
 			var p IPv4MatchDSCP
 			err := json.Unmarshal(*v, &p)
 			return &p, err
+		case TypeCondIPv6:
+			var c CondIPv6
+			err := json.Unmarshal(*v, &c)
+			return &c, err
+		case TypeIPv6MatchSource:
+			var p IPv6MatchSource
+			err := json.Unmarshal(*v, &p)
+			return &p, err
+		case TypeIPv6MatchDestination:
+			var p IPv6MatchDestination
+			err := json.Unmarshal(*v, &p)
+			return &p, err
+		case TypeIPv6MatchTrafficClass:
+			var p IPv6MatchTrafficClass
+			err := json.Unmarshal(*v, &p)
+			return &p, err
+		case TypeIPv6MatchFlowLabel:
+			var p IPv6MatchFlowLabel
+			err := json.Unmarshal(*v, &p)
+			return &p, err
+		case TypeIPv6MatchNextHeader:
+			var p IPv6MatchNextHeader
+			err := json.Unmarshal(*v, &p)
+			return &p, err
+		case TypeCondL4:
+			var c CondL4
+			err := json.Unmarshal(*v, &c)
+			return &c, err
+		case TypeL4MatchProtocol:
+			var p L4MatchProtocol
+			err := json.Unmarshal(*v, &p)
+			return &p, err
+		case TypeL4MatchSrcPort:
+			var p L4MatchSrcPort
+			err := json.Unmarshal(*v, &p)
+			return &p, err
+		case TypeL4MatchDstPort:
+			var p L4MatchDstPort
+			err := json.Unmarshal(*v, &p)
+			return &p, err
 		default:
 			return nil, common.NewBasicError("Unknown type", nil, "type", k)
 		}
@@ -145,6 +195,32 @@ func unmarshalPredicate(b []byte) (IPv4Predicate, error) {
 	return p, nil
 }
 
+// unmarshalIPv6Predicate extracts an IPv6Predicate from a JSON encoding
+func unmarshalIPv6Predicate(b []byte) (IPv6Predicate, error) {
+	t, err := unmarshalInterface(b)
+	if err != nil {
+		return nil, err
+	}
+	p, ok := t.(IPv6Predicate)
+	if !ok {
+		return nil, serrors.New("Unable to extract IPv6Predicate from interface")
+	}
+	return p, nil
+}
+
+// unmarshalL4Predicate extracts an L4Predicate from a JSON encoding
+func unmarshalL4Predicate(b []byte) (L4Predicate, error) {
+	t, err := unmarshalInterface(b)
+	if err != nil {
+		return nil, err
+	}
+	p, ok := t.(L4Predicate)
+	if !ok {
+		return nil, serrors.New("Unable to extract L4Predicate from interface")
+	}
+	return p, nil
+}
+
 // Special case slices because we only need them for Conds
 
 func marshalCondSlice(conds []Cond) ([]byte, error) {
@@ -28,9 +28,45 @@ type Packet struct {
 	parsedPkt gopacket.Packet
 }
 
+// NewPacket parses raw as an IPv4 or IPv6 packet, depending on the IP version
+// field.
 func NewPacket(raw common.RawBytes) *Packet {
+	first := layers.LayerTypeIPv4
+	if len(raw) > 0 && raw[0]>>4 == 6 {
+		first = layers.LayerTypeIPv6
+	}
 	return &Packet{
 		rawPkt:    raw,
-		parsedPkt: gopacket.NewPacket(raw, layers.LayerTypeIPv4, gopacket.NoCopy),
+		parsedPkt: gopacket.NewPacket(raw, first, gopacket.NoCopy),
+	}
+}
+
+// l4Header extracts the upper layer fields of the packet. For IPv6 packets,
+// the extension headers are traversed to find the upper layer protocol.
+func (p *Packet) l4Header() (*L4Header, bool) {
+	var hdr L4Header
+	var ok bool
+	for _, l := range p.parsedPkt.Layers() {
+		switch l := l.(type) {
+		case *layers.IPv4:
+			hdr.Protocol, ok = l.Protocol, true
+		case *layers.IPv6:
+			hdr.Protocol, ok = l.NextHeader, true
+		case *layers.IPv6HopByHop:
+			hdr.Protocol = l.NextHeader
+		case *layers.IPv6Routing:
+			hdr.Protocol = l.NextHeader
+		case *layers.IPv6Fragment:
+			hdr.Protocol = l.NextHeader
+		case *layers.IPv6Destination:
+			hdr.Protocol = l.NextHeader
+		case *layers.TCP:
+			hdr.HasPorts = true
+			hdr.SrcPort, hdr.DstPort = uint16(l.SrcPort), uint16(l.DstPort)
+		case *layers.UDP:
+			hdr.HasPorts = true
+			hdr.SrcPort, hdr.DstPort = uint16(l.SrcPort), uint16(l.DstPort)
+		}
 	}
+	return &hdr, ok
 }
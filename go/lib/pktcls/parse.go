@@ -69,24 +69,28 @@ func (l *classListener) EnterTrafficClass(ctx *traffic_class.TrafficClassContext
 
 func (l *classListener) EnterMatchDst(ctx *traffic_class.MatchDstContext) {
 	// Push Selector as Predicate on stack and update the number of Conds on the stack
-	var err error
-	mdst := &IPv4MatchDestination{}
-	_, mdst.Net, err = net.ParseCIDR(ctx.GetStop().GetText())
+	_, network, err := net.ParseCIDR(ctx.GetStop().GetText())
 	if err != nil {
 		l.err = common.NewBasicError("CIDR parsing failed!", err, "cidr", ctx.GetStop().GetText())
 	}
-	l.pushCond(NewCondIPv4(mdst))
+	if ctx.NET6() != nil {
+		l.pushCond(NewCondIPv6(&IPv6MatchDestination{Net: network}))
+		return
+	}
+	l.pushCond(NewCondIPv4(&IPv4MatchDestination{Net: network}))
 }
 
 func (l *classListener) EnterMatchSrc(ctx *traffic_class.MatchSrcContext) {
 	// Push Selector as Predicate on stack and update the number of Conds on the stack
-	var err error
-	msrc := &IPv4MatchSource{}
-	_, msrc.Net, err = net.ParseCIDR(ctx.GetStop().GetText())
+	_, network, err := net.ParseCIDR(ctx.GetStop().GetText())
 	if err != nil {
 		l.err = common.NewBasicError("CIDR parsing failed!", err, "cidr", ctx.GetStop().GetText())
 	}
-	l.pushCond(NewCondIPv4(msrc))
+	if ctx.NET6() != nil {
+		l.pushCond(NewCondIPv6(&IPv6MatchSource{Net: network}))
+		return
+	}
+	l.pushCond(NewCondIPv4(&IPv4MatchSource{Net: network}))
 }
 
 func (l *classListener) EnterMatchDSCP(ctx *traffic_class.MatchDSCPContext) {
@@ -111,6 +115,58 @@ func (l *classListener) EnterMatchTOS(ctx *traffic_class.MatchTOSContext) {
 	l.pushCond(NewCondIPv4(mtos))
 }
 
+func (l *classListener) EnterMatchTC(ctx *traffic_class.MatchTCContext) {
+	tc, err := strconv.ParseUint(ctx.GetStop().GetText(), 16, 8)
+	if err != nil {
+		l.err = common.NewBasicError("TC parsing failed!", err, "tc", ctx.GetStop().GetText())
+	}
+	l.pushCond(NewCondIPv6(&IPv6MatchTrafficClass{TC: uint8(tc)}))
+}
+
+func (l *classListener) EnterMatchFlowLabel(ctx *traffic_class.MatchFlowLabelContext) {
+	// The flow label is 20 bits wide.
+	label, err := strconv.ParseUint(ctx.GetStop().GetText(), 10, 20)
+	if err != nil {
+		l.err = common.NewBasicError("Flow label parsing failed!", err,
+			"flowlabel", ctx.GetStop().GetText())
+	}
+	l.pushCond(NewCondIPv6(&IPv6MatchFlowLabel{FlowLabel: uint32(label)}))
+}
+
+func (l *classListener) EnterMatchNextHdr(ctx *traffic_class.MatchNextHdrContext) {
+	nh, err := strconv.ParseUint(ctx.GetStop().GetText(), 10, 8)
+	if err != nil {
+		l.err = common.NewBasicError("Next header parsing failed!", err,
+			"nexthdr", ctx.GetStop().GetText())
+	}
+	l.pushCond(NewCondIPv6(&IPv6MatchNextHeader{NextHeader: uint8(nh)}))
+}
+
+func (l *classListener) EnterMatchProto(ctx *traffic_class.MatchProtoContext) {
+	proto, err := strconv.ParseUint(ctx.GetStop().GetText(), 10, 8)
+	if err != nil {
+		l.err = common.NewBasicError("Protocol parsing failed!", err,
+			"proto", ctx.GetStop().GetText())
+	}
+	l.pushCond(NewCondL4(&L4MatchProtocol{Protocol: uint8(proto)}))
+}
+
+func (l *classListener) EnterMatchSrcPort(ctx *traffic_class.MatchSrcPortContext) {
+	ports, err := ParsePortRange(ctx.GetStop().GetText())
+	if err != nil {
+		l.err = err
+	}
+	l.pushCond(NewCondL4(&L4MatchSrcPort{Ports: ports}))
+}
+
+func (l *classListener) EnterMatchDstPort(ctx *traffic_class.MatchDstPortContext) {
+	ports, err := ParsePortRange(ctx.GetStop().GetText())
+	if err != nil {
+		l.err = err
+	}
+	l.pushCond(NewCondL4(&L4MatchDstPort{Ports: ports}))
+}
+
 func (l *classListener) EnterCondCls(ctx *traffic_class.CondClsContext) {
 	l.pushCond(CondClass{TrafficClass: ctx.GetStop().GetText()})
 }
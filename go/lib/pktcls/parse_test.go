@@ -109,6 +109,76 @@ func TestTrafficClassValidation(t *testing.T) {
 			Class: "ANY(dscp=0x2,ALL(dst=12.12.12.0/24,dscp=0x2, NOT(src=2.2.2.0/28)))",
 			Valid: true,
 		},
+		{
+			Name:  "src IPv6Cond",
+			Class: "src=2001:db8::/32",
+			Valid: true,
+		},
+		{
+			Name:  "dst IPv6Cond",
+			Class: "dst=::/0",
+			Valid: true,
+		},
+		{
+			Name:  "bad dst IPv6Cond",
+			Class: "dst=2001:db8:::/32",
+			Valid: false,
+		},
+		{
+			Name:  "tc IPv6Cond",
+			Class: "tc=0xb8",
+			Valid: true,
+		},
+		{
+			Name:  "flowlabel IPv6Cond",
+			Class: "flowlabel=1048575",
+			Valid: true,
+		},
+		{
+			Name:  "bad flowlabel IPv6Cond",
+			Class: "flowlabel=1048576",
+			Valid: false,
+		},
+		{
+			Name:  "nexthdr IPv6Cond",
+			Class: "nexthdr=17",
+			Valid: true,
+		},
+		{
+			Name:  "proto L4Cond",
+			Class: "proto=6",
+			Valid: true,
+		},
+		{
+			Name:  "bad proto L4Cond",
+			Class: "proto=256",
+			Valid: false,
+		},
+		{
+			Name:  "sport L4Cond",
+			Class: "sport=53",
+			Valid: true,
+		},
+		{
+			Name:  "dport range L4Cond",
+			Class: "dport=5000-6000",
+			Valid: true,
+		},
+		{
+			Name:  "bad dport range L4Cond",
+			Class: "dport=6000-5000",
+			Valid: false,
+		},
+		{
+			Name:  "bad dport L4Cond",
+			Class: "dport=65536",
+			Valid: false,
+		},
+		{
+			Name:  "ALL IPv6 L4",
+			Class: "ALL(src=2001:db8::/32,tc=0x2e,ANY(dport=80,dport=443))",
+			Valid: true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -124,6 +194,7 @@ func TestTrafficClassValidation(t *testing.T) {
 }
 
 func TestTrafficClassTree(t *testing.T) {
+	_, net6, _ := net.ParseCIDR("2001:db8::/32")
 	_, net, _ := net.ParseCIDR("12.12.12.0/26")
 	testCases := []struct {
 		Name  string
@@ -205,6 +276,42 @@ func TestTrafficClassTree(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name:  "src IPv6Cond",
+			Class: "src=2001:db8::/32",
+			Tree: pktcls.NewCondIPv6(
+				&pktcls.IPv6MatchSource{Net: net6},
+			),
+		},
+		{
+			Name:  "dst IPv6Cond",
+			Class: "dst=2001:db8::/32",
+			Tree: pktcls.NewCondIPv6(
+				&pktcls.IPv6MatchDestination{Net: net6},
+			),
+		},
+		{
+			Name:  "tc flowlabel nexthdr IPv6Cond",
+			Class: "ALL(TC=0x2e,FLOWLABEL=12345,NEXTHDR=17)",
+			Tree: pktcls.CondAllOf{
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchTrafficClass{TC: 0x2e}),
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchFlowLabel{FlowLabel: 12345}),
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchNextHeader{NextHeader: 17}),
+			},
+		},
+		{
+			Name:  "proto sport dport L4Cond",
+			Class: "ALL(proto=17,sport=53,dport=5000-6000)",
+			Tree: pktcls.CondAllOf{
+				pktcls.NewCondL4(&pktcls.L4MatchProtocol{Protocol: 17}),
+				pktcls.NewCondL4(&pktcls.L4MatchSrcPort{
+					Ports: pktcls.PortRange{Min: 53, Max: 53},
+				}),
+				pktcls.NewCondL4(&pktcls.L4MatchDstPort{
+					Ports: pktcls.PortRange{Min: 5000, Max: 6000},
+				}),
+			},
+		},
 	}
 
 	for _, tc := range testCases {
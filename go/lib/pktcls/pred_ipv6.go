@@ -0,0 +1,245 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pktcls
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+
+	"github.com/google/gopacket/layers"
+
+	"github.com/scionproto/scion/go/lib/common"
+)
+
+// IPv6Predicate describes a single test on various IPv6 packet fields.
+type IPv6Predicate interface {
+	// Eval returns true if the IPv6 packet matched the predicate
+	Eval(*layers.IPv6) bool
+	Typer
+	fmt.Stringer
+}
+
+var _ IPv6Predicate = (*IPv6MatchSource)(nil)
+
+// IPv6MatchSource checks whether the source IPv6 address is contained in Net.
+type IPv6MatchSource struct {
+	Net *net.IPNet
+}
+
+func (m *IPv6MatchSource) Type() string {
+	return TypeIPv6MatchSource
+}
+
+func (m *IPv6MatchSource) Eval(p *layers.IPv6) bool {
+	return m.Net.Contains(p.SrcIP)
+}
+
+func (m *IPv6MatchSource) String() string {
+	if m.Net == nil {
+		return "src="
+	}
+	return fmt.Sprintf("src=%s", m.Net)
+}
+
+func (m *IPv6MatchSource) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		jsonContainer{
+			"Net": m.Net.String(),
+		},
+	)
+}
+
+func (m *IPv6MatchSource) UnmarshalJSON(b []byte) error {
+	network, err := unmarshalIPv6NetField(b, TypeIPv6MatchSource)
+	if err != nil {
+		return err
+	}
+	m.Net = network
+	return nil
+}
+
+var _ IPv6Predicate = (*IPv6MatchDestination)(nil)
+
+// IPv6MatchDestination checks whether the destination IPv6 address is
+// contained in Net.
+type IPv6MatchDestination struct {
+	Net *net.IPNet
+}
+
+func (m *IPv6MatchDestination) Type() string {
+	return TypeIPv6MatchDestination
+}
+
+func (m *IPv6MatchDestination) Eval(p *layers.IPv6) bool {
+	return m.Net.Contains(p.DstIP)
+}
+
+func (m *IPv6MatchDestination) String() string {
+	if m.Net == nil {
+		return "dst="
+	}
+	return fmt.Sprintf("dst=%s", m.Net)
+}
+
+func (m *IPv6MatchDestination) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		jsonContainer{
+			"Net": m.Net.String(),
+		},
+	)
+}
+
+func (m *IPv6MatchDestination) UnmarshalJSON(b []byte) error {
+	network, err := unmarshalIPv6NetField(b, TypeIPv6MatchDestination)
+	if err != nil {
+		return err
+	}
+	m.Net = network
+	return nil
+}
+
+var _ IPv6Predicate = (*IPv6MatchTrafficClass)(nil)
+
+// IPv6MatchTrafficClass checks whether the traffic class field matches.
+type IPv6MatchTrafficClass struct {
+	TC uint8
+}
+
+func (m *IPv6MatchTrafficClass) Type() string {
+	return TypeIPv6MatchTrafficClass
+}
+
+func (m *IPv6MatchTrafficClass) Eval(p *layers.IPv6) bool {
+	return m.TC == p.TrafficClass
+}
+
+func (m *IPv6MatchTrafficClass) String() string {
+	return fmt.Sprintf("tc=%s", m.toHex())
+}
+
+func (m *IPv6MatchTrafficClass) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		jsonContainer{
+			"TC": m.toHex(),
+		},
+	)
+}
+
+func (m *IPv6MatchTrafficClass) toHex() string {
+	return fmt.Sprintf("%#x", m.TC)
+}
+
+func (m *IPv6MatchTrafficClass) UnmarshalJSON(b []byte) error {
+	// Format is 0x hex number in quoted string
+	i, err := unmarshalUintField(b, "TC", "TC", 8)
+	if err != nil {
+		return err
+	}
+	m.TC = uint8(i)
+	return nil
+}
+
+var _ IPv6Predicate = (*IPv6MatchFlowLabel)(nil)
+
+// IPv6MatchFlowLabel checks whether the flow label matches.
+type IPv6MatchFlowLabel struct {
+	FlowLabel uint32
+}
+
+func (m *IPv6MatchFlowLabel) Type() string {
+	return TypeIPv6MatchFlowLabel
+}
+
+func (m *IPv6MatchFlowLabel) Eval(p *layers.IPv6) bool {
+	return m.FlowLabel == p.FlowLabel
+}
+
+func (m *IPv6MatchFlowLabel) String() string {
+	return fmt.Sprintf("flowlabel=%d", m.FlowLabel)
+}
+
+func (m *IPv6MatchFlowLabel) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		jsonContainer{
+			"FlowLabel": fmt.Sprint(m.FlowLabel),
+		},
+	)
+}
+
+func (m *IPv6MatchFlowLabel) UnmarshalJSON(b []byte) error {
+	// The flow label is 20 bits wide.
+	i, err := unmarshalUintField(b, "FlowLabel", "FlowLabel", 20)
+	if err != nil {
+		return err
+	}
+	m.FlowLabel = uint32(i)
+	return nil
+}
+
+var _ IPv6Predicate = (*IPv6MatchNextHeader)(nil)
+
+// IPv6MatchNextHeader checks whether the next header field of the fixed IPv6
+// header matches. Extension headers are not traversed, use L4MatchProtocol to
+// match the upper layer protocol.
+type IPv6MatchNextHeader struct {
+	NextHeader uint8
+}
+
+func (m *IPv6MatchNextHeader) Type() string {
+	return TypeIPv6MatchNextHeader
+}
+
+func (m *IPv6MatchNextHeader) Eval(p *layers.IPv6) bool {
+	return m.NextHeader == uint8(p.NextHeader)
+}
+
+func (m *IPv6MatchNextHeader) String() string {
+	return fmt.Sprintf("nexthdr=%d", m.NextHeader)
+}
+
+func (m *IPv6MatchNextHeader) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		jsonContainer{
+			"NextHeader": fmt.Sprint(m.NextHeader),
+		},
+	)
+}
+
+func (m *IPv6MatchNextHeader) UnmarshalJSON(b []byte) error {
+	i, err := unmarshalUintField(b, "NextHeader", "NextHeader", 8)
+	if err != nil {
+		return err
+	}
+	m.NextHeader = uint8(i)
+	return nil
+}
+
+// unmarshalIPv6NetField extracts the IPv6 network from the Net field.
+func unmarshalIPv6NetField(b []byte, name string) (*net.IPNet, error) {
+	s, err := unmarshalStringField(b, name, "Net")
+	if err != nil {
+		return nil, err
+	}
+	_, network, err := net.ParseCIDR(s)
+	if err != nil {
+		return nil, common.NewBasicError("Unable to parse network operand", err, "name", name)
+	}
+	if network.IP.To4() != nil {
+		return nil, common.NewBasicError("Operand is not an IPv6 network", nil,
+			"name", name, "net", s)
+	}
+	return network, nil
+}
@@ -0,0 +1,173 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pktcls
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/gopacket/layers"
+
+	"github.com/scionproto/scion/go/lib/common"
+)
+
+// L4Header contains the upper layer fields of a packet that can be matched by
+// L4 predicates. It is extracted from both IPv4 and IPv6 packets.
+type L4Header struct {
+	// Protocol is the upper layer protocol. For IPv6 packets, it is the next
+	// header value of the last extension header.
+	Protocol layers.IPProtocol
+	// HasPorts indicates whether the protocol carries ports, i.e., the packet
+	// is a TCP or UDP packet.
+	HasPorts bool
+	SrcPort  uint16
+	DstPort  uint16
+}
+
+// L4Predicate describes a single test on the upper layer fields of a packet.
+type L4Predicate interface {
+	// Eval returns true if the L4 header matched the predicate
+	Eval(*L4Header) bool
+	Typer
+	fmt.Stringer
+}
+
+var _ L4Predicate = (*L4MatchProtocol)(nil)
+
+// L4MatchProtocol checks whether the upper layer protocol matches.
+type L4MatchProtocol struct {
+	Protocol uint8
+}
+
+func (m *L4MatchProtocol) Type() string {
+	return TypeL4MatchProtocol
+}
+
+func (m *L4MatchProtocol) Eval(h *L4Header) bool {
+	return m.Protocol == uint8(h.Protocol)
+}
+
+func (m *L4MatchProtocol) String() string {
+	return fmt.Sprintf("proto=%d", m.Protocol)
+}
+
+func (m *L4MatchProtocol) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		jsonContainer{
+			"Protocol": fmt.Sprint(m.Protocol),
+		},
+	)
+}
+
+func (m *L4MatchProtocol) UnmarshalJSON(b []byte) error {
+	i, err := unmarshalUintField(b, "Protocol", "Protocol", 8)
+	if err != nil {
+		return err
+	}
+	m.Protocol = uint8(i)
+	return nil
+}
+
+// PortRange is an inclusive range of TCP or UDP ports.
+type PortRange struct {
+	Min uint16
+	Max uint16
+}
+
+// ParsePortRange parses a single port ("80") or a range of ports ("80-90").
+func ParsePortRange(s string) (PortRange, error) {
+	parts := strings.SplitN(s, "-", 2)
+	min, err := strconv.ParseUint(parts[0], 10, 16)
+	if err != nil {
+		return PortRange{}, common.NewBasicError("Unable to parse port", err, "raw", s)
+	}
+	max := min
+	if len(parts) == 2 {
+		if max, err = strconv.ParseUint(parts[1], 10, 16); err != nil {
+			return PortRange{}, common.NewBasicError("Unable to parse port", err, "raw", s)
+		}
+	}
+	if min > max {
+		return PortRange{}, common.NewBasicError("Invalid port range", nil, "raw", s)
+	}
+	return PortRange{Min: uint16(min), Max: uint16(max)}, nil
+}
+
+func (r PortRange) contains(port uint16) bool {
+	return r.Min <= port && port <= r.Max
+}
+
+func (r PortRange) String() string {
+	if r.Min == r.Max {
+		return fmt.Sprint(r.Min)
+	}
+	return fmt.Sprintf("%d-%d", r.Min, r.Max)
+}
+
+func (r PortRange) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.String())
+}
+
+func (r *PortRange) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return common.NewBasicError("Port range is non-string", err)
+	}
+	var err error
+	*r, err = ParsePortRange(s)
+	return err
+}
+
+var _ L4Predicate = (*L4MatchSrcPort)(nil)
+
+// L4MatchSrcPort checks whether the TCP or UDP source port is contained in
+// Ports. Packets of other protocols never match.
+type L4MatchSrcPort struct {
+	Ports PortRange
+}
+
+func (m *L4MatchSrcPort) Type() string {
+	return TypeL4MatchSrcPort
+}
+
+func (m *L4MatchSrcPort) Eval(h *L4Header) bool {
+	return h.HasPorts && m.Ports.contains(h.SrcPort)
+}
+
+func (m *L4MatchSrcPort) String() string {
+	return fmt.Sprintf("sport=%s", m.Ports)
+}
+
+var _ L4Predicate = (*L4MatchDstPort)(nil)
+
+// L4MatchDstPort checks whether the TCP or UDP destination port is contained
+// in Ports. Packets of other protocols never match.
+type L4MatchDstPort struct {
+	Ports PortRange
+}
+
+func (m *L4MatchDstPort) Type() string {
+	return TypeL4MatchDstPort
+}
+
+func (m *L4MatchDstPort) Eval(h *L4Header) bool {
+	return h.HasPorts && m.Ports.contains(h.DstPort)
+}
+
+func (m *L4MatchDstPort) String() string {
+	return fmt.Sprintf("dport=%s", m.Ports)
+}
@@ -44,6 +44,42 @@ func (s *BaseTrafficClassListener) EnterMatchTOS(ctx *MatchTOSContext) {}
 // ExitMatchTOS is called when production matchTOS is exited.
 func (s *BaseTrafficClassListener) ExitMatchTOS(ctx *MatchTOSContext) {}
 
+// EnterMatchTC is called when production matchTC is entered.
+func (s *BaseTrafficClassListener) EnterMatchTC(ctx *MatchTCContext) {}
+
+// ExitMatchTC is called when production matchTC is exited.
+func (s *BaseTrafficClassListener) ExitMatchTC(ctx *MatchTCContext) {}
+
+// EnterMatchFlowLabel is called when production matchFlowLabel is entered.
+func (s *BaseTrafficClassListener) EnterMatchFlowLabel(ctx *MatchFlowLabelContext) {}
+
+// ExitMatchFlowLabel is called when production matchFlowLabel is exited.
+func (s *BaseTrafficClassListener) ExitMatchFlowLabel(ctx *MatchFlowLabelContext) {}
+
+// EnterMatchNextHdr is called when production matchNextHdr is entered.
+func (s *BaseTrafficClassListener) EnterMatchNextHdr(ctx *MatchNextHdrContext) {}
+
+// ExitMatchNextHdr is called when production matchNextHdr is exited.
+func (s *BaseTrafficClassListener) ExitMatchNextHdr(ctx *MatchNextHdrContext) {}
+
+// EnterMatchProto is called when production matchProto is entered.
+func (s *BaseTrafficClassListener) EnterMatchProto(ctx *MatchProtoContext) {}
+
+// ExitMatchProto is called when production matchProto is exited.
+func (s *BaseTrafficClassListener) ExitMatchProto(ctx *MatchProtoContext) {}
+
+// EnterMatchSrcPort is called when production matchSrcPort is entered.
+func (s *BaseTrafficClassListener) EnterMatchSrcPort(ctx *MatchSrcPortContext) {}
+
+// ExitMatchSrcPort is called when production matchSrcPort is exited.
+func (s *BaseTrafficClassListener) ExitMatchSrcPort(ctx *MatchSrcPortContext) {}
+
+// EnterMatchDstPort is called when production matchDstPort is entered.
+func (s *BaseTrafficClassListener) EnterMatchDstPort(ctx *MatchDstPortContext) {}
+
+// ExitMatchDstPort is called when production matchDstPort is exited.
+func (s *BaseTrafficClassListener) ExitMatchDstPort(ctx *MatchDstPortContext) {}
+
 // EnterCondCls is called when production condCls is entered.
 func (s *BaseTrafficClassListener) EnterCondCls(ctx *CondClsContext) {}
 
@@ -80,6 +116,18 @@ func (s *BaseTrafficClassListener) EnterCondIPv4(ctx *CondIPv4Context) {}
 // ExitCondIPv4 is called when production condIPv4 is exited.
 func (s *BaseTrafficClassListener) ExitCondIPv4(ctx *CondIPv4Context) {}
 
+// EnterCondIPv6 is called when production condIPv6 is entered.
+func (s *BaseTrafficClassListener) EnterCondIPv6(ctx *CondIPv6Context) {}
+
+// ExitCondIPv6 is called when production condIPv6 is exited.
+func (s *BaseTrafficClassListener) ExitCondIPv6(ctx *CondIPv6Context) {}
+
+// EnterCondL4 is called when production condL4 is entered.
+func (s *BaseTrafficClassListener) EnterCondL4(ctx *CondL4Context) {}
+
+// ExitCondL4 is called when production condL4 is exited.
+func (s *BaseTrafficClassListener) ExitCondL4(ctx *CondL4Context) {}
+
 // EnterCond is called when production cond is entered.
 func (s *BaseTrafficClassListener) EnterCond(ctx *CondContext) {}
 
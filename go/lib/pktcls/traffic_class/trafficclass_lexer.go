@@ -14,82 +14,134 @@ var _ = fmt.Printf
 var _ = unicode.IsLetter
 
 var serializedLexerAtn = []uint16{
-	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 2, 22, 171,
+	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 2, 30, 283,
 	8, 1, 4, 2, 9, 2, 4, 3, 9, 3, 4, 4, 9, 4, 4, 5, 9, 5, 4, 6, 9, 6, 4, 7,
 	9, 7, 4, 8, 9, 8, 4, 9, 9, 9, 4, 10, 9, 10, 4, 11, 9, 11, 4, 12, 9, 12,
 	4, 13, 9, 13, 4, 14, 9, 14, 4, 15, 9, 15, 4, 16, 9, 16, 4, 17, 9, 17, 4,
-	18, 9, 18, 4, 19, 9, 19, 4, 20, 9, 20, 4, 21, 9, 21, 3, 2, 3, 2, 3, 3,
-	3, 3, 3, 3, 3, 3, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 5, 3, 5, 3, 6, 3, 6,
-	3, 7, 3, 7, 3, 8, 3, 8, 3, 8, 3, 8, 3, 8, 3, 9, 3, 9, 3, 9, 3, 9, 3, 9,
-	3, 9, 3, 10, 6, 10, 73, 10, 10, 13, 10, 14, 10, 74, 3, 10, 3, 10, 3, 11,
-	3, 11, 3, 11, 7, 11, 82, 10, 11, 12, 11, 14, 11, 85, 11, 11, 5, 11, 87,
-	10, 11, 3, 12, 6, 12, 90, 10, 12, 13, 12, 14, 12, 91, 3, 13, 3, 13, 3,
-	13, 3, 13, 3, 13, 3, 13, 3, 13, 3, 13, 3, 13, 3, 13, 3, 14, 3, 14, 3, 14,
-	3, 14, 3, 14, 3, 14, 5, 14, 110, 10, 14, 3, 15, 3, 15, 3, 15, 3, 15, 3,
-	15, 3, 15, 5, 15, 118, 10, 15, 3, 16, 3, 16, 3, 16, 3, 16, 3, 16, 3, 16,
-	5, 16, 126, 10, 16, 3, 17, 3, 17, 3, 17, 3, 17, 3, 17, 3, 17, 3, 17, 3,
-	17, 5, 17, 136, 10, 17, 3, 18, 3, 18, 3, 18, 3, 18, 3, 18, 3, 18, 5, 18,
-	144, 10, 18, 3, 19, 3, 19, 3, 19, 3, 19, 3, 19, 3, 19, 5, 19, 152, 10,
-	19, 3, 20, 3, 20, 3, 20, 3, 20, 3, 20, 3, 20, 3, 20, 3, 20, 5, 20, 162,
-	10, 20, 3, 21, 3, 21, 3, 21, 3, 21, 3, 21, 3, 21, 5, 21, 170, 10, 21, 2,
-	2, 22, 3, 3, 5, 4, 7, 5, 9, 6, 11, 7, 13, 8, 15, 9, 17, 10, 19, 11, 21,
-	12, 23, 13, 25, 14, 27, 15, 29, 16, 31, 17, 33, 18, 35, 19, 37, 20, 39,
-	21, 41, 22, 3, 2, 6, 5, 2, 11, 12, 15, 15, 34, 34, 3, 2, 51, 59, 3, 2,
-	50, 59, 5, 2, 50, 59, 67, 72, 99, 104, 2, 182, 2, 3, 3, 2, 2, 2, 2, 5,
-	3, 2, 2, 2, 2, 7, 3, 2, 2, 2, 2, 9, 3, 2, 2, 2, 2, 11, 3, 2, 2, 2, 2, 13,
-	3, 2, 2, 2, 2, 15, 3, 2, 2, 2, 2, 17, 3, 2, 2, 2, 2, 19, 3, 2, 2, 2, 2,
-	21, 3, 2, 2, 2, 2, 23, 3, 2, 2, 2, 2, 25, 3, 2, 2, 2, 2, 27, 3, 2, 2, 2,
-	2, 29, 3, 2, 2, 2, 2, 31, 3, 2, 2, 2, 2, 33, 3, 2, 2, 2, 2, 35, 3, 2, 2,
-	2, 2, 37, 3, 2, 2, 2, 2, 39, 3, 2, 2, 2, 2, 41, 3, 2, 2, 2, 3, 43, 3, 2,
-	2, 2, 5, 45, 3, 2, 2, 2, 7, 49, 3, 2, 2, 2, 9, 54, 3, 2, 2, 2, 11, 56,
-	3, 2, 2, 2, 13, 58, 3, 2, 2, 2, 15, 60, 3, 2, 2, 2, 17, 65, 3, 2, 2, 2,
-	19, 72, 3, 2, 2, 2, 21, 86, 3, 2, 2, 2, 23, 89, 3, 2, 2, 2, 25, 93, 3,
-	2, 2, 2, 27, 109, 3, 2, 2, 2, 29, 117, 3, 2, 2, 2, 31, 125, 3, 2, 2, 2,
-	33, 135, 3, 2, 2, 2, 35, 143, 3, 2, 2, 2, 37, 151, 3, 2, 2, 2, 39, 161,
-	3, 2, 2, 2, 41, 169, 3, 2, 2, 2, 43, 44, 7, 63, 2, 2, 44, 4, 3, 2, 2, 2,
-	45, 46, 7, 63, 2, 2, 46, 47, 7, 50, 2, 2, 47, 48, 7, 122, 2, 2, 48, 6,
-	3, 2, 2, 2, 49, 50, 7, 101, 2, 2, 50, 51, 7, 110, 2, 2, 51, 52, 7, 117,
-	2, 2, 52, 53, 7, 63, 2, 2, 53, 8, 3, 2, 2, 2, 54, 55, 7, 42, 2, 2, 55,
-	10, 3, 2, 2, 2, 56, 57, 7, 46, 2, 2, 57, 12, 3, 2, 2, 2, 58, 59, 7, 43,
-	2, 2, 59, 14, 3, 2, 2, 2, 60, 61, 7, 118, 2, 2, 61, 62, 7, 116, 2, 2, 62,
-	63, 7, 119, 2, 2, 63, 64, 7, 103, 2, 2, 64, 16, 3, 2, 2, 2, 65, 66, 7,
-	104, 2, 2, 66, 67, 7, 99, 2, 2, 67, 68, 7, 110, 2, 2, 68, 69, 7, 117, 2,
-	2, 69, 70, 7, 103, 2, 2, 70, 18, 3, 2, 2, 2, 71, 73, 9, 2, 2, 2, 72, 71,
-	3, 2, 2, 2, 73, 74, 3, 2, 2, 2, 74, 72, 3, 2, 2, 2, 74, 75, 3, 2, 2, 2,
-	75, 76, 3, 2, 2, 2, 76, 77, 8, 10, 2, 2, 77, 20, 3, 2, 2, 2, 78, 87, 7,
-	50, 2, 2, 79, 83, 9, 3, 2, 2, 80, 82, 9, 4, 2, 2, 81, 80, 3, 2, 2, 2, 82,
-	85, 3, 2, 2, 2, 83, 81, 3, 2, 2, 2, 83, 84, 3, 2, 2, 2, 84, 87, 3, 2, 2,
-	2, 85, 83, 3, 2, 2, 2, 86, 78, 3, 2, 2, 2, 86, 79, 3, 2, 2, 2, 87, 22,
-	3, 2, 2, 2, 88, 90, 9, 5, 2, 2, 89, 88, 3, 2, 2, 2, 90, 91, 3, 2, 2, 2,
-	91, 89, 3, 2, 2, 2, 91, 92, 3, 2, 2, 2, 92, 24, 3, 2, 2, 2, 93, 94, 5,
-	21, 11, 2, 94, 95, 7, 48, 2, 2, 95, 96, 5, 21, 11, 2, 96, 97, 7, 48, 2,
-	2, 97, 98, 5, 21, 11, 2, 98, 99, 7, 48, 2, 2, 99, 100, 5, 21, 11, 2, 100,
-	101, 7, 49, 2, 2, 101, 102, 5, 21, 11, 2, 102, 26, 3, 2, 2, 2, 103, 104,
-	7, 67, 2, 2, 104, 105, 7, 80, 2, 2, 105, 110, 7, 91, 2, 2, 106, 107, 7,
-	99, 2, 2, 107, 108, 7, 112, 2, 2, 108, 110, 7, 123, 2, 2, 109, 103, 3,
-	2, 2, 2, 109, 106, 3, 2, 2, 2, 110, 28, 3, 2, 2, 2, 111, 112, 7, 67, 2,
-	2, 112, 113, 7, 78, 2, 2, 113, 118, 7, 78, 2, 2, 114, 115, 7, 99, 2, 2,
-	115, 116, 7, 110, 2, 2, 116, 118, 7, 110, 2, 2, 117, 111, 3, 2, 2, 2, 117,
-	114, 3, 2, 2, 2, 118, 30, 3, 2, 2, 2, 119, 120, 7, 80, 2, 2, 120, 121,
-	7, 81, 2, 2, 121, 126, 7, 86, 2, 2, 122, 123, 7, 112, 2, 2, 123, 124, 7,
-	113, 2, 2, 124, 126, 7, 118, 2, 2, 125, 119, 3, 2, 2, 2, 125, 122, 3, 2,
-	2, 2, 126, 32, 3, 2, 2, 2, 127, 128, 7, 68, 2, 2, 128, 129, 7, 81, 2, 2,
-	129, 130, 7, 81, 2, 2, 130, 136, 7, 78, 2, 2, 131, 132, 7, 100, 2, 2, 132,
-	133, 7, 113, 2, 2, 133, 134, 7, 113, 2, 2, 134, 136, 7, 110, 2, 2, 135,
-	127, 3, 2, 2, 2, 135, 131, 3, 2, 2, 2, 136, 34, 3, 2, 2, 2, 137, 138, 7,
-	85, 2, 2, 138, 139, 7, 84, 2, 2, 139, 144, 7, 69, 2, 2, 140, 141, 7, 117,
-	2, 2, 141, 142, 7, 116, 2, 2, 142, 144, 7, 101, 2, 2, 143, 137, 3, 2, 2,
-	2, 143, 140, 3, 2, 2, 2, 144, 36, 3, 2, 2, 2, 145, 146, 7, 70, 2, 2, 146,
-	147, 7, 85, 2, 2, 147, 152, 7, 86, 2, 2, 148, 149, 7, 102, 2, 2, 149, 150,
-	7, 117, 2, 2, 150, 152, 7, 118, 2, 2, 151, 145, 3, 2, 2, 2, 151, 148, 3,
-	2, 2, 2, 152, 38, 3, 2, 2, 2, 153, 154, 7, 70, 2, 2, 154, 155, 7, 85, 2,
-	2, 155, 156, 7, 69, 2, 2, 156, 162, 7, 82, 2, 2, 157, 158, 7, 102, 2, 2,
-	158, 159, 7, 117, 2, 2, 159, 160, 7, 101, 2, 2, 160, 162, 7, 114, 2, 2,
-	161, 153, 3, 2, 2, 2, 161, 157, 3, 2, 2, 2, 162, 40, 3, 2, 2, 2, 163, 164,
-	7, 86, 2, 2, 164, 165, 7, 81, 2, 2, 165, 170, 7, 85, 2, 2, 166, 167, 7,
-	118, 2, 2, 167, 168, 7, 113, 2, 2, 168, 170, 7, 117, 2, 2, 169, 163, 3,
-	2, 2, 2, 169, 166, 3, 2, 2, 2, 170, 42, 3, 2, 2, 2, 16, 2, 74, 83, 86,
-	89, 91, 109, 117, 125, 135, 143, 151, 161, 169, 3, 8, 2, 2,
+	18, 9, 18, 4, 19, 9, 19, 4, 20, 9, 20, 4, 21, 9, 21, 4, 22, 9, 22, 4, 23,
+	9, 23, 4, 24, 9, 24, 4, 25, 9, 25, 4, 26, 9, 26, 4, 27, 9, 27, 4, 28, 9,
+	28, 4, 29, 9, 29, 3, 2, 3, 2, 3, 3, 3, 3, 3, 3, 3, 3, 3, 4, 3, 4, 3, 4,
+	3, 4, 3, 4, 3, 5, 3, 5, 3, 6, 3, 6, 3, 7, 3, 7, 3, 8, 3, 8, 3, 8, 3, 8,
+	3, 8, 3, 9, 3, 9, 3, 9, 3, 9, 3, 9, 3, 9, 3, 10, 6, 10, 89, 10, 10, 13,
+	10, 14, 10, 90, 3, 10, 3, 10, 3, 11, 3, 11, 3, 11, 7, 11, 98, 10, 11, 12,
+	11, 14, 11, 101, 11, 11, 5, 11, 103, 10, 11, 3, 12, 6, 12, 106, 10, 12,
+	13, 12, 14, 12, 107, 3, 13, 3, 13, 3, 13, 3, 13, 3, 13, 3, 13, 3, 13, 3,
+	13, 3, 13, 3, 13, 3, 14, 5, 14, 121, 10, 14, 3, 14, 6, 14, 124, 10, 14,
+	13, 14, 14, 14, 125, 3, 14, 5, 14, 129, 10, 14, 3, 14, 3, 14, 3, 14, 3,
+	15, 3, 15, 3, 15, 3, 15, 3, 16, 3, 16, 3, 16, 3, 16, 3, 16, 3, 16, 5, 16,
+	144, 10, 16, 3, 17, 3, 17, 3, 17, 3, 17, 3, 17, 3, 17, 5, 17, 152, 10,
+	17, 3, 18, 3, 18, 3, 18, 3, 18, 3, 18, 3, 18, 5, 18, 160, 10, 18, 3, 19,
+	3, 19, 3, 19, 3, 19, 3, 19, 3, 19, 3, 19, 3, 19, 5, 19, 170, 10, 19, 3,
+	20, 3, 20, 3, 20, 3, 20, 3, 20, 3, 20, 5, 20, 178, 10, 20, 3, 21, 3, 21,
+	3, 21, 3, 21, 3, 21, 3, 21, 5, 21, 186, 10, 21, 3, 22, 3, 22, 3, 22, 3,
+	22, 3, 22, 3, 22, 3, 22, 3, 22, 5, 22, 196, 10, 22, 3, 23, 3, 23, 3, 23,
+	3, 23, 3, 23, 3, 23, 5, 23, 204, 10, 23, 3, 24, 3, 24, 3, 24, 3, 24, 5,
+	24, 210, 10, 24, 3, 25, 3, 25, 3, 25, 3, 25, 3, 25, 3, 25, 3, 25, 3, 25,
+	3, 25, 3, 25, 3, 25, 3, 25, 3, 25, 3, 25, 3, 25, 3, 25, 3, 25, 3, 25, 5,
+	25, 230, 10, 25, 3, 26, 3, 26, 3, 26, 3, 26, 3, 26, 3, 26, 3, 26, 3, 26,
+	3, 26, 3, 26, 3, 26, 3, 26, 3, 26, 3, 26, 5, 26, 246, 10, 26, 3, 27, 3,
+	27, 3, 27, 3, 27, 3, 27, 3, 27, 3, 27, 3, 27, 3, 27, 3, 27, 5, 27, 258,
+	10, 27, 3, 28, 3, 28, 3, 28, 3, 28, 3, 28, 3, 28, 3, 28, 3, 28, 3, 28,
+	3, 28, 5, 28, 270, 10, 28, 3, 29, 3, 29, 3, 29, 3, 29, 3, 29, 3, 29, 3,
+	29, 3, 29, 3, 29, 3, 29, 5, 29, 282, 10, 29, 2, 2, 30, 3, 3, 5, 4, 7, 5,
+	9, 6, 11, 7, 13, 8, 15, 9, 17, 10, 19, 11, 21, 12, 23, 13, 25, 14, 27,
+	15, 29, 16, 31, 17, 33, 18, 35, 19, 37, 20, 39, 21, 41, 22, 43, 23, 45,
+	24, 47, 25, 49, 26, 51, 27, 53, 28, 55, 29, 57, 30, 3, 2, 6, 5, 2, 11,
+	12, 15, 15, 34, 34, 3, 2, 51, 59, 3, 2, 50, 59, 5, 2, 50, 59, 67, 72, 99,
+	104, 2, 303, 2, 3, 3, 2, 2, 2, 2, 5, 3, 2, 2, 2, 2, 7, 3, 2, 2, 2, 2, 9,
+	3, 2, 2, 2, 2, 11, 3, 2, 2, 2, 2, 13, 3, 2, 2, 2, 2, 15, 3, 2, 2, 2, 2,
+	17, 3, 2, 2, 2, 2, 19, 3, 2, 2, 2, 2, 21, 3, 2, 2, 2, 2, 23, 3, 2, 2, 2,
+	2, 25, 3, 2, 2, 2, 2, 27, 3, 2, 2, 2, 2, 29, 3, 2, 2, 2, 2, 31, 3, 2, 2,
+	2, 2, 33, 3, 2, 2, 2, 2, 35, 3, 2, 2, 2, 2, 37, 3, 2, 2, 2, 2, 39, 3, 2,
+	2, 2, 2, 41, 3, 2, 2, 2, 2, 43, 3, 2, 2, 2, 2, 45, 3, 2, 2, 2, 2, 47, 3,
+	2, 2, 2, 2, 49, 3, 2, 2, 2, 2, 51, 3, 2, 2, 2, 2, 53, 3, 2, 2, 2, 2, 55,
+	3, 2, 2, 2, 2, 57, 3, 2, 2, 2, 3, 59, 3, 2, 2, 2, 5, 61, 3, 2, 2, 2, 7,
+	65, 3, 2, 2, 2, 9, 70, 3, 2, 2, 2, 11, 72, 3, 2, 2, 2, 13, 74, 3, 2, 2,
+	2, 15, 76, 3, 2, 2, 2, 17, 81, 3, 2, 2, 2, 19, 88, 3, 2, 2, 2, 21, 102,
+	3, 2, 2, 2, 23, 105, 3, 2, 2, 2, 25, 109, 3, 2, 2, 2, 27, 123, 3, 2, 2,
+	2, 29, 133, 3, 2, 2, 2, 31, 143, 3, 2, 2, 2, 33, 151, 3, 2, 2, 2, 35, 159,
+	3, 2, 2, 2, 37, 169, 3, 2, 2, 2, 39, 177, 3, 2, 2, 2, 41, 185, 3, 2, 2,
+	2, 43, 195, 3, 2, 2, 2, 45, 203, 3, 2, 2, 2, 47, 209, 3, 2, 2, 2, 49, 229,
+	3, 2, 2, 2, 51, 245, 3, 2, 2, 2, 53, 257, 3, 2, 2, 2, 55, 269, 3, 2, 2,
+	2, 57, 281, 3, 2, 2, 2, 59, 60, 7, 63, 2, 2, 60, 4, 3, 2, 2, 2, 61, 62,
+	7, 63, 2, 2, 62, 63, 7, 50, 2, 2, 63, 64, 7, 122, 2, 2, 64, 6, 3, 2, 2,
+	2, 65, 66, 7, 101, 2, 2, 66, 67, 7, 110, 2, 2, 67, 68, 7, 117, 2, 2, 68,
+	69, 7, 63, 2, 2, 69, 8, 3, 2, 2, 2, 70, 71, 7, 42, 2, 2, 71, 10, 3, 2,
+	2, 2, 72, 73, 7, 46, 2, 2, 73, 12, 3, 2, 2, 2, 74, 75, 7, 43, 2, 2, 75,
+	14, 3, 2, 2, 2, 76, 77, 7, 118, 2, 2, 77, 78, 7, 116, 2, 2, 78, 79, 7,
+	119, 2, 2, 79, 80, 7, 103, 2, 2, 80, 16, 3, 2, 2, 2, 81, 82, 7, 104, 2,
+	2, 82, 83, 7, 99, 2, 2, 83, 84, 7, 110, 2, 2, 84, 85, 7, 117, 2, 2, 85,
+	86, 7, 103, 2, 2, 86, 18, 3, 2, 2, 2, 87, 89, 9, 2, 2, 2, 88, 87, 3, 2,
+	2, 2, 89, 90, 3, 2, 2, 2, 90, 88, 3, 2, 2, 2, 90, 91, 3, 2, 2, 2, 91, 92,
+	3, 2, 2, 2, 92, 93, 8, 10, 2, 2, 93, 20, 3, 2, 2, 2, 94, 103, 7, 50, 2,
+	2, 95, 99, 9, 3, 2, 2, 96, 98, 9, 4, 2, 2, 97, 96, 3, 2, 2, 2, 98, 101,
+	3, 2, 2, 2, 99, 97, 3, 2, 2, 2, 99, 100, 3, 2, 2, 2, 100, 103, 3, 2, 2,
+	2, 101, 99, 3, 2, 2, 2, 102, 94, 3, 2, 2, 2, 102, 95, 3, 2, 2, 2, 103,
+	22, 3, 2, 2, 2, 104, 106, 9, 5, 2, 2, 105, 104, 3, 2, 2, 2, 106, 107, 3,
+	2, 2, 2, 107, 105, 3, 2, 2, 2, 107, 108, 3, 2, 2, 2, 108, 24, 3, 2, 2,
+	2, 109, 110, 5, 21, 11, 2, 110, 111, 7, 48, 2, 2, 111, 112, 5, 21, 11,
+	2, 112, 113, 7, 48, 2, 2, 113, 114, 5, 21, 11, 2, 114, 115, 7, 48, 2, 2,
+	115, 116, 5, 21, 11, 2, 116, 117, 7, 49, 2, 2, 117, 118, 5, 21, 11, 2,
+	118, 26, 3, 2, 2, 2, 119, 121, 5, 23, 12, 2, 120, 119, 3, 2, 2, 2, 120,
+	121, 3, 2, 2, 2, 121, 122, 3, 2, 2, 2, 122, 124, 7, 60, 2, 2, 123, 120,
+	3, 2, 2, 2, 124, 125, 3, 2, 2, 2, 125, 123, 3, 2, 2, 2, 125, 126, 3, 2,
+	2, 2, 126, 128, 3, 2, 2, 2, 127, 129, 5, 23, 12, 2, 128, 127, 3, 2, 2,
+	2, 128, 129, 3, 2, 2, 2, 129, 130, 3, 2, 2, 2, 130, 131, 7, 49, 2, 2, 131,
+	132, 5, 21, 11, 2, 132, 28, 3, 2, 2, 2, 133, 134, 5, 21, 11, 2, 134, 135,
+	7, 47, 2, 2, 135, 136, 5, 21, 11, 2, 136, 30, 3, 2, 2, 2, 137, 138, 7,
+	67, 2, 2, 138, 139, 7, 80, 2, 2, 139, 144, 7, 91, 2, 2, 140, 141, 7, 99,
+	2, 2, 141, 142, 7, 112, 2, 2, 142, 144, 7, 123, 2, 2, 143, 137, 3, 2, 2,
+	2, 143, 140, 3, 2, 2, 2, 144, 32, 3, 2, 2, 2, 145, 146, 7, 67, 2, 2, 146,
+	147, 7, 78, 2, 2, 147, 152, 7, 78, 2, 2, 148, 149, 7, 99, 2, 2, 149, 150,
+	7, 110, 2, 2, 150, 152, 7, 110, 2, 2, 151, 145, 3, 2, 2, 2, 151, 148, 3,
+	2, 2, 2, 152, 34, 3, 2, 2, 2, 153, 154, 7, 80, 2, 2, 154, 155, 7, 81, 2,
+	2, 155, 160, 7, 86, 2, 2, 156, 157, 7, 112, 2, 2, 157, 158, 7, 113, 2,
+	2, 158, 160, 7, 118, 2, 2, 159, 153, 3, 2, 2, 2, 159, 156, 3, 2, 2, 2,
+	160, 36, 3, 2, 2, 2, 161, 162, 7, 68, 2, 2, 162, 163, 7, 81, 2, 2, 163,
+	164, 7, 81, 2, 2, 164, 170, 7, 78, 2, 2, 165, 166, 7, 100, 2, 2, 166, 167,
+	7, 113, 2, 2, 167, 168, 7, 113, 2, 2, 168, 170, 7, 110, 2, 2, 169, 161,
+	3, 2, 2, 2, 169, 165, 3, 2, 2, 2, 170, 38, 3, 2, 2, 2, 171, 172, 7, 85,
+	2, 2, 172, 173, 7, 84, 2, 2, 173, 178, 7, 69, 2, 2, 174, 175, 7, 117, 2,
+	2, 175, 176, 7, 116, 2, 2, 176, 178, 7, 101, 2, 2, 177, 171, 3, 2, 2, 2,
+	177, 174, 3, 2, 2, 2, 178, 40, 3, 2, 2, 2, 179, 180, 7, 70, 2, 2, 180,
+	181, 7, 85, 2, 2, 181, 186, 7, 86, 2, 2, 182, 183, 7, 102, 2, 2, 183, 184,
+	7, 117, 2, 2, 184, 186, 7, 118, 2, 2, 185, 179, 3, 2, 2, 2, 185, 182, 3,
+	2, 2, 2, 186, 42, 3, 2, 2, 2, 187, 188, 7, 70, 2, 2, 188, 189, 7, 85, 2,
+	2, 189, 190, 7, 69, 2, 2, 190, 196, 7, 82, 2, 2, 191, 192, 7, 102, 2, 2,
+	192, 193, 7, 117, 2, 2, 193, 194, 7, 101, 2, 2, 194, 196, 7, 114, 2, 2,
+	195, 187, 3, 2, 2, 2, 195, 191, 3, 2, 2, 2, 196, 44, 3, 2, 2, 2, 197, 198,
+	7, 86, 2, 2, 198, 199, 7, 81, 2, 2, 199, 204, 7, 85, 2, 2, 200, 201, 7,
+	118, 2, 2, 201, 202, 7, 113, 2, 2, 202, 204, 7, 117, 2, 2, 203, 197, 3,
+	2, 2, 2, 203, 200, 3, 2, 2, 2, 204, 46, 3, 2, 2, 2, 205, 206, 7, 86, 2,
+	2, 206, 210, 7, 69, 2, 2, 207, 208, 7, 118, 2, 2, 208, 210, 7, 101, 2,
+	2, 209, 205, 3, 2, 2, 2, 209, 207, 3, 2, 2, 2, 210, 48, 3, 2, 2, 2, 211,
+	212, 7, 72, 2, 2, 212, 213, 7, 78, 2, 2, 213, 214, 7, 81, 2, 2, 214, 215,
+	7, 89, 2, 2, 215, 216, 7, 78, 2, 2, 216, 217, 7, 67, 2, 2, 217, 218, 7,
+	68, 2, 2, 218, 219, 7, 71, 2, 2, 219, 230, 7, 78, 2, 2, 220, 221, 7, 104,
+	2, 2, 221, 222, 7, 110, 2, 2, 222, 223, 7, 113, 2, 2, 223, 224, 7, 121,
+	2, 2, 224, 225, 7, 110, 2, 2, 225, 226, 7, 99, 2, 2, 226, 227, 7, 100,
+	2, 2, 227, 228, 7, 103, 2, 2, 228, 230, 7, 110, 2, 2, 229, 211, 3, 2, 2,
+	2, 229, 220, 3, 2, 2, 2, 230, 50, 3, 2, 2, 2, 231, 232, 7, 80, 2, 2, 232,
+	233, 7, 71, 2, 2, 233, 234, 7, 90, 2, 2, 234, 235, 7, 86, 2, 2, 235, 236,
+	7, 74, 2, 2, 236, 237, 7, 70, 2, 2, 237, 246, 7, 84, 2, 2, 238, 239, 7,
+	112, 2, 2, 239, 240, 7, 103, 2, 2, 240, 241, 7, 122, 2, 2, 241, 242, 7,
+	118, 2, 2, 242, 243, 7, 106, 2, 2, 243, 244, 7, 102, 2, 2, 244, 246, 7,
+	116, 2, 2, 245, 231, 3, 2, 2, 2, 245, 238, 3, 2, 2, 2, 246, 52, 3, 2, 2,
+	2, 247, 248, 7, 82, 2, 2, 248, 249, 7, 84, 2, 2, 249, 250, 7, 81, 2, 2,
+	250, 251, 7, 86, 2, 2, 251, 258, 7, 81, 2, 2, 252, 253, 7, 114, 2, 2, 253,
+	254, 7, 116, 2, 2, 254, 255, 7, 113, 2, 2, 255, 256, 7, 118, 2, 2, 256,
+	258, 7, 113, 2, 2, 257, 247, 3, 2, 2, 2, 257, 252, 3, 2, 2, 2, 258, 54,
+	3, 2, 2, 2, 259, 260, 7, 85, 2, 2, 260, 261, 7, 82, 2, 2, 261, 262, 7,
+	81, 2, 2, 262, 263, 7, 84, 2, 2, 263, 270, 7, 86, 2, 2, 264, 265, 7, 117,
+	2, 2, 265, 266, 7, 114, 2, 2, 266, 267, 7, 113, 2, 2, 267, 268, 7, 116,
+	2, 2, 268, 270, 7, 118, 2, 2, 269, 259, 3, 2, 2, 2, 269, 264, 3, 2, 2,
+	2, 270, 56, 3, 2, 2, 2, 271, 272, 7, 70, 2, 2, 272, 273, 7, 82, 2, 2, 273,
+	274, 7, 81, 2, 2, 274, 275, 7, 84, 2, 2, 275, 282, 7, 86, 2, 2, 276, 277,
+	7, 102, 2, 2, 277, 278, 7, 114, 2, 2, 278, 279, 7, 113, 2, 2, 279, 280,
+	7, 116, 2, 2, 280, 282, 7, 118, 2, 2, 281, 271, 3, 2, 2, 2, 281, 276, 3,
+	2, 2, 2, 282, 58, 3, 2, 2, 2, 25, 2, 90, 99, 102, 105, 107, 120, 125, 128,
+	143, 151, 159, 169, 177, 185, 195, 203, 209, 229, 245, 257, 269, 281, 3,
+	8, 2, 2,
 }
 
 var lexerDeserializer = antlr.NewATNDeserializer(nil)
@@ -109,13 +161,15 @@ var lexerLiteralNames = []string{
 
 var lexerSymbolicNames = []string{
 	"", "", "", "", "", "", "", "", "", "WHITESPACE", "DIGITS", "HEX_DIGITS",
-	"NET", "ANY", "ALL", "NOT", "BOOL", "SRC", "DST", "DSCP", "TOS",
+	"NET", "NET6", "RANGE", "ANY", "ALL", "NOT", "BOOL", "SRC", "DST", "DSCP",
+	"TOS", "TC", "FLOWLABEL", "NEXTHDR", "PROTO", "SPORT", "DPORT",
 }
 
 var lexerRuleNames = []string{
 	"T__0", "T__1", "T__2", "T__3", "T__4", "T__5", "T__6", "T__7", "WHITESPACE",
-	"DIGITS", "HEX_DIGITS", "NET", "ANY", "ALL", "NOT", "BOOL", "SRC", "DST",
-	"DSCP", "TOS",
+	"DIGITS", "HEX_DIGITS", "NET", "NET6", "RANGE", "ANY", "ALL", "NOT", "BOOL",
+	"SRC", "DST", "DSCP", "TOS", "TC", "FLOWLABEL", "NEXTHDR", "PROTO", "SPORT",
+	"DPORT",
 }
 
 type TrafficClassLexer struct {
@@ -166,12 +220,20 @@ const (
 	TrafficClassLexerDIGITS     = 10
 	TrafficClassLexerHEX_DIGITS = 11
 	TrafficClassLexerNET        = 12
-	TrafficClassLexerANY        = 13
-	TrafficClassLexerALL        = 14
-	TrafficClassLexerNOT        = 15
-	TrafficClassLexerBOOL       = 16
-	TrafficClassLexerSRC        = 17
-	TrafficClassLexerDST        = 18
-	TrafficClassLexerDSCP       = 19
-	TrafficClassLexerTOS        = 20
+	TrafficClassLexerNET6       = 13
+	TrafficClassLexerRANGE      = 14
+	TrafficClassLexerANY        = 15
+	TrafficClassLexerALL        = 16
+	TrafficClassLexerNOT        = 17
+	TrafficClassLexerBOOL       = 18
+	TrafficClassLexerSRC        = 19
+	TrafficClassLexerDST        = 20
+	TrafficClassLexerDSCP       = 21
+	TrafficClassLexerTOS        = 22
+	TrafficClassLexerTC         = 23
+	TrafficClassLexerFLOWLABEL  = 24
+	TrafficClassLexerNEXTHDR    = 25
+	TrafficClassLexerPROTO      = 26
+	TrafficClassLexerSPORT      = 27
+	TrafficClassLexerDPORT      = 28
 )
@@ -19,6 +19,24 @@ type TrafficClassListener interface {
 	// EnterMatchTOS is called when entering the matchTOS production.
 	EnterMatchTOS(c *MatchTOSContext)
 
+	// EnterMatchTC is called when entering the matchTC production.
+	EnterMatchTC(c *MatchTCContext)
+
+	// EnterMatchFlowLabel is called when entering the matchFlowLabel production.
+	EnterMatchFlowLabel(c *MatchFlowLabelContext)
+
+	// EnterMatchNextHdr is called when entering the matchNextHdr production.
+	EnterMatchNextHdr(c *MatchNextHdrContext)
+
+	// EnterMatchProto is called when entering the matchProto production.
+	EnterMatchProto(c *MatchProtoContext)
+
+	// EnterMatchSrcPort is called when entering the matchSrcPort production.
+	EnterMatchSrcPort(c *MatchSrcPortContext)
+
+	// EnterMatchDstPort is called when entering the matchDstPort production.
+	EnterMatchDstPort(c *MatchDstPortContext)
+
 	// EnterCondCls is called when entering the condCls production.
 	EnterCondCls(c *CondClsContext)
 
@@ -37,6 +55,12 @@ type TrafficClassListener interface {
 	// EnterCondIPv4 is called when entering the condIPv4 production.
 	EnterCondIPv4(c *CondIPv4Context)
 
+	// EnterCondIPv6 is called when entering the condIPv6 production.
+	EnterCondIPv6(c *CondIPv6Context)
+
+	// EnterCondL4 is called when entering the condL4 production.
+	EnterCondL4(c *CondL4Context)
+
 	// EnterCond is called when entering the cond production.
 	EnterCond(c *CondContext)
 
@@ -55,6 +79,24 @@ type TrafficClassListener interface {
 	// ExitMatchTOS is called when exiting the matchTOS production.
 	ExitMatchTOS(c *MatchTOSContext)
 
+	// ExitMatchTC is called when exiting the matchTC production.
+	ExitMatchTC(c *MatchTCContext)
+
+	// ExitMatchFlowLabel is called when exiting the matchFlowLabel production.
+	ExitMatchFlowLabel(c *MatchFlowLabelContext)
+
+	// ExitMatchNextHdr is called when exiting the matchNextHdr production.
+	ExitMatchNextHdr(c *MatchNextHdrContext)
+
+	// ExitMatchProto is called when exiting the matchProto production.
+	ExitMatchProto(c *MatchProtoContext)
+
+	// ExitMatchSrcPort is called when exiting the matchSrcPort production.
+	ExitMatchSrcPort(c *MatchSrcPortContext)
+
+	// ExitMatchDstPort is called when exiting the matchDstPort production.
+	ExitMatchDstPort(c *MatchDstPortContext)
+
 	// ExitCondCls is called when exiting the condCls production.
 	ExitCondCls(c *CondClsContext)
 
@@ -73,6 +115,12 @@ type TrafficClassListener interface {
 	// ExitCondIPv4 is called when exiting the condIPv4 production.
 	ExitCondIPv4(c *CondIPv4Context)
 
+	// ExitCondIPv6 is called when exiting the condIPv6 production.
+	ExitCondIPv6(c *CondIPv6Context)
+
+	// ExitCondL4 is called when exiting the condL4 production.
+	ExitCondL4(c *CondL4Context)
+
 	// ExitCond is called when exiting the cond production.
 	ExitCond(c *CondContext)
 
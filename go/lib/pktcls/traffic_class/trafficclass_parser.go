@@ -15,43 +15,65 @@ var _ = reflect.Copy
 var _ = strconv.Itoa
 
 var parserATN = []uint16{
-	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 3, 22, 96, 4,
-	2, 9, 2, 4, 3, 9, 3, 4, 4, 9, 4, 4, 5, 9, 5, 4, 6, 9, 6, 4, 7, 9, 7, 4,
-	8, 9, 8, 4, 9, 9, 9, 4, 10, 9, 10, 4, 11, 9, 11, 4, 12, 9, 12, 4, 13, 9,
-	13, 3, 2, 3, 2, 3, 2, 3, 2, 3, 3, 3, 3, 3, 3, 3, 3, 3, 4, 3, 4, 3, 4, 3,
-	4, 3, 5, 3, 5, 3, 5, 3, 5, 3, 6, 3, 6, 3, 6, 3, 7, 3, 7, 3, 7, 3, 7, 3,
-	7, 7, 7, 51, 10, 7, 12, 7, 14, 7, 54, 11, 7, 3, 7, 3, 7, 3, 8, 3, 8, 3,
-	8, 3, 8, 3, 8, 7, 8, 63, 10, 8, 12, 8, 14, 8, 66, 11, 8, 3, 8, 3, 8, 3,
-	9, 3, 9, 3, 9, 3, 9, 3, 9, 3, 10, 3, 10, 3, 10, 3, 10, 3, 11, 3, 11, 3,
-	11, 3, 11, 5, 11, 83, 10, 11, 3, 12, 3, 12, 3, 12, 3, 12, 3, 12, 3, 12,
-	5, 12, 91, 10, 12, 3, 13, 3, 13, 3, 13, 3, 13, 2, 2, 14, 2, 4, 6, 8, 10,
-	12, 14, 16, 18, 20, 22, 24, 2, 4, 3, 2, 12, 13, 3, 2, 9, 10, 2, 93, 2,
-	26, 3, 2, 2, 2, 4, 30, 3, 2, 2, 2, 6, 34, 3, 2, 2, 2, 8, 38, 3, 2, 2, 2,
-	10, 42, 3, 2, 2, 2, 12, 45, 3, 2, 2, 2, 14, 57, 3, 2, 2, 2, 16, 69, 3,
-	2, 2, 2, 18, 74, 3, 2, 2, 2, 20, 82, 3, 2, 2, 2, 22, 90, 3, 2, 2, 2, 24,
-	92, 3, 2, 2, 2, 26, 27, 7, 19, 2, 2, 27, 28, 7, 3, 2, 2, 28, 29, 7, 14,
-	2, 2, 29, 3, 3, 2, 2, 2, 30, 31, 7, 20, 2, 2, 31, 32, 7, 3, 2, 2, 32, 33,
-	7, 14, 2, 2, 33, 5, 3, 2, 2, 2, 34, 35, 7, 21, 2, 2, 35, 36, 7, 4, 2, 2,
-	36, 37, 9, 2, 2, 2, 37, 7, 3, 2, 2, 2, 38, 39, 7, 22, 2, 2, 39, 40, 7,
-	4, 2, 2, 40, 41, 9, 2, 2, 2, 41, 9, 3, 2, 2, 2, 42, 43, 7, 5, 2, 2, 43,
-	44, 7, 12, 2, 2, 44, 11, 3, 2, 2, 2, 45, 46, 7, 15, 2, 2, 46, 47, 7, 6,
-	2, 2, 47, 52, 5, 22, 12, 2, 48, 49, 7, 7, 2, 2, 49, 51, 5, 22, 12, 2, 50,
-	48, 3, 2, 2, 2, 51, 54, 3, 2, 2, 2, 52, 50, 3, 2, 2, 2, 52, 53, 3, 2, 2,
-	2, 53, 55, 3, 2, 2, 2, 54, 52, 3, 2, 2, 2, 55, 56, 7, 8, 2, 2, 56, 13,
-	3, 2, 2, 2, 57, 58, 7, 16, 2, 2, 58, 59, 7, 6, 2, 2, 59, 64, 5, 22, 12,
-	2, 60, 61, 7, 7, 2, 2, 61, 63, 5, 22, 12, 2, 62, 60, 3, 2, 2, 2, 63, 66,
-	3, 2, 2, 2, 64, 62, 3, 2, 2, 2, 64, 65, 3, 2, 2, 2, 65, 67, 3, 2, 2, 2,
-	66, 64, 3, 2, 2, 2, 67, 68, 7, 8, 2, 2, 68, 15, 3, 2, 2, 2, 69, 70, 7,
-	17, 2, 2, 70, 71, 7, 6, 2, 2, 71, 72, 5, 22, 12, 2, 72, 73, 7, 8, 2, 2,
-	73, 17, 3, 2, 2, 2, 74, 75, 7, 18, 2, 2, 75, 76, 7, 3, 2, 2, 76, 77, 9,
-	3, 2, 2, 77, 19, 3, 2, 2, 2, 78, 83, 5, 2, 2, 2, 79, 83, 5, 4, 3, 2, 80,
-	83, 5, 6, 4, 2, 81, 83, 5, 8, 5, 2, 82, 78, 3, 2, 2, 2, 82, 79, 3, 2, 2,
-	2, 82, 80, 3, 2, 2, 2, 82, 81, 3, 2, 2, 2, 83, 21, 3, 2, 2, 2, 84, 91,
-	5, 14, 8, 2, 85, 91, 5, 12, 7, 2, 86, 91, 5, 16, 9, 2, 87, 91, 5, 20, 11,
-	2, 88, 91, 5, 10, 6, 2, 89, 91, 5, 18, 10, 2, 90, 84, 3, 2, 2, 2, 90, 85,
-	3, 2, 2, 2, 90, 86, 3, 2, 2, 2, 90, 87, 3, 2, 2, 2, 90, 88, 3, 2, 2, 2,
-	90, 89, 3, 2, 2, 2, 91, 23, 3, 2, 2, 2, 92, 93, 5, 22, 12, 2, 93, 94, 7,
-	2, 2, 3, 94, 25, 3, 2, 2, 2, 6, 52, 64, 82, 90,
+	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 3, 30, 148,
+	4, 2, 9, 2, 4, 3, 9, 3, 4, 4, 9, 4, 4, 5, 9, 5, 4, 6, 9, 6, 4, 7, 9, 7,
+	4, 8, 9, 8, 4, 9, 9, 9, 4, 10, 9, 10, 4, 11, 9, 11, 4, 12, 9, 12, 4, 13,
+	9, 13, 4, 14, 9, 14, 4, 15, 9, 15, 4, 16, 9, 16, 4, 17, 9, 17, 4, 18, 9,
+	18, 4, 19, 9, 19, 4, 20, 9, 20, 4, 21, 9, 21, 3, 2, 3, 2, 3, 2, 3, 2, 3,
+	3, 3, 3, 3, 3, 3, 3, 3, 4, 3, 4, 3, 4, 3, 4, 3, 5, 3, 5, 3, 5, 3, 5, 3,
+	6, 3, 6, 3, 6, 3, 6, 3, 7, 3, 7, 3, 7, 3, 7, 3, 8, 3, 8, 3, 8, 3, 8, 3,
+	9, 3, 9, 3, 9, 3, 9, 3, 10, 3, 10, 3, 10, 3, 10, 3, 11, 3, 11, 3, 11, 3,
+	11, 3, 12, 3, 12, 3, 12, 3, 13, 3, 13, 3, 13, 3, 13, 3, 13, 7, 13, 91,
+	10, 13, 12, 13, 14, 13, 94, 11, 13, 3, 13, 3, 13, 3, 14, 3, 14, 3, 14,
+	3, 14, 3, 14, 7, 14, 103, 10, 14, 12, 14, 14, 14, 106, 11, 14, 3, 14, 3,
+	14, 3, 15, 3, 15, 3, 15, 3, 15, 3, 15, 3, 16, 3, 16, 3, 16, 3, 16, 3, 17,
+	3, 17, 3, 17, 3, 17, 5, 17, 123, 10, 17, 3, 18, 3, 18, 3, 18, 5, 18, 128,
+	10, 18, 3, 19, 3, 19, 3, 19, 5, 19, 133, 10, 19, 3, 20, 3, 20, 3, 20, 3,
+	20, 3, 20, 3, 20, 3, 20, 3, 20, 5, 20, 143, 10, 20, 3, 21, 3, 21, 3, 21,
+	3, 21, 2, 2, 22, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24, 26, 28, 30,
+	32, 34, 36, 38, 40, 2, 6, 3, 2, 14, 15, 3, 2, 12, 13, 4, 2, 12, 12, 16,
+	16, 3, 2, 9, 10, 2, 143, 2, 42, 3, 2, 2, 2, 4, 46, 3, 2, 2, 2, 6, 50, 3,
+	2, 2, 2, 8, 54, 3, 2, 2, 2, 10, 58, 3, 2, 2, 2, 12, 62, 3, 2, 2, 2, 14,
+	66, 3, 2, 2, 2, 16, 70, 3, 2, 2, 2, 18, 74, 3, 2, 2, 2, 20, 78, 3, 2, 2,
+	2, 22, 82, 3, 2, 2, 2, 24, 85, 3, 2, 2, 2, 26, 97, 3, 2, 2, 2, 28, 109,
+	3, 2, 2, 2, 30, 114, 3, 2, 2, 2, 32, 122, 3, 2, 2, 2, 34, 127, 3, 2, 2,
+	2, 36, 132, 3, 2, 2, 2, 38, 142, 3, 2, 2, 2, 40, 144, 3, 2, 2, 2, 42, 43,
+	7, 21, 2, 2, 43, 44, 7, 3, 2, 2, 44, 45, 9, 2, 2, 2, 45, 3, 3, 2, 2, 2,
+	46, 47, 7, 22, 2, 2, 47, 48, 7, 3, 2, 2, 48, 49, 9, 2, 2, 2, 49, 5, 3,
+	2, 2, 2, 50, 51, 7, 23, 2, 2, 51, 52, 7, 4, 2, 2, 52, 53, 9, 3, 2, 2, 53,
+	7, 3, 2, 2, 2, 54, 55, 7, 24, 2, 2, 55, 56, 7, 4, 2, 2, 56, 57, 9, 3, 2,
+	2, 57, 9, 3, 2, 2, 2, 58, 59, 7, 25, 2, 2, 59, 60, 7, 4, 2, 2, 60, 61,
+	9, 3, 2, 2, 61, 11, 3, 2, 2, 2, 62, 63, 7, 26, 2, 2, 63, 64, 7, 3, 2, 2,
+	64, 65, 7, 12, 2, 2, 65, 13, 3, 2, 2, 2, 66, 67, 7, 27, 2, 2, 67, 68, 7,
+	3, 2, 2, 68, 69, 7, 12, 2, 2, 69, 15, 3, 2, 2, 2, 70, 71, 7, 28, 2, 2,
+	71, 72, 7, 3, 2, 2, 72, 73, 7, 12, 2, 2, 73, 17, 3, 2, 2, 2, 74, 75, 7,
+	29, 2, 2, 75, 76, 7, 3, 2, 2, 76, 77, 9, 4, 2, 2, 77, 19, 3, 2, 2, 2, 78,
+	79, 7, 30, 2, 2, 79, 80, 7, 3, 2, 2, 80, 81, 9, 4, 2, 2, 81, 21, 3, 2,
+	2, 2, 82, 83, 7, 5, 2, 2, 83, 84, 7, 12, 2, 2, 84, 23, 3, 2, 2, 2, 85,
+	86, 7, 17, 2, 2, 86, 87, 7, 6, 2, 2, 87, 92, 5, 38, 20, 2, 88, 89, 7, 7,
+	2, 2, 89, 91, 5, 38, 20, 2, 90, 88, 3, 2, 2, 2, 91, 94, 3, 2, 2, 2, 92,
+	90, 3, 2, 2, 2, 92, 93, 3, 2, 2, 2, 93, 95, 3, 2, 2, 2, 94, 92, 3, 2, 2,
+	2, 95, 96, 7, 8, 2, 2, 96, 25, 3, 2, 2, 2, 97, 98, 7, 18, 2, 2, 98, 99,
+	7, 6, 2, 2, 99, 104, 5, 38, 20, 2, 100, 101, 7, 7, 2, 2, 101, 103, 5, 38,
+	20, 2, 102, 100, 3, 2, 2, 2, 103, 106, 3, 2, 2, 2, 104, 102, 3, 2, 2, 2,
+	104, 105, 3, 2, 2, 2, 105, 107, 3, 2, 2, 2, 106, 104, 3, 2, 2, 2, 107,
+	108, 7, 8, 2, 2, 108, 27, 3, 2, 2, 2, 109, 110, 7, 19, 2, 2, 110, 111,
+	7, 6, 2, 2, 111, 112, 5, 38, 20, 2, 112, 113, 7, 8, 2, 2, 113, 29, 3, 2,
+	2, 2, 114, 115, 7, 20, 2, 2, 115, 116, 7, 3, 2, 2, 116, 117, 9, 5, 2, 2,
+	117, 31, 3, 2, 2, 2, 118, 123, 5, 2, 2, 2, 119, 123, 5, 4, 3, 2, 120, 123,
+	5, 6, 4, 2, 121, 123, 5, 8, 5, 2, 122, 118, 3, 2, 2, 2, 122, 119, 3, 2,
+	2, 2, 122, 120, 3, 2, 2, 2, 122, 121, 3, 2, 2, 2, 123, 33, 3, 2, 2, 2,
+	124, 128, 5, 10, 6, 2, 125, 128, 5, 12, 7, 2, 126, 128, 5, 14, 8, 2, 127,
+	124, 3, 2, 2, 2, 127, 125, 3, 2, 2, 2, 127, 126, 3, 2, 2, 2, 128, 35, 3,
+	2, 2, 2, 129, 133, 5, 16, 9, 2, 130, 133, 5, 18, 10, 2, 131, 133, 5, 20,
+	11, 2, 132, 129, 3, 2, 2, 2, 132, 130, 3, 2, 2, 2, 132, 131, 3, 2, 2, 2,
+	133, 37, 3, 2, 2, 2, 134, 143, 5, 26, 14, 2, 135, 143, 5, 24, 13, 2, 136,
+	143, 5, 28, 15, 2, 137, 143, 5, 32, 17, 2, 138, 143, 5, 34, 18, 2, 139,
+	143, 5, 36, 19, 2, 140, 143, 5, 22, 12, 2, 141, 143, 5, 30, 16, 2, 142,
+	134, 3, 2, 2, 2, 142, 135, 3, 2, 2, 2, 142, 136, 3, 2, 2, 2, 142, 137,
+	3, 2, 2, 2, 142, 138, 3, 2, 2, 2, 142, 139, 3, 2, 2, 2, 142, 140, 3, 2,
+	2, 2, 142, 141, 3, 2, 2, 2, 143, 39, 3, 2, 2, 2, 144, 145, 5, 38, 20, 2,
+	145, 146, 7, 2, 2, 3, 146, 41, 3, 2, 2, 2, 8, 92, 104, 122, 127, 132, 142,
 }
 var deserializer = antlr.NewATNDeserializer(nil)
 var deserializedATN = deserializer.DeserializeFromUInt16(parserATN)
@@ -61,12 +83,15 @@ var literalNames = []string{
 }
 var symbolicNames = []string{
 	"", "", "", "", "", "", "", "", "", "WHITESPACE", "DIGITS", "HEX_DIGITS",
-	"NET", "ANY", "ALL", "NOT", "BOOL", "SRC", "DST", "DSCP", "TOS",
+	"NET", "NET6", "RANGE", "ANY", "ALL", "NOT", "BOOL", "SRC", "DST", "DSCP",
+	"TOS", "TC", "FLOWLABEL", "NEXTHDR", "PROTO", "SPORT", "DPORT",
 }
 
 var ruleNames = []string{
-	"matchSrc", "matchDst", "matchDSCP", "matchTOS", "condCls", "condAny",
-	"condAll", "condNot", "condBool", "condIPv4", "cond", "trafficClass",
+	"matchSrc", "matchDst", "matchDSCP", "matchTOS", "matchTC", "matchFlowLabel",
+	"matchNextHdr", "matchProto", "matchSrcPort", "matchDstPort", "condCls",
+	"condAny", "condAll", "condNot", "condBool", "condIPv4", "condIPv6", "condL4",
+	"cond", "trafficClass",
 }
 var decisionToDFA = make([]*antlr.DFA, len(deserializedATN.DecisionToState))
 
@@ -110,30 +135,46 @@ const (
 	TrafficClassParserDIGITS     = 10
 	TrafficClassParserHEX_DIGITS = 11
 	TrafficClassParserNET        = 12
-	TrafficClassParserANY        = 13
-	TrafficClassParserALL        = 14
-	TrafficClassParserNOT        = 15
-	TrafficClassParserBOOL       = 16
-	TrafficClassParserSRC        = 17
-	TrafficClassParserDST        = 18
-	TrafficClassParserDSCP       = 19
-	TrafficClassParserTOS        = 20
+	TrafficClassParserNET6       = 13
+	TrafficClassParserRANGE      = 14
+	TrafficClassParserANY        = 15
+	TrafficClassParserALL        = 16
+	TrafficClassParserNOT        = 17
+	TrafficClassParserBOOL       = 18
+	TrafficClassParserSRC        = 19
+	TrafficClassParserDST        = 20
+	TrafficClassParserDSCP       = 21
+	TrafficClassParserTOS        = 22
+	TrafficClassParserTC         = 23
+	TrafficClassParserFLOWLABEL  = 24
+	TrafficClassParserNEXTHDR    = 25
+	TrafficClassParserPROTO      = 26
+	TrafficClassParserSPORT      = 27
+	TrafficClassParserDPORT      = 28
 )
 
 // TrafficClassParser rules.
 const (
-	TrafficClassParserRULE_matchSrc     = 0
-	TrafficClassParserRULE_matchDst     = 1
-	TrafficClassParserRULE_matchDSCP    = 2
-	TrafficClassParserRULE_matchTOS     = 3
-	TrafficClassParserRULE_condCls      = 4
-	TrafficClassParserRULE_condAny      = 5
-	TrafficClassParserRULE_condAll      = 6
-	TrafficClassParserRULE_condNot      = 7
-	TrafficClassParserRULE_condBool     = 8
-	TrafficClassParserRULE_condIPv4     = 9
-	TrafficClassParserRULE_cond         = 10
-	TrafficClassParserRULE_trafficClass = 11
+	TrafficClassParserRULE_matchSrc       = 0
+	TrafficClassParserRULE_matchDst       = 1
+	TrafficClassParserRULE_matchDSCP      = 2
+	TrafficClassParserRULE_matchTOS       = 3
+	TrafficClassParserRULE_matchTC        = 4
+	TrafficClassParserRULE_matchFlowLabel = 5
+	TrafficClassParserRULE_matchNextHdr   = 6
+	TrafficClassParserRULE_matchProto     = 7
+	TrafficClassParserRULE_matchSrcPort   = 8
+	TrafficClassParserRULE_matchDstPort   = 9
+	TrafficClassParserRULE_condCls        = 10
+	TrafficClassParserRULE_condAny        = 11
+	TrafficClassParserRULE_condAll        = 12
+	TrafficClassParserRULE_condNot        = 13
+	TrafficClassParserRULE_condBool       = 14
+	TrafficClassParserRULE_condIPv4       = 15
+	TrafficClassParserRULE_condIPv6       = 16
+	TrafficClassParserRULE_condL4         = 17
+	TrafficClassParserRULE_cond           = 18
+	TrafficClassParserRULE_trafficClass   = 19
 )
 
 // IMatchSrcContext is an interface to support dynamic dispatch.
@@ -184,6 +225,10 @@ func (s *MatchSrcContext) NET() antlr.TerminalNode {
 	return s.GetToken(TrafficClassParserNET, 0)
 }
 
+func (s *MatchSrcContext) NET6() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserNET6, 0)
+}
+
 func (s *MatchSrcContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -207,6 +252,7 @@ func (s *MatchSrcContext) ExitRule(listener antlr.ParseTreeListener) {
 func (p *TrafficClassParser) MatchSrc() (localctx IMatchSrcContext) {
 	localctx = NewMatchSrcContext(p, p.GetParserRuleContext(), p.GetState())
 	p.EnterRule(localctx, 0, TrafficClassParserRULE_matchSrc)
+	var _la int
 
 	defer func() {
 		p.ExitRule()
@@ -226,16 +272,23 @@ func (p *TrafficClassParser) MatchSrc() (localctx IMatchSrcContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(24)
+		p.SetState(40)
 		p.Match(TrafficClassParserSRC)
 	}
 	{
-		p.SetState(25)
+		p.SetState(41)
 		p.Match(TrafficClassParserT__0)
 	}
 	{
-		p.SetState(26)
-		p.Match(TrafficClassParserNET)
+		p.SetState(42)
+		_la = p.GetTokenStream().LA(1)
+
+		if !(_la == TrafficClassParserNET || _la == TrafficClassParserNET6) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
+		}
 	}
 
 	return localctx
@@ -289,6 +342,10 @@ func (s *MatchDstContext) NET() antlr.TerminalNode {
 	return s.GetToken(TrafficClassParserNET, 0)
 }
 
+func (s *MatchDstContext) NET6() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserNET6, 0)
+}
+
 func (s *MatchDstContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -312,6 +369,7 @@ func (s *MatchDstContext) ExitRule(listener antlr.ParseTreeListener) {
 func (p *TrafficClassParser) MatchDst() (localctx IMatchDstContext) {
 	localctx = NewMatchDstContext(p, p.GetParserRuleContext(), p.GetState())
 	p.EnterRule(localctx, 2, TrafficClassParserRULE_matchDst)
+	var _la int
 
 	defer func() {
 		p.ExitRule()
@@ -331,16 +389,23 @@ func (p *TrafficClassParser) MatchDst() (localctx IMatchDstContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(28)
+		p.SetState(44)
 		p.Match(TrafficClassParserDST)
 	}
 	{
-		p.SetState(29)
+		p.SetState(45)
 		p.Match(TrafficClassParserT__0)
 	}
 	{
-		p.SetState(30)
-		p.Match(TrafficClassParserNET)
+		p.SetState(46)
+		_la = p.GetTokenStream().LA(1)
+
+		if !(_la == TrafficClassParserNET || _la == TrafficClassParserNET6) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
+		}
 	}
 
 	return localctx
@@ -441,15 +506,15 @@ func (p *TrafficClassParser) MatchDSCP() (localctx IMatchDSCPContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(32)
+		p.SetState(48)
 		p.Match(TrafficClassParserDSCP)
 	}
 	{
-		p.SetState(33)
+		p.SetState(49)
 		p.Match(TrafficClassParserT__1)
 	}
 	{
-		p.SetState(34)
+		p.SetState(50)
 		_la = p.GetTokenStream().LA(1)
 
 		if !(_la == TrafficClassParserDIGITS || _la == TrafficClassParserHEX_DIGITS) {
@@ -519,25 +584,691 @@ func (s *MatchTOSContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *MatchTOSContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *MatchTOSContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *MatchTOSContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterMatchTOS(s)
+	}
+}
+
+func (s *MatchTOSContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitMatchTOS(s)
+	}
+}
+
+func (p *TrafficClassParser) MatchTOS() (localctx IMatchTOSContext) {
+	localctx = NewMatchTOSContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 6, TrafficClassParserRULE_matchTOS)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(52)
+		p.Match(TrafficClassParserTOS)
+	}
+	{
+		p.SetState(53)
+		p.Match(TrafficClassParserT__1)
+	}
+	{
+		p.SetState(54)
+		_la = p.GetTokenStream().LA(1)
+
+		if !(_la == TrafficClassParserDIGITS || _la == TrafficClassParserHEX_DIGITS) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
+		}
+	}
+
+	return localctx
+}
+
+// IMatchTCContext is an interface to support dynamic dispatch.
+type IMatchTCContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsMatchTCContext differentiates from other interfaces.
+	IsMatchTCContext()
+}
+
+type MatchTCContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyMatchTCContext() *MatchTCContext {
+	var p = new(MatchTCContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_matchTC
+	return p
+}
+
+func (*MatchTCContext) IsMatchTCContext() {}
+
+func NewMatchTCContext(parser antlr.Parser, parent antlr.ParserRuleContext,
+	invokingState int) *MatchTCContext {
+
+	var p = new(MatchTCContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_matchTC
+
+	return p
+}
+
+func (s *MatchTCContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *MatchTCContext) TC() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserTC, 0)
+}
+
+func (s *MatchTCContext) HEX_DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserHEX_DIGITS, 0)
+}
+
+func (s *MatchTCContext) DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDIGITS, 0)
+}
+
+func (s *MatchTCContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *MatchTCContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *MatchTCContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterMatchTC(s)
+	}
+}
+
+func (s *MatchTCContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitMatchTC(s)
+	}
+}
+
+func (p *TrafficClassParser) MatchTC() (localctx IMatchTCContext) {
+	localctx = NewMatchTCContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 8, TrafficClassParserRULE_matchTC)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(56)
+		p.Match(TrafficClassParserTC)
+	}
+	{
+		p.SetState(57)
+		p.Match(TrafficClassParserT__1)
+	}
+	{
+		p.SetState(58)
+		_la = p.GetTokenStream().LA(1)
+
+		if !(_la == TrafficClassParserDIGITS || _la == TrafficClassParserHEX_DIGITS) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
+		}
+	}
+
+	return localctx
+}
+
+// IMatchFlowLabelContext is an interface to support dynamic dispatch.
+type IMatchFlowLabelContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsMatchFlowLabelContext differentiates from other interfaces.
+	IsMatchFlowLabelContext()
+}
+
+type MatchFlowLabelContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyMatchFlowLabelContext() *MatchFlowLabelContext {
+	var p = new(MatchFlowLabelContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_matchFlowLabel
+	return p
+}
+
+func (*MatchFlowLabelContext) IsMatchFlowLabelContext() {}
+
+func NewMatchFlowLabelContext(parser antlr.Parser, parent antlr.ParserRuleContext,
+	invokingState int) *MatchFlowLabelContext {
+
+	var p = new(MatchFlowLabelContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_matchFlowLabel
+
+	return p
+}
+
+func (s *MatchFlowLabelContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *MatchFlowLabelContext) FLOWLABEL() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserFLOWLABEL, 0)
+}
+
+func (s *MatchFlowLabelContext) DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDIGITS, 0)
+}
+
+func (s *MatchFlowLabelContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *MatchFlowLabelContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *MatchFlowLabelContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterMatchFlowLabel(s)
+	}
+}
+
+func (s *MatchFlowLabelContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitMatchFlowLabel(s)
+	}
+}
+
+func (p *TrafficClassParser) MatchFlowLabel() (localctx IMatchFlowLabelContext) {
+	localctx = NewMatchFlowLabelContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 10, TrafficClassParserRULE_matchFlowLabel)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(60)
+		p.Match(TrafficClassParserFLOWLABEL)
+	}
+	{
+		p.SetState(61)
+		p.Match(TrafficClassParserT__0)
+	}
+	{
+		p.SetState(62)
+		p.Match(TrafficClassParserDIGITS)
+	}
+
+	return localctx
+}
+
+// IMatchNextHdrContext is an interface to support dynamic dispatch.
+type IMatchNextHdrContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsMatchNextHdrContext differentiates from other interfaces.
+	IsMatchNextHdrContext()
+}
+
+type MatchNextHdrContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyMatchNextHdrContext() *MatchNextHdrContext {
+	var p = new(MatchNextHdrContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_matchNextHdr
+	return p
+}
+
+func (*MatchNextHdrContext) IsMatchNextHdrContext() {}
+
+func NewMatchNextHdrContext(parser antlr.Parser, parent antlr.ParserRuleContext,
+	invokingState int) *MatchNextHdrContext {
+
+	var p = new(MatchNextHdrContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_matchNextHdr
+
+	return p
+}
+
+func (s *MatchNextHdrContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *MatchNextHdrContext) NEXTHDR() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserNEXTHDR, 0)
+}
+
+func (s *MatchNextHdrContext) DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDIGITS, 0)
+}
+
+func (s *MatchNextHdrContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *MatchNextHdrContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *MatchNextHdrContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterMatchNextHdr(s)
+	}
+}
+
+func (s *MatchNextHdrContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitMatchNextHdr(s)
+	}
+}
+
+func (p *TrafficClassParser) MatchNextHdr() (localctx IMatchNextHdrContext) {
+	localctx = NewMatchNextHdrContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 12, TrafficClassParserRULE_matchNextHdr)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(64)
+		p.Match(TrafficClassParserNEXTHDR)
+	}
+	{
+		p.SetState(65)
+		p.Match(TrafficClassParserT__0)
+	}
+	{
+		p.SetState(66)
+		p.Match(TrafficClassParserDIGITS)
+	}
+
+	return localctx
+}
+
+// IMatchProtoContext is an interface to support dynamic dispatch.
+type IMatchProtoContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsMatchProtoContext differentiates from other interfaces.
+	IsMatchProtoContext()
+}
+
+type MatchProtoContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyMatchProtoContext() *MatchProtoContext {
+	var p = new(MatchProtoContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_matchProto
+	return p
+}
+
+func (*MatchProtoContext) IsMatchProtoContext() {}
+
+func NewMatchProtoContext(parser antlr.Parser, parent antlr.ParserRuleContext,
+	invokingState int) *MatchProtoContext {
+
+	var p = new(MatchProtoContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_matchProto
+
+	return p
+}
+
+func (s *MatchProtoContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *MatchProtoContext) PROTO() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserPROTO, 0)
+}
+
+func (s *MatchProtoContext) DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDIGITS, 0)
+}
+
+func (s *MatchProtoContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *MatchProtoContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *MatchProtoContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterMatchProto(s)
+	}
+}
+
+func (s *MatchProtoContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitMatchProto(s)
+	}
+}
+
+func (p *TrafficClassParser) MatchProto() (localctx IMatchProtoContext) {
+	localctx = NewMatchProtoContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 14, TrafficClassParserRULE_matchProto)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(68)
+		p.Match(TrafficClassParserPROTO)
+	}
+	{
+		p.SetState(69)
+		p.Match(TrafficClassParserT__0)
+	}
+	{
+		p.SetState(70)
+		p.Match(TrafficClassParserDIGITS)
+	}
+
+	return localctx
+}
+
+// IMatchSrcPortContext is an interface to support dynamic dispatch.
+type IMatchSrcPortContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsMatchSrcPortContext differentiates from other interfaces.
+	IsMatchSrcPortContext()
+}
+
+type MatchSrcPortContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyMatchSrcPortContext() *MatchSrcPortContext {
+	var p = new(MatchSrcPortContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_matchSrcPort
+	return p
+}
+
+func (*MatchSrcPortContext) IsMatchSrcPortContext() {}
+
+func NewMatchSrcPortContext(parser antlr.Parser, parent antlr.ParserRuleContext,
+	invokingState int) *MatchSrcPortContext {
+
+	var p = new(MatchSrcPortContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_matchSrcPort
+
+	return p
+}
+
+func (s *MatchSrcPortContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *MatchSrcPortContext) SPORT() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserSPORT, 0)
+}
+
+func (s *MatchSrcPortContext) DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDIGITS, 0)
+}
+
+func (s *MatchSrcPortContext) RANGE() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserRANGE, 0)
+}
+
+func (s *MatchSrcPortContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *MatchSrcPortContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *MatchSrcPortContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterMatchSrcPort(s)
+	}
+}
+
+func (s *MatchSrcPortContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitMatchSrcPort(s)
+	}
+}
+
+func (p *TrafficClassParser) MatchSrcPort() (localctx IMatchSrcPortContext) {
+	localctx = NewMatchSrcPortContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 16, TrafficClassParserRULE_matchSrcPort)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(72)
+		p.Match(TrafficClassParserSPORT)
+	}
+	{
+		p.SetState(73)
+		p.Match(TrafficClassParserT__0)
+	}
+	{
+		p.SetState(74)
+		_la = p.GetTokenStream().LA(1)
+
+		if !(_la == TrafficClassParserDIGITS || _la == TrafficClassParserRANGE) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
+		}
+	}
+
+	return localctx
+}
+
+// IMatchDstPortContext is an interface to support dynamic dispatch.
+type IMatchDstPortContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsMatchDstPortContext differentiates from other interfaces.
+	IsMatchDstPortContext()
+}
+
+type MatchDstPortContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyMatchDstPortContext() *MatchDstPortContext {
+	var p = new(MatchDstPortContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_matchDstPort
+	return p
+}
+
+func (*MatchDstPortContext) IsMatchDstPortContext() {}
+
+func NewMatchDstPortContext(parser antlr.Parser, parent antlr.ParserRuleContext,
+	invokingState int) *MatchDstPortContext {
+
+	var p = new(MatchDstPortContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_matchDstPort
+
+	return p
+}
+
+func (s *MatchDstPortContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *MatchDstPortContext) DPORT() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDPORT, 0)
+}
+
+func (s *MatchDstPortContext) DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDIGITS, 0)
+}
+
+func (s *MatchDstPortContext) RANGE() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserRANGE, 0)
+}
+
+func (s *MatchDstPortContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *MatchDstPortContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *MatchTOSContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *MatchDstPortContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TrafficClassListener); ok {
-		listenerT.EnterMatchTOS(s)
+		listenerT.EnterMatchDstPort(s)
 	}
 }
 
-func (s *MatchTOSContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *MatchDstPortContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TrafficClassListener); ok {
-		listenerT.ExitMatchTOS(s)
+		listenerT.ExitMatchDstPort(s)
 	}
 }
 
-func (p *TrafficClassParser) MatchTOS() (localctx IMatchTOSContext) {
-	localctx = NewMatchTOSContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 6, TrafficClassParserRULE_matchTOS)
+func (p *TrafficClassParser) MatchDstPort() (localctx IMatchDstPortContext) {
+	localctx = NewMatchDstPortContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 18, TrafficClassParserRULE_matchDstPort)
 	var _la int
 
 	defer func() {
@@ -558,18 +1289,18 @@ func (p *TrafficClassParser) MatchTOS() (localctx IMatchTOSContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(36)
-		p.Match(TrafficClassParserTOS)
+		p.SetState(76)
+		p.Match(TrafficClassParserDPORT)
 	}
 	{
-		p.SetState(37)
-		p.Match(TrafficClassParserT__1)
+		p.SetState(77)
+		p.Match(TrafficClassParserT__0)
 	}
 	{
-		p.SetState(38)
+		p.SetState(78)
 		_la = p.GetTokenStream().LA(1)
 
-		if !(_la == TrafficClassParserDIGITS || _la == TrafficClassParserHEX_DIGITS) {
+		if !(_la == TrafficClassParserDIGITS || _la == TrafficClassParserRANGE) {
 			p.GetErrorHandler().RecoverInline(p)
 		} else {
 			p.GetErrorHandler().ReportMatch(p)
@@ -646,7 +1377,7 @@ func (s *CondClsContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) CondCls() (localctx ICondClsContext) {
 	localctx = NewCondClsContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 8, TrafficClassParserRULE_condCls)
+	p.EnterRule(localctx, 20, TrafficClassParserRULE_condCls)
 
 	defer func() {
 		p.ExitRule()
@@ -666,11 +1397,11 @@ func (p *TrafficClassParser) CondCls() (localctx ICondClsContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(40)
+		p.SetState(80)
 		p.Match(TrafficClassParserT__2)
 	}
 	{
-		p.SetState(41)
+		p.SetState(81)
 		p.Match(TrafficClassParserDIGITS)
 	}
 
@@ -766,7 +1497,7 @@ func (s *CondAnyContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) CondAny() (localctx ICondAnyContext) {
 	localctx = NewCondAnyContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 10, TrafficClassParserRULE_condAny)
+	p.EnterRule(localctx, 22, TrafficClassParserRULE_condAny)
 	var _la int
 
 	defer func() {
@@ -787,37 +1518,37 @@ func (p *TrafficClassParser) CondAny() (localctx ICondAnyContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(43)
+		p.SetState(83)
 		p.Match(TrafficClassParserANY)
 	}
 	{
-		p.SetState(44)
+		p.SetState(84)
 		p.Match(TrafficClassParserT__3)
 	}
 	{
-		p.SetState(45)
+		p.SetState(85)
 		p.Cond()
 	}
-	p.SetState(50)
+	p.SetState(90)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	for _la == TrafficClassParserT__4 {
 		{
-			p.SetState(46)
+			p.SetState(86)
 			p.Match(TrafficClassParserT__4)
 		}
 		{
-			p.SetState(47)
+			p.SetState(87)
 			p.Cond()
 		}
 
-		p.SetState(52)
+		p.SetState(92)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(53)
+		p.SetState(93)
 		p.Match(TrafficClassParserT__5)
 	}
 
@@ -913,7 +1644,7 @@ func (s *CondAllContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) CondAll() (localctx ICondAllContext) {
 	localctx = NewCondAllContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 12, TrafficClassParserRULE_condAll)
+	p.EnterRule(localctx, 24, TrafficClassParserRULE_condAll)
 	var _la int
 
 	defer func() {
@@ -934,37 +1665,37 @@ func (p *TrafficClassParser) CondAll() (localctx ICondAllContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(55)
+		p.SetState(95)
 		p.Match(TrafficClassParserALL)
 	}
 	{
-		p.SetState(56)
+		p.SetState(96)
 		p.Match(TrafficClassParserT__3)
 	}
 	{
-		p.SetState(57)
+		p.SetState(97)
 		p.Cond()
 	}
-	p.SetState(62)
+	p.SetState(102)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	for _la == TrafficClassParserT__4 {
 		{
-			p.SetState(58)
+			p.SetState(98)
 			p.Match(TrafficClassParserT__4)
 		}
 		{
-			p.SetState(59)
+			p.SetState(99)
 			p.Cond()
 		}
 
-		p.SetState(64)
+		p.SetState(104)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(65)
+		p.SetState(105)
 		p.Match(TrafficClassParserT__5)
 	}
 
@@ -1047,7 +1778,7 @@ func (s *CondNotContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) CondNot() (localctx ICondNotContext) {
 	localctx = NewCondNotContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 14, TrafficClassParserRULE_condNot)
+	p.EnterRule(localctx, 26, TrafficClassParserRULE_condNot)
 
 	defer func() {
 		p.ExitRule()
@@ -1067,19 +1798,19 @@ func (p *TrafficClassParser) CondNot() (localctx ICondNotContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(67)
+		p.SetState(107)
 		p.Match(TrafficClassParserNOT)
 	}
 	{
-		p.SetState(68)
+		p.SetState(108)
 		p.Match(TrafficClassParserT__3)
 	}
 	{
-		p.SetState(69)
+		p.SetState(109)
 		p.Cond()
 	}
 	{
-		p.SetState(70)
+		p.SetState(110)
 		p.Match(TrafficClassParserT__5)
 	}
 
@@ -1152,7 +1883,7 @@ func (s *CondBoolContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) CondBool() (localctx ICondBoolContext) {
 	localctx = NewCondBoolContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 16, TrafficClassParserRULE_condBool)
+	p.EnterRule(localctx, 28, TrafficClassParserRULE_condBool)
 	var _la int
 
 	defer func() {
@@ -1173,15 +1904,15 @@ func (p *TrafficClassParser) CondBool() (localctx ICondBoolContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(72)
+		p.SetState(112)
 		p.Match(TrafficClassParserBOOL)
 	}
 	{
-		p.SetState(73)
+		p.SetState(113)
 		p.Match(TrafficClassParserT__0)
 	}
 	{
-		p.SetState(74)
+		p.SetState(114)
 		_la = p.GetTokenStream().LA(1)
 
 		if !(_la == TrafficClassParserT__6 || _la == TrafficClassParserT__7) {
@@ -1297,7 +2028,7 @@ func (s *CondIPv4Context) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) CondIPv4() (localctx ICondIPv4Context) {
 	localctx = NewCondIPv4Context(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 18, TrafficClassParserRULE_condIPv4)
+	p.EnterRule(localctx, 30, TrafficClassParserRULE_condIPv4)
 
 	defer func() {
 		p.ExitRule()
@@ -1315,35 +2046,35 @@ func (p *TrafficClassParser) CondIPv4() (localctx ICondIPv4Context) {
 		}
 	}()
 
-	p.SetState(80)
+	p.SetState(120)
 	p.GetErrorHandler().Sync(p)
 
 	switch p.GetTokenStream().LA(1) {
 	case TrafficClassParserSRC:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(76)
+			p.SetState(116)
 			p.MatchSrc()
 		}
 
 	case TrafficClassParserDST:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(77)
+			p.SetState(117)
 			p.MatchDst()
 		}
 
 	case TrafficClassParserDSCP:
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(78)
+			p.SetState(118)
 			p.MatchDSCP()
 		}
 
 	case TrafficClassParserTOS:
 		p.EnterOuterAlt(localctx, 4)
 		{
-			p.SetState(79)
+			p.SetState(119)
 			p.MatchTOS()
 		}
 
@@ -1354,6 +2085,290 @@ func (p *TrafficClassParser) CondIPv4() (localctx ICondIPv4Context) {
 	return localctx
 }
 
+// ICondIPv6Context is an interface to support dynamic dispatch.
+type ICondIPv6Context interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsCondIPv6Context differentiates from other interfaces.
+	IsCondIPv6Context()
+}
+
+type CondIPv6Context struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyCondIPv6Context() *CondIPv6Context {
+	var p = new(CondIPv6Context)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_condIPv6
+	return p
+}
+
+func (*CondIPv6Context) IsCondIPv6Context() {}
+
+func NewCondIPv6Context(parser antlr.Parser, parent antlr.ParserRuleContext,
+	invokingState int) *CondIPv6Context {
+
+	var p = new(CondIPv6Context)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_condIPv6
+
+	return p
+}
+
+func (s *CondIPv6Context) GetParser() antlr.Parser { return s.parser }
+
+func (s *CondIPv6Context) MatchTC() IMatchTCContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchTCContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMatchTCContext)
+}
+
+func (s *CondIPv6Context) MatchFlowLabel() IMatchFlowLabelContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchFlowLabelContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMatchFlowLabelContext)
+}
+
+func (s *CondIPv6Context) MatchNextHdr() IMatchNextHdrContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchNextHdrContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMatchNextHdrContext)
+}
+
+func (s *CondIPv6Context) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *CondIPv6Context) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *CondIPv6Context) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterCondIPv6(s)
+	}
+}
+
+func (s *CondIPv6Context) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitCondIPv6(s)
+	}
+}
+
+func (p *TrafficClassParser) CondIPv6() (localctx ICondIPv6Context) {
+	localctx = NewCondIPv6Context(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 32, TrafficClassParserRULE_condIPv6)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.SetState(125)
+	p.GetErrorHandler().Sync(p)
+
+	switch p.GetTokenStream().LA(1) {
+	case TrafficClassParserTC:
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(122)
+			p.MatchTC()
+		}
+
+	case TrafficClassParserFLOWLABEL:
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(123)
+			p.MatchFlowLabel()
+		}
+
+	case TrafficClassParserNEXTHDR:
+		p.EnterOuterAlt(localctx, 3)
+		{
+			p.SetState(124)
+			p.MatchNextHdr()
+		}
+
+	default:
+		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+	}
+
+	return localctx
+}
+
+// ICondL4Context is an interface to support dynamic dispatch.
+type ICondL4Context interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsCondL4Context differentiates from other interfaces.
+	IsCondL4Context()
+}
+
+type CondL4Context struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyCondL4Context() *CondL4Context {
+	var p = new(CondL4Context)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_condL4
+	return p
+}
+
+func (*CondL4Context) IsCondL4Context() {}
+
+func NewCondL4Context(parser antlr.Parser, parent antlr.ParserRuleContext,
+	invokingState int) *CondL4Context {
+
+	var p = new(CondL4Context)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_condL4
+
+	return p
+}
+
+func (s *CondL4Context) GetParser() antlr.Parser { return s.parser }
+
+func (s *CondL4Context) MatchProto() IMatchProtoContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchProtoContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMatchProtoContext)
+}
+
+func (s *CondL4Context) MatchSrcPort() IMatchSrcPortContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchSrcPortContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMatchSrcPortContext)
+}
+
+func (s *CondL4Context) MatchDstPort() IMatchDstPortContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchDstPortContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMatchDstPortContext)
+}
+
+func (s *CondL4Context) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *CondL4Context) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *CondL4Context) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterCondL4(s)
+	}
+}
+
+func (s *CondL4Context) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitCondL4(s)
+	}
+}
+
+func (p *TrafficClassParser) CondL4() (localctx ICondL4Context) {
+	localctx = NewCondL4Context(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 34, TrafficClassParserRULE_condL4)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.SetState(130)
+	p.GetErrorHandler().Sync(p)
+
+	switch p.GetTokenStream().LA(1) {
+	case TrafficClassParserPROTO:
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(127)
+			p.MatchProto()
+		}
+
+	case TrafficClassParserSPORT:
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(128)
+			p.MatchSrcPort()
+		}
+
+	case TrafficClassParserDPORT:
+		p.EnterOuterAlt(localctx, 3)
+		{
+			p.SetState(129)
+			p.MatchDstPort()
+		}
+
+	default:
+		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+	}
+
+	return localctx
+}
+
 // ICondContext is an interface to support dynamic dispatch.
 type ICondContext interface {
 	antlr.ParserRuleContext
@@ -1434,6 +2449,26 @@ func (s *CondContext) CondIPv4() ICondIPv4Context {
 	return t.(ICondIPv4Context)
 }
 
+func (s *CondContext) CondIPv6() ICondIPv6Context {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ICondIPv6Context)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ICondIPv6Context)
+}
+
+func (s *CondContext) CondL4() ICondL4Context {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ICondL4Context)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ICondL4Context)
+}
+
 func (s *CondContext) CondCls() ICondClsContext {
 	var t = s.GetTypedRuleContext(reflect.TypeOf((*ICondClsContext)(nil)).Elem(), 0)
 
@@ -1476,7 +2511,7 @@ func (s *CondContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) Cond() (localctx ICondContext) {
 	localctx = NewCondContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 20, TrafficClassParserRULE_cond)
+	p.EnterRule(localctx, 36, TrafficClassParserRULE_cond)
 
 	defer func() {
 		p.ExitRule()
@@ -1494,28 +2529,28 @@ func (p *TrafficClassParser) Cond() (localctx ICondContext) {
 		}
 	}()
 
-	p.SetState(88)
+	p.SetState(140)
 	p.GetErrorHandler().Sync(p)
 
 	switch p.GetTokenStream().LA(1) {
 	case TrafficClassParserALL:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(82)
+			p.SetState(132)
 			p.CondAll()
 		}
 
 	case TrafficClassParserANY:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(83)
+			p.SetState(133)
 			p.CondAny()
 		}
 
 	case TrafficClassParserNOT:
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(84)
+			p.SetState(134)
 			p.CondNot()
 		}
 
@@ -1524,21 +2559,35 @@ func (p *TrafficClassParser) Cond() (localctx ICondContext) {
 
 		p.EnterOuterAlt(localctx, 4)
 		{
-			p.SetState(85)
+			p.SetState(135)
 			p.CondIPv4()
 		}
 
-	case TrafficClassParserT__2:
+	case TrafficClassParserTC, TrafficClassParserFLOWLABEL, TrafficClassParserNEXTHDR:
 		p.EnterOuterAlt(localctx, 5)
 		{
-			p.SetState(86)
+			p.SetState(136)
+			p.CondIPv6()
+		}
+
+	case TrafficClassParserPROTO, TrafficClassParserSPORT, TrafficClassParserDPORT:
+		p.EnterOuterAlt(localctx, 6)
+		{
+			p.SetState(137)
+			p.CondL4()
+		}
+
+	case TrafficClassParserT__2:
+		p.EnterOuterAlt(localctx, 7)
+		{
+			p.SetState(138)
 			p.CondCls()
 		}
 
 	case TrafficClassParserBOOL:
-		p.EnterOuterAlt(localctx, 6)
+		p.EnterOuterAlt(localctx, 8)
 		{
-			p.SetState(87)
+			p.SetState(139)
 			p.CondBool()
 		}
 
@@ -1625,7 +2674,7 @@ func (s *TrafficClassContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) TrafficClass() (localctx ITrafficClassContext) {
 	localctx = NewTrafficClassContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 22, TrafficClassParserRULE_trafficClass)
+	p.EnterRule(localctx, 38, TrafficClassParserRULE_trafficClass)
 
 	defer func() {
 		p.ExitRule()
@@ -1645,11 +2694,11 @@ func (p *TrafficClassParser) TrafficClass() (localctx ITrafficClassContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(90)
+		p.SetState(142)
 		p.Cond()
 	}
 	{
-		p.SetState(91)
+		p.SetState(143)
 		p.Match(TrafficClassParserEOF)
 	}
 
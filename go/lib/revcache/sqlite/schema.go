@@ -0,0 +1,42 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlite
+
+import "github.com/scionproto/scion/go/lib/infra/modules/db"
+
+// Migrations are the schema migrations of the SQLite revocation cache.
+// Existing migrations must never be changed, schema changes are appended as
+// new migrations.
+var Migrations = db.Migrations{
+	{
+		Version:     1,
+		Description: "Initial schema",
+		Up: `CREATE TABLE Revocations(
+			IsdID INTEGER NOT NULL,
+			AsID INTEGER NOT NULL,
+			IfID INTEGER NOT NULL,
+			IssuingTime INTEGER NOT NULL,
+			Expiration INTEGER NOT NULL,
+			RawSignedRev DATA NOT NULL,
+			PRIMARY KEY (IsdID, AsID, IfID)
+		);
+		CREATE INDEX RevocationsExpiration ON Revocations(Expiration);
+		`,
+	},
+}
+
+const (
+	RevocationsTable = "Revocations"
+)
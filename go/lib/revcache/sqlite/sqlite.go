@@ -0,0 +1,217 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sqlite contains a persistent SQLite backend for the revocation
+// cache.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"sync"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/infra/modules/db"
+	"github.com/scionproto/scion/go/lib/revcache"
+)
+
+var _ revcache.RevCache = (*Backend)(nil)
+
+// Backend is a revocation cache that is backed by an SQLite database.
+type Backend struct {
+	sync.RWMutex
+	db *sql.DB
+}
+
+// New returns a new SQLite backend opening a database at the given path. If
+// no database exists a new database is be created. Pending migrations in
+// schema.go are applied. If the schema version of the stored database is newer
+// than the latest migration, an error is returned.
+func New(path string) (*Backend, error) {
+	db, err := db.NewSqlite(path, Migrations)
+	if err != nil {
+		return nil, err
+	}
+	return NewFromDB(db), nil
+}
+
+// NewFromDB returns a new SQLite backend that uses an already opened
+// database. The database must contain the revocations table, e.g., a path
+// database that shares its storage with the revocation cache.
+func NewFromDB(db *sql.DB) *Backend {
+	return &Backend{db: db}
+}
+
+func (b *Backend) Get(ctx context.Context, keys revcache.KeySet) (revcache.Revocations, error) {
+	if len(keys) == 0 {
+		return revcache.Revocations{}, nil
+	}
+	b.RLock()
+	defer b.RUnlock()
+	conds := make([]string, 0, len(keys))
+	args := make([]interface{}, 0, 3*len(keys)+1)
+	args = append(args, time.Now().Unix())
+	for k := range keys {
+		conds = append(conds, "(IsdID = ? AND AsID = ? AND IfID = ?)")
+		args = append(args, k.IA.I, k.IA.A, k.IfId)
+	}
+	query := "SELECT RawSignedRev FROM Revocations WHERE Expiration > ? AND (" +
+		strings.Join(conds, " OR ") + ")"
+	rows, err := b.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, db.NewReadError("lookup revocations", err)
+	}
+	defer rows.Close()
+	revs := make(revcache.Revocations, len(keys))
+	for rows.Next() {
+		rev, info, err := scanRevocation(rows)
+		if err != nil {
+			return nil, err
+		}
+		revs[*revcache.NewKey(info.IA(), info.IfID)] = rev
+	}
+	if err := rows.Err(); err != nil {
+		return nil, db.NewReadError("read rows", err)
+	}
+	return revs, nil
+}
+
+func (b *Backend) GetAll(ctx context.Context) (revcache.ResultChan, error) {
+	b.RLock()
+	defer b.RUnlock()
+	query := "SELECT RawSignedRev FROM Revocations WHERE Expiration > ?"
+	rows, err := b.db.QueryContext(ctx, query, time.Now().Unix())
+	if err != nil {
+		return nil, db.NewReadError("lookup revocations", err)
+	}
+	// The result is collected upfront, so that the connection is released
+	// before the caller drains the channel.
+	defer rows.Close()
+	var res []revcache.RevOrErr
+	for rows.Next() {
+		rev, _, err := scanRevocation(rows)
+		res = append(res, revcache.RevOrErr{Rev: rev, Err: err})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, db.NewReadError("read rows", err)
+	}
+	resCh := make(chan revcache.RevOrErr, len(res))
+	for _, r := range res {
+		resCh <- r
+	}
+	close(resCh)
+	return resCh, nil
+}
+
+func (b *Backend) Insert(ctx context.Context, rev *path_mgmt.SignedRevInfo) (bool, error) {
+	info, err := rev.RevInfo()
+	if err != nil {
+		panic(err)
+	}
+	now := time.Now()
+	if !info.Expiration().After(now) {
+		return false, nil
+	}
+	packed, err := rev.Pack()
+	if err != nil {
+		return false, db.NewInputDataError("pack revocation", err)
+	}
+	b.Lock()
+	defer b.Unlock()
+	var inserted bool
+	err = db.DoInTx(ctx, b.db, func(ctx context.Context, tx *sql.Tx) error {
+		var issuingTime uint32
+		query := `
+		SELECT IssuingTime FROM Revocations
+		WHERE IsdID = ? AND AsID = ? AND IfID = ? AND Expiration > ?
+		`
+		err := tx.QueryRowContext(ctx, query, info.IA().I, info.IA().A, info.IfID,
+			now.Unix()).Scan(&issuingTime)
+		switch {
+		case err == sql.ErrNoRows:
+		case err != nil:
+			return db.NewReadError("lookup existing revocation", err)
+		case info.RawTimestamp <= issuingTime:
+			return nil
+		}
+		if err := insert(ctx, tx, info, packed); err != nil {
+			return err
+		}
+		inserted = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return inserted, nil
+}
+
+func (b *Backend) DeleteExpired(ctx context.Context) (int64, error) {
+	b.Lock()
+	defer b.Unlock()
+	cnt, err := db.DeleteInTx(ctx, b.db, func(tx *sql.Tx) (sql.Result, error) {
+		return tx.ExecContext(ctx, "DELETE FROM Revocations WHERE Expiration <= ?",
+			time.Now().Unix())
+	})
+	return int64(cnt), err
+}
+
+func (b *Backend) Close() error {
+	return b.db.Close()
+}
+
+func (b *Backend) SetMaxOpenConns(maxOpenConns int) {
+	b.db.SetMaxOpenConns(maxOpenConns)
+}
+
+func (b *Backend) SetMaxIdleConns(maxIdleConns int) {
+	b.db.SetMaxIdleConns(maxIdleConns)
+}
+
+func insert(ctx context.Context, tx *sql.Tx, info *path_mgmt.RevInfo,
+	packed common.RawBytes) error {
+
+	query := `
+	INSERT OR REPLACE INTO Revocations
+	(IsdID, AsID, IfID, IssuingTime, Expiration, RawSignedRev)
+	VALUES (?, ?, ?, ?, ?, ?)
+	`
+	_, err := tx.ExecContext(ctx, query, info.IA().I, info.IA().A, info.IfID,
+		info.RawTimestamp, info.Expiration().Unix(), packed)
+	if err != nil {
+		return db.NewWriteError("insert revocation", err)
+	}
+	return nil
+}
+
+func scanRevocation(rows *sql.Rows) (*path_mgmt.SignedRevInfo, *path_mgmt.RevInfo, error) {
+	var raw common.RawBytes
+	if err := rows.Scan(&raw); err != nil {
+		return nil, nil, db.NewReadError("read rows", err)
+	}
+	rev, err := path_mgmt.NewSignedRevInfoFromRaw(raw)
+	if err != nil {
+		return nil, nil, db.NewDataError("parse revocation", err)
+	}
+	info, err := rev.RevInfo()
+	if err != nil {
+		return nil, nil, db.NewDataError("parse revocation", err)
+	}
+	return rev, info, nil
+}
@@ -42,7 +42,10 @@ func (q Querier) Query(ctx context.Context, dst addr.IA) ([]snet.Path, error) {
 	return q.Connector.Paths(ctx, dst, q.IA, PathReqFlags{PathCount: q.MaxPaths})
 }
 
-// RevHandler is an adapter for sciond connector to implement snet.RevocationHandler.
+var _ snet.PacketRevocationHandler = RevHandler{}
+
+// RevHandler is an adapter for sciond connector to implement snet.RevocationHandler
+// and snet.PacketRevocationHandler.
 type RevHandler struct {
 	Connector Connector
 }
@@ -54,6 +57,13 @@ func (h RevHandler) RevokeRaw(ctx context.Context, rawSRevInfo common.RawBytes)
 	}
 }
 
+func (h RevHandler) RevokeFromPacket(ctx context.Context, rawSRevInfo, rawPkt common.RawBytes) {
+	_, err := h.Connector.RevNotificationFromPacket(ctx, rawSRevInfo, rawPkt)
+	if err != nil {
+		log.FromCtx(ctx).Error("Revocation notification to sciond failed", "err", err)
+	}
+}
+
 // TopoQuerier can be used to get topology information from sciond.
 type TopoQuerier struct {
 	Connector Connector
@@ -103,6 +113,7 @@ type Path struct {
 	spath      *spath.Path
 	mtu        uint16
 	expiry     time.Time
+	metadata   *snet.PathMetadata
 	dst        addr.IA
 }
 
@@ -138,6 +149,7 @@ func pathReplyEntryToPath(pe PathReplyEntry, dst addr.IA) (Path, error) {
 		spath:      sp,
 		mtu:        pe.Path.Mtu,
 		expiry:     pe.Path.Expiry(),
+		metadata:   pe.Path.Metadata.ToSnet(),
 	}
 	for _, intf := range pe.Path.Interfaces {
 		p.interfaces = append(p.interfaces, pathInterface{ia: intf.IA(), id: intf.ID()})
@@ -145,6 +157,48 @@ func pathReplyEntryToPath(pe PathReplyEntry, dst addr.IA) (Path, error) {
 	return p, nil
 }
 
+// ToSnet converts the metadata to its snet representation.
+func (pm *PathMetadata) ToSnet() *snet.PathMetadata {
+	if pm == nil {
+		return nil
+	}
+	res := &snet.PathMetadata{
+		Latency:      make([]time.Duration, 0, len(pm.Latency)),
+		Bandwidth:    append(pm.Bandwidth[:0:0], pm.Bandwidth...),
+		Geo:          make([]snet.GeoCoordinates, 0, len(pm.Geo)),
+		LinkType:     make([]snet.LinkType, 0, len(pm.LinkType)),
+		InternalHops: append(pm.InternalHops[:0:0], pm.InternalHops...),
+		Notes:        append(pm.Notes[:0:0], pm.Notes...),
+	}
+	for _, l := range pm.Latency {
+		if l < 0 {
+			res.Latency = append(res.Latency, snet.LatencyUnset)
+			continue
+		}
+		res.Latency = append(res.Latency, time.Duration(l)*time.Microsecond)
+	}
+	for _, g := range pm.Geo {
+		res.Geo = append(res.Geo, snet.GeoCoordinates(g))
+	}
+	for _, lt := range pm.LinkType {
+		res.LinkType = append(res.LinkType, linkTypeToSnet(lt))
+	}
+	return res
+}
+
+func linkTypeToSnet(lt proto.StaticInfoExtn_LinkType) snet.LinkType {
+	switch lt {
+	case proto.StaticInfoExtn_LinkType_direct:
+		return snet.LinkTypeDirect
+	case proto.StaticInfoExtn_LinkType_multiHop:
+		return snet.LinkTypeMultihop
+	case proto.StaticInfoExtn_LinkType_openNet:
+		return snet.LinkTypeOpennet
+	default:
+		return snet.LinkTypeUnset
+	}
+}
+
 func (p Path) Fingerprint() snet.PathFingerprint {
 	if len(p.interfaces) == 0 {
 		return ""
@@ -201,6 +255,10 @@ func (p Path) Expiry() time.Time {
 	return p.expiry
 }
 
+func (p Path) Metadata() *snet.PathMetadata {
+	return p.metadata.Copy()
+}
+
 func (p Path) Copy() snet.Path {
 	return Path{
 		interfaces: append(p.interfaces[:0:0], p.interfaces...),
@@ -208,6 +266,7 @@ func (p Path) Copy() snet.Path {
 		spath:      p.Path(),           // creates copy
 		mtu:        p.mtu,
 		expiry:     p.expiry,
+		metadata:   p.Metadata(), // creates copy
 	}
 }
 
@@ -175,6 +175,11 @@ func TestSVCInfo(t *testing.T) {
 
 }
 
+func TestSegTypeHop(t *testing.T) {
+	c := fake.New(&fake.Script{})
+	assert.PanicsWithValue(t, "not implemented", func() { c.SegTypeHop(nil, 0) })
+}
+
 func TestRevNotificationFromRaw(t *testing.T) {
 	c := fake.New(&fake.Script{})
 	assert.PanicsWithValue(t, "not implemented", func() { c.RevNotificationFromRaw(nil, nil) })
@@ -29,7 +29,9 @@ const (
 	subsystemASInfo     = "as_info"
 	subsystemIFInfo     = "if_info"
 	subsystemSVCInfo    = "service_info"
+	subsystemSegTypeHop = "seg_type_hop"
 	subsystemRevocation = "revocation"
+	subsystemDRKey      = "drkey"
 )
 
 // Result values
@@ -65,6 +67,10 @@ var (
 	IFInfos = newIFInfo()
 	// SVCInfos contains metrics for SVC info requests.
 	SVCInfos = newSVCInfo()
+	// SegTypeHops contains metrics for segment type hop requests.
+	SegTypeHops = newSegTypeHop()
+	// DRKeyLvl2s contains metrics for DRKey level 2 requests.
+	DRKeyLvl2s = newDRKeyLvl2()
 	// Conns contains metrics for connections to SCIOND.
 	Conns = newConn()
 )
@@ -114,9 +120,23 @@ func newSVCInfo() Request {
 	}
 }
 
+func newSegTypeHop() Request {
+	return Request{
+		count: prom.NewCounterVecWithLabels(Namespace, subsystemSegTypeHop, "requests_total",
+			"The amount of segment type hop requests sent.", resultLabel{}),
+	}
+}
+
 func newIFInfo() Request {
 	return Request{
 		count: prom.NewCounterVecWithLabels(Namespace, subsystemIFInfo, "requests_total",
 			"The amount of IF info requests sent.", resultLabel{}),
 	}
 }
+
+func newDRKeyLvl2() Request {
+	return Request{
+		count: prom.NewCounterVecWithLabels(Namespace, subsystemDRKey, "lvl2_requests_total",
+			"The amount of DRKey level 2 requests sent.", resultLabel{}),
+	}
+}
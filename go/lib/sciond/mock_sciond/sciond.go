@@ -10,11 +10,13 @@ import (
 	addr "github.com/scionproto/scion/go/lib/addr"
 	common "github.com/scionproto/scion/go/lib/common"
 	path_mgmt "github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	drkey "github.com/scionproto/scion/go/lib/drkey"
 	sciond "github.com/scionproto/scion/go/lib/sciond"
 	snet "github.com/scionproto/scion/go/lib/snet"
 	proto "github.com/scionproto/scion/go/proto"
 	net "net"
 	reflect "reflect"
+	time "time"
 )
 
 // MockService is a mock of Service interface
@@ -107,6 +109,21 @@ func (mr *MockConnectorMockRecorder) Close(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockConnector)(nil).Close), arg0)
 }
 
+// DRKeyGetLvl2Key mocks base method
+func (m *MockConnector) DRKeyGetLvl2Key(arg0 context.Context, arg1 drkey.Lvl2Meta, arg2 time.Time) (drkey.Lvl2Key, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DRKeyGetLvl2Key", arg0, arg1, arg2)
+	ret0, _ := ret[0].(drkey.Lvl2Key)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DRKeyGetLvl2Key indicates an expected call of DRKeyGetLvl2Key
+func (mr *MockConnectorMockRecorder) DRKeyGetLvl2Key(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DRKeyGetLvl2Key", reflect.TypeOf((*MockConnector)(nil).DRKeyGetLvl2Key), arg0, arg1, arg2)
+}
+
 // IFInfo mocks base method
 func (m *MockConnector) IFInfo(arg0 context.Context, arg1 []common.IFIDType) (map[common.IFIDType]*net.UDPAddr, error) {
 	m.ctrl.T.Helper()
@@ -152,6 +169,21 @@ func (mr *MockConnectorMockRecorder) RevNotification(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevNotification", reflect.TypeOf((*MockConnector)(nil).RevNotification), arg0, arg1)
 }
 
+// RevNotificationFromPacket mocks base method
+func (m *MockConnector) RevNotificationFromPacket(arg0 context.Context, arg1, arg2 []byte) (*sciond.RevReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevNotificationFromPacket", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*sciond.RevReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevNotificationFromPacket indicates an expected call of RevNotificationFromPacket
+func (mr *MockConnectorMockRecorder) RevNotificationFromPacket(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevNotificationFromPacket", reflect.TypeOf((*MockConnector)(nil).RevNotificationFromPacket), arg0, arg1, arg2)
+}
+
 // RevNotificationFromRaw mocks base method
 func (m *MockConnector) RevNotificationFromRaw(arg0 context.Context, arg1 []byte) (*sciond.RevReply, error) {
 	m.ctrl.T.Helper()
@@ -181,3 +213,18 @@ func (mr *MockConnectorMockRecorder) SVCInfo(arg0, arg1 interface{}) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SVCInfo", reflect.TypeOf((*MockConnector)(nil).SVCInfo), arg0, arg1)
 }
+
+// SegTypeHop mocks base method
+func (m *MockConnector) SegTypeHop(arg0 context.Context, arg1 proto.PathSegType) (*sciond.SegTypeHopReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SegTypeHop", arg0, arg1)
+	ret0, _ := ret[0].(*sciond.SegTypeHopReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SegTypeHop indicates an expected call of SegTypeHop
+func (mr *MockConnectorMockRecorder) SegTypeHop(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SegTypeHop", reflect.TypeOf((*MockConnector)(nil).SegTypeHop), arg0, arg1)
+}
@@ -31,13 +31,16 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"time"
 
 	capnp "zombiezen.com/go/capnproto2"
 	"zombiezen.com/go/capnproto2/pogs"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/drkey_mgmt"
 	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/drkey"
 	"github.com/scionproto/scion/go/lib/sciond/internal/metrics"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/snet"
@@ -95,11 +98,22 @@ type Connector interface {
 	// service types. If unset, a fresh (i.e., uncached) answer containing all
 	// service types is returned.
 	SVCInfo(ctx context.Context, svcTypes []proto.ServiceType) (*ServiceInfoReply, error)
+	// SegTypeHop requests from SCIOND the path segments of type segType that
+	// are stored in its path database.
+	SegTypeHop(ctx context.Context, segType proto.PathSegType) (*SegTypeHopReply, error)
 	// RevNotification sends a raw revocation to SCIOND, as contained in an
 	// SCMP message.
 	RevNotificationFromRaw(ctx context.Context, b []byte) (*RevReply, error)
 	// RevNotification sends a RevocationInfo message to SCIOND.
 	RevNotification(ctx context.Context, sRevInfo *path_mgmt.SignedRevInfo) (*RevReply, error)
+	// RevNotificationFromPacket sends a raw revocation to SCIOND, together
+	// with the raw SCMP packet it was contained in. SCIOND uses the packet to
+	// verify the authenticity of the revocation.
+	RevNotificationFromPacket(ctx context.Context, b, rawPkt []byte) (*RevReply, error)
+	// DRKeyGetLvl2Key requests from SCIOND the level 2 DRKey described by meta
+	// that is valid at valTime.
+	DRKeyGetLvl2Key(ctx context.Context, meta drkey.Lvl2Meta,
+		valTime time.Time) (drkey.Lvl2Key, error)
 	// Close shuts down the connection to a SCIOND server.
 	Close(ctx context.Context) error
 }
@@ -253,6 +267,30 @@ func (c *conn) SVCInfo(ctx context.Context,
 	return pld.ServiceInfoReply, nil
 }
 
+func (c *conn) SegTypeHop(ctx context.Context,
+	segType proto.PathSegType) (*SegTypeHopReply, error) {
+
+	conn, err := c.connect(ctx)
+	if err != nil {
+		metrics.SegTypeHops.Inc(errorToPrometheusLabel(err))
+		return nil, serrors.Wrap(ErrUnableToConnect, err)
+	}
+	pld, err := roundTrip(
+		&Pld{
+			TraceId:       tracing.IDFromCtx(ctx),
+			Which:         proto.SCIONDMsg_Which_segTypeHopReq,
+			SegTypeHopReq: &SegTypeHopReq{Type: segType},
+		},
+		conn,
+	)
+	if err != nil {
+		metrics.SegTypeHops.Inc(errorToPrometheusLabel(err))
+		return nil, serrors.WrapStr("[sciond-API] Failed to get SegTypeHop", err)
+	}
+	metrics.SegTypeHops.Inc(metrics.OkSuccess)
+	return pld.SegTypeHopReply, nil
+}
+
 func (c *conn) RevNotificationFromRaw(ctx context.Context, b []byte) (*RevReply, error) {
 	// Extract information from notification
 	sRevInfo, err := path_mgmt.NewSignedRevInfoFromRaw(b)
@@ -262,9 +300,25 @@ func (c *conn) RevNotificationFromRaw(ctx context.Context, b []byte) (*RevReply,
 	return c.RevNotification(ctx, sRevInfo)
 }
 
+func (c *conn) RevNotificationFromPacket(ctx context.Context, b,
+	rawPkt []byte) (*RevReply, error) {
+
+	sRevInfo, err := path_mgmt.NewSignedRevInfoFromRaw(b)
+	if err != nil {
+		return nil, err
+	}
+	return c.revNotification(ctx, &RevNotification{SRevInfo: sRevInfo, RawPkt: rawPkt})
+}
+
 func (c *conn) RevNotification(ctx context.Context,
 	sRevInfo *path_mgmt.SignedRevInfo) (*RevReply, error) {
 
+	return c.revNotification(ctx, &RevNotification{SRevInfo: sRevInfo})
+}
+
+func (c *conn) revNotification(ctx context.Context,
+	notification *RevNotification) (*RevReply, error) {
+
 	conn, err := c.connect(ctx)
 	if err != nil {
 		metrics.Revocations.Inc(errorToPrometheusLabel(err))
@@ -272,11 +326,9 @@ func (c *conn) RevNotification(ctx context.Context,
 	}
 	reply, err := roundTrip(
 		&Pld{
-			TraceId: tracing.IDFromCtx(ctx),
-			Which:   proto.SCIONDMsg_Which_revNotification,
-			RevNotification: &RevNotification{
-				SRevInfo: sRevInfo,
-			},
+			TraceId:         tracing.IDFromCtx(ctx),
+			Which:           proto.SCIONDMsg_Which_revNotification,
+			RevNotification: notification,
 		},
 		conn,
 	)
@@ -288,6 +340,35 @@ func (c *conn) RevNotification(ctx context.Context,
 	return reply.RevReply, nil
 }
 
+func (c *conn) DRKeyGetLvl2Key(ctx context.Context, meta drkey.Lvl2Meta,
+	valTime time.Time) (drkey.Lvl2Key, error) {
+
+	conn, err := c.connect(ctx)
+	if err != nil {
+		metrics.DRKeyLvl2s.Inc(errorToPrometheusLabel(err))
+		return drkey.Lvl2Key{}, serrors.Wrap(ErrUnableToConnect, err)
+	}
+	defer conn.Close()
+	reply, err := roundTrip(
+		&Pld{
+			TraceId:      tracing.IDFromCtx(ctx),
+			Which:        proto.SCIONDMsg_Which_drkeyLvl2Req,
+			DrkeyLvl2Req: drkey_mgmt.NewLvl2ReqFromMeta(meta, valTime),
+		},
+		conn,
+	)
+	if err != nil {
+		metrics.DRKeyLvl2s.Inc(errorToPrometheusLabel(err))
+		return drkey.Lvl2Key{}, serrors.WrapStr("[sciond-API] Failed to get DRKey", err)
+	}
+	if reply.DrkeyLvl2Reply == nil || len(reply.DrkeyLvl2Reply.Key) == 0 {
+		metrics.DRKeyLvl2s.Inc(metrics.ErrNotClassified)
+		return drkey.Lvl2Key{}, serrors.New("[sciond-API] DRKey not available")
+	}
+	metrics.DRKeyLvl2s.Inc(metrics.OkSuccess)
+	return reply.DrkeyLvl2Reply.ToKey(meta), nil
+}
+
 func (c *conn) Close(_ context.Context) error {
 	return nil
 }
@@ -22,6 +22,7 @@ import (
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/drkey_mgmt"
 	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
 	"github.com/scionproto/scion/go/lib/hostinfo"
 	"github.com/scionproto/scion/go/lib/util"
@@ -74,6 +75,10 @@ type Pld struct {
 	IfInfoReply        *IFInfoReply
 	ServiceInfoRequest *ServiceInfoRequest
 	ServiceInfoReply   *ServiceInfoReply
+	SegTypeHopReq      *SegTypeHopReq
+	SegTypeHopReply    *SegTypeHopReply
+	DrkeyLvl2Req       *drkey_mgmt.Lvl2Req
+	DrkeyLvl2Reply     *drkey_mgmt.Lvl2Rep
 }
 
 func NewPldFromRaw(b common.RawBytes) (*Pld, error) {
@@ -118,6 +123,14 @@ func (p *Pld) union() (interface{}, error) {
 		return p.ServiceInfoRequest, nil
 	case proto.SCIONDMsg_Which_serviceInfoReply:
 		return p.ServiceInfoReply, nil
+	case proto.SCIONDMsg_Which_segTypeHopReq:
+		return p.SegTypeHopReq, nil
+	case proto.SCIONDMsg_Which_segTypeHopReply:
+		return p.SegTypeHopReply, nil
+	case proto.SCIONDMsg_Which_drkeyLvl2Req:
+		return p.DrkeyLvl2Req, nil
+	case proto.SCIONDMsg_Which_drkeyLvl2Reply:
+		return p.DrkeyLvl2Reply, nil
 	}
 	return nil, common.NewBasicError("Unsupported SCIOND union type", nil, "type", p.Which)
 }
@@ -134,9 +147,10 @@ func (pathReq *PathReq) Copy() *PathReq {
 		return nil
 	}
 	return &PathReq{
-		Dst:   pathReq.Dst,
-		Src:   pathReq.Src,
-		Flags: pathReq.Flags,
+		Dst:    pathReq.Dst,
+		Src:    pathReq.Src,
+		HPCfgs: append([]*path_mgmt.HPGroupId(nil), pathReq.HPCfgs...),
+		Flags:  pathReq.Flags,
 	}
 }
 
@@ -188,6 +202,7 @@ type FwdPathMeta struct {
 	Mtu        uint16
 	Interfaces []PathInterface
 	ExpTime    uint32
+	Metadata   *PathMetadata
 }
 
 func (fpm *FwdPathMeta) SrcIA() addr.IA {
@@ -220,6 +235,7 @@ func (fpm *FwdPathMeta) Copy() *FwdPathMeta {
 		res.Interfaces = make([]PathInterface, len(fpm.Interfaces))
 		copy(res.Interfaces, fpm.Interfaces)
 	}
+	res.Metadata = fpm.Metadata.Copy()
 	return res
 }
 
@@ -245,6 +261,40 @@ func (fpm *FwdPathMeta) fmtIfaces() []string {
 	return hops
 }
 
+// PathMetadata contains the static metadata of a path. See snet.PathMetadata
+// for the semantics of the entries.
+type PathMetadata struct {
+	// Latency contains the latencies in microseconds. Unknown latencies are
+	// set to -1.
+	Latency []int32
+	// Bandwidth contains the bandwidths in Kbit/s.
+	Bandwidth    []uint64
+	Geo          []GeoCoordinates
+	LinkType     []proto.StaticInfoExtn_LinkType
+	InternalHops []uint32
+	Notes        []string
+}
+
+func (pm *PathMetadata) Copy() *PathMetadata {
+	if pm == nil {
+		return nil
+	}
+	return &PathMetadata{
+		Latency:      append(pm.Latency[:0:0], pm.Latency...),
+		Bandwidth:    append(pm.Bandwidth[:0:0], pm.Bandwidth...),
+		Geo:          append(pm.Geo[:0:0], pm.Geo...),
+		LinkType:     append(pm.LinkType[:0:0], pm.LinkType...),
+		InternalHops: append(pm.InternalHops[:0:0], pm.InternalHops...),
+		Notes:        append(pm.Notes[:0:0], pm.Notes...),
+	}
+}
+
+type GeoCoordinates struct {
+	Latitude  float32
+	Longitude float32
+	Address   string
+}
+
 type PathInterface struct {
 	RawIsdas addr.IAInt `capnp:"isdas"`
 	IfID     common.IFIDType
@@ -297,6 +347,9 @@ func (entry ASInfoReplyEntry) String() string {
 
 type RevNotification struct {
 	SRevInfo *path_mgmt.SignedRevInfo
+	// RawPkt is the raw SCMP packet the revocation was received in. It is
+	// used to verify the authenticity of the revocation and may be empty.
+	RawPkt common.RawBytes
 }
 
 func (rN *RevNotification) String() string {
@@ -365,3 +418,50 @@ type ServiceInfoReplyEntry struct {
 	Ttl         uint32
 	HostInfos   []hostinfo.Host
 }
+
+type SegTypeHopReq struct {
+	Type proto.PathSegType
+}
+
+func (r SegTypeHopReq) String() string {
+	return r.Type.String()
+}
+
+type SegTypeHopReply struct {
+	Entries []SegTypeHopReplyEntry
+}
+
+func (r *SegTypeHopReply) String() string {
+	strEntries := make([]string, len(r.Entries))
+	for i := range r.Entries {
+		strEntries[i] = r.Entries[i].String()
+	}
+	return strings.Join(strEntries, "\n")
+}
+
+// SegTypeHopReplyEntry describes a path segment known to SCIOND. The
+// interfaces are listed in construction direction of the segment.
+type SegTypeHopReplyEntry struct {
+	Interfaces []PathInterface
+	Timestamp  uint32
+	ExpTime    uint32
+}
+
+// Creation returns the creation time of the segment.
+func (e SegTypeHopReplyEntry) Creation() time.Time {
+	return util.SecsToTime(e.Timestamp)
+}
+
+// Expiry returns the expiration time of the segment.
+func (e SegTypeHopReplyEntry) Expiry() time.Time {
+	return util.SecsToTime(e.ExpTime)
+}
+
+func (e SegTypeHopReplyEntry) String() string {
+	ifaces := make([]string, len(e.Interfaces))
+	for i, iface := range e.Interfaces {
+		ifaces[i] = iface.String()
+	}
+	return fmt.Sprintf("Interfaces: [%s] Timestamp: %s Expiry: %s", strings.Join(ifaces, " "),
+		util.TimeToCompact(e.Creation()), util.TimeToCompact(e.Expiry()))
+}
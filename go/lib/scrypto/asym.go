@@ -29,6 +29,8 @@ import (
 // Available asymmetric crypto algorithms. The values must be lower case.
 const (
 	Ed25519                    = "ed25519"
+	ECDSAP256                  = "ecdsa-p256"
+	ECDSAP384                  = "ecdsa-p384"
 	Curve25519xSalsa20Poly1305 = "curve25519xsalsa20poly1305"
 )
 
@@ -67,6 +69,13 @@ func GenKeyPair(algo string) (common.RawBytes, common.RawBytes, error) {
 			return nil, nil, serrors.Wrap(ErrUnableToGenerateKeyPair, err, "algo", algo)
 		}
 		return common.RawBytes(pubkey), common.RawBytes(privkey), nil
+	case ECDSAP256, ECDSAP384:
+		params, _ := ecdsaParamsFromAlgo(strings.ToLower(algo))
+		pubkey, privkey, err := params.genKeyPair()
+		if err != nil {
+			return nil, nil, serrors.Wrap(ErrUnableToGenerateKeyPair, err, "algo", algo)
+		}
+		return pubkey, privkey, nil
 	default:
 		return nil, nil, serrors.WithCtx(ErrUnsupportedAlgo, "algo", algo)
 	}
@@ -89,12 +98,15 @@ func GetPubKey(privKey []byte, algo string) ([]byte, error) {
 		default:
 			return nil, serrors.WithCtx(ErrInvalidPrivKeySize, "len", len(privKey), "algo", Ed25519)
 		}
+	case ECDSAP256, ECDSAP384:
+		params, _ := ecdsaParamsFromAlgo(strings.ToLower(algo))
+		return params.pubKey(privKey)
 	}
 	return nil, serrors.WithCtx(ErrUnsupportedAlgo, "algo", algo)
 }
 
-// Sign takes a signature input and a signing key to create a signature. Currently only
-// ed25519 is supported.
+// Sign takes a signature input and a signing key to create a signature. Currently
+// ed25519, ecdsa-p256 and ecdsa-p384 are supported.
 func Sign(sigInput, signKey []byte, signAlgo string) ([]byte, error) {
 	switch strings.ToLower(signAlgo) {
 	case Ed25519:
@@ -107,13 +119,17 @@ func Sign(sigInput, signKey []byte, signAlgo string) ([]byte, error) {
 				"actual", len(signKey))
 		}
 		return ed25519.Sign(ed25519.PrivateKey(signKey), sigInput), nil
+	case ECDSAP256, ECDSAP384:
+		params, _ := ecdsaParamsFromAlgo(strings.ToLower(signAlgo))
+		return params.sign(sigInput, signKey)
 	default:
 		return nil, serrors.WithCtx(ErrUnsupportedSignAlgo, "algo", signAlgo)
 	}
 }
 
 // Verify takes a signature input and a verifying key and returns an error, if the
-// signature does not match. Currently only ed25519 is supported.
+// signature does not match. Currently ed25519, ecdsa-p256 and ecdsa-p384 are
+// supported.
 func Verify(sigInput, sig, verifyKey []byte, signAlgo string) error {
 	switch strings.ToLower(signAlgo) {
 	case Ed25519:
@@ -132,6 +148,9 @@ func Verify(sigInput, sig, verifyKey []byte, signAlgo string) error {
 			return ErrVerification
 		}
 		return nil
+	case ECDSAP256, ECDSAP384:
+		params, _ := ecdsaParamsFromAlgo(strings.ToLower(signAlgo))
+		return params.verify(sigInput, sig, verifyKey)
 	default:
 		return serrors.WithCtx(ErrUnsupportedSignAlgo, "algo", signAlgo)
 	}
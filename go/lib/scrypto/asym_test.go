@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/ed25519"
 
 	"github.com/scionproto/scion/go/lib/common"
@@ -40,6 +41,18 @@ var (
 		`6bd710a368c1249923fc7a1610747403040f0cc30815a00f9ff548a896bbda0b4eb2ca19ebcf917f0f34200a9e
 		dbad3901b64ab09cc5ef7b9bcc3c40c0ff7509`)
 
+	// ECDSA P-256 test vectors
+	// Taken from RFC 6979 A.2.5 (message "sample" with SHA-256).
+	ECDSAP256TestPrivateKey = xtest.MustParseHexString(
+		`c9afa9d845ba75166b5c215767b1d6934e50c3db36e89b127b8a622b120f6721`)
+	ECDSAP256TestPublicKey = xtest.MustParseHexString(
+		`0460fed4ba255a9d31c961eb74c6356d68c049b8923b61fa6ce669622e60f29fb6
+		7903fe1008b8bc99a41ae9e95628bc64f2f1b20c2d7e9f5177a3c294d4462299`)
+	ECDSAP256TestMsg       = []byte("sample")
+	ECDSAP256TestSignature = xtest.MustParseHexString(
+		`efd48b2aacb6a8fd1140dd9cd45e81d69d2c877b56aaf991c34d0ea84eaf3716
+		f7cb1c942d657c41d436c7a1b6e29f65f3e900dbb9aff4064dc4ab2f843acda8`)
+
 	// NaClBox test vectors
 	// Taken from the NaCl distribution:
 	// https://github.com/jedisct1/libsodium/blob/1.0.16/test/default/box.c
@@ -83,6 +96,22 @@ func TestGenKeyPairs(t *testing.T) {
 		assert.NotEqual(t, newPrivkey, rawPrivkey)
 	})
 
+	ecdsaTests := map[string]int{
+		ECDSAP256: 32,
+		ECDSAP384: 48,
+	}
+	for algo, size := range ecdsaTests {
+		t.Run("GenKeyPairs should return a valid "+algo+" key pair", func(t *testing.T) {
+			rawPubkey, rawPrivkey, err := GenKeyPair(algo)
+			assert.NoError(t, err)
+			assert.Len(t, rawPubkey, 1+2*size)
+			assert.Len(t, rawPrivkey, size)
+			pubkey, err := GetPubKey(rawPrivkey, algo)
+			assert.NoError(t, err)
+			assert.Equal(t, []byte(rawPubkey), pubkey)
+		})
+	}
+
 	t.Run("GenKeyPairs should throw error for unknown algo", func(t *testing.T) {
 		_, _, err := GenKeyPair("asdf")
 		assert.Error(t, err)
@@ -109,6 +138,22 @@ func TestSign(t *testing.T) {
 		_, err := Sign(Ed25519TestMsg, privKey, "asdf")
 		assert.Error(t, err)
 	})
+
+	for _, algo := range []string{ECDSAP256, ECDSAP384} {
+		t.Run("Sign should create verifiable "+algo+" signature", func(t *testing.T) {
+			pub, priv, err := GenKeyPair(algo)
+			require.NoError(t, err)
+			sig, err := Sign(Ed25519TestMsg, priv, algo)
+			require.NoError(t, err)
+			assert.Len(t, sig, len(priv)*2)
+			assert.NoError(t, Verify(Ed25519TestMsg, sig, pub, algo))
+		})
+	}
+
+	t.Run("Sign should throw error for invalid ECDSA key size", func(t *testing.T) {
+		_, err := Sign(ECDSAP256TestMsg, ECDSAP256TestPrivateKey[:31], ECDSAP256)
+		assert.Error(t, err)
+	})
 }
 
 func TestVerify(t *testing.T) {
@@ -138,6 +183,33 @@ func TestVerify(t *testing.T) {
 		err := Verify(Ed25519TestMsg, Ed25519TestSignature, Ed25519TestPublicKey, "asdf")
 		assert.Error(t, err)
 	})
+
+	t.Run("Verify should verify ECDSA signature correctly", func(t *testing.T) {
+		err := Verify(ECDSAP256TestMsg, ECDSAP256TestSignature, ECDSAP256TestPublicKey,
+			ECDSAP256)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Verify should throw an error for a mangled ECDSA signature", func(t *testing.T) {
+		mangled := append(common.RawBytes{}, ECDSAP256TestSignature...)
+		mangled[0] ^= 0xFF
+		err := Verify(ECDSAP256TestMsg, mangled, ECDSAP256TestPublicKey, ECDSAP256)
+		assert.Error(t, err)
+	})
+
+	t.Run("Verify should throw an error for an ECDSA key of wrong curve", func(t *testing.T) {
+		err := Verify(ECDSAP256TestMsg, ECDSAP256TestSignature, ECDSAP256TestPublicKey,
+			ECDSAP384)
+		assert.Error(t, err)
+	})
+}
+
+func TestGetPubKey(t *testing.T) {
+	t.Run("GetPubKey should compute ECDSA public key correctly", func(t *testing.T) {
+		pub, err := GetPubKey(ECDSAP256TestPrivateKey, ECDSAP256)
+		assert.NoError(t, err)
+		assert.Equal(t, ECDSAP256TestPublicKey, pub)
+	})
 }
 
 func TestEncrypt(t *testing.T) {
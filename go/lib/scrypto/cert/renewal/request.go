@@ -35,6 +35,10 @@ var (
 
 // KeyMeta is the meta information about a key.
 type KeyMeta struct {
+	// KeyVersion is the requested version of the key.
+	KeyVersion scrypto.KeyVersion `json:"key_version"`
+	// Algorithm indicates the algorithm associated with the key.
+	Algorithm string `json:"algorithm"`
 	// Key is the public key.
 	Key []byte `json:"key"`
 }
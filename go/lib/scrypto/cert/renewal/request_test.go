@@ -251,8 +251,16 @@ func newRequestInfo(now time.Time) renewal.RequestInfo {
 			NotAfter:  util.UnixTime{Time: now.Add(8760 * time.Hour)},
 		},
 		Keys: renewal.Keys{
-			Signing:    renewal.KeyMeta{Key: []byte("signKey1")},
-			Revocation: renewal.KeyMeta{Key: []byte("revKey1")},
+			Signing: renewal.KeyMeta{
+				KeyVersion: 2,
+				Algorithm:  scrypto.Ed25519,
+				Key:        []byte("signKey1"),
+			},
+			Revocation: renewal.KeyMeta{
+				KeyVersion: 2,
+				Algorithm:  scrypto.Ed25519,
+				Key:        []byte("revKey1"),
+			},
 		},
 		Issuer:      xtest.MustParseIA("1-ff00:0:110"),
 		RequestTime: util.UnixTime{Time: now},
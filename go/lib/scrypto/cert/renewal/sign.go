@@ -0,0 +1,187 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package renewal
+
+import (
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+var (
+	// ErrInvalidSignature indicates that a signature or proof of possession
+	// does not verify.
+	ErrInvalidSignature = serrors.New("invalid signature")
+	// ErrUnexpectedProtected indicates that the signature metadata does not
+	// match the key it refers to.
+	ErrUnexpectedProtected = serrors.New("unexpected protected meta")
+	// ErrMissingPOP indicates that a proof of possession is missing.
+	ErrMissingPOP = serrors.New("missing proof of possession")
+	// ErrDuplicatePOP indicates that there are multiple proofs of possession
+	// for the same key.
+	ErrDuplicatePOP = serrors.New("duplicate proof of possession")
+)
+
+// Signer creates signatures without exposing the private key material.
+type Signer interface {
+	Sign(input []byte) ([]byte, error)
+}
+
+// PrivateKey is a private key with the metadata that is necessary to create
+// signatures in the renewal process.
+type PrivateKey struct {
+	KeyVersion scrypto.KeyVersion
+	Algorithm  string
+	Key        []byte
+	// Signer, if set, creates the signatures instead of the key material in
+	// Key.
+	Signer Signer
+}
+
+// NewSignedRequest creates a signed renewal request for the request info. The
+// proofs of possession are created with the private keys that correspond to
+// the public keys in the request info. The request is signed with the signing
+// key that is authenticated by the currently active certificate.
+func NewSignedRequest(info *RequestInfo, signing, revocation,
+	current PrivateKey) (SignedRequest, error) {
+
+	encInfo, err := EncodeRequestInfo(info)
+	if err != nil {
+		return SignedRequest{}, serrors.WrapStr("unable to encode request info", err)
+	}
+	request := Request{Encoded: encInfo}
+	pops := []struct {
+		keyType KeyType
+		key     PrivateKey
+	}{
+		{keyType: SigningKey, key: signing},
+		{keyType: RevocationKey, key: revocation},
+	}
+	for _, p := range pops {
+		pop, err := sign(string(encInfo), p.keyType, p.key)
+		if err != nil {
+			return SignedRequest{}, serrors.WrapStr("unable to create proof of possession",
+				err, "key_type", p.keyType)
+		}
+		request.POPs = append(request.POPs, POP{
+			Protected: pop.EncodedProtected,
+			Signature: pop.Signature,
+		})
+	}
+	encRequest, err := EncodeRequest(&request)
+	if err != nil {
+		return SignedRequest{}, serrors.WrapStr("unable to encode request", err)
+	}
+	signed, err := sign(string(encRequest), SigningKey, current)
+	if err != nil {
+		return SignedRequest{}, serrors.WrapStr("unable to sign request", err)
+	}
+	signed.Encoded = encRequest
+	return signed, nil
+}
+
+func sign(payload string, keyType KeyType, key PrivateKey) (SignedRequest, error) {
+	protected, err := EncodeProtected(Protected{
+		Algorithm:  key.Algorithm,
+		KeyType:    keyType,
+		KeyVersion: key.KeyVersion,
+	})
+	if err != nil {
+		return SignedRequest{}, err
+	}
+	input := scrypto.JWSignatureInput(string(protected), payload)
+	sig, err := key.sign(input)
+	if err != nil {
+		return SignedRequest{}, err
+	}
+	return SignedRequest{EncodedProtected: protected, Signature: sig}, nil
+}
+
+func (k PrivateKey) sign(input []byte) ([]byte, error) {
+	if k.Signer != nil {
+		return k.Signer.Sign(input)
+	}
+	return scrypto.Sign(input, k.Key, k.Algorithm)
+}
+
+// Verify verifies the signed request and returns the decoded request info.
+// The outer signature is verified with the signing key of the currently active
+// certificate. The proofs of possession are verified with the keys contained
+// in the request info. Both the signing and the revocation key must be proven.
+func (s SignedRequest) Verify(current scrypto.KeyMeta) (RequestInfo, error) {
+	p, err := s.EncodedProtected.Decode()
+	if err != nil {
+		return RequestInfo{}, serrors.WrapStr("unable to decode protected", err)
+	}
+	if err := checkProtected(p, SigningKey, current.KeyVersion, current.Algorithm); err != nil {
+		return RequestInfo{}, err
+	}
+	err = scrypto.Verify(s.SigInput(), s.Signature, current.Key, current.Algorithm)
+	if err != nil {
+		return RequestInfo{}, serrors.Wrap(ErrInvalidSignature, err)
+	}
+	request, err := s.Encoded.Decode()
+	if err != nil {
+		return RequestInfo{}, serrors.WrapStr("unable to decode request", err)
+	}
+	info, err := request.Encoded.Decode()
+	if err != nil {
+		return RequestInfo{}, serrors.WrapStr("unable to decode request info", err)
+	}
+	keys := map[KeyType]KeyMeta{
+		SigningKey:    info.Keys.Signing,
+		RevocationKey: info.Keys.Revocation,
+	}
+	proven := make(map[KeyType]bool, len(keys))
+	for _, pop := range request.POPs {
+		p, err := pop.Protected.Decode()
+		if err != nil {
+			return RequestInfo{}, serrors.WrapStr("unable to decode protected of POP", err)
+		}
+		if proven[p.KeyType] {
+			return RequestInfo{}, serrors.WithCtx(ErrDuplicatePOP, "key_type", p.KeyType)
+		}
+		key := keys[p.KeyType]
+		if err := checkProtected(p, p.KeyType, key.KeyVersion, key.Algorithm); err != nil {
+			return RequestInfo{}, err
+		}
+		err = scrypto.Verify(pop.SigInput(request.Encoded), pop.Signature, key.Key,
+			key.Algorithm)
+		if err != nil {
+			return RequestInfo{}, serrors.Wrap(ErrInvalidSignature, err,
+				"key_type", p.KeyType)
+		}
+		proven[p.KeyType] = true
+	}
+	for keyType := range keys {
+		if !proven[keyType] {
+			return RequestInfo{}, serrors.WithCtx(ErrMissingPOP, "key_type", keyType)
+		}
+	}
+	return info, nil
+}
+
+func checkProtected(p Protected, keyType KeyType, version scrypto.KeyVersion,
+	algo string) error {
+
+	expected := Protected{
+		Algorithm:  algo,
+		KeyType:    keyType,
+		KeyVersion: version,
+	}
+	if p != expected {
+		return serrors.WithCtx(ErrUnexpectedProtected, "expected", expected, "actual", p)
+	}
+	return nil
+}
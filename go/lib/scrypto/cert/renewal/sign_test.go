@@ -0,0 +1,139 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package renewal_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/scrypto/cert/renewal"
+)
+
+func TestSignedRequestVerify(t *testing.T) {
+	current, currentMeta := newKey(t, 1)
+	signing, signingMeta := newKey(t, 2)
+	revocation, revocationMeta := newKey(t, 2)
+	_, otherMeta := newKey(t, 1)
+
+	tests := map[string]struct {
+		Modify      func(info *renewal.RequestInfo)
+		Signing     renewal.PrivateKey
+		Current     scrypto.KeyMeta
+		ExpectedErr error
+	}{
+		"valid": {
+			Modify:  func(*renewal.RequestInfo) {},
+			Signing: signing,
+			Current: currentMeta,
+		},
+		"valid signer": {
+			Modify: func(*renewal.RequestInfo) {},
+			Signing: renewal.PrivateKey{
+				KeyVersion: signing.KeyVersion,
+				Algorithm:  signing.Algorithm,
+				Signer: signerFunc(func(input []byte) ([]byte, error) {
+					return scrypto.Sign(input, signing.Key, signing.Algorithm)
+				}),
+			},
+			Current: currentMeta,
+		},
+		"wrong current key": {
+			Modify:      func(*renewal.RequestInfo) {},
+			Signing:     signing,
+			Current:     otherMeta,
+			ExpectedErr: renewal.ErrInvalidSignature,
+		},
+		"wrong current key version": {
+			Modify:  func(*renewal.RequestInfo) {},
+			Signing: signing,
+			Current: func() scrypto.KeyMeta {
+				m := currentMeta
+				m.KeyVersion = 3
+				return m
+			}(),
+			ExpectedErr: renewal.ErrUnexpectedProtected,
+		},
+		"signing key not proven": {
+			Modify:      func(*renewal.RequestInfo) {},
+			Signing:     revocation,
+			Current:     currentMeta,
+			ExpectedErr: renewal.ErrInvalidSignature,
+		},
+		"key version mismatch": {
+			Modify: func(info *renewal.RequestInfo) {
+				info.Keys.Signing.KeyVersion = 3
+			},
+			Signing:     signing,
+			Current:     currentMeta,
+			ExpectedErr: renewal.ErrUnexpectedProtected,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			info := newRequestInfo(time.Now())
+			info.Keys = renewal.Keys{
+				Signing:    toRenewalMeta(signingMeta),
+				Revocation: toRenewalMeta(revocationMeta),
+			}
+			test.Modify(&info)
+			signed, err := renewal.NewSignedRequest(&info, test.Signing, revocation, current)
+			require.NoError(t, err)
+			verified, err := signed.Verify(test.Current)
+			if test.ExpectedErr != nil {
+				assert.Truef(t, errors.Is(err, test.ExpectedErr),
+					"expected: %v, actual: %v", test.ExpectedErr, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, info, verified)
+		})
+	}
+}
+
+type signerFunc func(input []byte) ([]byte, error)
+
+func (f signerFunc) Sign(input []byte) ([]byte, error) {
+	return f(input)
+}
+
+func newKey(t *testing.T, version scrypto.KeyVersion) (renewal.PrivateKey, scrypto.KeyMeta) {
+	t.Helper()
+	pub, priv, err := scrypto.GenKeyPair(scrypto.Ed25519)
+	require.NoError(t, err)
+	key := renewal.PrivateKey{
+		KeyVersion: version,
+		Algorithm:  scrypto.Ed25519,
+		Key:        priv,
+	}
+	meta := scrypto.KeyMeta{
+		KeyVersion: version,
+		Algorithm:  scrypto.Ed25519,
+		Key:        pub,
+	}
+	return key, meta
+}
+
+func toRenewalMeta(m scrypto.KeyMeta) renewal.KeyMeta {
+	return renewal.KeyMeta{
+		KeyVersion: m.KeyVersion,
+		Algorithm:  m.Algorithm,
+		Key:        m.Key,
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scrypto
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"math/big"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// ecdsaParams holds the curve and hash function of an ECDSA algorithm.
+//
+// Private keys are encoded as the big-endian scalar padded to the curve size.
+// Public keys are encoded as uncompressed points. Signatures are encoded as
+// the concatenation of r and s, each padded to the curve size.
+type ecdsaParams struct {
+	curve elliptic.Curve
+	hash  crypto.Hash
+}
+
+func ecdsaParamsFromAlgo(algo string) (ecdsaParams, bool) {
+	switch algo {
+	case ECDSAP256:
+		return ecdsaParams{curve: elliptic.P256(), hash: crypto.SHA256}, true
+	case ECDSAP384:
+		return ecdsaParams{curve: elliptic.P384(), hash: crypto.SHA384}, true
+	default:
+		return ecdsaParams{}, false
+	}
+}
+
+// size returns the size of a scalar in bytes.
+func (p ecdsaParams) size() int {
+	return (p.curve.Params().BitSize + 7) / 8
+}
+
+func (p ecdsaParams) genKeyPair() ([]byte, []byte, error) {
+	priv, err := ecdsa.GenerateKey(p.curve, rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	return elliptic.Marshal(p.curve, priv.X, priv.Y), p.pad(priv.D), nil
+}
+
+func (p ecdsaParams) privateKey(raw []byte) (*ecdsa.PrivateKey, error) {
+	if len(raw) != p.size() {
+		return nil, serrors.WithCtx(ErrInvalidPrivKeySize, "expected", p.size(),
+			"actual", len(raw))
+	}
+	d := new(big.Int).SetBytes(raw)
+	if d.Sign() == 0 || d.Cmp(p.curve.Params().N) >= 0 {
+		return nil, serrors.New("invalid private key scalar")
+	}
+	priv := &ecdsa.PrivateKey{D: d}
+	priv.Curve = p.curve
+	priv.X, priv.Y = p.curve.ScalarBaseMult(raw)
+	return priv, nil
+}
+
+func (p ecdsaParams) pubKey(raw []byte) ([]byte, error) {
+	priv, err := p.privateKey(raw)
+	if err != nil {
+		return nil, err
+	}
+	return elliptic.Marshal(p.curve, priv.X, priv.Y), nil
+}
+
+func (p ecdsaParams) sign(input, raw []byte) ([]byte, error) {
+	priv, err := p.privateKey(raw)
+	if err != nil {
+		return nil, err
+	}
+	r, s, err := ecdsa.Sign(rand.Reader, priv, p.digest(input))
+	if err != nil {
+		return nil, err
+	}
+	return append(p.pad(r), p.pad(s)...), nil
+}
+
+func (p ecdsaParams) verify(input, sig, raw []byte) error {
+	if expected := 1 + 2*p.size(); len(raw) != expected {
+		return serrors.WithCtx(ErrInvalidPubKeySize, "expected", expected, "actual", len(raw))
+	}
+	if len(sig) != 2*p.size() {
+		return serrors.WithCtx(ErrInvalidSignatureSize, "expected", 2*p.size(),
+			"actual", len(sig))
+	}
+	x, y := elliptic.Unmarshal(p.curve, raw)
+	if x == nil {
+		return serrors.New("invalid public key point")
+	}
+	pub := &ecdsa.PublicKey{Curve: p.curve, X: x, Y: y}
+	r := new(big.Int).SetBytes(sig[:p.size()])
+	s := new(big.Int).SetBytes(sig[p.size():])
+	if !ecdsa.Verify(pub, p.digest(input), r, s) {
+		return ErrVerification
+	}
+	return nil
+}
+
+// pad returns the big-endian encoding of the scalar padded to the curve size.
+func (p ecdsaParams) pad(v *big.Int) []byte {
+	raw := v.Bytes()
+	return append(make([]byte, p.size()-len(raw), p.size()), raw...)
+}
+
+func (p ecdsaParams) digest(input []byte) []byte {
+	h := p.hash.New()
+	h.Write(input)
+	return h.Sum(nil)
+}
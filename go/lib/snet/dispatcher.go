@@ -16,6 +16,7 @@ package snet
 
 import (
 	"context"
+	"errors"
 	"net"
 
 	"github.com/scionproto/scion/go/lib/addr"
@@ -63,6 +64,16 @@ type RevocationHandler interface {
 	RevokeRaw(ctx context.Context, rawSRevInfo common.RawBytes)
 }
 
+// PacketRevocationHandler is an optional extension of RevocationHandler. If
+// the revocation handler implements it, the default SCMP handler also passes
+// the raw packet that carried the revocation, such that the authenticity of
+// the revocation can be verified by the handler.
+type PacketRevocationHandler interface {
+	// RevokeFromPacket handles a revocation received as raw bytes in the raw
+	// SCMP packet.
+	RevokeFromPacket(ctx context.Context, rawSRevInfo, rawPkt common.RawBytes)
+}
+
 // SCMPHandler customizes the way snet connections deal with SCMP.
 type SCMPHandler interface {
 	// Handle processes the packet as an SCMP packet. If packet is not SCMP, it
@@ -90,12 +101,29 @@ func NewSCMPHandler(rh RevocationHandler) SCMPHandler {
 	}
 }
 
+// NewAuthSCMPHandler creates an SCMP handler that behaves like the default
+// SCMP handler, but first verifies the authenticity of SCMP errors with the
+// verifier. SCMP errors that fail verification are dropped. SCMP errors that
+// do not carry any authentication are only dropped in strict mode.
+func NewAuthSCMPHandler(rh RevocationHandler, v SCMPVerifier, strict bool) SCMPHandler {
+	return &scmpHandler{
+		revocationHandler: rh,
+		verifier:          v,
+		strict:            strict,
+	}
+}
+
 // scmpHandler handles SCMP messages received from the network. If a revocation handler is
 // configured, it is informed of any received revocations. All revocations are passed back to the
 // caller embedded in the error, so applications can handle them manually.
 type scmpHandler struct {
 	// revocationHandler manages revocations received via SCMP. If nil, the handler is not called.
 	revocationHandler RevocationHandler
+	// verifier checks the authenticity of SCMP errors. If nil, SCMP errors are
+	// not verified.
+	verifier SCMPVerifier
+	// strict indicates that SCMP errors without authentication are dropped.
+	strict bool
 }
 
 func (h *scmpHandler) Handle(pkt *SCIONPacket) error {
@@ -103,12 +131,17 @@ func (h *scmpHandler) Handle(pkt *SCIONPacket) error {
 	if !ok {
 		return common.NewBasicError("scmp handler invoked with non-scmp packet", nil, "pkt", pkt)
 	}
-	if hdr.Class != scmp.C_General {
+	// SCMP::General::Unspecified is used for errors
+	isError := hdr.Class != scmp.C_General || hdr.Type == scmp.T_G_Unspecified
+	if isError {
 		metrics.M.SCMPErrors().Inc()
 	}
-	if hdr.Class == scmp.C_General && hdr.Type == scmp.T_G_Unspecified {
-		// SCMP::General::Unspecified is used for errors
-		metrics.M.SCMPErrors().Inc()
+	if isError && h.verifier != nil {
+		if err := h.verify(pkt); err != nil {
+			log.Debug("Dropping unauthenticated SCMP error", "hdr", hdr, "src", pkt.Source,
+				"err", err)
+			return nil
+		}
 	}
 
 	// Only handle revocations for now
@@ -132,7 +165,9 @@ func (h *scmpHandler) handleSCMPRev(hdr *scmp.Hdr, pkt *SCIONPacket) error {
 	}
 	log.Info("Received SCMP revocation", "header", hdr.String(), "payload", scmpPayload.String(),
 		"src", pkt.Source)
-	if h.revocationHandler != nil {
+	if prh, ok := h.revocationHandler.(PacketRevocationHandler); ok {
+		prh.RevokeFromPacket(context.TODO(), info.RawSRev, common.RawBytes(pkt.Bytes))
+	} else if h.revocationHandler != nil {
 		h.revocationHandler.RevokeRaw(context.TODO(), info.RawSRev)
 	}
 	sRevInfo, err := path_mgmt.NewSignedRevInfoFromRaw(info.RawSRev)
@@ -145,3 +180,15 @@ func (h *scmpHandler) handleSCMPRev(hdr *scmp.Hdr, pkt *SCIONPacket) error {
 	}
 	return &OpError{scmp: hdr, revInfo: revInfo}
 }
+
+// verify checks the authenticity of the SCMP error. Unauthenticated packets
+// are only rejected in strict mode.
+func (h *scmpHandler) verify(pkt *SCIONPacket) error {
+	ctx, cancelF := context.WithTimeout(context.Background(), scmpVerifyTimeout)
+	defer cancelF()
+	err := h.verifier.Verify(ctx, pkt)
+	if errors.Is(err, ErrSCMPUnauthenticated) && !h.strict {
+		return nil
+	}
+	return err
+}
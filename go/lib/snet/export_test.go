@@ -28,3 +28,9 @@ func NewScionConnBase(localIA addr.IA, listen *net.UDPAddr) *scionConnBase {
 		scionNet: &SCIONNetwork{localIA: localIA},
 	}
 }
+
+// NetworkSCMPHandler returns the SCMP handler of a network created with
+// NewNetworkWithPR.
+func NetworkSCMPHandler(n *SCIONNetwork) SCMPHandler {
+	return n.dispatcher.(*DefaultPacketDispatcherService).SCMPHandler
+}
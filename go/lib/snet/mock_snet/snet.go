@@ -503,6 +503,20 @@ func (mr *MockPathMockRecorder) MTU() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MTU", reflect.TypeOf((*MockPath)(nil).MTU))
 }
 
+// Metadata mocks base method
+func (m *MockPath) Metadata() *snet.PathMetadata {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Metadata")
+	ret0, _ := ret[0].(*snet.PathMetadata)
+	return ret0
+}
+
+// Metadata indicates an expected call of Metadata
+func (mr *MockPathMockRecorder) Metadata() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Metadata", reflect.TypeOf((*MockPath)(nil).Metadata))
+}
+
 // OverlayNextHop mocks base method
 func (m *MockPath) OverlayNextHop() *net.UDPAddr {
 	m.ctrl.T.Helper()
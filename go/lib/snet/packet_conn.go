@@ -243,7 +243,7 @@ func (c *SCIONPacketConn) readFrom(pkt *SCIONPacket, ov *net.UDPAddr) error {
 	pkt.Destination = SCIONAddress{IA: scnPkt.DstIA, Host: scnPkt.DstHost}
 	pkt.Source = SCIONAddress{IA: scnPkt.SrcIA, Host: scnPkt.SrcHost}
 	pkt.Path = scnPkt.Path
-	pkt.Extensions = append(pkt.Extensions, scnPkt.HBHExt...)
+	pkt.Extensions = append(pkt.Extensions[:0], scnPkt.HBHExt...)
 	pkt.Extensions = append(pkt.Extensions, scnPkt.E2EExt...)
 	pkt.L4Header = scnPkt.L4
 	pkt.Payload = scnPkt.Pld
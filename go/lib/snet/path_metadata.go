@@ -0,0 +1,140 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet
+
+import (
+	"fmt"
+	"time"
+)
+
+// LatencyUnset indicates that the latency of a hop is unknown.
+const LatencyUnset time.Duration = -1
+
+// PathMetadata contains the static metadata of a path, as announced by the ASes
+// on the path in their static info extensions. The entries are relative to the
+// list of interfaces of the path. For a path with N interfaces, Latency and
+// Bandwidth have N-1 entries, Geo has N entries, LinkType has N/2 entries,
+// InternalHops has N/2-1 entries and Notes has N/2+1 entries.
+type PathMetadata struct {
+	// Latency lists the latencies between consecutive interfaces, i.e., entry
+	// i is the latency between interface i and i+1. Unknown latencies are set
+	// to LatencyUnset.
+	Latency []time.Duration
+	// Bandwidth lists the bandwidths in Kbit/s between consecutive interfaces.
+	// Unknown bandwidths are 0.
+	Bandwidth []uint64
+	// Geo lists the locations of the interfaces. Unknown locations are zero
+	// values.
+	Geo []GeoCoordinates
+	// LinkType lists the types of the inter-AS links, i.e., entry i is the
+	// type of the link between interface 2*i and 2*i+1.
+	LinkType []LinkType
+	// InternalHops lists the number of AS internal hops of the transit ASes,
+	// i.e., entry i is the number of hops between interface 2*i+1 and 2*i+2.
+	// Unknown values are 0.
+	InternalHops []uint32
+	// Notes lists the notes of the ASes on the path.
+	Notes []string
+}
+
+// Copy creates a deep copy of the metadata.
+func (pm *PathMetadata) Copy() *PathMetadata {
+	if pm == nil {
+		return nil
+	}
+	return &PathMetadata{
+		Latency:      append(pm.Latency[:0:0], pm.Latency...),
+		Bandwidth:    append(pm.Bandwidth[:0:0], pm.Bandwidth...),
+		Geo:          append(pm.Geo[:0:0], pm.Geo...),
+		LinkType:     append(pm.LinkType[:0:0], pm.LinkType...),
+		InternalHops: append(pm.InternalHops[:0:0], pm.InternalHops...),
+		Notes:        append(pm.Notes[:0:0], pm.Notes...),
+	}
+}
+
+// TotalLatency returns the sum of the latencies along the path. If the latency
+// of any hop is unknown, the total latency is unknown and false is returned.
+func (pm *PathMetadata) TotalLatency() (time.Duration, bool) {
+	if pm == nil || len(pm.Latency) == 0 {
+		return 0, false
+	}
+	var total time.Duration
+	for _, l := range pm.Latency {
+		if l == LatencyUnset {
+			return 0, false
+		}
+		total += l
+	}
+	return total, true
+}
+
+// MinBandwidth returns the bottleneck bandwidth of the path in Kbit/s. If the
+// bandwidth of any hop is unknown, the bottleneck bandwidth is unknown and
+// false is returned.
+func (pm *PathMetadata) MinBandwidth() (uint64, bool) {
+	if pm == nil || len(pm.Bandwidth) == 0 {
+		return 0, false
+	}
+	min := pm.Bandwidth[0]
+	for _, bw := range pm.Bandwidth {
+		if bw == 0 {
+			return 0, false
+		}
+		if bw < min {
+			min = bw
+		}
+	}
+	return min, true
+}
+
+// GeoCoordinates is the location of an interface.
+type GeoCoordinates struct {
+	Latitude  float32
+	Longitude float32
+	Address   string
+}
+
+func (g GeoCoordinates) String() string {
+	return fmt.Sprintf("(%f, %f) %q", g.Latitude, g.Longitude, g.Address)
+}
+
+// LinkType is the type of an inter-AS link.
+type LinkType uint8
+
+const (
+	// LinkTypeUnset is used for unknown link types.
+	LinkTypeUnset LinkType = iota
+	// LinkTypeDirect is a direct physical connection.
+	LinkTypeDirect
+	// LinkTypeMultihop is a connection with local routing/switching.
+	LinkTypeMultihop
+	// LinkTypeOpennet is a connection overlayed over the public Internet.
+	LinkTypeOpennet
+)
+
+func (lt LinkType) String() string {
+	switch lt {
+	case LinkTypeUnset:
+		return "unset"
+	case LinkTypeDirect:
+		return "direct"
+	case LinkTypeMultihop:
+		return "multihop"
+	case LinkTypeOpennet:
+		return "opennet"
+	default:
+		return fmt.Sprintf("UNKNOWN (%d)", uint8(lt))
+	}
+}
@@ -0,0 +1,498 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pathconn provides a path-aware SCION connection.
+//
+// A plain snet.Conn sends on whatever path is contained in the destination
+// address. A pathconn.Conn instead owns the set of paths to a single remote
+// host. Paths are obtained from a snet.PathQuerier and are filtered and ordered
+// according to a path policy. The connection refreshes the paths before the
+// path in use expires, drops paths that are revoked via SCMP, and switches to
+// the next best path without any involvement of the application.
+//
+// Revocations are learned from the errors returned by Read, and, if the
+// connection is registered with an SCMPHandler, directly from the SCMP handler
+// of the network. The latter also works for applications that only write.
+//
+// Example:
+//
+//	scmpH := pathconn.NewSCMPHandler(snet.NewSCMPHandler(revHandler))
+//	network := snet.NewCustomNetworkWithPR(localIA,
+//	    &snet.DefaultPacketDispatcherService{
+//	        Dispatcher:  dispatcher,
+//	        SCMPHandler: scmpH,
+//	    },
+//	)
+//	conn, err := pathconn.Dial(ctx, network, listen, remote, pathconn.Config{
+//	    Querier:      querier,
+//	    Policy:       policy,
+//	    SCMPHandler:  scmpH,
+//	    OnPathChange: func(old, new snet.Path) { ... },
+//	})
+package pathconn
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/pathpol"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+const (
+	// DefaultRefreshInterval is the default maximum time between two path
+	// refreshes.
+	DefaultRefreshInterval = 5 * time.Minute
+	// DefaultRefreshLead is the default time before the expiration of the
+	// current path at which the paths are refreshed.
+	DefaultRefreshLead = time.Minute
+	// DefaultQueryTimeout is the default timeout for path queries.
+	DefaultQueryTimeout = 5 * time.Second
+
+	// minRefreshWait is the minimum time between two refresh attempts. It
+	// prevents busy looping if the querier keeps returning expiring paths.
+	minRefreshWait = time.Second
+)
+
+var (
+	// ErrNoPath indicates that no usable path to the remote is available.
+	ErrNoPath = serrors.New("no path available")
+	// ErrClosed indicates that the connection is closed.
+	ErrClosed = serrors.New("connection closed")
+)
+
+var _ snet.Conn = (*Conn)(nil)
+
+// PathChangeHandler is called when a connection switches from the old to the
+// new path. On the first path selection, old is nil. If no usable path is
+// left, new is nil.
+type PathChangeHandler func(old, new snet.Path)
+
+// Config configures a path-aware connection.
+type Config struct {
+	// Querier is used to obtain the paths to the remote AS. It must be set.
+	Querier snet.PathQuerier
+	// Policy filters and orders the paths. If nil, all paths are used and are
+	// ordered by fingerprint.
+	Policy *pathpol.Policy
+	// SCMPHandler, if set, informs the connection about SCMP revocations
+	// received by any connection of the network it is installed on.
+	SCMPHandler *SCMPHandler
+	// OnPathChange, if set, is called whenever the connection switches path.
+	// The handler is called from the goroutine that triggered the switch, and
+	// might be called concurrently.
+	OnPathChange PathChangeHandler
+	// RefreshInterval is the maximum time between two path refreshes. If
+	// zero, DefaultRefreshInterval is used.
+	RefreshInterval time.Duration
+	// RefreshLead is the time before the expiration of the current path at
+	// which the paths are refreshed. If zero, DefaultRefreshLead is used.
+	RefreshLead time.Duration
+	// QueryTimeout is the timeout for a single path query. If zero,
+	// DefaultQueryTimeout is used.
+	QueryTimeout time.Duration
+}
+
+func (cfg *Config) initDefaults() {
+	if cfg.RefreshInterval == 0 {
+		cfg.RefreshInterval = DefaultRefreshInterval
+	}
+	if cfg.RefreshLead == 0 {
+		cfg.RefreshLead = DefaultRefreshLead
+	}
+	if cfg.QueryTimeout == 0 {
+		cfg.QueryTimeout = DefaultQueryTimeout
+	}
+}
+
+// Conn is a SCION connection to a single remote host that transparently
+// switches between the available paths. Write sends on the currently selected
+// path, all other methods behave like the ones of the underlying connection.
+type Conn struct {
+	snet.Conn
+
+	cfg    Config
+	remote *snet.UDPAddr
+
+	mtx sync.Mutex
+	// paths contains the usable paths in the order of preference.
+	paths []snet.Path
+	// current is the path in use, nil if no path is usable.
+	current snet.Path
+	// revoked contains the revoked interfaces and the expiration of their
+	// revocation.
+	revoked map[revokedIntf]time.Time
+	closed  bool
+
+	refreshC chan struct{}
+	closeC   chan struct{}
+	doneC    chan struct{}
+}
+
+// Dial opens a connection on network that is bound to listen, and returns a
+// path-aware connection to remote on top of it. See New for details.
+func Dial(ctx context.Context, network snet.Network, listen *net.UDPAddr,
+	remote *snet.UDPAddr, cfg Config) (*Conn, error) {
+
+	conn, err := network.Listen(ctx, "udp", listen, addr.SvcNone)
+	if err != nil {
+		return nil, err
+	}
+	c, err := New(ctx, conn, remote, cfg)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// New returns a path-aware connection to remote that sends over conn. The path
+// contained in remote is ignored. The initial path query is done with ctx and
+// must return at least one usable path. The returned connection takes
+// ownership of conn, it is closed when the returned connection is closed.
+func New(ctx context.Context, conn snet.Conn, remote *snet.UDPAddr,
+	cfg Config) (*Conn, error) {
+
+	if cfg.Querier == nil {
+		return nil, serrors.New("querier must be set")
+	}
+	if remote == nil {
+		return nil, serrors.New("remote must be set")
+	}
+	cfg.initDefaults()
+	c := &Conn{
+		Conn:     conn,
+		cfg:      cfg,
+		remote:   remote.Copy(),
+		revoked:  make(map[revokedIntf]time.Time),
+		refreshC: make(chan struct{}, 1),
+		closeC:   make(chan struct{}),
+		doneC:    make(chan struct{}),
+	}
+	c.remote.Path, c.remote.NextHop = nil, nil
+	if err := c.refresh(ctx); err != nil {
+		return nil, err
+	}
+	if c.Path() == nil {
+		return nil, serrors.WithCtx(ErrNoPath, "remote", c.remote.IA)
+	}
+	if cfg.SCMPHandler != nil {
+		cfg.SCMPHandler.register(c)
+	}
+	go func() {
+		defer log.LogPanicAndExit()
+		c.run()
+	}()
+	return c, nil
+}
+
+// Path returns the path currently in use, or nil if no path is usable.
+func (c *Conn) Path() snet.Path {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.current
+}
+
+// Paths returns the usable paths in the order of preference.
+func (c *Conn) Paths() []snet.Path {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return append([]snet.Path(nil), c.paths...)
+}
+
+// Read reads data into b. Revocations contained in the returned error are
+// applied to the paths of the connection.
+func (c *Conn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	c.handleReadErr(err)
+	return n, err
+}
+
+// ReadFrom reads data into b. Revocations contained in the returned error are
+// applied to the paths of the connection.
+func (c *Conn) ReadFrom(b []byte) (int, net.Addr, error) {
+	n, a, err := c.Conn.ReadFrom(b)
+	c.handleReadErr(err)
+	return n, a, err
+}
+
+// Write sends b to the remote on the current path. If the current path has
+// expired, the next usable path is used. If no path is usable, the paths are
+// refreshed before sending.
+func (c *Conn) Write(b []byte) (int, error) {
+	path, err := c.writePath()
+	if err != nil {
+		return 0, err
+	}
+	remote := c.remote.Copy()
+	remote.Path, remote.NextHop = path.Path(), path.OverlayNextHop()
+	return c.Conn.WriteTo(b, remote)
+}
+
+// RemoteAddr returns the address of the remote, including the current path.
+func (c *Conn) RemoteAddr() net.Addr {
+	remote := c.remote.Copy()
+	if path := c.Path(); path != nil {
+		remote.Path, remote.NextHop = path.Path(), path.OverlayNextHop()
+	}
+	return remote
+}
+
+// Close stops the path refreshing and closes the underlying connection.
+func (c *Conn) Close() error {
+	c.mtx.Lock()
+	if c.closed {
+		c.mtx.Unlock()
+		return ErrClosed
+	}
+	c.closed = true
+	c.mtx.Unlock()
+	if c.cfg.SCMPHandler != nil {
+		c.cfg.SCMPHandler.unregister(c)
+	}
+	close(c.closeC)
+	<-c.doneC
+	return c.Conn.Close()
+}
+
+// Revoke removes all paths that traverse the revoked interface. If the current
+// path is affected, the connection switches to the next usable path. If no
+// path is left, a refresh is triggered.
+func (c *Conn) Revoke(revInfo *path_mgmt.RevInfo) {
+	if revInfo == nil || revInfo.Active() != nil {
+		return
+	}
+	intf := revokedIntf{ia: revInfo.IA(), ifID: revInfo.IfID}
+	c.mtx.Lock()
+	if exp, ok := c.revoked[intf]; ok && !exp.Before(revInfo.Expiration()) {
+		c.mtx.Unlock()
+		return
+	}
+	c.revoked[intf] = revInfo.Expiration()
+	old := c.current
+	c.paths = c.filterRevoked(c.paths, time.Now())
+	c.selectPath()
+	newPath := c.current
+	c.mtx.Unlock()
+	log.Debug("Applied revocation to path-aware connection", "revInfo", revInfo,
+		"remote", c.remote)
+	c.notify(old, newPath)
+	if newPath == nil {
+		c.triggerRefresh()
+	}
+}
+
+func (c *Conn) handleReadErr(err error) {
+	var opErr *snet.OpError
+	if err != nil && errors.As(err, &opErr) && opErr.RevInfo() != nil {
+		c.Revoke(opErr.RevInfo())
+	}
+}
+
+// writePath returns the path to use for sending.
+func (c *Conn) writePath() (snet.Path, error) {
+	c.mtx.Lock()
+	if c.closed {
+		c.mtx.Unlock()
+		return nil, ErrClosed
+	}
+	old := c.current
+	if old != nil && !expired(old, time.Now()) {
+		c.mtx.Unlock()
+		return old, nil
+	}
+	c.paths = filterExpired(c.paths, time.Now())
+	c.selectPath()
+	current := c.current
+	c.mtx.Unlock()
+	c.notify(old, current)
+	if current != nil {
+		return current, nil
+	}
+	ctx, cancelF := context.WithTimeout(context.Background(), c.cfg.QueryTimeout)
+	defer cancelF()
+	if err := c.refresh(ctx); err != nil {
+		return nil, err
+	}
+	if current = c.Path(); current == nil {
+		return nil, serrors.WithCtx(ErrNoPath, "remote", c.remote.IA)
+	}
+	return current, nil
+}
+
+// run refreshes the paths until the connection is closed.
+func (c *Conn) run() {
+	defer close(c.doneC)
+	for {
+		timer := time.NewTimer(c.nextRefresh())
+		select {
+		case <-c.closeC:
+			timer.Stop()
+			return
+		case <-c.refreshC:
+			timer.Stop()
+		case <-timer.C:
+		}
+		ctx, cancelF := context.WithTimeout(context.Background(), c.cfg.QueryTimeout)
+		if err := c.refresh(ctx); err != nil {
+			log.Info("Failed to refresh paths", "remote", c.remote, "err", err)
+		}
+		cancelF()
+	}
+}
+
+// nextRefresh returns the time to wait until the next refresh.
+func (c *Conn) nextRefresh() time.Duration {
+	wait := c.cfg.RefreshInterval
+	if path := c.Path(); path != nil && !path.Expiry().IsZero() {
+		if untilExp := time.Until(path.Expiry()) - c.cfg.RefreshLead; untilExp < wait {
+			wait = untilExp
+		}
+	}
+	if wait < minRefreshWait {
+		wait = minRefreshWait
+	}
+	return wait
+}
+
+func (c *Conn) triggerRefresh() {
+	select {
+	case c.refreshC <- struct{}{}:
+	default:
+	}
+}
+
+// refresh queries the paths to the remote and selects the path to use.
+func (c *Conn) refresh(ctx context.Context) error {
+	paths, err := c.cfg.Querier.Query(ctx, c.remote.IA)
+	if err != nil {
+		return common.NewBasicError("unable to query paths", err, "remote", c.remote.IA)
+	}
+	paths = c.applyPolicy(paths)
+	c.mtx.Lock()
+	now := time.Now()
+	c.paths = c.filterRevoked(filterExpired(paths, now), now)
+	old := c.current
+	c.selectPath()
+	current := c.current
+	c.mtx.Unlock()
+	c.notify(old, current)
+	return nil
+}
+
+// applyPolicy filters the paths with the path policy and returns them in the
+// order preferred by the policy.
+func (c *Conn) applyPolicy(paths []snet.Path) []snet.Path {
+	ps := make(pathpol.PathSet, len(paths))
+	for _, path := range paths {
+		ps[path.Fingerprint()] = path
+	}
+	var filtered []snet.Path
+	for _, path := range c.cfg.Policy.Sort(c.cfg.Policy.Filter(ps)) {
+		filtered = append(filtered, path.(snet.Path))
+	}
+	return filtered
+}
+
+// selectPath sets the current path. The current path is kept as long as it is
+// usable, so that the connection does not flap between equally good paths.
+// The caller must hold the lock.
+func (c *Conn) selectPath() {
+	if c.current != nil {
+		fp := c.current.Fingerprint()
+		for _, path := range c.paths {
+			if path.Fingerprint() == fp {
+				c.current = path
+				return
+			}
+		}
+	}
+	c.current = nil
+	if len(c.paths) > 0 {
+		c.current = c.paths[0]
+	}
+}
+
+// filterRevoked removes the paths that traverse a revoked interface. Expired
+// revocations are dropped. The caller must hold the lock.
+func (c *Conn) filterRevoked(paths []snet.Path, now time.Time) []snet.Path {
+	for intf, exp := range c.revoked {
+		if exp.Before(now) {
+			delete(c.revoked, intf)
+		}
+	}
+	if len(c.revoked) == 0 {
+		return paths
+	}
+	filtered := make([]snet.Path, 0, len(paths))
+	for _, path := range paths {
+		if !c.isRevoked(path) {
+			filtered = append(filtered, path)
+		}
+	}
+	return filtered
+}
+
+func (c *Conn) isRevoked(path snet.Path) bool {
+	for _, intf := range path.Interfaces() {
+		if _, ok := c.revoked[revokedIntf{ia: intf.IA(), ifID: intf.ID()}]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Conn) notify(old, new snet.Path) {
+	if c.cfg.OnPathChange == nil || samePath(old, new) {
+		return
+	}
+	c.cfg.OnPathChange(old, new)
+}
+
+type revokedIntf struct {
+	ia   addr.IA
+	ifID common.IFIDType
+}
+
+func filterExpired(paths []snet.Path, now time.Time) []snet.Path {
+	filtered := make([]snet.Path, 0, len(paths))
+	for _, path := range paths {
+		if !expired(path, now) {
+			filtered = append(filtered, path)
+		}
+	}
+	return filtered
+}
+
+// expired returns whether the path is expired. Paths with unknown expiration
+// never expire.
+func expired(path snet.Path, now time.Time) bool {
+	exp := path.Expiry()
+	return !exp.IsZero() && !now.Before(exp)
+}
+
+func samePath(a, b snet.Path) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Fingerprint() == b.Fingerprint()
+}
@@ -0,0 +1,292 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathconn_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/scmp"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/snet/mock_snet"
+	"github.com/scionproto/scion/go/lib/snet/pathconn"
+	"github.com/scionproto/scion/go/lib/spath"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/proto"
+)
+
+var (
+	ia110 = xtest.MustParseIA("1-ff00:0:110")
+	ia111 = xtest.MustParseIA("1-ff00:0:111")
+	ia112 = xtest.MustParseIA("1-ff00:0:112")
+
+	remote = &snet.UDPAddr{
+		IA:   ia112,
+		Host: &net.UDPAddr{IP: net.IP{192, 0, 2, 2}, Port: 4000},
+	}
+)
+
+func TestNew(t *testing.T) {
+	tests := map[string]struct {
+		Paths        []snet.Path
+		QueryErr     error
+		ErrAssertion assert.ErrorAssertionFunc
+	}{
+		"valid": {
+			Paths:        []snet.Path{newPath("a", 0, ia110, 1, ia112, 2)},
+			ErrAssertion: assert.NoError,
+		},
+		"query error": {
+			QueryErr:     errors.New("test"),
+			ErrAssertion: assert.Error,
+		},
+		"no paths": {
+			ErrAssertion: assert.Error,
+		},
+		"only expired paths": {
+			Paths:        []snet.Path{newPath("a", -time.Minute, ia110, 1, ia112, 2)},
+			ErrAssertion: assert.Error,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			querier := mock_snet.NewMockPathQuerier(ctrl)
+			querier.EXPECT().Query(gomock.Any(), ia112).Return(test.Paths, test.QueryErr)
+			conn := mock_snet.NewMockConn(ctrl)
+			conn.EXPECT().Close().AnyTimes()
+			c, err := pathconn.New(context.Background(), conn, remote,
+				pathconn.Config{Querier: querier})
+			test.ErrAssertion(t, err)
+			if err == nil {
+				assert.NoError(t, c.Close())
+			}
+		})
+	}
+}
+
+func TestConnWrite(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	pathA := newPath("a", 300*time.Millisecond, ia110, 1, ia112, 2)
+	pathB := newPath("b", time.Hour, ia110, 3, ia112, 4)
+	querier := mock_snet.NewMockPathQuerier(ctrl)
+	querier.EXPECT().Query(gomock.Any(), ia112).Return([]snet.Path{pathB, pathA}, nil)
+	conn := mock_snet.NewMockConn(ctrl)
+	changes := &pathChanges{}
+	c, err := pathconn.New(context.Background(), conn, remote,
+		pathconn.Config{Querier: querier, OnPathChange: changes.handle})
+	require.NoError(t, err)
+	defer c.Close()
+	conn.EXPECT().Close()
+
+	// Without policy, the paths are ordered by fingerprint.
+	assert.Equal(t, []snet.Path{pathA, pathB}, c.Paths())
+	conn.EXPECT().WriteTo([]byte("hello"), expectedAddr(pathA)).Return(5, nil)
+	n, err := c.Write([]byte("hello"))
+	assert.NoError(t, err)
+	assert.Equal(t, 5, n)
+	assert.Equal(t, expectedAddr(pathA), c.RemoteAddr())
+
+	// Once the current path has expired, the next path is used.
+	time.Sleep(time.Until(pathA.Expiry()))
+	conn.EXPECT().WriteTo([]byte("world"), expectedAddr(pathB)).Return(5, nil)
+	_, err = c.Write([]byte("world"))
+	assert.NoError(t, err)
+	assert.Equal(t, [][2]string{{"", "a"}, {"a", "b"}}, changes.get())
+}
+
+func TestConnRevoke(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	pathA := newPath("a", time.Hour, ia110, 1, ia111, 2, ia111, 5, ia112, 6)
+	pathB := newPath("b", time.Hour, ia110, 3, ia112, 4)
+	querier := mock_snet.NewMockPathQuerier(ctrl)
+	querier.EXPECT().Query(gomock.Any(), ia112).Return([]snet.Path{pathA, pathB}, nil).MinTimes(2)
+	conn := mock_snet.NewMockConn(ctrl)
+	changes := &pathChanges{}
+	c, err := pathconn.New(context.Background(), conn, remote,
+		pathconn.Config{Querier: querier, OnPathChange: changes.handle})
+	require.NoError(t, err)
+	defer c.Close()
+	conn.EXPECT().Close()
+
+	// Revocations of interfaces that are not on the current path have no
+	// effect on the current path.
+	c.Revoke(newRevInfo(ia110, 3))
+	assert.Equal(t, pathA, c.Path())
+	assert.Equal(t, []snet.Path{pathA}, c.Paths())
+
+	// If all paths are revoked, no path is usable and writing fails, even
+	// after refreshing, as long as the revocations are active.
+	c.Revoke(newRevInfo(ia111, 5))
+	assert.Nil(t, c.Path())
+	_, err = c.Write([]byte("hello"))
+	assert.True(t, errors.Is(err, pathconn.ErrNoPath), err)
+	assert.Equal(t, [][2]string{{"", "a"}, {"a", ""}}, changes.get())
+}
+
+func TestSCMPHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	pathA := newPath("a", time.Hour, ia110, 1, ia112, 2)
+	pathB := newPath("b", time.Hour, ia110, 3, ia112, 4)
+	querier := mock_snet.NewMockPathQuerier(ctrl)
+	querier.EXPECT().Query(gomock.Any(), ia112).Return([]snet.Path{pathA, pathB}, nil)
+	conn := mock_snet.NewMockConn(ctrl)
+	h := pathconn.NewSCMPHandler(snet.NewSCMPHandler(nil))
+	changes := &pathChanges{}
+	c, err := pathconn.New(context.Background(), conn, remote, pathconn.Config{
+		Querier:      querier,
+		SCMPHandler:  h,
+		OnPathChange: changes.handle,
+	})
+	require.NoError(t, err)
+	conn.EXPECT().Close()
+
+	err = h.Handle(newRevPacket(t, newRevInfo(ia110, 1)))
+	var opErr *snet.OpError
+	assert.True(t, errors.As(err, &opErr))
+	assert.Equal(t, pathB, c.Path())
+	assert.Equal(t, [][2]string{{"", "a"}, {"a", "b"}}, changes.get())
+
+	// Closed connections are no longer informed.
+	require.NoError(t, c.Close())
+	assert.Error(t, h.Handle(newRevPacket(t, newRevInfo(ia110, 3))))
+	assert.Equal(t, pathB, c.Path())
+}
+
+func expectedAddr(path snet.Path) *snet.UDPAddr {
+	a := remote.Copy()
+	a.Path, a.NextHop = path.Path(), path.OverlayNextHop()
+	return a
+}
+
+func newRevInfo(ia addr.IA, ifID common.IFIDType) *path_mgmt.RevInfo {
+	return &path_mgmt.RevInfo{
+		IfID:         ifID,
+		RawIsdas:     ia.IAInt(),
+		LinkType:     proto.LinkType_core,
+		RawTimestamp: util.TimeToSecs(time.Now()),
+		RawTTL:       uint32(path_mgmt.MinRevTTL.Seconds()),
+	}
+}
+
+// newRevPacket creates an SCMP revocation packet as passed to the SCMP handler.
+func newRevPacket(t *testing.T, revInfo *path_mgmt.RevInfo) *snet.SCIONPacket {
+	rawRev, err := revInfo.Pack()
+	require.NoError(t, err)
+	rawSRev, err := proto.PackRoot(&path_mgmt.SignedRevInfo{Blob: rawRev, Sign: &proto.SignS{}})
+	require.NoError(t, err)
+	ct := scmp.ClassType{Class: scmp.C_Path, Type: scmp.T_P_RevokedIF}
+	info := scmp.NewInfoRevocation(1, 2, revInfo.IfID, true, rawSRev)
+	pld := scmp.PldFromQuotes(ct, info, common.L4UDP, func(scmp.RawBlock) common.RawBytes {
+		return nil
+	})
+	return &snet.SCIONPacket{
+		SCIONPacketInfo: snet.SCIONPacketInfo{
+			Source:   snet.SCIONAddress{IA: revInfo.IA(), Host: addr.SvcNone},
+			L4Header: scmp.NewHdr(ct, pld.Len()),
+			Payload:  pld,
+		},
+	}
+}
+
+type pathChanges struct {
+	mtx     sync.Mutex
+	changes [][2]string
+}
+
+func (p *pathChanges) handle(old, new snet.Path) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.changes = append(p.changes, [2]string{fingerprint(old), fingerprint(new)})
+}
+
+func (p *pathChanges) get() [][2]string {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.changes
+}
+
+func fingerprint(path snet.Path) string {
+	if path == nil {
+		return ""
+	}
+	return string(path.Fingerprint())
+}
+
+type testPath struct {
+	fingerprint snet.PathFingerprint
+	expiry      time.Time
+	interfaces  []snet.PathInterface
+}
+
+// newPath creates a path with the given fingerprint that expires after
+// expiresIn. The interfaces are given as IA, interface ID pairs.
+func newPath(fingerprint string, expiresIn time.Duration,
+	intfs ...interface{}) *testPath {
+
+	p := &testPath{
+		fingerprint: snet.PathFingerprint(fingerprint),
+		expiry:      time.Now().Add(expiresIn),
+	}
+	if expiresIn == 0 {
+		p.expiry = time.Time{}
+	}
+	for i := 0; i < len(intfs); i += 2 {
+		p.interfaces = append(p.interfaces,
+			testIntf{ia: intfs[i].(addr.IA), id: common.IFIDType(intfs[i+1].(int))})
+	}
+	return p
+}
+
+func (p *testPath) Fingerprint() snet.PathFingerprint { return p.fingerprint }
+
+func (p *testPath) OverlayNextHop() *net.UDPAddr {
+	return &net.UDPAddr{IP: net.IP{192, 0, 2, 1}, Port: int(p.interfaces[0].ID())}
+}
+
+func (p *testPath) Path() *spath.Path {
+	return spath.New(common.RawBytes(p.fingerprint))
+}
+
+func (p *testPath) Interfaces() []snet.PathInterface { return p.interfaces }
+func (p *testPath) Destination() addr.IA             { return ia112 }
+func (p *testPath) MTU() uint16                      { return 1472 }
+func (p *testPath) Expiry() time.Time                { return p.expiry }
+func (p *testPath) Metadata() *snet.PathMetadata     { return nil }
+func (p *testPath) Copy() snet.Path                  { return p }
+
+type testIntf struct {
+	ia addr.IA
+	id common.IFIDType
+}
+
+func (i testIntf) ID() common.IFIDType { return i.id }
+func (i testIntf) IA() addr.IA         { return i.ia }
@@ -0,0 +1,81 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathconn
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+var _ snet.SCMPHandler = (*SCMPHandler)(nil)
+
+// SCMPHandler is an SCMP handler that informs the registered path-aware
+// connections about SCMP revocations. The SCMP messages are processed by the
+// wrapped handler first, only revocations it returns in an snet.OpError are
+// forwarded to the connections. Thus, revocations that fail authentication in
+// the wrapped handler never cause a path switch.
+type SCMPHandler struct {
+	handler snet.SCMPHandler
+
+	mtx   sync.Mutex
+	conns map[*Conn]struct{}
+}
+
+// NewSCMPHandler creates an SCMP handler that wraps handler. Typically, handler
+// is created with snet.NewSCMPHandler or snet.NewAuthSCMPHandler.
+func NewSCMPHandler(handler snet.SCMPHandler) *SCMPHandler {
+	return &SCMPHandler{
+		handler: handler,
+		conns:   make(map[*Conn]struct{}),
+	}
+}
+
+// Handle processes the packet with the wrapped handler and applies the
+// revocation it contains to all registered connections.
+func (h *SCMPHandler) Handle(pkt *snet.SCIONPacket) error {
+	err := h.handler.Handle(pkt)
+	var opErr *snet.OpError
+	if err == nil || !errors.As(err, &opErr) || opErr.RevInfo() == nil {
+		return err
+	}
+	for _, c := range h.registered() {
+		c.Revoke(opErr.RevInfo())
+	}
+	return err
+}
+
+func (h *SCMPHandler) registered() []*Conn {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	conns := make([]*Conn, 0, len(h.conns))
+	for c := range h.conns {
+		conns = append(conns, c)
+	}
+	return conns
+}
+
+func (h *SCMPHandler) register(c *Conn) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	h.conns[c] = struct{}{}
+}
+
+func (h *SCMPHandler) unregister(c *Conn) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	delete(h.conns, c)
+}
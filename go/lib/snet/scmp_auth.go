@@ -0,0 +1,188 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/scmp"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/spse/scmp_auth"
+)
+
+const (
+	// scmpVerifyTimeout is the maximum time spent verifying a single SCMP
+	// message.
+	scmpVerifyTimeout = 2 * time.Second
+	// scmpTimestampWindow is the maximum difference between the timestamp of
+	// an authenticated SCMP message and the local time.
+	scmpTimestampWindow = 10 * time.Second
+)
+
+var (
+	// ErrSCMPUnauthenticated indicates that an SCMP message does not carry any
+	// authentication.
+	ErrSCMPUnauthenticated = serrors.New("SCMP message not authenticated")
+	// ErrSCMPTimestamp indicates that the timestamp of an authenticated SCMP
+	// message is too far from the local time, e.g., because it is replayed.
+	ErrSCMPTimestamp = serrors.New("SCMP timestamp outside of accepted window")
+)
+
+// SCMPVerifier verifies the authenticity of received SCMP messages.
+type SCMPVerifier interface {
+	// Verify checks the authentication of the SCMP message contained in the
+	// packet. If the packet does not carry any authentication,
+	// ErrSCMPUnauthenticated is returned.
+	Verify(ctx context.Context, pkt *SCIONPacket) error
+}
+
+// DRKeyGetter fetches level 2 DRKeys. It is implemented by the SCIOND
+// connector.
+type DRKeyGetter interface {
+	DRKeyGetLvl2Key(ctx context.Context, meta drkey.Lvl2Meta,
+		valTime time.Time) (drkey.Lvl2Key, error)
+}
+
+var _ SCMPVerifier = (*DRKeySCMPVerifier)(nil)
+
+// DRKeySCMPVerifier verifies the DRKey MAC that border routers attach to the
+// SCMP errors they originate. The MAC is computed with the AS to host key
+// between the source AS and the destination host.
+//
+// Fetched keys are cached until their epoch expires, such that only the first
+// SCMP error per source AS and epoch requires a key request.
+type DRKeySCMPVerifier struct {
+	// Keys is used to fetch the level 2 keys.
+	Keys DRKeyGetter
+
+	mtx   sync.Mutex
+	cache map[scmpKeyID][]drkey.Lvl2Key
+}
+
+// scmpKeyID identifies the level 2 keys used to authenticate SCMP errors.
+type scmpKeyID struct {
+	srcIA   addr.IA
+	dstIA   addr.IA
+	dstHost string
+}
+
+func (v *DRKeySCMPVerifier) Verify(ctx context.Context, pkt *SCIONPacket) error {
+	hdr, ok := pkt.L4Header.(*scmp.Hdr)
+	if !ok {
+		return serrors.New("not an SCMP packet", "type", common.TypeOf(pkt.L4Header))
+	}
+	extn := drkeyExtn(pkt.Extensions)
+	if extn == nil {
+		return ErrSCMPUnauthenticated
+	}
+	if extn.Direction != scmp_auth.AsToHost {
+		return serrors.New("unsupported key direction", "dir", extn.Direction)
+	}
+	// The timestamp is authenticated by the MAC. Checking it before fetching
+	// the key bounds replays, and prevents forged timestamps from triggering
+	// key requests for arbitrary epochs.
+	if err := checkSCMPTime(hdr.Time(), time.Now()); err != nil {
+		return err
+	}
+	meta := drkey.Lvl2Meta{
+		KeyType:  drkey.AS2Host,
+		Protocol: scmp_auth.DRKeyProtocol,
+		SrcIA:    pkt.Source.IA,
+		DstIA:    pkt.Destination.IA,
+		DstHost:  pkt.Destination.Host,
+	}
+	key, err := v.key(ctx, meta, hdr.Time())
+	if err != nil {
+		return serrors.WrapStr("unable to fetch DRKey", err)
+	}
+	input, err := scmp_auth.MACInput(pkt.Source.IA, pkt.Destination.IA, pkt.Source.Host,
+		pkt.Destination.Host, hdr, pkt.Payload)
+	if err != nil {
+		return err
+	}
+	return extn.Verify(common.RawBytes(key.Key), input)
+}
+
+// key returns the level 2 key that is valid at valTime. The key is fetched,
+// if it is not cached yet.
+func (v *DRKeySCMPVerifier) key(ctx context.Context, meta drkey.Lvl2Meta,
+	valTime time.Time) (drkey.Lvl2Key, error) {
+
+	id := scmpKeyID{srcIA: meta.SrcIA, dstIA: meta.DstIA, dstHost: meta.DstHost.String()}
+	v.mtx.Lock()
+	for _, key := range v.cache[id] {
+		if key.Epoch.Contains(valTime) {
+			v.mtx.Unlock()
+			return key, nil
+		}
+	}
+	v.mtx.Unlock()
+
+	key, err := v.Keys.DRKeyGetLvl2Key(ctx, meta, valTime)
+	if err != nil {
+		return drkey.Lvl2Key{}, err
+	}
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+	if v.cache == nil {
+		v.cache = make(map[scmpKeyID][]drkey.Lvl2Key)
+	}
+	v.cache[id] = append(v.cache[id], key)
+	v.expire(time.Now())
+	return key, nil
+}
+
+// expire removes the cached keys whose epoch ended before now. The caller
+// must hold the lock.
+func (v *DRKeySCMPVerifier) expire(now time.Time) {
+	for id, keys := range v.cache {
+		valid := keys[:0]
+		for _, key := range keys {
+			if !key.Epoch.NotAfter.Before(now) {
+				valid = append(valid, key)
+			}
+		}
+		if len(valid) == 0 {
+			delete(v.cache, id)
+			continue
+		}
+		v.cache[id] = valid
+	}
+}
+
+// checkSCMPTime checks that the SCMP timestamp is within the accepted window
+// around now.
+func checkSCMPTime(ts, now time.Time) error {
+	if ts.Before(now.Add(-scmpTimestampWindow)) || ts.After(now.Add(scmpTimestampWindow)) {
+		return serrors.WithCtx(ErrSCMPTimestamp, "timestamp", ts, "now", now,
+			"window", scmpTimestampWindow)
+	}
+	return nil
+}
+
+// drkeyExtn returns the SCMPAuthDRKey extension, or nil if there is none.
+func drkeyExtn(extns []common.Extension) *scmp_auth.DRKeyExtn {
+	for _, e := range extns {
+		if extn, ok := e.(*scmp_auth.DRKeyExtn); ok {
+			return extn
+		}
+	}
+	return nil
+}
@@ -0,0 +1,339 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/hpkt"
+	"github.com/scionproto/scion/go/lib/scmp"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/spkt"
+	"github.com/scionproto/scion/go/lib/spse/scmp_auth"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/proto"
+)
+
+var (
+	scmpSrc = snet.SCIONAddress{
+		IA:   xtest.MustParseIA("1-ff00:0:110"),
+		Host: addr.HostFromIP(net.IP{192, 0, 2, 1}),
+	}
+	scmpDst = snet.SCIONAddress{
+		IA:   xtest.MustParseIA("1-ff00:0:111"),
+		Host: addr.HostFromIP(net.IP{192, 0, 2, 2}),
+	}
+	scmpKey = drkey.DRKey(xtest.MustParseHexString("c584cad32613547c64823c756651b6f5"))
+)
+
+func TestDRKeySCMPVerifier(t *testing.T) {
+	tests := map[string]struct {
+		Modify       func(pkt *snet.SCIONPacket)
+		Keys         keyGetter
+		ErrAssertion assert.ErrorAssertionFunc
+	}{
+		"valid": {
+			Keys:         keyGetter{key: scmpKey},
+			ErrAssertion: assert.NoError,
+		},
+		"wrong key": {
+			Keys:         keyGetter{key: make(drkey.DRKey, 16)},
+			ErrAssertion: assert.Error,
+		},
+		"key not available": {
+			Keys:         keyGetter{err: serrors.New("not available")},
+			ErrAssertion: assert.Error,
+		},
+		"modified payload": {
+			Modify: func(pkt *snet.SCIONPacket) {
+				pkt.Payload.(*scmp.Payload).Info.(*scmp.InfoRevocation).IfID = 42
+			},
+			Keys:         keyGetter{key: scmpKey},
+			ErrAssertion: assert.Error,
+		},
+		"modified source": {
+			Modify: func(pkt *snet.SCIONPacket) {
+				pkt.Source.Host = addr.HostFromIP(net.IP{192, 0, 2, 3})
+			},
+			Keys:         keyGetter{key: scmpKey},
+			ErrAssertion: assert.Error,
+		},
+		"stale timestamp": {
+			Modify: func(pkt *snet.SCIONPacket) {
+				pkt.L4Header.(*scmp.Hdr).SetTime(time.Now().Add(-time.Minute))
+			},
+			// The key is not fetched for messages outside of the window.
+			Keys: keyGetter{err: serrors.New("unexpected fetch")},
+			ErrAssertion: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.True(t, errors.Is(err, snet.ErrSCMPTimestamp))
+			},
+		},
+		"future timestamp": {
+			Modify: func(pkt *snet.SCIONPacket) {
+				pkt.L4Header.(*scmp.Hdr).SetTime(time.Now().Add(time.Minute))
+			},
+			Keys: keyGetter{err: serrors.New("unexpected fetch")},
+			ErrAssertion: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.True(t, errors.Is(err, snet.ErrSCMPTimestamp))
+			},
+		},
+		"unauthenticated": {
+			Modify: func(pkt *snet.SCIONPacket) {
+				pkt.Extensions = nil
+			},
+			Keys: keyGetter{key: scmpKey},
+			ErrAssertion: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.True(t, errors.Is(err, snet.ErrSCMPUnauthenticated))
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pkt := newAuthSCMPPacket(t, true)
+			if test.Modify != nil {
+				test.Modify(pkt)
+			}
+			v := &snet.DRKeySCMPVerifier{Keys: test.Keys}
+			test.ErrAssertion(t, v.Verify(context.Background(), pkt))
+		})
+	}
+}
+
+func TestAuthSCMPHandler(t *testing.T) {
+	tests := map[string]struct {
+		Authenticated bool
+		Key           drkey.DRKey
+		Strict        bool
+		Accepted      bool
+	}{
+		"authenticated": {
+			Authenticated: true,
+			Key:           scmpKey,
+			Strict:        true,
+			Accepted:      true,
+		},
+		"authentication failed": {
+			Authenticated: true,
+			Key:           make(drkey.DRKey, 16),
+			Accepted:      false,
+		},
+		"unauthenticated": {
+			Key:      scmpKey,
+			Accepted: true,
+		},
+		"unauthenticated strict": {
+			Key:      scmpKey,
+			Strict:   true,
+			Accepted: false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rh := &revHandler{}
+			h := snet.NewAuthSCMPHandler(rh,
+				&snet.DRKeySCMPVerifier{Keys: keyGetter{key: test.Key}}, test.Strict)
+			pkt := newAuthSCMPPacket(t, test.Authenticated)
+			err := h.Handle(pkt)
+			if !test.Accepted {
+				assert.NoError(t, err)
+				assert.Empty(t, rh.rawPkts)
+				return
+			}
+			var opErr *snet.OpError
+			require.True(t, errors.As(err, &opErr))
+			assert.Equal(t, []common.RawBytes{common.RawBytes(pkt.Bytes)}, rh.rawPkts)
+		})
+	}
+}
+
+func TestDRKeySCMPVerifierCache(t *testing.T) {
+	now := time.Now()
+	tests := map[string]struct {
+		Epoch           drkey.Epoch
+		ExpectedFetches int
+	}{
+		"valid epoch": {
+			Epoch: drkey.NewEpoch(util.TimeToSecs(now.Add(-time.Hour)),
+				util.TimeToSecs(now.Add(time.Hour))),
+			ExpectedFetches: 1,
+		},
+		"expired epoch": {
+			Epoch: drkey.NewEpoch(util.TimeToSecs(now.Add(-2*time.Hour)),
+				util.TimeToSecs(now.Add(-time.Hour))),
+			ExpectedFetches: 3,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			keys := &countingKeyGetter{keyGetter: keyGetter{key: scmpKey}, epoch: test.Epoch}
+			v := &snet.DRKeySCMPVerifier{Keys: keys}
+			for i := 0; i < 3; i++ {
+				assert.NoError(t, v.Verify(context.Background(), newAuthSCMPPacket(t, true)))
+			}
+			assert.Equal(t, test.ExpectedFetches, keys.fetches)
+		})
+	}
+}
+
+func TestNetworkWithSCMPAuth(t *testing.T) {
+	tests := map[string]struct {
+		Options  []snet.NetworkOption
+		Accepted bool
+	}{
+		"no options": {
+			Accepted: true,
+		},
+		"optional": {
+			Options: []snet.NetworkOption{snet.WithSCMPAuth(
+				&snet.DRKeySCMPVerifier{Keys: keyGetter{key: scmpKey}}, false)},
+			Accepted: true,
+		},
+		"strict": {
+			Options: []snet.NetworkOption{snet.WithSCMPAuth(
+				&snet.DRKeySCMPVerifier{Keys: keyGetter{key: scmpKey}}, true)},
+			Accepted: false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rh := &revHandler{}
+			n := snet.NewNetworkWithPR(scmpDst.IA, nil, nil, rh, test.Options...)
+			err := snet.NetworkSCMPHandler(n).Handle(newAuthSCMPPacket(t, false))
+			if !test.Accepted {
+				assert.NoError(t, err)
+				assert.Empty(t, rh.rawPkts)
+				return
+			}
+			var opErr *snet.OpError
+			require.True(t, errors.As(err, &opErr))
+			assert.Len(t, rh.rawPkts, 1)
+		})
+	}
+}
+
+// newAuthSCMPPacket creates a serialized and parsed SCMP revocation packet, as
+// received by snet. If authenticated is set, the packet carries a DRKey MAC.
+func newAuthSCMPPacket(t *testing.T, authenticated bool) *snet.SCIONPacket {
+	rev, err := (&path_mgmt.RevInfo{
+		IfID:         11,
+		RawIsdas:     scmpSrc.IA.IAInt(),
+		RawTimestamp: util.TimeToSecs(time.Now()),
+		RawTTL:       10,
+	}).Pack()
+	require.NoError(t, err)
+	rawSRev, err := proto.PackRoot(&path_mgmt.SignedRevInfo{Blob: rev, Sign: &proto.SignS{}})
+	require.NoError(t, err)
+	ct := scmp.ClassType{Class: scmp.C_Path, Type: scmp.T_P_RevokedIF}
+	info := scmp.NewInfoRevocation(1, 2, 11, true, rawSRev)
+	pld := scmp.PldFromQuotes(ct, info, common.L4UDP, func(scmp.RawBlock) common.RawBytes {
+		return nil
+	})
+	hdr := scmp.NewHdr(ct, pld.Len())
+	sp := &spkt.ScnPkt{
+		DstIA:   scmpDst.IA,
+		SrcIA:   scmpSrc.IA,
+		DstHost: scmpDst.Host,
+		SrcHost: scmpSrc.Host,
+		L4:      hdr,
+		Pld:     pld,
+	}
+	if authenticated {
+		input, err := scmp_auth.MACInput(sp.SrcIA, sp.DstIA, sp.SrcHost, sp.DstHost, hdr, pld)
+		require.NoError(t, err)
+		mac, err := scmp_auth.ComputeMAC(common.RawBytes(scmpKey), input)
+		require.NoError(t, err)
+		extn := scmp_auth.NewDRKeyExtn()
+		require.NoError(t, extn.SetDirection(scmp_auth.AsToHost))
+		require.NoError(t, extn.SetMAC(mac))
+		sp.E2EExt = []common.Extension{extn}
+	}
+	raw := make(common.RawBytes, common.MaxMTU)
+	n, err := hpkt.WriteScnPkt(sp, raw)
+	require.NoError(t, err)
+	parsed := &spkt.ScnPkt{}
+	require.NoError(t, hpkt.ParseScnPkt(parsed, raw[:n]))
+	return &snet.SCIONPacket{
+		Bytes: snet.Bytes(raw[:n]),
+		SCIONPacketInfo: snet.SCIONPacketInfo{
+			Destination: snet.SCIONAddress{IA: parsed.DstIA, Host: parsed.DstHost},
+			Source:      snet.SCIONAddress{IA: parsed.SrcIA, Host: parsed.SrcHost},
+			Path:        parsed.Path,
+			Extensions:  append(parsed.HBHExt, parsed.E2EExt...),
+			L4Header:    parsed.L4,
+			Payload:     parsed.Pld,
+		},
+	}
+}
+
+type keyGetter struct {
+	key drkey.DRKey
+	err error
+}
+
+func (g keyGetter) DRKeyGetLvl2Key(_ context.Context, meta drkey.Lvl2Meta,
+	_ time.Time) (drkey.Lvl2Key, error) {
+
+	if g.err != nil {
+		return drkey.Lvl2Key{}, g.err
+	}
+	if meta.KeyType != drkey.AS2Host || meta.Protocol != scmp_auth.DRKeyProtocol ||
+		!meta.SrcIA.Equal(scmpSrc.IA) || !meta.DstIA.Equal(scmpDst.IA) ||
+		!meta.DstHost.Equal(scmpDst.Host) {
+
+		return drkey.Lvl2Key{}, serrors.New("unexpected key", "meta", meta)
+	}
+	return drkey.Lvl2Key{Lvl2Meta: meta, Key: g.key}, nil
+}
+
+// countingKeyGetter counts the key fetches and returns keys for the epoch.
+type countingKeyGetter struct {
+	keyGetter
+	epoch   drkey.Epoch
+	fetches int
+}
+
+func (g *countingKeyGetter) DRKeyGetLvl2Key(ctx context.Context, meta drkey.Lvl2Meta,
+	valTime time.Time) (drkey.Lvl2Key, error) {
+
+	g.fetches++
+	key, err := g.keyGetter.DRKeyGetLvl2Key(ctx, meta, valTime)
+	key.Epoch = g.epoch
+	return key, err
+}
+
+type revHandler struct {
+	rawPkts []common.RawBytes
+}
+
+func (h *revHandler) RevokeRaw(_ context.Context, _ common.RawBytes) {
+	panic("raw packet not forwarded")
+}
+
+func (h *revHandler) RevokeFromPacket(_ context.Context, _, rawPkt common.RawBytes) {
+	h.rawPkts = append(h.rawPkts, rawPkt)
+}
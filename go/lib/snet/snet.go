@@ -66,17 +66,35 @@ type SCIONNetwork struct {
 	localIA addr.IA
 }
 
+// NetworkOption configures the network created by NewNetworkWithPR.
+type NetworkOption func(h *scmpHandler)
+
+// WithSCMPAuth configures the network to verify the authenticity of received
+// SCMP errors with the verifier. SCMP errors that fail verification are
+// dropped. SCMP errors that do not carry any authentication are only dropped
+// if strict is set. See NewAuthSCMPHandler.
+func WithSCMPAuth(v SCMPVerifier, strict bool) NetworkOption {
+	return func(h *scmpHandler) {
+		h.verifier = v
+		h.strict = strict
+	}
+}
+
 // NewNetworkWithPR creates a new networking context with path resolver pr. A
 // nil path resolver means the Network will run without SCIOND.
 func NewNetworkWithPR(ia addr.IA, dispatcher reliable.Dispatcher,
-	querier PathQuerier, revHandler RevocationHandler) *SCIONNetwork {
+	querier PathQuerier, revHandler RevocationHandler, opts ...NetworkOption) *SCIONNetwork {
 
+	h := &scmpHandler{
+		revocationHandler: revHandler,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
 	return &SCIONNetwork{
 		dispatcher: &DefaultPacketDispatcherService{
-			Dispatcher: dispatcher,
-			SCMPHandler: &scmpHandler{
-				revocationHandler: revHandler,
-			},
+			Dispatcher:  dispatcher,
+			SCMPHandler: h,
 		},
 		querier: querier,
 		localIA: ia,
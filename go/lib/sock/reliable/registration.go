@@ -25,9 +25,11 @@ import (
 type CommandBitField uint8
 
 const (
-	CmdBindAddress CommandBitField = 0x04
-	CmdEnableSCMP  CommandBitField = 0x02
-	CmdAlwaysOn    CommandBitField = 0x01
+	CmdBackpressure CommandBitField = 0x10
+	CmdQueueSize    CommandBitField = 0x08
+	CmdBindAddress  CommandBitField = 0x04
+	CmdEnableSCMP   CommandBitField = 0x02
+	CmdAlwaysOn     CommandBitField = 0x01
 )
 
 // Registration contains metadata for a SCION Dispatcher registration message.
@@ -36,6 +38,12 @@ type Registration struct {
 	PublicAddress *net.UDPAddr
 	BindAddress   *net.UDPAddr
 	SVCAddress    addr.HostSVC
+	// QueueSize is the requested size of the application ingress queue in the
+	// dispatcher, in packets. If it is 0, the dispatcher default is used.
+	QueueSize uint32
+	// Backpressure requests the dispatcher to send a BackpressureSignal to the
+	// application whenever packets for it are dropped.
+	Backpressure bool
 }
 
 func (r *Registration) SerializeTo(b []byte) (int, error) {
@@ -54,6 +62,13 @@ func (r *Registration) SerializeTo(b []byte) (int, error) {
 		msg.BindData = &bindAddress
 		bindAddress.SetFromUDPAddr(r.BindAddress)
 	}
+	if r.QueueSize != 0 {
+		msg.Command |= CmdQueueSize
+		msg.QueueSize = r.QueueSize
+	}
+	if r.Backpressure {
+		msg.Command |= CmdBackpressure
+	}
 	if r.SVCAddress != addr.SvcNone {
 		buffer := make([]byte, 2)
 		common.Order.PutUint16(buffer, uint16(r.SVCAddress))
@@ -86,6 +101,8 @@ func (r *Registration) DecodeFromBytes(b []byte) error {
 			Port: int(msg.BindData.Port),
 		}
 	}
+	r.QueueSize = msg.QueueSize
+	r.Backpressure = (msg.Command & CmdBackpressure) != 0
 	return nil
 }
 
@@ -97,6 +114,7 @@ type registrationMessage struct {
 	IA         uint64
 	PublicData registrationAddressField
 	BindData   *registrationAddressField
+	QueueSize  uint32
 	SVC        []byte
 }
 
@@ -118,6 +136,16 @@ func (m *registrationMessage) SerializeTo(b []byte) (int, error) {
 		}
 		offset += m.BindData.length()
 	}
+	if (m.Command & CmdQueueSize) != 0 {
+		if len(b[offset:]) < 4 {
+			return 0, common.NewBasicError(ErrBufferTooSmall, nil)
+		}
+		common.Order.PutUint32(b[offset:], m.QueueSize)
+		offset += 4
+	}
+	if len(b[offset:]) < len(m.SVC) {
+		return 0, common.NewBasicError(ErrBufferTooSmall, nil)
+	}
 	copy(b[offset:], m.SVC)
 	offset += len(m.SVC)
 	return offset, nil
@@ -142,6 +170,13 @@ func (l *registrationMessage) DecodeFromBytes(b []byte) error {
 		}
 		offset += l.BindData.length()
 	}
+	if (l.Command & CmdQueueSize) != 0 {
+		if len(b[offset:]) < 4 {
+			return common.NewBasicError(ErrIncompleteMessage, nil)
+		}
+		l.QueueSize = common.Order.Uint32(b[offset:])
+		offset += 4
+	}
 	switch len(b[offset:]) {
 	case 0:
 		return nil
@@ -218,3 +253,29 @@ func (c *Confirmation) DecodeFromBytes(b []byte) error {
 	c.Port = common.Order.Uint16(b)
 	return nil
 }
+
+// BackpressureSignal is sent by the dispatcher to applications that registered
+// with backpressure enabled, whenever packets destined to the application were
+// dropped because its ingress queue was full. The signal is sent as a frame
+// with address type NONE.
+type BackpressureSignal struct {
+	// Dropped is the number of packets that were dropped since the last
+	// signal.
+	Dropped uint32
+}
+
+func (s *BackpressureSignal) SerializeTo(b []byte) (int, error) {
+	if len(b) < 4 {
+		return 0, common.NewBasicError(ErrBufferTooSmall, nil)
+	}
+	common.Order.PutUint32(b, s.Dropped)
+	return 4, nil
+}
+
+func (s *BackpressureSignal) DecodeFromBytes(b []byte) error {
+	if len(b) < 4 {
+		return common.NewBasicError(ErrIncompleteMessage, nil)
+	}
+	s.Dropped = common.Order.Uint32(b)
+	return nil
+}
@@ -104,6 +104,19 @@ func TestRegistrationMessageSerializeTo(t *testing.T) {
 				0, 80, 1, 10, 2, 3, 4,
 				0, 81, 1, 10, 5, 6, 7, 0, 2},
 		},
+		{
+			Name: "public address with queue size and backpressure",
+			Registration: &Registration{
+				IA:            xtest.MustParseIA("1-ff00:0:1"),
+				PublicAddress: &net.UDPAddr{IP: net.IP{10, 2, 3, 4}, Port: 80},
+				SVCAddress:    addr.SvcCS,
+				QueueSize:     1024,
+				Backpressure:  true,
+			},
+			ExpectedData: []byte{0x1b, 17, 0, 1, 0xff, 0, 0, 0, 0, 0x01,
+				0, 80, 1, 10, 2, 3, 4,
+				0, 0, 0x04, 0, 0, 2},
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.Name, func(t *testing.T) {
@@ -235,6 +248,39 @@ func TestRegistrationMessageDecodeFromBytes(t *testing.T) {
 				SVCAddress:    addr.SvcPS,
 			},
 		},
+		{
+			Name: "queue size with bind and SVC",
+			Data: []byte{0x0f, 17, 0, 1, 0xff, 0, 0, 0, 0, 0x01,
+				0, 80, 1, 10, 2, 3, 4,
+				0, 81, 1, 10, 5, 6, 7,
+				0, 0, 0x04, 0,
+				0x00, 0x01},
+			ExpectedRegistration: Registration{
+				IA:            xtest.MustParseIA("1-ff00:0:1"),
+				PublicAddress: &net.UDPAddr{IP: net.IP{10, 2, 3, 4}, Port: 80},
+				BindAddress:   &net.UDPAddr{IP: net.IP{10, 5, 6, 7}, Port: 81},
+				SVCAddress:    addr.SvcPS,
+				QueueSize:     1024,
+			},
+		},
+		{
+			Name: "incomplete queue size",
+			Data: []byte{0x0b, 17, 0, 1, 0xff, 0, 0, 0, 0, 0x01,
+				0, 80, 1, 10, 2, 3, 4,
+				0, 0, 0x04},
+			ExpectedError: ErrIncompleteMessage,
+		},
+		{
+			Name: "backpressure",
+			Data: []byte{0x13, 17, 0, 1, 0xff, 0, 0, 0, 0, 0x01,
+				0, 80, 1, 10, 2, 3, 4},
+			ExpectedRegistration: Registration{
+				IA:            xtest.MustParseIA("1-ff00:0:1"),
+				PublicAddress: &net.UDPAddr{IP: net.IP{10, 2, 3, 4}, Port: 80},
+				SVCAddress:    addr.SvcNone,
+				Backpressure:  true,
+			},
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.Name, func(t *testing.T) {
@@ -277,3 +323,23 @@ func TestConfirmationDecodeFromBytes(t *testing.T) {
 		assert.Equal(t, Confirmation{Port: 0xaabb}, confirmation)
 	})
 }
+
+func TestBackpressureSignal(t *testing.T) {
+	t.Run("bad buffer", func(t *testing.T) {
+		signal := &BackpressureSignal{Dropped: 42}
+		n, err := signal.SerializeTo(make([]byte, 3))
+		xtest.AssertErrorsIs(t, err, ErrBufferTooSmall)
+		assert.Zero(t, n)
+		err = signal.DecodeFromBytes([]byte{0, 0, 1})
+		xtest.AssertErrorsIs(t, err, ErrIncompleteMessage)
+	})
+	t.Run("round trip", func(t *testing.T) {
+		b := make([]byte, 1500)
+		n, err := (&BackpressureSignal{Dropped: 0x01020304}).SerializeTo(b)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{1, 2, 3, 4}, b[:n])
+		var signal BackpressureSignal
+		assert.NoError(t, signal.DecodeFromBytes(b[:n]))
+		assert.Equal(t, BackpressureSignal{Dropped: 0x01020304}, signal)
+	})
+}
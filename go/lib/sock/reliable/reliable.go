@@ -33,7 +33,8 @@
 //
 // ReliableSocket registration message format:
 //  13-bytes: [Common header with address type NONE]
-//   1-byte: Command (bit mask with 0x04=Bind address, 0x02=SCMP enable, 0x01 always set)
+//   1-byte: Command (bit mask with 0x10=Backpressure, 0x08=Queue size, 0x04=Bind address,
+//                    0x02=SCMP enable, 0x01 always set)
 //   1-byte: L4 Proto (IANA number)
 //   8-bytes: ISD-AS
 //   2-bytes: L4 port
@@ -42,8 +43,13 @@
 //  +2-bytes: L4 bind port  \
 //  +1-byte: Address type    ) (optional bind address)
 //  +var-byte: Bind Address /
+//  +4-bytes: Queue size (optional requested ingress queue size)
 //  +2-bytes: SVC (optional SVC type)
 //
+// ReliableSocket backpressure signal format:
+//  13-bytes: [Common header with address type NONE]
+//   4-bytes: Number of dropped packets
+//
 // To communicate with SCIOND, clients must first connect to SCIOND's UNIX socket. Messages
 // for SCIOND must set the ADDR TYPE field in the common header to NONE. The payload contains
 // the query for SCIOND (e.g., a request for paths to a SCION destination). The reply header
@@ -57,6 +63,11 @@
 // To send messages to remote SCION hosts, hosts fill in the common header
 // with the address type, the address and the layer 4 port of the remote host.
 //
+// Applications can request the size of their ingress queue in the dispatcher,
+// the dispatcher caps the requested size to its configured maximum. If
+// backpressure is requested, the dispatcher sends a backpressure signal on the
+// registered connection whenever packets for the application were dropped.
+//
 // Reads and writes to the connection are thread safe.
 //
 package reliable
@@ -106,14 +117,37 @@ func NewDispatcher(name string) Dispatcher {
 	return &dispatcherService{Address: name}
 }
 
+// RegistrationOptions contains the optional parameters of a dispatcher
+// registration.
+type RegistrationOptions struct {
+	// QueueSize is the requested size of the application ingress queue in the
+	// dispatcher, in packets. If it is 0, the dispatcher default is used. The
+	// dispatcher caps the size to its configured maximum.
+	QueueSize uint32
+	// OnBackpressure, if set, enables backpressure signaling. It is called from
+	// within the read path of the registered connection whenever the
+	// dispatcher reports dropped packets, and must not block.
+	OnBackpressure func(BackpressureSignal)
+}
+
+// NewDispatcherWithOptions is similar to NewDispatcher, but all registrations
+// through the returned dispatcher use the given options.
+func NewDispatcherWithOptions(name string, opts RegistrationOptions) Dispatcher {
+	if name == "" {
+		name = DefaultDispPath
+	}
+	return &dispatcherService{Address: name, Options: opts}
+}
+
 type dispatcherService struct {
 	Address string
+	Options RegistrationOptions
 }
 
 func (d *dispatcherService) Register(ctx context.Context, ia addr.IA, public *net.UDPAddr,
 	svc addr.HostSVC) (net.PacketConn, uint16, error) {
 
-	return registerMetricsWrapper(ctx, d.Address, ia, public, svc)
+	return registerMetricsWrapper(ctx, d.Address, ia, public, svc, d.Options)
 }
 
 var _ net.Conn = (*Conn)(nil)
@@ -130,6 +164,11 @@ type Conn struct {
 	writeMutex    sync.Mutex
 	writeBuffer   []byte
 	writeStreamer *WriteStreamer
+
+	// onBackpressure is called for backpressure signals received from the
+	// dispatcher. If it is nil, frames without address are returned to the
+	// reader.
+	onBackpressure func(BackpressureSignal)
 }
 
 func newConn(c net.Conn) *Conn {
@@ -159,21 +198,23 @@ func Dial(ctx context.Context, address string) (*Conn, error) {
 }
 
 func registerMetricsWrapper(ctx context.Context, dispatcher string, ia addr.IA,
-	public *net.UDPAddr, svc addr.HostSVC) (*Conn, uint16, error) {
+	public *net.UDPAddr, svc addr.HostSVC, opts RegistrationOptions) (*Conn, uint16, error) {
 
-	conn, port, err := register(ctx, dispatcher, ia, public, svc)
+	conn, port, err := register(ctx, dispatcher, ia, public, svc, opts)
 	labels := metrics.RegisterLabels{Result: labelResult(err), SVC: svc.BaseString()}
 	metrics.M.Registers(labels).Inc()
 	return conn, port, err
 }
 
 func register(ctx context.Context, dispatcher string, ia addr.IA, public *net.UDPAddr,
-	svc addr.HostSVC) (*Conn, uint16, error) {
+	svc addr.HostSVC, opts RegistrationOptions) (*Conn, uint16, error) {
 
 	reg := &Registration{
 		IA:            ia,
 		PublicAddress: public,
 		SVCAddress:    svc,
+		QueueSize:     opts.QueueSize,
+		Backpressure:  opts.OnBackpressure != nil,
 	}
 
 	conn, err := Dial(ctx, dispatcher)
@@ -211,6 +252,9 @@ func register(ctx context.Context, dispatcher string, ia addr.IA, public *net.UD
 		}
 		// Disable deadline to not affect future I/O
 		conn.SetDeadline(time.Time{})
+		// Only install the handler after the registration exchange, the
+		// confirmation is also sent without address.
+		conn.onBackpressure = opts.OnBackpressure
 		return conn, uint16(registrationReturn.port), nil
 	case <-ctx.Done():
 		// Unblock registration worker I/O
@@ -265,12 +309,23 @@ func (conn *Conn) readFrom(buf []byte) (int, net.Addr, error) {
 	conn.readMutex.Lock()
 	defer conn.readMutex.Unlock()
 
-	n, err := conn.readPacketizer.Read(conn.readBuffer)
-	if err != nil {
-		return 0, nil, err
-	}
 	var p OverlayPacket
-	p.DecodeFromBytes(conn.readBuffer[:n])
+	for {
+		n, err := conn.readPacketizer.Read(conn.readBuffer)
+		if err != nil {
+			return 0, nil, err
+		}
+		p.DecodeFromBytes(conn.readBuffer[:n])
+		if p.Address != nil || conn.onBackpressure == nil {
+			break
+		}
+		var signal BackpressureSignal
+		if err := signal.DecodeFromBytes(p.Payload); err != nil {
+			log.Debug("Ignoring malformed backpressure signal", "err", err)
+			continue
+		}
+		conn.onBackpressure(signal)
+	}
 	var overlayAddr *net.UDPAddr
 	if p.Address != nil {
 		overlayAddr = &net.UDPAddr{
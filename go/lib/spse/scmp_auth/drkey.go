@@ -92,7 +92,26 @@ func NewDRKeyExtn() *DRKeyExtn {
 	return s
 }
 
-func (s DRKeyExtn) SetDirection(dir Dir) error {
+// DRKeyExtnFromRaw parses the SCMPAuthDRKey extension from raw bytes. The
+// bytes must not include the extension sub-header.
+func DRKeyExtnFromRaw(b common.RawBytes) (*DRKeyExtn, error) {
+	if len(b) != DRKeyTotalLength {
+		return nil, common.NewBasicError("Invalid header length", nil,
+			"expected", DRKeyTotalLength, "actual", len(b))
+	}
+	if spse.SecMode(b[0]) != spse.ScmpAuthDRKey {
+		return nil, common.NewBasicError("Invalid SecMode code", nil,
+			"expected", spse.ScmpAuthDRKey, "actual", spse.SecMode(b[0]))
+	}
+	s := NewDRKeyExtn()
+	if err := s.SetDirection(Dir(b[DirectionOffset])); err != nil {
+		return nil, err
+	}
+	copy(s.MAC, b[MACOffset:DRKeyTotalLength])
+	return s, nil
+}
+
+func (s *DRKeyExtn) SetDirection(dir Dir) error {
 	if dir > HostToHostReversed {
 		return common.NewBasicError("Invalid direction", nil, "dir", dir)
 	}
@@ -100,7 +119,7 @@ func (s DRKeyExtn) SetDirection(dir Dir) error {
 	return nil
 }
 
-func (s DRKeyExtn) SetMAC(mac common.RawBytes) error {
+func (s *DRKeyExtn) SetMAC(mac common.RawBytes) error {
 	if len(mac) != MACLength {
 		return common.NewBasicError("Invalid MAC size", nil,
 			"expected", MACLength, "actual", len(mac))
@@ -0,0 +1,91 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scmp_auth
+
+import (
+	"crypto/subtle"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/scmp"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// DRKeyProtocol is the protocol identifier of the level 2 DRKeys that are
+// used to authenticate SCMP messages.
+const DRKeyProtocol = "scmp"
+
+// ErrMACMismatch indicates that the MAC in the extension does not match the
+// authenticated input.
+var ErrMACMismatch = serrors.New("DRKey MAC mismatch")
+
+// MACInput returns the input that is authenticated by the DRKey MAC of an
+// SCMP message. It covers the source and destination addresses, the SCMP
+// header without the checksum, and the SCMP payload. Fields that are modified
+// in transit, such as the current info and hop field pointers, are not
+// covered.
+func MACInput(srcIA, dstIA addr.IA, srcHost, dstHost addr.HostAddr, hdr *scmp.Hdr,
+	pld common.Payload) (common.RawBytes, error) {
+
+	if srcHost == nil || dstHost == nil {
+		return nil, serrors.New("host address not set", "src", srcHost, "dst", dstHost)
+	}
+	if hdr == nil || pld == nil {
+		return nil, serrors.New("SCMP header or payload not set")
+	}
+	rawHdr, err := hdr.Pack(true)
+	if err != nil {
+		return nil, err
+	}
+	rawSrc, rawDst := srcHost.Pack(), dstHost.Pack()
+	b := make(common.RawBytes, 2*addr.IABytes+2, 2*addr.IABytes+2+len(rawSrc)+
+		len(rawDst)+len(rawHdr)+pld.Len())
+	srcIA.Write(b)
+	dstIA.Write(b[addr.IABytes:])
+	b[2*addr.IABytes], b[2*addr.IABytes+1] = byte(srcHost.Type()), byte(dstHost.Type())
+	b = append(b, rawSrc...)
+	b = append(b, rawDst...)
+	b = append(b, rawHdr...)
+	rawPld := b[len(b) : len(b)+pld.Len()]
+	if _, err := pld.WritePld(rawPld); err != nil {
+		return nil, err
+	}
+	return b[:len(b)+len(rawPld)], nil
+}
+
+// ComputeMAC computes the DRKey MAC over the input with the provided level 2
+// key.
+func ComputeMAC(key, input common.RawBytes) (common.RawBytes, error) {
+	mac, err := scrypto.InitMac(key)
+	if err != nil {
+		return nil, err
+	}
+	mac.Write(input)
+	return mac.Sum(nil)[:MACLength], nil
+}
+
+// Verify checks that the MAC in the extension authenticates the input with
+// the provided level 2 key.
+func (s *DRKeyExtn) Verify(key, input common.RawBytes) error {
+	expected, err := ComputeMAC(key, input)
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare(expected, s.MAC) != 1 {
+		return ErrMACMismatch
+	}
+	return nil
+}
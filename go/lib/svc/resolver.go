@@ -201,6 +201,10 @@ func (p *path) Expiry() time.Time {
 	return time.Time{}
 }
 
+func (p *path) Metadata() *snet.PathMetadata {
+	return nil
+}
+
 func (p *path) Copy() snet.Path {
 	if p == nil {
 		return nil
@@ -15,10 +15,12 @@
 package truststorage
 
 const trustDbSample = `
-# The type of trustdb backend. (default sqlite)
+# The type of trustdb backend. Supported backends are "sqlite" and
+# "postgres". (default sqlite)
 backend = "sqlite"
 
-# Connection for the trust database.
+# Connection for the trust database. The path to the database file for the
+# "sqlite" backend, or the connection string for the "postgres" backend.
 connection = "/var/lib/scion/spki/%s.trust.db"
 
 # The maximum number of open connections to the database. In case of the
@@ -25,6 +25,7 @@ import (
 	"github.com/scionproto/scion/go/lib/config"
 	"github.com/scionproto/scion/go/lib/infra/modules/db"
 	"github.com/scionproto/scion/go/lib/infra/modules/trust"
+	"github.com/scionproto/scion/go/lib/infra/modules/trust/trustdbpostgres"
 	"github.com/scionproto/scion/go/lib/infra/modules/trust/trustdbsqlite"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/serrors"
@@ -34,8 +35,9 @@ import (
 type Backend string
 
 const (
-	BackendNone   Backend = ""
-	BackendSqlite Backend = "sqlite"
+	BackendNone     Backend = ""
+	BackendSqlite   Backend = "sqlite"
+	BackendPostgres Backend = "postgres"
 )
 
 const (
@@ -96,7 +98,7 @@ func (cfg *TrustDBConf) Validate() error {
 
 func (cfg *TrustDBConf) validateBackend() error {
 	switch cfg.Backend() {
-	case BackendSqlite:
+	case BackendSqlite, BackendPostgres:
 		return nil
 	case BackendNone:
 		return serrors.New("No backend set")
@@ -120,6 +122,8 @@ func (cfg *TrustDBConf) New() (trust.DB, error) {
 	switch cfg.Backend() {
 	case BackendSqlite:
 		tdb, err = trustdbsqlite.New(cfg.Connection())
+	case BackendPostgres:
+		tdb, err = trustdbpostgres.New(cfg.Connection())
 	default:
 		return nil, common.NewBasicError("Unsupported backend", nil, "backend", cfg.Backend())
 	}
@@ -3,6 +3,7 @@
 package proto
 
 import (
+	math "math"
 	capnp "zombiezen.com/go/capnproto2"
 	text "zombiezen.com/go/capnproto2/encoding/text"
 	schemas "zombiezen.com/go/capnproto2/schemas"
@@ -236,32 +237,680 @@ func (p HiddenPathSegExtn_Promise) Struct() (HiddenPathSegExtn, error) {
 	return HiddenPathSegExtn{s}, err
 }
 
-const schema_e6c88f91b6a1209e = "x\xda\x8c\xce?\x8b\x13A\x1c\xc6\xf1\xe7\x99\xd9d\x8d" +
-	"h\x925\xfb\x02\xc4RD\x08\"\x88\x8d\x7fH\xc0t" +
-	";\xc6F\x10t\xc9\x8e\xc9Bvva'\xb0[\x05" +
-	"A\xad\x04I\xa1b#\xe45\x08bk\xa1\xf8\x1a\xae" +
-	";\xb8?/\xe0\xaa\xab\xf6\xd8\x14w\x1c\x97\xe2\xda\x1f" +
-	"\x9fy\xe6\xdb\xfd\xf6X\xf4\x1b\x1d\x02\xeaZ\xa3Y\x1d" +
-	"\xef\xdd\x7f\xf0{\xe7\xcfW\xa8\x0eE\xf5\xe3\xe6\xfa\xd7" +
-	"\xea\xf3\xff\x034\xe8\x02\xfdD\xd0+]\xc0[\x1c\x82" +
-	"\xd5\xfa\xdf\x9dwm\xfd\xf1oMyF\x1d\x17\xb8\xf7" +
-	"\x927\xd8\x8b\xebG=\xcdG`u\xf4i\x7f\xf7\xfb" +
-	"\xcf\xb2\xda\x86?\xf0*{_6x\xb5\xc1a\x9e\xbc" +
-	"\xd6\x85\xcd\xe5\xddI\x98\x99\xec\xe1\xf3tac3\x0d" +
-	"\xd2y<)\x87\x85E@\xaa\xaet\x00\x87\x80\x17\xde" +
-	"\x02\xd4+I5\x13\xf4H\x9f\xf5Q?\x05\xd4\x1bI" +
-	"5\x17\xf4\x04}\x0a\xc0\x8bo\x03*\x92T\x99 \xa5" +
-	"O\x09xI\x0dg\x92\xea\xbd\xa0\x9bkKB\x90\xe0" +
-	"2K\xe7/\xcaL\xb3\x09\xc1&\xd8\x89\xdf\x8e\x06l" +
-	"A\xb0\x05.\xe3<\x0as\x9d\xb3\x0d\x06\x92\x9bs{" +
-	"K\xf9h<xbL\xba0\x13\x9dhc\x87\x05m" +
-	"\xdd\xee\x9c\xb6_\xaf\xdb\xafH*\xff\xfc\xef\x17\x96\x9e" +
-	"\xc5Q\xa4M\x10\xda\xd9XO\x87\x855\xc0\xe5\x96N" +
-	"\x02\x00\x00\xff\xff#\x1dpa"
+type StaticInfoExtn struct{ capnp.Struct }
+
+// StaticInfoExtn_TypeID is the unique identifier for the type StaticInfoExtn.
+const StaticInfoExtn_TypeID = 0xdb505e3694652d57
+
+func NewStaticInfoExtn(s *capnp.Segment) (StaticInfoExtn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 5})
+	return StaticInfoExtn{st}, err
+}
+
+func NewRootStaticInfoExtn(s *capnp.Segment) (StaticInfoExtn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 5})
+	return StaticInfoExtn{st}, err
+}
+
+func ReadRootStaticInfoExtn(msg *capnp.Message) (StaticInfoExtn, error) {
+	root, err := msg.RootPtr()
+	return StaticInfoExtn{root.Struct()}, err
+}
+
+func (s StaticInfoExtn) String() string {
+	str, _ := text.Marshal(0xdb505e3694652d57, s.Struct)
+	return str
+}
+
+func (s StaticInfoExtn) Latency() (StaticInfoExtn_LatencyInfo, error) {
+	p, err := s.Struct.Ptr(0)
+	return StaticInfoExtn_LatencyInfo{Struct: p.Struct()}, err
+}
+
+func (s StaticInfoExtn) HasLatency() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s StaticInfoExtn) SetLatency(v StaticInfoExtn_LatencyInfo) error {
+	return s.Struct.SetPtr(0, v.Struct.ToPtr())
+}
+
+// NewLatency sets the latency field to a newly
+// allocated StaticInfoExtn_LatencyInfo struct, preferring placement in s's segment.
+func (s StaticInfoExtn) NewLatency() (StaticInfoExtn_LatencyInfo, error) {
+	ss, err := NewStaticInfoExtn_LatencyInfo(s.Struct.Segment())
+	if err != nil {
+		return StaticInfoExtn_LatencyInfo{}, err
+	}
+	err = s.Struct.SetPtr(0, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s StaticInfoExtn) Bandwidth() (StaticInfoExtn_BandwidthInfo, error) {
+	p, err := s.Struct.Ptr(1)
+	return StaticInfoExtn_BandwidthInfo{Struct: p.Struct()}, err
+}
+
+func (s StaticInfoExtn) HasBandwidth() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s StaticInfoExtn) SetBandwidth(v StaticInfoExtn_BandwidthInfo) error {
+	return s.Struct.SetPtr(1, v.Struct.ToPtr())
+}
+
+// NewBandwidth sets the bandwidth field to a newly
+// allocated StaticInfoExtn_BandwidthInfo struct, preferring placement in s's segment.
+func (s StaticInfoExtn) NewBandwidth() (StaticInfoExtn_BandwidthInfo, error) {
+	ss, err := NewStaticInfoExtn_BandwidthInfo(s.Struct.Segment())
+	if err != nil {
+		return StaticInfoExtn_BandwidthInfo{}, err
+	}
+	err = s.Struct.SetPtr(1, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s StaticInfoExtn) Geo() (StaticInfoExtn_GeoInfo_List, error) {
+	p, err := s.Struct.Ptr(2)
+	return StaticInfoExtn_GeoInfo_List{List: p.List()}, err
+}
+
+func (s StaticInfoExtn) HasGeo() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s StaticInfoExtn) SetGeo(v StaticInfoExtn_GeoInfo_List) error {
+	return s.Struct.SetPtr(2, v.List.ToPtr())
+}
+
+// NewGeo sets the geo field to a newly
+// allocated StaticInfoExtn_GeoInfo_List, preferring placement in s's segment.
+func (s StaticInfoExtn) NewGeo(n int32) (StaticInfoExtn_GeoInfo_List, error) {
+	l, err := NewStaticInfoExtn_GeoInfo_List(s.Struct.Segment(), n)
+	if err != nil {
+		return StaticInfoExtn_GeoInfo_List{}, err
+	}
+	err = s.Struct.SetPtr(2, l.List.ToPtr())
+	return l, err
+}
+
+func (s StaticInfoExtn) LinkType() StaticInfoExtn_LinkType {
+	return StaticInfoExtn_LinkType(s.Struct.Uint16(0))
+}
+
+func (s StaticInfoExtn) SetLinkType(v StaticInfoExtn_LinkType) {
+	s.Struct.SetUint16(0, uint16(v))
+}
+
+func (s StaticInfoExtn) InternalHops() (StaticInfoExtn_InterfaceValue_List, error) {
+	p, err := s.Struct.Ptr(3)
+	return StaticInfoExtn_InterfaceValue_List{List: p.List()}, err
+}
+
+func (s StaticInfoExtn) HasInternalHops() bool {
+	p, err := s.Struct.Ptr(3)
+	return p.IsValid() || err != nil
+}
+
+func (s StaticInfoExtn) SetInternalHops(v StaticInfoExtn_InterfaceValue_List) error {
+	return s.Struct.SetPtr(3, v.List.ToPtr())
+}
+
+// NewInternalHops sets the internalHops field to a newly
+// allocated StaticInfoExtn_InterfaceValue_List, preferring placement in s's segment.
+func (s StaticInfoExtn) NewInternalHops(n int32) (StaticInfoExtn_InterfaceValue_List, error) {
+	l, err := NewStaticInfoExtn_InterfaceValue_List(s.Struct.Segment(), n)
+	if err != nil {
+		return StaticInfoExtn_InterfaceValue_List{}, err
+	}
+	err = s.Struct.SetPtr(3, l.List.ToPtr())
+	return l, err
+}
+
+func (s StaticInfoExtn) Note() (string, error) {
+	p, err := s.Struct.Ptr(4)
+	return p.Text(), err
+}
+
+func (s StaticInfoExtn) HasNote() bool {
+	p, err := s.Struct.Ptr(4)
+	return p.IsValid() || err != nil
+}
+
+func (s StaticInfoExtn) NoteBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(4)
+	return p.TextBytes(), err
+}
+
+func (s StaticInfoExtn) SetNote(v string) error {
+	return s.Struct.SetText(4, v)
+}
+
+// StaticInfoExtn_List is a list of StaticInfoExtn.
+type StaticInfoExtn_List struct{ capnp.List }
+
+// NewStaticInfoExtn creates a new list of StaticInfoExtn.
+func NewStaticInfoExtn_List(s *capnp.Segment, sz int32) (StaticInfoExtn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 5}, sz)
+	return StaticInfoExtn_List{l}, err
+}
+
+func (s StaticInfoExtn_List) At(i int) StaticInfoExtn { return StaticInfoExtn{s.List.Struct(i)} }
+
+func (s StaticInfoExtn_List) Set(i int, v StaticInfoExtn) error { return s.List.SetStruct(i, v.Struct) }
+
+func (s StaticInfoExtn_List) String() string {
+	str, _ := text.MarshalList(0xdb505e3694652d57, s.List)
+	return str
+}
+
+// StaticInfoExtn_Promise is a wrapper for a StaticInfoExtn promised by a client call.
+type StaticInfoExtn_Promise struct{ *capnp.Pipeline }
+
+func (p StaticInfoExtn_Promise) Struct() (StaticInfoExtn, error) {
+	s, err := p.Pipeline.Struct()
+	return StaticInfoExtn{s}, err
+}
+
+func (p StaticInfoExtn_Promise) Latency() StaticInfoExtn_LatencyInfo_Promise {
+	return StaticInfoExtn_LatencyInfo_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
+}
+
+func (p StaticInfoExtn_Promise) Bandwidth() StaticInfoExtn_BandwidthInfo_Promise {
+	return StaticInfoExtn_BandwidthInfo_Promise{Pipeline: p.Pipeline.GetPipeline(1)}
+}
+
+type StaticInfoExtn_LatencyInfo struct{ capnp.Struct }
+
+// StaticInfoExtn_LatencyInfo_TypeID is the unique identifier for the type StaticInfoExtn_LatencyInfo.
+const StaticInfoExtn_LatencyInfo_TypeID = 0x92ae8103dd768751
+
+func NewStaticInfoExtn_LatencyInfo(s *capnp.Segment) (StaticInfoExtn_LatencyInfo, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return StaticInfoExtn_LatencyInfo{st}, err
+}
+
+func NewRootStaticInfoExtn_LatencyInfo(s *capnp.Segment) (StaticInfoExtn_LatencyInfo, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return StaticInfoExtn_LatencyInfo{st}, err
+}
+
+func ReadRootStaticInfoExtn_LatencyInfo(msg *capnp.Message) (StaticInfoExtn_LatencyInfo, error) {
+	root, err := msg.RootPtr()
+	return StaticInfoExtn_LatencyInfo{root.Struct()}, err
+}
+
+func (s StaticInfoExtn_LatencyInfo) String() string {
+	str, _ := text.Marshal(0x92ae8103dd768751, s.Struct)
+	return str
+}
+
+func (s StaticInfoExtn_LatencyInfo) Intra() (StaticInfoExtn_InterfaceValue_List, error) {
+	p, err := s.Struct.Ptr(0)
+	return StaticInfoExtn_InterfaceValue_List{List: p.List()}, err
+}
+
+func (s StaticInfoExtn_LatencyInfo) HasIntra() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s StaticInfoExtn_LatencyInfo) SetIntra(v StaticInfoExtn_InterfaceValue_List) error {
+	return s.Struct.SetPtr(0, v.List.ToPtr())
+}
+
+// NewIntra sets the intra field to a newly
+// allocated StaticInfoExtn_InterfaceValue_List, preferring placement in s's segment.
+func (s StaticInfoExtn_LatencyInfo) NewIntra(n int32) (StaticInfoExtn_InterfaceValue_List, error) {
+	l, err := NewStaticInfoExtn_InterfaceValue_List(s.Struct.Segment(), n)
+	if err != nil {
+		return StaticInfoExtn_InterfaceValue_List{}, err
+	}
+	err = s.Struct.SetPtr(0, l.List.ToPtr())
+	return l, err
+}
+
+func (s StaticInfoExtn_LatencyInfo) Inter() uint32 {
+	return s.Struct.Uint32(0)
+}
+
+func (s StaticInfoExtn_LatencyInfo) SetInter(v uint32) {
+	s.Struct.SetUint32(0, v)
+}
+
+// StaticInfoExtn_LatencyInfo_List is a list of StaticInfoExtn_LatencyInfo.
+type StaticInfoExtn_LatencyInfo_List struct{ capnp.List }
+
+// NewStaticInfoExtn_LatencyInfo creates a new list of StaticInfoExtn_LatencyInfo.
+func NewStaticInfoExtn_LatencyInfo_List(s *capnp.Segment, sz int32) (StaticInfoExtn_LatencyInfo_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1}, sz)
+	return StaticInfoExtn_LatencyInfo_List{l}, err
+}
+
+func (s StaticInfoExtn_LatencyInfo_List) At(i int) StaticInfoExtn_LatencyInfo {
+	return StaticInfoExtn_LatencyInfo{s.List.Struct(i)}
+}
+
+func (s StaticInfoExtn_LatencyInfo_List) Set(i int, v StaticInfoExtn_LatencyInfo) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s StaticInfoExtn_LatencyInfo_List) String() string {
+	str, _ := text.MarshalList(0x92ae8103dd768751, s.List)
+	return str
+}
+
+// StaticInfoExtn_LatencyInfo_Promise is a wrapper for a StaticInfoExtn_LatencyInfo promised by a client call.
+type StaticInfoExtn_LatencyInfo_Promise struct{ *capnp.Pipeline }
+
+func (p StaticInfoExtn_LatencyInfo_Promise) Struct() (StaticInfoExtn_LatencyInfo, error) {
+	s, err := p.Pipeline.Struct()
+	return StaticInfoExtn_LatencyInfo{s}, err
+}
+
+type StaticInfoExtn_BandwidthInfo struct{ capnp.Struct }
+
+// StaticInfoExtn_BandwidthInfo_TypeID is the unique identifier for the type StaticInfoExtn_BandwidthInfo.
+const StaticInfoExtn_BandwidthInfo_TypeID = 0xc6ff25e3b262348a
+
+func NewStaticInfoExtn_BandwidthInfo(s *capnp.Segment) (StaticInfoExtn_BandwidthInfo, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return StaticInfoExtn_BandwidthInfo{st}, err
+}
+
+func NewRootStaticInfoExtn_BandwidthInfo(s *capnp.Segment) (StaticInfoExtn_BandwidthInfo, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return StaticInfoExtn_BandwidthInfo{st}, err
+}
+
+func ReadRootStaticInfoExtn_BandwidthInfo(msg *capnp.Message) (StaticInfoExtn_BandwidthInfo, error) {
+	root, err := msg.RootPtr()
+	return StaticInfoExtn_BandwidthInfo{root.Struct()}, err
+}
+
+func (s StaticInfoExtn_BandwidthInfo) String() string {
+	str, _ := text.Marshal(0xc6ff25e3b262348a, s.Struct)
+	return str
+}
+
+func (s StaticInfoExtn_BandwidthInfo) Intra() (StaticInfoExtn_InterfaceValue_List, error) {
+	p, err := s.Struct.Ptr(0)
+	return StaticInfoExtn_InterfaceValue_List{List: p.List()}, err
+}
+
+func (s StaticInfoExtn_BandwidthInfo) HasIntra() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s StaticInfoExtn_BandwidthInfo) SetIntra(v StaticInfoExtn_InterfaceValue_List) error {
+	return s.Struct.SetPtr(0, v.List.ToPtr())
+}
+
+// NewIntra sets the intra field to a newly
+// allocated StaticInfoExtn_InterfaceValue_List, preferring placement in s's segment.
+func (s StaticInfoExtn_BandwidthInfo) NewIntra(n int32) (StaticInfoExtn_InterfaceValue_List, error) {
+	l, err := NewStaticInfoExtn_InterfaceValue_List(s.Struct.Segment(), n)
+	if err != nil {
+		return StaticInfoExtn_InterfaceValue_List{}, err
+	}
+	err = s.Struct.SetPtr(0, l.List.ToPtr())
+	return l, err
+}
+
+func (s StaticInfoExtn_BandwidthInfo) Inter() uint64 {
+	return s.Struct.Uint64(0)
+}
+
+func (s StaticInfoExtn_BandwidthInfo) SetInter(v uint64) {
+	s.Struct.SetUint64(0, v)
+}
+
+// StaticInfoExtn_BandwidthInfo_List is a list of StaticInfoExtn_BandwidthInfo.
+type StaticInfoExtn_BandwidthInfo_List struct{ capnp.List }
+
+// NewStaticInfoExtn_BandwidthInfo creates a new list of StaticInfoExtn_BandwidthInfo.
+func NewStaticInfoExtn_BandwidthInfo_List(s *capnp.Segment, sz int32) (StaticInfoExtn_BandwidthInfo_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1}, sz)
+	return StaticInfoExtn_BandwidthInfo_List{l}, err
+}
+
+func (s StaticInfoExtn_BandwidthInfo_List) At(i int) StaticInfoExtn_BandwidthInfo {
+	return StaticInfoExtn_BandwidthInfo{s.List.Struct(i)}
+}
+
+func (s StaticInfoExtn_BandwidthInfo_List) Set(i int, v StaticInfoExtn_BandwidthInfo) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s StaticInfoExtn_BandwidthInfo_List) String() string {
+	str, _ := text.MarshalList(0xc6ff25e3b262348a, s.List)
+	return str
+}
+
+// StaticInfoExtn_BandwidthInfo_Promise is a wrapper for a StaticInfoExtn_BandwidthInfo promised by a client call.
+type StaticInfoExtn_BandwidthInfo_Promise struct{ *capnp.Pipeline }
+
+func (p StaticInfoExtn_BandwidthInfo_Promise) Struct() (StaticInfoExtn_BandwidthInfo, error) {
+	s, err := p.Pipeline.Struct()
+	return StaticInfoExtn_BandwidthInfo{s}, err
+}
+
+type StaticInfoExtn_InterfaceValue struct{ capnp.Struct }
+
+// StaticInfoExtn_InterfaceValue_TypeID is the unique identifier for the type StaticInfoExtn_InterfaceValue.
+const StaticInfoExtn_InterfaceValue_TypeID = 0x8d1aeb86e4d50f0a
+
+func NewStaticInfoExtn_InterfaceValue(s *capnp.Segment) (StaticInfoExtn_InterfaceValue, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 0})
+	return StaticInfoExtn_InterfaceValue{st}, err
+}
+
+func NewRootStaticInfoExtn_InterfaceValue(s *capnp.Segment) (StaticInfoExtn_InterfaceValue, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 0})
+	return StaticInfoExtn_InterfaceValue{st}, err
+}
+
+func ReadRootStaticInfoExtn_InterfaceValue(msg *capnp.Message) (StaticInfoExtn_InterfaceValue, error) {
+	root, err := msg.RootPtr()
+	return StaticInfoExtn_InterfaceValue{root.Struct()}, err
+}
+
+func (s StaticInfoExtn_InterfaceValue) String() string {
+	str, _ := text.Marshal(0x8d1aeb86e4d50f0a, s.Struct)
+	return str
+}
+
+func (s StaticInfoExtn_InterfaceValue) IfID() uint64 {
+	return s.Struct.Uint64(0)
+}
+
+func (s StaticInfoExtn_InterfaceValue) SetIfID(v uint64) {
+	s.Struct.SetUint64(0, v)
+}
+
+func (s StaticInfoExtn_InterfaceValue) Value() uint64 {
+	return s.Struct.Uint64(8)
+}
+
+func (s StaticInfoExtn_InterfaceValue) SetValue(v uint64) {
+	s.Struct.SetUint64(8, v)
+}
+
+// StaticInfoExtn_InterfaceValue_List is a list of StaticInfoExtn_InterfaceValue.
+type StaticInfoExtn_InterfaceValue_List struct{ capnp.List }
+
+// NewStaticInfoExtn_InterfaceValue creates a new list of StaticInfoExtn_InterfaceValue.
+func NewStaticInfoExtn_InterfaceValue_List(s *capnp.Segment, sz int32) (StaticInfoExtn_InterfaceValue_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 16, PointerCount: 0}, sz)
+	return StaticInfoExtn_InterfaceValue_List{l}, err
+}
+
+func (s StaticInfoExtn_InterfaceValue_List) At(i int) StaticInfoExtn_InterfaceValue {
+	return StaticInfoExtn_InterfaceValue{s.List.Struct(i)}
+}
+
+func (s StaticInfoExtn_InterfaceValue_List) Set(i int, v StaticInfoExtn_InterfaceValue) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s StaticInfoExtn_InterfaceValue_List) String() string {
+	str, _ := text.MarshalList(0x8d1aeb86e4d50f0a, s.List)
+	return str
+}
+
+// StaticInfoExtn_InterfaceValue_Promise is a wrapper for a StaticInfoExtn_InterfaceValue promised by a client call.
+type StaticInfoExtn_InterfaceValue_Promise struct{ *capnp.Pipeline }
+
+func (p StaticInfoExtn_InterfaceValue_Promise) Struct() (StaticInfoExtn_InterfaceValue, error) {
+	s, err := p.Pipeline.Struct()
+	return StaticInfoExtn_InterfaceValue{s}, err
+}
+
+type StaticInfoExtn_GeoInfo struct{ capnp.Struct }
+
+// StaticInfoExtn_GeoInfo_TypeID is the unique identifier for the type StaticInfoExtn_GeoInfo.
+const StaticInfoExtn_GeoInfo_TypeID = 0xd8c2ba2779275a74
+
+func NewStaticInfoExtn_GeoInfo(s *capnp.Segment) (StaticInfoExtn_GeoInfo, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 1})
+	return StaticInfoExtn_GeoInfo{st}, err
+}
+
+func NewRootStaticInfoExtn_GeoInfo(s *capnp.Segment) (StaticInfoExtn_GeoInfo, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 1})
+	return StaticInfoExtn_GeoInfo{st}, err
+}
+
+func ReadRootStaticInfoExtn_GeoInfo(msg *capnp.Message) (StaticInfoExtn_GeoInfo, error) {
+	root, err := msg.RootPtr()
+	return StaticInfoExtn_GeoInfo{root.Struct()}, err
+}
+
+func (s StaticInfoExtn_GeoInfo) String() string {
+	str, _ := text.Marshal(0xd8c2ba2779275a74, s.Struct)
+	return str
+}
+
+func (s StaticInfoExtn_GeoInfo) IfID() uint64 {
+	return s.Struct.Uint64(0)
+}
+
+func (s StaticInfoExtn_GeoInfo) SetIfID(v uint64) {
+	s.Struct.SetUint64(0, v)
+}
+
+func (s StaticInfoExtn_GeoInfo) Latitude() float32 {
+	return math.Float32frombits(s.Struct.Uint32(8))
+}
+
+func (s StaticInfoExtn_GeoInfo) SetLatitude(v float32) {
+	s.Struct.SetUint32(8, math.Float32bits(v))
+}
+
+func (s StaticInfoExtn_GeoInfo) Longitude() float32 {
+	return math.Float32frombits(s.Struct.Uint32(12))
+}
+
+func (s StaticInfoExtn_GeoInfo) SetLongitude(v float32) {
+	s.Struct.SetUint32(12, math.Float32bits(v))
+}
+
+func (s StaticInfoExtn_GeoInfo) Address() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s StaticInfoExtn_GeoInfo) HasAddress() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s StaticInfoExtn_GeoInfo) AddressBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s StaticInfoExtn_GeoInfo) SetAddress(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+// StaticInfoExtn_GeoInfo_List is a list of StaticInfoExtn_GeoInfo.
+type StaticInfoExtn_GeoInfo_List struct{ capnp.List }
+
+// NewStaticInfoExtn_GeoInfo creates a new list of StaticInfoExtn_GeoInfo.
+func NewStaticInfoExtn_GeoInfo_List(s *capnp.Segment, sz int32) (StaticInfoExtn_GeoInfo_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 16, PointerCount: 1}, sz)
+	return StaticInfoExtn_GeoInfo_List{l}, err
+}
+
+func (s StaticInfoExtn_GeoInfo_List) At(i int) StaticInfoExtn_GeoInfo {
+	return StaticInfoExtn_GeoInfo{s.List.Struct(i)}
+}
+
+func (s StaticInfoExtn_GeoInfo_List) Set(i int, v StaticInfoExtn_GeoInfo) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s StaticInfoExtn_GeoInfo_List) String() string {
+	str, _ := text.MarshalList(0xd8c2ba2779275a74, s.List)
+	return str
+}
+
+// StaticInfoExtn_GeoInfo_Promise is a wrapper for a StaticInfoExtn_GeoInfo promised by a client call.
+type StaticInfoExtn_GeoInfo_Promise struct{ *capnp.Pipeline }
+
+func (p StaticInfoExtn_GeoInfo_Promise) Struct() (StaticInfoExtn_GeoInfo, error) {
+	s, err := p.Pipeline.Struct()
+	return StaticInfoExtn_GeoInfo{s}, err
+}
+
+type StaticInfoExtn_LinkType uint16
+
+// StaticInfoExtn_LinkType_TypeID is the unique identifier for the type StaticInfoExtn_LinkType.
+const StaticInfoExtn_LinkType_TypeID = 0x8e5ebeac625172fd
+
+// Values of StaticInfoExtn_LinkType.
+const (
+	StaticInfoExtn_LinkType_unset    StaticInfoExtn_LinkType = 0
+	StaticInfoExtn_LinkType_direct   StaticInfoExtn_LinkType = 1
+	StaticInfoExtn_LinkType_multiHop StaticInfoExtn_LinkType = 2
+	StaticInfoExtn_LinkType_openNet  StaticInfoExtn_LinkType = 3
+)
+
+// String returns the enum's constant name.
+func (c StaticInfoExtn_LinkType) String() string {
+	switch c {
+	case StaticInfoExtn_LinkType_unset:
+		return "unset"
+	case StaticInfoExtn_LinkType_direct:
+		return "direct"
+	case StaticInfoExtn_LinkType_multiHop:
+		return "multiHop"
+	case StaticInfoExtn_LinkType_openNet:
+		return "openNet"
+
+	default:
+		return ""
+	}
+}
+
+// StaticInfoExtn_LinkTypeFromString returns the enum value with a name,
+// or the zero value if there's no such value.
+func StaticInfoExtn_LinkTypeFromString(c string) StaticInfoExtn_LinkType {
+	switch c {
+	case "unset":
+		return StaticInfoExtn_LinkType_unset
+	case "direct":
+		return StaticInfoExtn_LinkType_direct
+	case "multiHop":
+		return StaticInfoExtn_LinkType_multiHop
+	case "openNet":
+		return StaticInfoExtn_LinkType_openNet
+
+	default:
+		return 0
+	}
+}
+
+type StaticInfoExtn_LinkType_List struct{ capnp.List }
+
+func NewStaticInfoExtn_LinkType_List(s *capnp.Segment, sz int32) (StaticInfoExtn_LinkType_List, error) {
+	l, err := capnp.NewUInt16List(s, sz)
+	return StaticInfoExtn_LinkType_List{l.List}, err
+}
+
+func (l StaticInfoExtn_LinkType_List) At(i int) StaticInfoExtn_LinkType {
+	ul := capnp.UInt16List{List: l.List}
+	return StaticInfoExtn_LinkType(ul.At(i))
+}
+
+func (l StaticInfoExtn_LinkType_List) Set(i int, v StaticInfoExtn_LinkType) {
+	ul := capnp.UInt16List{List: l.List}
+	ul.Set(i, uint16(v))
+}
+
+const schema_e6c88f91b6a1209e = "x\xda\xbcUO\x88[\xd5\x17>\xdf\xbd\xef\xf5\xf6W" +
+	"\xdaf\xee\xef\xa5P\x04\x9b\xa6Xj\x07-\xed\xb4\x8a" +
+	"\x14e\xc6\xd0\xd2\xc90H\xeeLi\xc1E\xf5Mr" +
+	"g\xf2j\xe6\xbd4yi\x13\x11\xe2\x80T\x94\xaa(" +
+	"E\xecF\x98\xa5\xd0\x0a*\x15\x11+*\xb4\xe8\xd2E" +
+	"A\x94B\x8b\xad\"\xae\xaau1\x0byr\xf3\xef%" +
+	"\x9d@\xeb\xc6\xc5G\xc2\xbd\xe7\xe4\x9e\xef\xfb\xce9\xd9" +
+	"]d\x13l\x8f\x9d\xe2D\xea!{M\xb4.q\xf5" +
+	"\xe6\xe9\xdf\x1fx\x83\xd4\x16\xb0\xe8\xe8\xa3\xfa\xec\xe3\xc7" +
+	"r?\x91%\x88\xf6\xd6p\x1c\xcek\x10\x1d\x9c\"r" +
+	"l&\xa2\xbf+j\xee\xfc\x97\xc7\xde$\xb9\xa5/\x83" +
+	"\xb0\xf76\xf6\xc3\x01\x13\x1d,\x109\x8a\x89H\xbdz" +
+	"\xf2\x1a_\xfa\xf0\x1d\xf3\x04\xe2\x04\x1b\xe6\x8d\xa7\xd8\x0c" +
+	"LT\x07\xe6\x8d[LD+7\x1f{\xe2\xb3\x1f\xbf" +
+	"~\x97T\x02,z\x7f\xeb\xf2\xa7o\xbf\xf5\xed/\x9d" +
+	"\x9c\xef\x19\x83s\x8d\x89\x0e~%r~\xe3\"Z\xbe" +
+	"\xf2\xc8\xd2F}\xfa\xb2\xc9A\x9c\xd3\xa2r\x95\xff\x1f" +
+	"\xce-.:\x18'r\xd2\x96\x88^\xdf7\xf7\xf1\xcf" +
+	"\xdb\xa3+CK\xdb`\xcd\xc1Du`J\xbbh\x89" +
+	"(|vGc\xc7\xe7\xdf\xfcp\x97b\xed\x9cek" +
+	"\x0c\xceG\x96\xe8\xc0\x94v\xd1\x16q\xd4`i\xb6-" +
+	"\x88\x9ce\xfb\x0f\xe7\x82}\xd4Y\xb1\xc5\xde\x15\xfb(" +
+	"\x88\x9c\xef\x84\x88\xfe<s\xeb\xc6\xb9O\x1a\xd10:" +
+	"\x17\xc5:8\x97\x85\xe8\xc0\xd0\xc1Z\x11\xb9\xd5\xc5\xe7" +
+	"t=\xacZ\xbb\xf2n\xd9/\xef\x9f\x0d\xdd\xd0\xcbg" +
+	"\xfd\xf9\xe0`=\xf4we\xfdPW\xe6\xdd\xbc>\x92" +
+	"pK5\x9d\x03r`j-\xb7\x88,\x10\xc9\x9d\xa3" +
+	"r\xa7P\x0fs\xa8}\x0c\x12H\xc2\x9c\xee\x19\x93{" +
+	"\x84\xda\xcd\xa1\x9edHx\xf3\xd9\x0390\xfc\x8f\x0c" +
+	"\x90:\xd9\xfe\xa5\xde\xc1\x04zU\xf0\xe1UL{\xe3" +
+	"\xfe\x0b\x87\x1b\xe5\xee\xfb#`\xe6\xed1\xb9S\x00r" +
+	"\xfb~\xb9]\x80\xc9\xf4\x94\xf9\xe42\x9d\x91i\x91\xaa" +
+	"\xf9U\x1d\xe6\xc0\xc6\x0b^E\xe7\xcd\xb7h\xb1V\x0a" +
+	"\xbd\xc9\xa0LD9\xb0fP\xd6\xfe3\xad\x98\x09\xdc" +
+	"K\x87i7\xd4~\xbe\x91\xf5\xe7\x11\xac\x16a\xac+" +
+	"\xc24CW\x83\xec\x98\xcc\x0a5\xc9\xa1\x0e3\xa4<" +
+	"?\xac\xb8\x86\xf3FB\x8e\x03#\xf1\x14\x11M@B" +
+	"\xe4\x18\xcc\xa5\x89\xd4\x15\x13\xb9\x96\x0c\x86\xa93\x13\xd4" +
+	"B\xcf_\xc8\x05%/\xdf8X\x0f\xa9+K\xaf\"" +
+	"w\x9bt\x85z\x9eC\x95\xfal\xf12\xd2\x13\xaa\xc8" +
+	"\xa1B\x06\xc9\x90l\xc9xbT\x9e\x10\xaa\xcc\xa1^" +
+	"b\x00O\x82\x13\xc9FF6\x84\xaas\xa8\xb3\x0c\xa2" +
+	"\xad$@\x06h\x96\x83R\xdb\x0c\x865d\xb0\xca\xe3" +
+	"\xa6W-\xb8U]\xedcln6\x0e\xa5\x93\x9d=" +
+	"\xf0\xb4\xef\x075?\xaf\x17\xb5\x1f\x1e\xac#\xec\x10\xb2" +
+	"z\x846l\x93\x1b\x84Z\xcf\xa16\xaf\xaa\xe7\xde\xee" +
+	"e\\\xbfp\xca+\x84\xc5\xac\xf0\xe7\xff+\xff\xee\xbb" +
+	"\xbb\x0f\xe9T\x90\x8d\xeb\xeasqt\xc0E\xd6qq" +
+	"J.\x0aU\xe2Pu\xe3\"o\xbbX\x9b\xe9\x1a\xf6" +
+	"J\xec\xe2RF.\x09\xf52\x87:\xb3z\x0e\xa3\x92" +
+	"\x1bza\xad\xa0\xdb\xe3\x80ud\x80\xa8\x14\xf8\x0b\xe6" +
+	"\x9c\xa0\xfb\x8e\x9bn\xa1P\xd1\xd5\x96\xa3\xeb\xc9\xa0\x9f" +
+	"\x19\xbb\x9bY\xaaEM%\x81x\x97\xcb\xf4\\\xbc=" +
+	"e\xba\x12K(\xd3/\xc6;R\xa63}\xff\x17\x0f" +
+	"NE\xdd\xd9#c^\xd4\xf5\x92RE#Z\xd4\xdd" +
+	"P4\xae\x8f\x98\xc5\xd2<\xa4[jF\xd3^{g" +
+	"\xb4\xe9\xa9\xcd=Y\xcfe\xe49\xa1\xde\xe3P\xe7\xfb" +
+	"\x86\xe3\x83\x19yA\xa8\xf3\x1c\xea+#+k\xcbz" +
+	"i\x9b\xbc$\xd4\x17\x1c\xeaz,\xeb\xb5)yC\xa8" +
+	"\xeb\x1c\xea\x0e\x83\xb4x\x12\x16\x91\xbc}\\\xfe%\xd4" +
+	"\x1d\x8e\xd9\xcd`\x90\xb6\x95\x84M\xe4l\xc2\xa8\xb3\x09" +
+	"b6\x09\x8e\xd9\xad`h\x96\xda|\x8c\x92#\xb1:" +
+	"}m4B\x88\xe6\xba4Ql\x07\xf6\x94\x1b\x0c\x14" +
+	"\x0b:\x18\xe8\xcb\x9e\x8e\x83}\x19\x95\x06\xe4@\"\xd6" +
+	"\xb8/0A\x88Z\x0d\xec\xbb%JL\x06\xe5\xea\xfd" +
+	"\xf4|\xc2\x0fB=\xbc1\xba-?\xe9\x15\x0a\xda\xcf" +
+	"\xb9aqV/\x98\xd6 \xfa\x973\xfe\xcf\x00\xd3T" +
+	"\x11\xc2"
 
 func init() {
 	schemas.Register(schema_e6c88f91b6a1209e,
+		0x8d1aeb86e4d50f0a,
+		0x8e5ebeac625172fd,
+		0x92ae8103dd768751,
 		0x96c1dab83835e4f9,
 		0xc586650e812cc6a1,
+		0xc6ff25e3b262348a,
+		0xd8c2ba2779275a74,
+		0xdb505e3694652d57,
 		0xff79b399e1e58cf3)
 }
@@ -133,12 +133,12 @@ type DRKeyRep struct{ capnp.Struct }
 const DRKeyRep_TypeID = 0xc3fe25dd82681d64
 
 func NewDRKeyRep(s *capnp.Segment) (DRKeyRep, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 2})
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 3})
 	return DRKeyRep{st}, err
 }
 
 func NewRootDRKeyRep(s *capnp.Segment) (DRKeyRep, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 2})
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 3})
 	return DRKeyRep{st}, err
 }
 
@@ -228,12 +228,34 @@ func (s DRKeyRep) SetTrcVer(v uint32) {
 	s.Struct.SetUint32(24, v)
 }
 
+func (s DRKeyRep) EpochBegin() uint32 {
+	return s.Struct.Uint32(28)
+}
+
+func (s DRKeyRep) SetEpochBegin(v uint32) {
+	s.Struct.SetUint32(28, v)
+}
+
+func (s DRKeyRep) Nonce() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return []byte(p.Data()), err
+}
+
+func (s DRKeyRep) HasNonce() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s DRKeyRep) SetNonce(v []byte) error {
+	return s.Struct.SetData(2, v)
+}
+
 // DRKeyRep_List is a list of DRKeyRep.
 type DRKeyRep_List struct{ capnp.List }
 
 // NewDRKeyRep creates a new list of DRKeyRep.
 func NewDRKeyRep_List(s *capnp.Segment, sz int32) (DRKeyRep_List, error) {
-	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 32, PointerCount: 2}, sz)
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 32, PointerCount: 3}, sz)
 	return DRKeyRep_List{l}, err
 }
 
@@ -254,17 +276,341 @@ func (p DRKeyRep_Promise) Struct() (DRKeyRep, error) {
 	return DRKeyRep{s}, err
 }
 
+type DRKeyHost struct{ capnp.Struct }
+
+// DRKeyHost_TypeID is the unique identifier for the type DRKeyHost.
+const DRKeyHost_TypeID = 0x929b462d5a0499b7
+
+func NewDRKeyHost(s *capnp.Segment) (DRKeyHost, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return DRKeyHost{st}, err
+}
+
+func NewRootDRKeyHost(s *capnp.Segment) (DRKeyHost, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return DRKeyHost{st}, err
+}
+
+func ReadRootDRKeyHost(msg *capnp.Message) (DRKeyHost, error) {
+	root, err := msg.RootPtr()
+	return DRKeyHost{root.Struct()}, err
+}
+
+func (s DRKeyHost) String() string {
+	str, _ := text.Marshal(0x929b462d5a0499b7, s.Struct)
+	return str
+}
+
+func (s DRKeyHost) Type() uint8 {
+	return s.Struct.Uint8(0)
+}
+
+func (s DRKeyHost) SetType(v uint8) {
+	s.Struct.SetUint8(0, v)
+}
+
+func (s DRKeyHost) Host() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return []byte(p.Data()), err
+}
+
+func (s DRKeyHost) HasHost() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s DRKeyHost) SetHost(v []byte) error {
+	return s.Struct.SetData(0, v)
+}
+
+// DRKeyHost_List is a list of DRKeyHost.
+type DRKeyHost_List struct{ capnp.List }
+
+// NewDRKeyHost creates a new list of DRKeyHost.
+func NewDRKeyHost_List(s *capnp.Segment, sz int32) (DRKeyHost_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1}, sz)
+	return DRKeyHost_List{l}, err
+}
+
+func (s DRKeyHost_List) At(i int) DRKeyHost { return DRKeyHost{s.List.Struct(i)} }
+
+func (s DRKeyHost_List) Set(i int, v DRKeyHost) error { return s.List.SetStruct(i, v.Struct) }
+
+func (s DRKeyHost_List) String() string {
+	str, _ := text.MarshalList(0x929b462d5a0499b7, s.List)
+	return str
+}
+
+// DRKeyHost_Promise is a wrapper for a DRKeyHost promised by a client call.
+type DRKeyHost_Promise struct{ *capnp.Pipeline }
+
+func (p DRKeyHost_Promise) Struct() (DRKeyHost, error) {
+	s, err := p.Pipeline.Struct()
+	return DRKeyHost{s}, err
+}
+
+type DRKeyLvl2Req struct{ capnp.Struct }
+
+// DRKeyLvl2Req_TypeID is the unique identifier for the type DRKeyLvl2Req.
+const DRKeyLvl2Req_TypeID = 0xe5a448baf4040d94
+
+func NewDRKeyLvl2Req(s *capnp.Segment) (DRKeyLvl2Req, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 3})
+	return DRKeyLvl2Req{st}, err
+}
+
+func NewRootDRKeyLvl2Req(s *capnp.Segment) (DRKeyLvl2Req, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 3})
+	return DRKeyLvl2Req{st}, err
+}
+
+func ReadRootDRKeyLvl2Req(msg *capnp.Message) (DRKeyLvl2Req, error) {
+	root, err := msg.RootPtr()
+	return DRKeyLvl2Req{root.Struct()}, err
+}
+
+func (s DRKeyLvl2Req) String() string {
+	str, _ := text.Marshal(0xe5a448baf4040d94, s.Struct)
+	return str
+}
+
+func (s DRKeyLvl2Req) Protocol() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s DRKeyLvl2Req) HasProtocol() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s DRKeyLvl2Req) ProtocolBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s DRKeyLvl2Req) SetProtocol(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s DRKeyLvl2Req) ReqType() uint8 {
+	return s.Struct.Uint8(0)
+}
+
+func (s DRKeyLvl2Req) SetReqType(v uint8) {
+	s.Struct.SetUint8(0, v)
+}
+
+func (s DRKeyLvl2Req) ValTime() uint32 {
+	return s.Struct.Uint32(4)
+}
+
+func (s DRKeyLvl2Req) SetValTime(v uint32) {
+	s.Struct.SetUint32(4, v)
+}
+
+func (s DRKeyLvl2Req) SrcIA() uint64 {
+	return s.Struct.Uint64(8)
+}
+
+func (s DRKeyLvl2Req) SetSrcIA(v uint64) {
+	s.Struct.SetUint64(8, v)
+}
+
+func (s DRKeyLvl2Req) DstIA() uint64 {
+	return s.Struct.Uint64(16)
+}
+
+func (s DRKeyLvl2Req) SetDstIA(v uint64) {
+	s.Struct.SetUint64(16, v)
+}
+
+func (s DRKeyLvl2Req) SrcHost() (DRKeyHost, error) {
+	p, err := s.Struct.Ptr(1)
+	return DRKeyHost{Struct: p.Struct()}, err
+}
+
+func (s DRKeyLvl2Req) HasSrcHost() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s DRKeyLvl2Req) SetSrcHost(v DRKeyHost) error {
+	return s.Struct.SetPtr(1, v.Struct.ToPtr())
+}
+
+// NewSrcHost sets the srcHost field to a newly
+// allocated DRKeyHost struct, preferring placement in s's segment.
+func (s DRKeyLvl2Req) NewSrcHost() (DRKeyHost, error) {
+	ss, err := NewDRKeyHost(s.Struct.Segment())
+	if err != nil {
+		return DRKeyHost{}, err
+	}
+	err = s.Struct.SetPtr(1, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s DRKeyLvl2Req) DstHost() (DRKeyHost, error) {
+	p, err := s.Struct.Ptr(2)
+	return DRKeyHost{Struct: p.Struct()}, err
+}
+
+func (s DRKeyLvl2Req) HasDstHost() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s DRKeyLvl2Req) SetDstHost(v DRKeyHost) error {
+	return s.Struct.SetPtr(2, v.Struct.ToPtr())
+}
+
+// NewDstHost sets the dstHost field to a newly
+// allocated DRKeyHost struct, preferring placement in s's segment.
+func (s DRKeyLvl2Req) NewDstHost() (DRKeyHost, error) {
+	ss, err := NewDRKeyHost(s.Struct.Segment())
+	if err != nil {
+		return DRKeyHost{}, err
+	}
+	err = s.Struct.SetPtr(2, ss.Struct.ToPtr())
+	return ss, err
+}
+
+// DRKeyLvl2Req_List is a list of DRKeyLvl2Req.
+type DRKeyLvl2Req_List struct{ capnp.List }
+
+// NewDRKeyLvl2Req creates a new list of DRKeyLvl2Req.
+func NewDRKeyLvl2Req_List(s *capnp.Segment, sz int32) (DRKeyLvl2Req_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 24, PointerCount: 3}, sz)
+	return DRKeyLvl2Req_List{l}, err
+}
+
+func (s DRKeyLvl2Req_List) At(i int) DRKeyLvl2Req { return DRKeyLvl2Req{s.List.Struct(i)} }
+
+func (s DRKeyLvl2Req_List) Set(i int, v DRKeyLvl2Req) error { return s.List.SetStruct(i, v.Struct) }
+
+func (s DRKeyLvl2Req_List) String() string {
+	str, _ := text.MarshalList(0xe5a448baf4040d94, s.List)
+	return str
+}
+
+// DRKeyLvl2Req_Promise is a wrapper for a DRKeyLvl2Req promised by a client call.
+type DRKeyLvl2Req_Promise struct{ *capnp.Pipeline }
+
+func (p DRKeyLvl2Req_Promise) Struct() (DRKeyLvl2Req, error) {
+	s, err := p.Pipeline.Struct()
+	return DRKeyLvl2Req{s}, err
+}
+
+func (p DRKeyLvl2Req_Promise) SrcHost() DRKeyHost_Promise {
+	return DRKeyHost_Promise{Pipeline: p.Pipeline.GetPipeline(1)}
+}
+
+func (p DRKeyLvl2Req_Promise) DstHost() DRKeyHost_Promise {
+	return DRKeyHost_Promise{Pipeline: p.Pipeline.GetPipeline(2)}
+}
+
+type DRKeyLvl2Rep struct{ capnp.Struct }
+
+// DRKeyLvl2Rep_TypeID is the unique identifier for the type DRKeyLvl2Rep.
+const DRKeyLvl2Rep_TypeID = 0xdfa735fef0302b84
+
+func NewDRKeyLvl2Rep(s *capnp.Segment) (DRKeyLvl2Rep, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 1})
+	return DRKeyLvl2Rep{st}, err
+}
+
+func NewRootDRKeyLvl2Rep(s *capnp.Segment) (DRKeyLvl2Rep, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 1})
+	return DRKeyLvl2Rep{st}, err
+}
+
+func ReadRootDRKeyLvl2Rep(msg *capnp.Message) (DRKeyLvl2Rep, error) {
+	root, err := msg.RootPtr()
+	return DRKeyLvl2Rep{root.Struct()}, err
+}
+
+func (s DRKeyLvl2Rep) String() string {
+	str, _ := text.Marshal(0xdfa735fef0302b84, s.Struct)
+	return str
+}
+
+func (s DRKeyLvl2Rep) Timestamp() uint32 {
+	return s.Struct.Uint32(0)
+}
+
+func (s DRKeyLvl2Rep) SetTimestamp(v uint32) {
+	s.Struct.SetUint32(0, v)
+}
+
+func (s DRKeyLvl2Rep) Drkey() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return []byte(p.Data()), err
+}
+
+func (s DRKeyLvl2Rep) HasDrkey() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s DRKeyLvl2Rep) SetDrkey(v []byte) error {
+	return s.Struct.SetData(0, v)
+}
+
+func (s DRKeyLvl2Rep) EpochBegin() uint32 {
+	return s.Struct.Uint32(4)
+}
+
+func (s DRKeyLvl2Rep) SetEpochBegin(v uint32) {
+	s.Struct.SetUint32(4, v)
+}
+
+func (s DRKeyLvl2Rep) EpochEnd() uint32 {
+	return s.Struct.Uint32(8)
+}
+
+func (s DRKeyLvl2Rep) SetEpochEnd(v uint32) {
+	s.Struct.SetUint32(8, v)
+}
+
+// DRKeyLvl2Rep_List is a list of DRKeyLvl2Rep.
+type DRKeyLvl2Rep_List struct{ capnp.List }
+
+// NewDRKeyLvl2Rep creates a new list of DRKeyLvl2Rep.
+func NewDRKeyLvl2Rep_List(s *capnp.Segment, sz int32) (DRKeyLvl2Rep_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 16, PointerCount: 1}, sz)
+	return DRKeyLvl2Rep_List{l}, err
+}
+
+func (s DRKeyLvl2Rep_List) At(i int) DRKeyLvl2Rep { return DRKeyLvl2Rep{s.List.Struct(i)} }
+
+func (s DRKeyLvl2Rep_List) Set(i int, v DRKeyLvl2Rep) error { return s.List.SetStruct(i, v.Struct) }
+
+func (s DRKeyLvl2Rep_List) String() string {
+	str, _ := text.MarshalList(0xdfa735fef0302b84, s.List)
+	return str
+}
+
+// DRKeyLvl2Rep_Promise is a wrapper for a DRKeyLvl2Rep promised by a client call.
+type DRKeyLvl2Rep_Promise struct{ *capnp.Pipeline }
+
+func (p DRKeyLvl2Rep_Promise) Struct() (DRKeyLvl2Rep, error) {
+	s, err := p.Pipeline.Struct()
+	return DRKeyLvl2Rep{s}, err
+}
+
 type DRKeyMgmt struct{ capnp.Struct }
 type DRKeyMgmt_Which uint16
 
 const (
-	DRKeyMgmt_Which_unset    DRKeyMgmt_Which = 0
-	DRKeyMgmt_Which_drkeyReq DRKeyMgmt_Which = 1
-	DRKeyMgmt_Which_drkeyRep DRKeyMgmt_Which = 2
+	DRKeyMgmt_Which_unset        DRKeyMgmt_Which = 0
+	DRKeyMgmt_Which_drkeyReq     DRKeyMgmt_Which = 1
+	DRKeyMgmt_Which_drkeyRep     DRKeyMgmt_Which = 2
+	DRKeyMgmt_Which_drkeyLvl2Req DRKeyMgmt_Which = 3
+	DRKeyMgmt_Which_drkeyLvl2Rep DRKeyMgmt_Which = 4
 )
 
 func (w DRKeyMgmt_Which) String() string {
-	const s = "unsetdrkeyReqdrkeyRep"
+	const s = "unsetdrkeyReqdrkeyRepdrkeyLvl2ReqdrkeyLvl2Rep"
 	switch w {
 	case DRKeyMgmt_Which_unset:
 		return s[0:5]
@@ -272,6 +618,10 @@ func (w DRKeyMgmt_Which) String() string {
 		return s[5:13]
 	case DRKeyMgmt_Which_drkeyRep:
 		return s[13:21]
+	case DRKeyMgmt_Which_drkeyLvl2Req:
+		return s[21:33]
+	case DRKeyMgmt_Which_drkeyLvl2Rep:
+		return s[33:45]
 
 	}
 	return "DRKeyMgmt_Which(" + strconv.FormatUint(uint64(w), 10) + ")"
@@ -374,6 +724,72 @@ func (s DRKeyMgmt) NewDrkeyRep() (DRKeyRep, error) {
 	return ss, err
 }
 
+func (s DRKeyMgmt) DrkeyLvl2Req() (DRKeyLvl2Req, error) {
+	if s.Struct.Uint16(0) != 3 {
+		panic("Which() != drkeyLvl2Req")
+	}
+	p, err := s.Struct.Ptr(0)
+	return DRKeyLvl2Req{Struct: p.Struct()}, err
+}
+
+func (s DRKeyMgmt) HasDrkeyLvl2Req() bool {
+	if s.Struct.Uint16(0) != 3 {
+		return false
+	}
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s DRKeyMgmt) SetDrkeyLvl2Req(v DRKeyLvl2Req) error {
+	s.Struct.SetUint16(0, 3)
+	return s.Struct.SetPtr(0, v.Struct.ToPtr())
+}
+
+// NewDrkeyLvl2Req sets the drkeyLvl2Req field to a newly
+// allocated DRKeyLvl2Req struct, preferring placement in s's segment.
+func (s DRKeyMgmt) NewDrkeyLvl2Req() (DRKeyLvl2Req, error) {
+	s.Struct.SetUint16(0, 3)
+	ss, err := NewDRKeyLvl2Req(s.Struct.Segment())
+	if err != nil {
+		return DRKeyLvl2Req{}, err
+	}
+	err = s.Struct.SetPtr(0, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s DRKeyMgmt) DrkeyLvl2Rep() (DRKeyLvl2Rep, error) {
+	if s.Struct.Uint16(0) != 4 {
+		panic("Which() != drkeyLvl2Rep")
+	}
+	p, err := s.Struct.Ptr(0)
+	return DRKeyLvl2Rep{Struct: p.Struct()}, err
+}
+
+func (s DRKeyMgmt) HasDrkeyLvl2Rep() bool {
+	if s.Struct.Uint16(0) != 4 {
+		return false
+	}
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s DRKeyMgmt) SetDrkeyLvl2Rep(v DRKeyLvl2Rep) error {
+	s.Struct.SetUint16(0, 4)
+	return s.Struct.SetPtr(0, v.Struct.ToPtr())
+}
+
+// NewDrkeyLvl2Rep sets the drkeyLvl2Rep field to a newly
+// allocated DRKeyLvl2Rep struct, preferring placement in s's segment.
+func (s DRKeyMgmt) NewDrkeyLvl2Rep() (DRKeyLvl2Rep, error) {
+	s.Struct.SetUint16(0, 4)
+	ss, err := NewDRKeyLvl2Rep(s.Struct.Segment())
+	if err != nil {
+		return DRKeyLvl2Rep{}, err
+	}
+	err = s.Struct.SetPtr(0, ss.Struct.ToPtr())
+	return ss, err
+}
+
 // DRKeyMgmt_List is a list of DRKeyMgmt.
 type DRKeyMgmt_List struct{ capnp.List }
 
@@ -408,51 +824,97 @@ func (p DRKeyMgmt_Promise) DrkeyRep() DRKeyRep_Promise {
 	return DRKeyRep_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
 }
 
-const schema_f85d2602085656c1 = "x\xda\x84\x92OHT]\x18\xc6\xdf\xe7}\xef\x9dQ" +
-	"p`\x0e3\x1f\xc8\xc7\xf7a\x9b\x846\x92\xbas\xa3" +
-	"\x84B\x7f\x0c<\xa3(D\x12\xc3x\x1c\x87\x1a\xb9\xde" +
-	"{\x85\xdc$\xb5k\xd1\xaeM\x90d\x90\xe0\xa2(\xa1" +
-	"\xa8E-\xa2E\x10m\x1a*,\x12\x12\x94Z$\x15" +
-	"\x14\x08\xa17\x8eW\xc7!\x94v3\xcfy\xce{\x9f" +
-	"\xe7\xfc\xde\xc3C\xe8\xe2V\xb7\x08\"\x9dv\x13\xd1\xd7" +
-	"O\xbd3\xffU\xfan\x90V\x90\xe8\xe9\xe0`\x1d7" +
-	"\x0f\xaf\x93\x8b$\x91\xbaVQsI\xa2\xd6\x9bC " +
-	"D/\xbeg~\xbd}\xf8d\xc1Z\xb1k\xedAR" +
-	"\x882\xef\xf0>\xb3joe\x96q\x97\x10\x8d\xfc?" +
-	"vi\xe9\xe0\xe63\xebvj\x06\xb3\xb5\\\xe4J\xe6" +
-	"\x8a\xfd\xd5~\x99\x9f\xdb\xd9\x8f\x9b\x0f\x05\x17\xd6\xe6+" +
-	"\xa4\xff\x85\xec\x86\xfa\x07I\x10\xb5\x0f;\x0cB&\xef" +
-	"t\xda\xc1\xfeY3u\xa6\\\xe4r\xd8R\xc8{\xe3" +
-	"^Gw\xee\x84\x99\xca\x19L\xf4\x01\xbaQ\x1c\"\x07" +
-	"6}\x1b\x91\xbe*\xd0\xb3\x0c\x05\xce\xc2\x8a39\"" +
-	"}]\xa0\xe7\x19Vc\"5g\xb5[\x02}\x8f\xa1" +
-	"D\xb2\x10\"u\xe7\x08\x91\x9e\x17\xe8\xfb\x0c\xe58Y" +
-	"8Dj\xa1\x83H\xdf\x16\xe8G\x0c\xb8\xa8\xc9\xad\x1e" +
-	"\xb4\x117\x95\x82\x91|\x80zb\xd4\x13\xa2\xb0T6" +
-	"A\x98/\x13<\xd4\x11\xa3\x8e\x10\x05\xa5\xe2x>\x9c" +
-	"\xf4\x09\x06)b\xa4\x08\xd3\x05\xe3\x87\x83\xc6\xdf\xf1t" +
-	"\x86~\xa1\xe6o\xd3\xe8\xb9|1\xd8\xaf\xf6\xc9\xa2\x94" +
-	"C\xdb\xbbA\x9c\x86(\xda*\xdec\x8bw\x09t/" +
-	"#\x85\xcd(n~\xec8\x91>*\xd0\x03\x8c\x14o" +
-	"Dqwm\xd5>\x81>\xcdh\x9a\x1c\x0fLH\x89" +
-	"\xf8[93ADH\xef\xd2  \xbd\x03 g\xbc" +
-	"\xf8\xb4\xca\xb9\xf6tO<\x9e\x8dy\xa0\x8a\xe7\x95M" +
-	"\xf9R\xa0\x17k\xf0\xbc\xb1(^\x0b\xf4G\x86b\x89" +
-	"3.Y\x14\x8b\x02\xbd\xc2\xc06\x9eeK\xe2\x83@" +
-	"\x7f\xb6x\x10\xe3Y\xb5\xb7W\x04\xfa\x1bC\xb9N\x16" +
-	".\x91Z;E\xa4\xbf\x08\xf4:C%\xdc,\x12D" +
-	"\xea\xa7\x15\x7f\x08r`\xa8d\"\xbb\xb5\xee\x1bv\xe6" +
-	"\xba\xa0\xdf\x01\xe3\xef,\xa7\xcdyo\xa0T6Un" +
-	"\x85\x927f\xfc\x1d\xac{\xa1\x8e\xb6Q\xf7\x93\xf8\x85" +
-	"\xeaNl\x8b\xdd$A\xb8\xcf\x12T\x9fU\xfe|\xd6" +
-	"\x89\x96\xad\xfd \xed\x88\x93\x9e\x8d+\xa7,\xd4\x06\x81" +
-	"ndD\x9eoFMX\x18\xb3\xb8@\x0c\x10~\x07" +
-	"\x00\x00\xff\xff\x9fS\xf7\xb5"
+func (p DRKeyMgmt_Promise) DrkeyLvl2Req() DRKeyLvl2Req_Promise {
+	return DRKeyLvl2Req_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
+}
+
+func (p DRKeyMgmt_Promise) DrkeyLvl2Rep() DRKeyLvl2Rep_Promise {
+	return DRKeyLvl2Rep_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
+}
+
+const schema_f85d2602085656c1 = "x\xda\x8cV]h\\E\x14>\xdf\xcc\xdd=\x1bh" +
+	"h\x86\xd9\x80H\xc2Z\xb0\xa5\xa9\xb4$\x1bE\x0c\x95" +
+	"\xc6\xa5\x954V\xc8\xec\xd6\x8a\xa1E\x96\xdd\xdbdk" +
+	"\xf6'\xbb\xb7\xb5\x01kPZ\xa8O\xfe\xe0\x83\xc1\x06" +
+	"\x15Z\xf4\xa5\xd0>H\xadT0X\xc1\x88\x88\x0d\"" +
+	"m\xb1hA)>\x88M\xe9\x83P\xdb+\xb3\xf7f" +
+	"\xb3\x89M\xeb\xc3y9\xdf\x99{\xe6|\xf3}g\xb7" +
+	"\xfb{\xd1/z\"eAd:\"Q\xff\xcc\x943" +
+	"\xbc\xf1\xe9\xf7\xdf!\xa3\x00\x7ff\xd7\xae\x98X\xb7\xe7" +
+	"o\x8a\x80\x89t'.\xeb.p\x18/\x13\xe9\x13`" +
+	"\xff\xafk;\xa6;\xe6\x86>\xb0G\xe4\xf2#oc" +
+	"NO\x83m\xf4N\xe3y\x10\xe9\x88d\xff\xdby}" +
+	"\xeb\xa73_\x9c^\xd6f\x1b8B\xa4\xe7\xc5e}" +
+	"[\xb0\x8d\xde\xdb\"a\x0f\x15\x1c\xf6\xf3\x9d\xa3\xaf_" +
+	"Y{\xe7+{\xc8ij$m\xa3\xe7\x9c9\x9du" +
+	"\xd8Fo\xd6y\\\xd8\xcfD\xd9?\xb7\xae\xab\xf6\xea" +
+	"\x9f\x9f\xcc\x91y\x10r\xf1\xaa\xed`\x10\xf5^\x89\x0a" +
+	"\xe8?\xa2\x1c\xc6\x16\"\xdd\xc5\xec\x1f~\xa4\xfb\xfa\x9d" +
+	"\xc7>\xfe\xc56\x12\xcb'j\xe7\x1bz\x0ds\x18\xd7" +
+	"\xec\x91\x18\xfb\xef\xb6:7?\x1f8\xfe\xfbr\x12\xea" +
+	"wk\x8f\xdd\xd0kbl\xa3wM\xecM;\xcf|" +
+	"\x0b\xfb\xf9\xeaK\xee\xc4\x8b\xc5\x11Q\xf46\xe5\xb2\x95" +
+	"R\xa5ok\xfa\x19wb\xa0,k\xde\x100\x04a" +
+	"b\xd2!r@\xa4\xba6\xa8.6\xeb%\xcc\xa3\x02" +
+	"@\x1c6\xd9\xb3A\xf5\xb0\xe9\x960\x9b\x05V{\x13" +
+	"\x15w\x08\x02Q\xb2\x81\xd5\xa3e\xfb\x1d\x81V\xb2\x81" +
+	"~\xac\xd41\xedb<l\xf8@\xa3\xe1TRM\xb1" +
+	"yO\xc2\x1c\x17P\x10A\xc7\x8f\xd2\xea\x04\x9b\xe3\x12" +
+	"\xe6\x94\x80M\x0a\"u2\xadN\xb39%a\xce\x09" +
+	"()\xe3\x90D\xealJ\x9de\xf3\x99\x849/\xa0" +
+	"\x1c'\x0e\x87H\xcd\xf4\xa9\x196_J\x98\xef\x04\x10" +
+	"\x01\x16\x9fH\xcd&\xd5,\x13\x12\x85Z>[\xb37" +
+	"o!\x1b\xf0\xbdB\xd1\xady\xd9\"\xa1b\xd31\xb2" +
+	"\x01\xbfV\x18)e\xbd\xfdU\x82\xdb4\xe7d\xce\xad" +
+	"z\xbb\xdcjS\xe5\x16\xaf\x9a[\x9aI\xec\x1d\xcb\x8e" +
+	"\xd8\x1e+\xb3\xf2\xec\x88,.\xbcC\\:\xab|\xbf" +
+	"\xce\xcb\xa1\xa4:\xc4\xe6\x15\x09sT\xa0\x15w\xfc\x80" +
+	"\x98#\x83\xea\x0d6G%\xcc1\x81Vq\xdb\x0f\xa8" +
+	"\x99\x1aT\xd3l\x8e\x05|\xb5\xca\x7f\xfc\x80\x9b\x93\xfb" +
+	"\x16\x18;/\xd0\xea\xdc\xf2Cr\xf6\xa9\xaf\xd9\x9c\x97" +
+	"0\x17\x05\x12\xfbK5\xd7> E\x83+\xa6\xddq" +
+	"\"\xb2C\xb4-*\x99\xa8\x1f\x0a<$\x806\xc2B" +
+	"a\xa5Q\xd80\xcd\xdd\x0aw\x1c\x18\xa3\xd5\xc9\xb4;" +
+	"\x1e\xd46D|\xaf\xdaJP\xdb\xf0\xc8\xd2\xda{\x8a" +
+	"\xac\x12\xb2\xb9~Ad\xba\x05I\xdd\x02\xce\xc4 \x91" +
+	"\x89cQhZ!\xad\xdb\xc1\x99\xb8E\x1e\xb2\x88\x90" +
+	"uJu'R\xba\x13\x9c\xe9\xb0\xc8z\x08 \x10\x9c" +
+	"^\x8b>\xbd\x16\x9cy\xd8\x02\xdd\xf6\x88\x83:\xafz" +
+	"#\xd2\xba\x07\x9c\xe9\xb6\xc8f\x8bD\x9c8\xec\xaay" +
+	"\x02\xc3\xfaIpf\xb3E\x06,\x12\x8d\xc4\x11%\xd2" +
+	"\xdb0\xac\xb7\x833\x03\x16\xd9i\x11\x8e\xc6\xeb\x1b\xc0" +
+	"\xa0O\x1bpf\xc8\"\xbb-\x12\xe38bD\xfa\x05" +
+	"\x0c\xeb=\xe0\xccn\x8b\x8cZ\xa4E\xc4\xd1B\xa4]" +
+	"$\xb5\x0b\xce\xe4-R\x81\xf8\xdf\"\x9ft\x0fVv" +
+	"\x16\x8an\xb3\x9as\x85\xca\xa8[m\x92\xfc\x0aN\xf0" +
+	"C'dHVs\xcd\xc6\x09\xf3[I\xd6\xbc\xa6\xfc" +
+	"\x7fm\xe2\xbb\x95rn4\xe5\x8e\x90,\x94\x9a\xedS" +
+	"*\x97r\xee\xdd\x97\x8b\\\xfe\xee\xe3\x9b\xeaf\xa3\xf0" +
+	"\xf5\x1d\xe9\xb4}Xg_\xb5\x0e*\xc5\xa6M\xc2t" +
+	"\x08\xf8\x95\xaa\xbb\xd7\xf5r\xa3\xa1vA6\xee\xa1\xa8" +
+	"\x1d\x07\x12c\x81 \xeb\xdfmk\xac\xaelZ\xb9l" +
+	"\xf2\x12\xa6\xb2\xb8+\x8bIUd3&a\x0eZ1" +
+	"!\xf0\xe7\xfea5\xc1\xe6\xa0\x849lW\x97\x08\xec" +
+	"\xf9\xda\xa0:\xc2\xe6\xb0\x84yK\xac\xf40\x89\xfa\xad" +
+	"\x9a\xd9^\x81\xab \xbf\xad\x94\x0f\xe7\x0a\xd3\xf7\x9dk" +
+	"a%w4\xe6\xfat\xb0i\xa3.\xcc5\x93jZ" +
+	"\xa8\x8d\xb9fSj\x96\xcd7\x12\xe6G;\x17\x82\xb9" +
+	".$\xd5\x056?H\x98\x9f\xad;D\xb0u.%" +
+	"\xd5%6\x17%\xcco\xd6\x19\x08\xde\xe6jJ]e" +
+	"\xf3\xab\x84\xb9i]!\xea\xaeP\xf3)5\xcf\xe6\xba" +
+	"D\xc6A\xfd\xc9\xca^9W\x1e\x0bG[E60" +
+	"Yu\xc7w.\xfd1\x9a<\x90\x1d[&\xe3D\xad" +
+	"\x9a\xdb\xfeT\x93\x03\x12\xf9\x9a\xb7$1Y\xab\xe6\x06" +
+	"\xc2\x1f\xb1\xb6\xc5\xbf&K7\xced\xbe\xe6\xdd\xb7\xa8" +
+	"\x1f\xff\x0e\x00\x11\xbe\xedR"
 
 func init() {
 	schemas.Register(schema_f85d2602085656c1,
+		0x929b462d5a0499b7,
 		0x9f50d21c9d4ce7ef,
 		0xb1bdb7d6fb13f1ca,
 		0xc3fe25dd82681d64,
-		0xd2a8ed7e732926bc)
+		0xd2a8ed7e732926bc,
+		0xdfa735fef0302b84,
+		0xe5a448baf4040d94)
 }
@@ -174,12 +174,12 @@ type ASEntry_exts ASEntry
 const ASEntry_TypeID = 0xd4a209e8e78874ff
 
 func NewASEntry(s *capnp.Segment) (ASEntry, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 4})
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 5})
 	return ASEntry{st}, err
 }
 
 func NewRootASEntry(s *capnp.Segment) (ASEntry, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 4})
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 5})
 	return ASEntry{st}, err
 }
 
@@ -335,12 +335,37 @@ func (s ASEntry_exts) NewHiddenPathSeg() (HiddenPathSegExtn, error) {
 	return ss, err
 }
 
+func (s ASEntry_exts) StaticInfo() (StaticInfoExtn, error) {
+	p, err := s.Struct.Ptr(4)
+	return StaticInfoExtn{Struct: p.Struct()}, err
+}
+
+func (s ASEntry_exts) HasStaticInfo() bool {
+	p, err := s.Struct.Ptr(4)
+	return p.IsValid() || err != nil
+}
+
+func (s ASEntry_exts) SetStaticInfo(v StaticInfoExtn) error {
+	return s.Struct.SetPtr(4, v.Struct.ToPtr())
+}
+
+// NewStaticInfo sets the staticInfo field to a newly
+// allocated StaticInfoExtn struct, preferring placement in s's segment.
+func (s ASEntry_exts) NewStaticInfo() (StaticInfoExtn, error) {
+	ss, err := NewStaticInfoExtn(s.Struct.Segment())
+	if err != nil {
+		return StaticInfoExtn{}, err
+	}
+	err = s.Struct.SetPtr(4, ss.Struct.ToPtr())
+	return ss, err
+}
+
 // ASEntry_List is a list of ASEntry.
 type ASEntry_List struct{ capnp.List }
 
 // NewASEntry creates a new list of ASEntry.
 func NewASEntry_List(s *capnp.Segment, sz int32) (ASEntry_List, error) {
-	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 32, PointerCount: 4}, sz)
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 32, PointerCount: 5}, sz)
 	return ASEntry_List{l}, err
 }
 
@@ -383,6 +408,10 @@ func (p ASEntry_exts_Promise) HiddenPathSeg() HiddenPathSegExtn_Promise {
 	return HiddenPathSegExtn_Promise{Pipeline: p.Pipeline.GetPipeline(3)}
 }
 
+func (p ASEntry_exts_Promise) StaticInfo() StaticInfoExtn_Promise {
+	return StaticInfoExtn_Promise{Pipeline: p.Pipeline.GetPipeline(4)}
+}
+
 type HopEntry struct{ capnp.Struct }
 
 // HopEntry_TypeID is the unique identifier for the type HopEntry.
@@ -729,66 +758,74 @@ func (l PathSegType_List) Set(i int, v PathSegType) {
 	ul.Set(i, uint16(v))
 }
 
-const schema_fb8053d9fb34b837 = "x\xda\xacT_h\x1c\xd5\x17>\xdf=\xb3\xbbI~" +
-	"\xbf8{\xd9\x95\xa6\"\xac\x15\x05]\xb5\xd4\xb4\x92R" +
-	"\x84\xd8\x9a\xb6\x89\x10\xdc\xdb\xc4JE\xd1\xe9\xee4\x19" +
-	"0\xb3\xc3\xce]\xda\x8d\x94\xd6\x87\xf8\x07\x15\x1f\x14\xb1" +
-	"\xa5%mQ0\xd0\xd0\x8a-\xa4A!-\x85\"\x08" +
-	"\xbe\x88h\xa0`\xb5E\x0b\xbe\xf9 m\xa9#w7" +
-	"\xb3\xbb$\xed\x9bow\x0e\xe7~\xe7|\xdf\xfd\xbeY" +
-	"w\x03\xcf\x88'\x13\x97\x04\x91z \x91\x8c\xfe\xf7\xd8" +
-	"\xdbgN]\xfe\xf2=R6\x12Q\xdf\xdc\x86[?" +
-	"\x8f\x1c\xbcE\x09\xa4\x882C\xb8\x90Q\xe6\xb4~\x18" +
-	"/\x82\x10\xdd\x0c\xe6\xdf:\xbcp\xe4#\x926\xda\x9a" +
-	"\x85i>.\x163\xb3\xf5\xd3\x8c\xd8K\x88\xec\xf9\xae" +
-	"\xa7\xdf\xd8\xf9\xe9\xb4A\xc6r\xe4\xd5\xbc\x98y\x98\xcd" +
-	"i\x0d\x9b\xe6\x83\x1bOt\xb93\x7f\x1f'i\x8bV" +
-	"/!\xb3\x9f\x173\xef\xd6\x1b\xa7x\x8c\x10E\xd3S" +
-	"G\xff\xe9\xd2\x17H\xad\x82\x15E\xfa\x9d\xdf\xff\xe8<" +
-	"\xf1\x03\xddk\xa5`&\xf3\xaf\x84\xcc,\x9f\"D\xf9" +
-	"?'W\xf5L~\x7fi\xd9\xae\x0d:V\x1e\x99W" +
-	",\x03\xbb\xcb\xea7\xb01\x90\xb2a\xb5u\xd7[j" +
-	"\xd6|\xe6MsZ\xbf\xdf\xfa\xd0\xc8pM\xd5\xfa\x8a" +
-	"\xdb\x17~\xbc#\xb5\xdb\x89\xc3\x99D\xd2\x9c\x904\xd4" +
-	"\x02G\x8f\xbf\x1a\xbacbm\xd1\x09\xfc`\xd3`9" +
-	"\xd8\xea\xebJ\x8d\x0a\x80\xeaa\x8b\xc8\x02\x91<\x94'" +
-	"R\x1f3\xd41\x01\x09da\x8aG_\"RG\x18" +
-	"\xea\x0b\x01):\xb2\x10D\xf2\xf3^\"u\x8c\xa1N" +
-	"\x0aH\xe6,\x98H\xce\x98\xe2g\x0cuZ@ZV" +
-	"\x16\x16\x91\x9c\xddM\xa4N2\xd4\x9c\x00\x12Y$\x88" +
-	"\xe4Y3\xe74C}-`{\xfe\xd0ft\x92@" +
-	"'!\xaa\xb8\x13e\xed\x0e\xf9\xc4C\xdb\xe2b\xce\xf3" +
-	"\x87G_@\x8a\x04R\x84\\\xb9\xaaW\\x\xbeJ" +
-	")\xdd\xbaa\x8f\x97\x83m\xe8&\x81\xee;\x90/8" +
-	"z|\xc4\x1d\x9bp\xd9\xd7\x86\x7fG\x93\xff\xa3\x86\xc0" +
-	"C\x0c\xb5\xae\x8d\xff\x13;\x88\xd4\xe3\x0c5(\x90\x0b" +
-	"K\x8ev\x9a\xc8NhT\xf4\\B\x88{\x08\x05\x06" +
-	"\xd2\xd1\x8e\xdfn\xf6Mm\xef\x9d&\x82)\xdem\xfc" +
-	"\xb0\xcb\xdaY6~Kk|s\xba\x91\xea\x11\x86\xda" +
-	" p h\\E\xba\x15\x02\x02\xd2\x04[\xd7\x02\x17" +
-	"v\xcb\xc2\x04\xd8w\x9f=Z\xe3\xc05\xb3\xd3\xf5\xc7" +
-	"4\xb4\x01\xb9\xe6>\"\x08\xb9:O\x04\x962O\x94" +
-	"\xab\xfa\xa1\xab\xb9\x1a\xd8\xa5\xf2^\xdf.\x96+\xee\x0a" +
-	"\xc8\xcd#u'\xaduS\xfbt\xa8\xfe\xcfV\x1aY" +
-	"$\x89\xe4\xd6\x0a\x91\x1a`\xa8\x82\x11Sd\x8d1\xe5" +
-	"\xb0Qx\x90\xa1F\x8d\x998\x8b\x0e\"\xa9Lg\x81" +
-	"\xa1^\x16\x88*\xe5\xaa\xf6\xfc\xb1\x02\xe5\xca\xaf{\xc5" +
-	"\x1a\xd2\xd1\x8d\xabOm\x9c[<\xff\xc9\x12\xd7\\\xe8" +
-	"\xed\xae8HG\x1f\xbcv}\xd7\xb9\xeb\xb7\xe7\x96\xea" +
-	"\xd1\xb8W*\xb9~\xc1\xa1\\\xac\xd2_\xef_\xbbr" +
-	"\xe8\xabZ\x14w\xc4\xbb\xf3r'\xf8z\xc4\x1b\xf3\xdd" +
-	"\x92=\xe04\x1e\xc5j>J\xb7\xd9\xb8\x83\xa1\xb2\xc2" +
-	"8qOy\xa5\xb1\x10K\xd1\xdf\xd0\xc2\x00\xdc\xdf\x04" +
-	"8\xdb\xdb2{\xd3T\xe76\x11\xa93\x0c\xb5`t" +
-	"\x10\x8dP}c\xde\x7f\x8e\xa1.\x9aP\xf54Bu" +
-	"\xfe9\"\xb5\xc0P\xdf\x09`)S\xdf\x1aS\\d" +
-	"\xa8_\x04d\xa2\xbb\x11\xaa\xcb\x0f\x12\xa9\x9f\x18\xea\xaa" +
-	"\x00\x92h\xfbM\xc9+y\x129/,9a\x9c\x90" +
-	"~])\xeet+\xf1\xe7\x81\xa2[\xd1m\xdf\x91\xb7" +
-	"gh`\xc4\x9bt\x89\x08I\x12H6B\xd5f\xf3" +
-	"\xe6O\xbba\xf3\xd4\x84\xae\xc6\x11\xb5\xdd}:\\\xa1" +
-	"N\x81\x9f\xdd\xf2\x9f\xf9\xdd\xec\x17/\xfbo\x00\x00\x00" +
-	"\xff\xff*<\x8e\xd4"
+const schema_fb8053d9fb34b837 = "x\xda\x94U_h\x1c\xd5\x17>\xe7\xde\x99\x9c\xb6\xbf" +
+	"_:\xb9\xccJ\xa3X\xd6\x0a\x01\xb3\xd2b7\x89I" +
+	"J!i\x8c\xb6+\x06\xe7vk\xab\x05\xffLw'" +
+	"\xc9\x80\x99]wgi7>\xb4/\xd1\x88\x8a\x0f\x8a" +
+	"4%%\xb4\xd4\x07\xc1\xa0b\x0b1\x18H\x8a\xa5-" +
+	"X\xac\xd0\x8a\x89\xfaP\xb5\xd4\x80o>hZu\xe4" +
+	"fw\x93\xc96\x16}\xf8\x089\x9c3\xe7\x9c\xef~" +
+	"\xdf\xd9\x87v\xb1N\xb6U?\xcf\x00\xe4}zM\xf0" +
+	"\xbf\x07_=\xfd\xe1\xf7\x1f\xbf\x0e\xd2@=h\x9dh" +
+	"\xbe5\x9b<r\x0bt$\x003\x81gM\x89\xa4\xd0" +
+	"$q\x1f\x02\x98W\x19\x057\xb3\x93\xaf\x1c\x9b\x1e}" +
+	"\x1b\x84\x81\xa1\x12\xa6Jf\xd8\x9cy\x89Q\x19\x07\x01" +
+	"L\x87S`L\xae\xdb\xfe\xf2\xde\xa3c\xaa\x0bVw" +
+	"\x91|\xce|\x96S\x19\xaad\x9eSp\xa4\xed\xe4:" +
+	"\xe7\xfd\xdfN\x800\xd8r\x05\xa0y\x95\xcf\x99\xd78" +
+	"\x95\xd1\x07`n\xd6(\x08\xc6\x86\x8e\xff\xb5\xce?\x0b" +
+	"r\x03jA\xe0\x0f\xdf\xf8y\xed\xc9+p\x97Nj" +
+	"\xea\xbb\xb5\x1f\xcc\x06\x8d\xca\xb8\x01`\xf6\xe8\x14\xc4~" +
+	"\x19\xdcP?\xf8\xe5\xf9\xaa=\xd4PM\xedz\x0c\xcd" +
+	"\x84Net\x00\x98C:-\x7fX\x1a\xa8\x85jt" +
+	"\xb5\xc8K\xfa\xa4Y\xd4I\xa1\xa9\xa8\xbf\xa5\x1a\xb7\x10" +
+	"\x05\xd7e\xb15\xb5s\xfa\xebU\x97\xdfD\xc7\xccF" +
+	"\xa22\xd4\xf2\xe3DA\xd6\xf6\xfb\x9f\xcf;}lK" +
+	"\xca\xcez\xd9m\xbb2\xd9G=?W\x04\x0b\xd1B" +
+	"&\xeb\xb9\x06\xa0!\x80\x18\x89\x89\x11\x92G9\xcaS" +
+	"\x0c\x05b\x04U\xf4\xc4~\xf1\x1e\xc9S\x1c\xe5G\x0c" +
+	"\x05[\x13A\x06 \xc6\xe3b\x9c\xe4\x07\x1c\xe5\x04C" +
+	"\xc1y\x049\x808\x13\x17gH\x9e\xe6(\xa7\x19\x0a" +
+	"M\x8b\xa0\x06 \xa6\x0e\x88\x19\x92\xd3\x1c\xe5\x17\x0cQ" +
+	"\x8f\xa0\x0e .\xc6\xc4E\x92\x178\xca+\x0c\x0d\xd7" +
+	"K\xec\xb0\x90\xe1ZP\xc0 \xe7\x0cd|'\xe1\x01" +
+	"O<\x16\x8aG]\xafg\xcfS*@\xa0\x80\xd1L" +
+	"\xc1_\xad\xf2\xc9\x02\x90\xbf\xa2\xd4\xe8\xcfd\x17\xff\xaf" +
+	"\x05\x05\xec\xc4\xdb\x98\xb1l\xbf?\xe9\xf4\x0d8\xdc\xf3" +
+	"\xcb\xe4\xacY\"\xa71.\x1aI>\xc0Q6\x87\xc8" +
+	"\xd9\xba[\xb4\x90l\xe6(\x9ff\x18\xcd\xa7m\xdf\x0e" +
+	"\xf5\x08\xec\xbc\xa2\xdau\x00\xf3*\xbc\x1e\xd0\xe2\x88u" +
+	"\xc1\xee\x1fo\xb6\x0e\xed\x8c\x8f\x01@'\x0a$\x8b!" +
+	"\xae\xbf\xd3L=\x0e\xf7\xed\xdbg\xea\xaa\xcc\xb4\x9da" +
+	"e\xa4\xf6\x98h'\xd9\xc6Q>\xc1\xf0p\xb6T\xaf" +
+	"\xba\xd7-\x9b-\xd4\xb6\x0e\xd0\xf0\x8bYGe\x18\xcb" +
+	"N\x09e\x18w\x1alO\x91g\x9d\xf2`u\xc8*" +
+	"D!\x8a\x86{D\x03!\x13\x9bbb\x13!\x17\x1b" +
+	"cb#E\x0b^\xde\xf1-d\xbc\x90\xb5\x90\x19\xe9" +
+	"\xccAO\xfdMerj\x82U\xfa\xecH.\xaau" +
+	"\x8bC\x87\xfc|\xa5\x11\xd7\xea0\x825\x00\xc2\xce\x09" +
+	"\x87d\x9a\xa3<\xa4\x9e\x85E\x90\x00D!.\x0a$" +
+	"}\x8erXi\x96Gp\x0d\x80\x18\xca\x89\xd7H\x0e" +
+	"s\x94\xa3J\xb3Z\x04\xd7*\xd5\xef\x17\xc7I\x8e\x96" +
+	"\xf4\x1d\xe42\x05\xdf\xf5\xfa,\x88f^tS\xc5\x12" +
+	"o\x0b?\xb5\xb4M\xcc\xcd\xbc[\xc5[4\xef\x1e\xc8" +
+	"\xd9\xa5\x947_\x98\x7f\xe6\xd3\xf9?'\xaaR\x82~" +
+	"7\x9dv<\xcb\x86h\xe8\x1d~}\xe3\xfa\xb5\x91O" +
+	"\x8aAur\xde\xb7}7\xa5T\xdf\x9b)e\xee\xdb" +
+	"\xec\xbc\xf3\xf0s\xd6\xb7U\x99!\x9ex\xb5x=?" +
+	"\xe9\xf6yN\xda\xe8\xb6\x97$\xa3-I\xa66.j" +
+	"I\xfe\x9f\xa3\xacg\xcaN\xbd\x99\x7f0\x05V\xf8\xef" +
+	"(=@\xf9K\xf7.}i\xa5\xd7+\xea\x9b\xda&" +
+	"\xa6H~\xc6Q^P\xcc\xb3\xd2\xb58\xd7%\xce\x91" +
+	"\xfc\x9c\xa3\xbc\xac\x98\xaf/]\x8bK\x8f\x8b\xafH^" +
+	"\xe6(\xbfc\x88\xe5c1\x1b\x13\xb3$\xbf\xe1(\xff" +
+	"`(\xf4\xda\xd2\xb5X\xb8_,\x90\xfc\x9dcRC" +
+	"\x86X\x83\xb8|\xa5M\xc4\x98\x89\x8b\xd7\xc0\xcd\xa7\xed" +
+	"|\xc8\xf4\x1d~.\xb5\xd7\xc9\x85\"\x87SN\xce_" +
+	"\x19\x0a\xdc\xdeDw\xd2\x1dt\x00@\x85k@a\xf1" +
+	"`\xact\xed\xd2\xef\xdbJ\xd7\xd2\x80_\x08\x9d$\xc3" +
+	"Y\x14*[\x85K\x8b?\xd2\xf5_M\xdc\xfdoM" +
+	"\xac\xb6\x08m\xd5\x89\x7f\x0f\x00\xc2\xce\xca\xa8"
 
 func init() {
 	schemas.Register(schema_fb8053d9fb34b837,
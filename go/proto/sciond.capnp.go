@@ -3,6 +3,7 @@
 package proto
 
 import (
+	math "math"
 	strconv "strconv"
 	capnp "zombiezen.com/go/capnproto2"
 	text "zombiezen.com/go/capnproto2/encoding/text"
@@ -26,10 +27,12 @@ const (
 	SCIONDMsg_Which_revReply           SCIONDMsg_Which = 10
 	SCIONDMsg_Which_segTypeHopReq      SCIONDMsg_Which = 11
 	SCIONDMsg_Which_segTypeHopReply    SCIONDMsg_Which = 12
+	SCIONDMsg_Which_drkeyLvl2Req       SCIONDMsg_Which = 13
+	SCIONDMsg_Which_drkeyLvl2Reply     SCIONDMsg_Which = 14
 )
 
 func (w SCIONDMsg_Which) String() string {
-	const s = "unsetpathReqpathReplyasInfoReqasInfoReplyrevNotificationifInfoRequestifInfoReplyserviceInfoRequestserviceInfoReplyrevReplysegTypeHopReqsegTypeHopReply"
+	const s = "unsetpathReqpathReplyasInfoReqasInfoReplyrevNotificationifInfoRequestifInfoReplyserviceInfoRequestserviceInfoReplyrevReplysegTypeHopReqsegTypeHopReplydrkeyLvl2ReqdrkeyLvl2Reply"
 	switch w {
 	case SCIONDMsg_Which_unset:
 		return s[0:5]
@@ -57,6 +60,10 @@ func (w SCIONDMsg_Which) String() string {
 		return s[122:135]
 	case SCIONDMsg_Which_segTypeHopReply:
 		return s[135:150]
+	case SCIONDMsg_Which_drkeyLvl2Req:
+		return s[150:162]
+	case SCIONDMsg_Which_drkeyLvl2Reply:
+		return s[162:176]
 
 	}
 	return "SCIONDMsg_Which(" + strconv.FormatUint(uint64(w), 10) + ")"
@@ -497,6 +504,72 @@ func (s SCIONDMsg) NewSegTypeHopReply() (SegTypeHopReply, error) {
 	return ss, err
 }
 
+func (s SCIONDMsg) DrkeyLvl2Req() (DRKeyLvl2Req, error) {
+	if s.Struct.Uint16(8) != 13 {
+		panic("Which() != drkeyLvl2Req")
+	}
+	p, err := s.Struct.Ptr(0)
+	return DRKeyLvl2Req{Struct: p.Struct()}, err
+}
+
+func (s SCIONDMsg) HasDrkeyLvl2Req() bool {
+	if s.Struct.Uint16(8) != 13 {
+		return false
+	}
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SCIONDMsg) SetDrkeyLvl2Req(v DRKeyLvl2Req) error {
+	s.Struct.SetUint16(8, 13)
+	return s.Struct.SetPtr(0, v.Struct.ToPtr())
+}
+
+// NewDrkeyLvl2Req sets the drkeyLvl2Req field to a newly
+// allocated DRKeyLvl2Req struct, preferring placement in s's segment.
+func (s SCIONDMsg) NewDrkeyLvl2Req() (DRKeyLvl2Req, error) {
+	s.Struct.SetUint16(8, 13)
+	ss, err := NewDRKeyLvl2Req(s.Struct.Segment())
+	if err != nil {
+		return DRKeyLvl2Req{}, err
+	}
+	err = s.Struct.SetPtr(0, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s SCIONDMsg) DrkeyLvl2Reply() (DRKeyLvl2Rep, error) {
+	if s.Struct.Uint16(8) != 14 {
+		panic("Which() != drkeyLvl2Reply")
+	}
+	p, err := s.Struct.Ptr(0)
+	return DRKeyLvl2Rep{Struct: p.Struct()}, err
+}
+
+func (s SCIONDMsg) HasDrkeyLvl2Reply() bool {
+	if s.Struct.Uint16(8) != 14 {
+		return false
+	}
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SCIONDMsg) SetDrkeyLvl2Reply(v DRKeyLvl2Rep) error {
+	s.Struct.SetUint16(8, 14)
+	return s.Struct.SetPtr(0, v.Struct.ToPtr())
+}
+
+// NewDrkeyLvl2Reply sets the drkeyLvl2Reply field to a newly
+// allocated DRKeyLvl2Rep struct, preferring placement in s's segment.
+func (s SCIONDMsg) NewDrkeyLvl2Reply() (DRKeyLvl2Rep, error) {
+	s.Struct.SetUint16(8, 14)
+	ss, err := NewDRKeyLvl2Rep(s.Struct.Segment())
+	if err != nil {
+		return DRKeyLvl2Rep{}, err
+	}
+	err = s.Struct.SetPtr(0, ss.Struct.ToPtr())
+	return ss, err
+}
+
 func (s SCIONDMsg) TraceId() ([]byte, error) {
 	p, err := s.Struct.Ptr(1)
 	return []byte(p.Data()), err
@@ -585,6 +658,14 @@ func (p SCIONDMsg_Promise) SegTypeHopReply() SegTypeHopReply_Promise {
 	return SegTypeHopReply_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
 }
 
+func (p SCIONDMsg_Promise) DrkeyLvl2Req() DRKeyLvl2Req_Promise {
+	return DRKeyLvl2Req_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
+}
+
+func (p SCIONDMsg_Promise) DrkeyLvl2Reply() DRKeyLvl2Rep_Promise {
+	return DRKeyLvl2Rep_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
+}
+
 type PathReq struct{ capnp.Struct }
 type PathReq_flags PathReq
 
@@ -1013,12 +1094,12 @@ type FwdPathMeta struct{ capnp.Struct }
 const FwdPathMeta_TypeID = 0x8adfcabe5ff9daf4
 
 func NewFwdPathMeta(s *capnp.Segment) (FwdPathMeta, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2})
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 3})
 	return FwdPathMeta{st}, err
 }
 
 func NewRootFwdPathMeta(s *capnp.Segment) (FwdPathMeta, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2})
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 3})
 	return FwdPathMeta{st}, err
 }
 
@@ -1087,12 +1168,37 @@ func (s FwdPathMeta) SetExpTime(v uint32) {
 	s.Struct.SetUint32(4, v)
 }
 
+func (s FwdPathMeta) Metadata() (PathMetadata, error) {
+	p, err := s.Struct.Ptr(2)
+	return PathMetadata{Struct: p.Struct()}, err
+}
+
+func (s FwdPathMeta) HasMetadata() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s FwdPathMeta) SetMetadata(v PathMetadata) error {
+	return s.Struct.SetPtr(2, v.Struct.ToPtr())
+}
+
+// NewMetadata sets the metadata field to a newly
+// allocated PathMetadata struct, preferring placement in s's segment.
+func (s FwdPathMeta) NewMetadata() (PathMetadata, error) {
+	ss, err := NewPathMetadata(s.Struct.Segment())
+	if err != nil {
+		return PathMetadata{}, err
+	}
+	err = s.Struct.SetPtr(2, ss.Struct.ToPtr())
+	return ss, err
+}
+
 // FwdPathMeta_List is a list of FwdPathMeta.
 type FwdPathMeta_List struct{ capnp.List }
 
 // NewFwdPathMeta creates a new list of FwdPathMeta.
 func NewFwdPathMeta_List(s *capnp.Segment, sz int32) (FwdPathMeta_List, error) {
-	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2}, sz)
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 3}, sz)
 	return FwdPathMeta_List{l}, err
 }
 
@@ -1113,6 +1219,297 @@ func (p FwdPathMeta_Promise) Struct() (FwdPathMeta, error) {
 	return FwdPathMeta{s}, err
 }
 
+func (p FwdPathMeta_Promise) Metadata() PathMetadata_Promise {
+	return PathMetadata_Promise{Pipeline: p.Pipeline.GetPipeline(2)}
+}
+
+type PathMetadata struct{ capnp.Struct }
+
+// PathMetadata_TypeID is the unique identifier for the type PathMetadata.
+const PathMetadata_TypeID = 0xa5cff7314a4335e5
+
+func NewPathMetadata(s *capnp.Segment) (PathMetadata, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 6})
+	return PathMetadata{st}, err
+}
+
+func NewRootPathMetadata(s *capnp.Segment) (PathMetadata, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 6})
+	return PathMetadata{st}, err
+}
+
+func ReadRootPathMetadata(msg *capnp.Message) (PathMetadata, error) {
+	root, err := msg.RootPtr()
+	return PathMetadata{root.Struct()}, err
+}
+
+func (s PathMetadata) String() string {
+	str, _ := text.Marshal(0xa5cff7314a4335e5, s.Struct)
+	return str
+}
+
+func (s PathMetadata) Latency() (capnp.Int32List, error) {
+	p, err := s.Struct.Ptr(0)
+	return capnp.Int32List{List: p.List()}, err
+}
+
+func (s PathMetadata) HasLatency() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s PathMetadata) SetLatency(v capnp.Int32List) error {
+	return s.Struct.SetPtr(0, v.List.ToPtr())
+}
+
+// NewLatency sets the latency field to a newly
+// allocated capnp.Int32List, preferring placement in s's segment.
+func (s PathMetadata) NewLatency(n int32) (capnp.Int32List, error) {
+	l, err := capnp.NewInt32List(s.Struct.Segment(), n)
+	if err != nil {
+		return capnp.Int32List{}, err
+	}
+	err = s.Struct.SetPtr(0, l.List.ToPtr())
+	return l, err
+}
+
+func (s PathMetadata) Bandwidth() (capnp.UInt64List, error) {
+	p, err := s.Struct.Ptr(1)
+	return capnp.UInt64List{List: p.List()}, err
+}
+
+func (s PathMetadata) HasBandwidth() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s PathMetadata) SetBandwidth(v capnp.UInt64List) error {
+	return s.Struct.SetPtr(1, v.List.ToPtr())
+}
+
+// NewBandwidth sets the bandwidth field to a newly
+// allocated capnp.UInt64List, preferring placement in s's segment.
+func (s PathMetadata) NewBandwidth(n int32) (capnp.UInt64List, error) {
+	l, err := capnp.NewUInt64List(s.Struct.Segment(), n)
+	if err != nil {
+		return capnp.UInt64List{}, err
+	}
+	err = s.Struct.SetPtr(1, l.List.ToPtr())
+	return l, err
+}
+
+func (s PathMetadata) Geo() (GeoCoordinates_List, error) {
+	p, err := s.Struct.Ptr(2)
+	return GeoCoordinates_List{List: p.List()}, err
+}
+
+func (s PathMetadata) HasGeo() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s PathMetadata) SetGeo(v GeoCoordinates_List) error {
+	return s.Struct.SetPtr(2, v.List.ToPtr())
+}
+
+// NewGeo sets the geo field to a newly
+// allocated GeoCoordinates_List, preferring placement in s's segment.
+func (s PathMetadata) NewGeo(n int32) (GeoCoordinates_List, error) {
+	l, err := NewGeoCoordinates_List(s.Struct.Segment(), n)
+	if err != nil {
+		return GeoCoordinates_List{}, err
+	}
+	err = s.Struct.SetPtr(2, l.List.ToPtr())
+	return l, err
+}
+
+func (s PathMetadata) LinkType() (StaticInfoExtn_LinkType_List, error) {
+	p, err := s.Struct.Ptr(3)
+	return StaticInfoExtn_LinkType_List{List: p.List()}, err
+}
+
+func (s PathMetadata) HasLinkType() bool {
+	p, err := s.Struct.Ptr(3)
+	return p.IsValid() || err != nil
+}
+
+func (s PathMetadata) SetLinkType(v StaticInfoExtn_LinkType_List) error {
+	return s.Struct.SetPtr(3, v.List.ToPtr())
+}
+
+// NewLinkType sets the linkType field to a newly
+// allocated StaticInfoExtn_LinkType_List, preferring placement in s's segment.
+func (s PathMetadata) NewLinkType(n int32) (StaticInfoExtn_LinkType_List, error) {
+	l, err := NewStaticInfoExtn_LinkType_List(s.Struct.Segment(), n)
+	if err != nil {
+		return StaticInfoExtn_LinkType_List{}, err
+	}
+	err = s.Struct.SetPtr(3, l.List.ToPtr())
+	return l, err
+}
+
+func (s PathMetadata) InternalHops() (capnp.UInt32List, error) {
+	p, err := s.Struct.Ptr(4)
+	return capnp.UInt32List{List: p.List()}, err
+}
+
+func (s PathMetadata) HasInternalHops() bool {
+	p, err := s.Struct.Ptr(4)
+	return p.IsValid() || err != nil
+}
+
+func (s PathMetadata) SetInternalHops(v capnp.UInt32List) error {
+	return s.Struct.SetPtr(4, v.List.ToPtr())
+}
+
+// NewInternalHops sets the internalHops field to a newly
+// allocated capnp.UInt32List, preferring placement in s's segment.
+func (s PathMetadata) NewInternalHops(n int32) (capnp.UInt32List, error) {
+	l, err := capnp.NewUInt32List(s.Struct.Segment(), n)
+	if err != nil {
+		return capnp.UInt32List{}, err
+	}
+	err = s.Struct.SetPtr(4, l.List.ToPtr())
+	return l, err
+}
+
+func (s PathMetadata) Notes() (capnp.TextList, error) {
+	p, err := s.Struct.Ptr(5)
+	return capnp.TextList{List: p.List()}, err
+}
+
+func (s PathMetadata) HasNotes() bool {
+	p, err := s.Struct.Ptr(5)
+	return p.IsValid() || err != nil
+}
+
+func (s PathMetadata) SetNotes(v capnp.TextList) error {
+	return s.Struct.SetPtr(5, v.List.ToPtr())
+}
+
+// NewNotes sets the notes field to a newly
+// allocated capnp.TextList, preferring placement in s's segment.
+func (s PathMetadata) NewNotes(n int32) (capnp.TextList, error) {
+	l, err := capnp.NewTextList(s.Struct.Segment(), n)
+	if err != nil {
+		return capnp.TextList{}, err
+	}
+	err = s.Struct.SetPtr(5, l.List.ToPtr())
+	return l, err
+}
+
+// PathMetadata_List is a list of PathMetadata.
+type PathMetadata_List struct{ capnp.List }
+
+// NewPathMetadata creates a new list of PathMetadata.
+func NewPathMetadata_List(s *capnp.Segment, sz int32) (PathMetadata_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 6}, sz)
+	return PathMetadata_List{l}, err
+}
+
+func (s PathMetadata_List) At(i int) PathMetadata { return PathMetadata{s.List.Struct(i)} }
+
+func (s PathMetadata_List) Set(i int, v PathMetadata) error { return s.List.SetStruct(i, v.Struct) }
+
+func (s PathMetadata_List) String() string {
+	str, _ := text.MarshalList(0xa5cff7314a4335e5, s.List)
+	return str
+}
+
+// PathMetadata_Promise is a wrapper for a PathMetadata promised by a client call.
+type PathMetadata_Promise struct{ *capnp.Pipeline }
+
+func (p PathMetadata_Promise) Struct() (PathMetadata, error) {
+	s, err := p.Pipeline.Struct()
+	return PathMetadata{s}, err
+}
+
+type GeoCoordinates struct{ capnp.Struct }
+
+// GeoCoordinates_TypeID is the unique identifier for the type GeoCoordinates.
+const GeoCoordinates_TypeID = 0xf7bdaedb09e317d9
+
+func NewGeoCoordinates(s *capnp.Segment) (GeoCoordinates, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return GeoCoordinates{st}, err
+}
+
+func NewRootGeoCoordinates(s *capnp.Segment) (GeoCoordinates, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return GeoCoordinates{st}, err
+}
+
+func ReadRootGeoCoordinates(msg *capnp.Message) (GeoCoordinates, error) {
+	root, err := msg.RootPtr()
+	return GeoCoordinates{root.Struct()}, err
+}
+
+func (s GeoCoordinates) String() string {
+	str, _ := text.Marshal(0xf7bdaedb09e317d9, s.Struct)
+	return str
+}
+
+func (s GeoCoordinates) Latitude() float32 {
+	return math.Float32frombits(s.Struct.Uint32(0))
+}
+
+func (s GeoCoordinates) SetLatitude(v float32) {
+	s.Struct.SetUint32(0, math.Float32bits(v))
+}
+
+func (s GeoCoordinates) Longitude() float32 {
+	return math.Float32frombits(s.Struct.Uint32(4))
+}
+
+func (s GeoCoordinates) SetLongitude(v float32) {
+	s.Struct.SetUint32(4, math.Float32bits(v))
+}
+
+func (s GeoCoordinates) Address() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s GeoCoordinates) HasAddress() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s GeoCoordinates) AddressBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s GeoCoordinates) SetAddress(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+// GeoCoordinates_List is a list of GeoCoordinates.
+type GeoCoordinates_List struct{ capnp.List }
+
+// NewGeoCoordinates creates a new list of GeoCoordinates.
+func NewGeoCoordinates_List(s *capnp.Segment, sz int32) (GeoCoordinates_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1}, sz)
+	return GeoCoordinates_List{l}, err
+}
+
+func (s GeoCoordinates_List) At(i int) GeoCoordinates { return GeoCoordinates{s.List.Struct(i)} }
+
+func (s GeoCoordinates_List) Set(i int, v GeoCoordinates) error { return s.List.SetStruct(i, v.Struct) }
+
+func (s GeoCoordinates_List) String() string {
+	str, _ := text.MarshalList(0xf7bdaedb09e317d9, s.List)
+	return str
+}
+
+// GeoCoordinates_Promise is a wrapper for a GeoCoordinates promised by a client call.
+type GeoCoordinates_Promise struct{ *capnp.Pipeline }
+
+func (p GeoCoordinates_Promise) Struct() (GeoCoordinates, error) {
+	s, err := p.Pipeline.Struct()
+	return GeoCoordinates{s}, err
+}
+
 type PathInterface struct{ capnp.Struct }
 
 // PathInterface_TypeID is the unique identifier for the type PathInterface.
@@ -1398,12 +1795,12 @@ type RevNotification struct{ capnp.Struct }
 const RevNotification_TypeID = 0x9b0685a785df42e9
 
 func NewRevNotification(s *capnp.Segment) (RevNotification, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
 	return RevNotification{st}, err
 }
 
 func NewRootRevNotification(s *capnp.Segment) (RevNotification, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
 	return RevNotification{st}, err
 }
 
@@ -1442,12 +1839,26 @@ func (s RevNotification) NewSRevInfo() (SignedBlob, error) {
 	return ss, err
 }
 
+func (s RevNotification) RawPkt() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return []byte(p.Data()), err
+}
+
+func (s RevNotification) HasRawPkt() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s RevNotification) SetRawPkt(v []byte) error {
+	return s.Struct.SetData(1, v)
+}
+
 // RevNotification_List is a list of RevNotification.
 type RevNotification_List struct{ capnp.List }
 
 // NewRevNotification creates a new list of RevNotification.
 func NewRevNotification_List(s *capnp.Segment, sz int32) (RevNotification_List, error) {
-	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1}, sz)
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2}, sz)
 	return RevNotification_List{l}, err
 }
 
@@ -2262,131 +2673,172 @@ func (p SegTypeHopReplyEntry_Promise) Struct() (SegTypeHopReplyEntry, error) {
 	return SegTypeHopReplyEntry{s}, err
 }
 
-const schema_8f4bd412642c9517 = "x\xda\x94W}l\x1bg\x19\x7f\x9e\xf7\xec\xd8Nl" +
-	"\x9f\xddsF\x09\x82\xd0\xaaU\x9bj\xa9\x96t\x85\xae" +
-	"\x82\xa5M\xdb,\x1et\xcd\xd9\x11\x7fLC\xcc\x8b_" +
-	"'FN\xec\xdc]\xd2z\xa2\x84\xa2\x06XaZ\xab" +
-	"-\x82\xd1N\xb4\x1b\x94\x06\x86\xd8J5\xb1\x82&\xd0" +
-	":>\xa2\x09\x98T4-\x1a\xac-\xeb\xdat\xabX" +
-	"\xba\x8e~\xd0r\xe8\xb9;\xdf]/\xd7\x8f\xe5\xaf7" +
-	"\xfe=\xfe\xbd\xcf\xd7\xefy^\xdf1/\xb8\x86\xb5\x05" +
-	"\xff\x1d\x02\x90\x1f\x08\xd6\xe9\x1f<\xf7\x8b\xfd\xef\x9e\x7f" +
-	"\xf8\xdb\x90\x8c\xa1\xfe\xb1\x89\xdb\xf3\xf3\x8e~\xe11\x08" +
-	"b\x08@\xfa\x870-\xcd\x08t:)t\x00\xea\xe7" +
-	"\xa7/}\xe5\xa5\xa9\xb7v\x80\x1cC\xb71#\x93\xc6" +
-	"\xc0\x94\xb4 @\xa7O\x06N\x01\xeaM\xc9=]o" +
-	"+\xdb\x1e\xf3\x18\x1b\x16\xc1\xe0A)\x16\xa4S$H" +
-	"\xc4]/w\x8d\x1d\xda}f\x17\xd92\xc7v\x03\x0b" +
-	"\xc50 \xb5\x04\x0fKmd\xbd\xa25\xb8I\x00\xd4" +
-	"\x9f:\x9d:\xb1t\xfe\xd7\x9f\xf0\xf3\xf9\xcf\xe1)\xe9" +
-	"\xefa:\xbd\x16&\xea}[\x1b\x0e\xac\\S\x9d\xf0" +
-	"P\x1bn`dZ\x8aE\x0c7\"\x9b\x01\xf5\x99\xce" +
-	"\xb7\xc6\x7f:^\xb7\xdb\x8f\x97G\xceH\xc3\x86\xed`" +
-	"\x84x\xa7\xdf\xd8q\xfax\xf0/\xbbAnDA\x7f" +
-	"\xf7\x99#o\xb65\xfe\xe1\x084b\x08\x01\xa4]\x91" +
-	"i@i\xc2`\x9d\xd7\xb6\xb7\xed\x81\xf0\xa6I\x1f\xd6" +
-	"\x15\xb3\x11\x86\xd2U\x83\xf6\x92A{hvR\xbe\x7f" +
-	"\xfe\xc5g\xbd)6\xac[\xeb\xe7\xa1\xf4\xf9z\xb2\xbe" +
-	"\xab\xfe\x97\x80\xfa\xa7\x17?\xbe9\xb8\xa4\xe9\xa0oA" +
-	"f\xea\x0fJ\xb3\x86\xf1\xd9z\xf2\xe3\xf4\xb9\xdbFO" +
-	"\x9e]\xf3\xb2_t\xad\x0dg\xa4\xbb\x1a\xe8\xb4\xb2\x81" +
-	"\xdc\xb0\xe3\x91c(\xccIE\xc3\xcf\xa4A2^Q" +
-	"lhF@\xfd\xbd\xd1\xefWz\x97\xeb\xafx\x98\x0d" +
-	"/\x86\xa3'\xa4\xadQ:U\xa3\xe4\x85\xc8\xff\xba\xb6" +
-	"s\xfb\xa7\xa6\xfc\xda\xe2\x8d\xe8\xb4t\xd2\xb0=\x1e%" +
-	"/~\xf2\xce\xa2=\x07\x9e\xe6\xaf\xfa\xd9b\xec\xb0\x14" +
-	"\x89\x19\xcd\x14#\xdb7\x8f\xfff\xff#\x8f/9\xe5" +
-	"\x9b\xb8\xc5\xb1&\x94V\x1a\xd6m1J\\\xe9X\xe6" +
-	"KM\xaf]8\xe5\x97\x8b\xe3\xb1)\xe9\xaca;c" +
-	"0\xafZ\xf2\xfa\xb7\xfa\x1b_y\xdf\x8fY\xfax\xfc" +
-	"\x9c\xb48N\xa7\x05q\x0a\xaf\xe3\x9d\xbb[^\x98\x11" +
-	"g}\x8d\xb7\xc5\x0fK\x8f\x18\xc6\xe3\x86\xf1\x8b/m" +
-	"\x99\xfc\xee\xeb\xfb/\xf8y\xf1a\xfc\x9c\x84\"\x9d\xae" +
-	"\xc6\xc9\x8bh\xd3?\x7f\xde\xbf\xf8\xe4%\x90oCW" +
-	"\xe1\x1b\x99\xd1o\xad\xe2\x09@\xa9M$\xd6_\xbd\xf0" +
-	"\xf0=\x87\x9ey\xfe\xb2_\xc7?*\x9e\x93\x9e4X" +
-	"'D\xca\x83\xdaW,\x0f\xe5\x97\xf7\xb1\\e\xa8\xb2" +
-	":\xdd\x95\x1e*\x943|x\x84\x0b\xaa\xd6\x83(\x07" +
-	"\x84\x00@\x00\x01\x92\xb1v\x009,\xa0\xbc\x88as" +
-	"\xb1\x90^\xafb\x1c\xb0G@\x8c\x00\xa3\xa3\x87\xabk" +
-	"s\xbe'\xa7\x0dl\xe4Z\x0e\x80\xa8\x126U\xae\x93" +
-	"f\x8f\x80\xf2\x00C\xc4\x14\xd2g|!\x80\xfc\xa0\x80" +
-	"r\x89a\x92a\x0a\x19@\xb2x?\x80< \xa0\xbc" +
-	"\x9daR\xc0\x14\x0a\x00\xc9m\xf4\xed\xaf\x09(\x7f\x87" +
-	"\xe1X\xc1\xbc\x05c\xc00\x06\x18\x1a\xd4F0\x04\x0c" +
-	"C\x80zqH\xe3J!\xd7\x07\x02\xb7}M8\xf3" +
-	"\x00\x90>\x1c\xe3[*\xbd\xc5A\x8ea`\x18vE" +
-	"\x81F\x14\x19>\xda\x9c\xe1\x95R\xd5\x93\x8c\xd5V2" +
-	"R\x0c;\x14\xae\x8e\x944\xfb\xdak\x09\xb2\xeb\xd2\x1d" +
-	"\x9b\xee[\xbfQ\xed'\x86\xcf\xd5\x18\xa4\x09l\x02\xc8" +
-	"\xeeD\x01\xb3{\x90a\x0cu\xddH\x84\xf4$\xb6\x03" +
-	"d\x9f `/\x01\xec\x7f\xba\x91\x0c\xe9)\xec\x04\xc8" +
-	"\xfe\x80\x80\x1f\x13 \\\xd5\x8d\x84H\xfb0\x03\x90\xdd" +
-	"K\xc0\xb3\x04\x04\xae\xe8)\x0c\x00H\x93\x06p\x80\x80" +
-	"C\x04\x04\xff\xab\xa70\x08 =\x8f\x0f\x01d\x9f#" +
-	"\xe0\xb7\x04\xd4]\xd6SX\x07 \xbd\x88\xdf\x04\xc8\xfe" +
-	"\x9a\x80#\x04\x84.\xe9)\xa3\x1b\x7f\x8f\x0a@\xf6w" +
-	"\x04\xbcJ@\xf8\xa2\x9e\xc20\x8d[\x83\xeaO\x04\x1c" +
-	"% rAOa\x84\xa6/\xfe\x10 {\x94\x80c" +
-	"\x04\xd4\xffGOa=-\x15\xdc\x01\x90=F\xc0{" +
-	"\x044|\xa8\xa7\xb0\x81\xd4\x86\xf7\x02dO\x13p\x9e" +
-	"\x80\xe8y=\x85Q\x00i\xd6\xb8\xfc}\x02\xae\x10\x10" +
-	"\xfb@Oa\x8cF\xa6\xe1\xeeE\x02\x02\x8ca2\x8e" +
-	")\x8c\xd3l`\x94\xaa+\xf4y\x981\x14\x8ay\xa3" +
-	"I#\x80\xcd#C*\xd7\xa0n\xac\x92\xd3\x062|" +
-	"\x18\x13\xce\xa8\x03\xc4\x04\xa0n\"\x95\x12`\x15\x13\x8e" +
-	"\x9e-4\xa7\x9a\x12\x01\xa4\xef\xda\x03\xca\x8b\x86*%" +
-	"\xfa\xb6\xbd\xa8,\\\xe1\xa3\xf7\x95\xb5b\x01\x8b}9" +
-	"\xadX\x1e\x02L8K\xc7\xb2)\x16,\x8e\xe6\xe1\x11" +
-	"\xaej\x98pV\xb4\xd7\xc2\xba\xc5\x1ef\x16\xaere" +
-	"\xb4\xd8\xc7\xd3\xe8\x123&\x9c=\xe4kV)U\x81" +
-	"\xdc\xb1g\x92\xe3\xb2\x05\x12j/u\x9b\xa3\xbf\xb7Z" +
-	"\xe1\xdd\xd0\\\xae\x98\xe9\xb4\xe7\xbb\xc7\x02\xc9\x80x0" +
-	"\xe1l\"\xd3fLSr}<\x9d\xaf\xa9\xd83I" +
-	"\xd6f\xd3\x8e\x87\x1e\x1dv:Ci\x8c\x0fiJ\xd1" +
-	"-u{\x10\x9aR\xf7\xd0\xd2\xdcH\x9b#B\xe8\xe3" +
-	"\xc4\x1b\xb6y[h\xd8-\x12P\xbe\x83a\xb26\xa2" +
-	"Z\x97\x01\xc8K\x05\x94\xef\xa4\x09\xa8\xe6sj\xad\xab" +
-	"D\x9a\x87\xb5\x7f<\xd7d\xac\x92\x17\xfbr\"\x95\xdc" +
-	"\x13\xc0\xbd\x00rT@y>C]\xcd\xf0Q\x0a\xd5" +
-	"Lu\xe6_\x97?;~O\xfb\x8f\xecD\xce\xf1>" +
-	"\xc3\x87\x97\x17J9\xa1_%\xd7\x13;\xcd\xf9\xd8\xd2" +
-	"\xe9\xf6}\x971\x0a\x92\xad\xab\x1d\xdf\xc7\x14^P\xb8" +
-	":\x80\x08\x0c\x11\xb0c\xa0\x98\xcf\xf3\xa1\xda\xbf\xf6E" +
-	"\x829\xc0\xac.\xa9\xf5\x92\xaayk\xf0U+\x84\xa5" +
-	"\xcc\xee\xa9^\x10\xab\x15\xa7\x14\xa2\xae\xf5\xff\xed\x13-" +
-	"\xad\x99\x13\xdeR\xd4\xee0{\xc4j\x91\x0dC\x9a\x82" +
-	"\xc6\xc4\x8d\xda\xb7l\xa0U\xb0^@\xf9Agg|" +
-	"9\xe3\xec\x11{g\xf0Ng\x91\xdc\xda\x0a\xd0\xb5\xe2" +
-	" W\xb5\xdc `\xa5\xb6\x06n\xb2\x16\xba\xcbj\xb3" +
-	"F)\xf1\xb4\xcd2'\xf5\xf4\xe7,\xeddk;0" +
-	"\xb1RV\xec=\xd1\x9c\xcb\xe7\x15\xd5SUW\"D" +
-	"\x9f\xads\xc3n\xb7_\x8e\x9e\x14c\xad_Dj\x18" +
-	"bL\xd9\x8c[i\xebn\xb1\x16l-\xad\xdb\x16:" +
-	"\x0b6\xc9\xc2fZ\xc7\xa9Q\xb7\x0b(\xefd\x88\x02" +
-	"\xba\xde\xbf\xc9G\xdb\x81a\xc0\xd8,\xc9\x11\xea\xb3\x8a" +
-	"\x80\xf2\xf7\x18\x86\xf2\xaaV\x13EHU\xfal\x81\x0c" +
-	"\xe6\xb6P\xfb\xaa\xd4\xe7\xb5l\x14J\xb9~\xb5c\xa0" +
-	"\xb2\xae\xd0\xef\x8ai\xfe\x86\xb7\xef\x96\xfe\xb8\xe0\xf0\xf5" +
-	"\x15l5LHS\xaa\xd7\xaf\x85#a\x8a\xe2v\x01" +
-	"\xe5U\x0cE\x1a\xf4\x98p~\xd4X:\x1b(\xab\x9a" +
-	"\xa3B\xfb\xa1\xe5\xabBW\xbd\x043\xb7\xaej-s" +
-	"\xde\x08\xa2V\xadp\x14\xf5o\xacz\xba\x9eO^\xd8" +
-	"Gl\xe2\x9c\x1a\xad\xcd\xa6;L\x99]\xe7\xe9\x95\xf2" +
-	"\x0e\x9e\x1bi\xd5\x14\x92\xa0x\x85\xf4\x90%\xa4\x1eW" +
-	"^6R\xc9\xbb\x05\x94{\x19\"3+.\x93\xbaz" +
-	",!\xd9\xba\x0e\x99\x81\xb8\xf5,\x02\x864\xaddK" +
-	"\xc5N \xba*\xe9\xcec\xfc\xba\x0f\xcf\x8f<\xe2\xed" +
-	"\xb7\xf9\xcdh\x9bi\xa8To$W\x9f\x0e\xb9f\xac" +
-	"\xdfZ_\xd8j\xeb\x18\xb0\xdf\x8d\xae\x1b3\xce\x18\xae" +
-	"\xdd\xd8\xd6i\xdd\xd8\xcdP\xe7\x8aRV\xd6\x95\xf3\x80" +
-	"\xbc&\x8d\xb9A\xdb?\xb7|\x83v5\x81\xef\xd3\xf5" +
-	"\x86\xf9\xb4\x7fE\xf9Rw[)X\x9e\xcb\x87\xf2\x8a" +
-	"j\x06fF1Gn\xcc\xb31\xc5be\xf4\xce\xda" +
-	"\x86\xa7\x7f>s\xf3u\xef\x14\xcd\xd5\xbf$\x865\x02" +
-	"\xca_\xa4\x8b\x02\xe6\xed\xe9\x85\xae\xa6f=\xe6\xed\x1b" +
-	"W;M}\xadl\xdc?\x13:\x8a\xea\xba\xb2\xc2k" +
-	"\xdb\xef\xff\x01\x00\x00\xff\xff\x18V:\xa1"
+const schema_8f4bd412642c9517 = "x\xda\xa4X\x0dl\x1c\xd5\xf1\x7f\xf3\xf6\xce\xe3\xd8w" +
+	"\xbe[\xbfu0\xf9\xeb_C\xd4\x08B\xe3(vp" +
+	"\x0b\x11\xe0\x8f|`\xbb\x04\xfc\xee\x9c\x16\x10\xb4\xdd\xf8" +
+	"\xd6\xf6\xc1\xf9\xee\xb2\xb7vb\xd4\xd4\x80\xe2\xb6PQ" +
+	"\x88\x00\x95\x14\x10\x0d\x94\x0f\xb7IKh\x82DJ(" +
+	"\x08h\xa5\x88\x14h\x89(iR \x10 \xd0HN" +
+	"\x08\x0d\xd0\xc2\xab\xde\xed\xde\xde\xde\xdd:\xa1\xad\xa5\x91" +
+	"|;3o\xe6\xcd\xc7ofw\xd1\x9f\xab:hK" +
+	"\xf0\xbejBx\"X%>|t\xebC\x1f\x1c\xbf" +
+	"\xee\x07D\x0d\x838\xed\xce\x05\x89\xfaW\xbe~+\x09" +
+	"\x02\x12\xc2\xda\x02\xfbXg\x00\x1dj'\x84\xdd\x12@" +
+	"q|\xdf'\xdf~j\xf7\xeb7\x13\x1e\x06\xaf\x8a\"" +
+	"U\xc6\x03\xbb\xd9d\x00%-\x9e\x0c4\x01!\xecp" +
+	"\x10\xc5\x1c\xf5\x9e\x15o\x9b7\xdcZ\xa6\x13\x90*{" +
+	"\x83\x8f\xb1\x03AtHZ9\xbd\x0a\xc5\x8agWL" +
+	"l\xbf\xfb\xfd\x8dR\x83\x165\x96S\x8c@\x80\x05\xab" +
+	"v\xb2p\x15JZ\x1c\xae\xfa\x9dB\x08\xfb\xa8\x06\xc5" +
+	"\xbd\xefi\x07\xcfn\xfc\xde\x1d~\x97y\xb3f7;" +
+	"R\x83\x0eI3\x9d\xb5(6\xaf\xaf}\xa4\xadc\xfc" +
+	"\xce23y\xc7\x9ak\xf7\xb1\xf3k\xd1\xa1\xb5\x84\xb0" +
+	"\x1d\xb5(\x0ew\xbd>\xf9\xf0d\xd5\xdde6\xa8\xd4" +
+	"\xd8\\\xfb>\xdbZ\x8b\x0eI\x8d\xb6\x10\x8a}\xaf\xdd" +
+	"\xfc\xde\x9b\xc1?\xdeMx\x03(\xe2\x83\x07\x9e\xdb\xdf" +
+	"\xd2\xf0\xfb\xe7H\x03 \x10\xc2\xce\x0c\xedc\xcd!t" +
+	"H\xeaL\x85P\x1cj[\xda\xdbr\xe2\xc5\x07\xcb\xac" +
+	"TI+w\x86\xfe\xc46\x87P\xd2\xe2\xcd\xa1o\xca" +
+	"C6\xd5\xa1\xa8o\xf9Y\xcbU\xd5\x97M\xf9\\~" +
+	"\xf1d\x1d\x05\xb6\xb1\x0e\x1d\x92\xb7?\\\x87b\xfb\xd1" +
+	")~e\xe3\xc7[\xcaS\x99\xd7\xd9[W\x0f\xecP" +
+	"\x1d:\xf4kB\xd83\x11\x14g\xcc\xbb}m\xf0\xac" +
+	"9\x8f\x95\xeb\xe4\x03\xb05\xf2\x18\xdb\x11A\x87\xe4e" +
+	"\x1a\xa2(\xde;6{\xec\xd0\x91\x8eg\xfd\xd2\x02\xd1" +
+	"\xf7Y8\x8a\x0eI\xc7\xf4(\x16\x83\xc4\xc3\xa0\x94\xab" +
+	"\xac\x8c\xfe\x82\xad\x8a\xa2\xa4\xc5\xab\xa2\xf9\x1a\xdb\xa8\xa2" +
+	"\xf8\xfb\xd8O\xb2\xfd\x0b\xc5\xf3~\x89Y\xaf\x1ed7" +
+	"\xa9\xe8\x90\xf4\xeb\xf4z\x14\x11\xe3\xc5\xce\xae\x0d_\xda" +
+	"\xedW\x95\xc1\xfa}L\xadG\x87\xa4_\xab\xeaQ<" +
+	"\xf8\xce\x97\xefy\xe4~\xe3\x05?\x8d\xce\xfa\x9d\xac\xa7" +
+	"\x1e\x1d\x92\x1a\x93\xf5(\xf6\xbf\xf9\xdb\x87n\xba\xfd\xac" +
+	"w}C\xbc\xa6~\x0e\xb0\x1b\xea\xd1!\x19b\x83\xa1" +
+	"H\xbd\x11\xfb\xc6\x9c\x97O\xbc\xeb\x17/\xcev\xb3\xab" +
+	"\x19:$\xadlc(\xce;\xeb\xd5\xef\x0f5<?" +
+	"\xedg\x85\xdd\xcb\x8e\xb1)\x86\x0e\xc9\xcb\xb7h(\xda" +
+	"\xdf\xb9h\xfe\xe3\x87#G}U\xfe_\xdb\xc9\xe6i" +
+	"\xe8\x90T\xd9\xa5\xa1x\xe2\xa9uS?z\xf5\xa1\x13" +
+	"~~Mi\xc7\xd8\x0e\x0d\x1d\x92~\xcdj@\xf1\xda" +
+	"io\xcd\xfa\xeb\xafv\x9d\xf05\xf2\x91v\x90A\x03" +
+	":$/\xff|\x03\x8a\xd0\x9c\xbf\xfdrh\xde\xa1O" +
+	"\x08\x9f\x0dP\xac\xb6\x06\x9a\xef\x96m\x0d\x07\xd9\xae\x06" +
+	"tH:v\xfel\x14\xbfy\xfc\xba\x8b\xb7?\xb0\xed" +
+	"S\xbf.\x9e7\xfb\x18k\x99\x8d\x0eI+Gf\xa3" +
+	"\xc8\x0d$3\xe9\xc4\xc2\x01\xaag\xd3\xd9%=+z" +
+	"\xd2\x83\x99\x98\xb1f\xd4PrV\x1f@\x1fP\x1eP" +
+	"\x02\x84\x04\x80\x105\xdc\xaa\x86\x91\x87\x14\xe0gSh" +
+	"J\x0e\xf6,\xcb\xf5\x01\x85:\x02}\x0a\xc0,\x92\xff" +
+	"\xb7\x03\xca\x0e]\xb16\xd1\xa7[\xc3+\x0dK'\xc4" +
+	"9Ss\xcf\\\xdf\xa5\xaeG\xfe]\x05\xf8\x0f)\x00" +
+	"h \x1fN\xceU'\x91oP\x80\xdfFA\xa5\xa0" +
+	"\x01%D\xbd\xe5Ju#\xf2\xdb\x14\xe0\xdb)\xa8\x0a" +
+	"h\xa0\x10\xa2n\xebR\xb7!\x7fT\x01\xfe$\x055" +
+	"@5\x08\x10\xa2>\xd1\xab\xeeB\xfe\xa4\x02\xfc%\x0a" +
+	"\x13\x83\xb6\x0b\xd2\xd90\x91\x048b\x8d\xca\x9fH$" +
+	"\x81H\xa6-\xc3\x1c\xd4\x07\x88bx\xef\x14-\x02#" +
+	"!\x1d\xa0\x02\xf6Q\x90\xcc\x09c]\xb6?9bH" +
+	"\xd9j\"\x09\xc4\x88a\xe9\x09]\xde\x92\xc8\xc7\xd1\"" +
+	"xyt\xa3%\x11\x82|\x84b\xc6XS\xcc\xc8\xa6" +
+	"\xc6+#\xbe\xa4\x10\xf1F\x0a\xed\xa6\x91\x1bMY\x1e" +
+	"\xb7+N\x8a/\xedi\xbf\xec\xd2e+sC\xceQ" +
+	"\xcb\x0aG\xb1=0\x87\xed\x01\x8c\xbf\x00\x0a\xc4\xff\"" +
+	"#\x01B\xe4\xc3\xcd\xf6B+\xdb\x0b\x18\x7fE\xb2\xde" +
+	"\x90,\xfa\xb9\xc8\xc7\x9c\x1d\x80.v\x000\xbe_\xb2" +
+	"\xa6%K\xf9L\xe4\x03\xcf\x8e@\x8c\x1d\x05\x8cOK" +
+	"V\x80R\x08\x07\xfe%\xf2\xd1g@c,H1\x1e" +
+	"\xa0\x0a\xc4\x1b%+\xf8O\xa1AP\xe2 ]\xcdN" +
+	"\xa7\x18o\x94\xac\x05\x92U\xf5\xa9\xd0\xa0\x8a\x106\x9f" +
+	"\xde\xc8\x9a)\xc6\x17HV\x87d\xe1'B\xcb\xb7\xc8" +
+	"\x85\xd4d\x9d\x14\xe3\x1d\x92\xd5/Y\xd5\x1f\x0b\x0d\xaa" +
+	"\x09a\x9c\xaef\xab(\xc6\xfb%kX\xb2f\x9d\x10" +
+	"\x1a\xcc\x92\x18B\x7f\xcaF(\xc6S\x92u\xbdd\xd5" +
+	"\xfcChP#\x81\x90\xde\xcc&)\xc67H\xd6]" +
+	"\x92U\xfb\x91\xd0\xa0V\x8e\x15\xda\xcb6Q\x8c\xdf%" +
+	"Y[$+t\\h\x10\x92\xcdMM\xb6\x95b|" +
+	"\x8bd=-Y\xe1\x0f\x85\x06a\x89\x0b\xf4F\xf6\x0c" +
+	"\xc5\xf8\xd3\x92\xf5\x0a\xa5\xa0FA\x83:B\xd8\xcb\xb4" +
+	"\x8b\xbdL1\xfe\x92\xe4\xec\x97Ju\xc7\x84\x06\x11B" +
+	"\xd8k\xf4\x1av\x80b|\xbfdMKV\xe4\xa8\xd0" +
+	" *\xa3K\xafcG)\xc6\xa7%+\xa0PP\x92" +
+	"\x09\x99y\xd9e\xb3\x084\x8d\xa6s\x86,\x05R5" +
+	"\x91\xd5\xad\xe1\x98\xb1\xc6\xae9wb\x94\xd6\x9c\xb0\x85" +
+	"\xb2)\x02\xe3\xb6\xa0\x8b{e\x82z\xce\xc6\x00\x02\xce" +
+	"\x89.\xd6\xcf$\x88\xf9\xca\x95\xa2\xee\xdeQ&j\x1a" +
+	"c\x97f\xac\xe4 $\x07t+\x99I;\xfd\xe1\xae" +
+	"\x10e\xe2\xc9A\xe7\xe4\xa65\xa3F\xce\xb2\x85\xdd\x05" +
+	"m&\xe1\xa2\x1b\xee\xdc(\x13\xcd\x19\xe6Xr\xc0\xe8" +
+	"\x81\x12\x90\x93\x1a\xee\xcepR\x8dlj\xbc\xd0\xda\xee" +
+	"\x08\xa8\xbc\xa9#\xe7\x08\xba\x1b_\xc5\xc9C\xfd\xe3Y" +
+	"\xa3\x9b4e\xb2n\xf2\xdcA<\x830d\xb2\xf6\xe9" +
+	"\xb6\xb8\xbbO\x94\x8aOX\xa6>`\xf4$<p'" +
+	"\x12\xe6\xb5\xc6\xf8%c)\x12iu\xad\xdd\x11\x0e\x1c" +
+	"\xdf\xd9\xfd\xf3C\xe5\xd6\\\xd9\xf6V\x07\x95\xa4\xf4\x86" +
+	"\xaf,\x9a\xfe\xbc\xed\xe1\xd7g\x063\x1b\xee;\xe3=" +
+	"\xde`\x95\x03ZW\x01\xd0\x16Q\x980\xd2\x96\x99," +
+	"\x03\\w\x86\x95\x02n\x85\x1d\x09\xe8=6j+\x03" +
+	"\x86c\xa8\xda54\xbfU\x9d\x8f\xfcl\x05\xf8\xb9\x14" +
+	"\xd4\xc2`i9GmA\xbeH\x01~\x81\x9c`\xb9" +
+	"\x84\x9e\xf34UD\x8e4\xcf\xef\x0a\x9b1\xa7\x8e\x93" +
+	"\x03zD\xd6q\xa5\xd5^\xb5\x19\xf9\x02\x05x\x87\xc7" +
+	"\xea\x85K\xd4\x0b\x91_\xa0\x00\xef\xa6 r1cL" +
+	"\x86\xc8-\x91\xd8[\x9f~m\xf2\xe2\xd6\xfb\xca\"\xdb" +
+	"n\xeak\xfb\xae\xb5<Y\xf4\x8dA\xccX\xb3p0" +
+	"\xa5+C\xb9\xa27\xd1\xdb\xec\xd98\xbf\xab$\x08\x1b" +
+	"\xed\xd9\xd8\xb2\xc4\x13\x84\x09\xd3\x184\x8d\\~6\x02" +
+	"\x91\x04\xed\xc3\xc9D\xc2H{\x9e\xf8\x1a^i\x8f;" +
+	"\xd0\x1d\xbb\x8dn\x146u\xa9\x9b\x90\xdf\xe5\x0c\xeaB" +
+	"\x14\xb6\xc5\xd4\x1d\xc8\xb7\xdb#Y\xa5\xd4\x1e\xea{\xe6" +
+	"\xaa{\x90\xbf\xa0\x00\x9f\x96C]\xb1\x1d?\xd2\xab\x1e" +
+	"E>\xad@\\\x039\xd5\x03\xf6\\Q\xe1\x1a\xd6\x00" +
+	"\x18\xd7\xe4\xc8Y$9\xc1\xa0=V\x9a\xa1\x955\x03" +
+	"\xc6\x17HN7P\x98H\xe9\x96\x91\x1e\x18\xf7\xd4V" +
+	"\xc0^P\xc4j=\x9dX\x9bLX\x04\x86+\xd7\x17" +
+	"\x1c22%\xf5\xe8\xaen\xa5\xf5(R\xc9\xf4\xb5\xb2" +
+	")\x9d4:\xf2\x11\xf1\x99\xc9Woy\xea[?." +
+	"\x97\xcfo\x18i=E\"\xdd\x99\xac\xb7\xe4\xabm\xc3" +
+	"M\xe9\x8cU\xd2\x0a\xa1\x8a}J\xb1g\xbc\x83I\x05" +
+	"\x10\xcbY>mv\x8d\xaa\"\x8f\xda\x89/\xa0X?" +
+	"\x89\x8cgKLD\x845\xf4\xd2\xff\xcdo\x8e\x1d\x9c" +
+	"\xb9\xdb\x0aFm\x04r\x00hy\xda2\xa1\xb0\xae\x84" +
+	"\\\xb3\xcb\xafT{\x90w+\xc0S\xc5e.\x19S" +
+	"G\x90\xa7\x14\xe0\xeb<\xcb\xdch\x97:\x8a\xdcR\x80" +
+	"_O\xff\xf3\xf5KX\xc9\x11#g\xe9#\x04\xb2\x9e" +
+	"\x15\xccg+\xabX\x91\xba3\xb9&K\x06\xaf\xb2y" +
+	"\xcf\xf1t\x8b\xfc+\xee\xe0jK\xab\xda\x82\x04\"\xd9" +
+	"\x8c\xe9]\xc0\x9a\xf4D\xc2\x94\x1eWt\x88'`\x11" +
+	"\xff\xd5\xee\x94H\xe8\xbe\x95\xce\x98\x1b(4c\xc4^" +
+	"\x00\xcaw\xeb\xb9\x9e\xddZ\x9da\xb9\xaev\x96\xeb\xde" +
+	"\xc2r}\x0f\x05P\x00\x8a\xaf\xeb\xea\xa6Vu\x13\x12" +
+	"\x80@\xbe\xd7\xd4\xc9%\x85\x03\x1e\xa1\x80\x09{\x86:" +
+	"\x90\x899s\xc0\xf3S\x8c\xe8\xeb$V\xe4\x9c.)" +
+	"\x84m0\xa5K\xbc\xa2\xed\xc3\xd9\xa5\x83C\xa5\xd7n" +
+	"\\\xfe\xf6E\xec\x0fg\xee\xfc\"\x03\xc0)F\xb4\xcc" +
+	"\xf1\x93\xa4\xf3\x02\xcf\xed\xcf\xef-`q\x1f\x85H\xd6" +
+	"y+\x88\x16\xbf\xe6\x94\x0d\xc3\xe1L\xce*Ak\xf7" +
+	"M\xec\x14s\xd0S\x00\x8a\xb1\xa62\xfd\xe7\x14\xd2\x7f" +
+	"\x06\x85\x885\x9e\xcd\x17mD\\\x7f\xde\xfd5\xc6\xd4" +
+	"\x89\xcd\xde\xe3#>I\xef\x8c\xf7\xb4\xdb\x08p\x92\xd7" +
+	"\xb4F\x9f!wr<\xb1{[1}z{u\xa1" +
+	"\xb7\xaf\xf2\x84\xf3\x8a\xb9\xea\x15\xc8/W\x80'(\x80" +
+	"\x83\xe9zL5\x90'\xec\xba+\xa2\x0f\xba\x97\xf4C" +
+	"\x9d\x08\x01\xb4\xac\x94\xf7\x85\xca\x8d=\x94V\x88_\x0a" +
+	"\xfc*\xa4g\xc5\xff\xb6\x8a\xb8_\x13\xbe\xb8\x9d&\x89" +
+	"\x8c\xe3\xa7\x82\x16g\x19\xe9U\xdb\x90\x9fk\xaf\x05\xe5" +
+	"\xbb\xc7\x7fSx.\x1c\xb4\x0f{_'=n\xc4\x0a" +
+	"\xeb\xc9yE7\xda\xba\x0an\\NA\x18\xa6\x991" +
+	"\x97f\x12\x04\x0cO\xc3\xfa\x86\xc7\xfd\xd0t\x8a\xf0x" +
+	"\x0al\xa6\xd7\xdcS\xa6\xc2\xfd|t\x0a[\x17\x1b\x99" +
+	"\xa5\x99\x8c\x99H\xa6u\xcc\x8f\xd3\xf2*\xee-Tq" +
+	"\xbf\xa7\x8ayL]\x85\xbc_\x01\xfe\x9db\x15_\xdd" +
+	"\xa5^\x8d\xfc*\x05\xf80\x05\x91\xd2\xad\xa45\x9a(" +
+	"\x8c\xfb\x1a\"\x09D*\x93\x1e\x92\xcf\x9dp9\x8f'" +
+	"\xe4X0r\xf9+\xc81\x1e\xf2\xf1\xb4\xdb\xc9\xefB" +
+	"=\x81\x09\xb3\xe0i>W\x1aT\xd4L\xdeUZ\xbe" +
+	"\xc1F\x92\xd9\xb1s=\x0b\xa2\xfc\xfd\xd5\x93-\x8c\x9d" +
+	"q\xffJ\xf5\x04\xa8U]\x8e|\x99\x8d\x8f\xaa\x84|" +
+	"\xf9t\xe5\\u%\xf2K\xec\x1aQi\x9f\xed\xcb\xaa" +
+	"%\x9e\xb0U\x00M\xd9'\x96\xf6dni\xc64J" +
+	"\xb6\xca\x7f\x0f\x00\xc8\xc9f\x1f"
 
 func init() {
 	schemas.Register(schema_8f4bd412642c9517,
@@ -2398,6 +2850,7 @@ func init() {
 		0x95794035a80b7da1,
 		0x9b0685a785df42e9,
 		0x9bce05e1e88ad9da,
+		0xa5cff7314a4335e5,
 		0xa94f085c31a03112,
 		0xacf8185a51a9f1b4,
 		0xb21a270577932520,
@@ -2411,6 +2864,7 @@ func init() {
 		0xf0c5156786d72738,
 		0xf10fe9b6293ee63f,
 		0xf7a6d78ba978beb9,
+		0xf7bdaedb09e317d9,
 		0xf9e52567abde1a0c,
 		0xfab1a3b4477ab6b3)
 }
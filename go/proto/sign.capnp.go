@@ -204,8 +204,10 @@ const SignType_TypeID = 0xf6b5bc42e3072fc9
 
 // Values of SignType.
 const (
-	SignType_none    SignType = 0
-	SignType_ed25519 SignType = 1
+	SignType_none      SignType = 0
+	SignType_ed25519   SignType = 1
+	SignType_ecdsaP256 SignType = 2
+	SignType_ecdsaP384 SignType = 3
 )
 
 // String returns the enum's constant name.
@@ -215,6 +217,10 @@ func (c SignType) String() string {
 		return "none"
 	case SignType_ed25519:
 		return "ed25519"
+	case SignType_ecdsaP256:
+		return "ecdsaP256"
+	case SignType_ecdsaP384:
+		return "ecdsaP384"
 
 	default:
 		return ""
@@ -229,6 +235,10 @@ func SignTypeFromString(c string) SignType {
 		return SignType_none
 	case "ed25519":
 		return SignType_ed25519
+	case "ecdsaP256":
+		return SignType_ecdsaP256
+	case "ecdsaP384":
+		return SignType_ecdsaP384
 
 	default:
 		return 0
@@ -252,29 +262,32 @@ func (l SignType_List) Set(i int, v SignType) {
 	ul.Set(i, uint16(v))
 }
 
-const schema_99440334ec0946a0 = "x\xdal\x91?\x8b\x13A\x18\xc6\x9fg&q\x13c" +
-	"\xd8\x1d6\xbd \x16\x1a\xf0OBB\xd0J\x82(\x16" +
-	"\x11'ZZ\xb8I\x86d!\xd9l\x92\x15\x09\x08\xa2" +
-	"\xe87\x10\xc4J\xb1\xb3\xb8\xe2\x0e\xae\xbb/p\x9f#" +
-	"\xc55W\x1eW\\\xb3\xc7,\x97\x04\x8eT3<\xfc" +
-	"x\x9f\xdf\xcb\xeb\xfdz&jy\x97\x80\xbe\x95\xbf\x91" +
-	"\xde\xfc\xfa\xfal\xf0\xb3\xf3\x1d\xbaD\xa6\x7f_\x14O" +
-	"\x1b\xf2\xf9o\xe4\x85\x03\xa8\xc975\xcb\xde\x130\xed" +
-	"\xae.Z?^\xd6\xff@\x95\xae\x83\xfe+\x1e\xf8\x9a" +
-	"\xf6\xd7\xe1'0=~\xe4\xac\xdaG\x87\xe7P%\xb1" +
-	"eA\xff?\xff\xf9\xfb\x19\xb8\xc7\x16\x98.\xc2a\xf4" +
-	"\xb0\x1f\xc4\x8c\xe2\xa7o\xc3a\x847\xa4\xf6d\x0e\xc8" +
-	"\x11PA\x15\xd0\xef%\xf5H\x90\xac\xd0f\xe6\x0e\xa0" +
-	"?H\xea\xb1\xa0\x12\xacP\x00*\xec\x02z$\xa9\x13" +
-	"A%Y\xa1\x04\xd4\xcc\x86\xb1\xa4\xfe,\xe8&\xcb\xd8" +
-	"\xd0\xdd\x9a\x81tAg1\xef\xb3\x0c\xc1\xf2\x95L\x90" +
-	"|\x9c\x83f\x93%\xe1\xc4,\x92`\x02\xc6,@\xb0" +
-	"\xb0C\xda\xdc\x1e\xb4\xc7\xd3\x9eU/l\xd4\xef[\xf5" +
-	"\xbb\x92\xfa\xb1\xa0Z\xbb?\xb0\xe1=I\xdd\x10t{" +
-	"\xe3io\xdd\xe3\xda\x99\xf4\xb6\xe7\x00\xe9\xed\xa8z\xe7" +
-	",c\x93\x15ek\xab\xaa\x05U\xb1\x0d\xb8\xd142" +
-	"_\xcc\xa0\xdel\xd6\x9e\\\x06\x00\x00\xff\xff6\xcal" +
-	"\x01"
+const schema_99440334ec0946a0 = "x\xdal\x91\xcfj\x13Q\x14\xc6\xcfwn\xe3\xa9F" +
+	"\x99\x1c&{Q(\xd8,\xd4\xc4\xc4\x7f \x84Pt" +
+	"\xa5\xcc\x8d>\x80\xd3d\x08\x013\x9d\xe9\x8cH\xa1E" +
+	"\x14\x0bu\xdf\x8d+\xc5G\x10\xba\xf3\x05|\x0c\xe9B" +
+	"\x04\x97\xea\xc2\xcd\xc8\x9d\xa6\x9d\xa0\x85\xfbq8\x87s" +
+	"\xee\xf9~\xf76\x0e\xfa\xdc\xaey \xb2\xe7kg\x8a" +
+	"s\xaf\x1e\xfd\x1c\xef?|C\xb6\x0e\x14\x1f\xee\x9f\xfd" +
+	"\xd15k\xef\xa8\xc6B\xa4\xb3\xd7\x9aJy\xbe\x11\xf9" +
+	";\x90bx\xf8\xe7\xd6\xee\x83\xce{\xd2\xfa\xbf\xdd\xfe" +
+	"\x14\x9f\xfc\x142\xd7\x0b\"\xff\x17\xa4\xf8rM\x0e\x07" +
+	"\x9f\x0f~\x93\xd6\xb9\x9a \xf8_\xf1\xd1\xff\x0e\x99k" +
+	"B\xe4\xdfc)\xb2\xe9$\xbe:\x0a\x13\xc4\xc9\xdd\xc7" +
+	"\xd3IL\x01\x10\x80m\xc3,\x11-\x81H\xc3\x96\x86" +
+	"b\x9f\x1a\xd8\x9c\x014\xe1\x8a\xe9eM\xc5&\x06v" +
+	"\x9b\xa1\x8c&\x98H\xb7\x86\xba#v\xdb\xc0\xee1\xd4" +
+	"\xa0\x09C\xa4\xbbC}+v\xcf\xc0\xee3\xbc|+" +
+	"\x89\x020\xbc\xca)Q\x1f\x0a\x09\x18\xf0\x08\x92m\x8e" +
+	"\\\xc3\x05rB\xe90\xcc\x9fo\x12\xa2\xc5r>\x9d" +
+	"EY\x1e\xce\x08\x89+/\x93\x13\xfa\xf8\x8f(\xba8" +
+	"\x1e<\xdbX\x9fs-\x9fp\xad\xb6tU\xec\x15\x03" +
+	"\xdbe\xe81X\xbb\xa5m\xb1\xd7\x0d\xec\x1a\xc3[/" +
+	"\x07O\x96z\xeen\x977\xaa\x7f\\0\xdf8u\xff" +
+	"\x13)\x89\x8f^\x15|\xbc\x19\xd0\x95\x81\xae\x08X/" +
+	"\x0d]4G\xd1\x8b7b\x07\xfa2\x1awz\xbd\xf6" +
+	"\x9d\x00\\D\xa3q\x16\x06\x9d\x1e\xe1f\x95\xde\xb8M" +
+	"\xe8\x06\xe0>\xfe\x0e\x00\xee9\x85\x89"
 
 func init() {
 	schemas.Register(schema_99440334ec0946a0,
@@ -96,6 +96,17 @@ type SDConfig struct {
 	// QueryInterval specifies after how much time segments
 	// for a destination should be refetched.
 	QueryInterval util.DurWrap
+	// SCMPAuth is the authentication mode for revocations received via SCMP.
+	// (disabled | optional | strict) (default disabled)
+	SCMPAuth SCMPAuthMode
+	// HiddenPathGroups is a list of files that contain the hidden path groups
+	// for which hidden path segments can be requested.
+	HiddenPathGroups []string
+	// HiddenPathServer is the TCP address of the AS local hidden path server.
+	// Hidden path segment requests are sent to this server, which forwards
+	// them to the group registries if necessary. It is required if hidden
+	// path groups are configured.
+	HiddenPathServer string
 }
 
 func (cfg *SDConfig) InitDefaults() {
@@ -105,6 +116,9 @@ func (cfg *SDConfig) InitDefaults() {
 	if cfg.QueryInterval.Duration == 0 {
 		cfg.QueryInterval.Duration = DefaultQueryInterval
 	}
+	if cfg.SCMPAuth == "" {
+		cfg.SCMPAuth = SCMPAuthDisabled
+	}
 	config.InitAll(&cfg.PathDB, &cfg.RevCache)
 }
 
@@ -112,6 +126,12 @@ func (cfg *SDConfig) Validate() error {
 	if cfg.QueryInterval.Duration == 0 {
 		return serrors.New("QueryInterval must not be zero")
 	}
+	if err := cfg.SCMPAuth.Validate(); err != nil {
+		return err
+	}
+	if len(cfg.HiddenPathGroups) > 0 && cfg.HiddenPathServer == "" {
+		return serrors.New("HiddenPathServer must be set if HiddenPathGroups are configured")
+	}
 	return config.ValidateAll(&cfg.PathDB, &cfg.RevCache)
 }
 
@@ -123,3 +143,27 @@ func (cfg *SDConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap)
 func (cfg *SDConfig) ConfigName() string {
 	return "sd"
 }
+
+// SCMPAuthMode is the authentication mode for revocations received via SCMP.
+type SCMPAuthMode string
+
+const (
+	// SCMPAuthDisabled indicates that the authenticity of revocations is not
+	// verified.
+	SCMPAuthDisabled SCMPAuthMode = "disabled"
+	// SCMPAuthOptional indicates that revocations that fail authentication
+	// are rejected, but revocations without authentication are accepted.
+	SCMPAuthOptional SCMPAuthMode = "optional"
+	// SCMPAuthStrict indicates that all revocations that are not
+	// authenticated are rejected.
+	SCMPAuthStrict SCMPAuthMode = "strict"
+)
+
+func (m SCMPAuthMode) Validate() error {
+	switch m {
+	case SCMPAuthDisabled, SCMPAuthOptional, SCMPAuthStrict:
+		return nil
+	default:
+		return serrors.New("unknown SCMPAuth mode", "mode", m)
+	}
+}
@@ -48,6 +48,9 @@ func InitTestConfig(cfg *Config) {
 func InitTestSDConfig(cfg *SDConfig) {
 	pathstoragetest.InitTestPathDBConf(&cfg.PathDB)
 	pathstoragetest.InitTestRevCacheConf(&cfg.RevCache)
+	cfg.SCMPAuth = SCMPAuthStrict
+	cfg.HiddenPathGroups = []string{"test"}
+	cfg.HiddenPathServer = "test"
 }
 
 func CheckTestConfig(t *testing.T, cfg *Config, id string) {
@@ -61,4 +64,7 @@ func CheckTestSDConfig(t *testing.T, cfg *SDConfig, id string) {
 	pathstoragetest.CheckTestRevCacheConf(t, &cfg.RevCache)
 	assert.Equal(t, sciond.DefaultSCIONDAddress, cfg.Address)
 	assert.Equal(t, DefaultQueryInterval, cfg.QueryInterval.Duration)
+	assert.Equal(t, SCMPAuthDisabled, cfg.SCMPAuth)
+	assert.Empty(t, cfg.HiddenPathGroups)
+	assert.Empty(t, cfg.HiddenPathServer)
 }
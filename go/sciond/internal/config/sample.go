@@ -23,4 +23,19 @@ address = "127.0.0.1:30255"
 
 # The time after which segments for a destination are refetched. (default 5m)
 QueryInterval = "5m"
+
+# The authentication mode for revocations received via SCMP. In optional mode,
+# revocations that fail DRKey authentication are rejected. In strict mode,
+# revocations without authentication are rejected as well.
+# (disabled | optional | strict) (default disabled)
+SCMPAuth = "disabled"
+
+# The files containing the hidden path groups for which hidden path segments
+# can be requested. (default [])
+HiddenPathGroups = []
+
+# The TCP address of the AS local hidden path server. Hidden path segment
+# requests are sent to this server. Required if HiddenPathGroups is not empty.
+# (default "")
+HiddenPathServer = ""
 `
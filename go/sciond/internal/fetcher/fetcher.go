@@ -28,6 +28,7 @@ import (
 	"github.com/scionproto/scion/go/lib/infra"
 	"github.com/scionproto/scion/go/lib/infra/modules/combinator"
 	"github.com/scionproto/scion/go/lib/infra/modules/segfetcher"
+	"github.com/scionproto/scion/go/lib/infra/modules/seghandler"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/pathdb"
 	"github.com/scionproto/scion/go/lib/revcache"
@@ -57,12 +58,13 @@ type Fetcher interface {
 
 type fetcher struct {
 	pather segfetcher.Pather
+	hidden *hiddenFetcher
 	config config.SDConfig
 }
 
 func NewFetcher(requestAPI segfetcher.RequestAPI, pathDB pathdb.PathDB, inspector infra.ASInspector,
 	verificationFactory infra.VerificationFactory, revCache revcache.RevCache, cfg config.SDConfig,
-	topoProvider topology.Provider) Fetcher {
+	topoProvider topology.Provider, hpCfg HiddenPathConfig) Fetcher {
 
 	localIA := topoProvider.Get().IA()
 	return &fetcher{
@@ -87,6 +89,19 @@ func NewFetcher(requestAPI segfetcher.RequestAPI, pathDB pathdb.PathDB, inspecto
 				LocalInfo:        neverLocal{},
 			}.New(),
 		},
+		hidden: &hiddenFetcher{
+			localIA:   localIA,
+			groups:    hpCfg.Groups,
+			server:    hpCfg.Server,
+			requester: hpCfg.Requester,
+			replyHandler: &seghandler.Handler{
+				Verifier: &seghandler.DefaultVerifier{
+					Verifier: verificationFactory.NewVerifier(),
+				},
+				Storage: &seghandler.DefaultStorage{PathDB: pathDB, RevCache: revCache},
+			},
+			pathDB: pathDB,
+		},
 		config: cfg,
 	}
 }
@@ -111,7 +126,13 @@ func (f *fetcher) GetPaths(ctx context.Context, req *sciond.PathReq,
 		return &sciond.PathReply{ErrorCode: sciond.ErrorBadSrcIA},
 			serrors.New("Bad source AS", "src", req.Src.IA())
 	}
-	cPaths, err := f.pather.GetPaths(ctx, req.Dst.IA(), req.Flags.Refresh)
+	var cPaths []*combinator.Path
+	var err error
+	if req.Flags.Hidden {
+		cPaths, err = f.getHiddenPaths(ctx, req)
+	} else {
+		cPaths, err = f.pather.GetPaths(ctx, req.Dst.IA(), req.Flags.Refresh)
+	}
 	switch {
 	case err == nil:
 		break
@@ -144,6 +165,72 @@ func (f *fetcher) GetPaths(ctx context.Context, req *sciond.PathReq,
 	return &sciond.PathReply{ErrorCode: sciond.ErrorOk, Entries: paths}, nil
 }
 
+// getHiddenPaths returns the paths to the destination that are built from the
+// hidden down segments of the requested hidden path groups and the public
+// segments. Public paths to the destination are included as well.
+func (f *fetcher) getHiddenPaths(ctx context.Context,
+	req *sciond.PathReq) ([]*combinator.Path, error) {
+
+	src := f.pather.TopoProvider.Get().IA()
+	dst := req.Dst.IA()
+	if dst.I == 0 || dst.IsWildcard() {
+		return nil, serrors.WithCtx(segfetcher.ErrBadDst, "dst", dst)
+	}
+	if dst.Equal(src) {
+		return f.pather.GetPaths(ctx, dst, req.Flags.Refresh)
+	}
+	ids, err := f.hidden.GroupIDs(req.HPCfgs)
+	if err != nil {
+		return nil, err
+	}
+	hiddenDowns, err := f.hidden.FetchDownSegs(ctx, dst, ids)
+	if err != nil {
+		return nil, err
+	}
+	logger := log.FromCtx(ctx)
+	segs, err := f.fetchSegs(ctx, src, dst, req.Flags.Refresh)
+	if err != nil {
+		logger.Debug("Failed to fetch public segments", "dst", dst, "err", err)
+	}
+	segs.Down = append(segs.Down, hiddenDowns...)
+	// The hidden down segments have to be reached with public segments.
+	for _, start := range hiddenDowns.FirstIAs() {
+		if start.Equal(src) {
+			continue
+		}
+		startSegs, err := f.fetchSegs(ctx, src, start, req.Flags.Refresh)
+		if err != nil {
+			logger.Debug("Failed to fetch public segments", "dst", start, "err", err)
+			continue
+		}
+		segs.Up = append(segs.Up, startSegs.Up...)
+		segs.Core = append(segs.Core, startSegs.Core...)
+	}
+	segs = segfetcher.Segments{
+		Up:   uniqueSegs(segs.Up),
+		Core: uniqueSegs(segs.Core),
+		Down: uniqueSegs(segs.Down),
+	}
+	paths, err := f.pather.BuildPaths(ctx, src, dst, segs)
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, segfetcher.ErrNoPaths
+	}
+	return paths, nil
+}
+
+func (f *fetcher) fetchSegs(ctx context.Context, src, dst addr.IA,
+	refresh bool) (segfetcher.Segments, error) {
+
+	req := segfetcher.Request{Src: src, Dst: dst}
+	if refresh {
+		req.State = segfetcher.Fetch
+	}
+	return f.pather.Fetcher.FetchSegs(ctx, req)
+}
+
 // translate returns a translated sciond.PathReplyEntry objects from the
 // combinator path.
 //
@@ -179,6 +266,7 @@ func (f *fetcher) translate(path *combinator.Path) (sciond.PathReplyEntry, error
 			Mtu:        path.Mtu,
 			Interfaces: path.Interfaces,
 			ExpTime:    uint32(path.ComputeExpTime().Unix()),
+			Metadata:   path.Metadata,
 		},
 		HostInfo: hostinfo.FromUDPAddr(*nextHop),
 	}
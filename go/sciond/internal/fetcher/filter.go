@@ -17,6 +17,7 @@ package fetcher
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/scionproto/scion/go/lib/infra/modules/combinator"
 	"github.com/scionproto/scion/go/lib/pathpol"
@@ -28,10 +29,24 @@ type Policy interface {
 	Filter(pathpol.PathSet) pathpol.PathSet
 }
 
+// sorter is implemented by policies that define an order on paths.
+type sorter interface {
+	Sort(pathpol.PathSet) []pathpol.Path
+}
+
 // Filter filters the given paths with the given policy. Note that this
-// function might change the order of elements.
+// function might change the order of elements. If the policy defines an
+// order, the returned paths are ordered accordingly.
 func Filter(paths []*combinator.Path, policy Policy) []*combinator.Path {
-	return psToPaths(policy.Filter(pathsToPs(paths)))
+	ps := policy.Filter(pathsToPs(paths))
+	if s, ok := policy.(sorter); ok {
+		sorted := make([]*combinator.Path, 0, len(ps))
+		for _, wp := range s.Sort(ps) {
+			sorted = append(sorted, wp.(pathWrap).origPath)
+		}
+		return sorted
+	}
+	return psToPaths(ps)
 }
 
 func pathsToPs(paths []*combinator.Path) pathpol.PathSet {
@@ -73,3 +88,6 @@ func newPathWrap(p *combinator.Path) pathWrap {
 
 func (p pathWrap) Interfaces() []snet.PathInterface  { return p.intfs }
 func (p pathWrap) Fingerprint() snet.PathFingerprint { return p.key }
+func (p pathWrap) MTU() uint16                       { return p.origPath.Mtu }
+func (p pathWrap) Expiry() time.Time                 { return p.origPath.ComputeExpTime() }
+func (p pathWrap) Metadata() *snet.PathMetadata      { return p.origPath.Metadata.ToSnet() }
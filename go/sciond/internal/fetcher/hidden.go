@@ -0,0 +1,209 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetcher
+
+import (
+	"context"
+	"net"
+	"sort"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/hiddenpath"
+	"github.com/scionproto/scion/go/lib/infra/messenger"
+	"github.com/scionproto/scion/go/lib/infra/modules/segfetcher"
+	"github.com/scionproto/scion/go/lib/infra/modules/seghandler"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/pathdb"
+	"github.com/scionproto/scion/go/lib/pathdb/query"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/proto"
+)
+
+// Hidden path errors.
+var (
+	// ErrHiddenPathsDisabled indicates that hidden paths were requested but no
+	// hidden path groups are configured.
+	ErrHiddenPathsDisabled = serrors.New("hidden paths not configured")
+	// ErrUnknownHPGroup indicates that a requested hidden path group is not
+	// configured.
+	ErrUnknownHPGroup = serrors.New("unknown hidden path group")
+	// ErrNotHPReader indicates that the local AS is not allowed to read the
+	// segments of a requested hidden path group.
+	ErrNotHPReader = serrors.New("local AS is not a reader of hidden path group")
+)
+
+// HPSegRequester requests hidden path segments.
+type HPSegRequester interface {
+	GetHPSegs(ctx context.Context, msg *path_mgmt.HPSegReq, a net.Addr,
+		id uint64) (*path_mgmt.HPSegReply, error)
+}
+
+// HiddenPathConfig is the configuration for hidden path lookups. Hidden path
+// lookups are disabled if no groups are configured.
+type HiddenPathConfig struct {
+	// Groups are the hidden path groups for which segments can be requested.
+	Groups map[hiddenpath.GroupId]*hiddenpath.Group
+	// Server is the address of the hidden path server that hidden path
+	// segment requests are sent to.
+	Server net.Addr
+	// Requester is used to request hidden path segments from the server.
+	Requester HPSegRequester
+}
+
+// hiddenFetcher fetches, verifies and stores hidden path segments.
+type hiddenFetcher struct {
+	localIA      addr.IA
+	groups       map[hiddenpath.GroupId]*hiddenpath.Group
+	server       net.Addr
+	requester    HPSegRequester
+	replyHandler segfetcher.ReplyHandler
+	pathDB       pathdb.Read
+}
+
+// GroupIDs returns the hidden path groups that are used for the request. If
+// no group is requested explicitly, all configured groups that the local AS
+// is allowed to read are used.
+func (f *hiddenFetcher) GroupIDs(raw []*path_mgmt.HPGroupId) ([]hiddenpath.GroupId, error) {
+	if len(f.groups) == 0 {
+		return nil, ErrHiddenPathsDisabled
+	}
+	var ids []hiddenpath.GroupId
+	if len(raw) == 0 {
+		for id, group := range f.groups {
+			if f.canRead(group) {
+				ids = append(ids, id)
+			}
+		}
+		if len(ids) == 0 {
+			return nil, ErrNotHPReader
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i].String() < ids[j].String() })
+		return ids, nil
+	}
+	for _, r := range raw {
+		id := hiddenpath.IdFromMsg(r)
+		group, ok := f.groups[id]
+		if !ok {
+			return nil, serrors.WithCtx(ErrUnknownHPGroup, "group", id)
+		}
+		if !f.canRead(group) {
+			return nil, serrors.WithCtx(ErrNotHPReader, "group", id)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// FetchDownSegs requests the hidden down segments to dst for the given
+// groups, verifies and stores them. It returns all non-expired hidden down
+// segments to dst of the groups in the path database. If the request fails,
+// only the segments that are already stored are returned.
+func (f *hiddenFetcher) FetchDownSegs(ctx context.Context, dst addr.IA,
+	ids []hiddenpath.GroupId) (seg.Segments, error) {
+
+	logger := log.FromCtx(ctx)
+	if err := f.request(ctx, dst, ids); err != nil {
+		logger.Warn("Failed to fetch hidden path segments, using cached segments",
+			"dst", dst, "err", err)
+	}
+	res, err := f.pathDB.Get(ctx, &query.Params{
+		EndsAt:   []addr.IA{dst},
+		SegTypes: []proto.PathSegType{proto.PathSegType_down},
+		HpCfgIDs: hpCfgIDs(ids),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.Segs(), nil
+}
+
+func (f *hiddenFetcher) request(ctx context.Context, dst addr.IA,
+	ids []hiddenpath.GroupId) error {
+
+	req := &path_mgmt.HPSegReq{
+		RawDstIA: dst.IAInt(),
+		GroupIds: make([]*path_mgmt.HPGroupId, 0, len(ids)),
+	}
+	for _, id := range ids {
+		req.GroupIds = append(req.GroupIds, id.ToMsg())
+	}
+	reply, err := f.requester.GetHPSegs(ctx, req, f.server, messenger.NextId())
+	if err != nil {
+		return err
+	}
+	logger := log.FromCtx(ctx)
+	var errs serrors.List
+	for _, recs := range reply.Recs {
+		if recs == nil || recs.GroupId == nil {
+			continue
+		}
+		id := hiddenpath.IdFromMsg(recs.GroupId)
+		if recs.Err != "" {
+			errs = append(errs, serrors.New("registry error", "group", id, "err", recs.Err))
+			continue
+		}
+		// Crypto material is looked up at the local CS, thus no server is
+		// passed to the handler.
+		r := f.replyHandler.Handle(ctx, seghandler.Segments{Segs: recs.Recs, HPGroupID: id},
+			nil, nil)
+		select {
+		case <-r.FullReplyProcessed():
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if err := r.Err(); err != nil {
+			errs = append(errs, serrors.WithCtx(err, "group", id))
+			continue
+		}
+		logger.Debug("Stored hidden path segments", "group", id,
+			"inserted", r.Stats().SegsInserted(), "updated", r.Stats().SegsUpdated())
+	}
+	return errs.ToError()
+}
+
+func (f *hiddenFetcher) canRead(group *hiddenpath.Group) bool {
+	return group.Owner.Equal(f.localIA) || group.HasReader(f.localIA)
+}
+
+// hpCfgIDs converts the group IDs to the hidden path config IDs that are used
+// as keys in the path database. The conversion is shared with the segment
+// handler that stores the segments, such that the keys match.
+func hpCfgIDs(ids []hiddenpath.GroupId) []*query.HPCfgID {
+	cfgIDs := make([]*query.HPCfgID, 0, len(ids))
+	for _, id := range ids {
+		cfgIDs = append(cfgIDs, seghandler.ConvertHPGroupID(id)...)
+	}
+	return cfgIDs
+}
+
+// uniqueSegs returns the segments without duplicates.
+func uniqueSegs(segs seg.Segments) seg.Segments {
+	seen := make(map[string]struct{}, len(segs))
+	var unique seg.Segments
+	for _, s := range segs {
+		id, err := s.ID()
+		if err != nil {
+			continue
+		}
+		if _, ok := seen[string(id)]; ok {
+			continue
+		}
+		seen[string(id)] = struct{}{}
+		unique = append(unique, s)
+	}
+	return unique
+}
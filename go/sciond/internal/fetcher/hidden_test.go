@@ -0,0 +1,237 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/hiddenpath"
+	"github.com/scionproto/scion/go/lib/infra/modules/seghandler"
+	"github.com/scionproto/scion/go/lib/infra/modules/segverifier"
+	"github.com/scionproto/scion/go/lib/pathdb/mem"
+	"github.com/scionproto/scion/go/lib/pathdb/query"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/lib/xtest/graph"
+	"github.com/scionproto/scion/go/proto"
+)
+
+var (
+	ia110 = xtest.MustParseIA("1-ff00:0:110")
+	ia111 = xtest.MustParseIA("1-ff00:0:111")
+	ia112 = xtest.MustParseIA("1-ff00:0:112")
+
+	group1 = &hiddenpath.Group{
+		Id:         hiddenpath.GroupId{OwnerAS: ia110.A, Suffix: 1},
+		Version:    1,
+		Owner:      ia110,
+		Writers:    []addr.IA{ia111},
+		Readers:    []addr.IA{ia112},
+		Registries: []addr.IA{ia110},
+	}
+	group2 = &hiddenpath.Group{
+		Id:         hiddenpath.GroupId{OwnerAS: ia111.A, Suffix: 2},
+		Version:    1,
+		Owner:      ia111,
+		Writers:    []addr.IA{ia111},
+		Readers:    []addr.IA{ia112},
+		Registries: []addr.IA{ia111},
+	}
+	group3 = &hiddenpath.Group{
+		Id:         hiddenpath.GroupId{OwnerAS: ia111.A, Suffix: 3},
+		Version:    1,
+		Owner:      ia111,
+		Writers:    []addr.IA{ia111},
+		Readers:    []addr.IA{ia110},
+		Registries: []addr.IA{ia111},
+	}
+	groups = map[hiddenpath.GroupId]*hiddenpath.Group{
+		group1.Id: group1,
+		group2.Id: group2,
+		group3.Id: group3,
+	}
+)
+
+func TestHiddenFetcherGroupIDs(t *testing.T) {
+	tests := map[string]struct {
+		Groups      map[hiddenpath.GroupId]*hiddenpath.Group
+		Requested   []*path_mgmt.HPGroupId
+		ExpectedIDs []hiddenpath.GroupId
+		ExpectedErr error
+	}{
+		"no groups configured": {
+			Requested:   []*path_mgmt.HPGroupId{group1.Id.ToMsg()},
+			ExpectedErr: ErrHiddenPathsDisabled,
+		},
+		"all readable groups": {
+			Groups:      groups,
+			ExpectedIDs: []hiddenpath.GroupId{group1.Id, group2.Id},
+		},
+		"requested groups": {
+			Groups:      groups,
+			Requested:   []*path_mgmt.HPGroupId{group2.Id.ToMsg()},
+			ExpectedIDs: []hiddenpath.GroupId{group2.Id},
+		},
+		"unknown group": {
+			Groups: groups,
+			Requested: []*path_mgmt.HPGroupId{
+				group1.Id.ToMsg(),
+				{OwnerAS: ia112.A, GroupId: 4},
+			},
+			ExpectedErr: ErrUnknownHPGroup,
+		},
+		"not a reader": {
+			Groups:      groups,
+			Requested:   []*path_mgmt.HPGroupId{group3.Id.ToMsg()},
+			ExpectedErr: ErrNotHPReader,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			f := &hiddenFetcher{localIA: ia112, groups: test.Groups}
+			ids, err := f.GroupIDs(test.Requested)
+			if test.ExpectedErr != nil {
+				assert.True(t, errors.Is(err, test.ExpectedErr), "err: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.ExpectedIDs, ids)
+		})
+	}
+}
+
+func TestHiddenFetcherFetchDownSegs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	g := graph.NewDefaultGraph(ctrl)
+	hiddenSeg := g.Beacon([]common.IFIDType{graph.If_120_X_111_B})
+	otherGroupSeg := g.Beacon([]common.IFIDType{graph.If_130_B_111_A})
+	publicSeg := g.Beacon([]common.IFIDType{graph.If_110_X_130_A, graph.If_130_B_111_A})
+	dst := hiddenSeg.LastIA()
+
+	tests := map[string]struct {
+		Reply       *path_mgmt.HPSegReply
+		ReplyErr    error
+		Stored      []*seg.PathSegment
+		ExpectedIDs []string
+	}{
+		"segments are stored and returned": {
+			Reply: &path_mgmt.HPSegReply{
+				Recs: []*path_mgmt.HPSegRecs{
+					{
+						GroupId: group1.Id.ToMsg(),
+						Recs: []*seg.Meta{
+							{Type: proto.PathSegType_down, Segment: hiddenSeg},
+						},
+					},
+					{
+						GroupId: group2.Id.ToMsg(),
+						Err:     "registry unavailable",
+					},
+				},
+			},
+			ExpectedIDs: []string{hiddenSeg.GetLoggingID()},
+		},
+		"cached segments on request error": {
+			ReplyErr:    errors.New("test error"),
+			Stored:      []*seg.PathSegment{hiddenSeg},
+			ExpectedIDs: []string{hiddenSeg.GetLoggingID()},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancelF := context.WithTimeout(context.Background(), time.Second)
+			defer cancelF()
+			db := mem.New()
+			_, err := db.Insert(ctx, &seg.Meta{Type: proto.PathSegType_down, Segment: publicSeg})
+			require.NoError(t, err)
+			_, err = db.InsertWithHPCfgIDs(ctx,
+				&seg.Meta{Type: proto.PathSegType_down, Segment: otherGroupSeg},
+				hpCfgIDs([]hiddenpath.GroupId{group3.Id}))
+			require.NoError(t, err)
+			for _, s := range test.Stored {
+				_, err := db.InsertWithHPCfgIDs(ctx,
+					&seg.Meta{Type: proto.PathSegType_down, Segment: s},
+					hpCfgIDs([]hiddenpath.GroupId{group1.Id}))
+				require.NoError(t, err)
+			}
+			requester := &fakeHPSegRequester{reply: test.Reply, err: test.ReplyErr}
+			f := &hiddenFetcher{
+				localIA:   ia112,
+				groups:    groups,
+				server:    &net.TCPAddr{IP: net.IP{127, 0, 0, 1}, Port: 30260},
+				requester: requester,
+				replyHandler: &seghandler.Handler{
+					Verifier: acceptVerifier{},
+					Storage:  &seghandler.DefaultStorage{PathDB: db},
+				},
+				pathDB: db,
+			}
+			ids := []hiddenpath.GroupId{group1.Id, group2.Id}
+			segs, err := f.FetchDownSegs(ctx, dst, ids)
+			require.NoError(t, err)
+			var segIDs []string
+			for _, s := range segs {
+				segIDs = append(segIDs, s.GetLoggingID())
+			}
+			assert.ElementsMatch(t, test.ExpectedIDs, segIDs)
+			require.NotNil(t, requester.req)
+			assert.Equal(t, dst, requester.req.DstIA())
+			assert.Equal(t, []*path_mgmt.HPGroupId{group1.Id.ToMsg(), group2.Id.ToMsg()},
+				requester.req.GroupIds)
+			res, err := db.Get(ctx, &query.Params{HpCfgIDs: hpCfgIDs(ids)})
+			require.NoError(t, err)
+			assert.Len(t, res, 1)
+		})
+	}
+}
+
+type fakeHPSegRequester struct {
+	reply *path_mgmt.HPSegReply
+	err   error
+	req   *path_mgmt.HPSegReq
+}
+
+func (r *fakeHPSegRequester) GetHPSegs(_ context.Context, msg *path_mgmt.HPSegReq, _ net.Addr,
+	_ uint64) (*path_mgmt.HPSegReply, error) {
+
+	r.req = msg
+	return r.reply, r.err
+}
+
+// acceptVerifier accepts all segments without verification.
+type acceptVerifier struct{}
+
+func (acceptVerifier) Verify(_ context.Context, recs seghandler.Segments,
+	_ net.Addr) (chan segverifier.UnitResult, int) {
+
+	units := segverifier.BuildUnits(recs.Segs, recs.SRevInfos)
+	results := make(chan segverifier.UnitResult, len(units))
+	for _, unit := range units {
+		results <- segverifier.UnitResult{Unit: unit, Errors: map[int]error{}}
+	}
+	return results, len(units)
+}
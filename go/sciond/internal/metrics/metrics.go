@@ -31,7 +31,9 @@ const (
 	subsystemASInfo     = "as_info"
 	subsystemIFInfo     = "if_info"
 	subsystemSVCInfo    = "service_info"
+	subsystemSegTypeHop = "seg_type_hop"
 	subsystemRevocation = "revocation"
+	subsystemDRKey      = "drkey"
 )
 
 // Revocation sources
@@ -64,6 +66,10 @@ var (
 	IFInfos = newIFInfo()
 	// SVCInfos contains metrics for SVC info requests.
 	SVCInfos = newSVCInfo()
+	// SegTypeHops contains metrics for segment type hop requests.
+	SegTypeHops = newSegTypeHop()
+	// DRKeyLvl2s contains metrics for DRKey level 2 requests.
+	DRKeyLvl2s = newDRKeyLvl2()
 )
 
 type resultLabel struct {
@@ -219,6 +225,16 @@ func newSVCInfo() Request {
 	}
 }
 
+func newSegTypeHop() Request {
+	return Request{
+		count: prom.NewCounterVecWithLabels(Namespace, subsystemSegTypeHop, "requests_total",
+			"The amount of segment type hop requests received.", resultLabel{}),
+		latency: prom.NewHistogramVecWithLabels(Namespace, subsystemSegTypeHop,
+			"request_duration_seconds", "Time to handle segment type hop requests.",
+			resultLabel{}, prom.DefaultLatencyBuckets),
+	}
+}
+
 func newIFInfo() Request {
 	return Request{
 		count: prom.NewCounterVecWithLabels(Namespace, subsystemIFInfo, "requests_total",
@@ -228,3 +244,13 @@ func newIFInfo() Request {
 			resultLabel{}, prom.DefaultLatencyBuckets),
 	}
 }
+
+func newDRKeyLvl2() Request {
+	return Request{
+		count: prom.NewCounterVecWithLabels(Namespace, subsystemDRKey, "lvl2_requests_total",
+			"The amount of DRKey level 2 requests received.", resultLabel{}),
+		latency: prom.NewHistogramVecWithLabels(Namespace, subsystemDRKey,
+			"lvl2_request_duration_seconds", "Time to handle DRKey level 2 requests.",
+			resultLabel{}, prom.DefaultLatencyBuckets),
+	}
+}
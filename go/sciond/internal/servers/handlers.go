@@ -15,21 +15,36 @@
 package servers
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"time"
 
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/drkey_mgmt"
 	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/drkey"
 	"github.com/scionproto/scion/go/lib/hostinfo"
+	"github.com/scionproto/scion/go/lib/hpkt"
 	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/infra/messenger"
 	"github.com/scionproto/scion/go/lib/infra/modules/itopo"
 	"github.com/scionproto/scion/go/lib/infra/modules/segfetcher"
 	"github.com/scionproto/scion/go/lib/infra/modules/segverifier"
 	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/pathdb"
+	"github.com/scionproto/scion/go/lib/pathdb/query"
 	"github.com/scionproto/scion/go/lib/revcache"
 	"github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/lib/scmp"
 	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/spkt"
+	"github.com/scionproto/scion/go/lib/util"
 	"github.com/scionproto/scion/go/proto"
 	"github.com/scionproto/scion/go/sciond/internal/fetcher"
 	"github.com/scionproto/scion/go/sciond/internal/metrics"
@@ -47,6 +62,9 @@ const (
 	DefaultServiceTTL uint32 = 300
 )
 
+// errSCMPAuth indicates that the authentication of a revocation failed.
+var errSCMPAuth = serrors.New("SCMP authentication failed")
+
 type Handler interface {
 	Handle(ctx context.Context, conn net.Conn, src net.Addr, pld *sciond.Pld)
 }
@@ -252,6 +270,96 @@ func (h *SVCInfoRequestHandler) Handle(ctx context.Context, conn net.Conn,
 	metricsDone(metrics.OkSuccess)
 }
 
+// SegTypeHopRequestHandler represents the shared global state for the handling
+// of all SegTypeHopReq queries. The requests are answered with the segments
+// stored in the path database.
+type SegTypeHopRequestHandler struct {
+	PathDB pathdb.Read
+}
+
+func (h *SegTypeHopRequestHandler) Handle(ctx context.Context, conn net.Conn,
+	src net.Addr, pld *sciond.Pld) {
+
+	defer conn.Close()
+	metricsDone := metrics.SegTypeHops.Start()
+	logger := log.FromCtx(ctx)
+	logger.Debug("[SegTypeHopRequestHandler] Received request", "req", pld.SegTypeHopReq)
+	workCtx, workCancelF := context.WithTimeout(ctx, DefaultWorkTimeout)
+	defer workCancelF()
+	result := metrics.OkSuccess
+	segTypeHopReply, err := h.segTypeHop(workCtx, pld.SegTypeHopReq.Type)
+	if err != nil {
+		logger.Error("Unable to get segments from path database", "err", err)
+		result = metrics.ErrInternal
+		// The protocol does not support errors, an empty reply indicates
+		// that no segments are available.
+		segTypeHopReply = &sciond.SegTypeHopReply{}
+	}
+	reply := &sciond.Pld{
+		Id:              pld.Id,
+		Which:           proto.SCIONDMsg_Which_segTypeHopReply,
+		SegTypeHopReply: segTypeHopReply,
+	}
+	conn.SetWriteDeadline(time.Now().Add(DefaultReplyTimeout))
+	if err := sciond.Send(reply, conn); err != nil {
+		logger.Warn("Unable to reply to client", "client", src, "err", err)
+		metricsDone(metrics.ErrNetwork)
+		return
+	}
+	logger.Trace("Sent reply", "segTypeHop", segTypeHopReply)
+	metricsDone(result)
+}
+
+func (h *SegTypeHopRequestHandler) segTypeHop(ctx context.Context,
+	segType proto.PathSegType) (*sciond.SegTypeHopReply, error) {
+
+	res, err := h.PathDB.Get(ctx, &query.Params{SegTypes: []proto.PathSegType{segType}})
+	if err != nil {
+		return nil, err
+	}
+	reply := &sciond.SegTypeHopReply{Entries: make([]sciond.SegTypeHopReplyEntry, 0, len(res))}
+	for _, r := range res {
+		entry, err := segTypeHopEntry(r.Seg)
+		if err != nil {
+			log.FromCtx(ctx).Info("Skipping invalid segment", "seg", r.Seg, "err", err)
+			continue
+		}
+		reply.Entries = append(reply.Entries, entry)
+	}
+	return reply, nil
+}
+
+// segTypeHopEntry lists the interfaces of the segment in construction
+// direction. AS entries without hop entries are skipped.
+func segTypeHopEntry(ps *seg.PathSegment) (sciond.SegTypeHopReplyEntry, error) {
+	info, err := ps.InfoF()
+	if err != nil {
+		return sciond.SegTypeHopReplyEntry{}, err
+	}
+	entry := sciond.SegTypeHopReplyEntry{
+		Timestamp: info.TsInt,
+		ExpTime:   util.TimeToSecs(ps.MinExpiry()),
+	}
+	for _, ase := range ps.ASEntries {
+		if len(ase.HopEntries) == 0 {
+			continue
+		}
+		hop, err := ase.HopEntries[0].HopField()
+		if err != nil {
+			return sciond.SegTypeHopReplyEntry{}, err
+		}
+		for _, ifid := range []common.IFIDType{hop.ConsIngress, hop.ConsEgress} {
+			if ifid != 0 {
+				entry.Interfaces = append(entry.Interfaces, sciond.PathInterface{
+					RawIsdas: ase.IA().IAInt(),
+					IfID:     ifid,
+				})
+			}
+		}
+	}
+	return entry, nil
+}
+
 // RevNotificationHandler represents the shared global state for the handling of all
 // RevNotification announcements. The SCIOND API spawns a goroutine with method Handle
 // for each RevNotification it receives.
@@ -259,6 +367,12 @@ type RevNotificationHandler struct {
 	RevCache         revcache.RevCache
 	VerifierFactory  infra.VerificationFactory
 	NextQueryCleaner segfetcher.NextQueryCleaner
+	// SCMPVerifier verifies the authenticity of the SCMP packet a revocation
+	// was received in. If nil, the authenticity is not verified.
+	SCMPVerifier snet.SCMPVerifier
+	// StrictSCMPAuth indicates that revocations without authentication are
+	// rejected. Revocations that fail authentication are always rejected.
+	StrictSCMPAuth bool
 }
 
 func (h *RevNotificationHandler) Handle(ctx context.Context, conn net.Conn,
@@ -278,6 +392,9 @@ func (h *RevNotificationHandler) Handle(ctx context.Context, conn net.Conn,
 	revNotification := pld.RevNotification
 	revReply := &sciond.RevReply{}
 	revInfo, err := h.verifySRevInfo(workCtx, revNotification.SRevInfo)
+	if err == nil {
+		err = h.verifySCMPAuth(workCtx, revNotification)
+	}
 	if err == nil {
 		_, err = h.RevCache.Insert(workCtx, revNotification.SRevInfo)
 		if err != nil {
@@ -326,6 +443,63 @@ func (h *RevNotificationHandler) verifySRevInfo(ctx context.Context,
 	return info, err
 }
 
+// verifySCMPAuth verifies the authenticity of the SCMP packet the revocation
+// was received in. Revocations without authentication are only rejected in
+// strict mode.
+func (h *RevNotificationHandler) verifySCMPAuth(ctx context.Context,
+	notification *sciond.RevNotification) error {
+
+	if h.SCMPVerifier == nil {
+		return nil
+	}
+	err := h.verifySCMPPacket(ctx, notification)
+	if err == nil || (errors.Is(err, snet.ErrSCMPUnauthenticated) && !h.StrictSCMPAuth) {
+		return nil
+	}
+	return serrors.Wrap(errSCMPAuth, err)
+}
+
+// verifySCMPPacket checks that the raw SCMP packet contains the revocation and
+// that the packet is authenticated.
+func (h *RevNotificationHandler) verifySCMPPacket(ctx context.Context,
+	notification *sciond.RevNotification) error {
+
+	if len(notification.RawPkt) == 0 {
+		return snet.ErrSCMPUnauthenticated
+	}
+	scnPkt := &spkt.ScnPkt{}
+	if err := hpkt.ParseScnPkt(scnPkt, notification.RawPkt); err != nil {
+		return serrors.WrapStr("unable to parse SCMP packet", err)
+	}
+	pld, ok := scnPkt.Pld.(*scmp.Payload)
+	if !ok {
+		return serrors.New("not an SCMP packet", "type", common.TypeOf(scnPkt.Pld))
+	}
+	info, ok := pld.Info.(*scmp.InfoRevocation)
+	if !ok {
+		return serrors.New("SCMP packet does not contain a revocation",
+			"type", common.TypeOf(pld.Info))
+	}
+	sRevInfo, err := path_mgmt.NewSignedRevInfoFromRaw(info.RawSRev)
+	if err != nil {
+		return serrors.WrapStr("unable to parse revocation in SCMP packet", err)
+	}
+	if !bytes.Equal(sRevInfo.Blob, notification.SRevInfo.Blob) {
+		return serrors.New("revocation does not match SCMP packet")
+	}
+	return h.SCMPVerifier.Verify(ctx, &snet.SCIONPacket{
+		Bytes: snet.Bytes(notification.RawPkt),
+		SCIONPacketInfo: snet.SCIONPacketInfo{
+			Destination: snet.SCIONAddress{IA: scnPkt.DstIA, Host: scnPkt.DstHost},
+			Source:      snet.SCIONAddress{IA: scnPkt.SrcIA, Host: scnPkt.SrcHost},
+			Path:        scnPkt.Path,
+			Extensions:  append(scnPkt.HBHExt, scnPkt.E2EExt...),
+			L4Header:    scnPkt.L4,
+			Payload:     scnPkt.Pld,
+		},
+	})
+}
+
 // isValid is a placeholder. It should return true if and only if revocation
 // verification ended with an outcome of valid.
 func isValid(err error) bool {
@@ -340,10 +514,11 @@ func isStale(err error) bool {
 }
 
 // isInvalid is a placeholder. It should return true if and only if revocation
-// verification ended with an outcome of invalid.
+// verification ended with an outcome of invalid. Currently, only failed SCMP
+// authentication is classified as invalid.
 func isInvalid(err error) bool {
 	// FIXME(scrye): implement this once we have verification
-	return false
+	return errors.Is(err, errSCMPAuth)
 }
 
 // isUnknown is a placeholder. It should return true if and only if revocation
@@ -351,3 +526,74 @@ func isInvalid(err error) bool {
 func isUnknown(err error) bool {
 	return err != nil
 }
+
+// DRKeyRequester requests level 2 DRKeys from the control service.
+type DRKeyRequester interface {
+	RequestDRKeyLvl2(ctx context.Context, msg *drkey_mgmt.Lvl2Req, a net.Addr,
+		id uint64) (*drkey_mgmt.Lvl2Rep, error)
+}
+
+// DRKeyGetter fetches level 2 DRKeys from the control service of the local
+// AS. It is used to verify the authenticity of SCMP revocations.
+type DRKeyGetter struct {
+	Requester DRKeyRequester
+	IA        addr.IA
+}
+
+func (g DRKeyGetter) DRKeyGetLvl2Key(ctx context.Context, meta drkey.Lvl2Meta,
+	valTime time.Time) (drkey.Lvl2Key, error) {
+
+	csAddr := &snet.SVCAddr{IA: g.IA, SVC: addr.SvcCS}
+	rep, err := g.Requester.RequestDRKeyLvl2(ctx, drkey_mgmt.NewLvl2ReqFromMeta(meta, valTime),
+		csAddr, messenger.NextId())
+	if err != nil {
+		return drkey.Lvl2Key{}, err
+	}
+	if len(rep.Key) == 0 {
+		return drkey.Lvl2Key{}, serrors.New("DRKey not available")
+	}
+	return rep.ToKey(meta), nil
+}
+
+// DRKeyLvl2RequestHandler represents the shared global state for the handling
+// of all DRKey level 2 requests. The requests are forwarded to the control
+// service of the local AS.
+type DRKeyLvl2RequestHandler struct {
+	Requester DRKeyRequester
+	IA        addr.IA
+}
+
+func (h *DRKeyLvl2RequestHandler) Handle(ctx context.Context, conn net.Conn,
+	src net.Addr, pld *sciond.Pld) {
+
+	defer conn.Close()
+	metricsDone := metrics.DRKeyLvl2s.Start()
+	logger := log.FromCtx(ctx)
+	logger.Debug("[DRKeyLvl2RequestHandler] Received request", "req", pld.DrkeyLvl2Req)
+	workCtx, workCancelF := context.WithTimeout(ctx, DefaultWorkTimeout)
+	defer workCancelF()
+	result := metrics.OkSuccess
+	csAddr := &snet.SVCAddr{IA: h.IA, SVC: addr.SvcCS}
+	rep, err := h.Requester.RequestDRKeyLvl2(workCtx, pld.DrkeyLvl2Req, csAddr,
+		messenger.NextId())
+	if err != nil {
+		logger.Error("Unable to get DRKey from control service", "err", err)
+		result = metrics.ErrNetwork
+		// The protocol does not support errors, a reply without a key
+		// indicates that the key is not available.
+		rep = &drkey_mgmt.Lvl2Rep{TimestampRaw: util.TimeToSecs(time.Now())}
+	}
+	reply := &sciond.Pld{
+		Id:             pld.Id,
+		Which:          proto.SCIONDMsg_Which_drkeyLvl2Reply,
+		DrkeyLvl2Reply: rep,
+	}
+	conn.SetWriteDeadline(time.Now().Add(DefaultReplyTimeout))
+	if err := sciond.Send(reply, conn); err != nil {
+		logger.Warn("Unable to reply to client", "client", src, "err", err)
+		metricsDone(metrics.ErrNetwork)
+		return
+	}
+	logger.Trace("Sent reply", "drkey", rep)
+	metricsDone(result)
+}
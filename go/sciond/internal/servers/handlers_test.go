@@ -0,0 +1,142 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	capnp "zombiezen.com/go/capnproto2"
+
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/pathdb/mock_pathdb"
+	"github.com/scionproto/scion/go/lib/pathdb/pathdbtest"
+	"github.com/scionproto/scion/go/lib/pathdb/query"
+	"github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/proto"
+)
+
+var (
+	ia330 = xtest.MustParseIA("1-ff00:0:330")
+	ia331 = xtest.MustParseIA("1-ff00:0:331")
+	ia332 = xtest.MustParseIA("1-ff00:0:332")
+)
+
+func TestSegTypeHopRequestHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ts := util.TimeToSecs(time.Now())
+	// The AS entry of 1-ff00:0:331 has two hop entries, only the first one is
+	// listed.
+	newSeg := func(t *testing.T) *seg.PathSegment {
+		ps, _ := pathdbtest.AllocPathSegment(t, ctrl, []uint64{0, 1, 2, 3, 4, 5, 6, 0}, ts)
+		return ps
+	}
+	downParams := &query.Params{SegTypes: []proto.PathSegType{proto.PathSegType_down}}
+	downReq := &sciond.Pld{
+		Id:            42,
+		Which:         proto.SCIONDMsg_Which_segTypeHopReq,
+		SegTypeHopReq: &sciond.SegTypeHopReq{Type: proto.PathSegType_down},
+	}
+
+	t.Run("interfaces in construction direction", func(t *testing.T) {
+		ps := newSeg(t)
+		db := mock_pathdb.NewMockReadWrite(ctrl)
+		db.EXPECT().Get(gomock.Any(), downParams).Return(
+			query.Results{{Seg: ps, Type: proto.PathSegType_down}}, nil)
+
+		reply := handleSegTypeHop(t, &SegTypeHopRequestHandler{PathDB: db}, downReq)
+		assert.Equal(t, uint64(42), reply.Id)
+		assert.Equal(t, proto.SCIONDMsg_Which_segTypeHopReply, reply.Which)
+		require.Len(t, reply.SegTypeHopReply.Entries, 1)
+		entry := reply.SegTypeHopReply.Entries[0]
+		assert.Equal(t, ts, entry.Timestamp)
+		assert.Equal(t, util.TimeToSecs(ps.MinExpiry()), entry.ExpTime)
+		expected := []sciond.PathInterface{
+			{RawIsdas: ia330.IAInt(), IfID: 1},
+			{RawIsdas: ia331.IAInt(), IfID: 2},
+			{RawIsdas: ia331.IAInt(), IfID: 3},
+			{RawIsdas: ia332.IAInt(), IfID: 6},
+		}
+		assert.Equal(t, expected, entry.Interfaces)
+	})
+	t.Run("segment type filter", func(t *testing.T) {
+		coreParams := &query.Params{SegTypes: []proto.PathSegType{proto.PathSegType_core}}
+		db := mock_pathdb.NewMockReadWrite(ctrl)
+		db.EXPECT().Get(gomock.Any(), coreParams).Return(
+			query.Results{{Seg: newSeg(t), Type: proto.PathSegType_core}}, nil)
+
+		req := &sciond.Pld{
+			Id:            43,
+			Which:         proto.SCIONDMsg_Which_segTypeHopReq,
+			SegTypeHopReq: &sciond.SegTypeHopReq{Type: proto.PathSegType_core},
+		}
+		reply := handleSegTypeHop(t, &SegTypeHopRequestHandler{PathDB: db}, req)
+		assert.Len(t, reply.SegTypeHopReply.Entries, 1)
+	})
+	t.Run("AS entry without hop entries", func(t *testing.T) {
+		ps := newSeg(t)
+		ps.ASEntries[1].HopEntries = nil
+		db := mock_pathdb.NewMockReadWrite(ctrl)
+		db.EXPECT().Get(gomock.Any(), downParams).Return(
+			query.Results{{Seg: ps, Type: proto.PathSegType_down}}, nil)
+
+		reply := handleSegTypeHop(t, &SegTypeHopRequestHandler{PathDB: db}, downReq)
+		require.Len(t, reply.SegTypeHopReply.Entries, 1)
+		expected := []sciond.PathInterface{
+			{RawIsdas: ia330.IAInt(), IfID: 1},
+			{RawIsdas: ia332.IAInt(), IfID: 6},
+		}
+		assert.Equal(t, expected, reply.SegTypeHopReply.Entries[0].Interfaces)
+	})
+	t.Run("DB error", func(t *testing.T) {
+		db := mock_pathdb.NewMockReadWrite(ctrl)
+		db.EXPECT().Get(gomock.Any(), downParams).Return(nil, serrors.New("test error"))
+
+		reply := handleSegTypeHop(t, &SegTypeHopRequestHandler{PathDB: db}, downReq)
+		assert.Equal(t, uint64(42), reply.Id)
+		assert.Equal(t, proto.SCIONDMsg_Which_segTypeHopReply, reply.Which)
+		require.NotNil(t, reply.SegTypeHopReply)
+		assert.Empty(t, reply.SegTypeHopReply.Entries)
+	})
+}
+
+// handleSegTypeHop passes the request to the handler and returns the reply
+// written to the connection.
+func handleSegTypeHop(t *testing.T, h *SegTypeHopRequestHandler,
+	req *sciond.Pld) *sciond.Pld {
+
+	client, server := net.Pipe()
+	defer client.Close()
+	go h.Handle(context.Background(), server, &net.UnixAddr{}, req)
+
+	require.NoError(t, client.SetReadDeadline(time.Now().Add(time.Second)))
+	msg, err := proto.SafeDecode(capnp.NewDecoder(client))
+	require.NoError(t, err)
+	root, err := msg.RootPtr()
+	require.NoError(t, err)
+	reply := &sciond.Pld{}
+	require.NoError(t, proto.SafeExtract(reply, proto.SCIONDMsg_TypeID, root.Struct()))
+	return reply
+}
@@ -17,8 +17,10 @@ package main
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"io/ioutil"
 	"net"
 	"net/http"
 	_ "net/http/pprof"
@@ -32,6 +34,7 @@ import (
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/env"
 	"github.com/scionproto/scion/go/lib/fatal"
+	"github.com/scionproto/scion/go/lib/hiddenpath"
 	"github.com/scionproto/scion/go/lib/infra"
 	"github.com/scionproto/scion/go/lib/infra/infraenv"
 	"github.com/scionproto/scion/go/lib/infra/messenger/tcp"
@@ -46,6 +49,7 @@ import (
 	"github.com/scionproto/scion/go/lib/prom"
 	"github.com/scionproto/scion/go/lib/revcache"
 	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/topology"
 	"github.com/scionproto/scion/go/proto"
 	"github.com/scionproto/scion/go/sciond/internal/config"
@@ -145,6 +149,17 @@ func realMain() int {
 		return 1
 	}
 
+	var scmpVerifier snet.SCMPVerifier
+	if cfg.SD.SCMPAuth != config.SCMPAuthDisabled {
+		scmpVerifier = &snet.DRKeySCMPVerifier{
+			Keys: servers.DRKeyGetter{Requester: msger, IA: itopo.Get().IA()},
+		}
+	}
+	hpCfg, err := hiddenPathConfig(msger)
+	if err != nil {
+		log.Crit("Unable to initialize hidden path lookups", "err", err)
+		return 1
+	}
 	handlers := servers.HandlerMap{
 		proto.SCIONDMsg_Which_pathReq: &servers.PathRequestHandler{
 			Fetcher: fetcher.NewFetcher(
@@ -155,6 +170,7 @@ func realMain() int {
 				revCache,
 				cfg.SD,
 				itopo.Provider(),
+				hpCfg,
 			),
 		},
 		proto.SCIONDMsg_Which_asInfoReq: &servers.ASInfoRequestHandler{
@@ -162,10 +178,19 @@ func realMain() int {
 		},
 		proto.SCIONDMsg_Which_ifInfoRequest:      &servers.IFInfoRequestHandler{},
 		proto.SCIONDMsg_Which_serviceInfoRequest: &servers.SVCInfoRequestHandler{},
+		proto.SCIONDMsg_Which_segTypeHopReq: &servers.SegTypeHopRequestHandler{
+			PathDB: pathDB,
+		},
 		proto.SCIONDMsg_Which_revNotification: &servers.RevNotificationHandler{
 			RevCache:         revCache,
 			VerifierFactory:  verificationFactory{Provider: trustStore},
 			NextQueryCleaner: segfetcher.NextQueryCleaner{PathDB: pathDB},
+			SCMPVerifier:     scmpVerifier,
+			StrictSCMPAuth:   cfg.SD.SCMPAuth == config.SCMPAuthStrict,
+		},
+		proto.SCIONDMsg_Which_drkeyLvl2Req: &servers.DRKeyLvl2RequestHandler{
+			Requester: msger,
+			IA:        itopo.Get().IA(),
 		},
 	}
 	cleaner := periodic.Start(pathdb.NewCleaner(pathDB, "sd_segments"),
@@ -203,6 +228,40 @@ func (v verificationFactory) NewVerifier() infra.Verifier {
 	return trust.NewVerifier(v.Provider)
 }
 
+// hiddenPathConfig loads the configured hidden path groups.
+func hiddenPathConfig(requester fetcher.HPSegRequester) (fetcher.HiddenPathConfig, error) {
+	if len(cfg.SD.HiddenPathGroups) == 0 {
+		return fetcher.HiddenPathConfig{}, nil
+	}
+	server, err := net.ResolveTCPAddr("tcp", cfg.SD.HiddenPathServer)
+	if err != nil {
+		return fetcher.HiddenPathConfig{}, serrors.WrapStr("unable to resolve hidden path server",
+			err, "addr", cfg.SD.HiddenPathServer)
+	}
+	groups := make(map[hiddenpath.GroupId]*hiddenpath.Group, len(cfg.SD.HiddenPathGroups))
+	for _, file := range cfg.SD.HiddenPathGroups {
+		b, err := ioutil.ReadFile(file)
+		if err != nil {
+			return fetcher.HiddenPathConfig{}, serrors.WrapStr(
+				"unable to read hidden path group file", err, "file", file)
+		}
+		group := &hiddenpath.Group{}
+		if err := json.Unmarshal(b, group); err != nil {
+			return fetcher.HiddenPathConfig{}, serrors.WrapStr(
+				"unable to parse hidden path group file", err, "file", file)
+		}
+		groups[group.Id] = group
+	}
+	return fetcher.HiddenPathConfig{
+		Groups: groups,
+		Server: &snet.UDPAddr{
+			IA:   itopo.Get().IA(),
+			Host: &net.UDPAddr{IP: server.IP, Port: server.Port, Zone: server.Zone},
+		},
+		Requester: requester,
+	}, nil
+}
+
 func setupBasic() error {
 	if _, err := toml.DecodeFile(env.ConfigFile(), &cfg); err != nil {
 		return serrors.New("Failed to load config", "err", err, "file", env.ConfigFile())
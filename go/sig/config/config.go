@@ -22,11 +22,20 @@ import (
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/pathpol"
+	"github.com/scionproto/scion/go/lib/pktcls"
+	"github.com/scionproto/scion/go/sig/mgmt"
 )
 
 // Cfg is a direct Go representation of the JSON file format.
 type Cfg struct {
-	ASes          map[addr.IA]*ASEntry
+	ASes map[addr.IA]*ASEntry
+	// Classes contains the traffic classes that can be referenced by the
+	// sessions of the remote ASes.
+	Classes pktcls.ClassMap `json:",omitempty"`
+	// PathPolicies contains the path policies that can be referenced by the
+	// remote ASes and their sessions. Policies can extend each other.
+	PathPolicies  pathpol.PolicyMap `json:",omitempty"`
 	ConfigVersion uint64
 }
 
@@ -40,9 +49,115 @@ func LoadFromFile(path string) (*Cfg, error) {
 	if err := json.Unmarshal(b, cfg); err != nil {
 		return nil, common.NewBasicError("Unable to parse SIG config", err)
 	}
+	if err := cfg.Validate(); err != nil {
+		return nil, common.NewBasicError("Invalid SIG config", err)
+	}
 	return cfg, nil
 }
 
+// Validate checks that the sessions of all remote ASes are valid, that they
+// only reference configured traffic classes and path policies, and that all
+// path policies compile.
+func (cfg *Cfg) Validate() error {
+	compiler := pathpol.NewCompiler(cfg.PathPolicies)
+	for name := range cfg.PathPolicies {
+		if _, err := compiler.Compile(name); err != nil {
+			return common.NewBasicError("Invalid path policy", err, "policy", name)
+		}
+	}
+	for ia, entry := range cfg.ASes {
+		if entry == nil {
+			continue
+		}
+		if err := cfg.validatePolicyRef(entry.PathPolicy); err != nil {
+			return common.NewBasicError("Invalid AS entry", err, "ia", ia)
+		}
+		ids := make(map[mgmt.SessionType]struct{})
+		for _, sess := range entry.Sessions {
+			if _, ok := ids[sess.ID]; ok {
+				return common.NewBasicError("Duplicate session ID", nil, "ia", ia, "id", sess.ID)
+			}
+			ids[sess.ID] = struct{}{}
+			for _, class := range sess.Classes {
+				if _, ok := cfg.Classes[class]; !ok {
+					return common.NewBasicError("Unknown traffic class", nil,
+						"ia", ia, "id", sess.ID, "class", class)
+				}
+			}
+			if err := cfg.validatePolicyRef(sess.PathPolicy); err != nil {
+				return common.NewBasicError("Invalid session", err, "ia", ia, "id", sess.ID)
+			}
+		}
+	}
+	return nil
+}
+
+func (cfg *Cfg) validatePolicyRef(name string) error {
+	if name == "" {
+		return nil
+	}
+	if _, ok := cfg.PathPolicies[name]; !ok {
+		return common.NewBasicError("Unknown path policy", nil, "policy", name)
+	}
+	return nil
+}
+
+// PathPolicy returns the compiled path policy with the given name. For an
+// empty name, nil is returned, i.e., paths are not filtered.
+func (cfg *Cfg) PathPolicy(name string) (*pathpol.Policy, error) {
+	if name == "" {
+		return nil, nil
+	}
+	compiled, err := pathpol.NewCompiler(cfg.PathPolicies).Compile(name)
+	if err != nil {
+		return nil, err
+	}
+	return compiled.Policy, nil
+}
+
 type ASEntry struct {
 	Nets []*IPNet
+	// Sessions contains the sessions to the remote AS. Flows are distributed
+	// among the sessions. If no session is configured, all traffic is sent
+	// on a single session with ID 0.
+	Sessions []*Session `json:",omitempty"`
+	// PathPolicy is the name of the path policy used by the sessions to the
+	// remote AS that do not specify their own path policy.
+	PathPolicy string `json:",omitempty"`
+}
+
+// Session is the configuration of a session to a remote AS.
+type Session struct {
+	// ID is the session ID. It must be unique per remote AS.
+	ID mgmt.SessionType
+	// Weight is the relative share of flows the session carries compared to
+	// the other sessions of the same traffic class. A weight of 0 is
+	// interpreted as 1.
+	Weight uint `json:",omitempty"`
+	// Classes contains the names of the traffic classes the session carries.
+	// Sessions without classes carry the traffic that does not match any
+	// class. If there are no such sessions, this traffic is distributed among
+	// all sessions.
+	Classes []string `json:",omitempty"`
+	// PathPolicy is the name of the path policy that filters the paths the
+	// session uses. It overrides the path policy of the remote AS. Path
+	// policies are applied in addition to the global path policy of the SIG.
+	PathPolicy string `json:",omitempty"`
+}
+
+// SessionPathPolicy returns the name of the path policy of the session.
+func (e *ASEntry) SessionPathPolicy(sess *Session) string {
+	if sess.PathPolicy != "" {
+		return sess.PathPolicy
+	}
+	return e.PathPolicy
+}
+
+// SessionsOrDefault returns the configured sessions. If none are configured,
+// the single default session is returned.
+func (e *ASEntry) SessionsOrDefault() []*Session {
+	if len(e.Sessions) == 0 {
+		return []*Session{{ID: 0}}
+	}
+	return e.Sessions
 }
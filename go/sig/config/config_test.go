@@ -19,10 +19,15 @@ import (
 	"net"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/pathpol"
+	"github.com/scionproto/scion/go/lib/pktcls"
+	"github.com/scionproto/scion/go/lib/util"
 	"github.com/scionproto/scion/go/lib/xtest"
 )
 
@@ -71,6 +76,89 @@ func TestLoadFromFile(t *testing.T) {
 				ConfigVersion: 9001,
 			},
 		},
+		{
+			Name:     "sessions",
+			FileName: "02-sessions",
+			Config: Cfg{
+				ASes: map[addr.IA]*ASEntry{
+					xtest.MustParseIA("1-ff00:0:1"): {
+						Nets: []*IPNet{
+							{
+								IP:   net.IP{192, 0, 2, 0},
+								Mask: net.CIDRMask(24, 8*net.IPv4len),
+							},
+							{
+								IP:   net.ParseIP("2001:db8::"),
+								Mask: net.CIDRMask(32, 8*net.IPv6len),
+							},
+						},
+						Sessions: []*Session{
+							{ID: 0},
+							{ID: 1, Weight: 2},
+							{ID: 2, Classes: []string{"voip", "voip6"}},
+						},
+					},
+				},
+				Classes: pktcls.ClassMap{
+					"voip": pktcls.NewClass("voip", pktcls.NewCondIPv4(
+						&pktcls.IPv4MatchDSCP{DSCP: 0x2e})),
+					"voip6": pktcls.NewClass("voip6", pktcls.NewCondAllOf(
+						pktcls.NewCondIPv6(&pktcls.IPv6MatchTrafficClass{TC: 0xb8}),
+						pktcls.NewCondL4(&pktcls.L4MatchDstPort{
+							Ports: pktcls.PortRange{Min: 5060, Max: 5061},
+						}),
+					)),
+				},
+				ConfigVersion: 9002,
+			},
+		},
+		{
+			Name:     "path policies",
+			FileName: "03-policies",
+			Config: Cfg{
+				ASes: map[addr.IA]*ASEntry{
+					xtest.MustParseIA("1-ff00:0:1"): {
+						Nets: []*IPNet{
+							{
+								IP:   net.IP{192, 0, 2, 0},
+								Mask: net.CIDRMask(24, 8*net.IPv4len),
+							},
+						},
+						Sessions: []*Session{
+							{ID: 0},
+							{ID: 1, Classes: []string{"voip"}, PathPolicy: "voice"},
+						},
+						PathPolicy: "bulk",
+					},
+				},
+				Classes: pktcls.ClassMap{
+					"voip": pktcls.NewClass("voip", pktcls.NewCondIPv4(
+						&pktcls.IPv4MatchDSCP{DSCP: 0x2e})),
+				},
+				PathPolicies: pathpol.PolicyMap{
+					"low-latency": &pathpol.ExtPolicy{
+						Policy: &pathpol.Policy{
+							Filters: &pathpol.Filters{
+								MaxLatency: &util.DurWrap{Duration: 50 * time.Millisecond},
+							},
+							Order: pathpol.Order{pathpol.OrderLatency},
+						},
+					},
+					"voice": &pathpol.ExtPolicy{
+						Extends: []string{"low-latency"},
+						Policy: &pathpol.Policy{
+							Filters: &pathpol.Filters{MaxHops: intPtr(5)},
+						},
+					},
+					"bulk": &pathpol.ExtPolicy{
+						Policy: &pathpol.Policy{
+							Order: pathpol.Order{pathpol.OrderBandwidth},
+						},
+					},
+				},
+				ConfigVersion: 9003,
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -86,6 +174,113 @@ func TestLoadFromFile(t *testing.T) {
 	}
 }
 
+func TestValidate(t *testing.T) {
+	ia := xtest.MustParseIA("1-ff00:0:1")
+	classes := pktcls.ClassMap{
+		"voip": pktcls.NewClass("voip", pktcls.CondBool(true)),
+	}
+	tests := map[string]struct {
+		Sessions  []*Session
+		Assertion assert.ErrorAssertionFunc
+	}{
+		"no sessions": {
+			Assertion: assert.NoError,
+		},
+		"valid sessions": {
+			Sessions:  []*Session{{ID: 0}, {ID: 1, Classes: []string{"voip"}}},
+			Assertion: assert.NoError,
+		},
+		"duplicate session ID": {
+			Sessions:  []*Session{{ID: 1}, {ID: 1}},
+			Assertion: assert.Error,
+		},
+		"unknown class": {
+			Sessions:  []*Session{{ID: 0, Classes: []string{"bulk"}}},
+			Assertion: assert.Error,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := &Cfg{
+				ASes:    map[addr.IA]*ASEntry{ia: {Sessions: test.Sessions}},
+				Classes: classes,
+			}
+			test.Assertion(t, cfg.Validate())
+		})
+	}
+}
+
+func TestValidatePathPolicies(t *testing.T) {
+	ia := xtest.MustParseIA("1-ff00:0:1")
+	policies := pathpol.PolicyMap{
+		"base": &pathpol.ExtPolicy{Policy: &pathpol.Policy{}},
+		"ext":  &pathpol.ExtPolicy{Extends: []string{"base"}},
+	}
+	tests := map[string]struct {
+		Entry     *ASEntry
+		Policies  pathpol.PolicyMap
+		Assertion assert.ErrorAssertionFunc
+	}{
+		"valid references": {
+			Entry: &ASEntry{
+				PathPolicy: "base",
+				Sessions:   []*Session{{ID: 0}, {ID: 1, PathPolicy: "ext"}},
+			},
+			Policies:  policies,
+			Assertion: assert.NoError,
+		},
+		"unknown AS policy": {
+			Entry:     &ASEntry{PathPolicy: "unknown"},
+			Policies:  policies,
+			Assertion: assert.Error,
+		},
+		"unknown session policy": {
+			Entry:     &ASEntry{Sessions: []*Session{{ID: 0, PathPolicy: "unknown"}}},
+			Policies:  policies,
+			Assertion: assert.Error,
+		},
+		"unreferenced invalid policy": {
+			Entry: &ASEntry{},
+			Policies: pathpol.PolicyMap{
+				"a": &pathpol.ExtPolicy{Extends: []string{"b"}},
+				"b": &pathpol.ExtPolicy{Extends: []string{"a"}},
+			},
+			Assertion: assert.Error,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := &Cfg{
+				ASes:         map[addr.IA]*ASEntry{ia: test.Entry},
+				PathPolicies: test.Policies,
+			}
+			test.Assertion(t, cfg.Validate())
+		})
+	}
+}
+
+func TestPathPolicy(t *testing.T) {
+	cfg, err := LoadFromFile(filepath.Join("testdata", "03-policies.json"))
+	require.NoError(t, err)
+	entry := cfg.ASes[xtest.MustParseIA("1-ff00:0:1")]
+
+	assert.Equal(t, "bulk", entry.SessionPathPolicy(entry.Sessions[0]))
+	assert.Equal(t, "voice", entry.SessionPathPolicy(entry.Sessions[1]))
+
+	policy, err := cfg.PathPolicy("voice")
+	require.NoError(t, err)
+	assert.Equal(t, "voice", policy.Name)
+	assert.Equal(t, 5, *policy.Filters.MaxHops)
+	// The order is inherited from the extended policy.
+	assert.Equal(t, pathpol.Order{pathpol.OrderLatency}, policy.Order)
+
+	policy, err = cfg.PathPolicy("")
+	assert.NoError(t, err)
+	assert.Nil(t, policy)
+	_, err = cfg.PathPolicy("unknown")
+	assert.Error(t, err)
+}
+
 func TestIPNetUnmarshalJSON(t *testing.T) {
 	tests := []struct {
 		Name  string
@@ -142,3 +337,7 @@ func TestIPNetUnmarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func intPtr(i int) *int {
+	return &i
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/pathpol"
 	"github.com/scionproto/scion/go/lib/ringbuf"
 	"github.com/scionproto/scion/go/sig/config"
 	"github.com/scionproto/scion/go/sig/egress/dispatcher"
@@ -32,6 +33,7 @@ import (
 	"github.com/scionproto/scion/go/sig/egress/selector"
 	"github.com/scionproto/scion/go/sig/egress/session"
 	"github.com/scionproto/scion/go/sig/internal/base"
+	"github.com/scionproto/scion/go/sig/mgmt"
 )
 
 const (
@@ -49,7 +51,8 @@ type ASEntry struct {
 	version           uint64 // used to track certain changes made to ASEntry
 	log.Logger
 
-	Session *session.Session
+	Sessions map[mgmt.SessionType]*session.Session
+	selector *selector.MultiSession
 }
 
 func newASEntry(ia addr.IA) (*ASEntry, error) {
@@ -59,15 +62,8 @@ func newASEntry(ia addr.IA) (*ASEntry, error) {
 		IAString:          ia.String(),
 		Nets:              make(map[string]*net.IPNet),
 		healthMonitorStop: make(chan struct{}),
-	}
-	var err error
-	pool, err := session.NewPathPool(ia)
-	if err != nil {
-		return nil, err
-	}
-	ae.Session, err = session.NewSession(ia, 0, ae.Logger, pool)
-	if err != nil {
-		return nil, err
+		Sessions:          make(map[mgmt.SessionType]*session.Session),
+		selector:          selector.NewMultiSession(nil, nil),
 	}
 	return ae, nil
 }
@@ -75,11 +71,119 @@ func newASEntry(ia addr.IA) (*ASEntry, error) {
 func (ae *ASEntry) ReloadConfig(cfg *config.Cfg, cfgEntry *config.ASEntry) bool {
 	ae.Lock()
 	defer ae.Unlock()
+	// Sessions first, such that traffic to new networks can be forwarded.
+	s := ae.reloadSessions(cfg, cfgEntry)
 	// Method calls first to prevent skips due to logical short-circuit
-	s := ae.addNewNets(cfgEntry.Nets)
+	s = ae.addNewNets(cfgEntry.Nets) && s
 	return ae.delOldNets(cfgEntry.Nets) && s
 }
 
+// reloadSessions creates the configured sessions that do not exist yet,
+// updates the path policies of the existing sessions, updates the session
+// selector, and removes the sessions that are no longer configured.
+func (ae *ASEntry) reloadSessions(cfg *config.Cfg, cfgEntry *config.ASEntry) bool {
+	s := true
+	cfgSessions := cfgEntry.SessionsOrDefault()
+	for _, cfgSess := range cfgSessions {
+		policyName := cfgEntry.SessionPathPolicy(cfgSess)
+		policy, err := cfg.PathPolicy(policyName)
+		if err != nil {
+			ae.Error("Unable to compile path policy", "id", cfgSess.ID,
+				"policy", policyName, "err", err)
+			s = false
+			continue
+		}
+		if sess, ok := ae.Sessions[cfgSess.ID]; ok {
+			if pool, ok := sess.PathPool().(*session.PathPool); ok {
+				pool.SetPolicy(policy)
+			}
+			continue
+		}
+		if err := ae.addSession(cfgSess.ID, policy); err != nil {
+			ae.Error("Unable to add session", "id", cfgSess.ID, "err", err)
+			s = false
+		}
+	}
+	ae.selector.Update(ae.buildSelection(cfg, cfgSessions))
+	// Remove old sessions after the selector no longer references them.
+Top:
+	for id, sess := range ae.Sessions {
+		for _, cfgSess := range cfgSessions {
+			if cfgSess.ID == id {
+				continue Top
+			}
+		}
+		delete(ae.Sessions, id)
+		if err := sess.Cleanup(); err != nil {
+			sess.Error("Error cleaning up session", "err", err)
+			s = false
+		}
+		ae.Info("Removed session", "id", id)
+	}
+	return s
+}
+
+func (ae *ASEntry) addSession(id mgmt.SessionType, policy *pathpol.Policy) error {
+	pool, err := session.NewPathPool(ae.IA, policy)
+	if err != nil {
+		return err
+	}
+	sess, err := session.NewSession(ae.IA, id, ae.Logger, pool)
+	if err != nil {
+		return err
+	}
+	ae.Sessions[id] = sess
+	if ae.egressRing != nil {
+		// The network setup is done, i.e., the existing sessions are running.
+		sess.Start()
+	}
+	ae.Info("Added session", "id", id)
+	return nil
+}
+
+// buildSelection maps the configured traffic classes to the sessions that
+// carry them, and determines the default sessions.
+func (ae *ASEntry) buildSelection(cfg *config.Cfg,
+	cfgSessions []*config.Session) ([]selector.ClassSessions, []selector.WeightedSession) {
+
+	var classes []selector.ClassSessions
+	classIdx := make(map[string]int)
+	var defaults, all []selector.WeightedSession
+	for _, cfgSess := range cfgSessions {
+		sess, ok := ae.Sessions[cfgSess.ID]
+		if !ok {
+			continue
+		}
+		weight := float64(cfgSess.Weight)
+		if weight == 0 {
+			weight = 1
+		}
+		ws := selector.WeightedSession{Session: sess, Weight: weight}
+		all = append(all, ws)
+		if len(cfgSess.Classes) == 0 {
+			defaults = append(defaults, ws)
+		}
+		for _, name := range cfgSess.Classes {
+			idx, ok := classIdx[name]
+			if !ok {
+				class, ok := cfg.Classes[name]
+				if !ok {
+					ae.Error("Unknown traffic class", "id", cfgSess.ID, "class", name)
+					continue
+				}
+				idx = len(classes)
+				classIdx[name] = idx
+				classes = append(classes, selector.ClassSessions{Class: class})
+			}
+			classes[idx].Sessions = append(classes[idx].Sessions, ws)
+		}
+	}
+	if len(defaults) == 0 {
+		defaults = all
+	}
+	return classes, defaults
+}
+
 // addNewNets adds the networks in ipnets that are not currently configured.
 func (ae *ASEntry) addNewNets(ipnets []*config.IPNet) bool {
 	s := true
@@ -203,8 +307,14 @@ func (ae *ASEntry) performHealthCheck(prevHealth *bool, prevVersion *uint64) {
 	*prevVersion = ae.version
 }
 
+// checkHealth returns true if at least one session is healthy.
 func (ae *ASEntry) checkHealth() bool {
-	return ae.Session.Healthy()
+	for _, sess := range ae.Sessions {
+		if sess.Healthy() {
+			return true
+		}
+	}
+	return false
 }
 
 func (ae *ASEntry) Cleanup() error {
@@ -225,8 +335,12 @@ func (ae *ASEntry) Cleanup() error {
 }
 
 func (ae *ASEntry) cleanSessions() {
-	if err := ae.Session.Cleanup(); err != nil {
-		ae.Session.Error("Error cleaning up session", "err", err)
+	ae.selector.Update(nil, nil)
+	for id, sess := range ae.Sessions {
+		if err := sess.Cleanup(); err != nil {
+			sess.Error("Error cleaning up session", "err", err)
+		}
+		delete(ae.Sessions, id)
 	}
 }
 
@@ -234,13 +348,14 @@ func (ae *ASEntry) setupNet() {
 	ae.egressRing = ringbuf.New(iface.EgressRemotePkts, nil, fmt.Sprintf("egress_%s", ae.IAString))
 	go func() {
 		defer log.LogPanicAndExit()
-		dispatcher.NewDispatcher(ae.IA, ae.egressRing,
-			&selector.SingleSession{Session: ae.Session}).Run()
+		dispatcher.NewDispatcher(ae.IA, ae.egressRing, ae.selector).Run()
 	}()
 	go func() {
 		defer log.LogPanicAndExit()
 		ae.monitorHealth()
 	}()
-	ae.Session.Start()
+	for _, sess := range ae.Sessions {
+		sess.Start()
+	}
 	ae.Info("Network setup done")
 }
@@ -66,12 +66,25 @@ type Session interface {
 	// Healthy returns true if the session has a remote SIG and is receiving
 	// keepalive responses from it.
 	Healthy() bool
+	// PathHealth returns the measured health of the path the session
+	// currently uses.
+	PathHealth() PathHealth
 	// PathPool returns the session's available pool of paths.
 	PathPool() PathPool
 	// AnnounceWorkerStopped is used to inform the session that its worker needed to shut down.
 	AnnounceWorkerStopped()
 }
 
+// PathHealth is the health of a session path, measured with the keepalive
+// probes of the session.
+type PathHealth struct {
+	// Loss is the smoothed fraction of probes that are not answered.
+	Loss float64
+	// RTT is the smoothed round trip time of the probes. It is zero if no RTT
+	// has been measured yet.
+	RTT time.Duration
+}
+
 type RemoteInfo struct {
 	Sig      *siginfo.Sig
 	SessPath *SessPath
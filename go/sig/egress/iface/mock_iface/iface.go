@@ -220,6 +220,20 @@ func (mr *MockSessionMockRecorder) New(arg0 ...interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "New", reflect.TypeOf((*MockSession)(nil).New), arg0...)
 }
 
+// PathHealth mocks base method
+func (m *MockSession) PathHealth() iface.PathHealth {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PathHealth")
+	ret0, _ := ret[0].(iface.PathHealth)
+	return ret0
+}
+
+// PathHealth indicates an expected call of PathHealth
+func (mr *MockSessionMockRecorder) PathHealth() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PathHealth", reflect.TypeOf((*MockSession)(nil).PathHealth))
+}
+
 // PathPool mocks base method
 func (m *MockSession) PathPool() iface.PathPool {
 	m.ctrl.T.Helper()
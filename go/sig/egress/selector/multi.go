@@ -0,0 +1,203 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"hash/fnv"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/pktcls"
+	"github.com/scionproto/scion/go/sig/egress/iface"
+)
+
+const (
+	// healthSteps is the number of steps the health factor is quantized to.
+	healthSteps = 20
+	// minHealthFactor is the lower bound of the health factor. It ensures that
+	// degraded sessions are still used if all sessions are degraded.
+	minHealthFactor = 1.0 / healthSteps
+)
+
+var _ iface.SessionSelector = (*MultiSession)(nil)
+
+// WeightedSession is a session together with its relative share of flows.
+type WeightedSession struct {
+	Session iface.Session
+	// Weight is the relative share of flows compared to the other sessions of
+	// the same set. Sessions with weight 0 are never chosen.
+	Weight float64
+}
+
+// ClassSessions maps a traffic class to the sessions that carry it.
+type ClassSessions struct {
+	Class    *pktcls.Class
+	Sessions []WeightedSession
+}
+
+// MultiSession implements iface.SessionSelector. It distributes the flows
+// among multiple sessions. A flow is identified by the IP 5-tuple of the
+// packet, and is pinned to a session as long as the set of healthy sessions
+// and their path health do not change.
+//
+// Packets are first matched against the traffic classes in order. The first
+// matching class determines the set of sessions the packet is sent on. Packets
+// that do not match any class are sent on the default sessions. Within a set,
+// a session is chosen by weighted rendezvous hashing over the healthy
+// sessions. If no session of the set is healthy, all sessions are considered.
+//
+// The effective weight of a session is its configured weight scaled by the
+// health of its path: it decreases with the probe loss of the path, and with
+// the RTT of the path relative to the lowest RTT among the considered
+// sessions. Thus, traffic shifts away from degrading paths before they are
+// declared unhealthy.
+type MultiSession struct {
+	mtx      sync.RWMutex
+	classes  []ClassSessions
+	defaults []WeightedSession
+}
+
+// NewMultiSession creates a new selector with the given traffic classes and
+// default sessions.
+func NewMultiSession(classes []ClassSessions, defaults []WeightedSession) *MultiSession {
+	return &MultiSession{classes: classes, defaults: defaults}
+}
+
+// Update replaces the traffic classes and default sessions of the selector.
+func (ms *MultiSession) Update(classes []ClassSessions, defaults []WeightedSession) {
+	ms.mtx.Lock()
+	defer ms.mtx.Unlock()
+	ms.classes = classes
+	ms.defaults = defaults
+}
+
+func (ms *MultiSession) ChooseSess(b common.RawBytes) iface.Session {
+	ms.mtx.RLock()
+	defer ms.mtx.RUnlock()
+	sessions := ms.defaults
+	if len(ms.classes) > 0 {
+		pkt := pktcls.NewPacket(b)
+		for _, cs := range ms.classes {
+			if cs.Class.Eval(pkt) {
+				sessions = cs.Sessions
+				break
+			}
+		}
+	}
+	return chooseWeighted(sessions, flowHash(b))
+}
+
+// chooseWeighted chooses the session with the highest weighted rendezvous
+// score for the flow. Healthy sessions are preferred.
+func chooseWeighted(sessions []WeightedSession, flow uint64) iface.Session {
+	if s := chooseWeightedHealth(sessions, flow, true); s != nil {
+		return s
+	}
+	return chooseWeightedHealth(sessions, flow, false)
+}
+
+func chooseWeightedHealth(sessions []WeightedSession, flow uint64,
+	healthyOnly bool) iface.Session {
+
+	var minRTT time.Duration
+	for _, ws := range sessions {
+		if ws.Weight <= 0 || (healthyOnly && !ws.Session.Healthy()) {
+			continue
+		}
+		if rtt := ws.Session.PathHealth().RTT; rtt > 0 && (minRTT == 0 || rtt < minRTT) {
+			minRTT = rtt
+		}
+	}
+	var best iface.Session
+	bestScore := math.Inf(-1)
+	for _, ws := range sessions {
+		if ws.Weight <= 0 || (healthyOnly && !ws.Session.Healthy()) {
+			continue
+		}
+		weight := ws.Weight * healthFactor(ws.Session.PathHealth(), minRTT)
+		// Map the hash to a uniform value in (0,1). The score -w/ln(u) is
+		// distributed such that the session is chosen with probability
+		// proportional to its weight.
+		h := mix(flow ^ (uint64(ws.Session.ID())+1)*0x9e3779b97f4a7c15)
+		u := (float64(h>>11) + 0.5) / (1 << 53)
+		if score := -weight / math.Log(u); score > bestScore {
+			best, bestScore = ws.Session, score
+		}
+	}
+	return best
+}
+
+// healthFactor returns the factor in [minHealthFactor, 1] by which the weight
+// of a session is scaled. The factor is the fraction of answered probes times
+// the ratio of minRTT to the RTT of the path. It is quantized, such that small
+// fluctuations of the measurements do not move flows between sessions.
+func healthFactor(health iface.PathHealth, minRTT time.Duration) float64 {
+	factor := 1 - health.Loss
+	if health.RTT > 0 && minRTT > 0 {
+		factor *= float64(minRTT) / float64(health.RTT)
+	}
+	factor = math.Ceil(factor*healthSteps) / healthSteps
+	return math.Max(minHealthFactor, math.Min(1, factor))
+}
+
+// mix is the finalizer of the 64-bit MurmurHash3.
+func mix(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
+
+// flowHash returns the hash of the IP 5-tuple of the packet. For protocols
+// other than TCP and UDP, only the addresses and the protocol are used.
+// Packets that cannot be parsed all hash to the same value.
+func flowHash(b common.RawBytes) uint64 {
+	var addrs common.RawBytes
+	var proto uint8
+	var l4 common.RawBytes
+	if len(b) < 1 {
+		return 0
+	}
+	switch b[0] >> 4 {
+	case 4:
+		if len(b) < 20 {
+			return 0
+		}
+		hdrLen := int(b[0]&0x0f) * 4
+		addrs, proto = b[12:20], b[9]
+		if len(b) >= hdrLen {
+			l4 = b[hdrLen:]
+		}
+	case 6:
+		if len(b) < 40 {
+			return 0
+		}
+		// Extension headers are not traversed.
+		addrs, proto, l4 = b[8:40], b[6], b[40:]
+	default:
+		return 0
+	}
+	h := fnv.New64a()
+	h.Write(addrs)
+	h.Write([]byte{proto})
+	if (proto == 6 || proto == 17) && len(l4) >= 4 {
+		h.Write(l4[:4])
+	}
+	return h.Sum64()
+}
@@ -0,0 +1,232 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector_test
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/pktcls"
+	"github.com/scionproto/scion/go/sig/egress/iface"
+	"github.com/scionproto/scion/go/sig/egress/iface/mock_iface"
+	"github.com/scionproto/scion/go/sig/egress/selector"
+	"github.com/scionproto/scion/go/sig/mgmt"
+)
+
+func TestMultiSessionFlowPinning(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	sessions := []selector.WeightedSession{
+		{Session: newSession(ctrl, 0, true), Weight: 1},
+		{Session: newSession(ctrl, 1, true), Weight: 1},
+		{Session: newSession(ctrl, 2, true), Weight: 1},
+	}
+	ms := selector.NewMultiSession(nil, sessions)
+
+	seen := make(map[iface.Session]bool)
+	for port := uint16(1000); port < 1100; port++ {
+		pkt := udpPacket(t, 0, port)
+		chosen := ms.ChooseSess(pkt)
+		require.NotNil(t, chosen)
+		// All packets of the same flow use the same session.
+		assert.Equal(t, chosen, ms.ChooseSess(udpPacket(t, 0, port)))
+		seen[chosen] = true
+	}
+	assert.Len(t, seen, 3, "flows are spread over all sessions")
+}
+
+func TestMultiSessionWeights(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	light := newSession(ctrl, 0, true)
+	heavy := newSession(ctrl, 1, true)
+	ms := selector.NewMultiSession(nil, []selector.WeightedSession{
+		{Session: light, Weight: 1},
+		{Session: heavy, Weight: 3},
+	})
+	counts := make(map[iface.Session]int)
+	for port := uint16(0); port < 2000; port++ {
+		counts[ms.ChooseSess(udpPacket(t, 0, port))]++
+	}
+	assert.InDelta(t, 1500, counts[heavy], 150)
+	assert.InDelta(t, 500, counts[light], 150)
+}
+
+func TestMultiSessionHealth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	healthy := newSession(ctrl, 0, true)
+	unhealthy := newSession(ctrl, 1, false)
+	ms := selector.NewMultiSession(nil, []selector.WeightedSession{
+		{Session: healthy, Weight: 1},
+		{Session: unhealthy, Weight: 1},
+	})
+	for port := uint16(0); port < 100; port++ {
+		assert.Equal(t, healthy, ms.ChooseSess(udpPacket(t, 0, port)))
+	}
+
+	// If no session is healthy, the unhealthy sessions are used.
+	ms.Update(nil, []selector.WeightedSession{{Session: unhealthy, Weight: 1}})
+	assert.Equal(t, unhealthy, ms.ChooseSess(udpPacket(t, 0, 1000)))
+
+	ms.Update(nil, nil)
+	assert.Nil(t, ms.ChooseSess(udpPacket(t, 0, 1000)))
+}
+
+func TestMultiSessionPathHealth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var health iface.PathHealth
+	degrading := mock_iface.NewMockSession(ctrl)
+	degrading.EXPECT().ID().Return(mgmt.SessionType(0)).AnyTimes()
+	degrading.EXPECT().Healthy().Return(true).AnyTimes()
+	degrading.EXPECT().PathHealth().DoAndReturn(
+		func() iface.PathHealth { return health },
+	).AnyTimes()
+	stable := mock_iface.NewMockSession(ctrl)
+	stable.EXPECT().ID().Return(mgmt.SessionType(1)).AnyTimes()
+	stable.EXPECT().Healthy().Return(true).AnyTimes()
+	stable.EXPECT().PathHealth().Return(
+		iface.PathHealth{RTT: 10 * time.Millisecond},
+	).AnyTimes()
+	ms := selector.NewMultiSession(nil, []selector.WeightedSession{
+		{Session: degrading, Weight: 1},
+		{Session: stable, Weight: 1},
+	})
+	share := func() int {
+		var count int
+		for port := uint16(0); port < 3000; port++ {
+			if ms.ChooseSess(udpPacket(t, 0, port)) == degrading {
+				count++
+			}
+		}
+		return count
+	}
+
+	health = iface.PathHealth{RTT: 10 * time.Millisecond}
+	assert.InDelta(t, 1500, share(), 150, "equal health")
+	health = iface.PathHealth{Loss: 0.5, RTT: 10 * time.Millisecond}
+	assert.InDelta(t, 1000, share(), 150, "half of the probes lost")
+	health = iface.PathHealth{RTT: 20 * time.Millisecond}
+	assert.InDelta(t, 1000, share(), 150, "twice the RTT")
+	health = iface.PathHealth{Loss: 0.9, RTT: 20 * time.Millisecond}
+	assert.InDelta(t, 150, share(), 100, "lossy and slow")
+}
+
+func TestMultiSessionClasses(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	def := newSession(ctrl, 0, true)
+	voip := newSession(ctrl, 1, true)
+	class := pktcls.NewClass("voip", pktcls.NewCondIPv4(&pktcls.IPv4MatchDSCP{DSCP: 0x2e}))
+	ms := selector.NewMultiSession(
+		[]selector.ClassSessions{
+			{Class: class, Sessions: []selector.WeightedSession{{Session: voip, Weight: 1}}},
+		},
+		[]selector.WeightedSession{{Session: def, Weight: 1}},
+	)
+	for port := uint16(0); port < 20; port++ {
+		assert.Equal(t, voip, ms.ChooseSess(udpPacket(t, 0x2e<<2, port)))
+		assert.Equal(t, def, ms.ChooseSess(udpPacket(t, 0, port)))
+	}
+}
+
+func TestMultiSessionIPv6Classes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	def := newSession(ctrl, 0, true)
+	voip := newSession(ctrl, 1, true)
+	class, err := pktcls.BuildClassTree("ALL(tc=0xb8,proto=17,dport=5060-5061)")
+	require.NoError(t, err)
+	ms := selector.NewMultiSession(
+		[]selector.ClassSessions{
+			{
+				Class:    pktcls.NewClass("voip", class),
+				Sessions: []selector.WeightedSession{{Session: voip, Weight: 1}},
+			},
+		},
+		[]selector.WeightedSession{{Session: def, Weight: 1}},
+	)
+	for port := uint16(0); port < 20; port++ {
+		assert.Equal(t, voip, ms.ChooseSess(udp6Packet(t, 0xb8, port, 5060)))
+		assert.Equal(t, def, ms.ChooseSess(udp6Packet(t, 0xb8, port, 53)))
+		assert.Equal(t, def, ms.ChooseSess(udp6Packet(t, 0, port, 5061)))
+	}
+}
+
+func newSession(ctrl *gomock.Controller, id mgmt.SessionType,
+	healthy bool) *mock_iface.MockSession {
+
+	sess := mock_iface.NewMockSession(ctrl)
+	sess.EXPECT().ID().Return(id).AnyTimes()
+	sess.EXPECT().Healthy().Return(healthy).AnyTimes()
+	sess.EXPECT().PathHealth().Return(iface.PathHealth{}).AnyTimes()
+	return sess
+}
+
+// udpPacket creates an IPv4 UDP packet with the given ToS and source port.
+func udpPacket(t *testing.T, tos uint8, srcPort uint16) common.RawBytes {
+	t.Helper()
+	ip := &layers.IPv4{
+		Version:  4,
+		TOS:      tos,
+		TTL:      64,
+		Protocol: layers.IPProtocolUDP,
+		SrcIP:    net.IP{192, 0, 2, 1},
+		DstIP:    net.IP{198, 51, 100, 1},
+	}
+	udp := &layers.UDP{
+		SrcPort: layers.UDPPort(srcPort),
+		DstPort: layers.UDPPort(53),
+	}
+	require.NoError(t, udp.SetNetworkLayerForChecksum(ip))
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+	err := gopacket.SerializeLayers(buf, opts, ip, udp, gopacket.Payload([]byte("pld")))
+	require.NoError(t, err)
+	return buf.Bytes()
+}
+
+// udp6Packet creates an IPv6 UDP packet with the given traffic class and
+// ports.
+func udp6Packet(t *testing.T, tc uint8, srcPort, dstPort uint16) common.RawBytes {
+	t.Helper()
+	ip := &layers.IPv6{
+		Version:      6,
+		TrafficClass: tc,
+		HopLimit:     64,
+		NextHeader:   layers.IPProtocolUDP,
+		SrcIP:        net.ParseIP("2001:db8::1"),
+		DstIP:        net.ParseIP("2001:db8:1::1"),
+	}
+	udp := &layers.UDP{
+		SrcPort: layers.UDPPort(srcPort),
+		DstPort: layers.UDPPort(dstPort),
+	}
+	require.NoError(t, udp.SetNetworkLayerForChecksum(ip))
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+	err := gopacket.SerializeLayers(buf, opts, ip, udp, gopacket.Payload([]byte("pld")))
+	require.NoError(t, err)
+	return buf.Bytes()
+}
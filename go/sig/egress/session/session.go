@@ -21,12 +21,14 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/pathpol"
 	"github.com/scionproto/scion/go/lib/pktdisp"
 	"github.com/scionproto/scion/go/lib/ringbuf"
 	"github.com/scionproto/scion/go/lib/snet"
@@ -52,7 +54,9 @@ type Session struct {
 	// FIXME: Use AtomicRemoteInfo instead
 	currRemote atomic.Value
 	// FIXME: Use AtomicBool instead.
-	healthy        atomic.Value
+	healthy atomic.Value
+	// pathHealth holds the iface.PathHealth of the current path.
+	pathHealth     atomic.Value
 	ring           *ringbuf.Ring
 	conn           snet.Conn
 	sessMonStop    chan struct{}
@@ -60,6 +64,9 @@ type Session struct {
 	pktDispStop    chan struct{}
 	pktDispStopped chan struct{}
 	workerStopped  chan struct{}
+	// started indicates whether the session monitor and worker are running.
+	// Start and Cleanup must not be called concurrently.
+	started bool
 }
 
 func NewSession(dstIA addr.IA, sessId mgmt.SessionType, logger log.Logger,
@@ -74,6 +81,7 @@ func NewSession(dstIA addr.IA, sessId mgmt.SessionType, logger log.Logger,
 	}
 	s.currRemote.Store((*iface.RemoteInfo)(nil))
 	s.healthy.Store(false)
+	s.pathHealth.Store(iface.PathHealth{})
 	s.ring = ringbuf.New(64, nil, fmt.Sprintf("egress_%s_%s", dstIA, sessId))
 	// Not using a fixed local port, as this is for outgoing data only.
 	s.conn, err = sigcmn.Network.Listen(context.Background(), "udp",
@@ -93,6 +101,7 @@ func NewSession(dstIA addr.IA, sessId mgmt.SessionType, logger log.Logger,
 }
 
 func (s *Session) Start() {
+	s.started = true
 	go func() {
 		defer log.LogPanicAndExit()
 		newSessMonitor(s).run()
@@ -106,10 +115,12 @@ func (s *Session) Start() {
 func (s *Session) Cleanup() error {
 	s.ring.Close()
 	close(s.sessMonStop)
-	s.Debug("iface.Session Cleanup: wait for worker")
-	<-s.workerStopped
-	s.Debug("iface.Session Cleanup: wait for session monitor")
-	<-s.sessMonStopped
+	if s.started {
+		s.Debug("iface.Session Cleanup: wait for worker")
+		<-s.workerStopped
+		s.Debug("iface.Session Cleanup: wait for session monitor")
+		<-s.sessMonStopped
+	}
 	close(s.pktDispStop)
 	s.Debug("iface.Session Cleanup: wait for pktDisp")
 	s.conn.SetReadDeadline(time.Now())
@@ -149,6 +160,10 @@ func (s *Session) Healthy() bool {
 	return s.healthy.Load().(bool)
 }
 
+func (s *Session) PathHealth() iface.PathHealth {
+	return s.pathHealth.Load().(iface.PathHealth)
+}
+
 func (s *Session) PathPool() iface.PathPool {
 	return s.pool
 }
@@ -157,21 +172,35 @@ func (s *Session) AnnounceWorkerStopped() {
 	close(s.workerStopped)
 }
 
+// PathPool contains the paths to the remote AS that satisfy the global path
+// policy of the SIG and the path policy of the session. The session path policy
+// can be changed at runtime.
 type PathPool struct {
 	ia   addr.IA
 	pool *pathmgr.SyncPaths
+	// policy is the session path policy. Access must be protected by mtx.
+	policy *pathpol.Policy
+	mtx    sync.RWMutex
 }
 
 var _ iface.PathPool = (*PathPool)(nil)
 
-func NewPathPool(dst addr.IA) (*PathPool, error) {
-	pool, err := sigcmn.PathMgr.Watch(context.TODO(), sigcmn.IA, dst)
+func NewPathPool(dst addr.IA, policy *pathpol.Policy) (*PathPool, error) {
+	var pool *pathmgr.SyncPaths
+	var err error
+	if sigcmn.PathPolicy != nil {
+		pool, err = sigcmn.PathMgr.WatchFilter(context.TODO(), sigcmn.IA, dst,
+			sigcmn.PathPolicy)
+	} else {
+		pool, err = sigcmn.PathMgr.Watch(context.TODO(), sigcmn.IA, dst)
+	}
 	if err != nil {
 		return nil, common.NewBasicError("Unable to register watch", err)
 	}
 	return &PathPool{
-		ia:   dst,
-		pool: pool,
+		ia:     dst,
+		pool:   pool,
+		policy: policy,
 	}, nil
 }
 
@@ -181,5 +210,33 @@ func (pp *PathPool) Destroy() error {
 }
 
 func (pp *PathPool) Paths() spathmeta.AppPathSet {
-	return pp.pool.Load().APS
+	aps := pp.pool.Load().APS
+	policy := pp.Policy()
+	if policy == nil {
+		return aps
+	}
+	ps := make(pathpol.PathSet, len(aps))
+	for key, path := range aps {
+		ps[key] = path
+	}
+	filtered := make(spathmeta.AppPathSet)
+	for key, path := range policy.Filter(ps) {
+		filtered[key] = path.(snet.Path)
+	}
+	return filtered
+}
+
+// Policy returns the session path policy.
+func (pp *PathPool) Policy() *pathpol.Policy {
+	pp.mtx.RLock()
+	defer pp.mtx.RUnlock()
+	return pp.policy
+}
+
+// SetPolicy replaces the session path policy. The paths returned by Paths are
+// filtered by the new policy immediately.
+func (pp *PathPool) SetPolicy(policy *pathpol.Policy) {
+	pp.mtx.Lock()
+	defer pp.mtx.Unlock()
+	pp.policy = policy
 }
@@ -37,6 +37,8 @@ const (
 	tout          = 1 * time.Second
 	writeTout     = 100 * time.Millisecond
 	pathExpiryLen = 10 * time.Second
+	// healthAlpha is the smoothing factor of the path health measurements.
+	healthAlpha = 0.2
 )
 
 // sessMonitor is responsible for monitoring a session, polling remote SIGs, and switching
@@ -59,6 +61,10 @@ type sessMonitor struct {
 	updateMsgId mgmt.MsgIdType
 	// the last time a PollRep was received.
 	lastReply time.Time
+	// whether the last PollReq sent was answered.
+	answered bool
+	// the health of the path used for sending polls.
+	health iface.PathHealth
 }
 
 func newSessMonitor(sess *Session) *sessMonitor {
@@ -248,6 +254,8 @@ func (sm *sessMonitor) getNewPath(old *iface.SessPath, reason string) *iface.Ses
 	if report {
 		metrics.SessionPathSwitched.WithLabelValues(sm.sess.IA().String(),
 			sm.sess.SessId.String(), reason).Inc()
+		// The measurements of the old path do not apply to the new path.
+		sm.setPathHealth(iface.PathHealth{})
 	}
 	return res
 }
@@ -256,6 +264,10 @@ func (sm *sessMonitor) sendReq() {
 	if sm.smRemote == nil || sm.smRemote.SessPath == nil {
 		return
 	}
+	if sm.updateMsgId != 0 {
+		sm.observeProbe(sm.answered)
+	}
+	sm.answered = false
 	sm.updateMsgId = mgmt.MsgIdType(time.Now().UnixNano())
 	spld, err := mgmt.NewPld(sm.updateMsgId, mgmt.NewPollReq(sigcmn.MgmtAddr, sm.sess.SessId))
 	if err != nil {
@@ -333,6 +345,8 @@ func (sm *sessMonitor) handleRep(rpld *disp.RegPld) {
 		sm.setHealth(true)
 
 		latency := time.Now().Sub(rpld.Id.Time())
+		sm.answered = true
+		sm.observeRTT(latency)
 		metrics.SessionProbeRTT.WithLabelValues(sm.sess.IA().String(),
 			sm.sess.SessId.String()).Observe(latency.Seconds())
 	} else {
@@ -353,3 +367,31 @@ func (sm *sessMonitor) setHealth(healthy bool) {
 	metrics.SessionHealth.WithLabelValues(sm.sess.IA().String(),
 		sm.sess.SessId.String()).Set(healthVal)
 }
+
+// observeProbe updates the loss of the path with the outcome of a probe.
+func (sm *sessMonitor) observeProbe(answered bool) {
+	var sample float64
+	if !answered {
+		sample = 1
+	}
+	health := sm.health
+	health.Loss = (1-healthAlpha)*health.Loss + healthAlpha*sample
+	sm.setPathHealth(health)
+}
+
+// observeRTT updates the RTT of the path with the RTT of a probe.
+func (sm *sessMonitor) observeRTT(rtt time.Duration) {
+	health := sm.health
+	if health.RTT == 0 {
+		health.RTT = rtt
+	} else {
+		health.RTT = time.Duration((1-healthAlpha)*float64(health.RTT) +
+			healthAlpha*float64(rtt))
+	}
+	sm.setPathHealth(health)
+}
+
+func (sm *sessMonitor) setPathHealth(health iface.PathHealth) {
+	sm.health = health
+	sm.sess.pathHealth.Store(health)
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/env"
 	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/pathpol"
 	"github.com/scionproto/scion/go/lib/sciond/fake"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/snet"
@@ -50,6 +51,7 @@ var (
 	Host addr.HostAddr
 
 	PathMgr    pathmgr.Resolver
+	PathPolicy *pathpol.Policy
 	Dispatcher reliable.Dispatcher
 	Network    *snet.SCIONNetwork
 	CtrlConn   snet.Conn
@@ -63,6 +65,13 @@ func Init(cfg sigconfig.SigConf, sdCfg env.SCIONDClient) error {
 	MgmtAddr = mgmt.NewAddr(Host, cfg.CtrlPort, cfg.EncapPort)
 	encapPort = cfg.EncapPort
 
+	if cfg.PathPolicy != "" {
+		policy, err := pathpol.LoadPolicy(cfg.PathPolicy)
+		if err != nil {
+			return common.NewBasicError("Error loading path policy", err)
+		}
+		PathPolicy = policy
+	}
 	network, resolver, err := initNetwork(cfg, sdCfg)
 	if err != nil {
 		return common.NewBasicError("Error creating local SCION Network context", err)
@@ -103,8 +112,9 @@ func initNetworkWithFakeSCIOND(cfg sigconfig.SigConf,
 	}
 	pathResolver := pathmgr.New(sciondConn, pathmgr.Timers{}, sdCfg.PathCount)
 	network := snet.NewNetworkWithPR(cfg.IA, Dispatcher, &snetmigrate.PathQuerier{
-		Resolver: pathResolver,
-		IA:       cfg.IA,
+		Resolver:   pathResolver,
+		PathPolicy: PathPolicy,
+		IA:         cfg.IA,
 	}, pathResolver)
 	return network, pathResolver, nil
 }
@@ -119,8 +129,9 @@ func initNetworkWithRealSCIOND(cfg sigconfig.SigConf,
 		resolver, err := snetmigrate.ResolverFromSD(sdCfg.Address, sdCfg.PathCount)
 		if err == nil {
 			return snet.NewNetworkWithPR(cfg.IA, Dispatcher, &snetmigrate.PathQuerier{
-				Resolver: resolver,
-				IA:       cfg.IA,
+				Resolver:   resolver,
+				PathPolicy: PathPolicy,
+				IA:         cfg.IA,
 			}, resolver), resolver, nil
 		}
 		log.Debug("SIG is retrying to get NewNetwork", "err", err)
@@ -137,7 +148,7 @@ func newDispatcher(cfg sigconfig.SigConf) (reliable.Dispatcher, error) {
 	}
 	// Initialize dispatcher bypass.
 	log.Info("Bypassing SCION dispatcher", "addr", cfg.DispatcherBypass)
-	dispServer, err := dispatcher.NewServer(cfg.DispatcherBypass)
+	dispServer, err := dispatcher.NewServer(cfg.DispatcherBypass, dispatcher.QueueConfig{})
 	if err != nil {
 		return nil, serrors.WrapStr("unable to initialize bypass dispatcher", err)
 	}
@@ -106,6 +106,10 @@ type SigConf struct {
 	// dispatcher. If the field is empty bypass is not done and SCION dispatcher is used
 	// instead.
 	DispatcherBypass string
+	// PathPolicy is the file containing the path policy (YAML or JSON) that is
+	// applied to the paths used for the sessions to remote SIGs. If the field
+	// is empty, all paths are used. (default "")
+	PathPolicy string
 }
 
 // InitDefaults sets the default values to unset values.
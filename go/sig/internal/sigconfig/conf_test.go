@@ -62,4 +62,5 @@ func CheckTestSigConf(t *testing.T, cfg *SigConf, id string) {
 	assert.Empty(t, cfg.Dispatcher)
 	assert.Equal(t, DefaultTunName, cfg.Tun)
 	assert.Equal(t, DefaultTunRTableId, cfg.TunRTableId)
+	assert.Empty(t, cfg.PathPolicy)
 }
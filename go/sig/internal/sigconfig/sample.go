@@ -43,4 +43,8 @@ Tun = "sig"
 
 # Id of the routing table. (default 11)
 TunRTableId = 11
+
+# The path policy file (YAML or JSON) applied to the paths to remote SIGs.
+# If empty, all paths are used. (default "")
+PathPolicy = ""
 `
@@ -49,8 +49,23 @@ func (q *PathQuerier) Query(ctx context.Context, dst addr.IA) ([]snet.Path, erro
 		return nil, common.NewBasicError("unable to find paths", nil)
 	}
 	paths := make([]snet.Path, 0, len(aps))
+	if q.PathPolicy != nil {
+		// Return the paths in the order preferred by the policy.
+		for _, path := range q.PathPolicy.Sort(apsToPs(aps)) {
+			paths = append(paths, path.(snet.Path))
+		}
+		return paths, nil
+	}
 	for _, ap := range aps {
 		paths = append(paths, ap)
 	}
 	return paths, nil
 }
+
+func apsToPs(aps spathmeta.AppPathSet) pathpol.PathSet {
+	ps := make(pathpol.PathSet, len(aps))
+	for key, path := range aps {
+		ps[key] = path
+	}
+	return ps
+}
@@ -17,6 +17,7 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"io"
@@ -48,6 +49,9 @@ import (
 
 var (
 	cfg sigconfig.Config
+	// trafficCfg contains the active *config.Cfg, i.e., the traffic classes,
+	// path policies and remote ASes loaded from the SIG config file.
+	trafficCfg atomic.Value
 )
 
 func init() {
@@ -205,12 +209,24 @@ func loadConfig(path string) bool {
 		return false
 	}
 	atomic.StoreUint64(&metrics.ConfigVersion, cfg.ConfigVersion)
+	trafficCfg.Store(cfg)
 	return true
 }
 
+// configHandler writes the SIG configuration followed by the active traffic
+// configuration in JSON format.
 func configHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "text/plain")
 	var buf bytes.Buffer
 	toml.NewEncoder(&buf).Encode(cfg)
+	if sigCfg, ok := trafficCfg.Load().(*config.Cfg); ok {
+		raw, err := json.MarshalIndent(sigCfg, "", "    ")
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Unable to marshal traffic config: %s", err),
+				http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprintf(&buf, "\n# Traffic config (%s)\n%s\n", cfg.Sig.SIGConfig, raw)
+	}
 	fmt.Fprint(w, buf.String())
 }
@@ -0,0 +1,144 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Tool to inspect and apply schema migrations of SQLite databases.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/scionproto/scion/go/cs/beacon/beacondbsqlite"
+	"github.com/scionproto/scion/go/lib/env"
+	"github.com/scionproto/scion/go/lib/infra/modules/db"
+	"github.com/scionproto/scion/go/lib/infra/modules/trust/trustdbsqlite"
+	pathdbsqlite "github.com/scionproto/scion/go/lib/pathdb/sqlite"
+	revcachesqlite "github.com/scionproto/scion/go/lib/revcache/sqlite"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+var (
+	dryRun  = flag.Bool("dry-run", false, "Only report the pending migrations.")
+	version = flag.Bool("version", false, "Output version information and exit.")
+)
+
+// schemas maps the database types to their migrations.
+var schemas = map[string]db.Migrations{
+	"pathdb":   pathdbsqlite.Migrations,
+	"beacondb": beacondbsqlite.Migrations,
+	"revcache": revcachesqlite.Migrations,
+	"trustdb":  trustdbsqlite.Migrations,
+}
+
+func main() {
+	flag.Usage = flagUsage
+	flag.Parse()
+	if *version {
+		fmt.Print(env.VersionInfo())
+		os.Exit(0)
+	}
+	if flag.NArg() != 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	migrations, ok := schemas[flag.Arg(0)]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "ERROR: Invalid database type %s\n", flag.Arg(0))
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := migrate(os.Stdout, flag.Arg(1), migrations, *dryRun); err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+// migrate prints the schema version and migration history of the database,
+// and applies the pending migrations. In a dry run, the pending migrations are
+// only printed. A database that does not exist yet is not created in a dry
+// run.
+func migrate(w io.Writer, file string, migrations db.Migrations, dryRun bool) error {
+	if _, err := os.Stat(file); os.IsNotExist(err) && dryRun {
+		fmt.Fprintf(w, "%s: database does not exist\n", file)
+		printMigrations(w, "Pending migrations", migrations)
+		return nil
+	}
+	sqlDB, err := db.OpenSqlite(file)
+	if err != nil {
+		return err
+	}
+	defer sqlDB.Close()
+	ctx := context.Background()
+	current, err := db.SqliteSchemaVersion(ctx, sqlDB)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "%s: schema version %d, latest %d\n", file, current, migrations.Latest())
+	history, err := db.SqliteMigrationHistory(ctx, sqlDB)
+	if err != nil {
+		return err
+	}
+	if len(history) > 0 {
+		fmt.Fprintln(w, "History:")
+		for _, r := range history {
+			fmt.Fprintf(w, "  %d: %s (applied %s)\n", r.Version, r.Description,
+				util.TimeToCompact(r.Applied))
+		}
+	}
+	migrated, err := db.MigrateSqlite(ctx, sqlDB, migrations, dryRun)
+	if err != nil {
+		return serrors.WrapStr("unable to migrate database", err, "file", file)
+	}
+	if dryRun {
+		printMigrations(w, "Pending migrations", migrated)
+	} else {
+		printMigrations(w, "Applied migrations", migrated)
+	}
+	return nil
+}
+
+func printMigrations(w io.Writer, title string, migrations db.Migrations) {
+	if len(migrations) == 0 {
+		fmt.Fprintf(w, "%s: none\n", title)
+		return
+	}
+	fmt.Fprintf(w, "%s:\n", title)
+	for _, m := range migrations {
+		fmt.Fprintf(w, "  %d: %s\n", m.Version, m.Description)
+	}
+}
+
+func flagUsage() {
+	types := make([]string, 0, len(schemas))
+	for t := range schemas {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	fmt.Fprintf(os.Stderr, `Usage: dbmigrate [flags] <type> <file>
+
+Applies the pending schema migrations to the SQLite database in <file>.
+Supported types: %s
+
+flags:
+`, strings.Join(types, ", "))
+	flag.PrintDefaults()
+}
@@ -0,0 +1,124 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Tool to inspect the state of a running dispatcher.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/scionproto/scion/go/godispatcher/dispatcher"
+	"github.com/scionproto/scion/go/lib/env"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+var (
+	address = flag.String("addr", "127.0.0.1:30441",
+		"HTTP address of the dispatcher (the Prometheus address in the dispatcher config)")
+	timeout = flag.Duration("timeout", 5*time.Second, "Timeout for the request")
+	jsonOut = flag.Bool("json", false, "Print the raw JSON reply")
+	version = flag.Bool("version", false, "Output version information and exit.")
+)
+
+func main() {
+	flag.Usage = flagUsage
+	flag.Parse()
+	if *version {
+		fmt.Print(env.VersionInfo())
+		os.Exit(0)
+	}
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	var err error
+	switch flag.Arg(0) {
+	case "registrations":
+		err = registrations(os.Stdout)
+	default:
+		fmt.Fprintf(os.Stderr, "ERROR: Invalid command %s\n", flag.Arg(0))
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+// registrations fetches the live registrations from the dispatcher and prints
+// them.
+func registrations(w io.Writer) error {
+	client := &http.Client{Timeout: *timeout}
+	url := fmt.Sprintf("http://%s/registrations", *address)
+	resp, err := client.Get(url)
+	if err != nil {
+		return serrors.WrapStr("unable to query dispatcher", err, "url", url)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return serrors.New("dispatcher replied with error", "url", url,
+			"status", resp.Status)
+	}
+	if *jsonOut {
+		_, err := io.Copy(w, resp.Body)
+		return err
+	}
+	var regs []dispatcher.RegistrationInfo
+	if err := json.NewDecoder(resp.Body).Decode(&regs); err != nil {
+		return serrors.WrapStr("unable to parse reply", err, "url", url)
+	}
+	printRegistrations(w, regs)
+	return nil
+}
+
+func printRegistrations(w io.Writer, regs []dispatcher.RegistrationInfo) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "IA\tPUBLIC\tBIND\tSVC\tQUEUE\tDROPS\tBACKPRESSURE")
+	for _, reg := range regs {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d/%d\t%d\t%t\n", reg.IA, reg.Public,
+			orDash(reg.Bind), orDash(reg.SVC), reg.QueueLen, reg.QueueSize, reg.Drops,
+			reg.Backpressure)
+	}
+	tw.Flush()
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func flagUsage() {
+	fmt.Fprintf(os.Stderr, `
+Usage: dispatcher-ctl [flags] <command>
+
+Inspects the state of a running dispatcher through its HTTP endpoint.
+
+commands:
+  registrations  List the live application registrations, their SVC
+                 bindings, ingress queue occupancy and drop counters.
+
+flags:
+`)
+	flag.PrintDefaults()
+}
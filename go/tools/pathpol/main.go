@@ -0,0 +1,121 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Tool to lint and explain path policy files.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/scionproto/scion/go/lib/env"
+	"github.com/scionproto/scion/go/lib/pathpol"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+var (
+	version = flag.Bool("version", false, "Output version information and exit.")
+)
+
+func main() {
+	flag.Usage = flagUsage
+	flag.Parse()
+	if *version {
+		fmt.Print(env.VersionInfo())
+		os.Exit(0)
+	}
+	if flag.NArg() < 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	var err error
+	switch flag.Arg(0) {
+	case "lint":
+		err = lint(os.Stdout, flag.Arg(1))
+	case "explain":
+		err = explain(os.Stdout, flag.Arg(1), flag.Args()[2:])
+	default:
+		fmt.Fprintf(os.Stderr, "ERROR: Invalid command %s\n", flag.Arg(0))
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+// lint checks all policies in the policy map file and reports the problems.
+func lint(w io.Writer, file string) error {
+	policies, err := pathpol.LoadPolicyMap(file)
+	if err != nil {
+		return err
+	}
+	errs := pathpol.NewCompiler(policies).Lint()
+	for _, err := range errs {
+		fmt.Fprintln(w, serrors.FmtError(err))
+	}
+	if len(errs) > 0 {
+		return serrors.New("policy file contains errors", "file", file, "errors", len(errs))
+	}
+	fmt.Fprintf(w, "%s: %d policies OK\n", file, len(policies))
+	return nil
+}
+
+// explain compiles the given policies, or all policies if none are given, and
+// prints the compiled rules together with the policies they originate from.
+func explain(w io.Writer, file string, names []string) error {
+	policies, err := pathpol.LoadPolicyMap(file)
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		for name := range policies {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+	compiler := pathpol.NewCompiler(policies)
+	for i, name := range names {
+		compiled, err := compiler.Compile(name)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		compiled.Explain(w)
+	}
+	return nil
+}
+
+func flagUsage() {
+	fmt.Fprintf(os.Stderr, `
+Usage: pathpol [flags] <command> <policy file> [policy...]
+
+Lints and explains files containing path policies in YAML or JSON format.
+
+commands:
+  lint     Compile all policies in the file and report problems, e.g.,
+           circular or unknown extended policies.
+  explain  Print the compiled policies, or all policies if none are given,
+           and the policy each rule originates from.
+
+flags:
+`)
+	flag.PrintDefaults()
+}
@@ -17,7 +17,6 @@ package certs
 import (
 	"io/ioutil"
 	"os"
-	"path/filepath"
 	"time"
 
 	"github.com/scionproto/scion/go/lib/addr"
@@ -170,26 +169,24 @@ func (g chainGen) sign(cfg conf.AS, chain cert.Chain) (cert.Chain, error) {
 	if err != nil {
 		return cert.Chain{}, serrors.WrapStr("unable to load issuer config", err, "file", file)
 	}
-	file = filepath.Join(keys.PrivateDir(g.Dirs.Out, cfg.IssuerIA),
-		keyconf.PrivateKeyFile(keyconf.IssCertSigningKey, *issCfg.IssuingGrantKeyVersion))
 	id := keyconf.ID{
 		IA:      cfg.IssuerIA,
 		Usage:   keyconf.IssCertSigningKey,
 		Version: *issCfg.IssuingGrantKeyVersion,
 	}
-	key, err := keyconf.LoadKeyFromFile(file, keyconf.PrivateKey, id)
+	signer, err := keys.Signer(g.Dirs.Out, id)
 	if err != nil {
-		return cert.Chain{}, serrors.WrapStr("unable to load issuing key", err, "file", file)
+		return cert.Chain{}, serrors.WrapStr("unable to load issuing key", err)
 	}
 	protected := cert.ProtectedAS{
-		Algorithm:          key.Algorithm,
+		Algorithm:          signer.Meta().Algorithm,
 		IA:                 cfg.IssuerIA,
 		CertificateVersion: cfg.IssuerCertVersion,
 	}
 	if chain.AS.EncodedProtected, err = cert.EncodeProtectedAS(protected); err != nil {
 		return cert.Chain{}, serrors.WrapStr("unable to encode protected", err)
 	}
-	chain.AS.Signature, err = scrypto.Sign(chain.AS.SigInput(), key.Bytes, key.Algorithm)
+	chain.AS.Signature, err = signer.Sign(chain.AS.SigInput())
 	if err != nil {
 		return cert.Chain{}, serrors.WrapStr("unable to sign issuer certificate", err)
 	}
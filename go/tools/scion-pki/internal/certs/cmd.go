@@ -112,10 +112,34 @@ and displays them in a human readable format.
 	},
 }
 
+var trcFile string
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verify issuer certificates and certificate chains",
+	Example: `  scion-pki certs verify ISD1/ASff00_0_110/certs/ISD1-ASff00_0_110-V1.crt
+  scion-pki certs verify ISD1/ASff00_0_110/certs/* -d $SPKI_ROOT_DIR
+  scion-pki certs verify --trc ISD1-V1.trc ISD1-ASff00_0_110-V1.crt`,
+	Long: `'verify' validates and verifies the provided issuer certificate and
+certificate chain files offline.
+
+By default, the certificates are verified against the TRC referenced by the
+issuer certificate, which must be present in the root directory. With the --trc
+flag, the certificates are verified against the provided TRC file instead.
+`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runVerify(args, trcFile)
+	},
+}
+
 func init() {
 	Cmd.PersistentFlags().Uint64Var(&version, "version", 0,
 		"certificate version (0 indicates newest)")
 	Cmd.AddCommand(genChainCmd)
 	Cmd.AddCommand(genIssuerCmd)
 	Cmd.AddCommand(humanCmd)
+	Cmd.AddCommand(verifyCmd)
+	verifyCmd.Flags().StringVar(&trcFile, "trc", "",
+		"TRC file to verify the certificates against")
 }
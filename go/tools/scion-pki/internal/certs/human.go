@@ -49,9 +49,9 @@ func genHumanIssuer(file string) error {
 	if err != nil {
 		return serrors.WrapStr("unable to parse signed issuer certificate", err)
 	}
-	d, err := decodeIssuer(&signed)
+	d, err := DecodeIssuer(&signed)
 	if err != nil {
-		return serrors.WrapStr("unable to decode issuer certificate", err)
+		return err
 	}
 	if raw, err = json.MarshalIndent(d, "", "  "); err != nil {
 		return serrors.WrapStr("unable to write human readable issuer certificate", err)
@@ -69,14 +69,9 @@ func genHumanChain(file string) error {
 	if err != nil {
 		return serrors.WrapStr("unable to parse signed certificate chain", err)
 	}
-	humanReadable := make([]decodedCert, 2)
-	humanReadable[0], err = decodeIssuer(&signed.Issuer)
+	humanReadable, err := DecodeChain(&signed)
 	if err != nil {
-		return serrors.WrapStr("unable to decode issuer certificate", err)
-	}
-	humanReadable[1], err = decodeAS(&signed.AS)
-	if err != nil {
-		return serrors.WrapStr("unable to decode AS certificate", err)
+		return err
 	}
 	if raw, err = json.MarshalIndent(humanReadable, "", "  "); err != nil {
 		return serrors.WrapStr("unable to write human readable certificate chain", err)
@@ -85,6 +80,34 @@ func genHumanChain(file string) error {
 	return err
 }
 
+// DecodeIssuer decodes the payload and the protected metadata of the signed
+// issuer certificate. The result can be marshalled to a human readable
+// representation.
+func DecodeIssuer(signed *cert.SignedIssuer) (interface{}, error) {
+	d, err := decodeIssuer(signed)
+	if err != nil {
+		return nil, serrors.WrapStr("unable to decode issuer certificate", err)
+	}
+	return d, nil
+}
+
+// DecodeChain decodes the payloads and the protected metadata of the
+// certificate chain. The result can be marshalled to a human readable
+// representation.
+func DecodeChain(chain *cert.Chain) (interface{}, error) {
+	var err error
+	humanReadable := make([]decodedCert, 2)
+	humanReadable[0], err = decodeIssuer(&chain.Issuer)
+	if err != nil {
+		return nil, serrors.WrapStr("unable to decode issuer certificate", err)
+	}
+	humanReadable[1], err = decodeAS(&chain.AS)
+	if err != nil {
+		return nil, serrors.WrapStr("unable to decode AS certificate", err)
+	}
+	return humanReadable, nil
+}
+
 type decodedCert struct {
 	Payload   interface{}         `json:"payload"`
 	Protected interface{}         `json:"protected"`
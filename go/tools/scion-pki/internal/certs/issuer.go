@@ -159,18 +159,18 @@ func (g issGen) sign(ia addr.IA, cfg conf.Issuer,
 		Usage:   keyconf.TRCIssuingGrantKey,
 		Version: *primary.IssuingGrantKeyVersion,
 	}
-	key, err := keyconf.LoadKeyFromFile(keys.PrivateFile(g.Dirs.Out, id), keyconf.PrivateKey, id)
+	signer, err := keys.Signer(g.Dirs.Out, id)
 	if err != nil {
-		return cert.SignedIssuer{}, serrors.WrapStr("unable to load issuing key", err, "file", file)
+		return cert.SignedIssuer{}, serrors.WrapStr("unable to load issuing key", err)
 	}
 	protected := cert.ProtectedIssuer{
-		Algorithm:  key.Algorithm,
+		Algorithm:  signer.Meta().Algorithm,
 		TRCVersion: cfg.TRCVersion,
 	}
 	if signed.EncodedProtected, err = cert.EncodeProtectedIssuer(protected); err != nil {
 		return cert.SignedIssuer{}, serrors.WrapStr("unable to encode protected", err)
 	}
-	signed.Signature, err = scrypto.Sign(signed.SigInput(), key.Bytes, key.Algorithm)
+	signed.Signature, err = signer.Sign(signed.SigInput())
 	if err != nil {
 		return cert.SignedIssuer{}, serrors.WrapStr("unable to sign issuer certificate", err)
 	}
@@ -45,32 +45,42 @@ func (v verifier) VerifyChain(raw []byte) error {
 	if err != nil {
 		return serrors.WrapStr("unable to parse signed certificate chain", err)
 	}
-	if err := v.verifyIssuer(chain.Issuer); err != nil {
+	t, err := v.issuingTRC(chain.Issuer)
+	if err != nil {
 		return err
 	}
-	issCert, err := chain.Issuer.Encoded.Decode()
+	return VerifyChain(chain, t)
+}
+
+func (v verifier) verifyIssuer(signed cert.SignedIssuer) error {
+	t, err := v.issuingTRC(signed)
 	if err != nil {
-		return serrors.WrapStr("unable to parse issuer certificate payload", err)
+		return err
 	}
-	asCert, err := chain.AS.Encoded.Decode()
+	return VerifyIssuer(signed, t)
+}
+
+// issuingTRC loads the TRC referenced by the issuer certificate.
+func (v verifier) issuingTRC(signed cert.SignedIssuer) (*trc.TRC, error) {
+	c, err := signed.Encoded.Decode()
 	if err != nil {
-		return serrors.WrapStr("unable to parse AS certificate payload", err)
-	}
-	if err := asCert.Validate(); err != nil {
-		return serrors.WrapStr("unable to validate AS certificate", err)
+		return nil, serrors.WrapStr("unable to parse issuer certificate payload", err)
 	}
-	asVer := cert.ASVerifier{
-		Issuer:   issCert,
-		AS:       asCert,
-		SignedAS: &chain.AS,
-	}
-	if err := asVer.Verify(); err != nil {
-		return serrors.WrapStr("unable to verify AS certificate", err)
+	return v.loadTRC(c.Subject.I, c.Issuer.TRCVersion)
+}
+
+// trc returns the anchor TRC if it is set. Otherwise, the TRC referenced by
+// the issuer certificate is loaded.
+func (v verifier) trc(signed cert.SignedIssuer, anchor *trc.TRC) (*trc.TRC, error) {
+	if anchor != nil {
+		return anchor, nil
 	}
-	return nil
+	return v.issuingTRC(signed)
 }
 
-func (v verifier) verifyIssuer(signed cert.SignedIssuer) error {
+// VerifyIssuer validates the signed issuer certificate and verifies it
+// against the provided TRC.
+func VerifyIssuer(signed cert.SignedIssuer, t *trc.TRC) error {
 	c, err := signed.Encoded.Decode()
 	if err != nil {
 		return serrors.WrapStr("unable to parse issuer certificate payload", err)
@@ -78,9 +88,9 @@ func (v verifier) verifyIssuer(signed cert.SignedIssuer) error {
 	if err := c.Validate(); err != nil {
 		return serrors.WrapStr("unable to validate issuer certificate", err)
 	}
-	t, err := v.loadTRC(c.Subject.I, c.Issuer.TRCVersion)
-	if err != nil {
-		return err
+	if c.Subject.I != t.ISD {
+		return serrors.New("issuer certificate and TRC ISD mismatch",
+			"subject", c.Subject, "trc_isd", t.ISD)
 	}
 	issVer := cert.IssuerVerifier{
 		Issuer:       c,
@@ -93,6 +103,34 @@ func (v verifier) verifyIssuer(signed cert.SignedIssuer) error {
 	return nil
 }
 
+// VerifyChain validates the certificate chain and verifies it against the
+// provided TRC.
+func VerifyChain(chain cert.Chain, t *trc.TRC) error {
+	if err := VerifyIssuer(chain.Issuer, t); err != nil {
+		return err
+	}
+	issCert, err := chain.Issuer.Encoded.Decode()
+	if err != nil {
+		return serrors.WrapStr("unable to parse issuer certificate payload", err)
+	}
+	asCert, err := chain.AS.Encoded.Decode()
+	if err != nil {
+		return serrors.WrapStr("unable to parse AS certificate payload", err)
+	}
+	if err := asCert.Validate(); err != nil {
+		return serrors.WrapStr("unable to validate AS certificate", err)
+	}
+	asVer := cert.ASVerifier{
+		Issuer:   issCert,
+		AS:       asCert,
+		SignedAS: &chain.AS,
+	}
+	if err := asVer.Verify(); err != nil {
+		return serrors.WrapStr("unable to verify AS certificate", err)
+	}
+	return nil
+}
+
 func (v verifier) loadTRC(isd addr.ISD, version scrypto.Version) (*trc.TRC, error) {
 	raw, err := ioutil.ReadFile(trcs.SignedFile(v.Dirs.Out, isd, version))
 	if err != nil {
@@ -108,3 +146,58 @@ func (v verifier) loadTRC(isd addr.ISD, version scrypto.Version) (*trc.TRC, erro
 	}
 	return t, nil
 }
+
+// runVerify verifies the issuer certificates and certificate chains. If
+// trcFile is set, the certificates are verified against that TRC. Otherwise,
+// the TRCs referenced by the issuer certificates are loaded from the output
+// directory.
+func runVerify(files []string, trcFile string) error {
+	var anchor *trc.TRC
+	if trcFile != "" {
+		var err error
+		if _, anchor, err = trcs.LoadSigned(trcFile); err != nil {
+			return serrors.WrapStr("unable to load TRC", err, "file", trcFile)
+		}
+	}
+	v := verifier{Dirs: pkicmn.GetDirs()}
+	issuers, chains := MatchFiles(files)
+	for _, file := range issuers {
+		raw, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		signed, err := cert.ParseSignedIssuer(raw)
+		if err != nil {
+			return serrors.WrapStr("unable to parse signed issuer certificate", err,
+				"file", file)
+		}
+		t, err := v.trc(signed, anchor)
+		if err != nil {
+			return serrors.WrapStr("unable to load issuing TRC", err, "file", file)
+		}
+		if err := VerifyIssuer(signed, t); err != nil {
+			return serrors.WrapStr("unable to verify issuer certificate", err, "file", file)
+		}
+		pkicmn.QuietPrint("Verified issuer certificate: %s\n", file)
+	}
+	for _, file := range chains {
+		raw, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		chain, err := cert.ParseChain(raw)
+		if err != nil {
+			return serrors.WrapStr("unable to parse signed certificate chain", err,
+				"file", file)
+		}
+		t, err := v.trc(chain.Issuer, anchor)
+		if err != nil {
+			return serrors.WrapStr("unable to load issuing TRC", err, "file", file)
+		}
+		if err := VerifyChain(chain, t); err != nil {
+			return serrors.WrapStr("unable to verify certificate chain", err, "file", file)
+		}
+		pkicmn.QuietPrint("Verified certificate chain: %s\n", file)
+	}
+	return nil
+}
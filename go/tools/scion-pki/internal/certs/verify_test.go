@@ -0,0 +1,83 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certs
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/scrypto/cert"
+	"github.com/scionproto/scion/go/lib/scrypto/trc"
+	"github.com/scionproto/scion/go/tools/scion-pki/internal/trcs"
+)
+
+func TestVerify(t *testing.T) {
+	_, anchor, err := trcs.LoadSigned("./testdata/ISD1/trcs/ISD1-V1.trc")
+	require.NoError(t, err)
+	otherISD := *anchor
+	otherISD.ISD = 2
+
+	raw, err := ioutil.ReadFile("./testdata/ISD1/ASff00_0_110/certs/ISD1-ASff00_0_110-V1.issuer")
+	require.NoError(t, err)
+	issuer, err := cert.ParseSignedIssuer(raw)
+	require.NoError(t, err)
+	raw, err = ioutil.ReadFile("./testdata/ISD1/ASff00_0_111/certs/ISD1-ASff00_0_111-V1.crt")
+	require.NoError(t, err)
+	chain, err := cert.ParseChain(raw)
+	require.NoError(t, err)
+	tamperedAS := chain
+	tamperedAS.AS.Signature = append([]byte(nil), chain.AS.Signature...)
+	tamperedAS.AS.Signature[0] ^= 0xFF
+
+	tests := map[string]struct {
+		Verify       func(*trc.TRC) error
+		TRC          *trc.TRC
+		ErrAssertion assert.ErrorAssertionFunc
+	}{
+		"issuer": {
+			Verify:       func(t *trc.TRC) error { return VerifyIssuer(issuer, t) },
+			TRC:          anchor,
+			ErrAssertion: assert.NoError,
+		},
+		"issuer wrong ISD": {
+			Verify:       func(t *trc.TRC) error { return VerifyIssuer(issuer, t) },
+			TRC:          &otherISD,
+			ErrAssertion: assert.Error,
+		},
+		"chain": {
+			Verify:       func(t *trc.TRC) error { return VerifyChain(chain, t) },
+			TRC:          anchor,
+			ErrAssertion: assert.NoError,
+		},
+		"chain wrong ISD": {
+			Verify:       func(t *trc.TRC) error { return VerifyChain(chain, t) },
+			TRC:          &otherISD,
+			ErrAssertion: assert.Error,
+		},
+		"chain invalid AS signature": {
+			Verify:       func(t *trc.TRC) error { return VerifyChain(tamperedAS, t) },
+			TRC:          anchor,
+			ErrAssertion: assert.Error,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.ErrAssertion(t, test.Verify(test.TRC))
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/tools/scion-pki/internal/certs"
+	"github.com/scionproto/scion/go/tools/scion-pki/internal/inspect"
 	"github.com/scionproto/scion/go/tools/scion-pki/internal/keys"
 	"github.com/scionproto/scion/go/tools/scion-pki/internal/pkicmn"
 	"github.com/scionproto/scion/go/tools/scion-pki/internal/tmpl"
@@ -64,6 +65,9 @@ var RootCmd = &cobra.Command{
 			pkicmn.OutDir = pkicmn.RootDir
 		}
 	},
+	PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
+		return keys.CloseSigners()
+	},
 	SilenceErrors: true,
 }
 
@@ -129,10 +133,23 @@ func init() {
 		"Output directory where certificates and keys will be placed. Defaults to -root/-d.")
 	RootCmd.PersistentFlags().BoolVarP(&pkicmn.Quiet, "quiet", "q", false,
 		"Quiet mode, i.e., only errors will be printed.")
+	RootCmd.PersistentFlags().StringVar(&pkicmn.SignerSocket, "signer", "",
+		"Unix socket of an external signing process. If specified, certificates and TRCs are "+
+			"signed by the process instead of with the private keys in the output directory.")
+	RootCmd.PersistentFlags().StringVar(&pkicmn.PKCS11Module, "pkcs11-module", "",
+		"PKCS#11 module of the token that holds the private keys. If specified, certificates "+
+			"and TRCs are signed by the token instead of with the private keys in the output "+
+			"directory. The public keys must be available in the output directory.")
+	RootCmd.PersistentFlags().StringVar(&pkicmn.PKCS11Token, "pkcs11-token", "",
+		"Label of the PKCS#11 token that holds the private keys.")
+	RootCmd.PersistentFlags().StringVar(&pkicmn.PKCS11PIN, "pkcs11-pin",
+		os.Getenv("SCION_PKI_PKCS11_PIN"),
+		"User PIN of the PKCS#11 token. Defaults to $SCION_PKI_PKCS11_PIN.")
 	autoCompleteCmd.PersistentFlags().BoolVarP(&zsh, "zsh", "z", false,
 		"Generate autocompletion script for zsh")
 
 	RootCmd.AddCommand(certs.Cmd)
+	RootCmd.AddCommand(inspect.Cmd)
 	RootCmd.AddCommand(tmpl.Cmd)
 	RootCmd.AddCommand(keys.Cmd)
 	RootCmd.AddCommand(trcs.Cmd)
@@ -0,0 +1,49 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inspect
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var trcFiles []string
+
+var Cmd = &cobra.Command{
+	Use:   "inspect",
+	Short: "Display signed objects with their validation status",
+	Example: `  scion-pki inspect ISD1/trcs/ISD1-V1.trc
+  scion-pki inspect ISD1/trcs/ISD1-V1.trc ISD1/trcs/ISD1-V2.trc
+  scion-pki inspect --trc ISD1/trcs/ISD1-V1.trc ISD1/ASff00_0_110/certs/*`,
+	Long: `'inspect' displays signed TRCs, issuer certificates and certificate chains
+in a human readable format, together with their validation status.
+
+The type of each object is detected from its content. TRC updates are verified
+against their predecessor, and certificates are verified against the TRC they
+reference. These TRCs are looked up in the TRCs provided with the --trc flag and
+in the successfully verified TRCs that precede the object on the command line.
+If the required TRC is not available, the object is reported as unverified.
+
+Objects that are outside of their validity period are reported with a warning.
+`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runInspect(args, trcFiles)
+	},
+}
+
+func init() {
+	Cmd.Flags().StringSliceVar(&trcFiles, "trc", nil,
+		"TRC files used to verify the objects (can be repeated)")
+}
@@ -0,0 +1,276 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inspect
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/scrypto/cert"
+	"github.com/scionproto/scion/go/lib/scrypto/trc"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/tools/scion-pki/internal/certs"
+	"github.com/scionproto/scion/go/tools/scion-pki/internal/trcs"
+)
+
+// Object types.
+const (
+	TypeTRC    = "trc"
+	TypeIssuer = "issuer_certificate"
+	TypeChain  = "certificate_chain"
+)
+
+// Validation status.
+const (
+	// StatusValid indicates that the object is valid and verified.
+	StatusValid = "valid"
+	// StatusInvalid indicates that the object failed validation or
+	// verification.
+	StatusInvalid = "invalid"
+	// StatusUnverified indicates that the object could not be verified,
+	// because the required TRC is not available.
+	StatusUnverified = "unverified"
+)
+
+// ErrInvalid indicates that at least one inspected object is invalid.
+var ErrInvalid = serrors.New("invalid objects")
+
+// Report is the result of inspecting a signed object.
+type Report struct {
+	File     string      `json:"file"`
+	Type     string      `json:"type"`
+	Status   string      `json:"status"`
+	Error    string      `json:"error,omitempty"`
+	Warnings []string    `json:"warnings,omitempty"`
+	Decoded  interface{} `json:"decoded"`
+}
+
+type trcID struct {
+	isd     addr.ISD
+	version scrypto.Version
+}
+
+// trcPool holds the TRCs that are used to verify the inspected objects.
+type trcPool map[trcID]*trc.TRC
+
+func (p trcPool) add(t *trc.TRC) {
+	p[trcID{isd: t.ISD, version: t.Version}] = t
+}
+
+func (p trcPool) get(isd addr.ISD, version scrypto.Version) *trc.TRC {
+	return p[trcID{isd: isd, version: version}]
+}
+
+func runInspect(files, trcFiles []string) error {
+	pool := make(trcPool)
+	for _, file := range trcFiles {
+		_, t, err := trcs.LoadSigned(file)
+		if err != nil {
+			return serrors.WrapStr("unable to load TRC", err, "file", file)
+		}
+		pool.add(t)
+	}
+	invalid := 0
+	for _, file := range files {
+		raw, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		r, err := inspect(raw, pool, time.Now())
+		if err != nil {
+			return serrors.WrapStr("unable to inspect object", err, "file", file)
+		}
+		r.File = file
+		if r.Status == StatusInvalid {
+			invalid++
+		}
+		if raw, err = json.MarshalIndent(r, "", "  "); err != nil {
+			return serrors.WrapStr("unable to write report", err, "file", file)
+		}
+		if _, err := fmt.Fprintln(os.Stdout, string(raw)); err != nil {
+			return err
+		}
+	}
+	if invalid > 0 {
+		return serrors.WithCtx(ErrInvalid, "count", invalid)
+	}
+	return nil
+}
+
+// inspect decodes the raw signed object and validates it. Verified TRCs are
+// added to the pool. An error is only returned if the object cannot be
+// decoded.
+func inspect(raw []byte, pool trcPool, now time.Time) (Report, error) {
+	typ, err := detectType(raw)
+	if err != nil {
+		return Report{}, err
+	}
+	switch typ {
+	case TypeTRC:
+		return inspectTRC(raw, pool, now)
+	case TypeIssuer:
+		return inspectIssuer(raw, pool, now)
+	default:
+		return inspectChain(raw, pool, now)
+	}
+}
+
+// detectType detects the object type based on the JSON structure. Certificate
+// chains are encoded as arrays, signed TRCs have a list of signatures, and
+// signed issuer certificates have a single protected header.
+func detectType(raw []byte) (string, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) > 0 && raw[0] == '[' {
+		return TypeChain, nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return "", serrors.WrapStr("unable to parse JSON", err)
+	}
+	if _, ok := fields["signatures"]; ok {
+		return TypeTRC, nil
+	}
+	if _, ok := fields["protected"]; ok {
+		return TypeIssuer, nil
+	}
+	return "", serrors.New("unknown object type")
+}
+
+func inspectTRC(raw []byte, pool trcPool, now time.Time) (Report, error) {
+	signed, err := trc.ParseSigned(raw)
+	if err != nil {
+		return Report{}, serrors.WrapStr("unable to parse signed TRC", err)
+	}
+	t, err := signed.EncodedTRC.Decode()
+	if err != nil {
+		return Report{}, serrors.WrapStr("unable to parse TRC payload", err)
+	}
+	decoded, err := trcs.DecodeHuman(signed)
+	if err != nil {
+		return Report{}, err
+	}
+	r := Report{
+		Type:     TypeTRC,
+		Decoded:  decoded,
+		Warnings: validityWarnings("TRC", t.Validity, now),
+	}
+	var prev *trc.TRC
+	if !t.Base() {
+		if prev = pool.get(t.ISD, t.Version-1); prev == nil {
+			r.Status = StatusUnverified
+			r.Error = fmt.Sprintf("previous TRC ISD%d-V%d not available", t.ISD, t.Version-1)
+			return r, nil
+		}
+	}
+	_, err = trcs.Verify(signed, prev)
+	setStatus(&r, err)
+	if err == nil {
+		pool.add(t)
+	}
+	return r, nil
+}
+
+func inspectIssuer(raw []byte, pool trcPool, now time.Time) (Report, error) {
+	signed, err := cert.ParseSignedIssuer(raw)
+	if err != nil {
+		return Report{}, serrors.WrapStr("unable to parse signed issuer certificate", err)
+	}
+	c, err := signed.Encoded.Decode()
+	if err != nil {
+		return Report{}, serrors.WrapStr("unable to parse issuer certificate payload", err)
+	}
+	decoded, err := certs.DecodeIssuer(&signed)
+	if err != nil {
+		return Report{}, err
+	}
+	r := Report{
+		Type:     TypeIssuer,
+		Decoded:  decoded,
+		Warnings: validityWarnings("issuer certificate", c.Validity, now),
+	}
+	t := pool.get(c.Subject.I, c.Issuer.TRCVersion)
+	if t == nil {
+		r.Status = StatusUnverified
+		r.Error = fmt.Sprintf("issuing TRC ISD%d-V%d not available", c.Subject.I,
+			c.Issuer.TRCVersion)
+		return r, nil
+	}
+	setStatus(&r, certs.VerifyIssuer(signed, t))
+	return r, nil
+}
+
+func inspectChain(raw []byte, pool trcPool, now time.Time) (Report, error) {
+	chain, err := cert.ParseChain(raw)
+	if err != nil {
+		return Report{}, serrors.WrapStr("unable to parse signed certificate chain", err)
+	}
+	issCert, err := chain.Issuer.Encoded.Decode()
+	if err != nil {
+		return Report{}, serrors.WrapStr("unable to parse issuer certificate payload", err)
+	}
+	asCert, err := chain.AS.Encoded.Decode()
+	if err != nil {
+		return Report{}, serrors.WrapStr("unable to parse AS certificate payload", err)
+	}
+	decoded, err := certs.DecodeChain(&chain)
+	if err != nil {
+		return Report{}, err
+	}
+	r := Report{
+		Type:    TypeChain,
+		Decoded: decoded,
+		Warnings: append(validityWarnings("issuer certificate", issCert.Validity, now),
+			validityWarnings("AS certificate", asCert.Validity, now)...),
+	}
+	t := pool.get(issCert.Subject.I, issCert.Issuer.TRCVersion)
+	if t == nil {
+		r.Status = StatusUnverified
+		r.Error = fmt.Sprintf("issuing TRC ISD%d-V%d not available", issCert.Subject.I,
+			issCert.Issuer.TRCVersion)
+		return r, nil
+	}
+	setStatus(&r, certs.VerifyChain(chain, t))
+	return r, nil
+}
+
+func setStatus(r *Report, err error) {
+	if err != nil {
+		r.Status, r.Error = StatusInvalid, err.Error()
+		return
+	}
+	r.Status = StatusValid
+}
+
+// validityWarnings returns warnings if the validity period does not cover the
+// provided time.
+func validityWarnings(obj string, validity *scrypto.Validity, now time.Time) []string {
+	switch {
+	case validity == nil:
+		return nil
+	case now.Before(validity.NotBefore.Time):
+		return []string{fmt.Sprintf("%s not yet valid (not_before: %s)", obj,
+			util.TimeToCompact(validity.NotBefore.Time))}
+	case now.After(validity.NotAfter.Time):
+		return []string{fmt.Sprintf("%s expired (not_after: %s)", obj, util.TimeToCompact(validity.NotAfter.Time))}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inspect
+
+import (
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInspect(t *testing.T) {
+	read := func(t *testing.T, file string) []byte {
+		raw, err := ioutil.ReadFile(file)
+		require.NoError(t, err)
+		return raw
+	}
+	validTime := time.Unix(1573033769, 0).Add(time.Hour)
+	tests := map[string]struct {
+		Files    []string
+		Type     string
+		Status   string
+		Warnings int
+		Time     time.Time
+	}{
+		"TRC": {
+			Files:  []string{"./testdata/ISD1-V1.trc"},
+			Type:   TypeTRC,
+			Status: StatusValid,
+			Time:   validTime,
+		},
+		"TRC update without predecessor": {
+			Files:  []string{"./testdata/ISD1-V2.trc"},
+			Type:   TypeTRC,
+			Status: StatusUnverified,
+			Time:   validTime,
+		},
+		"TRC update with wrong predecessor": {
+			Files:  []string{"./testdata/ISD1-V1.trc", "./testdata/ISD1-V2.trc"},
+			Type:   TypeTRC,
+			Status: StatusInvalid,
+			Time:   validTime,
+		},
+		"issuer certificate": {
+			Files:  []string{"./testdata/ISD1-V1.trc", "./testdata/ISD1-ASff00_0_110-V1.issuer"},
+			Type:   TypeIssuer,
+			Status: StatusValid,
+			Time:   validTime,
+		},
+		"issuer certificate without TRC": {
+			Files:  []string{"./testdata/ISD1-ASff00_0_110-V1.issuer"},
+			Type:   TypeIssuer,
+			Status: StatusUnverified,
+			Time:   validTime,
+		},
+		"certificate chain": {
+			Files:  []string{"./testdata/ISD1-V1.trc", "./testdata/ISD1-ASff00_0_111-V1.crt"},
+			Type:   TypeChain,
+			Status: StatusValid,
+			Time:   validTime,
+		},
+		"expired certificate chain": {
+			Files:    []string{"./testdata/ISD1-V1.trc", "./testdata/ISD1-ASff00_0_111-V1.crt"},
+			Type:     TypeChain,
+			Status:   StatusValid,
+			Warnings: 2,
+			Time:     validTime.AddDate(2, 0, 0),
+		},
+		"certificate chain not yet valid": {
+			Files:    []string{"./testdata/ISD1-V1.trc", "./testdata/ISD1-ASff00_0_111-V1.crt"},
+			Type:     TypeChain,
+			Status:   StatusValid,
+			Warnings: 2,
+			Time:     validTime.AddDate(-1, 0, 0),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pool := make(trcPool)
+			var r Report
+			for _, file := range test.Files {
+				var err error
+				r, err = inspect(read(t, file), pool, test.Time)
+				require.NoError(t, err)
+			}
+			assert.Equal(t, test.Type, r.Type)
+			assert.Equal(t, test.Status, r.Status)
+			assert.Len(t, r.Warnings, test.Warnings)
+			assert.NotNil(t, r.Decoded)
+		})
+	}
+	t.Run("unknown object", func(t *testing.T) {
+		_, err := inspect([]byte(`{"payload":"abc"}`), make(trcPool), time.Now())
+		assert.Error(t, err)
+	})
+}
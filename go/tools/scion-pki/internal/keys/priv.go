@@ -220,7 +220,7 @@ func genKey(algo string) ([]byte, error) {
 			return nil, err
 		}
 		return ed25519.PrivateKey(private).Seed(), nil
-	case scrypto.Curve25519xSalsa20Poly1305:
+	case scrypto.Curve25519xSalsa20Poly1305, scrypto.ECDSAP256, scrypto.ECDSAP384:
 		_, private, err := scrypto.GenKeyPair(algo)
 		return private, err
 	default:
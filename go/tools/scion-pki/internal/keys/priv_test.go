@@ -188,3 +188,26 @@ func keyMeta(algo string, notBefore uint32, validity time.Duration) conf.KeyMeta
 		},
 	}
 }
+
+func TestGenKey(t *testing.T) {
+	tests := []string{
+		scrypto.Ed25519,
+		scrypto.ECDSAP256,
+		scrypto.ECDSAP384,
+	}
+	for _, algo := range tests {
+		t.Run(algo, func(t *testing.T) {
+			priv, err := genKey(algo)
+			require.NoError(t, err)
+			pub, err := scrypto.GetPubKey(priv, algo)
+			require.NoError(t, err)
+			sig, err := scrypto.Sign([]byte("message"), priv, algo)
+			require.NoError(t, err)
+			assert.NoError(t, scrypto.Verify([]byte("message"), sig, pub, algo))
+		})
+	}
+	t.Run("unknown", func(t *testing.T) {
+		_, err := genKey("unknown")
+		assert.Error(t, err)
+	})
+}
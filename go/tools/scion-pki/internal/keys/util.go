@@ -19,6 +19,7 @@ import (
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/keyconf"
+	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/tools/scion-pki/internal/pkicmn"
 )
 
@@ -32,6 +33,71 @@ func PrivateFile(out string, id keyconf.ID) string {
 	return filepath.Join(PrivateDir(out, id.IA), keyconf.PrivateKeyFile(id.Usage, id.Version))
 }
 
+// pkcs11Ring is the open session to the PKCS#11 token. It is opened on first
+// use and closed by CloseSigners.
+var pkcs11Ring *keyconf.PKCS11Ring
+
+// Signer returns the signer for the private key. If an external signing process
+// or a PKCS#11 token is configured, signing is delegated to it. Otherwise, the
+// private key is loaded from the output directory.
+func Signer(out string, id keyconf.ID) (keyconf.Signer, error) {
+	switch {
+	case pkicmn.SignerSocket != "" && pkicmn.PKCS11Module != "":
+		return nil, serrors.New("external signing process and PKCS#11 token are " +
+			"mutually exclusive")
+	case pkicmn.SignerSocket != "":
+		ring := keyconf.ProcessRing{Socket: pkicmn.SignerSocket, IA: id.IA}
+		return ring.Signer(id.Usage, id.Version)
+	case pkicmn.PKCS11Module != "":
+		ring, err := openPKCS11Ring(out, id.IA)
+		if err != nil {
+			return nil, err
+		}
+		return ring.Signer(id.Usage, id.Version)
+	}
+	file := PrivateFile(out, id)
+	key, err := keyconf.LoadKeyFromFile(file, keyconf.PrivateKey, id)
+	if err != nil {
+		return nil, serrors.WrapStr("unable to load private key", err, "file", file)
+	}
+	return keyconf.KeySigner{Key: key}, nil
+}
+
+// openPKCS11Ring returns the session to the PKCS#11 token. The private keys on
+// the token are identified by usage and version only, thus a single invocation
+// can only sign with the keys of one AS.
+func openPKCS11Ring(out string, ia addr.IA) (*keyconf.PKCS11Ring, error) {
+	if pkcs11Ring != nil {
+		if !pkcs11Ring.IA.Equal(ia) {
+			return nil, serrors.New("PKCS#11 token only supports signing for a single AS",
+				"active", pkcs11Ring.IA, "requested", ia)
+		}
+		return pkcs11Ring, nil
+	}
+	cfg := keyconf.PKCS11Conf{
+		Module:     pkicmn.PKCS11Module,
+		TokenLabel: pkicmn.PKCS11Token,
+		PIN:        pkicmn.PKCS11PIN,
+	}
+	ring, err := keyconf.NewPKCS11Ring(PublicDir(out, ia), ia, cfg)
+	if err != nil {
+		return nil, err
+	}
+	pkcs11Ring = ring
+	return ring, nil
+}
+
+// CloseSigners closes the session to the PKCS#11 token, if it was opened by
+// Signer.
+func CloseSigners() error {
+	if pkcs11Ring == nil {
+		return nil
+	}
+	err := pkcs11Ring.Close()
+	pkcs11Ring = nil
+	return err
+}
+
 // PublicDir returns the directory where the public keys are written to.
 func PublicDir(out string, ia addr.IA) string {
 	return filepath.Join(pkicmn.GetAsPath(out, ia), "pub")
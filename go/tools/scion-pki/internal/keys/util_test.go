@@ -0,0 +1,58 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keys
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/keyconf"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/tools/scion-pki/internal/pkicmn"
+)
+
+func TestSignerBackends(t *testing.T) {
+	id := keyconf.ID{
+		IA:      xtest.MustParseIA("1-ff00:0:110"),
+		Usage:   keyconf.ASSigningKey,
+		Version: 1,
+	}
+	tests := map[string]struct {
+		Socket string
+		Module string
+	}{
+		"process and PKCS#11": {
+			Socket: "/run/signer.sock",
+			Module: "/usr/lib/softhsm/libsofthsm2.so",
+		},
+		"PKCS#11 module not found": {
+			Module: "/nonexistent/libpkcs11.so",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer func(socket, module string) {
+				pkicmn.SignerSocket, pkicmn.PKCS11Module = socket, module
+			}(pkicmn.SignerSocket, pkicmn.PKCS11Module)
+			pkicmn.SignerSocket, pkicmn.PKCS11Module = test.Socket, test.Module
+
+			_, err := Signer(t.Name(), id)
+			assert.Error(t, err)
+			require.NoError(t, CloseSigners())
+		})
+	}
+}
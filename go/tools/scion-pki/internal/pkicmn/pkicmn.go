@@ -28,16 +28,17 @@ import (
 )
 
 const (
-	CertNameFmt     = "ISD%d-AS%s-V%d.crt"
-	CoreCertNameFmt = "ISD%d-AS%s-V%d-core.crt"
-	IssuerNameFmt   = "ISD%d-AS%s-V%d.issuer"
-	TrcNameFmt      = "ISD%d-V%d.trc"
-	TRCPartsDirFmt  = "ISD%d-V%d.parts"
-	TRCSigPartFmt   = "ISD%d-V%d.%s.sig"
-	TRCProtoNameFmt = "ISD%d-V%d.prototype"
-	TRCsDir         = "trcs"
-	CertsDir        = "certs"
-	KeysDir         = "keys"
+	CertNameFmt        = "ISD%d-AS%s-V%d.crt"
+	CoreCertNameFmt    = "ISD%d-AS%s-V%d-core.crt"
+	IssuerNameFmt      = "ISD%d-AS%s-V%d.issuer"
+	TrcNameFmt         = "ISD%d-V%d.trc"
+	TRCPartsDirFmt     = "ISD%d-V%d.parts"
+	TRCSigPartFmt      = "ISD%d-V%d.%s.sig"
+	TRCProtoNameFmt    = "ISD%d-V%d.prototype"
+	TRCCeremonyNameFmt = "ISD%d-V%d.ceremony"
+	TRCsDir            = "trcs"
+	CertsDir           = "certs"
+	KeysDir            = "keys"
 )
 
 // Error values
@@ -52,6 +53,17 @@ var (
 	OutDir  string
 	Force   bool
 	Quiet   bool
+	// SignerSocket is the unix socket of an external signing process. If set,
+	// signing is delegated to the process instead of loading the private keys.
+	SignerSocket string
+	// PKCS11Module is the PKCS#11 module of the token that holds the private
+	// keys. If set, signing is delegated to the token instead of loading the
+	// private keys.
+	PKCS11Module string
+	// PKCS11Token is the label of the PKCS#11 token.
+	PKCS11Token string
+	// PKCS11PIN is the user PIN of the PKCS#11 token.
+	PKCS11PIN string
 )
 
 // Dirs holds the directory configuration.
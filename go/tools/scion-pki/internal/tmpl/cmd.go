@@ -21,6 +21,7 @@ import (
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v2"
 
+	"github.com/scionproto/scion/go/lib/scrypto"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/util"
 	"github.com/scionproto/scion/go/tools/scion-pki/internal/conf"
@@ -55,9 +56,13 @@ generated the respective trust material.
 		if err != nil {
 			return serrors.WithCtx(err, "file", args[0])
 		}
+		if err := validateSignAlgo(signAlgo); err != nil {
+			return err
+		}
 		g := topoGen{
-			Dirs:     pkicmn.GetDirs(),
-			Validity: val,
+			Dirs:          pkicmn.GetDirs(),
+			Validity:      val,
+			SignAlgorithm: signAlgo,
 		}
 		if err := g.Run(topo); err != nil {
 			return serrors.WrapStr("unable to generate templates from topo", err, "file", args[0])
@@ -71,6 +76,8 @@ func init() {
 		"set not_before time in all configs")
 	topo.PersistentFlags().StringVar(&rawValidity, "validity", "365d",
 		"set the validity of all crypto material")
+	topo.PersistentFlags().StringVar(&signAlgo, "sign-algo", scrypto.Ed25519,
+		"set the algorithm of all signing keys (ed25519|ecdsa-p256|ecdsa-p384)")
 	Cmd.AddCommand(topo)
 }
 
@@ -89,6 +96,15 @@ func validityFromFlags() (conf.Validity, error) {
 	return v, v.Validate()
 }
 
+func validateSignAlgo(algo string) error {
+	switch algo {
+	case scrypto.Ed25519, scrypto.ECDSAP256, scrypto.ECDSAP384:
+		return nil
+	default:
+		return serrors.New("unsupported signing algorithm", "algo", algo)
+	}
+}
+
 func readTopo(file string) (topoFile, error) {
 	var topo topoFile
 	raw, err := ioutil.ReadFile(file)
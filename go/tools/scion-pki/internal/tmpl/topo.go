@@ -32,11 +32,14 @@ import (
 var (
 	notBefore   uint32
 	rawValidity string
+	signAlgo    string
 )
 
 type topoGen struct {
 	Dirs     pkicmn.Dirs
 	Validity conf.Validity
+	// SignAlgorithm is the algorithm of all signing keys.
+	SignAlgorithm string
 }
 
 func (g topoGen) Run(topo topoFile) error {
@@ -139,8 +142,8 @@ func (g topoGen) genASKeys(as addr.AS, cfg conf.TRC) conf.Keys {
 		Primary: make(map[trc.KeyType]map[scrypto.KeyVersion]conf.KeyMeta),
 		Issuer:  make(map[cert.KeyType]map[scrypto.KeyVersion]conf.KeyMeta),
 		AS: map[cert.KeyType]map[scrypto.KeyVersion]conf.KeyMeta{
-			cert.SigningKey:    {1: {Algorithm: scrypto.Ed25519, Validity: g.Validity}},
-			cert.RevocationKey: {1: {Algorithm: scrypto.Ed25519, Validity: g.Validity}},
+			cert.SigningKey:    {1: {Algorithm: g.SignAlgorithm, Validity: g.Validity}},
+			cert.RevocationKey: {1: {Algorithm: g.SignAlgorithm, Validity: g.Validity}},
 			cert.EncryptionKey: {1: {Algorithm: scrypto.Curve25519xSalsa20Poly1305,
 				Validity: g.Validity}},
 		},
@@ -149,18 +152,18 @@ func (g topoGen) genASKeys(as addr.AS, cfg conf.TRC) conf.Keys {
 	_ = ok
 	if primary.Attributes.Contains(trc.Voting) {
 		keys.Primary[trc.VotingOnlineKey] = map[scrypto.KeyVersion]conf.KeyMeta{
-			1: {Algorithm: scrypto.Ed25519, Validity: g.Validity},
+			1: {Algorithm: g.SignAlgorithm, Validity: g.Validity},
 		}
 		keys.Primary[trc.VotingOfflineKey] = map[scrypto.KeyVersion]conf.KeyMeta{
-			1: {Algorithm: scrypto.Ed25519, Validity: g.Validity},
+			1: {Algorithm: g.SignAlgorithm, Validity: g.Validity},
 		}
 	}
 	if primary.Attributes.Contains(trc.Issuing) {
 		keys.Primary[trc.IssuingGrantKey] = map[scrypto.KeyVersion]conf.KeyMeta{
-			1: {Algorithm: scrypto.Ed25519, Validity: g.Validity},
+			1: {Algorithm: g.SignAlgorithm, Validity: g.Validity},
 		}
 		keys.Issuer[cert.IssuingKey] = map[scrypto.KeyVersion]conf.KeyMeta{
-			1: {Algorithm: scrypto.Ed25519, Validity: g.Validity},
+			1: {Algorithm: g.SignAlgorithm, Validity: g.Validity},
 		}
 	}
 	return keys
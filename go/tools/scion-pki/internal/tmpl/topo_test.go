@@ -63,6 +63,7 @@ func TestTopoGen(t *testing.T) {
 			NotBefore: 424242,
 			Validity:  year,
 		},
+		SignAlgorithm: scrypto.ECDSAP256,
 	}
 	err := g.Run(topo)
 	require.NoError(t, err)
@@ -112,16 +113,16 @@ func TestTopoGen(t *testing.T) {
 				assert.Equal(t, g.Validity, meta.Validity)
 			}
 
-			checkMeta(t, cfg.AS[cert.SigningKey][1], scrypto.Ed25519)
-			checkMeta(t, cfg.AS[cert.RevocationKey][1], scrypto.Ed25519)
+			checkMeta(t, cfg.AS[cert.SigningKey][1], g.SignAlgorithm)
+			checkMeta(t, cfg.AS[cert.RevocationKey][1], g.SignAlgorithm)
 			checkMeta(t, cfg.AS[cert.EncryptionKey][1], scrypto.Curve25519xSalsa20Poly1305)
 			if entry.Issuing {
-				checkMeta(t, cfg.Issuer[cert.IssuingKey][1], scrypto.Ed25519)
-				checkMeta(t, cfg.Primary[trc.IssuingGrantKey][1], scrypto.Ed25519)
+				checkMeta(t, cfg.Issuer[cert.IssuingKey][1], g.SignAlgorithm)
+				checkMeta(t, cfg.Primary[trc.IssuingGrantKey][1], g.SignAlgorithm)
 			}
 			if entry.Voting {
-				checkMeta(t, cfg.Primary[trc.VotingOnlineKey][1], scrypto.Ed25519)
-				checkMeta(t, cfg.Primary[trc.VotingOfflineKey][1], scrypto.Ed25519)
+				checkMeta(t, cfg.Primary[trc.VotingOnlineKey][1], g.SignAlgorithm)
+				checkMeta(t, cfg.Primary[trc.VotingOfflineKey][1], g.SignAlgorithm)
 			}
 		})
 	}
@@ -0,0 +1,487 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trcs
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/scrypto/trc"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/tools/scion-pki/internal/pkicmn"
+)
+
+type ceremonyGen struct {
+	Dirs    pkicmn.Dirs
+	Version scrypto.Version
+}
+
+// Run generates the prototype TRCs for all ISDs in the provided mapping and
+// starts a ceremony for each of them. Existing prototype TRCs are only
+// replaced if the force flag is set. The ceremony bundle is written next to
+// the prototype TRC.
+func (g ceremonyGen) Run(asMap pkicmn.ASMap) error {
+	if err := (protoGen{Dirs: g.Dirs, Version: g.Version}).Run(asMap); err != nil {
+		return err
+	}
+	l := loader{Dirs: g.Dirs, Version: g.Version}
+	cfgs, err := l.LoadConfigs(asMap.ISDs())
+	if err != nil {
+		return serrors.WrapStr("unable to load TRC configs", err)
+	}
+	protos, err := l.LoadProtos(cfgs)
+	if err != nil {
+		return serrors.WrapStr("unable to load prototype TRCs", err)
+	}
+	for isd, proto := range protos {
+		c, err := g.newCeremony(isd, proto)
+		if err != nil {
+			return serrors.WrapStr("unable to create ceremony bundle", err,
+				"isd", isd, "version", proto.Version)
+		}
+		raw, err := json.Marshal(c)
+		if err != nil {
+			return serrors.WrapStr("unable to encode ceremony bundle", err,
+				"isd", isd, "version", proto.Version)
+		}
+		file := CeremonyFile(g.Dirs.Out, isd, proto.Version)
+		if err := pkicmn.WriteToFile(raw, file, 0644); err != nil {
+			return serrors.WrapStr("unable to write ceremony bundle", err, "file", file)
+		}
+	}
+	return nil
+}
+
+func (g ceremonyGen) newCeremony(isd addr.ISD, proto signedMeta) (Ceremony, error) {
+	c := Ceremony{
+		Signed: trc.Signed{
+			EncodedTRC: proto.Signed.EncodedTRC,
+			Signatures: []trc.Signature{},
+		},
+	}
+	t, err := proto.Signed.EncodedTRC.Decode()
+	if err != nil {
+		return Ceremony{}, serrors.WrapStr("unable to parse prototype TRC payload", err)
+	}
+	if t.Base() {
+		return c, nil
+	}
+	file := SignedFile(g.Dirs.Out, isd, proto.Version-1)
+	prev, _, err := LoadSigned(file)
+	if err != nil {
+		return Ceremony{}, serrors.WrapStr("unable to load previous TRC", err, "file", file)
+	}
+	c.Prev = &prev
+	if _, _, err := c.Decode(); err != nil {
+		return Ceremony{}, err
+	}
+	return c, nil
+}
+
+// runCeremonyRecord records the signatures of the partially signed TRCs in the
+// ceremony bundle. If no parts are provided, all parts in the directory of the
+// ceremony bundle are recorded.
+func runCeremonyRecord(file string, parts []string) error {
+	c, err := LoadCeremony(file)
+	if err != nil {
+		return serrors.WrapStr("unable to load ceremony bundle", err, "file", file)
+	}
+	if len(parts) == 0 {
+		t, _, err := c.Decode()
+		if err != nil {
+			return err
+		}
+		pattern := filepath.Join(filepath.Dir(file),
+			fmt.Sprintf(pkicmn.TRCSigPartFmt, t.ISD, t.Version, "*"))
+		if parts, err = filepath.Glob(pattern); err != nil {
+			return serrors.WrapStr("unable to list partially signed TRCs", err)
+		}
+	}
+	for _, part := range parts {
+		signed, _, err := LoadSigned(part)
+		if err != nil {
+			return serrors.WrapStr("unable to load partially signed TRC", err, "file", part)
+		}
+		n, err := c.Record(signed)
+		if err != nil {
+			return serrors.WrapStr("unable to record signatures", err, "file", part)
+		}
+		pkicmn.QuietPrint("Recorded %d new signatures from %s\n", n, part)
+	}
+	raw, err := json.Marshal(c)
+	if err != nil {
+		return serrors.WrapStr("unable to encode ceremony bundle", err)
+	}
+	if err := ioutil.WriteFile(file, append(raw, "\n"...), 0644); err != nil {
+		return serrors.WrapStr("unable to write ceremony bundle", err, "file", file)
+	}
+	return runCeremonyStatus(file)
+}
+
+// runCeremonyStatus displays the status of the ceremony.
+func runCeremonyStatus(file string) error {
+	c, err := LoadCeremony(file)
+	if err != nil {
+		return serrors.WrapStr("unable to load ceremony bundle", err, "file", file)
+	}
+	s, err := c.Status()
+	if err != nil {
+		return serrors.WrapStr("unable to determine ceremony status", err, "file", file)
+	}
+	return s.Write(os.Stdout)
+}
+
+// runCeremonyCombine writes the signed TRC to the output directory. It refuses
+// to combine if the signed TRC is not verifiably valid.
+func runCeremonyCombine(file string, dirs pkicmn.Dirs) error {
+	c, err := LoadCeremony(file)
+	if err != nil {
+		return serrors.WrapStr("unable to load ceremony bundle", err, "file", file)
+	}
+	t, _, err := c.Decode()
+	if err != nil {
+		return err
+	}
+	signed, err := c.Combine()
+	if err != nil {
+		return serrors.WrapStr("refusing to combine invalid TRC", err, "file", file)
+	}
+	combined := map[addr.ISD]signedMeta{
+		t.ISD: {Signed: signed, Version: t.Version},
+	}
+	return combiner{Dirs: dirs, Version: t.Version}.Write(combined)
+}
+
+// Ceremony is the bundle of a TRC voting ceremony. It contains the prototype
+// TRC payload, the partial signatures that have been recorded so far, and the
+// previous TRC for TRC updates. The bundle is self-contained, i.e., the
+// ceremony status can be determined without access to the root directory.
+type Ceremony struct {
+	// Signed holds the prototype TRC payload and the recorded signatures.
+	trc.Signed
+	// Prev is the previous TRC. It is only set for TRC updates.
+	Prev *trc.Signed `json:"previous,omitempty"`
+}
+
+// LoadCeremony loads the ceremony bundle from the file.
+func LoadCeremony(file string) (Ceremony, error) {
+	raw, err := ioutil.ReadFile(file)
+	if err != nil {
+		return Ceremony{}, err
+	}
+	var c Ceremony
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return Ceremony{}, serrors.WrapStr("unable to parse ceremony bundle", err)
+	}
+	return c, nil
+}
+
+// Decode decodes the prototype TRC and the previous TRC. For base TRCs, the
+// previous TRC is nil.
+func (c Ceremony) Decode() (*trc.TRC, *trc.TRC, error) {
+	t, err := c.EncodedTRC.Decode()
+	if err != nil {
+		return nil, nil, serrors.WrapStr("unable to parse prototype TRC payload", err)
+	}
+	if t.Base() {
+		return t, nil, nil
+	}
+	if c.Prev == nil {
+		return nil, nil, serrors.New("previous TRC missing in ceremony bundle",
+			"isd", t.ISD, "version", t.Version)
+	}
+	prev, err := c.Prev.EncodedTRC.Decode()
+	if err != nil {
+		return nil, nil, serrors.WrapStr("unable to parse previous TRC payload", err)
+	}
+	if prev.ISD != t.ISD || prev.Version+1 != t.Version {
+		return nil, nil, serrors.New("previous TRC does not precede prototype TRC",
+			"prototype", fmt.Sprintf("ISD%d-V%d", t.ISD, t.Version),
+			"previous", fmt.Sprintf("ISD%d-V%d", prev.ISD, prev.Version))
+	}
+	return t, prev, nil
+}
+
+// Record verifies the signatures of the partially signed TRC and adds them to
+// the ceremony. Signatures that are not expected by the prototype TRC, or that
+// do not verify, are rejected. Signatures that have already been recorded are
+// ignored. The number of newly recorded signatures is returned.
+func (c *Ceremony) Record(part trc.Signed) (int, error) {
+	if part.EncodedTRC != c.EncodedTRC {
+		return 0, serrors.New("payload does not match prototype TRC")
+	}
+	t, prev, err := c.Decode()
+	if err != nil {
+		return 0, err
+	}
+	signatures := make(map[trc.Protected]trc.Signature)
+	for i, sig := range c.Signatures {
+		protected, err := sig.EncodedProtected.Decode()
+		if err != nil {
+			return 0, serrors.WrapStr("unable to parse recorded protected", err, "idx", i)
+		}
+		signatures[protected] = sig
+	}
+	recorded := 0
+	for i, sig := range part.Signatures {
+		protected, err := verifySignature(sig, c.EncodedTRC, t, prev)
+		if err != nil {
+			return 0, serrors.WithCtx(err, "idx", i)
+		}
+		if _, ok := signatures[protected]; ok {
+			continue
+		}
+		signatures[protected] = sig
+		recorded++
+	}
+	c.Signatures = sortSignatures(signatures)
+	return recorded, nil
+}
+
+// Status reports the voting and proof of possession status of the ceremony.
+func (c Ceremony) Status() (CeremonyStatus, error) {
+	t, prev, err := c.Decode()
+	if err != nil {
+		return CeremonyStatus{}, err
+	}
+	recorded := make(map[trc.Protected]struct{})
+	for i, sig := range c.Signatures {
+		protected, err := sig.EncodedProtected.Decode()
+		if err != nil {
+			return CeremonyStatus{}, serrors.WrapStr("unable to parse recorded protected",
+				err, "idx", i)
+		}
+		recorded[protected] = struct{}{}
+	}
+	s := CeremonyStatus{
+		ISD:     t.ISD,
+		Version: t.Version,
+	}
+	for as, keyTypes := range t.ProofOfPossession {
+		for _, keyType := range keyTypes {
+			protected := expectedProtected(trc.POPSignature, as, keyType,
+				t.PrimaryASes[as].Keys[keyType])
+			_, ok := recorded[protected]
+			s.POPs = append(s.POPs, SignatureStatus{AS: as, KeyType: keyType, Recorded: ok})
+		}
+	}
+	sortSignatureStatus(s.POPs)
+	if prev == nil {
+		s.Base = true
+		if err := t.ValidateInvariant(); err != nil {
+			s.ValidationErr = err
+			return s, nil
+		}
+		_, err := Verify(c.Signed, nil)
+		s.Valid = err == nil
+		return s, nil
+	}
+	s.Quorum = prev.VotingQuorum()
+	for as, keyType := range t.Votes {
+		protected := expectedProtected(trc.VoteSignature, as, keyType,
+			prev.PrimaryASes[as].Keys[keyType])
+		_, ok := recorded[protected]
+		s.Votes = append(s.Votes, SignatureStatus{AS: as, KeyType: keyType, Recorded: ok})
+	}
+	sortSignatureStatus(s.Votes)
+	v := trc.UpdateValidator{Next: t, Prev: prev}
+	info, err := v.Validate()
+	if err != nil {
+		s.ValidationErr = err
+		return s, nil
+	}
+	s.UpdateType = info.Type
+	if _, err := Verify(c.Signed, prev); err == nil {
+		s.Valid = true
+	}
+	return s, nil
+}
+
+// Combine returns the signed TRC. It fails if the TRC with the recorded
+// signatures is not verifiably valid.
+func (c Ceremony) Combine() (trc.Signed, error) {
+	_, prev, err := c.Decode()
+	if err != nil {
+		return trc.Signed{}, err
+	}
+	if _, err := Verify(c.Signed, prev); err != nil {
+		return trc.Signed{}, err
+	}
+	return c.Signed, nil
+}
+
+// CeremonyStatus is the status of a TRC voting ceremony.
+type CeremonyStatus struct {
+	ISD     addr.ISD
+	Version scrypto.Version
+	// Base indicates that the prototype TRC is a base TRC.
+	Base bool
+	// UpdateType is the update type. It is empty for base TRCs, and if the
+	// prototype TRC is not a valid update.
+	UpdateType trc.UpdateType
+	// Quorum is the voting quorum of the previous TRC.
+	Quorum int
+	// Votes contains the status of all votes expected by the prototype TRC.
+	Votes []SignatureStatus
+	// POPs contains the status of all proofs of possession expected by the
+	// prototype TRC.
+	POPs []SignatureStatus
+	// ValidationErr is set if the prototype TRC is not a valid update.
+	ValidationErr error
+	// Valid indicates that the TRC with the recorded signatures is valid and
+	// can be combined.
+	Valid bool
+}
+
+// VotesCast returns the number of recorded votes.
+func (s CeremonyStatus) VotesCast() int {
+	return countRecorded(s.Votes)
+}
+
+// SignatureStatus indicates whether a signature has been recorded.
+type SignatureStatus struct {
+	AS       addr.AS
+	KeyType  trc.KeyType
+	Recorded bool
+}
+
+// Write writes the human readable ceremony status.
+func (s CeremonyStatus) Write(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Ceremony ISD%d-V%d\n", s.ISD, s.Version)
+	switch {
+	case s.Base:
+		fmt.Fprintf(&b, "Type: base\n")
+	case s.UpdateType != "":
+		fmt.Fprintf(&b, "Type: %s update\n", s.UpdateType)
+	}
+	if !s.Base {
+		fmt.Fprintf(&b, "Voting quorum: %d (ISD%d-V%d)\n", s.Quorum, s.ISD, s.Version-1)
+		fmt.Fprintf(&b, "Votes: %d/%d cast\n", s.VotesCast(), len(s.Votes))
+		for _, v := range s.Votes {
+			fmt.Fprintf(&b, "  %s %s: %s\n", v.AS, v.KeyType, recordedStatus(v.Recorded))
+		}
+	}
+	fmt.Fprintf(&b, "Proofs of possession: %d/%d shown\n", countRecorded(s.POPs), len(s.POPs))
+	for _, p := range s.POPs {
+		fmt.Fprintf(&b, "  %s %s: %s\n", p.AS, p.KeyType, recordedStatus(p.Recorded))
+	}
+	switch {
+	case s.ValidationErr != nil:
+		fmt.Fprintf(&b, "Status: invalid prototype TRC\n%s\n", s.ValidationErr)
+	case s.Valid:
+		fmt.Fprintf(&b, "Status: ready to combine\n")
+	default:
+		fmt.Fprintf(&b, "Status: signatures missing\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// verifySignature checks that the signature is expected by the prototype TRC
+// and verifies it. Votes are verified with the keys in the previous TRC,
+// proofs of possession with the keys in the prototype TRC.
+func verifySignature(sig trc.Signature, encoded trc.Encoded, t, prev *trc.TRC) (trc.Protected,
+	error) {
+
+	protected, err := sig.EncodedProtected.Decode()
+	if err != nil {
+		return trc.Protected{}, serrors.WrapStr("unable to parse protected", err)
+	}
+	var meta scrypto.KeyMeta
+	switch protected.Type {
+	case trc.VoteSignature:
+		keyType, ok := t.Votes[protected.AS]
+		if !ok || keyType != protected.KeyType || prev == nil {
+			return trc.Protected{}, serrors.New("unexpected vote", "as", protected.AS,
+				"key_type", protected.KeyType)
+		}
+		meta = prev.PrimaryASes[protected.AS].Keys[protected.KeyType]
+	case trc.POPSignature:
+		if !containsKeyType(protected.KeyType, t.ProofOfPossession[protected.AS]) {
+			return trc.Protected{}, serrors.New("unexpected proof of possession",
+				"as", protected.AS, "key_type", protected.KeyType)
+		}
+		meta = t.PrimaryASes[protected.AS].Keys[protected.KeyType]
+	default:
+		return trc.Protected{}, serrors.New("unknown signature type", "type", protected.Type)
+	}
+	expected := expectedProtected(protected.Type, protected.AS, protected.KeyType, meta)
+	if protected != expected {
+		return trc.Protected{}, serrors.New("invalid protected", "expected", expected,
+			"actual", protected)
+	}
+	input := trc.SigInput(sig.EncodedProtected, encoded)
+	if err := scrypto.Verify(input, sig.Signature, meta.Key, meta.Algorithm); err != nil {
+		return trc.Protected{}, serrors.WrapStr("unable to verify signature", err,
+			"as", protected.AS, "key_type", protected.KeyType, "type", protected.Type)
+	}
+	return protected, nil
+}
+
+func expectedProtected(sigType trc.SignatureType, as addr.AS, keyType trc.KeyType,
+	meta scrypto.KeyMeta) trc.Protected {
+
+	return trc.Protected{
+		Algorithm:  meta.Algorithm,
+		Type:       sigType,
+		KeyType:    keyType,
+		KeyVersion: meta.KeyVersion,
+		AS:         as,
+	}
+}
+
+func containsKeyType(keyType trc.KeyType, keyTypes []trc.KeyType) bool {
+	for _, t := range keyTypes {
+		if t == keyType {
+			return true
+		}
+	}
+	return false
+}
+
+func sortSignatureStatus(s []SignatureStatus) {
+	sort.Slice(s, func(i, j int) bool {
+		if s[i].AS != s[j].AS {
+			return s[i].AS < s[j].AS
+		}
+		return s[i].KeyType < s[j].KeyType
+	})
+}
+
+func countRecorded(s []SignatureStatus) int {
+	count := 0
+	for _, v := range s {
+		if v.Recorded {
+			count++
+		}
+	}
+	return count
+}
+
+func recordedStatus(recorded bool) string {
+	if recorded {
+		return "recorded"
+	}
+	return "missing"
+}
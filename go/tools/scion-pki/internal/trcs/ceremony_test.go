@@ -0,0 +1,82 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trcs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/scrypto/trc"
+)
+
+func TestCeremony(t *testing.T) {
+	load := func(t *testing.T, file string) trc.Signed {
+		signed, _, err := LoadSigned(file)
+		require.NoError(t, err)
+		return signed
+	}
+	prev := load(t, SignedFile("./testdata", 1, 1))
+	proto := load(t, ProtoFile("./testdata", 1, 2))
+	c := Ceremony{Signed: trc.Signed{EncodedTRC: proto.EncodedTRC}, Prev: &prev}
+
+	s, err := c.Status()
+	require.NoError(t, err)
+	assert.Equal(t, trc.RegularUpdate, s.UpdateType)
+	assert.Equal(t, 2, s.Quorum)
+	assert.Len(t, s.Votes, 2)
+	assert.Equal(t, 0, s.VotesCast())
+	assert.False(t, s.Valid)
+	_, err = c.Combine()
+	assert.Error(t, err)
+
+	// Parts of another TRC are rejected.
+	_, err = c.Record(load(t, PartsFile("./testdata", ia120, 3)))
+	assert.Error(t, err)
+
+	n, err := c.Record(load(t, PartsFile("./testdata", ia110, 2)))
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	s, err = c.Status()
+	require.NoError(t, err)
+	assert.Equal(t, 1, s.VotesCast())
+	assert.False(t, s.Valid)
+
+	// Tampered signatures are rejected.
+	tampered := load(t, PartsFile("./testdata", ia120, 2))
+	tampered.Signatures[0].Signature = append([]byte(nil), tampered.Signatures[0].Signature...)
+	tampered.Signatures[0].Signature[0] ^= 0xFF
+	_, err = c.Record(tampered)
+	assert.Error(t, err)
+
+	n, err = c.Record(load(t, PartsFile("./testdata", ia120, 2)))
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	n, err = c.Record(load(t, PartsFile("./testdata", ia120, 2)))
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	s, err = c.Status()
+	require.NoError(t, err)
+	assert.Equal(t, 2, s.VotesCast())
+	assert.True(t, s.Valid)
+	signed, err := c.Combine()
+	require.NoError(t, err)
+	_, decodedPrev, err := c.Decode()
+	require.NoError(t, err)
+	_, err = Verify(signed, decodedPrev)
+	assert.NoError(t, err)
+}
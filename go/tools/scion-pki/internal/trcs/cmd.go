@@ -42,6 +42,9 @@ Generating a TRC can be split into three phases:
 In case the caller has access to all private keys, the caller can use a
 short-cut command that generates the signed TRC in one call: 'gen'.
 
+To keep track of the signatures while the prototype TRC is distributed to the
+signing ASes, use 'ceremony'.
+
 Selector:
     *: All ISDs under the root directory.
     X: ISD X.
@@ -198,11 +201,164 @@ var human = &cobra.Command{
 	},
 }
 
+var prevTRC string
+
+var verify = &cobra.Command{
+	Use:   "verify",
+	Short: "Verify TRCs",
+	Example: `  scion-pki trcs verify ISD1/trcs/ISD1-V1.trc
+  scion-pki trcs verify ISD1/trcs/ISD1-V1.trc ISD1/trcs/ISD1-V2.trc
+  scion-pki trcs verify --prev ISD1/trcs/ISD1-V1.trc ISD1/trcs/ISD1-V2.trc`,
+	Long: `'verify' validates and verifies the provided TRCs offline.
+
+For base TRCs, the TRC invariants are validated and all proofs of possession
+are verified. TRC updates are validated and verified against their predecessor.
+The TRCs are processed in the provided order, and each TRC update is verified
+against the TRC preceding it. The predecessor of the first TRC can be provided
+with the --prev flag.
+`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := runVerify(args, prevTRC); err != nil {
+			return serrors.WrapStr("unable to verify TRCs", err)
+		}
+		return nil
+	},
+}
+
+var diff = &cobra.Command{
+	Use:     "diff",
+	Short:   "Display the differences between two TRCs",
+	Example: `  scion-pki trcs diff ISD1/trcs/ISD1-V1.trc ISD1/trcs/ISD1-V2.trc`,
+	Long: `'diff' compares the payloads of two TRCs and displays the changed fields,
+e.g., modified keys, attributes, votes and proofs of possession.
+`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := runDiff(args[0], args[1]); err != nil {
+			return serrors.WrapStr("unable to diff TRCs", err)
+		}
+		return nil
+	},
+}
+
+var ceremony = &cobra.Command{
+	Use:   "ceremony",
+	Short: "Run a TRC voting ceremony",
+	Long: `'ceremony' tracks the signatures of a TRC while the prototype TRC is
+distributed to the voting and signing ASes.
+
+A ceremony consists of the following steps:
+1. 'start': Generate the prototype TRC and the ceremony bundle.
+2. Each primary AS signs the prototype TRC with 'scion-pki trcs sign'.
+3. 'record': Verify the partial signatures and record them in the bundle.
+4. 'status': Display the missing votes and proofs of possession.
+5. 'combine': Verify the TRC with the recorded signatures and write it.
+
+The ceremony bundle contains the prototype TRC, the recorded signatures, and
+for TRC updates the previous TRC. It is written to the same directory as the
+prototype TRC.
+`,
+}
+
+var ceremonyStart = &cobra.Command{
+	Use:   "start",
+	Short: "Start a TRC voting ceremony",
+	Example: `  scion-pki trcs ceremony start 1
+  scion-pki trcs ceremony start 1 --version 2 -d $SPKI_ROOT_DIR`,
+	Long: `'start' generates the prototype TRCs and the ceremony bundles based on the
+selector.
+
+This command has the same requirements as 'proto'.
+
+See 'scion-pki help trcs' for information on the selector.
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		g := ceremonyGen{
+			Dirs:    pkicmn.GetDirs(),
+			Version: scrypto.Version(version),
+		}
+		asMap, err := pkicmn.ProcessSelector(args[0])
+		if err != nil {
+			return serrors.WrapStr("unable to select target ISDs", err, "selector", args[0])
+		}
+		if err := g.Run(asMap); err != nil {
+			return serrors.WrapStr("unable to start ceremony", err)
+		}
+		return nil
+	},
+}
+
+var ceremonyRecord = &cobra.Command{
+	Use:   "record <bundle> [parts...]",
+	Short: "Record partial signatures in the ceremony bundle",
+	Example: `  scion-pki trcs ceremony record ISD1/trcs/ISD1-V2.parts/ISD1-V2.ceremony
+  scion-pki trcs ceremony record ISD1-V2.ceremony ISD1-V2.ff00_0_110.sig`,
+	Long: `'record' verifies the signatures of the partially signed TRCs and records
+them in the ceremony bundle. Signatures that are not expected by the prototype
+TRC, or that fail to verify, are rejected.
+
+If no partially signed TRCs are provided, all of them in the directory of the
+ceremony bundle are recorded.
+`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := runCeremonyRecord(args[0], args[1:]); err != nil {
+			return serrors.WrapStr("unable to record signatures", err)
+		}
+		return nil
+	},
+}
+
+var ceremonyStatus = &cobra.Command{
+	Use:     "status <bundle>",
+	Short:   "Display the status of the ceremony",
+	Example: `  scion-pki trcs ceremony status ISD1/trcs/ISD1-V2.parts/ISD1-V2.ceremony`,
+	Long: `'status' displays the recorded and missing votes and proofs of possession.
+For TRC updates, the votes are reported against the voting quorum of the
+previous TRC.
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := runCeremonyStatus(args[0]); err != nil {
+			return serrors.WrapStr("unable to display ceremony status", err)
+		}
+		return nil
+	},
+}
+
+var ceremonyCombine = &cobra.Command{
+	Use:     "combine <bundle>",
+	Short:   "Combine the recorded signatures to the signed TRC",
+	Example: `  scion-pki trcs ceremony combine ISD1/trcs/ISD1-V2.parts/ISD1-V2.ceremony`,
+	Long: `'combine' writes the signed TRC with the recorded signatures to the output
+directory. The command refuses to combine, unless the signed TRC is valid and
+all signatures verify.
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := runCeremonyCombine(args[0], pkicmn.GetDirs()); err != nil {
+			return serrors.WrapStr("unable to combine TRC", err)
+		}
+		return nil
+	},
+}
+
 func init() {
 	Cmd.PersistentFlags().Uint64Var(&version, "version", 0, "TRC version (0 indicates newest)")
+	verify.Flags().StringVar(&prevTRC, "prev", "",
+		"TRC preceding the first TRC that is verified")
 	Cmd.AddCommand(gen)
 	Cmd.AddCommand(proto)
 	Cmd.AddCommand(sign)
 	Cmd.AddCommand(combine)
 	Cmd.AddCommand(human)
+	Cmd.AddCommand(verify)
+	Cmd.AddCommand(diff)
+	Cmd.AddCommand(ceremony)
+	ceremony.AddCommand(ceremonyStart)
+	ceremony.AddCommand(ceremonyRecord)
+	ceremony.AddCommand(ceremonyStatus)
+	ceremony.AddCommand(ceremonyCombine)
 }
@@ -0,0 +1,204 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trcs
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/scrypto/trc"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+var keyTypes = []trc.KeyType{trc.IssuingGrantKey, trc.VotingOnlineKey, trc.VotingOfflineKey}
+
+func runDiff(oldFile, newFile string) error {
+	_, oldTRC, err := LoadSigned(oldFile)
+	if err != nil {
+		return serrors.WrapStr("unable to load TRC", err, "file", oldFile)
+	}
+	_, newTRC, err := LoadSigned(newFile)
+	if err != nil {
+		return serrors.WrapStr("unable to load TRC", err, "file", newFile)
+	}
+	return writeDiff(os.Stdout, Diff(oldTRC, newTRC))
+}
+
+func writeDiff(w io.Writer, diff []string) error {
+	if len(diff) == 0 {
+		_, err := fmt.Fprintln(w, "TRCs are identical")
+		return err
+	}
+	for _, line := range diff {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Diff returns the differences between the two TRC payloads in a human
+// readable format. Each entry describes the change of a single field.
+func Diff(a, b *trc.TRC) []string {
+	d := differ{}
+	d.cmp("isd", a.ISD, b.ISD)
+	d.cmp("trc_version", a.Version, b.Version)
+	d.cmp("base_version", a.BaseVersion, b.BaseVersion)
+	d.cmp("description", a.Description, b.Description)
+	d.cmp("voting_quorum", a.VotingQuorum(), b.VotingQuorum())
+	d.cmp("format_version", a.FormatVersion, b.FormatVersion)
+	d.cmp("grace_period", fmtGracePeriod(a.GracePeriod), fmtGracePeriod(b.GracePeriod))
+	d.cmp("trust_reset_allowed", a.TrustResetAllowed(), b.TrustResetAllowed())
+	d.cmp("validity", fmtValidity(a.Validity), fmtValidity(b.Validity))
+	for _, as := range primaryASes(a, b) {
+		d.primaryAS(as, a.PrimaryASes, b.PrimaryASes)
+	}
+	d.cmp("votes", fmtVotes(a.Votes), fmtVotes(b.Votes))
+	d.cmp("proof_of_possession", fmtPOPs(a.ProofOfPossession), fmtPOPs(b.ProofOfPossession))
+	return d.lines
+}
+
+type differ struct {
+	lines []string
+}
+
+func (d *differ) cmp(field string, a, b interface{}) {
+	if fmt.Sprint(a) != fmt.Sprint(b) {
+		d.add("%s: %v -> %v", field, a, b)
+	}
+}
+
+func (d *differ) add(format string, args ...interface{}) {
+	d.lines = append(d.lines, fmt.Sprintf(format, args...))
+}
+
+func (d *differ) primaryAS(as addr.AS, a, b trc.PrimaryASes) {
+	prefix := fmt.Sprintf("primary_ases.%s", as)
+	prev, inA := a[as]
+	next, inB := b[as]
+	switch {
+	case !inB:
+		d.add("%s: removed", prefix)
+		return
+	case !inA:
+		d.add("%s: added with attributes %v", prefix, next.Attributes)
+		for _, keyType := range keyTypes {
+			if meta, ok := next.Keys[keyType]; ok {
+				d.add("%s.keys.%s: added %s", prefix, keyType, fmtKey(meta))
+			}
+		}
+		return
+	}
+	d.cmp(prefix+".attributes", sortedAttributes(prev.Attributes),
+		sortedAttributes(next.Attributes))
+	for _, keyType := range keyTypes {
+		field := fmt.Sprintf("%s.keys.%s", prefix, keyType)
+		prevMeta, inA := prev.Keys[keyType]
+		nextMeta, inB := next.Keys[keyType]
+		switch {
+		case inA && !inB:
+			d.add("%s: removed %s", field, fmtKey(prevMeta))
+		case !inA && inB:
+			d.add("%s: added %s", field, fmtKey(nextMeta))
+		case inA && inB:
+			if prevMeta.KeyVersion != nextMeta.KeyVersion ||
+				prevMeta.Algorithm != nextMeta.Algorithm ||
+				!bytes.Equal(prevMeta.Key, nextMeta.Key) {
+
+				d.add("%s: %s -> %s", field, fmtKey(prevMeta), fmtKey(nextMeta))
+			}
+		}
+	}
+}
+
+func primaryASes(a, b *trc.TRC) []addr.AS {
+	set := make(map[addr.AS]struct{})
+	for as := range a.PrimaryASes {
+		set[as] = struct{}{}
+	}
+	for as := range b.PrimaryASes {
+		set[as] = struct{}{}
+	}
+	return sortedASes(set)
+}
+
+func sortedASes(set map[addr.AS]struct{}) []addr.AS {
+	ases := make([]addr.AS, 0, len(set))
+	for as := range set {
+		ases = append(ases, as)
+	}
+	sort.Slice(ases, func(i, j int) bool { return ases[i] < ases[j] })
+	return ases
+}
+
+func sortedAttributes(attrs trc.Attributes) []trc.Attribute {
+	sorted := append([]trc.Attribute(nil), attrs...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted
+}
+
+func fmtKey(meta scrypto.KeyMeta) string {
+	return fmt.Sprintf("v%d (%s %x)", meta.KeyVersion, meta.Algorithm, []byte(meta.Key))
+}
+
+func fmtGracePeriod(p *trc.Period) string {
+	if p == nil {
+		return "<nil>"
+	}
+	return util.FmtDuration(p.Duration)
+}
+
+func fmtValidity(v *scrypto.Validity) string {
+	if v == nil {
+		return "<nil>"
+	}
+	return v.String()
+}
+
+func fmtVotes(votes map[addr.AS]trc.KeyType) string {
+	set := make(map[addr.AS]struct{}, len(votes))
+	for as := range votes {
+		set[as] = struct{}{}
+	}
+	var entries []string
+	for _, as := range sortedASes(set) {
+		entries = append(entries, fmt.Sprintf("%s(%s)", as, votes[as]))
+	}
+	return "[" + strings.Join(entries, " ") + "]"
+}
+
+func fmtPOPs(pops map[addr.AS][]trc.KeyType) string {
+	set := make(map[addr.AS]struct{}, len(pops))
+	for as := range pops {
+		set[as] = struct{}{}
+	}
+	var entries []string
+	for _, as := range sortedASes(set) {
+		var types []string
+		for _, keyType := range pops[as] {
+			types = append(types, keyType.String())
+		}
+		sort.Strings(types)
+		entries = append(entries, fmt.Sprintf("%s(%s)", as, strings.Join(types, ",")))
+	}
+	return "[" + strings.Join(entries, " ") + "]"
+}
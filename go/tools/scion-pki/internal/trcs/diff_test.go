@@ -0,0 +1,40 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trcs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/scrypto/trc"
+)
+
+func TestDiff(t *testing.T) {
+	_, v1, err := LoadSigned(SignedFile("./testdata", 1, 1))
+	require.NoError(t, err)
+	_, v2, err := LoadSigned(SignedFile("./testdata", 1, 2))
+	require.NoError(t, err)
+
+	assert.Empty(t, Diff(v1, v1))
+	diff := Diff(v1, v2)
+	assert.Contains(t, diff, "trc_version: 1 -> 2")
+	assert.Contains(t, diff, "grace_period: 0s -> 10h")
+	assert.Contains(t, diff, "votes: [] -> [ff00:0:110(voting_offline) ff00:0:120(voting_online)]")
+	assert.Contains(t, diff, "primary_ases.ff00:0:110.keys.voting_online: "+
+		fmtKey(v1.PrimaryASes[ia110.A].Keys[trc.VotingOnlineKey])+" -> "+
+		fmtKey(v2.PrimaryASes[ia110.A].Keys[trc.VotingOnlineKey]))
+}
@@ -43,13 +43,27 @@ func genHuman(file string) error {
 	if err := json.Unmarshal(raw, &signed); err != nil {
 		return serrors.WrapStr("unable to parse signed TRC", err, "file", file)
 	}
+	humanReadable, err := DecodeHuman(signed)
+	if err != nil {
+		return serrors.WithCtx(err, "file", file)
+	}
+	if raw, err = json.MarshalIndent(humanReadable, "", "  "); err != nil {
+		return serrors.WrapStr("unable to write human readable trc", err, "file", file)
+	}
+	_, err = fmt.Fprintln(os.Stdout, string(raw))
+	return err
+}
+
+// DecodeHuman decodes the payload and the protected signature metadata of the
+// signed TRC. The result can be marshalled to a human readable representation.
+func DecodeHuman(signed trc.Signed) (interface{}, error) {
 	t, err := signed.EncodedTRC.Decode()
 	if err != nil {
-		return serrors.WrapStr("unable to parse TRC payload", err, "file", file)
+		return nil, serrors.WrapStr("unable to parse TRC payload", err)
 	}
 	signatures, err := parseSignatures(signed.Signatures)
 	if err != nil {
-		return serrors.WrapStr("unable to parse signatures", err, "file", file)
+		return nil, serrors.WrapStr("unable to parse signatures", err)
 	}
 	humanReadable := struct {
 		Payload    *trc.TRC    `json:"payload"`
@@ -58,11 +72,7 @@ func genHuman(file string) error {
 		Payload:    t,
 		Signatures: signatures,
 	}
-	if raw, err = json.MarshalIndent(humanReadable, "", "  "); err != nil {
-		return serrors.WrapStr("unable to write human readable trc", err, "file", file)
-	}
-	_, err = fmt.Fprintln(os.Stdout, string(raw))
-	return err
+	return humanReadable, nil
 }
 
 func parseSignatures(packed []trc.Signature) ([]signature, error) {
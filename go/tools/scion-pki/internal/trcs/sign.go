@@ -137,18 +137,18 @@ func (g signatureGen) castVote(signatures map[trc.Protected]trc.Signature, ia ad
 	if err != nil {
 		return err
 	}
-	priv, err := g.loadKey(id)
+	signer, err := keys.Signer(g.Dirs.Out, id)
 	if err != nil {
 		return err
 	}
 	protected := trc.Protected{
 		AS:         ia.A,
-		Algorithm:  priv.Algorithm,
+		Algorithm:  signer.Meta().Algorithm,
 		KeyType:    keyType,
-		KeyVersion: priv.Version,
+		KeyVersion: signer.Meta().Version,
 		Type:       trc.VoteSignature,
 	}
-	signature, err := g.sign(protected, signed, priv)
+	signature, err := g.sign(protected, signed, signer)
 	if err != nil {
 		return err
 	}
@@ -170,18 +170,18 @@ func (g signatureGen) showPOP(signatures map[trc.Protected]trc.Signature, ia add
 		if err != nil {
 			return err
 		}
-		priv, err := g.loadKey(id)
+		signer, err := keys.Signer(g.Dirs.Out, id)
 		if err != nil {
 			return err
 		}
 		protected := trc.Protected{
 			AS:         ia.A,
-			Algorithm:  priv.Algorithm,
+			Algorithm:  signer.Meta().Algorithm,
 			KeyType:    keyType,
-			KeyVersion: priv.Version,
+			KeyVersion: signer.Meta().Version,
 			Type:       trc.POPSignature,
 		}
-		signature, err := g.sign(protected, signed, priv)
+		signature, err := g.sign(protected, signed, signer)
 		if err != nil {
 			return err
 		}
@@ -191,24 +191,14 @@ func (g signatureGen) showPOP(signatures map[trc.Protected]trc.Signature, ia add
 	return nil
 }
 
-func (g signatureGen) loadKey(id keyconf.ID) (keyconf.Key, error) {
-	file := keys.PrivateFile(g.Dirs.Out, id)
-	priv, err := keyconf.LoadKeyFromFile(file, keyconf.PrivateKey, id)
-	if err != nil {
-		return keyconf.Key{}, serrors.WrapStr("unable to load private key", err, "file", file)
-	}
-	return priv, nil
-}
-
 func (g signatureGen) sign(protected trc.Protected, signed trc.Signed,
-	priv keyconf.Key) (trc.Signature, error) {
+	signer keyconf.Signer) (trc.Signature, error) {
 
 	encProtected, err := trc.EncodeProtected(protected)
 	if err != nil {
 		return trc.Signature{}, serrors.WrapStr("unable to encode protected", err)
 	}
-	sig, err := scrypto.Sign(trc.SigInput(encProtected, signed.EncodedTRC),
-		priv.Bytes, priv.Algorithm)
+	sig, err := signer.Sign(trc.SigInput(encProtected, signed.EncodedTRC))
 	if err != nil {
 		return trc.Signature{}, serrors.WrapStr("unable to sign", err)
 	}
@@ -52,6 +52,11 @@ func AllPartsFiles(dir string, isd addr.ISD, ver scrypto.Version) string {
 	return filepath.Join(PartsDir(dir, isd, ver), fmt.Sprintf(pkicmn.TRCSigPartFmt, isd, ver, "*"))
 }
 
+// CeremonyFile returns the file path for the TRC ceremony bundle.
+func CeremonyFile(dir string, isd addr.ISD, ver scrypto.Version) string {
+	return filepath.Join(PartsDir(dir, isd, ver), fmt.Sprintf(pkicmn.TRCCeremonyNameFmt, isd, ver))
+}
+
 // SignedFile returns the file path for the signed TRC.
 func SignedFile(dir string, isd addr.ISD, ver scrypto.Version) string {
 	return filepath.Join(Dir(dir, isd), fmt.Sprintf(pkicmn.TrcNameFmt, isd, ver))
@@ -81,17 +86,26 @@ func sortSignatures(signatures map[trc.Protected]trc.Signature) []trc.Signature
 }
 
 func loadTRC(file string) (*trc.TRC, trc.Encoded, error) {
-	raw, err := ioutil.ReadFile(file)
+	signed, t, err := LoadSigned(file)
 	if err != nil {
 		return nil, "", err
 	}
+	return t, signed.EncodedTRC, nil
+}
+
+// LoadSigned loads the signed TRC from the file and decodes its payload.
+func LoadSigned(file string) (trc.Signed, *trc.TRC, error) {
+	raw, err := ioutil.ReadFile(file)
+	if err != nil {
+		return trc.Signed{}, nil, err
+	}
 	signed, err := trc.ParseSigned(raw)
 	if err != nil {
-		return nil, "", err
+		return trc.Signed{}, nil, err
 	}
 	t, err := signed.EncodedTRC.Decode()
 	if err != nil {
-		return nil, "", err
+		return trc.Signed{}, nil, err
 	}
-	return t, signed.EncodedTRC, nil
+	return signed, t, nil
 }
@@ -42,39 +42,12 @@ func (v validator) validate(isd addr.ISD, meta signedMeta) error {
 	if err != nil {
 		return serrors.WrapStr("invalid TRC payload", err)
 	}
-	if err := t.ValidateInvariant(); err != nil {
-		return serrors.WrapStr("violated TRC invariant", err)
-	}
-	pop := trc.POPVerifier{
-		TRC:        t,
-		Encoded:    meta.Signed.EncodedTRC,
-		Signatures: meta.Signed.Signatures,
-	}
-	if err := pop.Verify(); err != nil {
-		return serrors.WrapStr("proof of possesions fail to verify", err)
-	}
-	if t.Base() {
-		return nil
-	}
-	prev, _, err := loadTRC(SignedFile(v.Dirs.Out, isd, meta.Version-1))
-	if err != nil {
-		return serrors.WrapStr("unable to load previous TRC", err, "version", meta.Version-1)
-	}
-	val := trc.UpdateValidator{
-		Next: t,
-		Prev: prev,
-	}
-	if _, err := val.Validate(); err != nil {
-		return serrors.WrapStr("unable to validate TRC update", err)
-	}
-	ver := trc.UpdateVerifier{
-		Next:        t,
-		NextEncoded: meta.Signed.EncodedTRC,
-		Signatures:  meta.Signed.Signatures,
-		Prev:        prev,
-	}
-	if err := ver.Verify(); err != nil {
-		return serrors.WrapStr("unable to verify TRC update", err)
+	var prev *trc.TRC
+	if !t.Base() {
+		if prev, _, err = loadTRC(SignedFile(v.Dirs.Out, isd, meta.Version-1)); err != nil {
+			return serrors.WrapStr("unable to load previous TRC", err, "version", meta.Version-1)
+		}
 	}
-	return nil
+	_, err = Verify(meta.Signed, prev)
+	return err
 }
@@ -0,0 +1,93 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trcs
+
+import (
+	"github.com/scionproto/scion/go/lib/scrypto/trc"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/tools/scion-pki/internal/pkicmn"
+)
+
+// Verify validates and verifies the signed TRC. For base TRCs, the TRC
+// invariants are validated and all proofs of possession are verified. TRC
+// updates are additionally validated and verified based on the previous TRC,
+// which must be provided in that case.
+func Verify(signed trc.Signed, prev *trc.TRC) (*trc.TRC, error) {
+	t, err := signed.EncodedTRC.Decode()
+	if err != nil {
+		return nil, serrors.WrapStr("invalid TRC payload", err)
+	}
+	if err := t.ValidateInvariant(); err != nil {
+		return nil, serrors.WrapStr("violated TRC invariant", err)
+	}
+	pop := trc.POPVerifier{
+		TRC:        t,
+		Encoded:    signed.EncodedTRC,
+		Signatures: signed.Signatures,
+	}
+	if err := pop.Verify(); err != nil {
+		return nil, serrors.WrapStr("proof of possesions fail to verify", err)
+	}
+	if t.Base() {
+		return t, nil
+	}
+	if prev == nil {
+		return nil, serrors.New("previous TRC required to verify TRC update",
+			"version", t.Version)
+	}
+	val := trc.UpdateValidator{
+		Next: t,
+		Prev: prev,
+	}
+	if _, err := val.Validate(); err != nil {
+		return nil, serrors.WrapStr("unable to validate TRC update", err)
+	}
+	ver := trc.UpdateVerifier{
+		Next:        t,
+		NextEncoded: signed.EncodedTRC,
+		Signatures:  signed.Signatures,
+		Prev:        prev,
+	}
+	if err := ver.Verify(); err != nil {
+		return nil, serrors.WrapStr("unable to verify TRC update", err)
+	}
+	return t, nil
+}
+
+// runVerify verifies the TRC files in the provided order. Each TRC update is
+// verified based on the TRC preceding it. The first TRC is verified based on
+// the TRC in prevFile, if it is set.
+func runVerify(files []string, prevFile string) error {
+	var prev *trc.TRC
+	if prevFile != "" {
+		var err error
+		if _, prev, err = LoadSigned(prevFile); err != nil {
+			return serrors.WrapStr("unable to load previous TRC", err, "file", prevFile)
+		}
+	}
+	for _, file := range files {
+		signed, _, err := LoadSigned(file)
+		if err != nil {
+			return serrors.WrapStr("unable to load TRC", err, "file", file)
+		}
+		t, err := Verify(signed, prev)
+		if err != nil {
+			return serrors.WrapStr("unable to verify TRC", err, "file", file)
+		}
+		pkicmn.QuietPrint("Verified TRC ISD%d-V%d: %s\n", t.ISD, t.Version, file)
+		prev = t
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trcs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/scrypto/trc"
+)
+
+func TestVerify(t *testing.T) {
+	load := func(t *testing.T, v scrypto.Version) (trc.Signed, *trc.TRC) {
+		signed, decoded, err := LoadSigned(SignedFile("./testdata", 1, v))
+		require.NoError(t, err)
+		return signed, decoded
+	}
+	tests := map[string]struct {
+		Version      scrypto.Version
+		Prev         scrypto.Version
+		ErrAssertion assert.ErrorAssertionFunc
+	}{
+		"base TRC": {
+			Version:      1,
+			ErrAssertion: assert.NoError,
+		},
+		"update v2": {
+			Version:      2,
+			Prev:         1,
+			ErrAssertion: assert.NoError,
+		},
+		"update v3": {
+			Version:      3,
+			Prev:         2,
+			ErrAssertion: assert.NoError,
+		},
+		"update without previous TRC": {
+			Version:      2,
+			ErrAssertion: assert.Error,
+		},
+		"update with wrong previous TRC": {
+			Version:      3,
+			Prev:         1,
+			ErrAssertion: assert.Error,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			signed, decoded := load(t, test.Version)
+			var prev *trc.TRC
+			if test.Prev != 0 {
+				_, prev = load(t, test.Prev)
+			}
+			verified, err := Verify(signed, prev)
+			test.ErrAssertion(t, err)
+			if err == nil {
+				assert.Equal(t, decoded, verified)
+			}
+		})
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/env"
 	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/pathpol"
 	"github.com/scionproto/scion/go/lib/sciond"
 	"github.com/scionproto/scion/go/lib/sciond/pathprobe"
 	"github.com/scionproto/scion/go/lib/serrors"
@@ -40,13 +41,17 @@ var (
 	expiration = flag.Bool("expiration", false, "Show path expiration timestamps")
 	refresh    = flag.Bool("refresh", false, "Set refresh flag for SCIOND path request")
 	status     = flag.Bool("p", false, "Probe the paths and print out the statuses")
+	metadata   = flag.Bool("metadata", false, "Show path latency and bandwidth, if known")
 	version    = flag.Bool("version", false, "Output version information and exit.")
+	policyFile = flag.String("policy", "",
+		"Path policy file (YAML or JSON) used to filter and order the paths")
 )
 
 var (
-	dstIA addr.IA
-	srcIA addr.IA
-	local snet.UDPAddr
+	dstIA  addr.IA
+	srcIA  addr.IA
+	local  snet.UDPAddr
+	policy *pathpol.Policy
 )
 
 func init() {
@@ -87,6 +92,9 @@ func main() {
 			fmt.Printf(" Expires: %s (%s)", path.Expiry(),
 				time.Until(path.Expiry()).Truncate(time.Second))
 		}
+		if *metadata {
+			fmt.Printf(" Latency: %s Bandwidth: %s", formatLatency(path), formatBandwidth(path))
+		}
 		if *status {
 			fmt.Printf(" Status: %s", pathStatuses[pathprobe.PathKey(path)])
 		}
@@ -119,6 +127,12 @@ func validateFlags() {
 	if *status && (local.IA.IsZero() || local.Host == nil) {
 		LogFatal("Local address is required for health checks")
 	}
+
+	if *policyFile != "" {
+		if policy, err = pathpol.LoadPolicy(*policyFile); err != nil {
+			LogFatal("Unable to load path policy", "err", err)
+		}
+	}
 }
 
 // TODO(lukedirtwalker): Replace this with snet.Router once we have the
@@ -134,7 +148,38 @@ func getPaths(ctx context.Context) ([]snet.Path, error) {
 	if err != nil {
 		return nil, serrors.WrapStr("failed to retrieve paths from SCIOND", err)
 	}
-	return paths, nil
+	if policy == nil {
+		return paths, nil
+	}
+	return applyPolicy(paths), nil
+}
+
+// applyPolicy filters the paths with the path policy and returns them in the
+// order preferred by the policy.
+func applyPolicy(paths []snet.Path) []snet.Path {
+	ps := make(pathpol.PathSet, len(paths))
+	for _, path := range paths {
+		ps[path.Fingerprint()] = path
+	}
+	var filtered []snet.Path
+	for _, path := range policy.Sort(policy.Filter(ps)) {
+		filtered = append(filtered, path.(snet.Path))
+	}
+	return filtered
+}
+
+func formatLatency(path snet.Path) string {
+	if latency, ok := path.Metadata().TotalLatency(); ok {
+		return latency.String()
+	}
+	return "unknown"
+}
+
+func formatBandwidth(path snet.Path) string {
+	if bw, ok := path.Metadata().MinBandwidth(); ok {
+		return fmt.Sprintf("%d Kbit/s", bw)
+	}
+	return "unknown"
 }
 
 func flagUsage() {
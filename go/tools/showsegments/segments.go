@@ -0,0 +1,122 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Simple application that shows the path segments known to SCIOND.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/env"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/proto"
+)
+
+var (
+	sciondAddr = flag.String("sciond", sciond.DefaultSCIONDAddress, "SCIOND address")
+	timeout    = flag.Duration("timeout", 5*time.Second, "Timeout in seconds")
+	segTypeStr = flag.String("type", "", "Segment type to show: up, core or down (default all)")
+	version    = flag.Bool("version", false, "Output version information and exit.")
+)
+
+var segTypes = []proto.PathSegType{
+	proto.PathSegType_up,
+	proto.PathSegType_core,
+	proto.PathSegType_down,
+}
+
+func init() {
+	flag.Usage = flagUsage
+}
+
+func main() {
+	log.AddLogConsFlags()
+	validateFlags()
+	if err := log.SetupFromFlags(""); err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: %s", err)
+		flag.Usage()
+		os.Exit(1)
+	}
+	defer log.LogPanicAndExit()
+
+	ctx, cancelF := context.WithTimeout(context.Background(), *timeout)
+	defer cancelF()
+	sdConn, err := sciond.NewService(*sciondAddr).Connect(ctx)
+	if err != nil {
+		LogFatal("Failed to connect to SCIOND", "err", err)
+	}
+	for _, segType := range segTypes {
+		reply, err := getSegments(ctx, sdConn, segType)
+		if err != nil {
+			LogFatal("Failed to get segments", "type", segType, "err", err)
+		}
+		fmt.Printf("%s segments:\n", segType)
+		for i, entry := range reply.Entries {
+			fmt.Printf("[%2d] %s\n", i, entry)
+		}
+	}
+}
+
+func getSegments(ctx context.Context, sdConn sciond.Connector,
+	segType proto.PathSegType) (*sciond.SegTypeHopReply, error) {
+
+	reply, err := sdConn.SegTypeHop(ctx, segType)
+	if err != nil {
+		return nil, serrors.WrapStr("failed to retrieve segments from SCIOND", err)
+	}
+	if reply == nil {
+		return &sciond.SegTypeHopReply{}, nil
+	}
+	return reply, nil
+}
+
+func validateFlags() {
+	flag.Parse()
+	if *version {
+		fmt.Print(env.VersionInfo())
+		os.Exit(0)
+	}
+	if *segTypeStr == "" {
+		return
+	}
+	segType := proto.PathSegTypeFromString(*segTypeStr)
+	if segType == proto.PathSegType_unset {
+		LogFatal("Invalid segment type", "type", *segTypeStr)
+	}
+	segTypes = []proto.PathSegType{segType}
+}
+
+func flagUsage() {
+	fmt.Fprintf(os.Stderr, `
+Usage: showsegments [flags]
+
+Lists the path segments stored in the path database of SCIOND, together with the
+interfaces of each hop. The segments are listed in construction direction. This
+is meant for debugging, the segments might be expired or revoked.
+
+flags:
+`)
+	flag.PrintDefaults()
+}
+
+func LogFatal(msg string, a ...interface{}) {
+	log.Crit(msg, a...)
+	os.Exit(1)
+}
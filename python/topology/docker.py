@@ -170,7 +170,9 @@ class DockerGenerator(object):
                     self._cache_vol(),
                     self._logs_vol(),
                     self._certs_vol(),
-                    '%s:/share/conf:ro' % os.path.join(base, k),
+                    # The CS writes the private keys of renewed certificate
+                    # chains to its configuration directory.
+                    '%s:/share/conf:rw' % os.path.join(base, k),
                     self._disp_vol(k),
                 ],
                 'command': []